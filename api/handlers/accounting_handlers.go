@@ -1,14 +1,22 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"erp-system/internal/accounting/models"
 	"erp-system/internal/accounting/service"
 	acc_dto "erp-system/internal/accounting/service/dto" // Alias for accounting specific DTOs
 	"erp-system/pkg/errors" // Keep this for type assertion in respondWithError if it's still specific
-	// "erp-system/pkg/logger" // REMOVED - No longer directly used here
+	"erp-system/pkg/logger"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv" // For parsing limit/page from query
+	"strings"
 	"time"    // Was missing, needed for date parsing in GetTrialBalance
 
 	"github.com/google/uuid"
@@ -31,10 +39,15 @@ func (h *AccountingHandlers) RegisterAccountingRoutes(r *mux.Router) {
 	coaRouter := r.PathPrefix("/api/v1/accounting/accounts").Subrouter()
 	coaRouter.HandleFunc("", h.CreateChartOfAccount).Methods("POST")
 	coaRouter.HandleFunc("", h.ListChartOfAccounts).Methods("GET")
+	coaRouter.HandleFunc("/tree", h.GetChartOfAccountTree).Methods("GET") // Registered before /{id}
 	coaRouter.HandleFunc("/{id}", h.GetChartOfAccountByID).Methods("GET")
 	coaRouter.HandleFunc("/code/{code}", h.GetChartOfAccountByCode).Methods("GET") // Custom route for by code
 	coaRouter.HandleFunc("/{id}", h.UpdateChartOfAccount).Methods("PUT")
 	coaRouter.HandleFunc("/{id}", h.DeleteChartOfAccount).Methods("DELETE")
+	coaRouter.HandleFunc("/{id}/ledger", h.GetAccountLedger).Methods("GET")
+	coaRouter.HandleFunc("/{id}/move", h.MoveChartOfAccount).Methods("POST")
+	coaRouter.HandleFunc("/{id}/merge", h.MergeChartOfAccount).Methods("POST")
+	coaRouter.HandleFunc("/{id}/merges", h.ListChartOfAccountMerges).Methods("GET")
 
 	// Journal Entries Routes
 	journalRouter := r.PathPrefix("/api/v1/accounting/journals").Subrouter()
@@ -44,10 +57,31 @@ func (h *AccountingHandlers) RegisterAccountingRoutes(r *mux.Router) {
 	journalRouter.HandleFunc("/{id}", h.UpdateJournalEntry).Methods("PUT")
 	journalRouter.HandleFunc("/{id}", h.DeleteJournalEntry).Methods("DELETE")
 	journalRouter.HandleFunc("/{id}/post", h.PostJournalEntry).Methods("POST")
+	journalRouter.HandleFunc("/{id}/void", h.VoidJournalEntry).Methods("POST")
+	journalRouter.HandleFunc("/{id}/submit", h.SubmitJournalEntry).Methods("POST")
+	journalRouter.HandleFunc("/{id}/approve", h.ApproveJournalEntry).Methods("POST")
+	journalRouter.HandleFunc("/{id}/reject", h.RejectJournalEntry).Methods("POST")
 
 	// Reporting Routes
 	reportRouter := r.PathPrefix("/api/v1/accounting/reports").Subrouter()
 	reportRouter.HandleFunc("/trial-balance", h.GetTrialBalance).Methods("GET") // Changed to GET as it's safer for report generation
+	reportRouter.HandleFunc("/balance-sheet", h.GetBalanceSheet).Methods("GET")
+	reportRouter.HandleFunc("/profit-and-loss", h.GetProfitAndLossStatement).Methods("GET")
+	reportRouter.HandleFunc("/cash-flow", h.GetCashFlowStatement).Methods("GET")
+	reportRouter.HandleFunc("/general-ledger", h.GetGeneralLedger).Methods("GET") // format=csv for a file export
+
+	// Year-End Close Routes (fiscal years themselves are served by FiscalCalendarHandlers)
+	fiscalYearRouter := r.PathPrefix("/api/v1/accounting/fiscal-years").Subrouter()
+	fiscalYearRouter.HandleFunc("/{id}/close", h.CloseFiscalYear).Methods("POST")
+	fiscalYearRouter.HandleFunc("/{id}/reopen", h.ReopenFiscalYear).Methods("POST")
+
+	// Automatic Reversal Routes
+	reversalRouter := r.PathPrefix("/api/v1/accounting/reversals").Subrouter()
+	reversalRouter.HandleFunc("", h.ListPendingReversals).Methods("GET")
+	reversalRouter.HandleFunc("/{id}/cancel", h.CancelScheduledReversal).Methods("POST")
+
+	// Foreign Currency Revaluation Routes
+	r.HandleFunc("/api/v1/accounting/fx-revaluations", h.RevalueForeignCurrencies).Methods("POST")
 	// Add other report routes here, e.g., Balance Sheet, P&L
 }
 
@@ -150,6 +184,87 @@ func (h *AccountingHandlers) UpdateChartOfAccount(w http.ResponseWriter, r *http
 	respondWithJSON(w, http.StatusOK, account)
 }
 
+// MoveChartOfAccount moves an account and the accounts below it under the given parent.
+func (h *AccountingHandlers) MoveChartOfAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid account ID format", "id"))
+		return
+	}
+
+	var req acc_dto.MoveChartOfAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	account, err := h.service.MoveChartOfAccount(r.Context(), id, req.ParentAccountID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, account)
+}
+
+// MergeChartOfAccount merges the account into target_account_id and deactivates it.
+func (h *AccountingHandlers) MergeChartOfAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid account ID format", "id"))
+		return
+	}
+
+	var req acc_dto.MergeChartOfAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	merge, err := h.service.MergeChartOfAccount(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, merge)
+}
+
+// ListChartOfAccountMerges lists the merges the account took part in, as source or target.
+func (h *AccountingHandlers) ListChartOfAccountMerges(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid account ID format", "id"))
+		return
+	}
+	merges, err := h.service.ListChartOfAccountMerges(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, merges)
+}
+
+// GetChartOfAccountTree returns the nested chart of accounts with roll-up balances as of as_of_date.
+func (h *AccountingHandlers) GetChartOfAccountTree(w http.ResponseWriter, r *http.Request) {
+	asOfDate := time.Now()
+	if asOfDateStr := r.URL.Query().Get("as_of_date"); asOfDateStr != "" {
+		t, err := time.Parse("2006-01-02", asOfDateStr)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid as_of_date format, use YYYY-MM-DD", "as_of_date"))
+			return
+		}
+		asOfDate = t
+	}
+
+	tree, err := h.service.GetChartOfAccountTree(r.Context(), asOfDate)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, tree)
+}
+
 func (h *AccountingHandlers) DeleteChartOfAccount(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr, ok := vars["id"]
@@ -198,6 +313,14 @@ func (h *AccountingHandlers) ListChartOfAccounts(w http.ResponseWriter, r *http.
             return
         }
     }
+	if parentStr := queryParams.Get("parent_account_id"); parentStr != "" {
+		parentID, err := uuid.Parse(parentStr)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid parent_account_id format", "parent_account_id"))
+			return
+		}
+		listReq.ParentAccountID = &parentID
+	}
 
 
 	accounts, total, err := h.service.ListChartOfAccounts(r.Context(), listReq)
@@ -307,11 +430,12 @@ func (h *AccountingHandlers) DeleteJournalEntry(w http.ResponseWriter, r *http.R
 func (h *AccountingHandlers) ListJournalEntries(w http.ResponseWriter, r *http.Request) {
 	queryParams := r.URL.Query()
 	listReq := acc_dto.ListJournalEntriesRequest{ // Changed to acc_dto
-		Page:        1,
-		Limit:       20,
-		Description: queryParams.Get("description"),
-		Reference:   queryParams.Get("reference"),
-		Status:      models.JournalStatus(queryParams.Get("status")),
+		Page:           1,
+		Limit:          20,
+		Description:    queryParams.Get("description"),
+		Reference:      queryParams.Get("reference"),
+		DocumentNumber: queryParams.Get("document_number"),
+		Status:         models.JournalStatus(queryParams.Get("status")),
 	}
 
 	if pageStr := queryParams.Get("page"); pageStr != "" {
@@ -387,6 +511,146 @@ func (h *AccountingHandlers) PostJournalEntry(w http.ResponseWriter, r *http.Req
 	respondWithJSON(w, http.StatusOK, entry)
 }
 
+func (h *AccountingHandlers) VoidJournalEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid journal entry ID format", "id"))
+		return
+	}
+	var req acc_dto.VoidJournalEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	result, err := h.service.VoidJournalEntry(r.Context(), id, req.Reason, req.ReversalDate)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// --- Journal Entry Approval Handlers ---
+
+func (h *AccountingHandlers) SubmitJournalEntry(w http.ResponseWriter, r *http.Request) {
+	h.handleJournalApproval(w, r, h.service.SubmitJournalEntry)
+}
+
+func (h *AccountingHandlers) ApproveJournalEntry(w http.ResponseWriter, r *http.Request) {
+	h.handleJournalApproval(w, r, h.service.ApproveJournalEntry)
+}
+
+func (h *AccountingHandlers) RejectJournalEntry(w http.ResponseWriter, r *http.Request) {
+	h.handleJournalApproval(w, r, h.service.RejectJournalEntry)
+}
+
+// handleJournalApproval runs one approval step on the journal entry in the path. The request
+// body, holding the step's comment, may be empty.
+func (h *AccountingHandlers) handleJournalApproval(w http.ResponseWriter, r *http.Request, step func(context.Context, uuid.UUID, string) (*models.JournalEntry, error)) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid journal entry ID format", "id"))
+		return
+	}
+	var req acc_dto.JournalApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	entry, err := step(r.Context(), id, req.Comment)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, entry)
+}
+
+// --- Automatic Reversal Handlers ---
+
+func (h *AccountingHandlers) ListPendingReversals(w http.ResponseWriter, r *http.Request) {
+	reversals, err := h.service.ListPendingReversals(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, reversals)
+}
+
+func (h *AccountingHandlers) CancelScheduledReversal(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid scheduled reversal ID format", "id"))
+		return
+	}
+
+	reversal, err := h.service.CancelScheduledReversal(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, reversal)
+}
+
+// --- Year-End Close Handlers ---
+
+func (h *AccountingHandlers) CloseFiscalYear(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid fiscal year ID format", "id"))
+		return
+	}
+
+	result, err := h.service.CloseFiscalYear(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (h *AccountingHandlers) ReopenFiscalYear(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid fiscal year ID format", "id"))
+		return
+	}
+
+	result, err := h.service.ReopenFiscalYear(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// --- Foreign Currency Revaluation Handlers ---
+
+// RevalueForeignCurrencies runs a revaluation. It answers 201 when an entry was posted and 200 for a
+// dry run or when no balance needed adjusting.
+func (h *AccountingHandlers) RevalueForeignCurrencies(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.FXRevaluationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	result, err := h.service.RevalueForeignCurrencies(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	status := http.StatusOK
+	if result.Entry != nil {
+		status = http.StatusCreated
+	}
+	respondWithJSON(w, status, result)
+}
+
 // --- Reporting Handlers ---
 
 func (h *AccountingHandlers) GetTrialBalance(w http.ResponseWriter, r *http.Request) {
@@ -422,6 +686,11 @@ func (h *AccountingHandlers) GetTrialBalance(w http.ResponseWriter, r *http.Requ
             return
         }
 	}
+	if req.Dimensions, err = parseDimensionFilter(queryParams); err != nil {
+		respondWithError(w, err)
+		return
+	}
+	req.GroupBy = queryParams.Get("group_by")
 
 	report, err := h.service.GetTrialBalance(r.Context(), req)
 	if err != nil {
@@ -430,3 +699,257 @@ func (h *AccountingHandlers) GetTrialBalance(w http.ResponseWriter, r *http.Requ
 	}
 	respondWithJSON(w, http.StatusOK, report)
 }
+
+func (h *AccountingHandlers) GetBalanceSheet(w http.ResponseWriter, r *http.Request) {
+	asOfDate := time.Now()
+	if asOfDateStr := r.URL.Query().Get("as_of_date"); asOfDateStr != "" {
+		t, err := time.Parse("2006-01-02", asOfDateStr)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid as_of_date format, use YYYY-MM-DD", "as_of_date"))
+			return
+		}
+		asOfDate = t
+	}
+
+	report, err := h.service.GetBalanceSheet(r.Context(), asOfDate)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *AccountingHandlers) GetProfitAndLossStatement(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	startDateStr, endDateStr := queryParams.Get("start_date"), queryParams.Get("end_date")
+	if startDateStr == "" || endDateStr == "" {
+		respondWithError(w, errors.NewValidationError("start_date and end_date query parameters are required", "start_date"))
+		return
+	}
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid start_date format, use YYYY-MM-DD", "start_date"))
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid end_date format, use YYYY-MM-DD", "end_date"))
+		return
+	}
+
+	req := acc_dto.ProfitAndLossRequest{StartDate: startDate, EndDate: endDate}
+	if v := queryParams.Get("compare_prior_period"); v != "" {
+		if req.CompareToPriorPeriod, err = strconv.ParseBool(v); err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid boolean value for 'compare_prior_period'", "compare_prior_period"))
+			return
+		}
+	}
+	if v := queryParams.Get("compare_prior_year"); v != "" {
+		if req.CompareToPriorYear, err = strconv.ParseBool(v); err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid boolean value for 'compare_prior_year'", "compare_prior_year"))
+			return
+		}
+	}
+
+	report, err := h.service.GetProfitAndLossStatement(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *AccountingHandlers) GetCashFlowStatement(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	startDateStr, endDateStr := queryParams.Get("start_date"), queryParams.Get("end_date")
+	if startDateStr == "" || endDateStr == "" {
+		respondWithError(w, errors.NewValidationError("start_date and end_date query parameters are required", "start_date"))
+		return
+	}
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid start_date format, use YYYY-MM-DD", "start_date"))
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid end_date format, use YYYY-MM-DD", "end_date"))
+		return
+	}
+
+	report, err := h.service.GetCashFlowStatement(r.Context(), acc_dto.CashFlowRequest{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// --- Ledger Handlers ---
+
+func (h *AccountingHandlers) GetAccountLedger(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid account ID format", "id"))
+		return
+	}
+	from, to, err := parseLedgerPeriod(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	dimensions, err := parseDimensionFilter(r.URL.Query())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	ledger, err := h.service.GetAccountLedger(r.Context(), id, from, to, dimensions)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, ledger)
+}
+
+func (h *AccountingHandlers) GetGeneralLedger(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	from, to, err := parseLedgerPeriod(queryParams)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	req := acc_dto.GeneralLedgerRequest{StartDate: from, EndDate: to, GroupBy: queryParams.Get("group_by")}
+	if req.Dimensions, err = parseDimensionFilter(queryParams); err != nil {
+		respondWithError(w, err)
+		return
+	}
+	// account_id may be repeated or comma-separated
+	for _, value := range queryParams["account_id"] {
+		for _, idStr := range strings.Split(value, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(idStr))
+			if err != nil {
+				respondWithError(w, errors.NewValidationError("Invalid account_id format", "account_id"))
+				return
+			}
+			req.AccountIDs = append(req.AccountIDs, id)
+		}
+	}
+	format := queryParams.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		respondWithError(w, errors.NewValidationError("format must be json or csv", "format"))
+		return
+	}
+
+	report, err := h.service.GetGeneralLedger(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	if format == "csv" {
+		writeGeneralLedgerCSV(w, report)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// parseLedgerPeriod reads the optional from and to query parameters (YYYY-MM-DD).
+func parseLedgerPeriod(queryParams url.Values) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if v := queryParams.Get("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			return time.Time{}, time.Time{}, errors.NewValidationError("Invalid from format, use YYYY-MM-DD", "from")
+		}
+	}
+	if v := queryParams.Get("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			return time.Time{}, time.Time{}, errors.NewValidationError("Invalid to format, use YYYY-MM-DD", "to")
+		}
+	}
+	return from, to, nil
+}
+
+// parseDimensionFilter reads the optional dimension query parameters, each a DIMENSION:VALUE pair of
+// codes, e.g. dimension=DEPARTMENT:SALES. The parameter may be repeated to filter on several dimensions.
+func parseDimensionFilter(queryParams url.Values) (models.DimensionTags, error) {
+	if len(queryParams["dimension"]) == 0 {
+		return nil, nil
+	}
+	filter := make(models.DimensionTags)
+	for _, pair := range queryParams["dimension"] {
+		code, value, ok := strings.Cut(pair, ":")
+		if !ok || strings.TrimSpace(code) == "" || strings.TrimSpace(value) == "" {
+			return nil, errors.NewValidationError("dimension must be DIMENSION:VALUE", "dimension")
+		}
+		filter[code] = value
+	}
+	return filter, nil
+}
+
+// writeGeneralLedgerCSV writes one row per ledger line, framed by opening and closing balance rows
+// for each account. The file is rendered in memory first so a failed export is reported as an error
+// instead of a truncated 200 response.
+func writeGeneralLedgerCSV(w http.ResponseWriter, report *acc_dto.GeneralLedgerResponse) {
+	var buf bytes.Buffer
+	if err := renderGeneralLedgerCSV(&buf, report); err != nil {
+		respondWithError(w, errors.NewInternalServerError("failed to export general ledger", err))
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=general-ledger-%s.csv", report.EndDate.Format("2006-01-02")))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.ErrorLogger.Printf("Handler: Error writing general ledger export: %v", err)
+	}
+}
+
+func renderGeneralLedgerCSV(dst io.Writer, report *acc_dto.GeneralLedgerResponse) error {
+	out := csv.NewWriter(dst)
+	// A ledger grouped by a dimension gets a dimension_value column after the account.
+	row := func(ledger acc_dto.AccountLedgerResponse, fields ...string) []string {
+		if report.GroupBy == "" {
+			return append([]string{ledger.AccountCode, ledger.AccountName}, fields...)
+		}
+		return append([]string{ledger.AccountCode, ledger.AccountName, ledger.DimensionValue}, fields...)
+	}
+	header := []string{"account_code", "account_name", "entry_date", "journal_entry_id", "reference", "description", "counter_accounts", "debit", "credit", "balance"}
+	if report.GroupBy != "" {
+		header = slices.Insert(header, 2, "dimension_value")
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	for _, ledger := range report.Accounts {
+		openingDate := ""
+		if !ledger.StartDate.IsZero() {
+			openingDate = ledger.StartDate.Format("2006-01-02")
+		}
+		if err := out.Write(row(ledger, openingDate, "", "", "Opening balance", "", "", "", ledger.OpeningBalance.String())); err != nil {
+			return err
+		}
+		for _, line := range ledger.Lines {
+			counters := make([]string, 0, len(line.CounterAccounts))
+			for _, counter := range line.CounterAccounts {
+				if counter.AccountCode != "" {
+					counters = append(counters, counter.AccountCode)
+				} else {
+					counters = append(counters, counter.AccountID.String())
+				}
+			}
+			err := out.Write(row(ledger,
+				line.EntryDate.Format("2006-01-02"), line.JournalEntryID.String(),
+				line.Reference, line.Description, strings.Join(counters, " "),
+				line.Debit.String(), line.Credit.String(), line.RunningBalance.String(),
+			))
+			if err != nil {
+				return err
+			}
+		}
+		if err := out.Write(row(ledger, ledger.EndDate.Format("2006-01-02"), "", "", "Closing balance", "", ledger.TotalDebits.String(), ledger.TotalCredits.String(), ledger.ClosingBalance.String())); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
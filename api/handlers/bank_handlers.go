@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxStatementFileSize limits the size of a bank statement upload.
+const maxStatementFileSize = 10 << 20
+
+// BankHandlers wraps the bank service to provide HTTP handlers.
+type BankHandlers struct {
+	service service.BankService
+}
+
+// NewBankHandlers creates a new BankHandlers instance.
+func NewBankHandlers(serv service.BankService) *BankHandlers {
+	return &BankHandlers{service: serv}
+}
+
+// RegisterBankRoutes registers bank account, statement, matching and reconciliation routes with the provided router.
+func (h *BankHandlers) RegisterBankRoutes(r *mux.Router) {
+	bankRouter := r.PathPrefix("/api/v1/accounting/bank-accounts").Subrouter()
+	bankRouter.HandleFunc("", h.CreateBankAccount).Methods("POST")
+	bankRouter.HandleFunc("", h.ListBankAccounts).Methods("GET")
+	bankRouter.HandleFunc("/{id}", h.GetBankAccount).Methods("GET")
+	bankRouter.HandleFunc("/{id}", h.UpdateBankAccount).Methods("PUT")
+	bankRouter.HandleFunc("/{id}/statements", h.ImportStatement).Methods("POST") // CSV, OFX or camt.053 body or multipart "file"
+	bankRouter.HandleFunc("/{id}/statements", h.ListStatements).Methods("GET")
+	bankRouter.HandleFunc("/{id}/statement-lines", h.ListStatementLines).Methods("GET")
+	bankRouter.HandleFunc("/{id}/auto-match", h.AutoMatch).Methods("POST")
+	bankRouter.HandleFunc("/{id}/reconciliation", h.GetReconciliation).Methods("GET")
+
+	lineRouter := r.PathPrefix("/api/v1/accounting/bank-statement-lines").Subrouter()
+	lineRouter.HandleFunc("/{id}/match", h.MatchStatementLine).Methods("POST")
+	lineRouter.HandleFunc("/{id}/unmatch", h.UnmatchStatementLine).Methods("POST")
+}
+
+func (h *BankHandlers) CreateBankAccount(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateBankAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	account, err := h.service.CreateBankAccount(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, account)
+}
+
+func (h *BankHandlers) ListBankAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.service.ListBankAccounts(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, accounts)
+}
+
+func (h *BankHandlers) GetBankAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bank account ID format", "id"))
+		return
+	}
+	account, err := h.service.GetBankAccount(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, account)
+}
+
+func (h *BankHandlers) UpdateBankAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bank account ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateBankAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	account, err := h.service.UpdateBankAccount(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, account)
+}
+
+// ImportStatement imports a statement file, sent either as the request body or as the "file" field
+// of a multipart form. The optional format parameter (CSV, OFX or CAMT053) overrides detection.
+func (h *BankHandlers) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bank account ID format", "id"))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxStatementFileSize)
+	defer r.Body.Close()
+
+	var file io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		part, _, err := r.FormFile("file")
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("a statement file is required in the file field", "file"))
+			return
+		}
+		defer part.Close()
+		file = part
+	}
+
+	result, err := h.service.ImportStatement(r.Context(), id, models.StatementFormat(r.URL.Query().Get("format")), file)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, result)
+}
+
+func (h *BankHandlers) ListStatements(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bank account ID format", "id"))
+		return
+	}
+	statements, err := h.service.ListStatements(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, statements)
+}
+
+// ListStatementLines lists a bank account's statement lines; status=matched or status=unmatched
+// narrows the list.
+func (h *BankHandlers) ListStatementLines(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bank account ID format", "id"))
+		return
+	}
+	var matched *bool
+	switch status := r.URL.Query().Get("status"); status {
+	case "":
+	case "matched", "unmatched":
+		value := status == "matched"
+		matched = &value
+	default:
+		respondWithError(w, errors.NewValidationError("Invalid status, use matched or unmatched", "status"))
+		return
+	}
+	lines, err := h.service.ListStatementLines(r.Context(), id, matched)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, lines)
+}
+
+func (h *BankHandlers) AutoMatch(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bank account ID format", "id"))
+		return
+	}
+	result, err := h.service.AutoMatch(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// GetReconciliation returns the bank reconciliation as of as_of_date (default today).
+func (h *BankHandlers) GetReconciliation(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bank account ID format", "id"))
+		return
+	}
+	asOfDate := time.Now()
+	if asOfDateStr := r.URL.Query().Get("as_of_date"); asOfDateStr != "" {
+		t, err := time.Parse("2006-01-02", asOfDateStr)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid as_of_date format, use YYYY-MM-DD", "as_of_date"))
+			return
+		}
+		asOfDate = t
+	}
+
+	report, err := h.service.GetReconciliation(r.Context(), id, asOfDate)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *BankHandlers) MatchStatementLine(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid statement line ID format", "id"))
+		return
+	}
+	var req acc_dto.MatchStatementLineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	line, err := h.service.MatchStatementLine(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, line)
+}
+
+func (h *BankHandlers) UnmatchStatementLine(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid statement line ID format", "id"))
+		return
+	}
+	line, err := h.service.UnmatchStatementLine(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, line)
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxBudgetFileSize limits the size of a budget CSV upload.
+const maxBudgetFileSize = 10 << 20
+
+// BudgetHandlers wraps the budget service to provide HTTP handlers.
+type BudgetHandlers struct {
+	service service.BudgetService
+}
+
+// NewBudgetHandlers creates a new BudgetHandlers instance.
+func NewBudgetHandlers(serv service.BudgetService) *BudgetHandlers {
+	return &BudgetHandlers{service: serv}
+}
+
+// RegisterBudgetRoutes registers budget and budget-vs-actual report routes with the provided router.
+func (h *BudgetHandlers) RegisterBudgetRoutes(r *mux.Router) {
+	budgetRouter := r.PathPrefix("/api/v1/accounting/budgets").Subrouter()
+	budgetRouter.HandleFunc("", h.CreateBudget).Methods("POST")
+	budgetRouter.HandleFunc("", h.ListBudgets).Methods("GET")
+	budgetRouter.HandleFunc("/{id}", h.GetBudget).Methods("GET")
+	budgetRouter.HandleFunc("/{id}", h.UpdateBudget).Methods("PUT")
+	budgetRouter.HandleFunc("/{id}/import", h.ImportBudgetLines).Methods("POST") // CSV body or multipart "file"
+	budgetRouter.HandleFunc("/{id}/approve", h.ApproveBudget).Methods("POST")
+	budgetRouter.HandleFunc("/{id}/versions", h.CreateBudgetVersion).Methods("POST")
+
+	r.HandleFunc("/api/v1/accounting/reports/budget-vs-actual", h.GetBudgetVsActual).Methods("GET")
+}
+
+func (h *BudgetHandlers) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	budget, err := h.service.CreateBudget(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, budget)
+}
+
+// ListBudgets lists budget versions, optionally those of one fiscal_year_id.
+func (h *BudgetHandlers) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	var fiscalYearID *uuid.UUID
+	if idStr := r.URL.Query().Get("fiscal_year_id"); idStr != "" {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid fiscal_year_id format", "fiscal_year_id"))
+			return
+		}
+		fiscalYearID = &id
+	}
+	budgets, err := h.service.ListBudgets(r.Context(), fiscalYearID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, budgets)
+}
+
+func (h *BudgetHandlers) GetBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid budget ID format", "id"))
+		return
+	}
+	budget, err := h.service.GetBudget(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, budget)
+}
+
+func (h *BudgetHandlers) UpdateBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid budget ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	budget, err := h.service.UpdateBudget(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, budget)
+}
+
+// ImportBudgetLines replaces a draft budget's lines with an account_code,period,amount[,dimension,value]
+// CSV file, sent either as the request body or as the "file" field of a multipart form.
+func (h *BudgetHandlers) ImportBudgetLines(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid budget ID format", "id"))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBudgetFileSize)
+	defer r.Body.Close()
+
+	var file io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		part, _, err := r.FormFile("file")
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("a CSV file is required in the file field", "file"))
+			return
+		}
+		defer part.Close()
+		file = part
+	}
+
+	result, err := h.service.ImportBudgetLines(r.Context(), id, file)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (h *BudgetHandlers) ApproveBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid budget ID format", "id"))
+		return
+	}
+	budget, err := h.service.ApproveBudget(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, budget)
+}
+
+// CreateBudgetVersion starts a new draft version from the budget version in the path.
+func (h *BudgetHandlers) CreateBudgetVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid budget ID format", "id"))
+		return
+	}
+	budget, err := h.service.CreateBudgetVersion(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, budget)
+}
+
+// GetBudgetVsActual compares budget_id with posted lines over from_period..to_period.
+func (h *BudgetHandlers) GetBudgetVsActual(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	var req acc_dto.BudgetVsActualRequest
+	budgetID, err := uuid.Parse(queryParams.Get("budget_id"))
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("budget_id is required", "budget_id"))
+		return
+	}
+	req.BudgetID = budgetID
+	for param, target := range map[string]*int{"from_period": &req.FromPeriod, "to_period": &req.ToPeriod} {
+		if value := queryParams.Get(param); value != "" {
+			period, err := strconv.Atoi(value)
+			if err != nil {
+				respondWithError(w, errors.NewValidationError("Invalid "+param+", use a period number", param))
+				return
+			}
+			*target = period
+		}
+	}
+
+	report, err := h.service.GetBudgetVsActual(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// maxChartFileSize limits the size of a chart of accounts upload.
+const maxChartFileSize = 5 << 20
+
+// ChartTransferHandlers wraps the chart transfer service to provide HTTP handlers.
+type ChartTransferHandlers struct {
+	service service.ChartTransferService
+}
+
+// NewChartTransferHandlers creates a new ChartTransferHandlers instance.
+func NewChartTransferHandlers(serv service.ChartTransferService) *ChartTransferHandlers {
+	return &ChartTransferHandlers{service: serv}
+}
+
+// RegisterChartTransferRoutes registers chart of accounts import, export and template routes with
+// the provided router. They must be registered before the accounting routes, whose /accounts/{id}
+// would otherwise take /accounts/export.
+func (h *ChartTransferHandlers) RegisterChartTransferRoutes(r *mux.Router) {
+	coaRouter := r.PathPrefix("/api/v1/accounting/accounts").Subrouter()
+	coaRouter.HandleFunc("/export", h.ExportChartOfAccounts).Methods("GET")
+	coaRouter.HandleFunc("/import", h.ImportChartOfAccounts).Methods("POST") // CSV or JSON body or multipart "file"
+
+	templateRouter := r.PathPrefix("/api/v1/accounting/chart-templates").Subrouter()
+	templateRouter.HandleFunc("", h.ListChartTemplates).Methods("GET")
+	templateRouter.HandleFunc("/{name}", h.GetChartTemplate).Methods("GET")
+	templateRouter.HandleFunc("/{name}/apply", h.ApplyChartTemplate).Methods("POST")
+}
+
+// ExportChartOfAccounts downloads the chart of accounts as JSON, or as CSV with format=csv.
+func (h *ChartTransferHandlers) ExportChartOfAccounts(w http.ResponseWriter, r *http.Request) {
+	format := service.ChartFileFormat(strings.ToUpper(r.URL.Query().Get("format")))
+	if format != "" && format != service.ChartCSV && format != service.ChartJSON {
+		respondWithError(w, errors.NewValidationError("Invalid format, use csv or json", "format"))
+		return
+	}
+	records, err := h.service.ExportChartOfAccounts(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType, extension := "application/json", "json"
+	if format == service.ChartCSV {
+		contentType, extension = "text/csv", "csv"
+		err = renderChartOfAccountsCSV(&buf, records)
+	} else {
+		err = json.NewEncoder(&buf).Encode(records)
+	}
+	if err != nil {
+		respondWithError(w, errors.NewInternalServerError("failed to export chart of accounts", err))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=chart-of-accounts-%s.%s", time.Now().Format("2006-01-02"), extension))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logger.ErrorLogger.Printf("Handler: Error writing chart of accounts export: %v", err)
+	}
+}
+
+func renderChartOfAccountsCSV(dst io.Writer, records []acc_dto.ChartOfAccountRecord) error {
+	out := csv.NewWriter(dst)
+	if err := out.Write(service.ChartOfAccountsCSVColumns); err != nil {
+		return err
+	}
+	for _, record := range records {
+		isActive := record.IsActive == nil || *record.IsActive
+		err := out.Write([]string{
+			record.AccountCode,
+			record.AccountName,
+			string(record.AccountType),
+			record.ParentCode,
+			strconv.FormatBool(isActive),
+			string(record.CashFlowCategory),
+			record.Description,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// ImportChartOfAccounts imports a chart of accounts file, sent either as the request body or as the
+// "file" field of a multipart form. The optional format parameter (csv or json) overrides
+// detection; dry_run=true only validates the file and returns the report.
+func (h *ChartTransferHandlers) ImportChartOfAccounts(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid dry_run value, use true or false", "dry_run"))
+			return
+		}
+		dryRun = parsed
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxChartFileSize)
+	defer r.Body.Close()
+
+	var file io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		part, _, err := r.FormFile("file")
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("a chart of accounts file is required in the file field", "file"))
+			return
+		}
+		defer part.Close()
+		file = part
+	}
+
+	result, err := h.service.ImportChartOfAccounts(r.Context(), service.ChartFileFormat(r.URL.Query().Get("format")), file, dryRun)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	respondWithJSON(w, status, result)
+}
+
+func (h *ChartTransferHandlers) ListChartTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.service.ListChartTemplates(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, templates)
+}
+
+func (h *ChartTransferHandlers) GetChartTemplate(w http.ResponseWriter, r *http.Request) {
+	template, err := h.service.GetChartTemplate(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, template)
+}
+
+// ApplyChartTemplate creates the accounts of a template in an empty chart of accounts.
+func (h *ChartTransferHandlers) ApplyChartTemplate(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.ApplyChartTemplate(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, result)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/company/service"
+	company_dto "erp-system/internal/company/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// CompanyHandlers wraps the company service to provide HTTP handlers.
+type CompanyHandlers struct {
+	service service.CompanyService
+}
+
+// NewCompanyHandlers creates a new CompanyHandlers instance.
+func NewCompanyHandlers(serv service.CompanyService) *CompanyHandlers {
+	return &CompanyHandlers{service: serv}
+}
+
+// RegisterCompanyRoutes registers company routes with the provided router. Like the consolidation
+// routes, they do not need an active company.
+func (h *CompanyHandlers) RegisterCompanyRoutes(r *mux.Router) {
+	companyRouter := r.PathPrefix("/api/v1/companies").Subrouter()
+	companyRouter.HandleFunc("", h.CreateCompany).Methods("POST")
+	companyRouter.HandleFunc("", h.ListCompanies).Methods("GET")
+	companyRouter.HandleFunc("/{id}", h.GetCompany).Methods("GET")
+	companyRouter.HandleFunc("/{id}", h.UpdateCompany).Methods("PUT")
+}
+
+func (h *CompanyHandlers) CreateCompany(w http.ResponseWriter, r *http.Request) {
+	var req company_dto.CreateCompanyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	company, err := h.service.CreateCompany(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, company)
+}
+
+// ListCompanies lists the companies, only the active ones with active_only=true.
+func (h *CompanyHandlers) ListCompanies(w http.ResponseWriter, r *http.Request) {
+	activeOnly := false
+	if v := r.URL.Query().Get("active_only"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid active_only value, use true or false", "active_only"))
+			return
+		}
+		activeOnly = parsed
+	}
+	companies, err := h.service.ListCompanies(r.Context(), activeOnly)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, companies)
+}
+
+func (h *CompanyHandlers) GetCompany(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid company ID format", "id"))
+		return
+	}
+	company, err := h.service.GetCompany(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, company)
+}
+
+func (h *CompanyHandlers) UpdateCompany(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid company ID format", "id"))
+		return
+	}
+	var req company_dto.UpdateCompanyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	company, err := h.service.UpdateCompany(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, company)
+}
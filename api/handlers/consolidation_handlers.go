@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// ConsolidationHandlers wraps the consolidation service to provide HTTP handlers.
+type ConsolidationHandlers struct {
+	service service.ConsolidationService
+}
+
+// NewConsolidationHandlers creates a new ConsolidationHandlers instance.
+func NewConsolidationHandlers(serv service.ConsolidationService) *ConsolidationHandlers {
+	return &ConsolidationHandlers{service: serv}
+}
+
+// RegisterConsolidationRoutes registers the group chart, elimination rule and consolidation report
+// routes, which span all companies, and the account mapping routes of the active company.
+func (h *ConsolidationHandlers) RegisterConsolidationRoutes(r *mux.Router) {
+	consolidationRouter := r.PathPrefix("/api/v1/consolidation").Subrouter()
+	consolidationRouter.HandleFunc("/group-accounts", h.CreateGroupAccount).Methods("POST")
+	consolidationRouter.HandleFunc("/group-accounts", h.ListGroupAccounts).Methods("GET")
+	consolidationRouter.HandleFunc("/group-accounts/{id}", h.UpdateGroupAccount).Methods("PUT")
+	consolidationRouter.HandleFunc("/elimination-rules", h.CreateEliminationRule).Methods("POST")
+	consolidationRouter.HandleFunc("/elimination-rules", h.ListEliminationRules).Methods("GET")
+	consolidationRouter.HandleFunc("/elimination-rules/{id}", h.UpdateEliminationRule).Methods("PUT")
+	consolidationRouter.HandleFunc("/report", h.GetConsolidationReport).Methods("GET")
+
+	mappingRouter := r.PathPrefix("/api/v1/accounting/group-account-mappings").Subrouter()
+	mappingRouter.HandleFunc("", h.ListGroupAccountMappings).Methods("GET")
+	mappingRouter.HandleFunc("", h.MapGroupAccount).Methods("PUT")
+	mappingRouter.HandleFunc("/{accountId}", h.UnmapGroupAccount).Methods("DELETE")
+}
+
+func (h *ConsolidationHandlers) CreateGroupAccount(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateGroupAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	account, err := h.service.CreateGroupAccount(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, account)
+}
+
+func (h *ConsolidationHandlers) ListGroupAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.service.ListGroupAccounts(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, accounts)
+}
+
+func (h *ConsolidationHandlers) UpdateGroupAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid group account ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateGroupAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	account, err := h.service.UpdateGroupAccount(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, account)
+}
+
+func (h *ConsolidationHandlers) ListGroupAccountMappings(w http.ResponseWriter, r *http.Request) {
+	mappings, err := h.service.ListGroupAccountMappings(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, mappings)
+}
+
+// MapGroupAccount maps an account of the active company to a group account, replacing any
+// previous mapping of the account.
+func (h *ConsolidationHandlers) MapGroupAccount(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.MapGroupAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	mapping, err := h.service.MapGroupAccount(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, mapping)
+}
+
+func (h *ConsolidationHandlers) UnmapGroupAccount(w http.ResponseWriter, r *http.Request) {
+	accountID, err := uuid.Parse(mux.Vars(r)["accountId"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid account ID format", "accountId"))
+		return
+	}
+	if err := h.service.UnmapGroupAccount(r.Context(), accountID); err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Group account mapping deleted successfully"})
+}
+
+func (h *ConsolidationHandlers) CreateEliminationRule(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateEliminationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	rule, err := h.service.CreateEliminationRule(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, rule)
+}
+
+func (h *ConsolidationHandlers) ListEliminationRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListEliminationRules(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, rules)
+}
+
+func (h *ConsolidationHandlers) UpdateEliminationRule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid elimination rule ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateEliminationRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	rule, err := h.service.UpdateEliminationRule(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, rule)
+}
+
+// GetConsolidationReport reports the consolidated trial balance as of end_date, with the result
+// of start_date to end_date. company_id may be repeated to consolidate only some companies.
+func (h *ConsolidationHandlers) GetConsolidationReport(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	endDateStr := queryParams.Get("end_date")
+	if endDateStr == "" {
+		respondWithError(w, errors.NewValidationError("end_date query parameter is required", "end_date"))
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid end_date format, use YYYY-MM-DD", "end_date"))
+		return
+	}
+
+	req := acc_dto.ConsolidationRequest{EndDate: endDate, Currency: queryParams.Get("currency")}
+	if v := queryParams.Get("start_date"); v != "" {
+		if req.StartDate, err = time.Parse("2006-01-02", v); err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid start_date format, use YYYY-MM-DD", "start_date"))
+			return
+		}
+	}
+	for _, v := range queryParams["company_id"] {
+		companyID, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid company_id format", "company_id"))
+			return
+		}
+		req.CompanyIDs = append(req.CompanyIDs, companyID)
+	}
+
+	report, err := h.service.GetConsolidationReport(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
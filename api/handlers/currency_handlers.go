@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// maxExchangeRateFileSize limits the size of an exchange rate CSV upload.
+const maxExchangeRateFileSize = 10 << 20
+
+// CurrencyHandlers wraps the currency service to provide HTTP handlers.
+type CurrencyHandlers struct {
+	service service.CurrencyService
+}
+
+// NewCurrencyHandlers creates a new CurrencyHandlers instance.
+func NewCurrencyHandlers(serv service.CurrencyService) *CurrencyHandlers {
+	return &CurrencyHandlers{service: serv}
+}
+
+// RegisterCurrencyRoutes registers currency, exchange rate and per-currency report routes with the provided router.
+func (h *CurrencyHandlers) RegisterCurrencyRoutes(r *mux.Router) {
+	currencyRouter := r.PathPrefix("/api/v1/accounting/currencies").Subrouter()
+	currencyRouter.HandleFunc("", h.CreateCurrency).Methods("POST")
+	currencyRouter.HandleFunc("", h.ListCurrencies).Methods("GET")
+	currencyRouter.HandleFunc("/{code}", h.GetCurrency).Methods("GET")
+	currencyRouter.HandleFunc("/{code}", h.UpdateCurrency).Methods("PUT")
+
+	rateRouter := r.PathPrefix("/api/v1/accounting/exchange-rates").Subrouter()
+	rateRouter.HandleFunc("", h.ListExchangeRates).Methods("GET")
+	rateRouter.HandleFunc("/import", h.ImportExchangeRates).Methods("POST") // CSV body or multipart "file"
+
+	r.HandleFunc("/api/v1/accounting/reports/currency-balances", h.GetCurrencyBalances).Methods("GET")
+}
+
+func (h *CurrencyHandlers) CreateCurrency(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateCurrencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	currency, err := h.service.CreateCurrency(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, currency)
+}
+
+func (h *CurrencyHandlers) ListCurrencies(w http.ResponseWriter, r *http.Request) {
+	currencies, err := h.service.ListCurrencies(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, currencies)
+}
+
+func (h *CurrencyHandlers) GetCurrency(w http.ResponseWriter, r *http.Request) {
+	currency, err := h.service.GetCurrency(r.Context(), mux.Vars(r)["code"])
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, currency)
+}
+
+func (h *CurrencyHandlers) UpdateCurrency(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.UpdateCurrencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	currency, err := h.service.UpdateCurrency(r.Context(), mux.Vars(r)["code"], req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, currency)
+}
+
+// ListExchangeRates lists rates into the functional currency, filtered by the optional currency,
+// from and to query parameters.
+func (h *CurrencyHandlers) ListExchangeRates(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	from, to, err := parseLedgerPeriod(queryParams)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	rates, err := h.service.ListExchangeRates(r.Context(), queryParams.Get("currency"), from, to)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, rates)
+}
+
+// ImportExchangeRates saves the rates in a currency,rate_date,rate CSV file, sent either as the
+// request body or as the "file" field of a multipart form.
+func (h *CurrencyHandlers) ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxExchangeRateFileSize)
+	defer r.Body.Close()
+
+	var file io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		part, _, err := r.FormFile("file")
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("a CSV file is required in the file field", "file"))
+			return
+		}
+		defer part.Close()
+		file = part
+	}
+
+	result, err := h.service.ImportExchangeRates(r.Context(), file)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, result)
+}
+
+// GetCurrencyBalances returns account balances per transaction currency as of as_of_date.
+func (h *CurrencyHandlers) GetCurrencyBalances(w http.ResponseWriter, r *http.Request) {
+	asOfDate := time.Now()
+	if asOfDateStr := r.URL.Query().Get("as_of_date"); asOfDateStr != "" {
+		t, err := time.Parse("2006-01-02", asOfDateStr)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid as_of_date format, use YYYY-MM-DD", "as_of_date"))
+			return
+		}
+		asOfDate = t
+	}
+
+	report, err := h.service.GetCurrencyBalances(r.Context(), asOfDate)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
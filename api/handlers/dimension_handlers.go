@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// DimensionHandlers wraps the dimension service to provide HTTP handlers.
+type DimensionHandlers struct {
+	service service.DimensionService
+}
+
+// NewDimensionHandlers creates a new DimensionHandlers instance.
+func NewDimensionHandlers(serv service.DimensionService) *DimensionHandlers {
+	return &DimensionHandlers{service: serv}
+}
+
+// RegisterDimensionRoutes registers dimension, dimension value and account rule routes with the provided router.
+func (h *DimensionHandlers) RegisterDimensionRoutes(r *mux.Router) {
+	dimensionRouter := r.PathPrefix("/api/v1/accounting/dimensions").Subrouter()
+	dimensionRouter.HandleFunc("", h.CreateDimension).Methods("POST")
+	dimensionRouter.HandleFunc("", h.ListDimensions).Methods("GET")
+	dimensionRouter.HandleFunc("/{id}", h.GetDimension).Methods("GET")
+	dimensionRouter.HandleFunc("/{id}", h.UpdateDimension).Methods("PUT")
+	dimensionRouter.HandleFunc("/{id}/values", h.CreateDimensionValue).Methods("POST")
+	dimensionRouter.HandleFunc("/{id}/values/{valueId}", h.UpdateDimensionValue).Methods("PUT")
+
+	r.HandleFunc("/api/v1/accounting/accounts/{id}/required-dimensions", h.GetRequiredDimensions).Methods("GET")
+	r.HandleFunc("/api/v1/accounting/accounts/{id}/required-dimensions", h.SetRequiredDimensions).Methods("PUT")
+}
+
+func (h *DimensionHandlers) CreateDimension(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateDimensionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	dimension, err := h.service.CreateDimension(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, dimension)
+}
+
+func (h *DimensionHandlers) ListDimensions(w http.ResponseWriter, r *http.Request) {
+	dimensions, err := h.service.ListDimensions(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, dimensions)
+}
+
+func (h *DimensionHandlers) GetDimension(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid dimension ID format", "id"))
+		return
+	}
+	dimension, err := h.service.GetDimension(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, dimension)
+}
+
+func (h *DimensionHandlers) UpdateDimension(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid dimension ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateDimensionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	dimension, err := h.service.UpdateDimension(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, dimension)
+}
+
+func (h *DimensionHandlers) CreateDimensionValue(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid dimension ID format", "id"))
+		return
+	}
+	var req acc_dto.CreateDimensionValueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	value, err := h.service.CreateDimensionValue(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, value)
+}
+
+func (h *DimensionHandlers) UpdateDimensionValue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid dimension ID format", "id"))
+		return
+	}
+	valueID, err := uuid.Parse(vars["valueId"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid dimension value ID format", "valueId"))
+		return
+	}
+	var req acc_dto.UpdateDimensionValueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	value, err := h.service.UpdateDimensionValue(r.Context(), id, valueID, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, value)
+}
+
+func (h *DimensionHandlers) GetRequiredDimensions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid account ID format", "id"))
+		return
+	}
+	dimensions, err := h.service.GetRequiredDimensions(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, dimensions)
+}
+
+func (h *DimensionHandlers) SetRequiredDimensions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid account ID format", "id"))
+		return
+	}
+	var req acc_dto.SetRequiredDimensionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	dimensions, err := h.service.SetRequiredDimensions(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, dimensions)
+}
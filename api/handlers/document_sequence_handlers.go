@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// DocumentSequenceHandlers wraps the document sequence service to provide HTTP handlers.
+type DocumentSequenceHandlers struct {
+	service service.DocumentSequenceService
+}
+
+// NewDocumentSequenceHandlers creates a new DocumentSequenceHandlers instance.
+func NewDocumentSequenceHandlers(serv service.DocumentSequenceService) *DocumentSequenceHandlers {
+	return &DocumentSequenceHandlers{service: serv}
+}
+
+// RegisterDocumentSequenceRoutes registers document numbering routes with the provided router.
+func (h *DocumentSequenceHandlers) RegisterDocumentSequenceRoutes(r *mux.Router) {
+	sequenceRouter := r.PathPrefix("/api/v1/accounting/document-sequences").Subrouter()
+	sequenceRouter.HandleFunc("", h.ListDocumentSequences).Methods("GET")
+	sequenceRouter.HandleFunc("/{documentType}", h.GetDocumentSequence).Methods("GET")
+	sequenceRouter.HandleFunc("/{documentType}", h.UpdateDocumentSequence).Methods("PUT")
+}
+
+func (h *DocumentSequenceHandlers) ListDocumentSequences(w http.ResponseWriter, r *http.Request) {
+	sequences, err := h.service.ListDocumentSequences(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, sequences)
+}
+
+func (h *DocumentSequenceHandlers) GetDocumentSequence(w http.ResponseWriter, r *http.Request) {
+	sequence, err := h.service.GetDocumentSequence(r.Context(), mux.Vars(r)["documentType"])
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, sequence)
+}
+
+func (h *DocumentSequenceHandlers) UpdateDocumentSequence(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.UpdateDocumentSequenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	sequence, err := h.service.UpdateDocumentSequence(r.Context(), mux.Vars(r)["documentType"], req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, sequence)
+}
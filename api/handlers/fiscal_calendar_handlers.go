@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// FiscalCalendarHandlers wraps the fiscal calendar service to provide HTTP handlers.
+type FiscalCalendarHandlers struct {
+	service service.FiscalCalendarService
+}
+
+// NewFiscalCalendarHandlers creates a new FiscalCalendarHandlers instance.
+func NewFiscalCalendarHandlers(serv service.FiscalCalendarService) *FiscalCalendarHandlers {
+	return &FiscalCalendarHandlers{service: serv}
+}
+
+// RegisterFiscalCalendarRoutes registers fiscal year and period routes with the provided router.
+func (h *FiscalCalendarHandlers) RegisterFiscalCalendarRoutes(r *mux.Router) {
+	yearRouter := r.PathPrefix("/api/v1/accounting/fiscal-years").Subrouter()
+	yearRouter.HandleFunc("", h.CreateFiscalYear).Methods("POST")
+	yearRouter.HandleFunc("", h.ListFiscalYears).Methods("GET")
+	yearRouter.HandleFunc("/{id}", h.GetFiscalYear).Methods("GET")
+
+	periodRouter := r.PathPrefix("/api/v1/accounting/fiscal-periods").Subrouter()
+	periodRouter.HandleFunc("/{id}/status", h.UpdateFiscalPeriodStatus).Methods("PUT")
+}
+
+func (h *FiscalCalendarHandlers) CreateFiscalYear(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateFiscalYearRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	year, err := h.service.CreateFiscalYear(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, year)
+}
+
+func (h *FiscalCalendarHandlers) ListFiscalYears(w http.ResponseWriter, r *http.Request) {
+	years, err := h.service.ListFiscalYears(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, years)
+}
+
+func (h *FiscalCalendarHandlers) GetFiscalYear(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid fiscal year ID format", "id"))
+		return
+	}
+	year, err := h.service.GetFiscalYear(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, year)
+}
+
+func (h *FiscalCalendarHandlers) UpdateFiscalPeriodStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid fiscal period ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateFiscalPeriodStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	period, err := h.service.SetPeriodStatus(r.Context(), id, req.Status)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, period)
+}
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/procurement/models"
+	"erp-system/internal/procurement/service"
+	proc_dto "erp-system/internal/procurement/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// ProcurementHandlers wraps the procurement service to provide HTTP handlers.
+type ProcurementHandlers struct {
+	service service.ProcurementService
+}
+
+// NewProcurementHandlers creates a new ProcurementHandlers instance.
+func NewProcurementHandlers(serv service.ProcurementService) *ProcurementHandlers {
+	return &ProcurementHandlers{service: serv}
+}
+
+// RegisterProcurementRoutes registers the vendor, bill, payment and payables aging routes.
+func (h *ProcurementHandlers) RegisterProcurementRoutes(r *mux.Router) {
+	vendorRouter := r.PathPrefix("/api/v1/procurement/vendors").Subrouter()
+	vendorRouter.HandleFunc("", h.CreateVendor).Methods("POST")
+	vendorRouter.HandleFunc("", h.ListVendors).Methods("GET")
+	vendorRouter.HandleFunc("/{id}", h.GetVendor).Methods("GET")
+	vendorRouter.HandleFunc("/{id}", h.UpdateVendor).Methods("PUT")
+	vendorRouter.HandleFunc("/{id}/statement", h.GetVendorStatement).Methods("GET")
+
+	billRouter := r.PathPrefix("/api/v1/procurement/bills").Subrouter()
+	billRouter.HandleFunc("", h.CreateBill).Methods("POST")
+	billRouter.HandleFunc("", h.ListBills).Methods("GET")
+	billRouter.HandleFunc("/{id}", h.GetBill).Methods("GET")
+	billRouter.HandleFunc("/{id}", h.DeleteBill).Methods("DELETE")
+	billRouter.HandleFunc("/{id}/post", h.PostBill).Methods("POST")
+	billRouter.HandleFunc("/{id}/allocations", h.ListDocumentAllocations).Methods("GET")
+
+	paymentRouter := r.PathPrefix("/api/v1/procurement/payments").Subrouter()
+	paymentRouter.HandleFunc("", h.CreatePayment).Methods("POST")
+	paymentRouter.HandleFunc("", h.ListPayments).Methods("GET")
+	paymentRouter.HandleFunc("/{id}", h.GetPayment).Methods("GET")
+	paymentRouter.HandleFunc("/{id}/allocations", h.AllocatePayment).Methods("POST")
+	paymentRouter.HandleFunc("/{id}/allocations", h.ListDocumentAllocations).Methods("GET")
+
+	r.HandleFunc("/api/v1/procurement/aging", h.GetAgingReport).Methods("GET")
+}
+
+// --- Vendor Handlers ---
+
+func (h *ProcurementHandlers) CreateVendor(w http.ResponseWriter, r *http.Request) {
+	var req proc_dto.CreateVendorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	vendor, err := h.service.CreateVendor(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, vendor)
+}
+
+func (h *ProcurementHandlers) ListVendors(w http.ResponseWriter, r *http.Request) {
+	vendors, err := h.service.ListVendors(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, vendors)
+}
+
+func (h *ProcurementHandlers) GetVendor(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid vendor ID format", "id"))
+		return
+	}
+	vendor, err := h.service.GetVendor(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, vendor)
+}
+
+func (h *ProcurementHandlers) UpdateVendor(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid vendor ID format", "id"))
+		return
+	}
+	var req proc_dto.UpdateVendorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	vendor, err := h.service.UpdateVendor(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, vendor)
+}
+
+// GetVendorStatement lists a vendor's bills, payments and discounts from start_date to end_date
+// (the first of the month to today if omitted).
+func (h *ProcurementHandlers) GetVendorStatement(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid vendor ID format", "id"))
+		return
+	}
+	queryParams := r.URL.Query()
+	now := time.Now().UTC()
+	req := proc_dto.StatementRequest{
+		VendorID:  id,
+		StartDate: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   now,
+	}
+	if v := queryParams.Get("start_date"); v != "" {
+		startDate, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid start_date format, use YYYY-MM-DD", "start_date"))
+			return
+		}
+		req.StartDate = startDate
+	}
+	if v := queryParams.Get("end_date"); v != "" {
+		endDate, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid end_date format, use YYYY-MM-DD", "end_date"))
+			return
+		}
+		req.EndDate = endDate
+	}
+
+	statement, err := h.service.GetVendorStatement(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, statement)
+}
+
+// --- Bill Handlers ---
+
+func (h *ProcurementHandlers) CreateBill(w http.ResponseWriter, r *http.Request) {
+	var req proc_dto.CreateBillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	bill, err := h.service.CreateBill(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, bill)
+}
+
+// ListBills lists bills, optionally filtered by vendor_id and status.
+func (h *ProcurementHandlers) ListBills(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	req := proc_dto.ListBillsRequest{Status: models.BillStatus(queryParams.Get("status"))}
+	if v := queryParams.Get("vendor_id"); v != "" {
+		vendorID, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid vendor_id format", "vendor_id"))
+			return
+		}
+		req.VendorID = &vendorID
+	}
+
+	bills, err := h.service.ListBills(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, bills)
+}
+
+func (h *ProcurementHandlers) GetBill(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bill ID format", "id"))
+		return
+	}
+	bill, err := h.service.GetBill(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, bill)
+}
+
+func (h *ProcurementHandlers) DeleteBill(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bill ID format", "id"))
+		return
+	}
+	if err := h.service.DeleteBill(r.Context(), id); err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Bill deleted successfully"})
+}
+
+// PostBill numbers a draft bill and posts it to the general ledger.
+func (h *ProcurementHandlers) PostBill(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid bill ID format", "id"))
+		return
+	}
+	bill, err := h.service.PostBill(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, bill)
+}
+
+// ListDocumentAllocations lists the allocations of a bill or payment.
+func (h *ProcurementHandlers) ListDocumentAllocations(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid document ID format", "id"))
+		return
+	}
+	allocations, err := h.service.ListDocumentAllocations(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, allocations)
+}
+
+// --- Payment Handlers ---
+
+func (h *ProcurementHandlers) CreatePayment(w http.ResponseWriter, r *http.Request) {
+	var req proc_dto.CreatePaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	payment, err := h.service.CreatePayment(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, payment)
+}
+
+// ListPayments lists payments, optionally to one vendor_id.
+func (h *ProcurementHandlers) ListPayments(w http.ResponseWriter, r *http.Request) {
+	var vendorID *uuid.UUID
+	if v := r.URL.Query().Get("vendor_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid vendor_id format", "vendor_id"))
+			return
+		}
+		vendorID = &id
+	}
+	payments, err := h.service.ListPayments(r.Context(), vendorID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, payments)
+}
+
+func (h *ProcurementHandlers) GetPayment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid payment ID format", "id"))
+		return
+	}
+	payment, err := h.service.GetPayment(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, payment)
+}
+
+// AllocatePayment applies the unallocated part of a payment to bills of its vendor.
+func (h *ProcurementHandlers) AllocatePayment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid payment ID format", "id"))
+		return
+	}
+	var req proc_dto.AllocatePaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	allocations, err := h.service.AllocatePayment(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, allocations)
+}
+
+// --- Reporting Handlers ---
+
+// GetAgingReport ages the open payables as of as_of_date (today if omitted), of one vendor_id if
+// given.
+func (h *ProcurementHandlers) GetAgingReport(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	req := proc_dto.AgingRequest{AsOfDate: time.Now().UTC()}
+	if v := queryParams.Get("as_of_date"); v != "" {
+		asOfDate, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid as_of_date format, use YYYY-MM-DD", "as_of_date"))
+			return
+		}
+		req.AsOfDate = asOfDate
+	}
+	if v := queryParams.Get("vendor_id"); v != "" {
+		vendorID, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid vendor_id format", "vendor_id"))
+			return
+		}
+		req.VendorID = &vendorID
+	}
+
+	report, err := h.service.GetAgingReport(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
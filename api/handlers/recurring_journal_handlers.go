@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// RecurringJournalHandlers wraps the recurring journal service to provide HTTP handlers.
+type RecurringJournalHandlers struct {
+	service service.RecurringJournalService
+}
+
+// NewRecurringJournalHandlers creates a new RecurringJournalHandlers instance.
+func NewRecurringJournalHandlers(serv service.RecurringJournalService) *RecurringJournalHandlers {
+	return &RecurringJournalHandlers{service: serv}
+}
+
+// RegisterRecurringJournalRoutes registers recurring journal template routes with the provided router.
+func (h *RecurringJournalHandlers) RegisterRecurringJournalRoutes(r *mux.Router) {
+	templateRouter := r.PathPrefix("/api/v1/accounting/recurring-journals").Subrouter()
+	templateRouter.HandleFunc("", h.CreateRecurringJournalTemplate).Methods("POST")
+	templateRouter.HandleFunc("", h.ListRecurringJournalTemplates).Methods("GET")
+	templateRouter.HandleFunc("/{id}", h.GetRecurringJournalTemplate).Methods("GET")
+	templateRouter.HandleFunc("/{id}", h.UpdateRecurringJournalTemplate).Methods("PUT")
+	templateRouter.HandleFunc("/{id}", h.DeleteRecurringJournalTemplate).Methods("DELETE")
+	templateRouter.HandleFunc("/{id}/runs", h.ListRecurringJournalRuns).Methods("GET")
+}
+
+func (h *RecurringJournalHandlers) CreateRecurringJournalTemplate(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateRecurringJournalTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	template, err := h.service.CreateTemplate(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, template)
+}
+
+func (h *RecurringJournalHandlers) ListRecurringJournalTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.service.ListTemplates(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, templates)
+}
+
+func (h *RecurringJournalHandlers) GetRecurringJournalTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid recurring journal template ID format", "id"))
+		return
+	}
+	template, err := h.service.GetTemplate(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, template)
+}
+
+func (h *RecurringJournalHandlers) UpdateRecurringJournalTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid recurring journal template ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateRecurringJournalTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	template, err := h.service.UpdateTemplate(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, template)
+}
+
+func (h *RecurringJournalHandlers) DeleteRecurringJournalTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid recurring journal template ID format", "id"))
+		return
+	}
+	if err := h.service.DeleteTemplate(r.Context(), id); err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Recurring journal template deleted successfully"})
+}
+
+func (h *RecurringJournalHandlers) ListRecurringJournalRuns(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid recurring journal template ID format", "id"))
+		return
+	}
+	runs, err := h.service.ListRuns(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, runs)
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/sales/models"
+	"erp-system/internal/sales/service"
+	sales_dto "erp-system/internal/sales/service/dto"
+	"erp-system/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// SalesHandlers wraps the sales service to provide HTTP handlers.
+type SalesHandlers struct {
+	service service.SalesService
+}
+
+// NewSalesHandlers creates a new SalesHandlers instance.
+func NewSalesHandlers(serv service.SalesService) *SalesHandlers {
+	return &SalesHandlers{service: serv}
+}
+
+// RegisterSalesRoutes registers the customer, invoice, receipt and receivables aging routes.
+func (h *SalesHandlers) RegisterSalesRoutes(r *mux.Router) {
+	customerRouter := r.PathPrefix("/api/v1/sales/customers").Subrouter()
+	customerRouter.HandleFunc("", h.CreateCustomer).Methods("POST")
+	customerRouter.HandleFunc("", h.ListCustomers).Methods("GET")
+	customerRouter.HandleFunc("/{id}", h.GetCustomer).Methods("GET")
+	customerRouter.HandleFunc("/{id}", h.UpdateCustomer).Methods("PUT")
+
+	invoiceRouter := r.PathPrefix("/api/v1/sales/invoices").Subrouter()
+	invoiceRouter.HandleFunc("", h.CreateInvoice).Methods("POST")
+	invoiceRouter.HandleFunc("", h.ListInvoices).Methods("GET")
+	invoiceRouter.HandleFunc("/{id}", h.GetInvoice).Methods("GET")
+	invoiceRouter.HandleFunc("/{id}", h.DeleteInvoice).Methods("DELETE")
+	invoiceRouter.HandleFunc("/{id}/post", h.PostInvoice).Methods("POST")
+	invoiceRouter.HandleFunc("/{id}/allocations", h.ApplyCreditNote).Methods("POST")
+	invoiceRouter.HandleFunc("/{id}/allocations", h.ListDocumentAllocations).Methods("GET")
+
+	receiptRouter := r.PathPrefix("/api/v1/sales/receipts").Subrouter()
+	receiptRouter.HandleFunc("", h.CreateReceipt).Methods("POST")
+	receiptRouter.HandleFunc("", h.ListReceipts).Methods("GET")
+	receiptRouter.HandleFunc("/{id}", h.GetReceipt).Methods("GET")
+	receiptRouter.HandleFunc("/{id}/allocations", h.AllocateReceipt).Methods("POST")
+	receiptRouter.HandleFunc("/{id}/allocations", h.ListDocumentAllocations).Methods("GET")
+
+	r.HandleFunc("/api/v1/sales/aging", h.GetAgingReport).Methods("GET")
+}
+
+// --- Customer Handlers ---
+
+func (h *SalesHandlers) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var req sales_dto.CreateCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	customer, err := h.service.CreateCustomer(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, customer)
+}
+
+func (h *SalesHandlers) ListCustomers(w http.ResponseWriter, r *http.Request) {
+	customers, err := h.service.ListCustomers(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, customers)
+}
+
+func (h *SalesHandlers) GetCustomer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid customer ID format", "id"))
+		return
+	}
+	customer, err := h.service.GetCustomer(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, customer)
+}
+
+func (h *SalesHandlers) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid customer ID format", "id"))
+		return
+	}
+	var req sales_dto.UpdateCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	customer, err := h.service.UpdateCustomer(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, customer)
+}
+
+// --- Invoice Handlers ---
+
+// CreateInvoice drafts an invoice or, with invoice_type CREDIT_NOTE, a credit note.
+func (h *SalesHandlers) CreateInvoice(w http.ResponseWriter, r *http.Request) {
+	var req sales_dto.CreateInvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	invoice, err := h.service.CreateInvoice(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, invoice)
+}
+
+// ListInvoices lists invoices and credit notes, optionally filtered by customer_id, invoice_type
+// and status.
+func (h *SalesHandlers) ListInvoices(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	req := sales_dto.ListInvoicesRequest{
+		InvoiceType: models.InvoiceType(queryParams.Get("invoice_type")),
+		Status:      models.InvoiceStatus(queryParams.Get("status")),
+	}
+	if v := queryParams.Get("customer_id"); v != "" {
+		customerID, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid customer_id format", "customer_id"))
+			return
+		}
+		req.CustomerID = &customerID
+	}
+
+	invoices, err := h.service.ListInvoices(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, invoices)
+}
+
+func (h *SalesHandlers) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid invoice ID format", "id"))
+		return
+	}
+	invoice, err := h.service.GetInvoice(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, invoice)
+}
+
+func (h *SalesHandlers) DeleteInvoice(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid invoice ID format", "id"))
+		return
+	}
+	if err := h.service.DeleteInvoice(r.Context(), id); err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Invoice deleted successfully"})
+}
+
+// PostInvoice numbers a draft invoice or credit note and posts it to the general ledger.
+func (h *SalesHandlers) PostInvoice(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid invoice ID format", "id"))
+		return
+	}
+	invoice, err := h.service.PostInvoice(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, invoice)
+}
+
+// ApplyCreditNote applies a posted credit note to invoices of its customer.
+func (h *SalesHandlers) ApplyCreditNote(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid invoice ID format", "id"))
+		return
+	}
+	var req sales_dto.AllocateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	allocations, err := h.service.ApplyCreditNote(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, allocations)
+}
+
+// ListDocumentAllocations lists the allocations of an invoice, credit note or receipt.
+func (h *SalesHandlers) ListDocumentAllocations(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid document ID format", "id"))
+		return
+	}
+	allocations, err := h.service.ListDocumentAllocations(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, allocations)
+}
+
+// --- Receipt Handlers ---
+
+func (h *SalesHandlers) CreateReceipt(w http.ResponseWriter, r *http.Request) {
+	var req sales_dto.CreateReceiptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	receipt, err := h.service.CreateReceipt(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, receipt)
+}
+
+// ListReceipts lists receipts, optionally of one customer_id.
+func (h *SalesHandlers) ListReceipts(w http.ResponseWriter, r *http.Request) {
+	var customerID *uuid.UUID
+	if v := r.URL.Query().Get("customer_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid customer_id format", "customer_id"))
+			return
+		}
+		customerID = &id
+	}
+	receipts, err := h.service.ListReceipts(r.Context(), customerID)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, receipts)
+}
+
+func (h *SalesHandlers) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid receipt ID format", "id"))
+		return
+	}
+	receipt, err := h.service.GetReceipt(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, receipt)
+}
+
+// AllocateReceipt applies the unallocated part of a receipt to invoices of its customer.
+func (h *SalesHandlers) AllocateReceipt(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid receipt ID format", "id"))
+		return
+	}
+	var req sales_dto.AllocateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	allocations, err := h.service.AllocateReceipt(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, allocations)
+}
+
+// --- Reporting Handlers ---
+
+// GetAgingReport ages the open receivables as of as_of_date (today if omitted), of one
+// customer_id if given.
+func (h *SalesHandlers) GetAgingReport(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	req := sales_dto.AgingRequest{AsOfDate: time.Now().UTC()}
+	if v := queryParams.Get("as_of_date"); v != "" {
+		asOfDate, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid as_of_date format, use YYYY-MM-DD", "as_of_date"))
+			return
+		}
+		req.AsOfDate = asOfDate
+	}
+	if v := queryParams.Get("customer_id"); v != "" {
+		customerID, err := uuid.Parse(v)
+		if err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid customer_id format", "customer_id"))
+			return
+		}
+		req.CustomerID = &customerID
+	}
+
+	report, err := h.service.GetAgingReport(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
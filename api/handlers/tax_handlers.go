@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"erp-system/internal/accounting/service"
+	acc_dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// TaxHandlers wraps the tax service to provide HTTP handlers.
+type TaxHandlers struct {
+	service service.TaxService
+}
+
+// NewTaxHandlers creates a new TaxHandlers instance.
+func NewTaxHandlers(serv service.TaxService) *TaxHandlers {
+	return &TaxHandlers{service: serv}
+}
+
+// RegisterTaxRoutes registers the tax code, tax calculation and tax return routes.
+func (h *TaxHandlers) RegisterTaxRoutes(r *mux.Router) {
+	taxRouter := r.PathPrefix("/api/v1/accounting/tax-codes").Subrouter()
+	taxRouter.HandleFunc("", h.CreateTaxCode).Methods("POST")
+	taxRouter.HandleFunc("", h.ListTaxCodes).Methods("GET")
+	taxRouter.HandleFunc("/{id}", h.GetTaxCode).Methods("GET")
+	taxRouter.HandleFunc("/{id}", h.UpdateTaxCode).Methods("PUT")
+	taxRouter.HandleFunc("/{id}/rates", h.AddTaxRate).Methods("POST")
+
+	r.HandleFunc("/api/v1/accounting/tax-calculation", h.CalculateTax).Methods("GET")
+	r.HandleFunc("/api/v1/accounting/tax-return", h.GetTaxReturn).Methods("GET")
+}
+
+func (h *TaxHandlers) CreateTaxCode(w http.ResponseWriter, r *http.Request) {
+	var req acc_dto.CreateTaxCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	taxCode, err := h.service.CreateTaxCode(r.Context(), req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, taxCode)
+}
+
+func (h *TaxHandlers) ListTaxCodes(w http.ResponseWriter, r *http.Request) {
+	taxCodes, err := h.service.ListTaxCodes(r.Context())
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, taxCodes)
+}
+
+func (h *TaxHandlers) GetTaxCode(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid tax code ID format", "id"))
+		return
+	}
+	taxCode, err := h.service.GetTaxCode(r.Context(), id)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, taxCode)
+}
+
+func (h *TaxHandlers) UpdateTaxCode(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid tax code ID format", "id"))
+		return
+	}
+	var req acc_dto.UpdateTaxCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	taxCode, err := h.service.UpdateTaxCode(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, taxCode)
+}
+
+// AddTaxRate sets a new rate of the tax code from effective_from on and returns the tax code with
+// all its rates.
+func (h *TaxHandlers) AddTaxRate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid tax code ID format", "id"))
+		return
+	}
+	var req acc_dto.TaxRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid request payload", err.Error()))
+		return
+	}
+	defer r.Body.Close()
+
+	taxCode, err := h.service.AddTaxRate(r.Context(), id, req)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, taxCode)
+}
+
+// CalculateTax returns the tax that tax_code charges on amount in currency (the functional
+// currency if omitted) on date (today if omitted).
+func (h *TaxHandlers) CalculateTax(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	code := queryParams.Get("tax_code")
+	if code == "" {
+		respondWithError(w, errors.NewValidationError("tax_code query parameter is required", "tax_code"))
+		return
+	}
+	amount, err := money.Parse(queryParams.Get("amount"))
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid amount format", "amount"))
+		return
+	}
+	date := time.Now().UTC()
+	if v := queryParams.Get("date"); v != "" {
+		if date, err = time.Parse("2006-01-02", v); err != nil {
+			respondWithError(w, errors.NewValidationError("Invalid date format, use YYYY-MM-DD", "date"))
+			return
+		}
+	}
+
+	calculation, err := h.service.CalculateTax(r.Context(), code, amount, queryParams.Get("currency"), date)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, calculation)
+}
+
+// GetTaxReturn reports the taxable base and tax per return box and per tax code of the posted
+// lines dated from start_date to end_date.
+func (h *TaxHandlers) GetTaxReturn(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	startDateStr := queryParams.Get("start_date")
+	endDateStr := queryParams.Get("end_date")
+	if startDateStr == "" || endDateStr == "" {
+		respondWithError(w, errors.NewValidationError("start_date and end_date query parameters are required", "start_date"))
+		return
+	}
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid start_date format, use YYYY-MM-DD", "start_date"))
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		respondWithError(w, errors.NewValidationError("Invalid end_date format, use YYYY-MM-DD", "end_date"))
+		return
+	}
+
+	taxReturn, err := h.service.GetTaxReturn(r.Context(), acc_dto.TaxReturnRequest{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, taxReturn)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"erp-system/configs"
+	acc_repo "erp-system/internal/accounting/repository"
+	"erp-system/internal/accounting/scheduler"
+	acc_service "erp-system/internal/accounting/service"
+	company_repo "erp-system/internal/company/repository"
+	"erp-system/pkg/company"
+	"erp-system/pkg/logger"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultSchedulerInterval is used when SCHEDULER_INTERVAL is unset or invalid.
+const defaultSchedulerInterval = time.Hour
+
+// NewScheduler creates the background job scheduler that runs alongside the HTTP server.
+// The caller is responsible for starting and stopping it.
+func NewScheduler(db *gorm.DB) *scheduler.Scheduler {
+	accountingService, _ := newAccountingServices(db)
+	recurringJournalService := acc_service.NewRecurringJournalService(acc_repo.NewRecurringJournalRepository(db), accountingService)
+	companyRepo := company_repo.NewCompanyRepository(db)
+
+	autoReversals := scheduler.Job{
+		Name: "auto-reversals",
+		Run: forEachCompany(companyRepo, func(ctx context.Context, now time.Time) error {
+			_, err := accountingService.ProcessDueReversals(ctx, now)
+			return err
+		}),
+	}
+	recurringJournals := scheduler.Job{
+		Name: "recurring-journals",
+		Run: forEachCompany(companyRepo, func(ctx context.Context, now time.Time) error {
+			_, err := recurringJournalService.ProcessDueTemplates(ctx, now)
+			return err
+		}),
+	}
+	return scheduler.New(schedulerInterval(), recurringJournals, autoReversals)
+}
+
+// forEachCompany runs a job once for every active company, with that company active in the
+// context. A company whose run fails does not keep the job from running for the others.
+func forEachCompany(companyRepo company_repo.CompanyRepository, run func(ctx context.Context, now time.Time) error) func(ctx context.Context, now time.Time) error {
+	return func(ctx context.Context, now time.Time) error {
+		companies, err := companyRepo.List(ctx, true)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for _, c := range companies {
+			companyCtx := company.WithCompany(ctx, company.Company{ID: c.ID, Code: c.Code, BaseCurrency: c.BaseCurrency})
+			if err := run(companyCtx, now); err != nil {
+				errs = append(errs, fmt.Errorf("company %s: %w", c.Code, err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func schedulerInterval() time.Duration {
+	raw := configs.GetConfig().SchedulerInterval
+	if raw == "" {
+		return defaultSchedulerInterval
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval <= 0 {
+		logger.WarnLogger.Printf("Invalid SCHEDULER_INTERVAL %q, using %s", raw, defaultSchedulerInterval)
+		return defaultSchedulerInterval
+	}
+	return interval
+}
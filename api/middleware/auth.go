@@ -2,11 +2,11 @@ package middleware
 
 import (
 	"context"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/logger"
 	"net/http"
 	"strings"
-	// "erp-system/pkg/errors" // For custom error types
-	// "erp-system/pkg/logger" // For logging
-	// "erp-system/configs"    // For JWT secret or other auth configs
+	"time"
 )
 
 // UserContextKey is a custom type for context key to avoid collisions.
@@ -17,86 +17,54 @@ const (
 	ContextUserKey UserContextKey = "user"
 )
 
-// Authenticate is a middleware that checks for a valid token (e.g., JWT).
-// This is a placeholder and needs to be implemented with actual token validation logic.
-func Authenticate(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// logger.InfoLogger.Println("Auth middleware: processing request for", r.URL.Path)
-
-		// 1. Get token from Authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			// logger.WarnLogger.Println("Auth middleware: Authorization header missing")
-			// http.Error(w, errors.NewUnauthorizedError("Authorization header required").Error(), http.StatusUnauthorized)
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
-			return
-		}
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			// logger.WarnLogger.Println("Auth middleware: Invalid Authorization header format")
-			// http.Error(w, errors.NewUnauthorizedError("Invalid Authorization header format").Error(), http.StatusUnauthorized)
-			http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
-			return
-		}
-		tokenString := parts[1]
+// Authenticate returns middleware that requires a bearer token signed with secret (see
+// auth.SignToken). The token's user and roles are stored in the request context, where services
+// read them through pkg/auth. With an empty secret every request is rejected.
+func Authenticate(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 1. Get token from Authorization header
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				http.Error(w, "Authorization header required", http.StatusUnauthorized)
+				return
+			}
 
-		// 2. Validate the token (e.g., parse JWT, check signature, expiry)
-		// This is where you would use your JWT library and secret key from config.
-		// For example, if using "github.com/golang-jwt/jwt/v5":
-		/*
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				// Don't forget to validate the alg is what you expect:
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-				}
-				// cfg := configs.GetConfig() // Assuming JWTSecret is in your config
-				// return []byte(cfg.JWTSecretKey), nil
-				return []byte("your_jwt_secret_placeholder"), nil // Replace with actual secret
-			})
+			parts := strings.Split(authHeader, " ")
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+				http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
+				return
+			}
 
+			// 2. Validate the token's signature and expiry
+			principal, err := auth.ParseToken(secret, parts[1], time.Now())
 			if err != nil {
-				logger.ErrorLogger.Printf("Auth middleware: Invalid token: %v", err)
-				http.Error(w, errors.NewUnauthorizedError("Invalid token").Error(), http.StatusUnauthorized)
+				logger.WarnLogger.Printf("Auth middleware: Rejected token for %s %s: %v", r.Method, r.URL.Path, err)
+				http.Error(w, "Invalid token", http.StatusUnauthorized)
 				return
 			}
 
-			if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-				// 3. If valid, extract user information and add it to the request context.
-				// You might want to store user ID, roles, etc.
-				// For example, getting a "sub" (subject, usually user ID) claim:
-				userID, ok := claims["sub"].(string)
-				if !ok {
-					logger.ErrorLogger.Println("Auth middleware: 'sub' claim missing or not a string in token")
-					http.Error(w, errors.NewInternalServerError("Error processing token claims", nil).Error(), http.StatusInternalServerError)
-					return
-				}
+			// 3. Add the user to the request context
+			ctx := context.WithValue(r.Context(), ContextUserKey, principal.UserID)
+			ctx = auth.WithPrincipal(ctx, principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
 
-				// Create a user object or struct to store in context
-				// For simplicity, storing userID directly. You might have a User struct.
-				ctx := context.WithValue(r.Context(), ContextUserKey, userID)
-				logger.InfoLogger.Printf("Auth middleware: User %s authenticated", userID)
-				next.ServeHTTP(w, r.WithContext(ctx))
-			} else {
-				logger.ErrorLogger.Println("Auth middleware: Token claims invalid or token is not valid")
-				http.Error(w, errors.NewUnauthorizedError("Invalid token claims").Error(), http.StatusUnauthorized)
+// ForPathPrefix applies mw only to requests whose path starts with prefix, so routes such as the
+// health check stay public when mw is installed on the root router.
+func ForPathPrefix(prefix string, mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				wrapped.ServeHTTP(w, r)
 				return
 			}
-		*/
-
-		// Placeholder: For now, let's assume the token is valid if it's "test_token"
-		if tokenString == "test_token" {
-			// Store some dummy user info in context
-			ctx := context.WithValue(r.Context(), ContextUserKey, "test_user_id")
-			// logger.InfoLogger.Println("Auth middleware: Test user authenticated")
-			next.ServeHTTP(w, r.WithContext(ctx))
-		} else {
-			// logger.WarnLogger.Println("Auth middleware: Invalid token provided")
-			// http.Error(w, errors.NewUnauthorizedError("Invalid token").Error(), http.StatusUnauthorized)
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
-			return
-		}
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 // GetUserFromContext retrieves user information from the request context.
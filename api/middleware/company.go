@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"erp-system/internal/company/models"
+	"erp-system/pkg/company"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"net/http"
+)
+
+// CompanyHeader names the company a request works on, by its ID or its code.
+const CompanyHeader = "X-Company-ID"
+
+// CompanyResolver finds the active company that a request names.
+type CompanyResolver interface {
+	ResolveCompany(ctx context.Context, ref string) (*models.Company, error)
+}
+
+// ActiveCompany returns middleware that requires the X-Company-ID header and stores the company
+// it names in the request context, where the repositories read it to scope their records (see
+// pkg/company). Requests naming an unknown or inactive company are rejected.
+func ActiveCompany(resolver CompanyResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ref := r.Header.Get(CompanyHeader)
+			if ref == "" {
+				http.Error(w, CompanyHeader+" header required", http.StatusBadRequest)
+				return
+			}
+
+			active, err := resolver.ResolveCompany(r.Context(), ref)
+			if err != nil {
+				switch err.(type) {
+				case *errors.NotFoundError, *errors.ValidationError:
+					logger.WarnLogger.Printf("Company middleware: Unknown company %q for %s %s", ref, r.Method, r.URL.Path)
+					http.Error(w, "Unknown company", http.StatusBadRequest)
+				case *errors.ForbiddenError:
+					http.Error(w, err.Error(), http.StatusForbidden)
+				default:
+					logger.ErrorLogger.Printf("Company middleware: Error resolving company %q: %v", ref, err)
+					http.Error(w, "Could not resolve company", http.StatusInternalServerError)
+				}
+				return
+			}
+
+			ctx := company.WithCompany(r.Context(), company.Company{ID: active.ID, Code: active.Code, BaseCurrency: active.BaseCurrency})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
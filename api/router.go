@@ -2,13 +2,24 @@ package api
 
 import (
 	acc_handlers "erp-system/api/handlers" // Alias for accounting handlers
+	company_handlers "erp-system/api/handlers" // Alias for company handlers
 	inv_handlers "erp-system/api/handlers" // Alias for inventory handlers (will be distinct type)
-	// "erp-system/api/middleware"
+	sales_handlers "erp-system/api/handlers" // Alias for sales handlers
+	proc_handlers "erp-system/api/handlers" // Alias for procurement handlers
+	"erp-system/api/middleware"
+	"erp-system/configs"
 	acc_repo "erp-system/internal/accounting/repository" // Alias for accounting repo
 	acc_service "erp-system/internal/accounting/service" // Alias for accounting service
+	company_repo "erp-system/internal/company/repository"
+	company_service "erp-system/internal/company/service"
 	inv_repo "erp-system/internal/inventory/repository" // Alias for inventory repo
 	inv_service "erp-system/internal/inventory/service" // Alias for inventory service
+	proc_repo "erp-system/internal/procurement/repository"
+	proc_service "erp-system/internal/procurement/service"
+	sales_repo "erp-system/internal/sales/repository"
+	sales_service "erp-system/internal/sales/service"
 	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -29,34 +40,101 @@ func NewRouter(db *gorm.DB) *mux.Router {
 		w.Write([]byte(`{"status":"ok","message":"ERP system is healthy"}`))
 	}).Methods("GET")
 
+	// --- Initialize Company Dependencies ---
+	companyBaseCurrency := configs.GetConfig().BaseCurrency
+	if companyBaseCurrency == "" {
+		companyBaseCurrency = acc_service.DefaultBaseCurrency
+	}
+	companyRepo := company_repo.NewCompanyRepository(db)
+	companyService := company_service.NewCompanyService(companyRepo, companyBaseCurrency)
+	companyAPIHandlers := company_handlers.NewCompanyHandlers(companyService)
+
 	// --- Initialize Accounting Dependencies ---
-	accountingCoaRepo := acc_repo.NewChartOfAccountRepository(db)
-	accountingJournalRepo := acc_repo.NewJournalEntryRepository(db)
-	accountingService := acc_service.NewAccountingService(accountingCoaRepo, accountingJournalRepo)
+	accountingService, fiscalCalendarService := newAccountingServices(db)
+	recurringJournalService := acc_service.NewRecurringJournalService(acc_repo.NewRecurringJournalRepository(db), accountingService)
+	currencyService := acc_service.NewCurrencyService(acc_repo.NewCurrencyRepository(db), acc_repo.NewJournalEntryRepository(db),
+		acc_repo.NewChartOfAccountRepository(db), accountingService)
 	accountingAPIHandlers := acc_handlers.NewAccountingHandlers(accountingService)
+	fiscalCalendarAPIHandlers := acc_handlers.NewFiscalCalendarHandlers(fiscalCalendarService)
+	recurringJournalAPIHandlers := acc_handlers.NewRecurringJournalHandlers(recurringJournalService)
+	currencyAPIHandlers := acc_handlers.NewCurrencyHandlers(currencyService)
+	dimensionAPIHandlers := acc_handlers.NewDimensionHandlers(acc_service.NewDimensionService(acc_repo.NewDimensionRepository(db), acc_repo.NewChartOfAccountRepository(db)))
+	budgetService := acc_service.NewBudgetService(acc_repo.NewBudgetRepository(db), acc_repo.NewFiscalPeriodRepository(db),
+		acc_repo.NewChartOfAccountRepository(db), acc_repo.NewJournalEntryRepository(db), acc_repo.NewDimensionRepository(db))
+	budgetAPIHandlers := acc_handlers.NewBudgetHandlers(budgetService)
+	bankService := acc_service.NewBankService(acc_repo.NewBankRepository(db), acc_repo.NewChartOfAccountRepository(db),
+		acc_repo.NewJournalEntryRepository(db), accountingService)
+	bankAPIHandlers := acc_handlers.NewBankHandlers(bankService)
+	documentSequenceAPIHandlers := acc_handlers.NewDocumentSequenceHandlers(acc_service.NewDocumentSequenceService(acc_repo.NewDocumentSequenceRepository(db)))
+	chartTransferAPIHandlers := acc_handlers.NewChartTransferHandlers(acc_service.NewChartTransferService(acc_repo.NewChartOfAccountRepository(db)))
+	groupCurrency := configs.GetConfig().GroupCurrency
+	if groupCurrency == "" {
+		groupCurrency = companyBaseCurrency
+	}
+	consolidationService := acc_service.NewConsolidationService(acc_repo.NewConsolidationRepository(db), acc_repo.NewChartOfAccountRepository(db),
+		acc_repo.NewJournalEntryRepository(db), acc_repo.NewCurrencyRepository(db), companyRepo, groupCurrency)
+	consolidationAPIHandlers := acc_handlers.NewConsolidationHandlers(consolidationService)
+	taxService := acc_service.NewTaxService(acc_repo.NewTaxRepository(db), acc_repo.NewChartOfAccountRepository(db),
+		acc_repo.NewJournalEntryRepository(db), accountingService)
+	taxAPIHandlers := acc_handlers.NewTaxHandlers(taxService)
 
 	// --- Initialize Inventory Dependencies ---
 	itemRepo := inv_repo.NewItemRepository(db)
 	warehouseRepo := inv_repo.NewWarehouseRepository(db)
 	inventoryTransactionRepo := inv_repo.NewInventoryTransactionRepository(db)
-	inventoryService := inv_service.NewInventoryService(itemRepo, warehouseRepo, inventoryTransactionRepo)
+	inventoryService := inv_service.NewInventoryService(itemRepo, warehouseRepo, inventoryTransactionRepo,
+		inv_service.WithPostingPeriodChecker(fiscalCalendarService)) // Inventory obeys the accounting period locks
 	// Correctly use inv_handlers for NewInventoryHandlers
 	inventoryAPIHandlers := inv_handlers.NewInventoryHandlers(inventoryService)
 
+	// --- Initialize Sales Dependencies ---
+	salesService := sales_service.NewSalesService(sales_repo.NewCustomerRepository(db), sales_repo.NewReceivableRepository(db),
+		accountingService, taxService, configs.GetConfig().ReceivablesAccountCode) // Posts to the general ledger
+	salesAPIHandlers := sales_handlers.NewSalesHandlers(salesService)
+
+	// --- Initialize Procurement Dependencies ---
+	procurementService := proc_service.NewProcurementService(proc_repo.NewVendorRepository(db), proc_repo.NewPayableRepository(db),
+		accountingService, taxService, configs.GetConfig().PayablesAccountCode, configs.GetConfig().PurchaseDiscountAccountCode) // Posts to the general ledger
+	procurementAPIHandlers := proc_handlers.NewProcurementHandlers(procurementService)
+
 
 	// Apply global middleware (e.g., logging, CORS, authentication if globally applied)
 	// r.Use(middleware.LoggingMiddleware)
 	// r.Use(middleware.CORSMiddleware)
-	// apiV1Router := r.PathPrefix("/api/v1").Subrouter()
-	// apiV1Router.Use(middleware.Authenticate) // Apply auth to all /api/v1 routes
+	// Every /api/v1 route requires a bearer token; services read the caller's roles from it.
+	authTokenSecret := configs.GetConfig().AuthTokenSecret
+	if authTokenSecret == "" {
+		logger.WarnLogger.Println("AUTH_TOKEN_SECRET is not set; all /api/v1 requests will be rejected")
+	}
+	r.Use(middleware.ForPathPrefix("/api/v1/", middleware.Authenticate([]byte(authTokenSecret))))
+	// Accounting, inventory, sales and procurement requests work on the books of the company in the X-Company-ID header.
+	// Consolidation requests span all companies and need none.
+	activeCompany := middleware.ActiveCompany(companyService)
+	r.Use(middleware.ForPathPrefix("/api/v1/accounting/", activeCompany))
+	r.Use(middleware.ForPathPrefix("/api/v1/inventory/", activeCompany))
+	r.Use(middleware.ForPathPrefix("/api/v1/sales/", activeCompany))
+	r.Use(middleware.ForPathPrefix("/api/v1/procurement/", activeCompany))
 
 
 	// Register routes for different modules
 	// The handlers themselves define full paths starting with /api/v1/...
 	// So, we register them directly on the main router `r`.
 
+	companyAPIHandlers.RegisterCompanyRoutes(r)
+	chartTransferAPIHandlers.RegisterChartTransferRoutes(r) // Before the accounting routes, see its doc comment
 	accountingAPIHandlers.RegisterAccountingRoutes(r)
+	fiscalCalendarAPIHandlers.RegisterFiscalCalendarRoutes(r)
+	recurringJournalAPIHandlers.RegisterRecurringJournalRoutes(r)
+	currencyAPIHandlers.RegisterCurrencyRoutes(r)
+	dimensionAPIHandlers.RegisterDimensionRoutes(r)
+	budgetAPIHandlers.RegisterBudgetRoutes(r)
+	bankAPIHandlers.RegisterBankRoutes(r)
+	documentSequenceAPIHandlers.RegisterDocumentSequenceRoutes(r)
+	consolidationAPIHandlers.RegisterConsolidationRoutes(r)
+	taxAPIHandlers.RegisterTaxRoutes(r)
 	inventoryAPIHandlers.RegisterInventoryRoutes(r)
+	salesAPIHandlers.RegisterSalesRoutes(r)
+	procurementAPIHandlers.RegisterProcurementRoutes(r)
 	// Add more module route registrations here as they are implemented
 
 	logger.InfoLogger.Println("Router initialization complete.")
@@ -94,7 +172,44 @@ func CORSMiddleware(next http.Handler) http.Handler {
 }
 */
 
-// Note: The actual `middleware.Authenticate` is a placeholder.
-// It needs to be fully implemented with JWT validation or other auth mechanisms.
-// The `db *gorm.DB` is passed to initialize repositories which are then passed to services,
+// Note: The `db *gorm.DB` is passed to initialize repositories which are then passed to services,
 // and services to handlers. This sets up the dependency injection chain.
+
+// newAccountingServices wires the accounting and fiscal calendar services. It is shared by the
+// router and the background job scheduler so both see the same configuration.
+func newAccountingServices(db *gorm.DB) (acc_service.AccountingService, acc_service.FiscalCalendarService) {
+	accountingCoaRepo := acc_repo.NewChartOfAccountRepository(db)
+	accountingJournalRepo := acc_repo.NewJournalEntryRepository(db)
+	fiscalPeriodRepo := acc_repo.NewFiscalPeriodRepository(db)
+	scheduledReversalRepo := acc_repo.NewScheduledReversalRepository(db)
+	currencyRepo := acc_repo.NewCurrencyRepository(db)
+	fiscalCalendarService := acc_service.NewFiscalCalendarService(fiscalPeriodRepo)
+	accountingService := acc_service.NewAccountingService(accountingCoaRepo, accountingJournalRepo,
+		acc_service.WithPostingPeriodChecker(fiscalCalendarService),
+		acc_service.WithYearEndClose(fiscalPeriodRepo, configs.GetConfig().RetainedEarningsAccountCode),
+		acc_service.WithScheduledReversals(scheduledReversalRepo),
+		acc_service.WithCurrencies(currencyRepo),
+		acc_service.WithDimensions(acc_repo.NewDimensionRepository(db)),
+		acc_service.WithTaxCodes(acc_repo.NewTaxRepository(db)),
+		acc_service.WithFXRevaluation(configs.GetConfig().FXGainAccountCode, configs.GetConfig().FXLossAccountCode),
+		acc_service.WithBaseCurrency(configs.GetConfig().BaseCurrency),
+		acc_service.WithApprovalThresholds(journalApprovalThresholds()...),
+		acc_service.WithConfiguredAccounts(map[string]string{
+			configs.GetConfig().ReceivablesAccountCode:      "receivables control",
+			configs.GetConfig().PayablesAccountCode:         "payables control",
+			configs.GetConfig().PurchaseDiscountAccountCode: "purchase discount",
+		}))
+	return accountingService, fiscalCalendarService
+}
+
+// journalApprovalThresholds reads JOURNAL_APPROVAL_THRESHOLDS. A setting that cannot be parsed
+// makes every journal entry need approval rather than silently disabling the workflow.
+func journalApprovalThresholds() []acc_service.ApprovalThreshold {
+	raw := configs.GetConfig().JournalApprovalThresholds
+	thresholds, err := acc_service.ParseApprovalThresholds(raw)
+	if err != nil {
+		logger.ErrorLogger.Printf("Invalid JOURNAL_APPROVAL_THRESHOLDS %q, requiring approval of every journal entry: %v", raw, err)
+		return []acc_service.ApprovalThreshold{{MinAmount: money.Zero}}
+	}
+	return thresholds
+}
@@ -17,6 +17,33 @@ type AppConfig struct {
 	DBPassword string `mapstructure:"DB_PASSWORD"`
 	DBName     string `mapstructure:"DB_NAME"`
 	SSLMode    string `mapstructure:"DB_SSLMODE"`
+	// RetainedEarningsAccountCode is the EQUITY account that receives the year-end close.
+	RetainedEarningsAccountCode string `mapstructure:"RETAINED_EARNINGS_ACCOUNT_CODE"`
+	// SchedulerInterval is how often background accounting jobs run, as a Go duration (e.g. "15m").
+	SchedulerInterval string `mapstructure:"SCHEDULER_INTERVAL"`
+	// BaseCurrency is the company's reporting currency (ISO 4217), "USD" if unset.
+	BaseCurrency string `mapstructure:"BASE_CURRENCY"`
+	// GroupCurrency is the presentation currency of consolidation reports, BaseCurrency if unset.
+	GroupCurrency string `mapstructure:"GROUP_CURRENCY"`
+	// FXGainAccountCode and FXLossAccountCode receive unrealized gains and losses from
+	// foreign-currency revaluation. Both must be REVENUE or EXPENSE accounts; they may be the same.
+	FXGainAccountCode string `mapstructure:"FX_GAIN_ACCOUNT_CODE"`
+	FXLossAccountCode string `mapstructure:"FX_LOSS_ACCOUNT_CODE"`
+	// JournalApprovalThresholds lists the journal entry totals that need approval before posting
+	// and who may approve them, as "amount:ROLE|ROLE,..." (e.g. "10000:ACCOUNTING_MANAGER|ADMIN,100000:ADMIN").
+	// Empty means entries can be posted without approval.
+	JournalApprovalThresholds string `mapstructure:"JOURNAL_APPROVAL_THRESHOLDS"`
+	// ReceivablesAccountCode is the ASSET account the receivables subledger posts customer
+	// invoices, credit notes and receipts to, and reconciles its aging report with.
+	ReceivablesAccountCode string `mapstructure:"RECEIVABLES_ACCOUNT_CODE"`
+	// PayablesAccountCode is the LIABILITY account the payables subledger posts vendor bills and
+	// payments to, and reconciles its aging report and vendor statements with.
+	PayablesAccountCode string `mapstructure:"PAYABLES_ACCOUNT_CODE"`
+	// PurchaseDiscountAccountCode is the REVENUE or EXPENSE account credited with the early-payment
+	// discounts taken on vendor bills. Empty means discounts cannot be taken.
+	PurchaseDiscountAccountCode string `mapstructure:"PURCHASE_DISCOUNT_ACCOUNT_CODE"`
+	// AuthTokenSecret signs and verifies the bearer tokens required on /api/v1 routes.
+	AuthTokenSecret string `mapstructure:"AUTH_TOKEN_SECRET"`
 	// Add other configurations here, e.g., JWT secret, API keys, etc.
 }
 
@@ -67,6 +94,17 @@ func LoadConfig(path string) (AppConfig, error) {
 	overrideWithEnvVar("DB_PASSWORD", &config.DBPassword)
 	overrideWithEnvVar("DB_NAME", &config.DBName)
 	overrideWithEnvVar("DB_SSLMODE", &config.SSLMode)
+	overrideWithEnvVar("RETAINED_EARNINGS_ACCOUNT_CODE", &config.RetainedEarningsAccountCode)
+	overrideWithEnvVar("SCHEDULER_INTERVAL", &config.SchedulerInterval)
+	overrideWithEnvVar("BASE_CURRENCY", &config.BaseCurrency)
+	overrideWithEnvVar("GROUP_CURRENCY", &config.GroupCurrency)
+	overrideWithEnvVar("FX_GAIN_ACCOUNT_CODE", &config.FXGainAccountCode)
+	overrideWithEnvVar("FX_LOSS_ACCOUNT_CODE", &config.FXLossAccountCode)
+	overrideWithEnvVar("JOURNAL_APPROVAL_THRESHOLDS", &config.JournalApprovalThresholds)
+	overrideWithEnvVar("RECEIVABLES_ACCOUNT_CODE", &config.ReceivablesAccountCode)
+	overrideWithEnvVar("PAYABLES_ACCOUNT_CODE", &config.PayablesAccountCode)
+	overrideWithEnvVar("PURCHASE_DISCOUNT_ACCOUNT_CODE", &config.PurchaseDiscountAccountCode)
+	overrideWithEnvVar("AUTH_TOKEN_SECRET", &config.AuthTokenSecret)
 
 	GlobalConfig = config
 	log.Println("Configuration loaded successfully.")
@@ -6,10 +6,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"erp-system/api" // For NewRouter
+	"erp-system/api/middleware"
+	"erp-system/configs"
 	"erp-system/internal/accounting/models"
 	acc_repo "erp-system/internal/accounting/repository" // Alias to avoid conflict if any
 	"erp-system/internal/accounting/service"
 	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/money"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -28,6 +32,7 @@ type APIHandlersIntegrationTestSuite struct {
 	suite.Suite
 	db     *gorm.DB
 	router *mux.Router
+	token  string // Bearer token sent with every request
 
 	// Services and Repos (can be initialized here if needed, or rely on NewRouter to do it)
 	// coaRepo acc_repo.ChartOfAccountRepository
@@ -38,10 +43,15 @@ type APIHandlersIntegrationTestSuite struct {
 // SetupSuite runs once before all tests in the suite.
 func (s *APIHandlersIntegrationTestSuite) SetupSuite() {
 	s.T().Log("Setting up suite for API Handlers integration tests...")
-	s.db = dbInstance // From integration_test_setup_test.go
+	// Direct queries work in the test company, like requests carrying its X-Company-ID header.
+	s.db = dbInstance.WithContext(testCompanyCtx()) // From integration_test_setup_test.go
 
 	// Initialize the main application router. NewRouter sets up repos, services, and handlers.
-	s.router = api.NewRouter(s.db)
+	configs.GlobalConfig.AuthTokenSecret = "integration-test-secret"
+	s.router = api.NewRouter(dbInstance)
+	token, err := auth.SignToken([]byte(configs.GlobalConfig.AuthTokenSecret), auth.Principal{UserID: "integration-test", Roles: []string{auth.RoleAdmin}}, time.Now().Add(time.Hour))
+	s.Require().NoError(err)
+	s.token = token
 	s.T().Log("API Handlers suite setup complete.")
 }
 
@@ -49,6 +59,7 @@ func (s *APIHandlersIntegrationTestSuite) SetupSuite() {
 func (s *APIHandlersIntegrationTestSuite) SetupTest() {
 	s.T().Logf("Setting up test: %s", s.T().Name())
 	resetTables(s.T(), s.db) // Reset database tables
+	seedTestCompany(s.T(), s.db)
 	s.T().Logf("Test setup complete for: %s", s.T().Name())
 }
 
@@ -76,7 +87,8 @@ func (s *APIHandlersIntegrationTestSuite) performRequest(method, path string, bo
 	req, err := http.NewRequest(method, path, reqBody)
 	s.Require().NoError(err, "Failed to create HTTP request")
 	req.Header.Set("Content-Type", "application/json")
-	// Add Auth header if needed: req.Header.Set("Authorization", "Bearer test_token")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set(middleware.CompanyHeader, testCompany.Code)
 
 	rr := httptest.NewRecorder()
 	s.router.ServeHTTP(rr, req)
@@ -196,8 +208,8 @@ func (s *APIHandlersIntegrationTestSuite) TestCreateJournalEntryAPI_ValidAndBala
 		EntryDate:   time.Now(),
 		Description: "API Journal Sale",
 		Lines: []dto.JournalLineRequest{
-			{AccountID: cashAcc.ID, Amount: 250.50, IsDebit: true},
-			{AccountID: revAcc.ID, Amount: 250.50, IsDebit: false},
+			{AccountID: cashAcc.ID, Amount: money.MustParse("250.50"), IsDebit: true},
+			{AccountID: revAcc.ID, Amount: money.MustParse("250.50"), IsDebit: false},
 		},
 	}
 
@@ -229,8 +241,8 @@ func (s *APIHandlersIntegrationTestSuite) TestCreateJournalEntryAPI_Unbalanced()
 	payload := dto.CreateJournalEntryRequest{
 		Description: "API Unbalanced Journal",
 		Lines: []dto.JournalLineRequest{
-			{AccountID: cashAcc.ID, Amount: 100.00, IsDebit: true},
-			{AccountID: revAcc.ID, Amount: 99.00, IsDebit: false}, // Unbalanced
+			{AccountID: cashAcc.ID, Amount: money.MustParse("100.00"), IsDebit: true},
+			{AccountID: revAcc.ID, Amount: money.MustParse("99.00"), IsDebit: false}, // Unbalanced
 		},
 	}
 
@@ -254,8 +266,8 @@ func (s *APIHandlersIntegrationTestSuite) TestPostJournalEntryAPI() {
 	draftEntry := models.JournalEntry{
 		EntryDate: time.Now(), Description: "Draft for API Post", Status: models.StatusDraft,
 		JournalLines: []models.JournalLine{
-			{AccountID: cashAcc.ID, Amount: 75.00, IsDebit: true},
-			{AccountID: revAcc.ID, Amount: 75.00, IsDebit: false},
+			{AccountID: cashAcc.ID, Amount: money.MustParse("75.00"), IsDebit: true},
+			{AccountID: revAcc.ID, Amount: money.MustParse("75.00"), IsDebit: false},
 		},
 	}
 	// Use GORM to create directly for test setup
@@ -302,8 +314,8 @@ func (s *APIHandlersIntegrationTestSuite) TestGetTrialBalanceAPI() {
 	postedEntry := models.JournalEntry{
 		EntryDate: entryTime, Description: "TB API Sale", Status: models.StatusPosted,
 		JournalLines: []models.JournalLine{
-			{AccountID: cash.ID, Amount: 500.00, IsDebit: true},
-			{AccountID: revenue.ID, Amount: 500.00, IsDebit: false},
+			{AccountID: cash.ID, Amount: money.MustParse("500.00"), IsDebit: true},
+			{AccountID: revenue.ID, Amount: money.MustParse("500.00"), IsDebit: false},
 		},
 	}
 	s.db.Create(&postedEntry)
@@ -315,8 +327,8 @@ func (s *APIHandlersIntegrationTestSuite) TestGetTrialBalanceAPI() {
 	postedEntry2 := models.JournalEntry{
 		EntryDate: entryTime.Add(24 * time.Hour), Description: "TB API Expense", Status: models.StatusPosted,
 		JournalLines: []models.JournalLine{
-			{AccountID: expense.ID, Amount: 100.00, IsDebit: true},
-			{AccountID: cash.ID, Amount: 100.00, IsDebit: false},
+			{AccountID: expense.ID, Amount: money.MustParse("100.00"), IsDebit: true},
+			{AccountID: cash.ID, Amount: money.MustParse("100.00"), IsDebit: false},
 		},
 	}
 	s.db.Create(&postedEntry2)
@@ -343,14 +355,14 @@ func (s *APIHandlersIntegrationTestSuite) TestGetTrialBalanceAPI() {
 	s.Require().NoError(err, "Failed to unmarshal TrialBalanceResponse data")
 
 	s.Len(tbResponse.Lines, 3, "Trial balance should have 3 lines (Cash, Revenue, Expense)")
-	s.InDelta(500.00, tbResponse.TotalDebits, 0.001)  // Cash 400 DR, Expense 100 DR
-	s.InDelta(500.00, tbResponse.TotalCredits, 0.001) // Revenue 500 CR
+	s.Equal(money.MustParse("500.00"), tbResponse.TotalDebits)  // Cash 400 DR, Expense 100 DR
+	s.Equal(money.MustParse("500.00"), tbResponse.TotalCredits) // Revenue 500 CR
 
 	foundCash := false
 	for _, line := range tbResponse.Lines {
 		if line.AccountCode == "TB1000" { // Cash
-			s.InDelta(400.00, line.Debit, 0.001) // 500 DR - 100 CR
-			s.InDelta(0.00, line.Credit, 0.001)
+			s.Equal(money.MustParse("400.00"), line.Debit) // 500 DR - 100 CR
+			s.Equal(money.MustParse("0.00"), line.Credit)
 			foundCash = true
 		}
 	}
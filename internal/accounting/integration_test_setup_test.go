@@ -4,7 +4,11 @@ import (
 	"context"
 	"erp-system/configs"
 	"erp-system/internal/accounting/models" // For GORM auto-migration
+	companyModels "erp-system/internal/company/models"
 	inventoryModels "erp-system/internal/inventory/models"
+	procurementModels "erp-system/internal/procurement/models"
+	salesModels "erp-system/internal/sales/models"
+	"erp-system/pkg/company"
 	"erp-system/pkg/database"
 	"erp-system/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -27,8 +31,22 @@ import (
 var (
 	dbInstance *gorm.DB
 	testConfig configs.AppConfig
+	// testCompany is the company every API test works in; seedTestCompany creates it after each reset.
+	testCompany = companyModels.Company{ID: uuid.MustParse("00000000-0000-0000-0000-00000000c001"), Code: "TEST", Name: "Integration Test Company", BaseCurrency: "USD", IsActive: true}
 )
 
+// testCompanyCtx returns a context with the test company active, as the API middleware sets it.
+func testCompanyCtx() context.Context {
+	return company.WithCompany(context.Background(), company.Company{ID: testCompany.ID, Code: testCompany.Code, BaseCurrency: testCompany.BaseCurrency})
+}
+
+// seedTestCompany creates the test company, which requests name in the X-Company-ID header.
+func seedTestCompany(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	seed := testCompany
+	assert.NoError(t, db.Create(&seed).Error, "Failed to seed the test company")
+}
+
 // setupTestDB sets up a PostgreSQL test container and initializes a GORM connection.
 // It also runs migrations.
 func setupTestDB(ctx context.Context) (*gorm.DB, configs.AppConfig, func(), error) {
@@ -147,12 +165,49 @@ func setupTestDB(ctx context.Context) (*gorm.DB, configs.AppConfig, func(), erro
 
 
 	err = gormDB.AutoMigrate(
+		&companyModels.Company{},
 		&models.ChartOfAccount{}, // Accounting model
 		&models.JournalEntry{},   // Accounting model
 		&models.JournalLine{},    // Accounting model
+		&models.JournalEntryApproval{},
+		&models.FiscalYear{},     // Accounting model
+		&models.FiscalPeriod{},   // Accounting model
+		&models.ScheduledReversal{},
+		&models.RecurringJournalTemplate{},
+		&models.RecurringJournalLine{},
+		&models.RecurringJournalRun{},
+		&models.Currency{},
+		&models.ExchangeRate{},
+		&models.Dimension{},
+		&models.DimensionValue{},
+		&models.JournalLineDimension{},
+		&models.AccountDimensionRule{},
+		&models.Budget{},
+		&models.BudgetLine{},
+		&models.BankAccount{},
+		&models.BankStatement{},
+		&models.BankStatementLine{},
+		&models.DocumentSequence{},
+		&models.DocumentSequenceCounter{},
+		&models.AccountMerge{},
+		&models.GroupAccount{},
+		&models.GroupAccountMapping{},
+		&models.EliminationRule{},
+		&models.TaxCode{},
+		&models.TaxRate{},
 		&inventoryModels.Item{},
 		&inventoryModels.Warehouse{},
 		&inventoryModels.InventoryTransaction{},
+		&salesModels.Customer{},
+		&salesModels.Invoice{},
+		&salesModels.InvoiceLine{},
+		&salesModels.Receipt{},
+		&salesModels.Allocation{},
+		&procurementModels.Vendor{},
+		&procurementModels.Bill{},
+		&procurementModels.BillLine{},
+		&procurementModels.Payment{},
+		&procurementModels.Allocation{},
 	)
 	if err != nil {
 		sqlDB, _ := gormDB.DB()
@@ -238,8 +293,16 @@ func resetTables(t *testing.T, db *gorm.DB) {
 	// Inventory transactions might reference items and warehouses.
 	// Journal lines reference chart of accounts.
 
+	// Procurement Module Tables
+	err := db.Exec("TRUNCATE TABLE payable_allocations, vendor_payments, purchase_bill_lines, purchase_bills, vendors CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate procurement tables")
+
+	// Sales Module Tables
+	err = db.Exec("TRUNCATE TABLE receivable_allocations, customer_receipts, sales_invoice_lines, sales_invoices, customers CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate sales tables")
+
 	// Inventory Module Tables
-	err := db.Exec("TRUNCATE TABLE inventory_transactions CASCADE").Error
+	err = db.Exec("TRUNCATE TABLE inventory_transactions CASCADE").Error
 	assert.NoError(t, err, "Failed to truncate inventory_transactions")
 
 	err = db.Exec("TRUNCATE TABLE items CASCADE").Error
@@ -249,6 +312,30 @@ func resetTables(t *testing.T, db *gorm.DB) {
 	assert.NoError(t, err, "Failed to truncate warehouses")
 
 	// Accounting Module Tables
+	err = db.Exec("TRUNCATE TABLE recurring_journal_runs, recurring_journal_lines, recurring_journal_templates CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate recurring journal tables")
+
+	err = db.Exec("TRUNCATE TABLE scheduled_reversals CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate scheduled_reversals")
+
+	err = db.Exec("TRUNCATE TABLE exchange_rates, currencies CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate currency tables")
+
+	err = db.Exec("TRUNCATE TABLE document_sequence_counters, document_sequences CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate document sequence tables")
+
+	err = db.Exec("TRUNCATE TABLE tax_rates, tax_codes CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate tax tables")
+
+	err = db.Exec("TRUNCATE TABLE group_account_mappings CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate group_account_mappings")
+	err = db.Exec("TRUNCATE TABLE elimination_rules CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate elimination_rules")
+	err = db.Exec("TRUNCATE TABLE group_accounts CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate group_accounts")
+	err = db.Exec("TRUNCATE TABLE account_merges CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate account_merges")
+
 	err = db.Exec("TRUNCATE TABLE journal_lines CASCADE").Error
 	assert.NoError(t, err, "Failed to truncate journal_lines")
 
@@ -258,6 +345,15 @@ func resetTables(t *testing.T, db *gorm.DB) {
 	err = db.Exec("TRUNCATE TABLE chart_of_accounts CASCADE").Error
 	assert.NoError(t, err, "Failed to truncate chart_of_accounts")
 
+	err = db.Exec("TRUNCATE TABLE fiscal_periods CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate fiscal_periods")
+
+	err = db.Exec("TRUNCATE TABLE fiscal_years CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate fiscal_years")
+
+	err = db.Exec("TRUNCATE TABLE companies CASCADE").Error
+	assert.NoError(t, err, "Failed to truncate companies")
+
 	// If using sequences that need resetting (e.g. for serial IDs, not UUIDs):
 	// db.Exec("ALTER SEQUENCE chart_of_accounts_id_seq RESTART WITH 1")
 	// etc. for other tables. Not needed for UUIDs.
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccountMerge is the audit record of merging a duplicate source account into a target account:
+// what was moved to the target, who merged them, and why. The source is deactivated by the merge.
+// The codes are copied so the record still reads well if an account is renamed later.
+type AccountMerge struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID          uuid.UUID `gorm:"type:uuid;not null;index" json:"company_id"`
+	SourceAccountID    uuid.UUID `gorm:"type:uuid;not null;index" json:"source_account_id"`
+	SourceAccountCode  string    `gorm:"type:varchar(20);not null" json:"source_account_code"`
+	TargetAccountID    uuid.UUID `gorm:"type:uuid;not null;index" json:"target_account_id"`
+	TargetAccountCode  string    `gorm:"type:varchar(20);not null" json:"target_account_code"`
+	JournalLinesMoved  int64     `gorm:"not null" json:"journal_lines_moved"`
+	ChildAccountsMoved int64     `gorm:"not null" json:"child_accounts_moved"`
+	TemplateLinesMoved int64     `gorm:"not null" json:"template_lines_moved"` // Recurring journal template lines
+	BankAccountsMoved  int64     `gorm:"not null" json:"bank_accounts_moved"`
+	TaxCodesMoved      int64     `gorm:"not null;default:0" json:"tax_codes_moved"` // Tax codes whose tax or reverse-charge account moved
+	Reason             string    `gorm:"type:varchar(500)" json:"reason,omitempty"`
+	MergedBy           string    `gorm:"type:varchar(100);not null" json:"merged_by"`
+	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for AccountMerge model.
+func (AccountMerge) TableName() string {
+	return "account_merges"
+}
+
+// BeforeCreate will set a UUID for the new merge record.
+func (m *AccountMerge) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StatementFormat is the file format a bank statement was imported from.
+type StatementFormat string
+
+const (
+	StatementCSV     StatementFormat = "CSV"
+	StatementOFX     StatementFormat = "OFX"
+	StatementCAMT053 StatementFormat = "CAMT053" // ISO 20022 camt.053 bank-to-customer statement
+)
+
+// MatchMethod records how a statement line was matched to a journal line.
+type MatchMethod string
+
+const (
+	MatchAuto   MatchMethod = "AUTO"
+	MatchManual MatchMethod = "MANUAL"
+)
+
+// BankAccount is an account held at a bank, booked in the general ledger on an ASSET account.
+// Each ledger account backs at most one bank account.
+type BankAccount struct {
+	ID               uuid.UUID       `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID        uuid.UUID       `gorm:"type:uuid;not null;index" json:"company_id"`
+	Name             string          `gorm:"type:varchar(100);not null" json:"name"`
+	AccountNumber    string          `gorm:"type:varchar(50)" json:"account_number"` // IBAN or local account number, without spaces
+	Currency         string          `gorm:"type:varchar(3);not null" json:"currency"`
+	ChartOfAccountID uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex" json:"chart_of_account_id"`
+	IsActive         bool            `gorm:"not null;default:true" json:"is_active"`
+	ChartOfAccount   *ChartOfAccount `gorm:"foreignKey:ChartOfAccountID;references:ID" json:"chart_of_account,omitempty"`
+	CreatedAt        time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID for the new bank account.
+func (ba *BankAccount) BeforeCreate(tx *gorm.DB) (err error) {
+	if ba.ID == uuid.Nil {
+		ba.ID = uuid.New()
+	}
+	return
+}
+
+// BankStatement is one imported statement file. The balances are those the bank reported, when
+// the format carries them.
+type BankStatement struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID      uuid.UUID           `gorm:"type:uuid;not null;index" json:"company_id"`
+	BankAccountID  uuid.UUID           `gorm:"type:uuid;not null;index" json:"bank_account_id"`
+	Format         StatementFormat     `gorm:"type:varchar(10);not null" json:"format"`
+	StatementRef   string              `gorm:"type:varchar(100)" json:"statement_ref,omitempty"` // The bank's statement identifier
+	StartDate      time.Time           `gorm:"type:date;not null" json:"start_date"`
+	EndDate        time.Time           `gorm:"type:date;not null;index" json:"end_date"`
+	OpeningBalance *money.Amount       `gorm:"type:numeric(18,4)" json:"opening_balance,omitempty"`
+	ClosingBalance *money.Amount       `gorm:"type:numeric(18,4)" json:"closing_balance,omitempty"`
+	Lines          []BankStatementLine `gorm:"foreignKey:StatementID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines,omitempty"`
+	CreatedAt      time.Time           `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID for the new bank statement.
+func (bs *BankStatement) BeforeCreate(tx *gorm.DB) (err error) {
+	if bs.ID == uuid.Nil {
+		bs.ID = uuid.New()
+	}
+	return
+}
+
+// BankStatementLine is one transaction on a bank statement. Amount is in the bank account's
+// currency: positive for money received, negative for money paid out. A matched line points at
+// the journal line on the bank's ledger account that records the same transaction.
+type BankStatementLine struct {
+	ID              uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID       uuid.UUID    `gorm:"type:uuid;not null;index" json:"company_id"`
+	StatementID     uuid.UUID    `gorm:"type:uuid;not null;index" json:"statement_id"`
+	BankAccountID   uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_bank_statement_lines_external_id" json:"bank_account_id"`
+	TransactionDate time.Time    `gorm:"type:date;not null;index" json:"transaction_date"`
+	Amount          money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"`
+	Description     string       `gorm:"type:varchar(255)" json:"description"`
+	Reference       string       `gorm:"type:varchar(100)" json:"reference"`
+	// ExternalID is the bank's transaction ID (OFX FITID, camt.053 AcctSvcrRef) or, when the file
+	// has none, a fingerprint of the line; re-importing a statement skips lines already held.
+	ExternalID    string      `gorm:"type:varchar(100);not null;uniqueIndex:idx_bank_statement_lines_external_id" json:"external_id"`
+	JournalLineID *uuid.UUID  `gorm:"type:uuid;uniqueIndex" json:"journal_line_id,omitempty"`
+	MatchMethod   MatchMethod `gorm:"type:varchar(10)" json:"match_method,omitempty"` // Empty while unmatched
+	MatchedAt     *time.Time  `json:"matched_at,omitempty"`
+	CreatedAt     time.Time   `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID for the new bank statement line.
+func (bsl *BankStatementLine) BeforeCreate(tx *gorm.DB) (err error) {
+	if bsl.ID == uuid.Nil {
+		bsl.ID = uuid.New()
+	}
+	return
+}
+
+// IsMatched reports whether the line has been matched to a journal line.
+func (bsl *BankStatementLine) IsMatched() bool {
+	return bsl.JournalLineID != nil
+}
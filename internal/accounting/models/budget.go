@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BudgetStatus tracks a budget version through approval.
+type BudgetStatus string
+
+const (
+	BudgetDraft      BudgetStatus = "DRAFT"      // Lines may still be changed
+	BudgetApproved   BudgetStatus = "APPROVED"   // Read-only; changes need a new version
+	BudgetSuperseded BudgetStatus = "SUPERSEDED" // A later version of the same budget was approved
+)
+
+// Budget is one version of a named budget for a fiscal year, e.g. version 2 of "Operating budget"
+// for FY2026. Versions of the same budget share its name and fiscal year.
+type Budget struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID    uuid.UUID    `gorm:"type:uuid;not null;index" json:"company_id"`
+	Name         string       `gorm:"type:varchar(100);not null;uniqueIndex:idx_budgets_version" json:"name"`
+	FiscalYearID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_budgets_version" json:"fiscal_year_id"`
+	Version      int          `gorm:"not null;uniqueIndex:idx_budgets_version" json:"version"`
+	Description  string       `gorm:"type:varchar(255)" json:"description"`
+	Status       BudgetStatus `gorm:"type:varchar(20);not null;default:'DRAFT';index" json:"status"`
+	ApprovedBy   string       `gorm:"type:varchar(100)" json:"approved_by,omitempty"`
+	ApprovedAt   *time.Time   `json:"approved_at,omitempty"`
+	Lines        []BudgetLine `gorm:"foreignKey:BudgetID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines,omitempty"`
+	CreatedAt    time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID for the new budget.
+func (b *Budget) BeforeCreate(tx *gorm.DB) (err error) {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	if b.Status == "" {
+		b.Status = BudgetDraft
+	}
+	return
+}
+
+// BudgetLine is the amount budgeted for an account in one fiscal period, optionally for a single
+// value of a dimension. Amounts are in the functional currency, in the account's normal balance
+// direction: a positive expense budget is a debit, a positive revenue budget a credit.
+type BudgetLine struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID      uuid.UUID    `gorm:"type:uuid;not null;index" json:"company_id"`
+	BudgetID       uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_budget_lines_key" json:"budget_id"`
+	AccountID      uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_budget_lines_key" json:"account_id"`
+	FiscalPeriodID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_budget_lines_key" json:"fiscal_period_id"`
+	DimensionCode  string       `gorm:"type:varchar(30);not null;default:'';uniqueIndex:idx_budget_lines_key" json:"dimension_code,omitempty"`
+	ValueCode      string       `gorm:"type:varchar(30);not null;default:'';uniqueIndex:idx_budget_lines_key" json:"value_code,omitempty"`
+	Amount         money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"`
+}
+
+// BeforeCreate will set a UUID for the new budget line.
+func (bl *BudgetLine) BeforeCreate(tx *gorm.DB) (err error) {
+	if bl.ID == uuid.Nil {
+		bl.ID = uuid.New()
+	}
+	return
+}
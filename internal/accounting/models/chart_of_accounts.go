@@ -18,18 +18,37 @@ const (
 	Expense   AccountType = "EXPENSE"
 )
 
+// CashFlowCategory classifies a balance sheet account for the statement of cash flows.
+type CashFlowCategory string
+
+const (
+	// CashFlowCash marks cash and cash-equivalent accounts whose change the statement explains.
+	CashFlowCash CashFlowCategory = "CASH"
+	// CashFlowOperating accounts are working-capital items (receivables, inventory, payables, ...).
+	CashFlowOperating CashFlowCategory = "OPERATING"
+	// CashFlowNonCash accounts are added back to net income as non-cash items (e.g. accumulated depreciation).
+	CashFlowNonCash   CashFlowCategory = "NON_CASH"
+	CashFlowInvesting CashFlowCategory = "INVESTING"
+	CashFlowFinancing CashFlowCategory = "FINANCING"
+)
+
+// ValidCashFlowCategories lists the accepted values for ChartOfAccount.CashFlowCategory.
+var ValidCashFlowCategories = []CashFlowCategory{CashFlowCash, CashFlowOperating, CashFlowNonCash, CashFlowInvesting, CashFlowFinancing}
+
 // ChartOfAccount represents an account in the chart of accounts.
 type ChartOfAccount struct {
-	ID              uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
-	AccountCode     string         `gorm:"type:varchar(20);not null;uniqueIndex" json:"account_code"`
-	AccountName     string         `gorm:"type:varchar(100);not null" json:"account_name"`
-	AccountType     AccountType    `gorm:"type:varchar(20);not null;index" json:"account_type"`
-	IsActive        bool           `gorm:"not null;default:true;index" json:"is_active"`
-	Description     string         `gorm:"type:varchar(255)" json:"description"`
-	ParentAccountID *uuid.UUID     `gorm:"type:uuid;index" json:"parent_account_id"`
-	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	ID               uuid.UUID        `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID        uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_chart_of_accounts_company_code" json:"company_id"`
+	AccountCode      string           `gorm:"type:varchar(20);not null;uniqueIndex:idx_chart_of_accounts_company_code" json:"account_code"`
+	AccountName      string           `gorm:"type:varchar(100);not null" json:"account_name"`
+	AccountType      AccountType      `gorm:"type:varchar(20);not null;index" json:"account_type"`
+	IsActive         bool             `gorm:"not null;default:true;index" json:"is_active"`
+	Description      string           `gorm:"type:varchar(255)" json:"description"`
+	ParentAccountID  *uuid.UUID       `gorm:"type:uuid;index" json:"parent_account_id"`
+	CashFlowCategory CashFlowCategory `gorm:"type:varchar(20)" json:"cash_flow_category,omitempty"` // Balance sheet accounts only; see EffectiveCashFlowCategory
+	CreatedAt        time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt        gorm.DeletedAt   `gorm:"index" json:"-"`
 }
 
 // TableName specifies the table name for ChartOfAccount model.
@@ -37,6 +56,23 @@ func (ChartOfAccount) TableName() string {
 	return "chart_of_accounts"
 }
 
+// EffectiveCashFlowCategory returns the account's cash flow category. When none is set,
+// assets and liabilities default to OPERATING and equity to FINANCING.
+// It returns "" for REVENUE and EXPENSE accounts, which enter the statement through net income.
+func (coa *ChartOfAccount) EffectiveCashFlowCategory() CashFlowCategory {
+	switch coa.AccountType {
+	case Revenue, Expense:
+		return ""
+	}
+	if coa.CashFlowCategory != "" {
+		return coa.CashFlowCategory
+	}
+	if coa.AccountType == Equity {
+		return CashFlowFinancing
+	}
+	return CashFlowOperating
+}
+
 // BeforeCreate will set a UUID for the new account.
 func (coa *ChartOfAccount) BeforeCreate(tx *gorm.DB) (err error) {
 	if coa.ID == uuid.Nil {
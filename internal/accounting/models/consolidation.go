@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GroupAccount is an account in the group chart of accounts, which every company's accounts are
+// mapped to for consolidated reporting. The group chart is shared by all companies.
+type GroupAccount struct {
+	ID          uuid.UUID   `gorm:"type:uuid;primary_key;" json:"id"`
+	Code        string      `gorm:"type:varchar(20);uniqueIndex;not null" json:"code"`
+	Name        string      `gorm:"type:varchar(100);not null" json:"name"`
+	AccountType AccountType `gorm:"type:varchar(50);not null" json:"account_type"`
+	IsActive    bool        `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt   time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GroupAccount model.
+func (GroupAccount) TableName() string {
+	return "group_accounts"
+}
+
+// BeforeCreate will set a UUID for the new group account.
+func (ga *GroupAccount) BeforeCreate(tx *gorm.DB) (err error) {
+	if ga.ID == uuid.Nil {
+		ga.ID = uuid.New()
+	}
+	return
+}
+
+// GroupAccountMapping maps one of a company's accounts to a group account. Accounts without a
+// mapping are consolidated into the group account with the same code, if there is one.
+type GroupAccountMapping struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID      uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_group_account_mappings_account" json:"company_id"`
+	AccountID      uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_group_account_mappings_account" json:"account_id"`
+	GroupAccountID uuid.UUID     `gorm:"type:uuid;not null;index" json:"group_account_id"`
+	GroupAccount   *GroupAccount `gorm:"foreignKey:GroupAccountID" json:"group_account,omitempty"`
+	CreatedAt      time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID for the new mapping.
+func (m *GroupAccountMapping) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return
+}
+
+// EliminationRule removes intercompany balances on consolidation. It pairs two group accounts
+// that mirror each other between group companies, such as intercompany receivables and payables
+// or intercompany revenue and expenses: the group totals of both are cleared, and whatever they
+// do not cancel out (e.g. an invoice booked by only one side) is moved to the difference account.
+type EliminationRule struct {
+	ID                       uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	Name                     string    `gorm:"type:varchar(100);uniqueIndex;not null" json:"name"`
+	GroupAccountID           uuid.UUID `gorm:"type:uuid;not null" json:"group_account_id"`         // e.g. intercompany receivables
+	CounterGroupAccountID    uuid.UUID `gorm:"type:uuid;not null" json:"counter_group_account_id"` // e.g. intercompany payables
+	DifferenceGroupAccountID uuid.UUID `gorm:"type:uuid;not null" json:"difference_group_account_id"`
+	IsActive                 bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt                time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt                time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID for the new elimination rule.
+func (er *EliminationRule) BeforeCreate(tx *gorm.DB) (err error) {
+	if er.ID == uuid.Nil {
+		er.ID = uuid.New()
+	}
+	return
+}
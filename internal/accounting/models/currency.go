@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Currency is an entry in the currency master. Journal lines may only use active currencies, apart
+// from the functional currency, which is always accepted. Decimal places follow ISO 4217; see
+// money.MinorUnits.
+type Currency struct {
+	Code      string    `gorm:"type:varchar(3);primary_key" json:"code"` // ISO 4217, e.g. "EUR"
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	IsActive  bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Currency model.
+func (Currency) TableName() string {
+	return "currencies"
+}
+
+// ExchangeRate is the value of one unit of FromCurrency in ToCurrency on RateDate. A rate applies
+// from its date until the next rate for the same pair.
+type ExchangeRate struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	FromCurrency string     `gorm:"type:varchar(3);not null;uniqueIndex:idx_exchange_rates_pair_date" json:"from_currency"`
+	ToCurrency   string     `gorm:"type:varchar(3);not null;uniqueIndex:idx_exchange_rates_pair_date" json:"to_currency"`
+	RateDate     time.Time  `gorm:"type:date;not null;uniqueIndex:idx_exchange_rates_pair_date" json:"rate_date"`
+	Rate         money.Rate `gorm:"type:numeric(18,10);not null" json:"rate"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for ExchangeRate model.
+func (ExchangeRate) TableName() string {
+	return "exchange_rates"
+}
+
+// BeforeCreate will set a UUID for the new exchange rate.
+func (er *ExchangeRate) BeforeCreate(tx *gorm.DB) (err error) {
+	if er.ID == uuid.Nil {
+		er.ID = uuid.New()
+	}
+	return
+}
+
+// CurrencyBalance is what an account holds in one transaction currency: debits minus credits in
+// that currency, and in the functional currency at the rates they were booked at.
+type CurrencyBalance struct {
+	AccountID          uuid.UUID    `json:"account_id"`
+	Currency           string       `json:"currency"`
+	TransactionBalance money.Amount `json:"transaction_balance"`
+	FunctionalBalance  money.Amount `json:"functional_balance"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Dimension is an analytical axis, such as a cost center, department or project, that journal lines
+// are tagged with independently of their account. Codes never change once created, so lines keep
+// the codes they were tagged with.
+type Dimension struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_dimensions_company_code" json:"company_id"`
+	Code      string           `gorm:"type:varchar(30);uniqueIndex:idx_dimensions_company_code;not null" json:"code"` // e.g. "DEPARTMENT"
+	Name      string           `gorm:"type:varchar(100);not null" json:"name"`
+	IsActive  bool             `gorm:"not null;default:true" json:"is_active"`
+	Values    []DimensionValue `gorm:"foreignKey:DimensionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"values"`
+	CreatedAt time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID for the new dimension.
+func (d *Dimension) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return
+}
+
+// DimensionValue is one entry in a dimension's value list, e.g. the SALES department.
+type DimensionValue struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID   uuid.UUID `gorm:"type:uuid;not null;index" json:"company_id"`
+	DimensionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_dimension_values_code" json:"dimension_id"`
+	Code        string    `gorm:"type:varchar(30);not null;uniqueIndex:idx_dimension_values_code" json:"code"`
+	Name        string    `gorm:"type:varchar(100);not null" json:"name"`
+	IsActive    bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID for the new dimension value.
+func (dv *DimensionValue) BeforeCreate(tx *gorm.DB) (err error) {
+	if dv.ID == uuid.Nil {
+		dv.ID = uuid.New()
+	}
+	return
+}
+
+// JournalLineDimension tags a journal line with one value of a dimension. A line carries at most
+// one value per dimension. The codes are copied from the dimension and value so reports can filter
+// and group lines without joining the value lists.
+type JournalLineDimension struct {
+	CompanyID        uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	JournalLineID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	DimensionID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"dimension_id"`
+	DimensionValueID uuid.UUID `gorm:"type:uuid;not null;index" json:"dimension_value_id"`
+	DimensionCode    string    `gorm:"type:varchar(30);not null;index:idx_journal_line_dimensions_codes" json:"dimension_code"`
+	ValueCode        string    `gorm:"type:varchar(30);not null;index:idx_journal_line_dimensions_codes" json:"value_code"`
+}
+
+// AccountDimensionRule makes a dimension mandatory on every journal line posted to an account.
+type AccountDimensionRule struct {
+	CompanyID   uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	AccountID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"account_id"`
+	DimensionID uuid.UUID `gorm:"type:uuid;primaryKey" json:"dimension_id"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// DimensionTags maps dimension codes to value codes, e.g. {"DEPARTMENT": "SALES"}. It is how
+// requests name a line's dimensions and how recurring templates store them.
+type DimensionTags map[string]string
+
+// Value stores the tags as JSON.
+func (t DimensionTags) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(map[string]string(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan reads tags stored as JSON.
+func (t *DimensionTags) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into DimensionTags", value)
+	}
+	return json.Unmarshal(b, (*map[string]string)(t))
+}
+
+// DimensionBalance is debits minus credits of an account's lines carrying one value of the
+// dimension a report is grouped by. ValueCode is empty for lines without a value, and for every
+// line when the report is not grouped.
+type DimensionBalance struct {
+	AccountID uuid.UUID    `json:"account_id"`
+	ValueCode string       `json:"value_code"`
+	Balance   money.Amount `json:"balance"`
+}
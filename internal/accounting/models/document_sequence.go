@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Document types of the journal entry sequences, one per JournalEntryType.
+const (
+	DocumentTypeJournalStandard    = "JOURNAL_STANDARD"
+	DocumentTypeJournalClosing     = "JOURNAL_CLOSING"
+	DocumentTypeJournalReversal    = "JOURNAL_REVERSAL"
+	DocumentTypeJournalRevaluation = "JOURNAL_REVALUATION"
+	DocumentTypeJournalSubledger   = "JOURNAL_SUBLEDGER"
+)
+
+// Document types of the documents that subledgers number themselves.
+const (
+	DocumentTypeSalesInvoice    = "SALES_INVOICE"
+	DocumentTypeSalesCreditNote = "SALES_CREDIT_NOTE"
+	DocumentTypeCustomerReceipt = "CUSTOMER_RECEIPT"
+	DocumentTypePurchaseBill    = "PURCHASE_BILL"
+	DocumentTypeVendorPayment   = "VENDOR_PAYMENT"
+)
+
+// DefaultDocumentSequencePadding is the number of digits used when a sequence does not set one.
+const DefaultDocumentSequencePadding = 6
+
+// DocumentSequence configures the numbers handed out for one document type, e.g. GJ-2026-000123
+// for prefix "GJ", fiscal year 2026 and padding 6. Numbers are consecutive and gap-free: each is
+// taken in the same transaction that saves its document.
+type DocumentSequence struct {
+	CompanyID    uuid.UUID `gorm:"type:uuid;primary_key" json:"-"`
+	DocumentType string    `gorm:"type:varchar(50);primary_key" json:"document_type"` // e.g. "JOURNAL_STANDARD"
+	Prefix       string    `gorm:"type:varchar(20);not null" json:"prefix"`
+	Padding      int       `gorm:"not null;default:6" json:"padding"`
+	// ResetYearly restarts numbering at 1 in every fiscal year and puts the year in the number.
+	// It has no GORM default, so that false is saved as given.
+	ResetYearly bool      `gorm:"not null" json:"reset_yearly"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for DocumentSequence model.
+func (DocumentSequence) TableName() string {
+	return "document_sequences"
+}
+
+// Format renders number as a document number of the sequence for the given fiscal year.
+func (s *DocumentSequence) Format(year int, number int64) string {
+	padding := s.Padding
+	if padding <= 0 {
+		padding = DefaultDocumentSequencePadding
+	}
+	if !s.ResetYearly {
+		return fmt.Sprintf("%s-%0*d", s.Prefix, padding, number)
+	}
+	return fmt.Sprintf("%s-%d-%0*d", s.Prefix, year, padding, number)
+}
+
+// DocumentSequenceCounter holds the last number handed out by a sequence in one fiscal year
+// (year 0 for sequences that do not reset yearly).
+type DocumentSequenceCounter struct {
+	CompanyID    uuid.UUID `gorm:"type:uuid;primary_key" json:"-"`
+	DocumentType string    `gorm:"type:varchar(50);primary_key" json:"document_type"`
+	Year         int       `gorm:"primary_key;autoIncrement:false" json:"year"`
+	LastNumber   int64     `gorm:"not null" json:"last_number"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for DocumentSequenceCounter model.
+func (DocumentSequenceCounter) TableName() string {
+	return "document_sequence_counters"
+}
+
+// DefaultDocumentSequences are used for the journal and subledger document types until they are
+// configured.
+var DefaultDocumentSequences = map[string]DocumentSequence{
+	DocumentTypeJournalStandard:    {DocumentType: DocumentTypeJournalStandard, Prefix: "GJ", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeJournalClosing:     {DocumentType: DocumentTypeJournalClosing, Prefix: "CJ", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeJournalReversal:    {DocumentType: DocumentTypeJournalReversal, Prefix: "RJ", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeJournalRevaluation: {DocumentType: DocumentTypeJournalRevaluation, Prefix: "FX", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeJournalSubledger:   {DocumentType: DocumentTypeJournalSubledger, Prefix: "SJ", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeSalesInvoice:       {DocumentType: DocumentTypeSalesInvoice, Prefix: "INV", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeSalesCreditNote:    {DocumentType: DocumentTypeSalesCreditNote, Prefix: "CN", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeCustomerReceipt:    {DocumentType: DocumentTypeCustomerReceipt, Prefix: "RCT", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypePurchaseBill:       {DocumentType: DocumentTypePurchaseBill, Prefix: "BILL", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+	DocumentTypeVendorPayment:      {DocumentType: DocumentTypeVendorPayment, Prefix: "PAY", Padding: DefaultDocumentSequencePadding, ResetYearly: true},
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PeriodStatus defines whether postings are accepted for dates in a fiscal period.
+type PeriodStatus string
+
+const (
+	PeriodOpen       PeriodStatus = "OPEN"
+	PeriodSoftClosed PeriodStatus = "SOFT_CLOSED" // Only privileged roles may post
+	PeriodClosed     PeriodStatus = "CLOSED"
+)
+
+// FiscalYear groups the accounting periods of one financial year.
+type FiscalYear struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_fiscal_years_company_name" json:"company_id"`
+	Name      string       `gorm:"type:varchar(50);not null;uniqueIndex:idx_fiscal_years_company_name" json:"name"` // e.g., "FY2026"
+	StartDate time.Time    `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate   time.Time    `gorm:"type:date;not null;index" json:"end_date"`
+	Status    PeriodStatus `gorm:"type:varchar(20);not null;default:'OPEN'" json:"status"` // OPEN or CLOSED (after year-end close)
+	// ClosingEntryID is the posted entry that rolled the year's P&L into retained earnings.
+	ClosingEntryID *uuid.UUID     `gorm:"type:uuid" json:"closing_entry_id,omitempty"`
+	ClosedAt       *time.Time     `json:"closed_at,omitempty"`
+	Periods        []FiscalPeriod `gorm:"foreignKey:FiscalYearID" json:"periods,omitempty"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for FiscalYear model.
+func (FiscalYear) TableName() string {
+	return "fiscal_years"
+}
+
+// BeforeCreate will set a UUID for the new fiscal year.
+func (fy *FiscalYear) BeforeCreate(tx *gorm.DB) (err error) {
+	if fy.ID == uuid.Nil {
+		fy.ID = uuid.New()
+	}
+	if fy.Status == "" {
+		fy.Status = PeriodOpen
+	}
+	return
+}
+
+// FiscalPeriod is a posting period (usually a month) within a fiscal year.
+// StartDate and EndDate are inclusive calendar dates.
+type FiscalPeriod struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"company_id"`
+	FiscalYearID uuid.UUID      `gorm:"type:uuid;not null;index" json:"fiscal_year_id"`
+	PeriodNumber int            `gorm:"not null" json:"period_number"`
+	Name         string         `gorm:"type:varchar(50);not null" json:"name"` // e.g., "Jan 2026"
+	StartDate    time.Time      `gorm:"type:date;not null;index" json:"start_date"`
+	EndDate      time.Time      `gorm:"type:date;not null;index" json:"end_date"`
+	Status       PeriodStatus   `gorm:"type:varchar(20);not null;default:'OPEN';index" json:"status"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for FiscalPeriod model.
+func (FiscalPeriod) TableName() string {
+	return "fiscal_periods"
+}
+
+// BeforeCreate will set a UUID for the new fiscal period.
+func (fp *FiscalPeriod) BeforeCreate(tx *gorm.DB) (err error) {
+	if fp.ID == uuid.Nil {
+		fp.ID = uuid.New()
+	}
+	if fp.Status == "" {
+		fp.Status = PeriodOpen
+	}
+	return
+}
+
+// Contains reports whether t falls on a calendar day within the period.
+func (fp *FiscalPeriod) Contains(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(fp.StartDate.Year(), fp.StartDate.Month(), fp.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(fp.EndDate.Year(), fp.EndDate.Month(), fp.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+	return !day.Before(start) && !day.After(end)
+}
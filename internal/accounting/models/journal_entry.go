@@ -1,6 +1,7 @@
 package models
 
 import (
+	"erp-system/pkg/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,21 +15,59 @@ const (
 	StatusDraft  JournalStatus = "DRAFT"
 	StatusPosted JournalStatus = "POSTED"
 	StatusVoided JournalStatus = "VOIDED" // Example of an additional status
+	// StatusPendingApproval entries have been submitted and wait for an approver, who posts them.
+	StatusPendingApproval JournalStatus = "PENDING_APPROVAL"
+	// StatusRejected entries were sent back by an approver; they can be edited and resubmitted.
+	StatusRejected JournalStatus = "REJECTED"
+)
+
+// JournalEntryType distinguishes ordinary entries from system-generated ones.
+type JournalEntryType string
+
+const (
+	EntryTypeStandard JournalEntryType = "STANDARD"
+	EntryTypeClosing  JournalEntryType = "CLOSING"  // Year-end close into retained earnings, and its reversal on reopen
+	EntryTypeReversal JournalEntryType = "REVERSAL" // Cancels a voided entry
+	// EntryTypeRevaluation restates foreign-currency balances at a period-end rate; it is reversed
+	// automatically the next day.
+	EntryTypeRevaluation JournalEntryType = "REVALUATION"
+	// EntryTypeSubledger entries post a subledger document, such as a customer invoice, and are
+	// corrected through that subledger rather than voided.
+	EntryTypeSubledger JournalEntryType = "SUBLEDGER"
 )
 
 // JournalEntry represents a financial transaction header.
 type JournalEntry struct {
-	ID          uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
-	EntryDate   time.Time      `gorm:"not null" json:"entry_date"`
-	Description string         `gorm:"type:varchar(255)" json:"description"`
-	Reference   string         `gorm:"type:varchar(100)" json:"reference"`                       // E.g., Invoice number, PO number
-	Status      JournalStatus  `gorm:"type:varchar(20);default:'POSTED';not null" json:"status"` // Default to DRAFT might be safer in some flows
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID          uuid.UUID        `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID   uuid.UUID        `gorm:"type:uuid;not null;index;index:idx_journal_entries_document_number,unique,where:document_number <> ''" json:"company_id"`
+	EntryDate   time.Time        `gorm:"not null" json:"entry_date"`
+	Description string           `gorm:"type:varchar(255)" json:"description"`
+	Reference   string           `gorm:"type:varchar(100)" json:"reference"`                       // E.g., Invoice number, PO number
+	Status      JournalStatus    `gorm:"type:varchar(20);default:'POSTED';not null" json:"status"` // Default to DRAFT might be safer in some flows
+	EntryType   JournalEntryType `gorm:"type:varchar(20);default:'STANDARD';not null;index" json:"entry_type"`
+	// ReversalOfID is set on a reversing entry and points at the entry it cancels;
+	// ReversedByID is set on the voided entry and points back at its reversal.
+	ReversalOfID *uuid.UUID `gorm:"type:uuid;index" json:"reversal_of_id,omitempty"`
+	ReversedByID *uuid.UUID `gorm:"type:uuid;index" json:"reversed_by_id,omitempty"`
+	VoidReason   string     `gorm:"type:varchar(255)" json:"void_reason,omitempty"`
+	VoidedAt     *time.Time `json:"voided_at,omitempty"`
+	// AutoReverseOn schedules a mirror entry to be posted on that date once this entry is posted.
+	AutoReverseOn *time.Time `gorm:"type:date" json:"auto_reverse_on,omitempty"`
+	// DocumentNumber is the gap-free number, such as GJ-2026-000123, given to the entry when it is posted.
+	DocumentNumber string `gorm:"type:varchar(50);index:idx_journal_entries_document_number,unique,where:document_number <> ''" json:"document_number,omitempty"`
+	// CreatedBy is the user who created the entry, empty for entries the system derives itself.
+	// ApprovedBy and ApprovedAt are set when an approver posts a submitted entry.
+	CreatedBy  string         `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	ApprovedBy string         `gorm:"type:varchar(100)" json:"approved_by,omitempty"`
+	ApprovedAt *time.Time     `json:"approved_at,omitempty"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Associations
 	JournalLines []JournalLine `gorm:"foreignKey:JournalID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"journal_lines"` // Lines associated with this entry
+	// Approvals is the entry's approval history, oldest first.
+	Approvals []JournalEntryApproval `gorm:"foreignKey:JournalEntryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"approvals,omitempty"`
 }
 
 // JournalLine represents a single debit or credit in a journal entry.
@@ -36,7 +75,7 @@ type JournalEntry struct {
 // 	ID          uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
 // 	JournalID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"journal_id"`
 // 	AccountID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"account_id"`
-// 	Amount      float64        `gorm:"type:decimal(18,4);not null" json:"amount"` // Use decimal in DB for precision
+// 	Amount      money.Amount   `gorm:"type:numeric(18,4);not null" json:"amount"`
 // 	Currency    string         `gorm:"type:varchar(3);not null;default:'USD'" json:"currency"`
 // 	IsDebit     bool           `gorm:"not null" json:"is_debit"`
 // 	Description string         `gorm:"type:varchar(255)" json:"description"`
@@ -48,6 +87,28 @@ type JournalEntry struct {
 // 	ChartOfAccount *ChartOfAccount `gorm:"foreignKey:AccountID" json:"chart_of_account,omitempty"`
 // }
 
+// AffectsBalances reports whether the entry counts towards account balances: POSTED entries, and
+// VOIDED entries whose effect is cancelled by their posted reversing entry.
+func (je *JournalEntry) AffectsBalances() bool {
+	return je.Status == StatusPosted || (je.Status == StatusVoided && je.ReversedByID != nil)
+}
+
+// DocumentType returns the document sequence that numbers the entry, one per entry type.
+func (je *JournalEntry) DocumentType() string {
+	switch je.EntryType {
+	case EntryTypeClosing:
+		return DocumentTypeJournalClosing
+	case EntryTypeReversal:
+		return DocumentTypeJournalReversal
+	case EntryTypeRevaluation:
+		return DocumentTypeJournalRevaluation
+	case EntryTypeSubledger:
+		return DocumentTypeJournalSubledger
+	default:
+		return DocumentTypeJournalStandard
+	}
+}
+
 // TableName specifies the table name for JournalEntry model.
 func (JournalEntry) TableName() string {
 	return "journal_entries"
@@ -74,6 +135,9 @@ func (je *JournalEntry) BeforeCreate(tx *gorm.DB) (err error) {
 	if je.EntryDate.IsZero() {
 		je.EntryDate = time.Now()
 	}
+	if je.EntryType == "" {
+		je.EntryType = EntryTypeStandard
+	}
 	return
 }
 
@@ -91,33 +155,29 @@ func (jl *JournalLine) BeforeCreate(tx *gorm.DB) (err error) {
 }
 
 // TotalDebits calculates the sum of all debit amounts in the journal lines.
-func (je *JournalEntry) TotalDebits() float64 {
-	var total float64
+func (je *JournalEntry) TotalDebits() money.Amount {
+	total := money.Zero
 	for _, line := range je.JournalLines {
 		if line.IsDebit {
-			total += line.Amount
+			total = total.Add(line.Amount)
 		}
 	}
 	return total
 }
 
 // TotalCredits calculates the sum of all credit amounts in the journal lines.
-func (je *JournalEntry) TotalCredits() float64 {
-	var total float64
+func (je *JournalEntry) TotalCredits() money.Amount {
+	total := money.Zero
 	for _, line := range je.JournalLines {
 		if !line.IsDebit {
-			total += line.Amount
+			total = total.Add(line.Amount)
 		}
 	}
 	return total
 }
 
 // IsBalanced checks if the journal entry's debits equal its credits.
-// Note: This is a simplified check and doesn't handle floating point inaccuracies well.
-// For financial calculations, use decimal types or scaled integers.
+// Amounts are exact fixed-point values, so no tolerance is needed.
 func (je *JournalEntry) IsBalanced() bool {
-	// A small tolerance for float comparison might be needed if using float64 directly
-	// const tolerance = 1e-9
-	// return math.Abs(je.TotalDebits() - je.TotalCredits()) < tolerance
-	return je.TotalDebits() == je.TotalCredits() // Simpler for now
+	return je.TotalDebits().Equal(je.TotalCredits())
 }
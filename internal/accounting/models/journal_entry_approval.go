@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ApprovalAction is a step in a journal entry's approval workflow.
+type ApprovalAction string
+
+const (
+	ApprovalSubmitted ApprovalAction = "SUBMITTED"
+	ApprovalApproved  ApprovalAction = "APPROVED"
+	ApprovalRejected  ApprovalAction = "REJECTED"
+)
+
+// JournalEntryApproval records who submitted, approved or rejected a journal entry, when, and why.
+type JournalEntryApproval struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"company_id"`
+	JournalEntryID uuid.UUID      `gorm:"type:uuid;not null;index" json:"journal_entry_id"`
+	Action         ApprovalAction `gorm:"type:varchar(20);not null" json:"action"`
+	UserID         string         `gorm:"type:varchar(100);not null" json:"user_id"`
+	Comment        string         `gorm:"type:varchar(500)" json:"comment,omitempty"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID for the new approval record.
+func (a *JournalEntryApproval) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecurrenceFrequency defines how often a recurring journal template produces an entry.
+type RecurrenceFrequency string
+
+const (
+	FrequencyMonthly   RecurrenceFrequency = "MONTHLY"   // Every month on the start date's day (clamped to month end)
+	FrequencyQuarterly RecurrenceFrequency = "QUARTERLY" // Every three months on the start date's day
+	FrequencyCron      RecurrenceFrequency = "CRON"      // Days matched by CronExpression
+)
+
+// RecurringRunStatus tracks one scheduled occurrence of a recurring journal template.
+type RecurringRunStatus string
+
+const (
+	RunPending RecurringRunStatus = "PENDING" // Claimed by the scheduler, entry not recorded yet
+	RunCreated RecurringRunStatus = "CREATED"
+	RunFailed  RecurringRunStatus = "FAILED" // The last attempt failed; retried on the next run of the scheduler
+)
+
+// RecurringJournalTemplate holds the lines of a journal entry that is created on a schedule,
+// e.g. monthly rent or depreciation.
+type RecurringJournalTemplate struct {
+	ID          uuid.UUID           `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID   uuid.UUID           `gorm:"type:uuid;not null;uniqueIndex:idx_recurring_journal_templates_name,where:deleted_at IS NULL" json:"company_id"`
+	Name        string              `gorm:"type:varchar(100);not null;uniqueIndex:idx_recurring_journal_templates_name,where:deleted_at IS NULL" json:"name"`
+	Description string              `gorm:"type:varchar(255)" json:"description"` // Copied onto every created entry
+	Reference   string              `gorm:"type:varchar(100)" json:"reference"`
+	Frequency   RecurrenceFrequency `gorm:"type:varchar(20);not null" json:"frequency"`
+	// CronExpression is a standard five-field cron rule, used when Frequency is CRON. Entries are
+	// dated by day, so only the day-of-month, month and day-of-week fields are significant.
+	CronExpression string        `gorm:"type:varchar(100)" json:"cron_expression,omitempty"`
+	StartDate      time.Time     `gorm:"type:date;not null" json:"start_date"`
+	EndDate        *time.Time    `gorm:"type:date" json:"end_date,omitempty"`
+	EntryStatus    JournalStatus `gorm:"type:varchar(20);not null;default:'DRAFT'" json:"entry_status"` // DRAFT or POSTED
+	IsActive       bool          `gorm:"default:true" json:"is_active"`
+	// LastRunOn is the latest occurrence claimed by the scheduler, or the day before a paused template
+	// was resumed; NextRunOn is the next one due, or nil once the schedule has ended. Both survive
+	// restarts so no occurrence runs twice.
+	LastRunOn *time.Time             `gorm:"type:date" json:"last_run_on,omitempty"`
+	NextRunOn *time.Time             `gorm:"type:date;index" json:"next_run_on,omitempty"`
+	Lines     []RecurringJournalLine `gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines"`
+	CreatedAt time.Time              `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time              `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt         `gorm:"index" json:"-"`
+}
+
+// RecurringJournalLine is a single debit or credit of a recurring journal template.
+type RecurringJournalLine struct {
+	ID         uuid.UUID     `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"company_id"`
+	TemplateID uuid.UUID     `gorm:"type:uuid;not null;index" json:"template_id"`
+	AccountID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"account_id"`
+	Amount     money.Amount  `gorm:"type:numeric(18,4);not null" json:"amount"`
+	Currency   string        `gorm:"type:varchar(3);default:'USD'" json:"currency"`
+	IsDebit    bool          `gorm:"not null" json:"is_debit"`
+	Dimensions DimensionTags `gorm:"type:jsonb" json:"dimensions,omitempty"` // Checked when each entry is created
+	CreatedAt  time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// RecurringJournalRun records one occurrence of a template. The unique (template, run date) pair
+// guarantees an occurrence is never turned into an entry twice.
+type RecurringJournalRun struct {
+	ID             uuid.UUID          `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID      uuid.UUID          `gorm:"type:uuid;not null;index" json:"company_id"`
+	TemplateID     uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_recurring_journal_runs_occurrence" json:"template_id"`
+	RunDate        time.Time          `gorm:"type:date;not null;uniqueIndex:idx_recurring_journal_runs_occurrence" json:"run_date"`
+	Status         RecurringRunStatus `gorm:"type:varchar(20);not null;default:'PENDING'" json:"status"`
+	JournalEntryID *uuid.UUID         `gorm:"type:uuid" json:"journal_entry_id,omitempty"`
+	Error          string             `gorm:"type:varchar(255)" json:"error,omitempty"` // Why the last attempt failed
+	CreatedAt      time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Associations
+	Template *RecurringJournalTemplate `gorm:"foreignKey:TemplateID" json:"-"`
+}
+
+// TableName specifies the table name for RecurringJournalTemplate model.
+func (RecurringJournalTemplate) TableName() string {
+	return "recurring_journal_templates"
+}
+
+// TableName specifies the table name for RecurringJournalLine model.
+func (RecurringJournalLine) TableName() string {
+	return "recurring_journal_lines"
+}
+
+// TableName specifies the table name for RecurringJournalRun model.
+func (RecurringJournalRun) TableName() string {
+	return "recurring_journal_runs"
+}
+
+// BeforeCreate will set a UUID for the new template.
+func (t *RecurringJournalTemplate) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	if t.EntryStatus == "" {
+		t.EntryStatus = StatusDraft
+	}
+	return
+}
+
+// BeforeCreate will set a UUID for the new template line.
+func (l *RecurringJournalLine) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
+
+// BeforeCreate will set a UUID for the new run.
+func (r *RecurringJournalRun) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.Status == "" {
+		r.Status = RunPending
+	}
+	return
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReversalStatus tracks a scheduled automatic reversal of a posted journal entry.
+type ReversalStatus string
+
+const (
+	ReversalPending   ReversalStatus = "PENDING"
+	ReversalPosted    ReversalStatus = "POSTED"
+	ReversalCancelled ReversalStatus = "CANCELLED"
+)
+
+// ScheduledReversal is created when an entry with AutoReverseOn is posted. On ReverseOn the
+// scheduler posts a mirror entry and records it in ReversalEntryID.
+type ScheduledReversal struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID       uuid.UUID      `gorm:"type:uuid;not null;index" json:"company_id"`
+	JournalEntryID  uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"journal_entry_id"`
+	ReverseOn       time.Time      `gorm:"type:date;not null;index" json:"reverse_on"`
+	Status          ReversalStatus `gorm:"type:varchar(20);not null;default:'PENDING';index" json:"status"`
+	ReversalEntryID *uuid.UUID     `gorm:"type:uuid" json:"reversal_entry_id,omitempty"`
+	LastError       string         `gorm:"type:varchar(255)" json:"last_error,omitempty"` // Why the last attempt failed, e.g. a closed period
+	ProcessedAt     *time.Time     `json:"processed_at,omitempty"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Associations
+	JournalEntry *JournalEntry `gorm:"foreignKey:JournalEntryID" json:"journal_entry,omitempty"`
+}
+
+// TableName specifies the table name for ScheduledReversal model.
+func (ScheduledReversal) TableName() string {
+	return "scheduled_reversals"
+}
+
+// BeforeCreate will set a UUID for the new scheduled reversal.
+func (sr *ScheduledReversal) BeforeCreate(tx *gorm.DB) (err error) {
+	if sr.ID == uuid.Nil {
+		sr.ID = uuid.New()
+	}
+	if sr.Status == "" {
+		sr.Status = ReversalPending
+	}
+	return
+}
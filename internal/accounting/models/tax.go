@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaxType says on which side of trade a tax code is charged.
+type TaxType string
+
+const (
+	TaxTypeOutput TaxType = "OUTPUT" // Charged on sales and owed to the tax authority
+	TaxTypeInput  TaxType = "INPUT"  // Paid on purchases and reclaimed from the tax authority
+)
+
+// TaxLineType marks the journal lines that take part in a tax calculation.
+type TaxLineType string
+
+const (
+	TaxLineBase TaxLineType = "BASE" // The taxable amount, as entered
+	TaxLineTax  TaxLineType = "TAX"  // The tax on a base line, added automatically
+	// TaxLineReverseCharge is the output tax a buyer owes on a reverse-charge purchase. It offsets
+	// the input tax line of the same purchase.
+	TaxLineReverseCharge TaxLineType = "REVERSE_CHARGE"
+)
+
+// TaxCode is a VAT/GST treatment that journal lines are tagged with, such as standard-rated sales
+// or reverse-charge services from abroad. Its rates change over time; a line is taxed at the rate
+// in force on the entry date. The boxes name where the code's amounts go on the tax return.
+type TaxCode struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tax_codes_company_code" json:"company_id"`
+	Code      string    `gorm:"type:varchar(20);uniqueIndex:idx_tax_codes_company_code;not null" json:"code"` // e.g. "VAT20"
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	TaxType   TaxType   `gorm:"type:varchar(10);not null" json:"tax_type"`
+	// TaxAccountID receives the tax: a liability for output tax, an asset for reclaimable input tax.
+	TaxAccountID uuid.UUID `gorm:"type:uuid;not null" json:"tax_account_id"`
+	// IsReverseCharge input codes make the buyer account for the supplier's output tax as well:
+	// ReverseChargeAccountID is credited with the same amount the input tax account is debited.
+	IsReverseCharge        bool       `gorm:"not null;default:false" json:"is_reverse_charge"`
+	ReverseChargeAccountID *uuid.UUID `gorm:"type:uuid" json:"reverse_charge_account_id,omitempty"`
+	BaseBox                string     `gorm:"type:varchar(20)" json:"base_box,omitempty"`           // Return box of the taxable base
+	TaxBox                 string     `gorm:"type:varchar(20)" json:"tax_box,omitempty"`            // Return box of the tax
+	ReverseChargeBox       string     `gorm:"type:varchar(20)" json:"reverse_charge_box,omitempty"` // Return box of the reverse-charge output tax
+	IsActive               bool       `gorm:"not null;default:true" json:"is_active"`
+	Rates                  []TaxRate  `gorm:"foreignKey:TaxCodeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"rates"`
+	CreatedAt              time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt              time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BeforeCreate will set a UUID for the new tax code.
+func (tc *TaxCode) BeforeCreate(tx *gorm.DB) (err error) {
+	if tc.ID == uuid.Nil {
+		tc.ID = uuid.New()
+	}
+	return
+}
+
+// RateOn returns the rate in force on date, the one with the latest EffectiveFrom on or before it,
+// or nil if the code has no rate yet on that date.
+func (tc *TaxCode) RateOn(date time.Time) *TaxRate {
+	var inForce *TaxRate
+	for i := range tc.Rates {
+		rate := &tc.Rates[i]
+		if !rate.EffectiveFrom.After(date) && (inForce == nil || rate.EffectiveFrom.After(inForce.EffectiveFrom)) {
+			inForce = rate
+		}
+	}
+	return inForce
+}
+
+// TaxRate is the rate of a tax code from EffectiveFrom until the next rate of the code takes over.
+type TaxRate struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"-"`
+	TaxCodeID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_tax_rates_code_date" json:"tax_code_id"`
+	EffectiveFrom time.Time  `gorm:"type:date;not null;uniqueIndex:idx_tax_rates_code_date" json:"effective_from"`
+	Rate          money.Rate `gorm:"type:numeric(18,10);not null" json:"rate"` // A fraction of the base, e.g. 0.2 for 20%
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// BeforeCreate will set a UUID for the new tax rate.
+func (tr *TaxRate) BeforeCreate(tx *gorm.DB) (err error) {
+	if tr.ID == uuid.Nil {
+		tr.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BankRepository defines the interface for database operations for bank accounts, imported
+// statements and the matching of statement lines to journal lines.
+type BankRepository interface {
+	CreateBankAccount(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error)
+	GetBankAccount(ctx context.Context, id uuid.UUID) (*models.BankAccount, error)
+	GetBankAccountByChartOfAccount(ctx context.Context, chartOfAccountID uuid.UUID) (*models.BankAccount, error)
+	ListBankAccounts(ctx context.Context) ([]*models.BankAccount, error)
+	UpdateBankAccount(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error)
+	CreateStatement(ctx context.Context, statement *models.BankStatement) (*models.BankStatement, error)
+	ListStatements(ctx context.Context, bankAccountID uuid.UUID) ([]*models.BankStatement, error)
+	GetLatestStatement(ctx context.Context, bankAccountID uuid.UUID, endingOnOrBefore time.Time) (*models.BankStatement, error)
+	FindExternalIDs(ctx context.Context, bankAccountID uuid.UUID, externalIDs []string) ([]string, error)
+	GetStatementLine(ctx context.Context, id uuid.UUID) (*models.BankStatementLine, error)
+	GetStatementLineByJournalLine(ctx context.Context, journalLineID uuid.UUID) (*models.BankStatementLine, error)
+	ListStatementLines(ctx context.Context, bankAccountID uuid.UUID, matched *bool, asOf time.Time) ([]*models.BankStatementLine, error)
+	SaveMatches(ctx context.Context, lines []*models.BankStatementLine) error
+	GetJournalLine(ctx context.Context, id uuid.UUID) (*models.JournalLine, error)
+	ListUnclearedJournalLines(ctx context.Context, accountID uuid.UUID, asOf time.Time) ([]models.JournalLine, error)
+}
+
+// gormBankRepository is an implementation of BankRepository using GORM.
+type gormBankRepository struct {
+	db *gorm.DB
+}
+
+// NewBankRepository creates a new GORM-based BankRepository.
+func NewBankRepository(db *gorm.DB) BankRepository {
+	return &gormBankRepository{db: db}
+}
+
+func (r *gormBankRepository) CreateBankAccount(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error) {
+	logger.InfoLogger.Printf("Repository: Creating bank account %s", account.Name)
+	if err := r.db.WithContext(ctx).Omit("ChartOfAccount").Create(account).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating bank account %s: %v", account.Name, err)
+		return nil, errors.NewInternalServerError("failed to create bank account", err)
+	}
+	return account, nil
+}
+
+func (r *gormBankRepository) GetBankAccount(ctx context.Context, id uuid.UUID) (*models.BankAccount, error) {
+	var account models.BankAccount
+	if err := r.db.WithContext(ctx).Preload("ChartOfAccount").First(&account, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("bank_account", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving bank account %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get bank account %s", id), err)
+	}
+	return &account, nil
+}
+
+func (r *gormBankRepository) GetBankAccountByChartOfAccount(ctx context.Context, chartOfAccountID uuid.UUID) (*models.BankAccount, error) {
+	var account models.BankAccount
+	if err := r.db.WithContext(ctx).First(&account, "chart_of_account_id = ?", chartOfAccountID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("bank_account", chartOfAccountID.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving bank account of ledger account %s: %v", chartOfAccountID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get bank account of ledger account %s", chartOfAccountID), err)
+	}
+	return &account, nil
+}
+
+// ListBankAccounts returns every bank account ordered by name.
+func (r *gormBankRepository) ListBankAccounts(ctx context.Context) ([]*models.BankAccount, error) {
+	var accounts []*models.BankAccount
+	if err := r.db.WithContext(ctx).Preload("ChartOfAccount").Order("name asc").Find(&accounts).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing bank accounts: %v", err)
+		return nil, errors.NewInternalServerError("failed to list bank accounts", err)
+	}
+	return accounts, nil
+}
+
+func (r *gormBankRepository) UpdateBankAccount(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error) {
+	logger.InfoLogger.Printf("Repository: Updating bank account %s", account.ID)
+	if err := r.db.WithContext(ctx).Omit("ChartOfAccount").Save(account).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating bank account %s: %v", account.ID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update bank account %s", account.ID), err)
+	}
+	return account, nil
+}
+
+// CreateStatement inserts a statement together with its lines in one transaction.
+func (r *gormBankRepository) CreateStatement(ctx context.Context, statement *models.BankStatement) (*models.BankStatement, error) {
+	logger.InfoLogger.Printf("Repository: Creating %s statement with %d lines for bank account %s", statement.Format, len(statement.Lines), statement.BankAccountID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(statement).Error // Lines are created through the association
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating statement for bank account %s: %v", statement.BankAccountID, err)
+		return nil, errors.NewInternalServerError("failed to create bank statement", err)
+	}
+	return statement, nil
+}
+
+// ListStatements returns a bank account's statements without their lines, latest first.
+func (r *gormBankRepository) ListStatements(ctx context.Context, bankAccountID uuid.UUID) ([]*models.BankStatement, error) {
+	var statements []*models.BankStatement
+	if err := r.db.WithContext(ctx).Where("bank_account_id = ?", bankAccountID).Order("end_date desc, created_at desc").Find(&statements).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing statements of bank account %s: %v", bankAccountID, err)
+		return nil, errors.NewInternalServerError("failed to list bank statements", err)
+	}
+	return statements, nil
+}
+
+// GetLatestStatement returns the statement with the latest end date on or before the given date
+// that reports a closing balance.
+func (r *gormBankRepository) GetLatestStatement(ctx context.Context, bankAccountID uuid.UUID, endingOnOrBefore time.Time) (*models.BankStatement, error) {
+	var statement models.BankStatement
+	err := r.db.WithContext(ctx).
+		Where("bank_account_id = ? AND end_date <= ? AND closing_balance IS NOT NULL", bankAccountID, endingOnOrBefore).
+		Order("end_date desc, created_at desc").
+		First(&statement).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("bank_statement", bankAccountID.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving latest statement of bank account %s: %v", bankAccountID, err)
+		return nil, errors.NewInternalServerError("failed to get latest bank statement", err)
+	}
+	return &statement, nil
+}
+
+// FindExternalIDs returns which of the given external IDs the bank account already holds.
+func (r *gormBankRepository) FindExternalIDs(ctx context.Context, bankAccountID uuid.UUID, externalIDs []string) ([]string, error) {
+	var found []string
+	if len(externalIDs) == 0 {
+		return found, nil
+	}
+	err := r.db.WithContext(ctx).Model(&models.BankStatementLine{}).
+		Where("bank_account_id = ? AND external_id IN ?", bankAccountID, externalIDs).
+		Pluck("external_id", &found).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error checking statement lines of bank account %s: %v", bankAccountID, err)
+		return nil, errors.NewInternalServerError("failed to check existing statement lines", err)
+	}
+	return found, nil
+}
+
+func (r *gormBankRepository) GetStatementLine(ctx context.Context, id uuid.UUID) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	if err := r.db.WithContext(ctx).First(&line, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("bank_statement_line", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving statement line %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get statement line %s", id), err)
+	}
+	return &line, nil
+}
+
+func (r *gormBankRepository) GetStatementLineByJournalLine(ctx context.Context, journalLineID uuid.UUID) (*models.BankStatementLine, error) {
+	var line models.BankStatementLine
+	if err := r.db.WithContext(ctx).First(&line, "journal_line_id = ?", journalLineID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("bank_statement_line", journalLineID.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving statement line matched to journal line %s: %v", journalLineID, err)
+		return nil, errors.NewInternalServerError("failed to get statement line", err)
+	}
+	return &line, nil
+}
+
+// ListStatementLines returns a bank account's statement lines ordered by date. matched, when set,
+// selects matched or unmatched lines only; a non-zero asOf leaves out lines dated after it.
+func (r *gormBankRepository) ListStatementLines(ctx context.Context, bankAccountID uuid.UUID, matched *bool, asOf time.Time) ([]*models.BankStatementLine, error) {
+	var lines []*models.BankStatementLine
+	query := r.db.WithContext(ctx).Where("bank_account_id = ?", bankAccountID)
+	if matched != nil {
+		if *matched {
+			query = query.Where("journal_line_id IS NOT NULL")
+		} else {
+			query = query.Where("journal_line_id IS NULL")
+		}
+	}
+	if !asOf.IsZero() {
+		query = query.Where("transaction_date <= ?", asOf)
+	}
+	if err := query.Order("transaction_date asc, created_at asc").Find(&lines).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing statement lines of bank account %s: %v", bankAccountID, err)
+		return nil, errors.NewInternalServerError("failed to list statement lines", err)
+	}
+	return lines, nil
+}
+
+// SaveMatches stores the match fields of the given statement lines in one transaction.
+func (r *gormBankRepository) SaveMatches(ctx context.Context, lines []*models.BankStatementLine) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, line := range lines {
+			err := tx.Model(&models.BankStatementLine{}).Where("id = ?", line.ID).
+				Select("journal_line_id", "match_method", "matched_at").
+				Updates(map[string]interface{}{"journal_line_id": line.JournalLineID, "match_method": line.MatchMethod, "matched_at": line.MatchedAt}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error saving %d statement line matches: %v", len(lines), err)
+		return errors.NewInternalServerError("failed to save statement line matches", err)
+	}
+	return nil
+}
+
+// GetJournalLine retrieves a journal line with its entry.
+func (r *gormBankRepository) GetJournalLine(ctx context.Context, id uuid.UUID) (*models.JournalLine, error) {
+	var line models.JournalLine
+	if err := r.db.WithContext(ctx).Preload("JournalEntry").First(&line, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("journal_line", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving journal line %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get journal line %s", id), err)
+	}
+	return &line, nil
+}
+
+// ListUnclearedJournalLines returns the lines on a ledger account, with their entries, that no
+// statement line is matched to. Only posted entries dated up to asOf are considered; a voided
+// entry and the reversal that cancels it never reach the bank and are left out.
+func (r *gormBankRepository) ListUnclearedJournalLines(ctx context.Context, accountID uuid.UUID, asOf time.Time) ([]models.JournalLine, error) {
+	var lines []models.JournalLine
+	err := r.db.WithContext(ctx).
+		Preload("JournalEntry").
+		Joins("JOIN journal_entries ON journal_entries.id = journal_lines.journal_id AND journal_entries.deleted_at IS NULL").
+		Where("journal_lines.account_id = ? AND journal_entries.status = ? AND journal_entries.entry_date <= ?", accountID, models.StatusPosted, asOf).
+		Where("NOT EXISTS (SELECT 1 FROM journal_entries voided WHERE voided.id = journal_entries.reversal_of_id AND voided.status = ?)", models.StatusVoided).
+		Where("NOT EXISTS (SELECT 1 FROM bank_statement_lines WHERE bank_statement_lines.journal_line_id = journal_lines.id)").
+		Order("journal_entries.entry_date asc, journal_lines.created_at asc").
+		Find(&lines).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing uncleared journal lines of account %s: %v", accountID, err)
+		return nil, errors.NewInternalServerError("failed to list uncleared journal lines", err)
+	}
+	return lines, nil
+}
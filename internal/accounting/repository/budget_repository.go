@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BudgetRepository defines the interface for database operations for budget versions and their lines.
+type BudgetRepository interface {
+	CreateBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error)
+	GetBudget(ctx context.Context, id uuid.UUID) (*models.Budget, error)
+	GetLatestVersion(ctx context.Context, name string, fiscalYearID uuid.UUID) (*models.Budget, error)
+	ListBudgets(ctx context.Context, fiscalYearID *uuid.UUID) ([]*models.Budget, error)
+	UpdateBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error)
+	ApproveBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error)
+}
+
+// gormBudgetRepository is an implementation of BudgetRepository using GORM.
+type gormBudgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository creates a new GORM-based BudgetRepository.
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &gormBudgetRepository{db: db}
+}
+
+// CreateBudget inserts a budget version together with its lines in one transaction.
+func (r *gormBudgetRepository) CreateBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error) {
+	logger.InfoLogger.Printf("Repository: Creating budget %s version %d with %d lines", budget.Name, budget.Version, len(budget.Lines))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(budget).Error // Lines are created through the association
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating budget %s version %d: %v", budget.Name, budget.Version, err)
+		return nil, errors.NewInternalServerError("failed to create budget", err)
+	}
+	return budget, nil
+}
+
+// GetBudget retrieves a budget version and its lines.
+func (r *gormBudgetRepository) GetBudget(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	var budget models.Budget
+	if err := r.db.WithContext(ctx).Preload("Lines").First(&budget, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("budget", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving budget %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get budget %s", id), err)
+	}
+	return &budget, nil
+}
+
+// GetLatestVersion returns the highest version of the named budget for a fiscal year, without its lines.
+func (r *gormBudgetRepository) GetLatestVersion(ctx context.Context, name string, fiscalYearID uuid.UUID) (*models.Budget, error) {
+	var budget models.Budget
+	err := r.db.WithContext(ctx).
+		Where("name = ? AND fiscal_year_id = ?", name, fiscalYearID).
+		Order("version desc").
+		First(&budget).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("budget", name)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving latest version of budget %s: %v", name, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get budget %s", name), err)
+	}
+	return &budget, nil
+}
+
+// ListBudgets returns every budget version, or those of one fiscal year, without their lines.
+func (r *gormBudgetRepository) ListBudgets(ctx context.Context, fiscalYearID *uuid.UUID) ([]*models.Budget, error) {
+	var budgets []*models.Budget
+	query := r.db.WithContext(ctx)
+	if fiscalYearID != nil {
+		query = query.Where("fiscal_year_id = ?", *fiscalYearID)
+	}
+	if err := query.Order("name asc, version desc").Find(&budgets).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing budgets: %v", err)
+		return nil, errors.NewInternalServerError("failed to list budgets", err)
+	}
+	return budgets, nil
+}
+
+// UpdateBudget saves a draft budget and replaces all of its lines in one transaction.
+func (r *gormBudgetRepository) UpdateBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error) {
+	logger.InfoLogger.Printf("Repository: Updating budget %s", budget.ID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Lines").Save(budget).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("budget_id = ?", budget.ID).Delete(&models.BudgetLine{}).Error; err != nil {
+			return err
+		}
+		for i := range budget.Lines {
+			budget.Lines[i].ID = uuid.Nil
+			budget.Lines[i].BudgetID = budget.ID
+		}
+		if len(budget.Lines) > 0 {
+			return tx.Create(&budget.Lines).Error
+		}
+		return nil
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Transaction failed for updating budget %s: %v", budget.ID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update budget %s", budget.ID), err)
+	}
+	return budget, nil
+}
+
+// ApproveBudget saves an approved budget version and marks the previously approved version of the
+// same budget SUPERSEDED, in one transaction.
+func (r *gormBudgetRepository) ApproveBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error) {
+	logger.InfoLogger.Printf("Repository: Approving budget %s version %d", budget.Name, budget.Version)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Budget{}).
+			Where("name = ? AND fiscal_year_id = ? AND status = ? AND id <> ?", budget.Name, budget.FiscalYearID, models.BudgetApproved, budget.ID).
+			Update("status", models.BudgetSuperseded).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Lines").Save(budget).Error
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Transaction failed for approving budget %s: %v", budget.ID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to approve budget %s", budget.ID), err)
+	}
+	return budget, nil
+}
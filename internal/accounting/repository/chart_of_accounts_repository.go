@@ -9,16 +9,21 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ChartOfAccountRepository defines the interface for database operations for ChartOfAccount.
 type ChartOfAccountRepository interface {
 	Create(ctx context.Context, account *models.ChartOfAccount) (*models.ChartOfAccount, error)
+	CreateAll(ctx context.Context, accounts []*models.ChartOfAccount) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.ChartOfAccount, error)
 	GetByCode(ctx context.Context, code string) (*models.ChartOfAccount, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.ChartOfAccount, error)
 	Update(ctx context.Context, account *models.ChartOfAccount) (*models.ChartOfAccount, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, offset, limit int, filters map[string]interface{}) ([]*models.ChartOfAccount, int64, error)
+	MergeAccounts(ctx context.Context, merge *models.AccountMerge) (*models.AccountMerge, error)
+	ListMerges(ctx context.Context, accountID uuid.UUID) ([]*models.AccountMerge, error)
 }
 
 // gormChartOfAccountRepository is an implementation of ChartOfAccountRepository using GORM.
@@ -42,6 +47,32 @@ func (r *gormChartOfAccountRepository) Create(ctx context.Context, account *mode
 	return account, nil
 }
 
+// CreateAll adds accounts in one transaction, in the order given, so a parent must come before
+// the accounts below it. Either every account is created or none is.
+func (r *gormChartOfAccountRepository) CreateAll(ctx context.Context, accounts []*models.ChartOfAccount) error {
+	logger.InfoLogger.Printf("Repository: Attempting to create %d charts of account", len(accounts))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, account := range accounts {
+			if err := tx.Create(account).Error; err != nil {
+				return fmt.Errorf("account %s: %w", account.AccountCode, err)
+			}
+			// is_active has a database default, so an inactive account is created active first.
+			if !account.IsActive {
+				if err := tx.Model(account).Update("is_active", false).Error; err != nil {
+					return fmt.Errorf("account %s: %w", account.AccountCode, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating charts of account: %v", err)
+		return errors.NewInternalServerError("failed to create charts of account", err)
+	}
+	logger.InfoLogger.Printf("Repository: Successfully created %d charts of account", len(accounts))
+	return nil
+}
+
 // GetByID retrieves a chart of account by its ID.
 func (r *gormChartOfAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ChartOfAccount, error) {
 	logger.InfoLogger.Printf("Repository: Attempting to retrieve chart of account with ID: %s", id)
@@ -58,6 +89,20 @@ func (r *gormChartOfAccountRepository) GetByID(ctx context.Context, id uuid.UUID
 	return &account, nil
 }
 
+// GetByIDs retrieves the charts of account with the given IDs, ordered by account code.
+// IDs that do not exist are left out of the result.
+func (r *gormChartOfAccountRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.ChartOfAccount, error) {
+	var accounts []*models.ChartOfAccount
+	if len(ids) == 0 {
+		return accounts, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("account_code asc").Find(&accounts).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error retrieving charts of account by IDs: %v", err)
+		return nil, errors.NewInternalServerError("failed to get charts of account by IDs", err)
+	}
+	return accounts, nil
+}
+
 // GetByCode retrieves a chart of account by its account code.
 func (r *gormChartOfAccountRepository) GetByCode(ctx context.Context, code string) (*models.ChartOfAccount, error) {
 	logger.InfoLogger.Printf("Repository: Attempting to retrieve chart of account with code: %s", code)
@@ -114,6 +159,9 @@ func (r *gormChartOfAccountRepository) List(ctx context.Context, offset, limit i
     if isActive, ok := filters["is_active"].(bool); ok {
         query = query.Where("is_active = ?", isActive)
     }
+	if parentID, ok := filters["parent_account_id"].(uuid.UUID); ok {
+		query = query.Where("parent_account_id = ?", parentID)
+	}
 
 
 	if err := query.Count(&total).Error; err != nil {
@@ -137,3 +185,93 @@ func (r *gormChartOfAccountRepository) List(ctx context.Context, offset, limit i
 	logger.InfoLogger.Printf("Repository: Successfully listed %d chart of accounts, total count: %d", len(accounts), total)
 	return accounts, total, nil
 }
+
+// MergeAccounts moves the journal lines, child accounts, recurring template lines, bank account link
+// and tax code accounts of merge.SourceAccountID to merge.TargetAccountID, deactivates the source and saves merge,
+// with the counts filled in, as its audit record, all in one transaction. Both accounts stay locked
+// until it ends; it returns a ConflictError if the target was deactivated meanwhile or both
+// accounts back a bank account.
+func (r *gormChartOfAccountRepository) MergeAccounts(ctx context.Context, merge *models.AccountMerge) (*models.AccountMerge, error) {
+	logger.InfoLogger.Printf("Repository: Merging chart of account %s into %s", merge.SourceAccountCode, merge.TargetAccountCode)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked []*models.ChartOfAccount
+		ids := []uuid.UUID{merge.SourceAccountID, merge.TargetAccountID}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Order("id").Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return errors.NewConflictError(fmt.Sprintf("account %s or %s no longer exists", merge.SourceAccountCode, merge.TargetAccountCode))
+		}
+		for _, acc := range locked {
+			if acc.ID == merge.TargetAccountID && !acc.IsActive {
+				return errors.NewConflictError(fmt.Sprintf("account %s is no longer active", acc.AccountCode))
+			}
+		}
+
+		var bankAccounts []models.BankAccount
+		if err := tx.Where("chart_of_account_id IN ?", ids).Find(&bankAccounts).Error; err != nil {
+			return err
+		}
+		if len(bankAccounts) > 1 {
+			return errors.NewConflictError(fmt.Sprintf("accounts %s and %s both back a bank account", merge.SourceAccountCode, merge.TargetAccountCode))
+		}
+
+		result := tx.Model(&models.JournalLine{}).Where("account_id = ?", merge.SourceAccountID).Update("account_id", merge.TargetAccountID)
+		if result.Error != nil {
+			return result.Error
+		}
+		merge.JournalLinesMoved = result.RowsAffected
+		result = tx.Model(&models.ChartOfAccount{}).Where("parent_account_id = ?", merge.SourceAccountID).Update("parent_account_id", merge.TargetAccountID)
+		if result.Error != nil {
+			return result.Error
+		}
+		merge.ChildAccountsMoved = result.RowsAffected
+		result = tx.Model(&models.RecurringJournalLine{}).Where("account_id = ?", merge.SourceAccountID).Update("account_id", merge.TargetAccountID)
+		if result.Error != nil {
+			return result.Error
+		}
+		merge.TemplateLinesMoved = result.RowsAffected
+		result = tx.Model(&models.BankAccount{}).Where("chart_of_account_id = ?", merge.SourceAccountID).Update("chart_of_account_id", merge.TargetAccountID)
+		if result.Error != nil {
+			return result.Error
+		}
+		merge.BankAccountsMoved = result.RowsAffected
+		// Tax codes keep posting their tax to the same, merged, account.
+		result = tx.Model(&models.TaxCode{}).Where("tax_account_id = ?", merge.SourceAccountID).Update("tax_account_id", merge.TargetAccountID)
+		if result.Error != nil {
+			return result.Error
+		}
+		merge.TaxCodesMoved = result.RowsAffected
+		result = tx.Model(&models.TaxCode{}).Where("reverse_charge_account_id = ?", merge.SourceAccountID).Update("reverse_charge_account_id", merge.TargetAccountID)
+		if result.Error != nil {
+			return result.Error
+		}
+		merge.TaxCodesMoved += result.RowsAffected
+
+		if err := tx.Model(&models.ChartOfAccount{}).Where("id = ?", merge.SourceAccountID).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		return tx.Create(merge).Error
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Error merging chart of account %s into %s: %v", merge.SourceAccountCode, merge.TargetAccountCode, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to merge chart of account %s into %s", merge.SourceAccountCode, merge.TargetAccountCode), err)
+	}
+	logger.InfoLogger.Printf("Repository: Merged chart of account %s into %s, moving %d journal lines", merge.SourceAccountCode, merge.TargetAccountCode, merge.JournalLinesMoved)
+	return merge, nil
+}
+
+// ListMerges returns the merges accountID took part in, as source or target, latest first.
+func (r *gormChartOfAccountRepository) ListMerges(ctx context.Context, accountID uuid.UUID) ([]*models.AccountMerge, error) {
+	var merges []*models.AccountMerge
+	err := r.db.WithContext(ctx).Where("source_account_id = ? OR target_account_id = ?", accountID, accountID).Order("created_at desc").Find(&merges).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing merges of chart of account %s: %v", accountID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to list merges of chart of account %s", accountID), err)
+	}
+	return merges, nil
+}
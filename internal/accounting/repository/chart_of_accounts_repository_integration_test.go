@@ -6,7 +6,9 @@ import (
 	"erp-system/internal/accounting/repository"
 	// Use the global dbInstance from the setup file in the same package (accounting_test)
 	// "erp-system/internal/accounting" // This would be if setup was in a different package
+	"erp-system/pkg/money"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	// "github.com/stretchr/testify/assert" // REMOVED - suite provides assertions
@@ -26,9 +28,9 @@ type ChartOfAccountRepositoryIntegrationTestSuite struct {
 // SetupSuite runs once before all tests in the suite.
 func (s *ChartOfAccountRepositoryIntegrationTestSuite) SetupSuite() {
 	s.T().Log("Setting up suite for ChartOfAccountRepository integration tests...")
-	s.db = dbInstance_acc_repo // Use the DB instance from the local setup
+	s.ctx = companyCtx_acc_repo()
+	s.db = dbInstance_acc_repo.WithContext(s.ctx) // Use the DB instance from the local setup
 	s.repo = repository.NewChartOfAccountRepository(s.db)
-	s.ctx = context.Background()
 	s.T().Log("Suite setup complete.")
 }
 
@@ -271,6 +273,76 @@ func (s *ChartOfAccountRepositoryIntegrationTestSuite) TestParentChildRelationsh
 	s.Equal(createdParent.ID, *fetchedChild.ParentAccountID)
 }
 
+// TestCreateAll tests that accounts are created together, keeping inactive ones inactive, or not at all.
+func (s *ChartOfAccountRepositoryIntegrationTestSuite) TestCreateAll() {
+	parent := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "B100", AccountName: "Batch Parent", AccountType: models.Asset, IsActive: true}
+	child := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "B110", AccountName: "Batch Child", AccountType: models.Asset, ParentAccountID: &parent.ID}
+	s.Require().NoError(s.repo.CreateAll(s.ctx, []*models.ChartOfAccount{parent, child}))
+
+	fetchedChild, err := s.repo.GetByCode(s.ctx, "B110")
+	s.Require().NoError(err)
+	s.False(fetchedChild.IsActive)
+	s.Require().NotNil(fetchedChild.ParentAccountID)
+	s.Equal(parent.ID, *fetchedChild.ParentAccountID)
+
+	fresh := &models.ChartOfAccount{AccountCode: "B200", AccountName: "Batch Fresh", AccountType: models.Asset, IsActive: true}
+	duplicate := &models.ChartOfAccount{AccountCode: "B100", AccountName: "Batch Duplicate", AccountType: models.Asset, IsActive: true}
+	s.Error(s.repo.CreateAll(s.ctx, []*models.ChartOfAccount{fresh, duplicate}))
+	_, err = s.repo.GetByCode(s.ctx, "B200")
+	s.Error(err, "the batch is rolled back when one account fails")
+}
+
+// TestMergeAccounts tests that a merge moves lines, children and tax code accounts to the target,
+// deactivates the source and leaves an audit record.
+func (s *ChartOfAccountRepositoryIntegrationTestSuite) TestMergeAccounts() {
+	target := &models.ChartOfAccount{AccountCode: "M100", AccountName: "Bank", AccountType: models.Asset, IsActive: true}
+	source := &models.ChartOfAccount{AccountCode: "M101", AccountName: "Bank (duplicate)", AccountType: models.Asset, IsActive: true}
+	revenue := &models.ChartOfAccount{AccountCode: "M400", AccountName: "Revenue", AccountType: models.Revenue, IsActive: true}
+	s.Require().NoError(s.repo.CreateAll(s.ctx, []*models.ChartOfAccount{target, source, revenue}))
+	child := &models.ChartOfAccount{AccountCode: "M102", AccountName: "Bank sub-account", AccountType: models.Asset, IsActive: true, ParentAccountID: &source.ID}
+	_, err := s.repo.Create(s.ctx, child)
+	s.Require().NoError(err)
+	entry := &models.JournalEntry{
+		EntryDate: time.Now(), Description: "Deposit", Status: models.StatusPosted,
+		JournalLines: []models.JournalLine{
+			{AccountID: source.ID, Amount: money.MustParse("25.00"), IsDebit: true},
+			{AccountID: revenue.ID, Amount: money.MustParse("25.00"), IsDebit: false},
+		},
+	}
+	_, err = repository.NewJournalEntryRepository(s.db).Create(s.ctx, entry)
+	s.Require().NoError(err)
+	taxCode, err := repository.NewTaxRepository(s.db).CreateTaxCode(s.ctx, &models.TaxCode{
+		Code: "M-IN", Name: "Input tax", TaxType: models.TaxTypeInput, TaxAccountID: source.ID, IsActive: true,
+	})
+	s.Require().NoError(err)
+
+	merge, err := s.repo.MergeAccounts(s.ctx, &models.AccountMerge{
+		SourceAccountID: source.ID, SourceAccountCode: source.AccountCode,
+		TargetAccountID: target.ID, TargetAccountCode: target.AccountCode, MergedBy: "tester",
+	})
+	s.Require().NoError(err)
+	s.Equal(int64(1), merge.JournalLinesMoved)
+	s.Equal(int64(1), merge.ChildAccountsMoved)
+	s.Equal(int64(1), merge.TaxCodesMoved)
+
+	var lines int64
+	s.NoError(s.db.Model(&models.JournalLine{}).Where("account_id = ?", target.ID).Count(&lines).Error)
+	s.Equal(int64(1), lines)
+	fetchedChild, err := s.repo.GetByID(s.ctx, child.ID)
+	s.Require().NoError(err)
+	s.Equal(target.ID, *fetchedChild.ParentAccountID)
+	fetchedSource, err := s.repo.GetByID(s.ctx, source.ID)
+	s.Require().NoError(err)
+	s.False(fetchedSource.IsActive)
+	var fetchedTaxCode models.TaxCode
+	s.Require().NoError(s.db.First(&fetchedTaxCode, "id = ?", taxCode.ID).Error)
+	s.Equal(target.ID, fetchedTaxCode.TaxAccountID, "Taxed lines must keep posting to an active account")
+
+	merges, err := s.repo.ListMerges(s.ctx, target.ID)
+	s.NoError(err)
+	s.Len(merges, 1)
+}
+
 // TestUniqueAccountCodeConstraint tests the unique constraint on account_code.
 func (s *ChartOfAccountRepositoryIntegrationTestSuite) TestUniqueAccountCodeConstraint() {
 	s.T().Log("Running TestUniqueAccountCodeConstraint")
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConsolidationRepository defines the interface for database operations for the group chart of
+// accounts, the mappings of each company's accounts to it, and the intercompany elimination rules.
+// Group accounts and rules are shared by all companies; mappings belong to the active company.
+type ConsolidationRepository interface {
+	CreateGroupAccount(ctx context.Context, account *models.GroupAccount) (*models.GroupAccount, error)
+	GetGroupAccount(ctx context.Context, id uuid.UUID) (*models.GroupAccount, error)
+	GetGroupAccountByCode(ctx context.Context, code string) (*models.GroupAccount, error)
+	ListGroupAccounts(ctx context.Context) ([]*models.GroupAccount, error)
+	UpdateGroupAccount(ctx context.Context, account *models.GroupAccount) (*models.GroupAccount, error)
+	ListMappings(ctx context.Context) ([]*models.GroupAccountMapping, error)
+	SaveMapping(ctx context.Context, mapping *models.GroupAccountMapping) (*models.GroupAccountMapping, error)
+	DeleteMapping(ctx context.Context, accountID uuid.UUID) error
+	CreateEliminationRule(ctx context.Context, rule *models.EliminationRule) (*models.EliminationRule, error)
+	GetEliminationRule(ctx context.Context, id uuid.UUID) (*models.EliminationRule, error)
+	ListEliminationRules(ctx context.Context) ([]*models.EliminationRule, error)
+	UpdateEliminationRule(ctx context.Context, rule *models.EliminationRule) (*models.EliminationRule, error)
+}
+
+// gormConsolidationRepository is an implementation of ConsolidationRepository using GORM.
+type gormConsolidationRepository struct {
+	db *gorm.DB
+}
+
+// NewConsolidationRepository creates a new GORM-based ConsolidationRepository.
+func NewConsolidationRepository(db *gorm.DB) ConsolidationRepository {
+	return &gormConsolidationRepository{db: db}
+}
+
+func (r *gormConsolidationRepository) CreateGroupAccount(ctx context.Context, account *models.GroupAccount) (*models.GroupAccount, error) {
+	logger.InfoLogger.Printf("Repository: Creating group account %s", account.Code)
+	if err := r.db.WithContext(ctx).Create(account).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating group account %s: %v", account.Code, err)
+		return nil, errors.NewInternalServerError("failed to create group account", err)
+	}
+	return account, nil
+}
+
+func (r *gormConsolidationRepository) GetGroupAccount(ctx context.Context, id uuid.UUID) (*models.GroupAccount, error) {
+	var account models.GroupAccount
+	if err := r.db.WithContext(ctx).First(&account, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("group_account", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving group account %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get group account %s", id), err)
+	}
+	return &account, nil
+}
+
+func (r *gormConsolidationRepository) GetGroupAccountByCode(ctx context.Context, code string) (*models.GroupAccount, error) {
+	var account models.GroupAccount
+	if err := r.db.WithContext(ctx).First(&account, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("group_account_code", code)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving group account %s: %v", code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get group account %s", code), err)
+	}
+	return &account, nil
+}
+
+// ListGroupAccounts returns the whole group chart ordered by code, inactive accounts included.
+func (r *gormConsolidationRepository) ListGroupAccounts(ctx context.Context) ([]*models.GroupAccount, error) {
+	var accounts []*models.GroupAccount
+	if err := r.db.WithContext(ctx).Order("code asc").Find(&accounts).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing group accounts: %v", err)
+		return nil, errors.NewInternalServerError("failed to list group accounts", err)
+	}
+	return accounts, nil
+}
+
+func (r *gormConsolidationRepository) UpdateGroupAccount(ctx context.Context, account *models.GroupAccount) (*models.GroupAccount, error) {
+	logger.InfoLogger.Printf("Repository: Updating group account %s", account.Code)
+	if err := r.db.WithContext(ctx).Save(account).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating group account %s: %v", account.Code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update group account %s", account.Code), err)
+	}
+	return account, nil
+}
+
+// ListMappings returns the active company's mappings with their group accounts.
+func (r *gormConsolidationRepository) ListMappings(ctx context.Context) ([]*models.GroupAccountMapping, error) {
+	var mappings []*models.GroupAccountMapping
+	if err := r.db.WithContext(ctx).Preload("GroupAccount").Find(&mappings).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing group account mappings: %v", err)
+		return nil, errors.NewInternalServerError("failed to list group account mappings", err)
+	}
+	return mappings, nil
+}
+
+// SaveMapping creates the mapping of mapping.AccountID or points the existing one at
+// mapping.GroupAccountID.
+func (r *gormConsolidationRepository) SaveMapping(ctx context.Context, mapping *models.GroupAccountMapping) (*models.GroupAccountMapping, error) {
+	logger.InfoLogger.Printf("Repository: Mapping account %s to group account %s", mapping.AccountID, mapping.GroupAccountID)
+	err := r.db.WithContext(ctx).Omit("GroupAccount").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}, {Name: "account_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"group_account_id", "updated_at"}),
+	}).Create(mapping).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error mapping account %s: %v", mapping.AccountID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to map account %s", mapping.AccountID), err)
+	}
+	var saved models.GroupAccountMapping
+	if err := r.db.WithContext(ctx).Preload("GroupAccount").First(&saved, "account_id = ?", mapping.AccountID).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error retrieving mapping of account %s: %v", mapping.AccountID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get mapping of account %s", mapping.AccountID), err)
+	}
+	return &saved, nil
+}
+
+// DeleteMapping removes the mapping of an account. It returns a NotFoundError if there is none.
+func (r *gormConsolidationRepository) DeleteMapping(ctx context.Context, accountID uuid.UUID) error {
+	logger.InfoLogger.Printf("Repository: Deleting group account mapping of account %s", accountID)
+	result := r.db.WithContext(ctx).Where("account_id = ?", accountID).Delete(&models.GroupAccountMapping{})
+	if result.Error != nil {
+		logger.ErrorLogger.Printf("Repository: Error deleting mapping of account %s: %v", accountID, result.Error)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to delete mapping of account %s", accountID), result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("group_account_mapping", accountID.String())
+	}
+	return nil
+}
+
+func (r *gormConsolidationRepository) CreateEliminationRule(ctx context.Context, rule *models.EliminationRule) (*models.EliminationRule, error) {
+	logger.InfoLogger.Printf("Repository: Creating elimination rule %s", rule.Name)
+	if err := r.db.WithContext(ctx).Create(rule).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating elimination rule %s: %v", rule.Name, err)
+		return nil, errors.NewInternalServerError("failed to create elimination rule", err)
+	}
+	return rule, nil
+}
+
+func (r *gormConsolidationRepository) GetEliminationRule(ctx context.Context, id uuid.UUID) (*models.EliminationRule, error) {
+	var rule models.EliminationRule
+	if err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("elimination_rule", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving elimination rule %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get elimination rule %s", id), err)
+	}
+	return &rule, nil
+}
+
+// ListEliminationRules returns every rule ordered by name, inactive ones included.
+func (r *gormConsolidationRepository) ListEliminationRules(ctx context.Context) ([]*models.EliminationRule, error) {
+	var rules []*models.EliminationRule
+	if err := r.db.WithContext(ctx).Order("name asc").Find(&rules).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing elimination rules: %v", err)
+		return nil, errors.NewInternalServerError("failed to list elimination rules", err)
+	}
+	return rules, nil
+}
+
+func (r *gormConsolidationRepository) UpdateEliminationRule(ctx context.Context, rule *models.EliminationRule) (*models.EliminationRule, error) {
+	logger.InfoLogger.Printf("Repository: Updating elimination rule %s", rule.Name)
+	if err := r.db.WithContext(ctx).Save(rule).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating elimination rule %s: %v", rule.Name, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update elimination rule %s", rule.Name), err)
+	}
+	return rule, nil
+}
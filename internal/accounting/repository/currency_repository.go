@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CurrencyRepository defines the interface for database operations for the currency master and
+// the dated exchange-rate table.
+type CurrencyRepository interface {
+	CreateCurrency(ctx context.Context, currency *models.Currency) (*models.Currency, error)
+	GetCurrency(ctx context.Context, code string) (*models.Currency, error)
+	ListCurrencies(ctx context.Context) ([]*models.Currency, error)
+	UpdateCurrency(ctx context.Context, currency *models.Currency) (*models.Currency, error)
+	SaveExchangeRates(ctx context.Context, rates []*models.ExchangeRate) error
+	GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, on time.Time) (*models.ExchangeRate, error)
+	ListExchangeRates(ctx context.Context, fromCurrency, toCurrency string, startDate, endDate time.Time) ([]*models.ExchangeRate, error)
+}
+
+// gormCurrencyRepository is an implementation of CurrencyRepository using GORM.
+type gormCurrencyRepository struct {
+	db *gorm.DB
+}
+
+// NewCurrencyRepository creates a new GORM-based CurrencyRepository.
+func NewCurrencyRepository(db *gorm.DB) CurrencyRepository {
+	return &gormCurrencyRepository{db: db}
+}
+
+func (r *gormCurrencyRepository) CreateCurrency(ctx context.Context, currency *models.Currency) (*models.Currency, error) {
+	logger.InfoLogger.Printf("Repository: Creating currency %s", currency.Code)
+	if err := r.db.WithContext(ctx).Create(currency).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating currency %s: %v", currency.Code, err)
+		return nil, errors.NewInternalServerError("failed to create currency", err)
+	}
+	return currency, nil
+}
+
+func (r *gormCurrencyRepository) GetCurrency(ctx context.Context, code string) (*models.Currency, error) {
+	var currency models.Currency
+	if err := r.db.WithContext(ctx).First(&currency, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("currency", code)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving currency %s: %v", code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get currency %s", code), err)
+	}
+	return &currency, nil
+}
+
+func (r *gormCurrencyRepository) ListCurrencies(ctx context.Context) ([]*models.Currency, error) {
+	var currencies []*models.Currency
+	if err := r.db.WithContext(ctx).Order("code asc").Find(&currencies).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing currencies: %v", err)
+		return nil, errors.NewInternalServerError("failed to list currencies", err)
+	}
+	return currencies, nil
+}
+
+func (r *gormCurrencyRepository) UpdateCurrency(ctx context.Context, currency *models.Currency) (*models.Currency, error) {
+	logger.InfoLogger.Printf("Repository: Updating currency %s", currency.Code)
+	if err := r.db.WithContext(ctx).Save(currency).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating currency %s: %v", currency.Code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update currency %s", currency.Code), err)
+	}
+	return currency, nil
+}
+
+// SaveExchangeRates inserts the rates in one transaction. A rate for a pair and date that already
+// exists is replaced, so re-importing a corrected file is safe.
+func (r *gormCurrencyRepository) SaveExchangeRates(ctx context.Context, rates []*models.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+	logger.InfoLogger.Printf("Repository: Saving %d exchange rates", len(rates))
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "from_currency"}, {Name: "to_currency"}, {Name: "rate_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).CreateInBatches(rates, 500).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error saving exchange rates: %v", err)
+		return errors.NewInternalServerError("failed to save exchange rates", err)
+	}
+	return nil
+}
+
+// GetExchangeRate returns the latest rate for the pair dated on or before the given date. It
+// returns a NotFoundError if there is none.
+func (r *gormCurrencyRepository) GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, on time.Time) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := r.db.WithContext(ctx).
+		Where("from_currency = ? AND to_currency = ? AND rate_date <= ?", fromCurrency, toCurrency, on.Format("2006-01-02")).
+		Order("rate_date desc").
+		First(&rate).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("exchange_rate", fmt.Sprintf("%s/%s on %s", fromCurrency, toCurrency, on.Format("2006-01-02")))
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving %s/%s exchange rate on %s: %v", fromCurrency, toCurrency, on.Format("2006-01-02"), err)
+		return nil, errors.NewInternalServerError("failed to get exchange rate", err)
+	}
+	return &rate, nil
+}
+
+// ListExchangeRates returns rates ordered by pair and date. Empty currencies and zero dates are not
+// used as filters.
+func (r *gormCurrencyRepository) ListExchangeRates(ctx context.Context, fromCurrency, toCurrency string, startDate, endDate time.Time) ([]*models.ExchangeRate, error) {
+	var rates []*models.ExchangeRate
+	query := r.db.WithContext(ctx)
+	if fromCurrency != "" {
+		query = query.Where("from_currency = ?", fromCurrency)
+	}
+	if toCurrency != "" {
+		query = query.Where("to_currency = ?", toCurrency)
+	}
+	if !startDate.IsZero() {
+		query = query.Where("rate_date >= ?", startDate.Format("2006-01-02"))
+	}
+	if !endDate.IsZero() {
+		query = query.Where("rate_date <= ?", endDate.Format("2006-01-02"))
+	}
+	if err := query.Order("from_currency asc, to_currency asc, rate_date asc").Find(&rates).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing exchange rates: %v", err)
+		return nil, errors.NewInternalServerError("failed to list exchange rates", err)
+	}
+	return rates, nil
+}
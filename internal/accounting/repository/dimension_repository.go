@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DimensionRepository defines the interface for database operations for analytical dimensions,
+// their value lists and the dimensions accounts require.
+type DimensionRepository interface {
+	CreateDimension(ctx context.Context, dimension *models.Dimension) (*models.Dimension, error)
+	GetDimension(ctx context.Context, id uuid.UUID) (*models.Dimension, error)
+	GetDimensionByCode(ctx context.Context, code string) (*models.Dimension, error)
+	ListDimensions(ctx context.Context) ([]*models.Dimension, error)
+	UpdateDimension(ctx context.Context, dimension *models.Dimension) (*models.Dimension, error)
+	CreateValue(ctx context.Context, value *models.DimensionValue) (*models.DimensionValue, error)
+	GetValue(ctx context.Context, id uuid.UUID) (*models.DimensionValue, error)
+	UpdateValue(ctx context.Context, value *models.DimensionValue) (*models.DimensionValue, error)
+	ListAccountRules(ctx context.Context, accountIDs []uuid.UUID) ([]models.AccountDimensionRule, error)
+	SetAccountRules(ctx context.Context, accountID uuid.UUID, dimensionIDs []uuid.UUID) error
+}
+
+// gormDimensionRepository is an implementation of DimensionRepository using GORM.
+type gormDimensionRepository struct {
+	db *gorm.DB
+}
+
+// NewDimensionRepository creates a new GORM-based DimensionRepository.
+func NewDimensionRepository(db *gorm.DB) DimensionRepository {
+	return &gormDimensionRepository{db: db}
+}
+
+// preloadValues loads a dimension's values ordered by code.
+func preloadValues(db *gorm.DB) *gorm.DB {
+	return db.Order("code asc")
+}
+
+func (r *gormDimensionRepository) CreateDimension(ctx context.Context, dimension *models.Dimension) (*models.Dimension, error) {
+	logger.InfoLogger.Printf("Repository: Creating dimension %s", dimension.Code)
+	if err := r.db.WithContext(ctx).Create(dimension).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating dimension %s: %v", dimension.Code, err)
+		return nil, errors.NewInternalServerError("failed to create dimension", err)
+	}
+	return dimension, nil
+}
+
+func (r *gormDimensionRepository) GetDimension(ctx context.Context, id uuid.UUID) (*models.Dimension, error) {
+	var dimension models.Dimension
+	if err := r.db.WithContext(ctx).Preload("Values", preloadValues).First(&dimension, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("dimension", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving dimension %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get dimension %s", id), err)
+	}
+	return &dimension, nil
+}
+
+func (r *gormDimensionRepository) GetDimensionByCode(ctx context.Context, code string) (*models.Dimension, error) {
+	var dimension models.Dimension
+	if err := r.db.WithContext(ctx).Preload("Values", preloadValues).First(&dimension, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("dimension_code", code)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving dimension %s: %v", code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get dimension %s", code), err)
+	}
+	return &dimension, nil
+}
+
+// ListDimensions returns every dimension with its values, ordered by code.
+func (r *gormDimensionRepository) ListDimensions(ctx context.Context) ([]*models.Dimension, error) {
+	var dimensions []*models.Dimension
+	if err := r.db.WithContext(ctx).Preload("Values", preloadValues).Order("code asc").Find(&dimensions).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing dimensions: %v", err)
+		return nil, errors.NewInternalServerError("failed to list dimensions", err)
+	}
+	return dimensions, nil
+}
+
+// UpdateDimension saves the dimension's own fields; its values are saved through UpdateValue.
+func (r *gormDimensionRepository) UpdateDimension(ctx context.Context, dimension *models.Dimension) (*models.Dimension, error) {
+	logger.InfoLogger.Printf("Repository: Updating dimension %s", dimension.Code)
+	if err := r.db.WithContext(ctx).Omit("Values").Save(dimension).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating dimension %s: %v", dimension.Code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update dimension %s", dimension.Code), err)
+	}
+	return dimension, nil
+}
+
+func (r *gormDimensionRepository) CreateValue(ctx context.Context, value *models.DimensionValue) (*models.DimensionValue, error) {
+	logger.InfoLogger.Printf("Repository: Creating value %s of dimension %s", value.Code, value.DimensionID)
+	if err := r.db.WithContext(ctx).Create(value).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating value %s of dimension %s: %v", value.Code, value.DimensionID, err)
+		return nil, errors.NewInternalServerError("failed to create dimension value", err)
+	}
+	return value, nil
+}
+
+func (r *gormDimensionRepository) GetValue(ctx context.Context, id uuid.UUID) (*models.DimensionValue, error) {
+	var value models.DimensionValue
+	if err := r.db.WithContext(ctx).First(&value, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("dimension_value", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving dimension value %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get dimension value %s", id), err)
+	}
+	return &value, nil
+}
+
+func (r *gormDimensionRepository) UpdateValue(ctx context.Context, value *models.DimensionValue) (*models.DimensionValue, error) {
+	logger.InfoLogger.Printf("Repository: Updating dimension value %s", value.ID)
+	if err := r.db.WithContext(ctx).Save(value).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating dimension value %s: %v", value.ID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update dimension value %s", value.ID), err)
+	}
+	return value, nil
+}
+
+// ListAccountRules returns the dimensions required on the given accounts. An empty accountIDs
+// returns every rule.
+func (r *gormDimensionRepository) ListAccountRules(ctx context.Context, accountIDs []uuid.UUID) ([]models.AccountDimensionRule, error) {
+	var rules []models.AccountDimensionRule
+	query := r.db.WithContext(ctx)
+	if len(accountIDs) > 0 {
+		query = query.Where("account_id IN ?", accountIDs)
+	}
+	if err := query.Find(&rules).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing account dimension rules: %v", err)
+		return nil, errors.NewInternalServerError("failed to list account dimension rules", err)
+	}
+	return rules, nil
+}
+
+// SetAccountRules replaces the dimensions required on an account in one transaction.
+func (r *gormDimensionRepository) SetAccountRules(ctx context.Context, accountID uuid.UUID, dimensionIDs []uuid.UUID) error {
+	logger.InfoLogger.Printf("Repository: Setting %d required dimensions on account %s", len(dimensionIDs), accountID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("account_id = ?", accountID).Delete(&models.AccountDimensionRule{}).Error; err != nil {
+			return err
+		}
+		if len(dimensionIDs) == 0 {
+			return nil
+		}
+		rules := make([]models.AccountDimensionRule, len(dimensionIDs))
+		for i, id := range dimensionIDs {
+			rules[i] = models.AccountDimensionRule{AccountID: accountID, DimensionID: id}
+		}
+		return tx.Create(&rules).Error
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error setting required dimensions on account %s: %v", accountID, err)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to set required dimensions on account %s", accountID), err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/company"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentSequenceRepository defines the interface for database operations for document sequences.
+// Numbers are taken with NextDocumentNumber inside the transaction that saves the document.
+type DocumentSequenceRepository interface {
+	GetSequence(ctx context.Context, documentType string) (*models.DocumentSequence, error)
+	ListSequences(ctx context.Context) ([]*models.DocumentSequence, error)
+	SaveSequence(ctx context.Context, sequence *models.DocumentSequence) (*models.DocumentSequence, error)
+	ListCounters(ctx context.Context, documentType string) ([]*models.DocumentSequenceCounter, error)
+}
+
+// gormDocumentSequenceRepository is an implementation of DocumentSequenceRepository using GORM.
+type gormDocumentSequenceRepository struct {
+	db *gorm.DB
+}
+
+// NewDocumentSequenceRepository creates a new GORM-based DocumentSequenceRepository.
+func NewDocumentSequenceRepository(db *gorm.DB) DocumentSequenceRepository {
+	return &gormDocumentSequenceRepository{db: db}
+}
+
+// GetSequence returns the configured sequence of documentType, or its default if it has none.
+func (r *gormDocumentSequenceRepository) GetSequence(ctx context.Context, documentType string) (*models.DocumentSequence, error) {
+	sequence, err := findDocumentSequence(r.db.WithContext(ctx), documentType)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("document_sequence", documentType)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving document sequence %s: %v", documentType, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get document sequence %s", documentType), err)
+	}
+	return sequence, nil
+}
+
+// ListSequences returns the configured sequences together with the defaults not yet configured.
+func (r *gormDocumentSequenceRepository) ListSequences(ctx context.Context) ([]*models.DocumentSequence, error) {
+	var sequences []*models.DocumentSequence
+	if err := r.db.WithContext(ctx).Order("document_type asc").Find(&sequences).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing document sequences: %v", err)
+		return nil, errors.NewInternalServerError("failed to list document sequences", err)
+	}
+	configured := make(map[string]bool, len(sequences))
+	for _, sequence := range sequences {
+		configured[sequence.DocumentType] = true
+	}
+	for documentType, sequence := range models.DefaultDocumentSequences {
+		if !configured[documentType] {
+			sequence := sequence
+			sequences = append(sequences, &sequence)
+		}
+	}
+	sort.Slice(sequences, func(i, j int) bool { return sequences[i].DocumentType < sequences[j].DocumentType })
+	return sequences, nil
+}
+
+// SaveSequence creates or replaces the configuration of sequence.DocumentType. Its counters are kept.
+func (r *gormDocumentSequenceRepository) SaveSequence(ctx context.Context, sequence *models.DocumentSequence) (*models.DocumentSequence, error) {
+	logger.InfoLogger.Printf("Repository: Saving document sequence %s", sequence.DocumentType)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "company_id"}, {Name: "document_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"prefix", "padding", "reset_yearly", "updated_at"}),
+	}).Create(sequence).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error saving document sequence %s: %v", sequence.DocumentType, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to save document sequence %s", sequence.DocumentType), err)
+	}
+	return r.GetSequence(ctx, sequence.DocumentType)
+}
+
+// ListCounters returns the last number handed out by documentType in each year, latest year first.
+func (r *gormDocumentSequenceRepository) ListCounters(ctx context.Context, documentType string) ([]*models.DocumentSequenceCounter, error) {
+	var counters []*models.DocumentSequenceCounter
+	if err := r.db.WithContext(ctx).Where("document_type = ?", documentType).Order("year desc").Find(&counters).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing counters of document sequence %s: %v", documentType, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to list counters of document sequence %s", documentType), err)
+	}
+	return counters, nil
+}
+
+// NextDocumentNumber takes the next number of documentType for a document dated on date, within
+// the caller's transaction tx. The counter row stays locked until tx ends, so concurrent callers
+// wait for each other, and a rolled-back transaction gives its number back: numbers have no gaps.
+// Sequences that reset yearly count per fiscal year, named after the calendar year it ends in, or
+// per calendar year for dates outside any fiscal year. Each company numbers its documents on its
+// own; the active company is taken from tx's context.
+func NextDocumentNumber(tx *gorm.DB, documentType string, date time.Time) (string, error) {
+	companyID, err := company.IDFromContext(tx.Statement.Context)
+	if err != nil {
+		return "", err
+	}
+	sequence, err := findDocumentSequence(tx, documentType)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", errors.NewValidationError(fmt.Sprintf("no document sequence is configured for %s", documentType), "document_type")
+		}
+		return "", err
+	}
+	year := 0
+	if sequence.ResetYearly {
+		if year, err = documentYear(tx, date); err != nil {
+			return "", err
+		}
+	}
+	var number int64
+	err = tx.Raw(`INSERT INTO document_sequence_counters (company_id, document_type, year, last_number, updated_at) VALUES (?, ?, ?, 1, ?)
+ON CONFLICT (company_id, document_type, year) DO UPDATE SET last_number = document_sequence_counters.last_number + 1, updated_at = EXCLUDED.updated_at
+RETURNING last_number`, companyID, documentType, year, time.Now()).Scan(&number).Error
+	if err != nil {
+		return "", err
+	}
+	return sequence.Format(year, number), nil
+}
+
+// numberJournalEntry gives a journal entry that is saved as POSTED its document number within tx.
+// Entries that already have a number keep it. An empty status is saved as POSTED by the model.
+func numberJournalEntry(tx *gorm.DB, entry *models.JournalEntry) error {
+	if entry.DocumentNumber != "" || (entry.Status != models.StatusPosted && entry.Status != "") {
+		return nil
+	}
+	date := entry.EntryDate
+	if date.IsZero() {
+		date = time.Now()
+	}
+	number, err := NextDocumentNumber(tx, entry.DocumentType(), date)
+	if err != nil {
+		return err
+	}
+	entry.DocumentNumber = number
+	return nil
+}
+
+// findDocumentSequence loads the sequence of documentType, falling back to its default.
+func findDocumentSequence(db *gorm.DB, documentType string) (*models.DocumentSequence, error) {
+	var sequence models.DocumentSequence
+	err := db.First(&sequence, "document_type = ?", documentType).Error
+	if err == gorm.ErrRecordNotFound {
+		if fallback, ok := models.DefaultDocumentSequences[documentType]; ok {
+			return &fallback, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sequence, nil
+}
+
+// documentYear returns the year that numbers documents dated on date.
+func documentYear(db *gorm.DB, date time.Time) (int, error) {
+	var fiscalYears []models.FiscalYear
+	day := date.Format("2006-01-02")
+	if err := db.Where("start_date <= ? AND end_date >= ?", day, day).Limit(1).Find(&fiscalYears).Error; err != nil {
+		return 0, err
+	}
+	if len(fiscalYears) == 0 {
+		return date.Year(), nil
+	}
+	return fiscalYears[0].EndDate.Year(), nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FiscalPeriodRepository defines the interface for database operations for fiscal years and their periods.
+type FiscalPeriodRepository interface {
+	CreateFiscalYear(ctx context.Context, year *models.FiscalYear) (*models.FiscalYear, error)
+	GetFiscalYearByID(ctx context.Context, id uuid.UUID) (*models.FiscalYear, error)
+	ListFiscalYears(ctx context.Context) ([]*models.FiscalYear, error)
+	FindOverlappingFiscalYears(ctx context.Context, startDate, endDate time.Time) ([]*models.FiscalYear, error)
+	GetPeriodByID(ctx context.Context, id uuid.UUID) (*models.FiscalPeriod, error)
+	GetPeriodForDate(ctx context.Context, date time.Time) (*models.FiscalPeriod, error)
+	UpdatePeriodStatus(ctx context.Context, id uuid.UUID, status models.PeriodStatus) error
+	MarkFiscalYearClosed(ctx context.Context, id uuid.UUID, closingEntry *models.JournalEntry, closedAt time.Time) error
+	MarkFiscalYearReopened(ctx context.Context, id uuid.UUID, closingReversal *models.JournalEntry, reason string, periodStatus models.PeriodStatus, reopenedAt time.Time) error
+}
+
+// gormFiscalPeriodRepository is an implementation of FiscalPeriodRepository using GORM.
+type gormFiscalPeriodRepository struct {
+	db *gorm.DB
+}
+
+// NewFiscalPeriodRepository creates a new GORM-based FiscalPeriodRepository.
+func NewFiscalPeriodRepository(db *gorm.DB) FiscalPeriodRepository {
+	return &gormFiscalPeriodRepository{db: db}
+}
+
+// CreateFiscalYear inserts a fiscal year together with its periods in one transaction.
+func (r *gormFiscalPeriodRepository) CreateFiscalYear(ctx context.Context, year *models.FiscalYear) (*models.FiscalYear, error) {
+	logger.InfoLogger.Printf("Repository: Attempting to create fiscal year %s with %d periods", year.Name, len(year.Periods))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(year).Error // Periods are created through the association
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating fiscal year %s: %v", year.Name, err)
+		return nil, errors.NewInternalServerError("failed to create fiscal year", err)
+	}
+	logger.InfoLogger.Printf("Repository: Successfully created fiscal year with ID: %s", year.ID)
+	return year, nil
+}
+
+// GetFiscalYearByID retrieves a fiscal year and its periods ordered by period number.
+func (r *gormFiscalPeriodRepository) GetFiscalYearByID(ctx context.Context, id uuid.UUID) (*models.FiscalYear, error) {
+	var year models.FiscalYear
+	err := r.db.WithContext(ctx).
+		Preload("Periods", func(db *gorm.DB) *gorm.DB { return db.Order("period_number asc") }).
+		First(&year, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			logger.WarnLogger.Printf("Repository: Fiscal year with ID %s not found", id)
+			return nil, errors.NewNotFoundError("fiscal_year", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving fiscal year by ID %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get fiscal year by ID %s", id), err)
+	}
+	return &year, nil
+}
+
+// ListFiscalYears returns all fiscal years with their periods, most recent first.
+func (r *gormFiscalPeriodRepository) ListFiscalYears(ctx context.Context) ([]*models.FiscalYear, error) {
+	var years []*models.FiscalYear
+	err := r.db.WithContext(ctx).
+		Preload("Periods", func(db *gorm.DB) *gorm.DB { return db.Order("period_number asc") }).
+		Order("start_date desc").
+		Find(&years).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing fiscal years: %v", err)
+		return nil, errors.NewInternalServerError("failed to list fiscal years", err)
+	}
+	return years, nil
+}
+
+// FindOverlappingFiscalYears returns fiscal years that share at least one day with [startDate, endDate].
+func (r *gormFiscalPeriodRepository) FindOverlappingFiscalYears(ctx context.Context, startDate, endDate time.Time) ([]*models.FiscalYear, error) {
+	var years []*models.FiscalYear
+	err := r.db.WithContext(ctx).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Find(&years).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error checking overlapping fiscal years: %v", err)
+		return nil, errors.NewInternalServerError("failed to check overlapping fiscal years", err)
+	}
+	return years, nil
+}
+
+// GetPeriodByID retrieves a single fiscal period.
+func (r *gormFiscalPeriodRepository) GetPeriodByID(ctx context.Context, id uuid.UUID) (*models.FiscalPeriod, error) {
+	var period models.FiscalPeriod
+	if err := r.db.WithContext(ctx).First(&period, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			logger.WarnLogger.Printf("Repository: Fiscal period with ID %s not found", id)
+			return nil, errors.NewNotFoundError("fiscal_period", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving fiscal period by ID %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get fiscal period by ID %s", id), err)
+	}
+	return &period, nil
+}
+
+// GetPeriodForDate returns the fiscal period covering the calendar day of date,
+// or a NotFoundError if no period has been defined for it.
+func (r *gormFiscalPeriodRepository) GetPeriodForDate(ctx context.Context, date time.Time) (*models.FiscalPeriod, error) {
+	day := date.Format("2006-01-02")
+	var period models.FiscalPeriod
+	err := r.db.WithContext(ctx).
+		Where("start_date <= ? AND end_date >= ?", day, day).
+		First(&period).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("fiscal_period_for_date", day)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving fiscal period for date %s: %v", day, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get fiscal period for date %s", day), err)
+	}
+	return &period, nil
+}
+
+// UpdatePeriodStatus sets the status of a fiscal period.
+func (r *gormFiscalPeriodRepository) UpdatePeriodStatus(ctx context.Context, id uuid.UUID, status models.PeriodStatus) error {
+	logger.InfoLogger.Printf("Repository: Updating fiscal period %s status to %s", id, status)
+	result := r.db.WithContext(ctx).Model(&models.FiscalPeriod{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating fiscal period %s status: %v", id, result.Error)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to update status for fiscal period %s", id), result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("fiscal_period", id.String())
+	}
+	return nil
+}
+
+// MarkFiscalYearClosed creates the closing entry (if any), records it on the fiscal year and closes
+// the year and all of its periods in one transaction. It returns a ConflictError if the year is no
+// longer OPEN, so a retried or concurrent close never posts a second closing entry.
+func (r *gormFiscalPeriodRepository) MarkFiscalYearClosed(ctx context.Context, id uuid.UUID, closingEntry *models.JournalEntry, closedAt time.Time) error {
+	logger.InfoLogger.Printf("Repository: Marking fiscal year %s as closed", id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var closingEntryID *uuid.UUID
+		if closingEntry != nil {
+			if err := numberJournalEntry(tx, closingEntry); err != nil {
+				return err
+			}
+			if err := tx.Create(closingEntry).Error; err != nil {
+				return err
+			}
+			closingEntryID = &closingEntry.ID
+		}
+		result := tx.Model(&models.FiscalYear{}).Where("id = ? AND status = ?", id, models.PeriodOpen).Updates(map[string]interface{}{
+			"status":           models.PeriodClosed,
+			"closing_entry_id": closingEntryID,
+			"closed_at":        closedAt,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("fiscal year %s is no longer OPEN", id))
+		}
+		return tx.Model(&models.FiscalPeriod{}).Where("fiscal_year_id = ?", id).Update("status", models.PeriodClosed).Error
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return err
+		}
+		logger.ErrorLogger.Printf("Repository: Error closing fiscal year %s: %v", id, err)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to close fiscal year %s", id), err)
+	}
+	return nil
+}
+
+// MarkFiscalYearReopened voids the year's closing entry (if any) with closingReversal, clears it,
+// reopens the fiscal year and sets all of its periods to periodStatus in one transaction. It returns a
+// ConflictError if the year is no longer CLOSED, so a retried or concurrent reopen never reverses the
+// closing entry twice.
+func (r *gormFiscalPeriodRepository) MarkFiscalYearReopened(ctx context.Context, id uuid.UUID, closingReversal *models.JournalEntry, reason string, periodStatus models.PeriodStatus, reopenedAt time.Time) error {
+	logger.InfoLogger.Printf("Repository: Reopening fiscal year %s with periods %s", id, periodStatus)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var year models.FiscalYear
+		if err := tx.First(&year, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if year.Status != models.PeriodClosed {
+			return errors.NewConflictError(fmt.Sprintf("fiscal year %s is no longer CLOSED", year.Name))
+		}
+		if year.ClosingEntryID != nil {
+			if closingReversal == nil {
+				return errors.NewConflictError(fmt.Sprintf("fiscal year %s has a closing entry to reverse", year.Name))
+			}
+			if err := voidWithReversal(tx, *year.ClosingEntryID, closingReversal, reason, reopenedAt); err != nil {
+				return err
+			}
+		}
+		result := tx.Model(&models.FiscalYear{}).Where("id = ? AND status = ?", id, models.PeriodClosed).Updates(map[string]interface{}{
+			"status":           models.PeriodOpen,
+			"closing_entry_id": nil,
+			"closed_at":        nil,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("fiscal year %s is no longer CLOSED", year.Name))
+		}
+		return tx.Model(&models.FiscalPeriod{}).Where("fiscal_year_id = ?", id).Update("status", periodStatus).Error
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewNotFoundError("fiscal_year", id.String())
+		}
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return err
+		}
+		logger.ErrorLogger.Printf("Repository: Error reopening fiscal year %s: %v", id, err)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to reopen fiscal year %s", id), err)
+	}
+	return nil
+}
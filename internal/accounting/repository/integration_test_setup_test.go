@@ -7,6 +7,7 @@ import (
 	// No need to import inventory models here if accounting repo tests don't directly depend on them for FKs
 	// If they do, then inventory models should be imported and migrated.
 	// For strict separation, only migrate tables relevant to the package being tested.
+	"erp-system/pkg/company"
 	"erp-system/pkg/database"
 	"erp-system/pkg/logger"
 	"fmt"
@@ -28,8 +29,15 @@ var (
 	// Suffix _acc_repo to distinguish if it were ever in a truly global scope with other test DBs
 	dbInstance_acc_repo *gorm.DB
 	testConfig_acc_repo configs.AppConfig
+	// testCompany_acc_repo is the company every accounting repository test works in.
+	testCompany_acc_repo = company.Company{ID: uuid.MustParse("00000000-0000-0000-0000-00000000a001"), Code: "TEST", BaseCurrency: "USD"}
 )
 
+// companyCtx_acc_repo returns a context with the test company active, as the API middleware sets it.
+func companyCtx_acc_repo() context.Context {
+	return company.WithCompany(context.Background(), testCompany_acc_repo)
+}
+
 // setupAccRepoTestDB sets up a PostgreSQL test container for accounting repository tests.
 func setupAccRepoTestDB(ctx context.Context) (*gorm.DB, configs.AppConfig, func(), error) {
 	logger.InfoLogger.Println("Setting up ACCOUNTING REPOSITORY test database container...")
@@ -69,6 +77,32 @@ func setupAccRepoTestDB(ctx context.Context) (*gorm.DB, configs.AppConfig, func(
 		&accModels.ChartOfAccount{},
 		&accModels.JournalEntry{},
 		&accModels.JournalLine{},
+		&accModels.JournalEntryApproval{},
+		&accModels.FiscalYear{},
+		&accModels.FiscalPeriod{},
+		&accModels.ScheduledReversal{},
+		&accModels.RecurringJournalTemplate{},
+		&accModels.RecurringJournalLine{},
+		&accModels.RecurringJournalRun{},
+		&accModels.Currency{},
+		&accModels.ExchangeRate{},
+		&accModels.Dimension{},
+		&accModels.DimensionValue{},
+		&accModels.JournalLineDimension{},
+		&accModels.AccountDimensionRule{},
+		&accModels.Budget{},
+		&accModels.BudgetLine{},
+		&accModels.BankAccount{},
+		&accModels.BankStatement{},
+		&accModels.BankStatementLine{},
+		&accModels.DocumentSequence{},
+		&accModels.DocumentSequenceCounter{},
+		&accModels.AccountMerge{},
+		&accModels.GroupAccount{},
+		&accModels.GroupAccountMapping{},
+		&accModels.EliminationRule{},
+		&accModels.TaxCode{},
+		&accModels.TaxRate{},
 	)
 	if err != nil {
 		sqlDB, _ := gormDB.DB(); if sqlDB != nil { sqlDB.Close() }; pgContainer.Terminate(ctx)
@@ -110,7 +144,7 @@ func TestMain(m *testing.M) {
 // resetAccRepoTables truncates tables relevant to accounting repository tests.
 func resetAccRepoTables(t *testing.T, db *gorm.DB) {
 	t.Helper()
-	tables := []string{"journal_lines", "journal_entries", "chart_of_accounts"}
+	tables := []string{"tax_rates", "tax_codes", "group_account_mappings", "elimination_rules", "group_accounts", "account_merges", "document_sequence_counters", "document_sequences", "bank_statement_lines", "bank_statements", "bank_accounts", "budget_lines", "budgets", "recurring_journal_runs", "recurring_journal_lines", "recurring_journal_templates", "scheduled_reversals", "journal_entry_approvals", "journal_lines", "journal_entries", "chart_of_accounts", "fiscal_periods", "fiscal_years", "exchange_rates", "currencies", "journal_line_dimensions", "account_dimension_rules", "dimension_values", "dimensions"}
 	// Truncate in reverse order of creation or consider FKs
 	for _, table := range tables {
 		err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table)).Error
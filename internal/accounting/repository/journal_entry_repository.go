@@ -5,6 +5,7 @@ import (
 	"erp-system/internal/accounting/models"
 	"erp-system/pkg/errors"
 	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
 	"fmt"
 	"time"
 
@@ -21,12 +22,18 @@ type JournalEntryRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, offset, limit int, filters map[string]interface{}) ([]*models.JournalEntry, int64, error)
 	UpdateJournalEntryStatus(ctx context.Context, id uuid.UUID, newStatus models.JournalStatus) error
+	SaveApprovalStep(ctx context.Context, entry *models.JournalEntry, step *models.JournalEntryApproval) error
 	GetJournalLinesByEntryID(ctx context.Context, journalID uuid.UUID) ([]models.JournalLine, error)
 	AddJournalLine(ctx context.Context, journalID uuid.UUID, line *models.JournalLine) (*models.JournalLine, error)
 	RemoveJournalLine(ctx context.Context, lineID uuid.UUID) error
 	UpdateJournalLine(ctx context.Context, line *models.JournalLine) (*models.JournalLine, error)
 	GetJournalEntriesForTrialBalance(ctx context.Context, startDate, endDate time.Time) ([]models.JournalEntry, error)
 	GetJournalEntriesByAccountID(ctx context.Context, accountID uuid.UUID, offset, limit int, startDate, endDate time.Time) ([]*models.JournalEntry, int64, error)
+	GetAccountBalancesBefore(ctx context.Context, accountIDs []uuid.UUID, before time.Time) (map[uuid.UUID]money.Amount, error)
+	GetCurrencyBalances(ctx context.Context, before time.Time) ([]models.CurrencyBalance, error)
+	GetDimensionBalancesBefore(ctx context.Context, accountIDs []uuid.UUID, before time.Time, filter map[string]string, groupBy string) ([]models.DimensionBalance, error)
+	GetLedgerEntries(ctx context.Context, accountIDs []uuid.UUID, startDate, endDate time.Time) ([]models.JournalEntry, error)
+	VoidWithReversal(ctx context.Context, originalID uuid.UUID, reversal *models.JournalEntry, reason string, voidedAt time.Time) (*models.JournalEntry, error)
 }
 
 // gormJournalEntryRepository is an implementation of JournalEntryRepository using GORM.
@@ -42,10 +49,17 @@ func NewJournalEntryRepository(db *gorm.DB) JournalEntryRepository {
 func (r *gormJournalEntryRepository) Create(ctx context.Context, entry *models.JournalEntry) (*models.JournalEntry, error) {
 	logger.InfoLogger.Printf("Repository: Attempting to create journal entry with description: %s", entry.Description)
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := numberJournalEntry(tx, entry); err != nil {
+			logger.ErrorLogger.Printf("Repository: Error numbering journal entry: %v", err)
+			return err
+		}
 		if err := tx.Create(entry).Error; err != nil { // This creates header and lines if associations are set up
 			logger.ErrorLogger.Printf("Repository: Error creating journal entry (and lines): %v", err)
 			return err
 		}
+		if entry.Status == models.StatusPosted {
+			return scheduleReversal(tx, entry)
+		}
 		return nil
 	})
 
@@ -55,7 +69,7 @@ func (r *gormJournalEntryRepository) Create(ctx context.Context, entry *models.J
 	}
 
 	// Reload to ensure all data (like preloaded lines with their own DB-generated fields) is fresh.
-	if err := r.db.WithContext(ctx).Preload("JournalLines").First(entry, "id = ?", entry.ID).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("JournalLines").Preload("JournalLines.Dimensions").First(entry, "id = ?", entry.ID).Error; err != nil {
 		logger.ErrorLogger.Printf("Repository: Error reloading journal entry with lines after creation: %v", err)
 		return nil, errors.NewInternalServerError("failed to reload journal entry after creation", err)
 	}
@@ -64,10 +78,22 @@ func (r *gormJournalEntryRepository) Create(ctx context.Context, entry *models.J
 	return entry, nil
 }
 
+// SaveJournalEntry numbers entry and creates it with its lines within tx, for modules that save
+// the entry of a document in the same transaction as the document itself.
+func SaveJournalEntry(tx *gorm.DB, entry *models.JournalEntry) error {
+	if err := numberJournalEntry(tx, entry); err != nil {
+		return err
+	}
+	return tx.Create(entry).Error
+}
+
 func (r *gormJournalEntryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.JournalEntry, error) {
 	logger.InfoLogger.Printf("Repository: Attempting to retrieve journal entry with ID: %s", id)
 	var entry models.JournalEntry
-	if err := r.db.WithContext(ctx).Preload("JournalLines").Preload("JournalLines.ChartOfAccount").First(&entry, "id = ?", id).Error; err != nil {
+	err := r.db.WithContext(ctx).Preload("JournalLines").Preload("JournalLines.ChartOfAccount").Preload("JournalLines.Dimensions").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB { return db.Order("created_at asc") }).
+		First(&entry, "id = ?", id).Error
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			logger.WarnLogger.Printf("Repository: Journal entry with ID %s not found", id)
 			return nil, errors.NewNotFoundError("journal_entry", id.String())
@@ -82,6 +108,28 @@ func (r *gormJournalEntryRepository) GetByID(ctx context.Context, id uuid.UUID)
 func (r *gormJournalEntryRepository) Update(ctx context.Context, entry *models.JournalEntry) (*models.JournalEntry, error) {
 	logger.InfoLogger.Printf("Repository: Attempting to update journal entry with ID: %s", entry.ID)
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Dimension tags are saved again from the lines below, so tags a line no longer has are dropped.
+		if err := tx.Where("journal_line_id IN (?)", tx.Model(&models.JournalLine{}).Select("id").Where("journal_id = ?", entry.ID)).
+			Delete(&models.JournalLineDimension{}).Error; err != nil {
+			logger.ErrorLogger.Printf("Repository: Error clearing dimension tags of journal entry %s: %v", entry.ID, err)
+			return err
+		}
+		// Only an entry posted by this update is numbered and has its reversal scheduled; entries
+		// posted before numbering keep none.
+		postedNow := false
+		if entry.Status == models.StatusPosted {
+			var stored models.JournalEntry
+			if err := tx.Select("status").First(&stored, "id = ?", entry.ID).Error; err != nil {
+				return err
+			}
+			postedNow = stored.Status != models.StatusPosted
+		}
+		if postedNow {
+			if err := numberJournalEntry(tx, entry); err != nil {
+				logger.ErrorLogger.Printf("Repository: Error numbering journal entry %s: %v", entry.ID, err)
+				return err
+			}
+		}
 		// Save the main entry fields. Using Select("*") to ensure all fields are updated, including zero values if intended.
 		// Or, use .Updates() with a map for partial updates if only specific fields should change.
 		// For full replacement including associations, GORM's Save is powerful.
@@ -89,6 +137,9 @@ func (r *gormJournalEntryRepository) Update(ctx context.Context, entry *models.J
 			logger.ErrorLogger.Printf("Repository: Error saving journal entry (and lines) %s: %v", entry.ID, err)
 			return err
 		}
+		if postedNow {
+			return scheduleReversal(tx, entry)
+		}
 		return nil
 	})
 
@@ -132,9 +183,13 @@ func (r *gormJournalEntryRepository) List(ctx context.Context, offset, limit int
 
 	if desc, ok := filters["description"].(string); ok && desc != "" { query = query.Where("description ILIKE ?", "%"+desc+"%") }
 	if ref, ok := filters["reference"].(string); ok && ref != "" { query = query.Where("reference ILIKE ?", "%"+ref+"%") }
+	if number, ok := filters["document_number"].(string); ok && number != "" { query = query.Where("document_number = ?", number) }
 	if status, ok := filters["status"].(models.JournalStatus); ok && status != "" { query = query.Where("status = ?", status) }
 	if dateFrom, ok := filters["date_from"].(time.Time); ok && !dateFrom.IsZero() { query = query.Where("entry_date >= ?", dateFrom) }
 	if dateTo, ok := filters["date_to"].(time.Time); ok && !dateTo.IsZero() { query = query.Where("entry_date <= ?", dateTo) }
+	if accountID, ok := filters["account_id"].(uuid.UUID); ok && accountID != uuid.Nil {
+		query = query.Where("id IN (?)", r.db.WithContext(ctx).Model(&models.JournalLine{}).Select("journal_id").Where("account_id = ?", accountID))
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, errors.NewInternalServerError("failed to count journal entries", err)
@@ -148,13 +203,72 @@ func (r *gormJournalEntryRepository) List(ctx context.Context, offset, limit int
 	return entries, total, nil
 }
 
+// UpdateJournalEntryStatus sets the status of an entry. An entry that becomes POSTED is given its
+// document number, and has its automatic reversal scheduled, in the same transaction.
 func (r *gormJournalEntryRepository) UpdateJournalEntryStatus(ctx context.Context, id uuid.UUID, newStatus models.JournalStatus) error {
-	result := r.db.WithContext(ctx).Model(&models.JournalEntry{}).Where("id = ?", id).Update("status", newStatus)
-	if result.Error != nil {
-		return errors.NewInternalServerError(fmt.Sprintf("failed to update status for journal entry %s", id), result.Error)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var entry models.JournalEntry
+		if err := tx.First(&entry, "id = ?", id).Error; err != nil {
+			return err
+		}
+		updates := map[string]interface{}{"status": newStatus}
+		postedNow := newStatus == models.StatusPosted && entry.Status != models.StatusPosted
+		if postedNow {
+			entry.Status = newStatus
+			if err := numberJournalEntry(tx, &entry); err != nil {
+				return err
+			}
+			updates["document_number"] = entry.DocumentNumber
+		}
+		if err := tx.Model(&models.JournalEntry{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		if postedNow {
+			return scheduleReversal(tx, &entry)
+		}
+		return nil
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewNotFoundError("journal_entry", id.String())
+		}
+		return errors.NewInternalServerError(fmt.Sprintf("failed to update status for journal entry %s", id), err)
 	}
-	if result.RowsAffected == 0 {
-		return errors.NewNotFoundError("journal_entry", id.String())
+	return nil
+}
+
+// SaveApprovalStep stores an entry's new status and approval fields together with the approval
+// history record in one transaction, numbering the entry and scheduling its automatic reversal if
+// it is posted.
+func (r *gormJournalEntryRepository) SaveApprovalStep(ctx context.Context, entry *models.JournalEntry, step *models.JournalEntryApproval) error {
+	logger.InfoLogger.Printf("Repository: Recording %s of journal entry %s by %s", step.Action, entry.ID, step.UserID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := numberJournalEntry(tx, entry); err != nil {
+			return err
+		}
+		result := tx.Model(&models.JournalEntry{}).Where("id = ?", entry.ID).
+			Updates(map[string]interface{}{"status": entry.Status, "approved_by": entry.ApprovedBy, "approved_at": entry.ApprovedAt, "document_number": entry.DocumentNumber})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewNotFoundError("journal_entry", entry.ID.String())
+		}
+		step.JournalEntryID = entry.ID
+		if err := tx.Create(step).Error; err != nil {
+			return err
+		}
+		if entry.Status == models.StatusPosted {
+			return scheduleReversal(tx, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		if notFound, ok := err.(*errors.NotFoundError); ok {
+			return notFound
+		}
+		logger.ErrorLogger.Printf("Repository: Error recording %s of journal entry %s: %v", step.Action, entry.ID, err)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to record approval step for journal entry %s", entry.ID), err)
 	}
 	return nil
 }
@@ -196,7 +310,9 @@ func (r *gormJournalEntryRepository) GetJournalEntriesForTrialBalance(ctx contex
 	err := r.db.WithContext(ctx).
 		Preload("JournalLines").
 		Preload("JournalLines.ChartOfAccount").
-		Where("status = ? AND entry_date BETWEEN ? AND ?", models.StatusPosted, startDate, endDate).
+		Preload("JournalLines.Dimensions").
+		// Voided entries stay in the ledger when a posted reversing entry cancels them.
+		Where("(status = ? OR (status = ? AND reversed_by_id IS NOT NULL)) AND entry_date BETWEEN ? AND ?", models.StatusPosted, models.StatusVoided, startDate, endDate).
 		Order("entry_date asc").
 		Find(&entries).Error
 	if err != nil {
@@ -210,7 +326,7 @@ func (r *gormJournalEntryRepository) GetJournalEntriesByAccountID(ctx context.Co
 	var total int64
 
 	// Subquery to find journal_ids that have a line with the specified account_id
-	subQuery := r.db.Model(&models.JournalLine{}).Select("journal_id").Where("account_id = ?", accountID)
+	subQuery := r.db.WithContext(ctx).Model(&models.JournalLine{}).Select("journal_id").Where("account_id = ?", accountID)
 
 	query := r.db.WithContext(ctx).Model(&models.JournalEntry{}).Where("id IN (?)", subQuery)
 	if !startDate.IsZero() { query = query.Where("entry_date >= ?", startDate) }
@@ -227,3 +343,163 @@ func (r *gormJournalEntryRepository) GetJournalEntriesByAccountID(ctx context.Co
 	}
 	return entries, total, nil
 }
+
+// GetAccountBalancesBefore sums debits minus credits per account over the entries that affect
+// balances and are dated before the given date. An empty accountIDs covers every account; accounts
+// without such lines are absent from the result.
+func (r *gormJournalEntryRepository) GetAccountBalancesBefore(ctx context.Context, accountIDs []uuid.UUID, before time.Time) (map[uuid.UUID]money.Amount, error) {
+	var rows []struct {
+		AccountID uuid.UUID
+		Balance   money.Amount
+	}
+	query := r.db.WithContext(ctx).Model(&models.JournalLine{}).
+		Select("journal_lines.account_id, SUM(CASE WHEN journal_lines.is_debit THEN journal_lines.amount ELSE -journal_lines.amount END) AS balance").
+		Joins("JOIN journal_entries ON journal_entries.id = journal_lines.journal_id AND journal_entries.deleted_at IS NULL").
+		Where("(journal_entries.status = ? OR (journal_entries.status = ? AND journal_entries.reversed_by_id IS NOT NULL)) AND journal_entries.entry_date < ?", models.StatusPosted, models.StatusVoided, before)
+	if len(accountIDs) > 0 {
+		query = query.Where("journal_lines.account_id IN ?", accountIDs)
+	}
+	if err := query.Group("journal_lines.account_id").Scan(&rows).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error summing account balances before %s: %v", before.Format("2006-01-02"), err)
+		return nil, errors.NewInternalServerError("failed to sum account balances", err)
+	}
+	balances := make(map[uuid.UUID]money.Amount, len(rows))
+	for _, row := range rows {
+		balances[row.AccountID] = row.Balance
+	}
+	return balances, nil
+}
+
+// GetCurrencyBalances sums debits minus credits per account and transaction currency over the
+// entries that affect balances and are dated before the given date, both as entered and in the
+// functional currency. Pairs whose lines net to zero in both are still returned.
+func (r *gormJournalEntryRepository) GetCurrencyBalances(ctx context.Context, before time.Time) ([]models.CurrencyBalance, error) {
+	var rows []models.CurrencyBalance
+	err := r.db.WithContext(ctx).Model(&models.JournalLine{}).
+		Select("journal_lines.account_id, journal_lines.currency, " +
+			"SUM(CASE WHEN journal_lines.is_debit THEN journal_lines.transaction_amount ELSE -journal_lines.transaction_amount END) AS transaction_balance, " +
+			"SUM(CASE WHEN journal_lines.is_debit THEN journal_lines.amount ELSE -journal_lines.amount END) AS functional_balance").
+		Joins("JOIN journal_entries ON journal_entries.id = journal_lines.journal_id AND journal_entries.deleted_at IS NULL").
+		Where("(journal_entries.status = ? OR (journal_entries.status = ? AND journal_entries.reversed_by_id IS NOT NULL)) AND journal_entries.entry_date < ?", models.StatusPosted, models.StatusVoided, before).
+		Group("journal_lines.account_id, journal_lines.currency").
+		Order("journal_lines.currency asc").
+		Scan(&rows).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error summing currency balances before %s: %v", before.Format("2006-01-02"), err)
+		return nil, errors.NewInternalServerError("failed to sum currency balances", err)
+	}
+	return rows, nil
+}
+
+// GetDimensionBalancesBefore is GetAccountBalancesBefore restricted to lines tagged with every
+// dimension value in filter (dimension code to value code). If groupBy names a dimension, balances
+// are further split by the line's value of it, with untagged lines under an empty value code.
+func (r *gormJournalEntryRepository) GetDimensionBalancesBefore(ctx context.Context, accountIDs []uuid.UUID, before time.Time, filter map[string]string, groupBy string) ([]models.DimensionBalance, error) {
+	var rows []models.DimensionBalance
+	valueCode, group := "''", "journal_lines.account_id"
+	query := r.db.WithContext(ctx).Model(&models.JournalLine{}).
+		Joins("JOIN journal_entries ON journal_entries.id = journal_lines.journal_id AND journal_entries.deleted_at IS NULL").
+		Where("(journal_entries.status = ? OR (journal_entries.status = ? AND journal_entries.reversed_by_id IS NOT NULL)) AND journal_entries.entry_date < ?", models.StatusPosted, models.StatusVoided, before)
+	if groupBy != "" {
+		query = query.Joins("LEFT JOIN journal_line_dimensions grp ON grp.journal_line_id = journal_lines.id AND grp.dimension_code = ?", groupBy)
+		valueCode, group = "COALESCE(grp.value_code, '')", "journal_lines.account_id, grp.value_code"
+	}
+	for dimensionCode, value := range filter {
+		query = query.Where("EXISTS (SELECT 1 FROM journal_line_dimensions jld WHERE jld.journal_line_id = journal_lines.id AND jld.dimension_code = ? AND jld.value_code = ?)", dimensionCode, value)
+	}
+	if len(accountIDs) > 0 {
+		query = query.Where("journal_lines.account_id IN ?", accountIDs)
+	}
+	err := query.
+		Select("journal_lines.account_id, " + valueCode + " AS value_code, SUM(CASE WHEN journal_lines.is_debit THEN journal_lines.amount ELSE -journal_lines.amount END) AS balance").
+		Group(group).
+		Scan(&rows).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error summing dimension balances before %s: %v", before.Format("2006-01-02"), err)
+		return nil, errors.NewInternalServerError("failed to sum dimension balances", err)
+	}
+	return rows, nil
+}
+
+// GetLedgerEntries returns the entries that affect balances, are dated within [startDate, endDate]
+// and have a line on one of the accounts, oldest first. An empty accountIDs covers every account.
+func (r *gormJournalEntryRepository) GetLedgerEntries(ctx context.Context, accountIDs []uuid.UUID, startDate, endDate time.Time) ([]models.JournalEntry, error) {
+	var entries []models.JournalEntry
+	query := r.db.WithContext(ctx).
+		Preload("JournalLines").
+		Preload("JournalLines.ChartOfAccount").
+		Preload("JournalLines.Dimensions").
+		Where("(status = ? OR (status = ? AND reversed_by_id IS NOT NULL)) AND entry_date BETWEEN ? AND ?", models.StatusPosted, models.StatusVoided, startDate, endDate)
+	if len(accountIDs) > 0 {
+		query = query.Where("id IN (?)", r.db.WithContext(ctx).Model(&models.JournalLine{}).Select("journal_id").Where("account_id IN ?", accountIDs))
+	}
+	if err := query.Order("entry_date asc, created_at asc").Find(&entries).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error fetching ledger entries: %v", err)
+		return nil, errors.NewInternalServerError("failed to fetch ledger entries", err)
+	}
+	return entries, nil
+}
+
+// VoidWithReversal creates the reversing entry and marks the original POSTED entry VOIDED in one
+// transaction, linking the two and cancelling the original's pending scheduled reversal, if any.
+// It returns a ConflictError if the original is no longer POSTED.
+func (r *gormJournalEntryRepository) VoidWithReversal(ctx context.Context, originalID uuid.UUID, reversal *models.JournalEntry, reason string, voidedAt time.Time) (*models.JournalEntry, error) {
+	logger.InfoLogger.Printf("Repository: Attempting to void journal entry %s with a reversing entry", originalID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return voidWithReversal(tx, originalID, reversal, reason, voidedAt)
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Transaction failed for voiding journal entry %s: %v", originalID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to void journal entry %s", originalID), err)
+	}
+	logger.InfoLogger.Printf("Repository: Voided journal entry %s, reversing entry %s", originalID, reversal.ID)
+	return r.GetByID(ctx, reversal.ID)
+}
+
+// scheduleReversal records, within the transaction that posts entry, the pending reversal of an entry
+// that carries AutoReverseOn, so an auto-reversing entry is never posted without its reversal.
+func scheduleReversal(tx *gorm.DB, entry *models.JournalEntry) error {
+	if entry.AutoReverseOn == nil {
+		return nil
+	}
+	reverseOn := *entry.AutoReverseOn
+	return tx.Create(&models.ScheduledReversal{
+		JournalEntryID: entry.ID,
+		ReverseOn:      time.Date(reverseOn.Year(), reverseOn.Month(), reverseOn.Day(), 0, 0, 0, 0, time.UTC),
+		Status:         models.ReversalPending,
+	}).Error
+}
+
+// voidWithReversal creates the reversing entry and marks the original POSTED entry VOIDED within tx,
+// linking the two. A voided accrual must not be reversed a second time, so its pending scheduled
+// reversal is cancelled. It returns a ConflictError if the original is no longer POSTED.
+func voidWithReversal(tx *gorm.DB, originalID uuid.UUID, reversal *models.JournalEntry, reason string, voidedAt time.Time) error {
+	reversal.ReversalOfID = &originalID
+	if err := numberJournalEntry(tx, reversal); err != nil {
+		return err
+	}
+	if err := tx.Create(reversal).Error; err != nil {
+		return err
+	}
+	result := tx.Model(&models.JournalEntry{}).
+		Where("id = ? AND status = ?", originalID, models.StatusPosted).
+		Updates(map[string]interface{}{
+			"status":         models.StatusVoided,
+			"reversed_by_id": reversal.ID,
+			"void_reason":    reason,
+			"voided_at":      voidedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewConflictError(fmt.Sprintf("journal entry %s is no longer POSTED and cannot be voided", originalID))
+	}
+	return tx.Model(&models.ScheduledReversal{}).
+		Where("journal_entry_id = ? AND status = ?", originalID, models.ReversalPending).
+		Update("status", models.ReversalCancelled).Error
+}
@@ -5,6 +5,8 @@ import (
 	"erp-system/internal/accounting/models"
 	"erp-system/internal/accounting/repository"
 	// app_errors "erp-system/pkg/errors" // Alias to avoid conflict - REMOVED as unused directly by suite methods
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 	app_errors "erp-system/pkg/errors" // Re-add if specific error type checks are needed outside suite methods
+	"erp-system/pkg/money"
 )
 
 // JournalEntryRepositoryIntegrationTestSuite defines the suite for JournalEntryRepository integration tests.
@@ -32,10 +35,10 @@ type JournalEntryRepositoryIntegrationTestSuite struct {
 // SetupSuite runs once before all tests in the suite.
 func (s *JournalEntryRepositoryIntegrationTestSuite) SetupSuite() {
 	s.T().Log("Setting up suite for JournalEntryRepository integration tests...")
-	s.db = dbInstance_acc_repo // Use the DB instance from the local setup
+	s.ctx = companyCtx_acc_repo()
+	s.db = dbInstance_acc_repo.WithContext(s.ctx) // Use the DB instance from the local setup
 	s.repo = repository.NewJournalEntryRepository(s.db)
 	s.coaRepo = repository.NewChartOfAccountRepository(s.db) // Initialize COA repo
-	s.ctx = context.Background()
 	s.T().Log("Suite setup complete.")
 }
 
@@ -78,8 +81,8 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestCreateJournalEntry_With
 		Description: "Test Sale Transaction",
 		Status:      models.StatusDraft,
 		JournalLines: []models.JournalLine{
-			{AccountID: s.cashAccount.ID, Amount: 150.75, IsDebit: true, Currency: "USD"},
-			{AccountID: s.revenueAccount.ID, Amount: 150.75, IsDebit: false, Currency: "USD"},
+			{AccountID: s.cashAccount.ID, Amount: money.MustParse("150.75"), IsDebit: true, Currency: "USD"},
+			{AccountID: s.revenueAccount.ID, Amount: money.MustParse("150.75"), IsDebit: false, Currency: "USD"},
 		},
 	}
 
@@ -95,7 +98,7 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestCreateJournalEntry_With
 	s.NoError(err)
 	s.Equal(createdEntry.Description, fetchedEntry.Description)
 	s.Len(fetchedEntry.JournalLines, 2)
-	s.InDelta(150.75, fetchedEntry.JournalLines[0].Amount, 0.001)
+	s.Equal(money.MustParse("150.75"), fetchedEntry.JournalLines[0].Amount)
 }
 
 func (s *JournalEntryRepositoryIntegrationTestSuite) TestGetJournalEntryByID_WithLines() {
@@ -103,8 +106,8 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestGetJournalEntryByID_Wit
 	seedEntry := models.JournalEntry{
 		EntryDate:   time.Now(), Description: "Seed Entry for Get", Status: models.StatusPosted,
 		JournalLines: []models.JournalLine{
-			{AccountID: s.expenseAccount.ID, Amount: 50.00, IsDebit: true},
-			{AccountID: s.cashAccount.ID, Amount: 50.00, IsDebit: false},
+			{AccountID: s.expenseAccount.ID, Amount: money.MustParse("50.00"), IsDebit: true},
+			{AccountID: s.cashAccount.ID, Amount: money.MustParse("50.00"), IsDebit: false},
 		},
 	}
 	// Use repo.Create to ensure BeforeCreate hooks run and lines are associated
@@ -137,8 +140,8 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestUpdateJournalEntry_Head
 		Description: "Initial Entry for Update",
 		Status:      models.StatusDraft,
 		JournalLines: []models.JournalLine{
-			{AccountID: s.cashAccount.ID, Amount: 100.00, IsDebit: true},
-			{AccountID: s.revenueAccount.ID, Amount: 100.00, IsDebit: false},
+			{AccountID: s.cashAccount.ID, Amount: money.MustParse("100.00"), IsDebit: true},
+			{AccountID: s.revenueAccount.ID, Amount: money.MustParse("100.00"), IsDebit: false},
 		},
 	}
 	createdInitialEntry, err := s.repo.Create(s.ctx, &initialEntry)
@@ -159,11 +162,11 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestUpdateJournalEntry_Head
 			// Update existing line 1 (match by ID if GORM's Replace handles it, or ensure full replacement)
 			// For GORM's .Association("JournalLines").Replace(), new lines without ID are created,
 			// lines with existing ID that are present are updated, lines with existing ID not present are deleted.
-			{ID: initialLine1ID, JournalID: createdInitialEntry.ID, AccountID: s.cashAccount.ID, Amount: 120.00, IsDebit: true}, // Amount changed
+			{ID: initialLine1ID, JournalID: createdInitialEntry.ID, AccountID: s.cashAccount.ID, Amount: money.MustParse("120.00"), IsDebit: true}, // Amount changed
 			// Line 2 is omitted, so it should be deleted by Replace behavior
 			// Add a new line
-			{JournalID: createdInitialEntry.ID, AccountID: s.expenseAccount.ID, Amount: 30.00, IsDebit: true},
-			{JournalID: createdInitialEntry.ID, AccountID: s.revenueAccount.ID, Amount: 150.00, IsDebit: false}, // New balancing credit line
+			{JournalID: createdInitialEntry.ID, AccountID: s.expenseAccount.ID, Amount: money.MustParse("30.00"), IsDebit: true},
+			{JournalID: createdInitialEntry.ID, AccountID: s.revenueAccount.ID, Amount: money.MustParse("150.00"), IsDebit: false}, // New balancing credit line
 		},
 	}
     // Ensure the updated entry is balanced: 120 DR + 30 DR = 150 DR; 150 CR. Balanced.
@@ -187,13 +190,13 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestUpdateJournalEntry_Head
 
 	for _, line := range fetchedEntryAfterUpdate.JournalLines {
 		if line.AccountID == s.cashAccount.ID {
-			s.InDelta(120.00, line.Amount, 0.001, "Cash line amount should be updated")
+			s.Equal(money.MustParse("120.00"), line.Amount, "Cash line amount should be updated")
 			foundUpdatedCashLine = true
 		} else if line.AccountID == s.expenseAccount.ID {
-			s.InDelta(30.00, line.Amount, 0.001, "New expense line should exist")
+			s.Equal(money.MustParse("30.00"), line.Amount, "New expense line should exist")
 			foundNewLineExpense = true
 		} else if line.AccountID == s.revenueAccount.ID && !line.IsDebit { // Ensure it's the new credit line
-			s.InDelta(150.00, line.Amount, 0.001, "New revenue line should exist")
+			s.Equal(money.MustParse("150.00"), line.Amount, "New revenue line should exist")
 			foundNewLineRevenue = true
 		}
 		// Check that initialLine2ID is not present
@@ -210,8 +213,8 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestDeleteJournalEntry_Soft
 	entry := models.JournalEntry{
 		EntryDate: time.Now(), Description: "Entry to be soft-deleted", Status: models.StatusDraft,
 		JournalLines: []models.JournalLine{
-			{AccountID: s.cashAccount.ID, Amount: 10.00, IsDebit: true},
-			{AccountID: s.revenueAccount.ID, Amount: 10.00, IsDebit: false},
+			{AccountID: s.cashAccount.ID, Amount: money.MustParse("10.00"), IsDebit: true},
+			{AccountID: s.revenueAccount.ID, Amount: money.MustParse("10.00"), IsDebit: false},
 		},
 	}
 	createdEntry, err := s.repo.Create(s.ctx, &entry)
@@ -249,15 +252,15 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestListJournalEntries() {
 	now := time.Now().Truncate(time.Second) // Truncate for easier comparison if needed
 	entry1 := models.JournalEntry{
 		EntryDate: now.Add(-2 * 24 * time.Hour), Description: "Older Entry Alpha", Status: models.StatusPosted,
-		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: 10, IsDebit: true}, {AccountID: s.revenueAccount.ID, Amount: 10, IsDebit: false}},
+		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: money.MustParse("10.00"), IsDebit: true}, {AccountID: s.revenueAccount.ID, Amount: money.MustParse("10.00"), IsDebit: false}},
 	}
 	entry2 := models.JournalEntry{
 		EntryDate: now.Add(-1 * 24 * time.Hour), Description: "Recent Entry Beta", Status: models.StatusDraft,
-		JournalLines: []models.JournalLine{{AccountID: s.expenseAccount.ID, Amount: 20, IsDebit: true}, {AccountID: s.cashAccount.ID, Amount: 20, IsDebit: false}},
+		JournalLines: []models.JournalLine{{AccountID: s.expenseAccount.ID, Amount: money.MustParse("20.00"), IsDebit: true}, {AccountID: s.cashAccount.ID, Amount: money.MustParse("20.00"), IsDebit: false}},
 	}
 	entry3 := models.JournalEntry{
 		EntryDate: now, Description: "Current Entry Alpha", Status: models.StatusPosted,
-		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: 30, IsDebit: true}, {AccountID: s.revenueAccount.ID, Amount: 30, IsDebit: false}},
+		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: money.MustParse("30.00"), IsDebit: true}, {AccountID: s.revenueAccount.ID, Amount: money.MustParse("30.00"), IsDebit: false}},
 	}
 	_, err := s.repo.Create(s.ctx, &entry1); s.Require().NoError(err)
 	_, err = s.repo.Create(s.ctx, &entry2); s.Require().NoError(err)
@@ -307,7 +310,7 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestUpdateJournalEntryStatu
 	s.T().Log("Running TestUpdateJournalEntryStatus")
 	entry := models.JournalEntry{
 		EntryDate: time.Now(), Description: "Status Update Test", Status: models.StatusDraft,
-		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: 5, IsDebit: true}, {AccountID: s.revenueAccount.ID, Amount: 5, IsDebit: false}},
+		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: money.MustParse("5.00"), IsDebit: true}, {AccountID: s.revenueAccount.ID, Amount: money.MustParse("5.00"), IsDebit: false}},
 	}
 	createdEntry, err := s.repo.Create(s.ctx, &entry); s.Require().NoError(err)
 
@@ -332,18 +335,18 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestGetJournalEntriesForTri
 	// Posted entry within range
 	entry1 := models.JournalEntry{ EntryDate: now.AddDate(0, 0, -5), Status: models.StatusPosted, Description: "TB Entry 1",
 		JournalLines: []models.JournalLine{
-			{AccountID: s.cashAccount.ID, Amount: 100, IsDebit: true, ChartOfAccount: s.cashAccount}, // Ensure ChartOfAccount is pre-filled for test simplicity
-			{AccountID: s.revenueAccount.ID, Amount: 100, IsDebit: false, ChartOfAccount: s.revenueAccount},
+			{AccountID: s.cashAccount.ID, Amount: money.MustParse("100.00"), IsDebit: true, ChartOfAccount: s.cashAccount}, // Ensure ChartOfAccount is pre-filled for test simplicity
+			{AccountID: s.revenueAccount.ID, Amount: money.MustParse("100.00"), IsDebit: false, ChartOfAccount: s.revenueAccount},
 		}}
 	// Draft entry within range (should be ignored)
 	entry2 := models.JournalEntry{ EntryDate: now.AddDate(0, 0, -4), Status: models.StatusDraft, Description: "TB Entry 2 Draft",
-		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: 50, IsDebit: true, ChartOfAccount: s.cashAccount}}}
+		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: money.MustParse("50.00"), IsDebit: true, ChartOfAccount: s.cashAccount}}}
 	// Posted entry outside range (before)
 	entry3 := models.JournalEntry{ EntryDate: now.AddDate(0, 0, -15), Status: models.StatusPosted, Description: "TB Entry 3 Old",
-		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: 20, IsDebit: true, ChartOfAccount: s.cashAccount}}}
+		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: money.MustParse("20.00"), IsDebit: true, ChartOfAccount: s.cashAccount}}}
 	// Posted entry outside range (after)
 	entry4 := models.JournalEntry{ EntryDate: now.AddDate(0, 0, 1), Status: models.StatusPosted, Description: "TB Entry 4 Future",
-		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: 20, IsDebit: true, ChartOfAccount: s.cashAccount}}}
+		JournalLines: []models.JournalLine{{AccountID: s.cashAccount.ID, Amount: money.MustParse("20.00"), IsDebit: true, ChartOfAccount: s.cashAccount}}}
 
 
 	_, err := s.repo.Create(s.ctx, &entry1); s.Require().NoError(err)
@@ -365,3 +368,58 @@ func (s *JournalEntryRepositoryIntegrationTestSuite) TestGetJournalEntriesForTri
 		s.Equal(s.cashAccount.ID, entries[0].JournalLines[0].ChartOfAccount.ID)
 	}
 }
+
+func (s *JournalEntryRepositoryIntegrationTestSuite) TestDocumentNumbers_GapFreeUnderConcurrentPosting() {
+	entryDate := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	newEntry := func(status models.JournalStatus) *models.JournalEntry {
+		return &models.JournalEntry{
+			EntryDate: entryDate, Description: "Numbered entry", Status: status,
+			JournalLines: []models.JournalLine{
+				{AccountID: s.cashAccount.ID, Amount: money.MustParse("10.00"), IsDebit: true},
+				{AccountID: s.revenueAccount.ID, Amount: money.MustParse("10.00"), IsDebit: false},
+			},
+		}
+	}
+
+	draft, err := s.repo.Create(s.ctx, newEntry(models.StatusDraft))
+	s.Require().NoError(err)
+	s.Empty(draft.DocumentNumber, "drafts are numbered when they are posted")
+
+	const posters = 8
+	var wg sync.WaitGroup
+	numbers := make(chan string, posters)
+	for i := 0; i < posters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			created, err := s.repo.Create(s.ctx, newEntry(models.StatusPosted))
+			if s.NoError(err) {
+				numbers <- created.DocumentNumber
+			}
+		}()
+	}
+	wg.Wait()
+	close(numbers)
+	seen := make(map[string]bool)
+	for number := range numbers {
+		seen[number] = true
+	}
+	for i := 1; i <= posters; i++ {
+		s.True(seen[fmt.Sprintf("GJ-2026-%06d", i)], "missing GJ-2026-%06d", i)
+	}
+
+	// A rolled-back transaction gives its number back.
+	rollback := fmt.Errorf("rollback")
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		number, err := repository.NextDocumentNumber(tx, models.DocumentTypeJournalStandard, entryDate)
+		s.Require().NoError(err)
+		s.Equal("GJ-2026-000009", number)
+		return rollback
+	})
+	s.Equal(rollback, err)
+
+	s.Require().NoError(s.repo.UpdateJournalEntryStatus(s.ctx, draft.ID, models.StatusPosted))
+	posted, err := s.repo.GetByID(s.ctx, draft.ID)
+	s.Require().NoError(err)
+	s.Equal("GJ-2026-000009", posted.DocumentNumber)
+}
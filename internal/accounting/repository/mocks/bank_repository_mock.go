@@ -0,0 +1,368 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// BankRepository is an autogenerated mock type for the BankRepository type
+type BankRepository struct {
+	mock.Mock
+}
+
+// CreateBankAccount provides a mock function with given fields: ctx, account
+func (_m *BankRepository) CreateBankAccount(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error) {
+	ret := _m.Called(ctx, account)
+
+	var r0 *models.BankAccount
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BankAccount) *models.BankAccount); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.BankAccount) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateStatement provides a mock function with given fields: ctx, statement
+func (_m *BankRepository) CreateStatement(ctx context.Context, statement *models.BankStatement) (*models.BankStatement, error) {
+	ret := _m.Called(ctx, statement)
+
+	var r0 *models.BankStatement
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BankStatement) *models.BankStatement); ok {
+		r0 = rf(ctx, statement)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.BankStatement) error); ok {
+		r1 = rf(ctx, statement)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindExternalIDs provides a mock function with given fields: ctx, bankAccountID, externalIDs
+func (_m *BankRepository) FindExternalIDs(ctx context.Context, bankAccountID uuid.UUID, externalIDs []string) ([]string, error) {
+	ret := _m.Called(ctx, bankAccountID, externalIDs)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) []string); ok {
+		r0 = rf(ctx, bankAccountID, externalIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []string) error); ok {
+		r1 = rf(ctx, bankAccountID, externalIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBankAccount provides a mock function with given fields: ctx, id
+func (_m *BankRepository) GetBankAccount(ctx context.Context, id uuid.UUID) (*models.BankAccount, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.BankAccount
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.BankAccount); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBankAccountByChartOfAccount provides a mock function with given fields: ctx, chartOfAccountID
+func (_m *BankRepository) GetBankAccountByChartOfAccount(ctx context.Context, chartOfAccountID uuid.UUID) (*models.BankAccount, error) {
+	ret := _m.Called(ctx, chartOfAccountID)
+
+	var r0 *models.BankAccount
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.BankAccount); ok {
+		r0 = rf(ctx, chartOfAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, chartOfAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJournalLine provides a mock function with given fields: ctx, id
+func (_m *BankRepository) GetJournalLine(ctx context.Context, id uuid.UUID) (*models.JournalLine, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.JournalLine
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.JournalLine); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalLine)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestStatement provides a mock function with given fields: ctx, bankAccountID, endingOnOrBefore
+func (_m *BankRepository) GetLatestStatement(ctx context.Context, bankAccountID uuid.UUID, endingOnOrBefore time.Time) (*models.BankStatement, error) {
+	ret := _m.Called(ctx, bankAccountID, endingOnOrBefore)
+
+	var r0 *models.BankStatement
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *models.BankStatement); ok {
+		r0 = rf(ctx, bankAccountID, endingOnOrBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, bankAccountID, endingOnOrBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatementLine provides a mock function with given fields: ctx, id
+func (_m *BankRepository) GetStatementLine(ctx context.Context, id uuid.UUID) (*models.BankStatementLine, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.BankStatementLine
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.BankStatementLine); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatementLine)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatementLineByJournalLine provides a mock function with given fields: ctx, journalLineID
+func (_m *BankRepository) GetStatementLineByJournalLine(ctx context.Context, journalLineID uuid.UUID) (*models.BankStatementLine, error) {
+	ret := _m.Called(ctx, journalLineID)
+
+	var r0 *models.BankStatementLine
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.BankStatementLine); ok {
+		r0 = rf(ctx, journalLineID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankStatementLine)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, journalLineID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBankAccounts provides a mock function with given fields: ctx
+func (_m *BankRepository) ListBankAccounts(ctx context.Context) ([]*models.BankAccount, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.BankAccount
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.BankAccount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BankAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStatementLines provides a mock function with given fields: ctx, bankAccountID, matched, asOf
+func (_m *BankRepository) ListStatementLines(ctx context.Context, bankAccountID uuid.UUID, matched *bool, asOf time.Time) ([]*models.BankStatementLine, error) {
+	ret := _m.Called(ctx, bankAccountID, matched, asOf)
+
+	var r0 []*models.BankStatementLine
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *bool, time.Time) []*models.BankStatementLine); ok {
+		r0 = rf(ctx, bankAccountID, matched, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BankStatementLine)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *bool, time.Time) error); ok {
+		r1 = rf(ctx, bankAccountID, matched, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStatements provides a mock function with given fields: ctx, bankAccountID
+func (_m *BankRepository) ListStatements(ctx context.Context, bankAccountID uuid.UUID) ([]*models.BankStatement, error) {
+	ret := _m.Called(ctx, bankAccountID)
+
+	var r0 []*models.BankStatement
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.BankStatement); ok {
+		r0 = rf(ctx, bankAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.BankStatement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, bankAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUnclearedJournalLines provides a mock function with given fields: ctx, accountID, asOf
+func (_m *BankRepository) ListUnclearedJournalLines(ctx context.Context, accountID uuid.UUID, asOf time.Time) ([]models.JournalLine, error) {
+	ret := _m.Called(ctx, accountID, asOf)
+
+	var r0 []models.JournalLine
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) []models.JournalLine); ok {
+		r0 = rf(ctx, accountID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JournalLine)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, accountID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveMatches provides a mock function with given fields: ctx, lines
+func (_m *BankRepository) SaveMatches(ctx context.Context, lines []*models.BankStatementLine) error {
+	ret := _m.Called(ctx, lines)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.BankStatementLine) error); ok {
+		r0 = rf(ctx, lines)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateBankAccount provides a mock function with given fields: ctx, account
+func (_m *BankRepository) UpdateBankAccount(ctx context.Context, account *models.BankAccount) (*models.BankAccount, error) {
+	ret := _m.Called(ctx, account)
+
+	var r0 *models.BankAccount
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BankAccount) *models.BankAccount); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BankAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.BankAccount) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewBankRepository creates a new instance of BankRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBankRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BankRepository {
+	mock := &BankRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.BankRepository = (*BankRepository)(nil)
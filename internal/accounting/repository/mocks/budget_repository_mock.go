@@ -0,0 +1,169 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// BudgetRepository is an autogenerated mock type for the BudgetRepository type
+type BudgetRepository struct {
+	mock.Mock
+}
+
+// ApproveBudget provides a mock function with given fields: ctx, budget
+func (_m *BudgetRepository) ApproveBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error) {
+	ret := _m.Called(ctx, budget)
+
+	var r0 *models.Budget
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget) *models.Budget); ok {
+		r0 = rf(ctx, budget)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Budget) error); ok {
+		r1 = rf(ctx, budget)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBudget provides a mock function with given fields: ctx, budget
+func (_m *BudgetRepository) CreateBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error) {
+	ret := _m.Called(ctx, budget)
+
+	var r0 *models.Budget
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget) *models.Budget); ok {
+		r0 = rf(ctx, budget)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Budget) error); ok {
+		r1 = rf(ctx, budget)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBudget provides a mock function with given fields: ctx, id
+func (_m *BudgetRepository) GetBudget(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Budget
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Budget); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestVersion provides a mock function with given fields: ctx, name, fiscalYearID
+func (_m *BudgetRepository) GetLatestVersion(ctx context.Context, name string, fiscalYearID uuid.UUID) (*models.Budget, error) {
+	ret := _m.Called(ctx, name, fiscalYearID)
+
+	var r0 *models.Budget
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) *models.Budget); ok {
+		r0 = rf(ctx, name, fiscalYearID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, name, fiscalYearID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBudgets provides a mock function with given fields: ctx, fiscalYearID
+func (_m *BudgetRepository) ListBudgets(ctx context.Context, fiscalYearID *uuid.UUID) ([]*models.Budget, error) {
+	ret := _m.Called(ctx, fiscalYearID)
+
+	var r0 []*models.Budget
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) []*models.Budget); ok {
+		r0 = rf(ctx, fiscalYearID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Budget)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, fiscalYearID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBudget provides a mock function with given fields: ctx, budget
+func (_m *BudgetRepository) UpdateBudget(ctx context.Context, budget *models.Budget) (*models.Budget, error) {
+	ret := _m.Called(ctx, budget)
+
+	var r0 *models.Budget
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Budget) *models.Budget); ok {
+		r0 = rf(ctx, budget)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Budget)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Budget) error); ok {
+		r1 = rf(ctx, budget)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewBudgetRepository creates a new instance of BudgetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetRepository {
+	mock := &BudgetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.BudgetRepository = (*BudgetRepository)(nil)
@@ -37,6 +37,20 @@ func (_m *ChartOfAccountRepository) Create(ctx context.Context, account *models.
 	return r0, r1
 }
 
+// CreateAll provides a mock function with given fields: ctx, accounts
+func (_m *ChartOfAccountRepository) CreateAll(ctx context.Context, accounts []*models.ChartOfAccount) error {
+	ret := _m.Called(ctx, accounts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.ChartOfAccount) error); ok {
+		r0 = rf(ctx, accounts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *ChartOfAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	ret := _m.Called(ctx, id)
@@ -97,6 +111,29 @@ func (_m *ChartOfAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (
 	return r0, r1
 }
 
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *ChartOfAccountRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.ChartOfAccount, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*models.ChartOfAccount
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []*models.ChartOfAccount); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ChartOfAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, offset, limit, filters
 func (_m *ChartOfAccountRepository) List(ctx context.Context, offset int, limit int, filters map[string]interface{}) ([]*models.ChartOfAccount, int64, error) {
 	ret := _m.Called(ctx, offset, limit, filters)
@@ -127,6 +164,52 @@ func (_m *ChartOfAccountRepository) List(ctx context.Context, offset int, limit
 	return r0, r1, r2
 }
 
+// ListMerges provides a mock function with given fields: ctx, accountID
+func (_m *ChartOfAccountRepository) ListMerges(ctx context.Context, accountID uuid.UUID) ([]*models.AccountMerge, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*models.AccountMerge
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.AccountMerge); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AccountMerge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeAccounts provides a mock function with given fields: ctx, merge
+func (_m *ChartOfAccountRepository) MergeAccounts(ctx context.Context, merge *models.AccountMerge) (*models.AccountMerge, error) {
+	ret := _m.Called(ctx, merge)
+
+	var r0 *models.AccountMerge
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccountMerge) *models.AccountMerge); ok {
+		r0 = rf(ctx, merge)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccountMerge)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.AccountMerge) error); ok {
+		r1 = rf(ctx, merge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, account
 func (_m *ChartOfAccountRepository) Update(ctx context.Context, account *models.ChartOfAccount) (*models.ChartOfAccount, error) {
 	ret := _m.Called(ctx, account)
@@ -0,0 +1,298 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// ConsolidationRepository is an autogenerated mock type for the ConsolidationRepository type
+type ConsolidationRepository struct {
+	mock.Mock
+}
+
+// CreateEliminationRule provides a mock function with given fields: ctx, rule
+func (_m *ConsolidationRepository) CreateEliminationRule(ctx context.Context, rule *models.EliminationRule) (*models.EliminationRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	var r0 *models.EliminationRule
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EliminationRule) *models.EliminationRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EliminationRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EliminationRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGroupAccount provides a mock function with given fields: ctx, account
+func (_m *ConsolidationRepository) CreateGroupAccount(ctx context.Context, account *models.GroupAccount) (*models.GroupAccount, error) {
+	ret := _m.Called(ctx, account)
+
+	var r0 *models.GroupAccount
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GroupAccount) *models.GroupAccount); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GroupAccount) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMapping provides a mock function with given fields: ctx, accountID
+func (_m *ConsolidationRepository) DeleteMapping(ctx context.Context, accountID uuid.UUID) error {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetEliminationRule provides a mock function with given fields: ctx, id
+func (_m *ConsolidationRepository) GetEliminationRule(ctx context.Context, id uuid.UUID) (*models.EliminationRule, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.EliminationRule
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.EliminationRule); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EliminationRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupAccount provides a mock function with given fields: ctx, id
+func (_m *ConsolidationRepository) GetGroupAccount(ctx context.Context, id uuid.UUID) (*models.GroupAccount, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.GroupAccount
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.GroupAccount); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupAccountByCode provides a mock function with given fields: ctx, code
+func (_m *ConsolidationRepository) GetGroupAccountByCode(ctx context.Context, code string) (*models.GroupAccount, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *models.GroupAccount
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.GroupAccount); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListEliminationRules provides a mock function with given fields: ctx
+func (_m *ConsolidationRepository) ListEliminationRules(ctx context.Context) ([]*models.EliminationRule, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.EliminationRule
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.EliminationRule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EliminationRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroupAccounts provides a mock function with given fields: ctx
+func (_m *ConsolidationRepository) ListGroupAccounts(ctx context.Context) ([]*models.GroupAccount, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.GroupAccount
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.GroupAccount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GroupAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListMappings provides a mock function with given fields: ctx
+func (_m *ConsolidationRepository) ListMappings(ctx context.Context) ([]*models.GroupAccountMapping, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.GroupAccountMapping
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.GroupAccountMapping); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GroupAccountMapping)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveMapping provides a mock function with given fields: ctx, mapping
+func (_m *ConsolidationRepository) SaveMapping(ctx context.Context, mapping *models.GroupAccountMapping) (*models.GroupAccountMapping, error) {
+	ret := _m.Called(ctx, mapping)
+
+	var r0 *models.GroupAccountMapping
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GroupAccountMapping) *models.GroupAccountMapping); ok {
+		r0 = rf(ctx, mapping)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupAccountMapping)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GroupAccountMapping) error); ok {
+		r1 = rf(ctx, mapping)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateEliminationRule provides a mock function with given fields: ctx, rule
+func (_m *ConsolidationRepository) UpdateEliminationRule(ctx context.Context, rule *models.EliminationRule) (*models.EliminationRule, error) {
+	ret := _m.Called(ctx, rule)
+
+	var r0 *models.EliminationRule
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EliminationRule) *models.EliminationRule); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EliminationRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.EliminationRule) error); ok {
+		r1 = rf(ctx, rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateGroupAccount provides a mock function with given fields: ctx, account
+func (_m *ConsolidationRepository) UpdateGroupAccount(ctx context.Context, account *models.GroupAccount) (*models.GroupAccount, error) {
+	ret := _m.Called(ctx, account)
+
+	var r0 *models.GroupAccount
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GroupAccount) *models.GroupAccount); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroupAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.GroupAccount) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewConsolidationRepository creates a new instance of ConsolidationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewConsolidationRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ConsolidationRepository {
+	mock := &ConsolidationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.ConsolidationRepository = (*ConsolidationRepository)(nil)
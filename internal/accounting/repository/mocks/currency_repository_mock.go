@@ -0,0 +1,183 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// CurrencyRepository is an autogenerated mock type for the CurrencyRepository type
+type CurrencyRepository struct {
+	mock.Mock
+}
+
+// CreateCurrency provides a mock function with given fields: ctx, currency
+func (_m *CurrencyRepository) CreateCurrency(ctx context.Context, currency *models.Currency) (*models.Currency, error) {
+	ret := _m.Called(ctx, currency)
+
+	var r0 *models.Currency
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Currency) *models.Currency); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Currency)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Currency) error); ok {
+		r1 = rf(ctx, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrency provides a mock function with given fields: ctx, code
+func (_m *CurrencyRepository) GetCurrency(ctx context.Context, code string) (*models.Currency, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *models.Currency
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Currency); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Currency)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExchangeRate provides a mock function with given fields: ctx, fromCurrency, toCurrency, on
+func (_m *CurrencyRepository) GetExchangeRate(ctx context.Context, fromCurrency string, toCurrency string, on time.Time) (*models.ExchangeRate, error) {
+	ret := _m.Called(ctx, fromCurrency, toCurrency, on)
+
+	var r0 *models.ExchangeRate
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *models.ExchangeRate); ok {
+		r0 = rf(ctx, fromCurrency, toCurrency, on)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExchangeRate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, fromCurrency, toCurrency, on)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCurrencies provides a mock function with given fields: ctx
+func (_m *CurrencyRepository) ListCurrencies(ctx context.Context) ([]*models.Currency, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.Currency
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Currency); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Currency)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListExchangeRates provides a mock function with given fields: ctx, fromCurrency, toCurrency, startDate, endDate
+func (_m *CurrencyRepository) ListExchangeRates(ctx context.Context, fromCurrency string, toCurrency string, startDate time.Time, endDate time.Time) ([]*models.ExchangeRate, error) {
+	ret := _m.Called(ctx, fromCurrency, toCurrency, startDate, endDate)
+
+	var r0 []*models.ExchangeRate
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) []*models.ExchangeRate); ok {
+		r0 = rf(ctx, fromCurrency, toCurrency, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExchangeRate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, fromCurrency, toCurrency, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveExchangeRates provides a mock function with given fields: ctx, rates
+func (_m *CurrencyRepository) SaveExchangeRates(ctx context.Context, rates []*models.ExchangeRate) error {
+	ret := _m.Called(ctx, rates)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.ExchangeRate) error); ok {
+		r0 = rf(ctx, rates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCurrency provides a mock function with given fields: ctx, currency
+func (_m *CurrencyRepository) UpdateCurrency(ctx context.Context, currency *models.Currency) (*models.Currency, error) {
+	ret := _m.Called(ctx, currency)
+
+	var r0 *models.Currency
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Currency) *models.Currency); ok {
+		r0 = rf(ctx, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Currency)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Currency) error); ok {
+		r1 = rf(ctx, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCurrencyRepository creates a new instance of CurrencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCurrencyRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CurrencyRepository {
+	mock := &CurrencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.CurrencyRepository = (*CurrencyRepository)(nil)
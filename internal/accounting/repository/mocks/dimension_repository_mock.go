@@ -0,0 +1,252 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// DimensionRepository is an autogenerated mock type for the DimensionRepository type
+type DimensionRepository struct {
+	mock.Mock
+}
+
+// CreateDimension provides a mock function with given fields: ctx, dimension
+func (_m *DimensionRepository) CreateDimension(ctx context.Context, dimension *models.Dimension) (*models.Dimension, error) {
+	ret := _m.Called(ctx, dimension)
+
+	var r0 *models.Dimension
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Dimension) *models.Dimension); ok {
+		r0 = rf(ctx, dimension)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dimension)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Dimension) error); ok {
+		r1 = rf(ctx, dimension)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateValue provides a mock function with given fields: ctx, value
+func (_m *DimensionRepository) CreateValue(ctx context.Context, value *models.DimensionValue) (*models.DimensionValue, error) {
+	ret := _m.Called(ctx, value)
+
+	var r0 *models.DimensionValue
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DimensionValue) *models.DimensionValue); ok {
+		r0 = rf(ctx, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DimensionValue)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DimensionValue) error); ok {
+		r1 = rf(ctx, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDimension provides a mock function with given fields: ctx, id
+func (_m *DimensionRepository) GetDimension(ctx context.Context, id uuid.UUID) (*models.Dimension, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Dimension
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Dimension); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dimension)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDimensionByCode provides a mock function with given fields: ctx, code
+func (_m *DimensionRepository) GetDimensionByCode(ctx context.Context, code string) (*models.Dimension, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *models.Dimension
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Dimension); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dimension)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetValue provides a mock function with given fields: ctx, id
+func (_m *DimensionRepository) GetValue(ctx context.Context, id uuid.UUID) (*models.DimensionValue, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.DimensionValue
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.DimensionValue); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DimensionValue)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAccountRules provides a mock function with given fields: ctx, accountIDs
+func (_m *DimensionRepository) ListAccountRules(ctx context.Context, accountIDs []uuid.UUID) ([]models.AccountDimensionRule, error) {
+	ret := _m.Called(ctx, accountIDs)
+
+	var r0 []models.AccountDimensionRule
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []models.AccountDimensionRule); ok {
+		r0 = rf(ctx, accountIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccountDimensionRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, accountIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDimensions provides a mock function with given fields: ctx
+func (_m *DimensionRepository) ListDimensions(ctx context.Context) ([]*models.Dimension, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.Dimension
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Dimension); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Dimension)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAccountRules provides a mock function with given fields: ctx, accountID, dimensionIDs
+func (_m *DimensionRepository) SetAccountRules(ctx context.Context, accountID uuid.UUID, dimensionIDs []uuid.UUID) error {
+	ret := _m.Called(ctx, accountID, dimensionIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) error); ok {
+		r0 = rf(ctx, accountID, dimensionIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDimension provides a mock function with given fields: ctx, dimension
+func (_m *DimensionRepository) UpdateDimension(ctx context.Context, dimension *models.Dimension) (*models.Dimension, error) {
+	ret := _m.Called(ctx, dimension)
+
+	var r0 *models.Dimension
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Dimension) *models.Dimension); ok {
+		r0 = rf(ctx, dimension)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Dimension)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Dimension) error); ok {
+		r1 = rf(ctx, dimension)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateValue provides a mock function with given fields: ctx, value
+func (_m *DimensionRepository) UpdateValue(ctx context.Context, value *models.DimensionValue) (*models.DimensionValue, error) {
+	ret := _m.Called(ctx, value)
+
+	var r0 *models.DimensionValue
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DimensionValue) *models.DimensionValue); ok {
+		r0 = rf(ctx, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DimensionValue)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DimensionValue) error); ok {
+		r1 = rf(ctx, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDimensionRepository creates a new instance of DimensionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDimensionRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DimensionRepository {
+	mock := &DimensionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.DimensionRepository = (*DimensionRepository)(nil)
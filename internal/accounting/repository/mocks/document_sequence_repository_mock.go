@@ -0,0 +1,122 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// DocumentSequenceRepository is an autogenerated mock type for the DocumentSequenceRepository type
+type DocumentSequenceRepository struct {
+	mock.Mock
+}
+
+// GetSequence provides a mock function with given fields: ctx, documentType
+func (_m *DocumentSequenceRepository) GetSequence(ctx context.Context, documentType string) (*models.DocumentSequence, error) {
+	ret := _m.Called(ctx, documentType)
+
+	var r0 *models.DocumentSequence
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.DocumentSequence); ok {
+		r0 = rf(ctx, documentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DocumentSequence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, documentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCounters provides a mock function with given fields: ctx, documentType
+func (_m *DocumentSequenceRepository) ListCounters(ctx context.Context, documentType string) ([]*models.DocumentSequenceCounter, error) {
+	ret := _m.Called(ctx, documentType)
+
+	var r0 []*models.DocumentSequenceCounter
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.DocumentSequenceCounter); ok {
+		r0 = rf(ctx, documentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DocumentSequenceCounter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, documentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSequences provides a mock function with given fields: ctx
+func (_m *DocumentSequenceRepository) ListSequences(ctx context.Context) ([]*models.DocumentSequence, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.DocumentSequence
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.DocumentSequence); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DocumentSequence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveSequence provides a mock function with given fields: ctx, sequence
+func (_m *DocumentSequenceRepository) SaveSequence(ctx context.Context, sequence *models.DocumentSequence) (*models.DocumentSequence, error) {
+	ret := _m.Called(ctx, sequence)
+
+	var r0 *models.DocumentSequence
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DocumentSequence) *models.DocumentSequence); ok {
+		r0 = rf(ctx, sequence)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DocumentSequence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DocumentSequence) error); ok {
+		r1 = rf(ctx, sequence)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDocumentSequenceRepository creates a new instance of DocumentSequenceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDocumentSequenceRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DocumentSequenceRepository {
+	mock := &DocumentSequenceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.DocumentSequenceRepository = (*DocumentSequenceRepository)(nil)
@@ -0,0 +1,212 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// FiscalPeriodRepository is an autogenerated mock type for the FiscalPeriodRepository type
+type FiscalPeriodRepository struct {
+	mock.Mock
+}
+
+// CreateFiscalYear provides a mock function with given fields: ctx, year
+func (_m *FiscalPeriodRepository) CreateFiscalYear(ctx context.Context, year *models.FiscalYear) (*models.FiscalYear, error) {
+	ret := _m.Called(ctx, year)
+
+	var r0 *models.FiscalYear
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FiscalYear) *models.FiscalYear); ok {
+		r0 = rf(ctx, year)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FiscalYear)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.FiscalYear) error); ok {
+		r1 = rf(ctx, year)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOverlappingFiscalYears provides a mock function with given fields: ctx, startDate, endDate
+func (_m *FiscalPeriodRepository) FindOverlappingFiscalYears(ctx context.Context, startDate time.Time, endDate time.Time) ([]*models.FiscalYear, error) {
+	ret := _m.Called(ctx, startDate, endDate)
+
+	var r0 []*models.FiscalYear
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []*models.FiscalYear); ok {
+		r0 = rf(ctx, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FiscalYear)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFiscalYearByID provides a mock function with given fields: ctx, id
+func (_m *FiscalPeriodRepository) GetFiscalYearByID(ctx context.Context, id uuid.UUID) (*models.FiscalYear, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.FiscalYear
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.FiscalYear); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FiscalYear)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPeriodByID provides a mock function with given fields: ctx, id
+func (_m *FiscalPeriodRepository) GetPeriodByID(ctx context.Context, id uuid.UUID) (*models.FiscalPeriod, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.FiscalPeriod
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.FiscalPeriod); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FiscalPeriod)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPeriodForDate provides a mock function with given fields: ctx, date
+func (_m *FiscalPeriodRepository) GetPeriodForDate(ctx context.Context, date time.Time) (*models.FiscalPeriod, error) {
+	ret := _m.Called(ctx, date)
+
+	var r0 *models.FiscalPeriod
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) *models.FiscalPeriod); ok {
+		r0 = rf(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FiscalPeriod)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFiscalYears provides a mock function with given fields: ctx
+func (_m *FiscalPeriodRepository) ListFiscalYears(ctx context.Context) ([]*models.FiscalYear, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.FiscalYear
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.FiscalYear); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FiscalYear)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkFiscalYearClosed provides a mock function with given fields: ctx, id, closingEntry, closedAt
+func (_m *FiscalPeriodRepository) MarkFiscalYearClosed(ctx context.Context, id uuid.UUID, closingEntry *models.JournalEntry, closedAt time.Time) error {
+	ret := _m.Called(ctx, id, closingEntry, closedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.JournalEntry, time.Time) error); ok {
+		r0 = rf(ctx, id, closingEntry, closedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkFiscalYearReopened provides a mock function with given fields: ctx, id, closingReversal, reason, periodStatus, reopenedAt
+func (_m *FiscalPeriodRepository) MarkFiscalYearReopened(ctx context.Context, id uuid.UUID, closingReversal *models.JournalEntry, reason string, periodStatus models.PeriodStatus, reopenedAt time.Time) error {
+	ret := _m.Called(ctx, id, closingReversal, reason, periodStatus, reopenedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.JournalEntry, string, models.PeriodStatus, time.Time) error); ok {
+		r0 = rf(ctx, id, closingReversal, reason, periodStatus, reopenedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePeriodStatus provides a mock function with given fields: ctx, id, status
+func (_m *FiscalPeriodRepository) UpdatePeriodStatus(ctx context.Context, id uuid.UUID, status models.PeriodStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.PeriodStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewFiscalPeriodRepository creates a new instance of FiscalPeriodRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFiscalPeriodRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FiscalPeriodRepository {
+	mock := &FiscalPeriodRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.FiscalPeriodRepository = (*FiscalPeriodRepository)(nil)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"erp-system/internal/accounting/models"
 	"erp-system/internal/accounting/repository"
+	"erp-system/pkg/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -75,6 +76,29 @@ func (_m *JournalEntryRepository) Delete(ctx context.Context, id uuid.UUID) erro
 	return r0
 }
 
+// GetAccountBalancesBefore provides a mock function with given fields: ctx, accountIDs, before
+func (_m *JournalEntryRepository) GetAccountBalancesBefore(ctx context.Context, accountIDs []uuid.UUID, before time.Time) (map[uuid.UUID]money.Amount, error) {
+	ret := _m.Called(ctx, accountIDs, before)
+
+	var r0 map[uuid.UUID]money.Amount
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, time.Time) map[uuid.UUID]money.Amount); ok {
+		r0 = rf(ctx, accountIDs, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]money.Amount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, accountIDs, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *JournalEntryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.JournalEntry, error) {
 	ret := _m.Called(ctx, id)
@@ -98,6 +122,52 @@ func (_m *JournalEntryRepository) GetByID(ctx context.Context, id uuid.UUID) (*m
 	return r0, r1
 }
 
+// GetCurrencyBalances provides a mock function with given fields: ctx, before
+func (_m *JournalEntryRepository) GetCurrencyBalances(ctx context.Context, before time.Time) ([]models.CurrencyBalance, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 []models.CurrencyBalance
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.CurrencyBalance); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CurrencyBalance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDimensionBalancesBefore provides a mock function with given fields: ctx, accountIDs, before, filter, groupBy
+func (_m *JournalEntryRepository) GetDimensionBalancesBefore(ctx context.Context, accountIDs []uuid.UUID, before time.Time, filter map[string]string, groupBy string) ([]models.DimensionBalance, error) {
+	ret := _m.Called(ctx, accountIDs, before, filter, groupBy)
+
+	var r0 []models.DimensionBalance
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, time.Time, map[string]string, string) []models.DimensionBalance); ok {
+		r0 = rf(ctx, accountIDs, before, filter, groupBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DimensionBalance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, time.Time, map[string]string, string) error); ok {
+		r1 = rf(ctx, accountIDs, before, filter, groupBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetJournalEntriesByAccountID provides a mock function with given fields: ctx, accountID, offset, limit, startDate, endDate
 func (_m *JournalEntryRepository) GetJournalEntriesByAccountID(ctx context.Context, accountID uuid.UUID, offset int, limit int, startDate time.Time, endDate time.Time) ([]*models.JournalEntry, int64, error) {
 	ret := _m.Called(ctx, accountID, offset, limit, startDate, endDate)
@@ -174,6 +244,29 @@ func (_m *JournalEntryRepository) GetJournalLinesByEntryID(ctx context.Context,
 	return r0, r1
 }
 
+// GetLedgerEntries provides a mock function with given fields: ctx, accountIDs, startDate, endDate
+func (_m *JournalEntryRepository) GetLedgerEntries(ctx context.Context, accountIDs []uuid.UUID, startDate time.Time, endDate time.Time) ([]models.JournalEntry, error) {
+	ret := _m.Called(ctx, accountIDs, startDate, endDate)
+
+	var r0 []models.JournalEntry
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, time.Time, time.Time) []models.JournalEntry); ok {
+		r0 = rf(ctx, accountIDs, startDate, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JournalEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, accountIDs, startDate, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, offset, limit, filters
 func (_m *JournalEntryRepository) List(ctx context.Context, offset int, limit int, filters map[string]interface{}) ([]*models.JournalEntry, int64, error) {
 	ret := _m.Called(ctx, offset, limit, filters)
@@ -218,6 +311,20 @@ func (_m *JournalEntryRepository) RemoveJournalLine(ctx context.Context, lineID
 	return r0
 }
 
+// SaveApprovalStep provides a mock function with given fields: ctx, entry, step
+func (_m *JournalEntryRepository) SaveApprovalStep(ctx context.Context, entry *models.JournalEntry, step *models.JournalEntryApproval) error {
+	ret := _m.Called(ctx, entry, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JournalEntry, *models.JournalEntryApproval) error); ok {
+		r0 = rf(ctx, entry, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, entry
 func (_m *JournalEntryRepository) Update(ctx context.Context, entry *models.JournalEntry) (*models.JournalEntry, error) {
 	ret := _m.Called(ctx, entry)
@@ -278,6 +385,29 @@ func (_m *JournalEntryRepository) UpdateJournalLine(ctx context.Context, line *m
 	return r0, r1
 }
 
+// VoidWithReversal provides a mock function with given fields: ctx, originalID, reversal, reason, voidedAt
+func (_m *JournalEntryRepository) VoidWithReversal(ctx context.Context, originalID uuid.UUID, reversal *models.JournalEntry, reason string, voidedAt time.Time) (*models.JournalEntry, error) {
+	ret := _m.Called(ctx, originalID, reversal, reason, voidedAt)
+
+	var r0 *models.JournalEntry
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.JournalEntry, string, time.Time) *models.JournalEntry); ok {
+		r0 = rf(ctx, originalID, reversal, reason, voidedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *models.JournalEntry, string, time.Time) error); ok {
+		r1 = rf(ctx, originalID, reversal, reason, voidedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewJournalEntryRepository creates a new instance of JournalEntryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewJournalEntryRepositoryMock(t interface {
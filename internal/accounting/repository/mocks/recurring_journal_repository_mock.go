@@ -0,0 +1,290 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// RecurringJournalRepository is an autogenerated mock type for the RecurringJournalRepository type
+type RecurringJournalRepository struct {
+	mock.Mock
+}
+
+// ClaimRun provides a mock function with given fields: ctx, templateID, runDate, nextRunOn
+func (_m *RecurringJournalRepository) ClaimRun(ctx context.Context, templateID uuid.UUID, runDate time.Time, nextRunOn *time.Time) (*models.RecurringJournalRun, error) {
+	ret := _m.Called(ctx, templateID, runDate, nextRunOn)
+
+	var r0 *models.RecurringJournalRun
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, *time.Time) *models.RecurringJournalRun); ok {
+		r0 = rf(ctx, templateID, runDate, nextRunOn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RecurringJournalRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time, *time.Time) error); ok {
+		r1 = rf(ctx, templateID, runDate, nextRunOn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteRun provides a mock function with given fields: ctx, runID, entry
+func (_m *RecurringJournalRepository) CompleteRun(ctx context.Context, runID uuid.UUID, entry *models.JournalEntry) (*models.JournalEntry, error) {
+	ret := _m.Called(ctx, runID, entry)
+
+	var r0 *models.JournalEntry
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.JournalEntry) *models.JournalEntry); ok {
+		r0 = rf(ctx, runID, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *models.JournalEntry) error); ok {
+		r1 = rf(ctx, runID, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTemplate provides a mock function with given fields: ctx, template
+func (_m *RecurringJournalRepository) CreateTemplate(ctx context.Context, template *models.RecurringJournalTemplate) (*models.RecurringJournalTemplate, error) {
+	ret := _m.Called(ctx, template)
+
+	var r0 *models.RecurringJournalTemplate
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RecurringJournalTemplate) *models.RecurringJournalTemplate); ok {
+		r0 = rf(ctx, template)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RecurringJournalTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.RecurringJournalTemplate) error); ok {
+		r1 = rf(ctx, template)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTemplate provides a mock function with given fields: ctx, id
+func (_m *RecurringJournalRepository) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTemplateByID provides a mock function with given fields: ctx, id
+func (_m *RecurringJournalRepository) GetTemplateByID(ctx context.Context, id uuid.UUID) (*models.RecurringJournalTemplate, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.RecurringJournalTemplate
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.RecurringJournalTemplate); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RecurringJournalTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTemplateByName provides a mock function with given fields: ctx, name
+func (_m *RecurringJournalRepository) GetTemplateByName(ctx context.Context, name string) (*models.RecurringJournalTemplate, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *models.RecurringJournalTemplate
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RecurringJournalTemplate); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RecurringJournalTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDueTemplates provides a mock function with given fields: ctx, asOf
+func (_m *RecurringJournalRepository) ListDueTemplates(ctx context.Context, asOf time.Time) ([]*models.RecurringJournalTemplate, error) {
+	ret := _m.Called(ctx, asOf)
+
+	var r0 []*models.RecurringJournalTemplate
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.RecurringJournalTemplate); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RecurringJournalTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRetryableRuns provides a mock function with given fields: ctx, asOf
+func (_m *RecurringJournalRepository) ListRetryableRuns(ctx context.Context, asOf time.Time) ([]*models.RecurringJournalRun, error) {
+	ret := _m.Called(ctx, asOf)
+
+	var r0 []*models.RecurringJournalRun
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.RecurringJournalRun); ok {
+		r0 = rf(ctx, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RecurringJournalRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRuns provides a mock function with given fields: ctx, templateID
+func (_m *RecurringJournalRepository) ListRuns(ctx context.Context, templateID uuid.UUID) ([]*models.RecurringJournalRun, error) {
+	ret := _m.Called(ctx, templateID)
+
+	var r0 []*models.RecurringJournalRun
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.RecurringJournalRun); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RecurringJournalRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTemplates provides a mock function with given fields: ctx
+func (_m *RecurringJournalRepository) ListTemplates(ctx context.Context) ([]*models.RecurringJournalTemplate, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.RecurringJournalTemplate
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.RecurringJournalTemplate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RecurringJournalTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordRunFailure provides a mock function with given fields: ctx, runID, message
+func (_m *RecurringJournalRepository) RecordRunFailure(ctx context.Context, runID uuid.UUID, message string) error {
+	ret := _m.Called(ctx, runID, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, runID, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTemplate provides a mock function with given fields: ctx, template
+func (_m *RecurringJournalRepository) UpdateTemplate(ctx context.Context, template *models.RecurringJournalTemplate) (*models.RecurringJournalTemplate, error) {
+	ret := _m.Called(ctx, template)
+
+	var r0 *models.RecurringJournalTemplate
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RecurringJournalTemplate) *models.RecurringJournalTemplate); ok {
+		r0 = rf(ctx, template)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RecurringJournalTemplate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.RecurringJournalTemplate) error); ok {
+		r1 = rf(ctx, template)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRecurringJournalRepository creates a new instance of RecurringJournalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecurringJournalRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecurringJournalRepository {
+	mock := &RecurringJournalRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.RecurringJournalRepository = (*RecurringJournalRepository)(nil)
@@ -0,0 +1,175 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// ScheduledReversalRepository is an autogenerated mock type for the ScheduledReversalRepository type
+type ScheduledReversalRepository struct {
+	mock.Mock
+}
+
+// CompleteWithEntry provides a mock function with given fields: ctx, id, reversalEntry, processedAt
+func (_m *ScheduledReversalRepository) CompleteWithEntry(ctx context.Context, id uuid.UUID, reversalEntry *models.JournalEntry, processedAt time.Time) (*models.JournalEntry, error) {
+	ret := _m.Called(ctx, id, reversalEntry, processedAt)
+
+	var r0 *models.JournalEntry
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.JournalEntry, time.Time) *models.JournalEntry); ok {
+		r0 = rf(ctx, id, reversalEntry, processedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JournalEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *models.JournalEntry, time.Time) error); ok {
+		r1 = rf(ctx, id, reversalEntry, processedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, reversal
+func (_m *ScheduledReversalRepository) Create(ctx context.Context, reversal *models.ScheduledReversal) (*models.ScheduledReversal, error) {
+	ret := _m.Called(ctx, reversal)
+
+	var r0 *models.ScheduledReversal
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ScheduledReversal) *models.ScheduledReversal); ok {
+		r0 = rf(ctx, reversal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ScheduledReversal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ScheduledReversal) error); ok {
+		r1 = rf(ctx, reversal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *ScheduledReversalRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ScheduledReversal, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.ScheduledReversal
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ScheduledReversal); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ScheduledReversal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingByJournalEntryID provides a mock function with given fields: ctx, journalEntryID
+func (_m *ScheduledReversalRepository) GetPendingByJournalEntryID(ctx context.Context, journalEntryID uuid.UUID) (*models.ScheduledReversal, error) {
+	ret := _m.Called(ctx, journalEntryID)
+
+	var r0 *models.ScheduledReversal
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.ScheduledReversal); ok {
+		r0 = rf(ctx, journalEntryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ScheduledReversal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, journalEntryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPending provides a mock function with given fields: ctx, dueOnOrBefore
+func (_m *ScheduledReversalRepository) ListPending(ctx context.Context, dueOnOrBefore time.Time) ([]*models.ScheduledReversal, error) {
+	ret := _m.Called(ctx, dueOnOrBefore)
+
+	var r0 []*models.ScheduledReversal
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.ScheduledReversal); ok {
+		r0 = rf(ctx, dueOnOrBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ScheduledReversal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, dueOnOrBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordFailure provides a mock function with given fields: ctx, id, message
+func (_m *ScheduledReversalRepository) RecordFailure(ctx context.Context, id uuid.UUID, message string) error {
+	ret := _m.Called(ctx, id, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, from, to
+func (_m *ScheduledReversalRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from models.ReversalStatus, to models.ReversalStatus) error {
+	ret := _m.Called(ctx, id, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, models.ReversalStatus, models.ReversalStatus) error); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewScheduledReversalRepository creates a new instance of ScheduledReversalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduledReversalRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduledReversalRepository {
+	mock := &ScheduledReversalRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.ScheduledReversalRepository = (*ScheduledReversalRepository)(nil)
@@ -0,0 +1,169 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// TaxRepository is an autogenerated mock type for the TaxRepository type
+type TaxRepository struct {
+	mock.Mock
+}
+
+// CreateRate provides a mock function with given fields: ctx, rate
+func (_m *TaxRepository) CreateRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	ret := _m.Called(ctx, rate)
+
+	var r0 *models.TaxRate
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaxRate) *models.TaxRate); ok {
+		r0 = rf(ctx, rate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaxRate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TaxRate) error); ok {
+		r1 = rf(ctx, rate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTaxCode provides a mock function with given fields: ctx, taxCode
+func (_m *TaxRepository) CreateTaxCode(ctx context.Context, taxCode *models.TaxCode) (*models.TaxCode, error) {
+	ret := _m.Called(ctx, taxCode)
+
+	var r0 *models.TaxCode
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaxCode) *models.TaxCode); ok {
+		r0 = rf(ctx, taxCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaxCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TaxCode) error); ok {
+		r1 = rf(ctx, taxCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaxCode provides a mock function with given fields: ctx, id
+func (_m *TaxRepository) GetTaxCode(ctx context.Context, id uuid.UUID) (*models.TaxCode, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.TaxCode
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.TaxCode); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaxCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaxCodeByCode provides a mock function with given fields: ctx, code
+func (_m *TaxRepository) GetTaxCodeByCode(ctx context.Context, code string) (*models.TaxCode, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *models.TaxCode
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.TaxCode); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaxCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTaxCodes provides a mock function with given fields: ctx
+func (_m *TaxRepository) ListTaxCodes(ctx context.Context) ([]*models.TaxCode, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.TaxCode
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.TaxCode); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaxCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTaxCode provides a mock function with given fields: ctx, taxCode
+func (_m *TaxRepository) UpdateTaxCode(ctx context.Context, taxCode *models.TaxCode) (*models.TaxCode, error) {
+	ret := _m.Called(ctx, taxCode)
+
+	var r0 *models.TaxCode
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaxCode) *models.TaxCode); ok {
+		r0 = rf(ctx, taxCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaxCode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TaxCode) error); ok {
+		r1 = rf(ctx, taxCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaxRepository creates a new instance of TaxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaxRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaxRepository {
+	mock := &TaxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.TaxRepository = (*TaxRepository)(nil)
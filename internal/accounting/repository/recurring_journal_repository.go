@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecurringJournalRepository defines the interface for database operations for recurring journal templates
+// and the runs that record which occurrences have already been turned into entries.
+type RecurringJournalRepository interface {
+	CreateTemplate(ctx context.Context, template *models.RecurringJournalTemplate) (*models.RecurringJournalTemplate, error)
+	GetTemplateByID(ctx context.Context, id uuid.UUID) (*models.RecurringJournalTemplate, error)
+	GetTemplateByName(ctx context.Context, name string) (*models.RecurringJournalTemplate, error)
+	ListTemplates(ctx context.Context) ([]*models.RecurringJournalTemplate, error)
+	UpdateTemplate(ctx context.Context, template *models.RecurringJournalTemplate) (*models.RecurringJournalTemplate, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+	ListDueTemplates(ctx context.Context, asOf time.Time) ([]*models.RecurringJournalTemplate, error)
+	ClaimRun(ctx context.Context, templateID uuid.UUID, runDate time.Time, nextRunOn *time.Time) (*models.RecurringJournalRun, error)
+	ListRetryableRuns(ctx context.Context, asOf time.Time) ([]*models.RecurringJournalRun, error)
+	CompleteRun(ctx context.Context, runID uuid.UUID, entry *models.JournalEntry) (*models.JournalEntry, error)
+	RecordRunFailure(ctx context.Context, runID uuid.UUID, message string) error
+	ListRuns(ctx context.Context, templateID uuid.UUID) ([]*models.RecurringJournalRun, error)
+}
+
+// gormRecurringJournalRepository is an implementation of RecurringJournalRepository using GORM.
+type gormRecurringJournalRepository struct {
+	db *gorm.DB
+}
+
+// NewRecurringJournalRepository creates a new GORM-based RecurringJournalRepository.
+func NewRecurringJournalRepository(db *gorm.DB) RecurringJournalRepository {
+	return &gormRecurringJournalRepository{db: db}
+}
+
+func (r *gormRecurringJournalRepository) CreateTemplate(ctx context.Context, template *models.RecurringJournalTemplate) (*models.RecurringJournalTemplate, error) {
+	logger.InfoLogger.Printf("Repository: Creating recurring journal template %s", template.Name)
+	if err := r.db.WithContext(ctx).Create(template).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating recurring journal template %s: %v", template.Name, err)
+		return nil, errors.NewInternalServerError("failed to create recurring journal template", err)
+	}
+	return template, nil
+}
+
+func (r *gormRecurringJournalRepository) GetTemplateByID(ctx context.Context, id uuid.UUID) (*models.RecurringJournalTemplate, error) {
+	var template models.RecurringJournalTemplate
+	if err := r.db.WithContext(ctx).Preload("Lines").First(&template, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			logger.WarnLogger.Printf("Repository: Recurring journal template with ID %s not found", id)
+			return nil, errors.NewNotFoundError("recurring_journal_template", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving recurring journal template by ID %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get recurring journal template by ID %s", id), err)
+	}
+	return &template, nil
+}
+
+func (r *gormRecurringJournalRepository) GetTemplateByName(ctx context.Context, name string) (*models.RecurringJournalTemplate, error) {
+	var template models.RecurringJournalTemplate
+	if err := r.db.WithContext(ctx).First(&template, "name = ?", name).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("recurring_journal_template_name", name)
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get recurring journal template by name %s", name), err)
+	}
+	return &template, nil
+}
+
+func (r *gormRecurringJournalRepository) ListTemplates(ctx context.Context) ([]*models.RecurringJournalTemplate, error) {
+	var templates []*models.RecurringJournalTemplate
+	if err := r.db.WithContext(ctx).Preload("Lines").Order("name asc").Find(&templates).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing recurring journal templates: %v", err)
+		return nil, errors.NewInternalServerError("failed to list recurring journal templates", err)
+	}
+	return templates, nil
+}
+
+// UpdateTemplate saves the template header and replaces its lines in one transaction.
+func (r *gormRecurringJournalRepository) UpdateTemplate(ctx context.Context, template *models.RecurringJournalTemplate) (*models.RecurringJournalTemplate, error) {
+	logger.InfoLogger.Printf("Repository: Updating recurring journal template %s", template.ID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Lines").Save(template).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", template.ID).Delete(&models.RecurringJournalLine{}).Error; err != nil {
+			return err
+		}
+		for i := range template.Lines {
+			template.Lines[i].ID = uuid.Nil
+			template.Lines[i].TemplateID = template.ID
+		}
+		if len(template.Lines) > 0 {
+			return tx.Create(&template.Lines).Error
+		}
+		return nil
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Transaction failed for updating recurring journal template %s: %v", template.ID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update recurring journal template %s", template.ID), err)
+	}
+	return template, nil
+}
+
+func (r *gormRecurringJournalRepository) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	logger.InfoLogger.Printf("Repository: Deleting recurring journal template %s", id)
+	result := r.db.WithContext(ctx).Delete(&models.RecurringJournalTemplate{}, "id = ?", id)
+	if result.Error != nil {
+		logger.ErrorLogger.Printf("Repository: Error deleting recurring journal template %s: %v", id, result.Error)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to delete recurring journal template %s", id), result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("recurring_journal_template", id.String())
+	}
+	return nil
+}
+
+// ListDueTemplates returns active templates whose next occurrence is on or before asOf.
+func (r *gormRecurringJournalRepository) ListDueTemplates(ctx context.Context, asOf time.Time) ([]*models.RecurringJournalTemplate, error) {
+	var templates []*models.RecurringJournalTemplate
+	err := r.db.WithContext(ctx).Preload("Lines").
+		Where("is_active = ? AND next_run_on IS NOT NULL AND next_run_on <= ?", true, asOf.Format("2006-01-02")).
+		Order("next_run_on asc, name asc").
+		Find(&templates).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing due recurring journal templates: %v", err)
+		return nil, errors.NewInternalServerError("failed to list due recurring journal templates", err)
+	}
+	return templates, nil
+}
+
+// ClaimRun records the occurrence on runDate and moves the template on to nextRunOn in one
+// transaction. It returns a ConflictError if the occurrence was already claimed, so an entry is
+// never created twice for the same date, even across restarts or concurrent schedulers.
+func (r *gormRecurringJournalRepository) ClaimRun(ctx context.Context, templateID uuid.UUID, runDate time.Time, nextRunOn *time.Time) (*models.RecurringJournalRun, error) {
+	run := &models.RecurringJournalRun{TemplateID: templateID, RunDate: runDate, Status: models.RunPending}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RecurringJournalTemplate{}).
+			Where("id = ? AND next_run_on = ?", templateID, runDate.Format("2006-01-02")).
+			Updates(map[string]interface{}{"last_run_on": runDate, "next_run_on": nextRunOn})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("occurrence %s of recurring journal template %s was already claimed", runDate.Format("2006-01-02"), templateID))
+		}
+		return tx.Create(run).Error
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Transaction failed for claiming run %s of recurring journal template %s: %v", runDate.Format("2006-01-02"), templateID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to claim run of recurring journal template %s", templateID), err)
+	}
+	return run, nil
+}
+
+// ListRetryableRuns returns PENDING and FAILED runs dated on or before asOf, oldest first, with
+// their template and its lines. Runs of paused or deleted templates are left alone.
+func (r *gormRecurringJournalRepository) ListRetryableRuns(ctx context.Context, asOf time.Time) ([]*models.RecurringJournalRun, error) {
+	var runs []*models.RecurringJournalRun
+	err := r.db.WithContext(ctx).Preload("Template.Lines").
+		Joins("JOIN recurring_journal_templates t ON t.id = recurring_journal_runs.template_id").
+		Where("recurring_journal_runs.status IN ? AND recurring_journal_runs.run_date <= ?", []models.RecurringRunStatus{models.RunPending, models.RunFailed}, asOf.Format("2006-01-02")).
+		Where("t.is_active = ? AND t.deleted_at IS NULL", true).
+		Order("recurring_journal_runs.run_date asc, recurring_journal_runs.created_at asc").
+		Find(&runs).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing retryable recurring journal runs: %v", err)
+		return nil, errors.NewInternalServerError("failed to list retryable recurring journal runs", err)
+	}
+	return runs, nil
+}
+
+// CompleteRun creates the run's journal entry and marks the run CREATED in one transaction. It
+// returns a ConflictError if the run was completed concurrently, so an occurrence never produces
+// two entries and a crash never leaves an entry without a completed run.
+func (r *gormRecurringJournalRepository) CompleteRun(ctx context.Context, runID uuid.UUID, entry *models.JournalEntry) (*models.JournalEntry, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := numberJournalEntry(tx, entry); err != nil {
+			return err
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.RecurringJournalRun{}).
+			Where("id = ? AND status IN ?", runID, []models.RecurringRunStatus{models.RunPending, models.RunFailed}).
+			Updates(map[string]interface{}{"status": models.RunCreated, "journal_entry_id": entry.ID, "error": ""})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("recurring journal run %s was already completed", runID))
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Transaction failed for completing recurring journal run %s: %v", runID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to complete recurring journal run %s", runID), err)
+	}
+	return entry, nil
+}
+
+// RecordRunFailure marks a run FAILED and stores why; it is retried on the next scheduler run.
+func (r *gormRecurringJournalRepository) RecordRunFailure(ctx context.Context, runID uuid.UUID, message string) error {
+	if len(message) > 255 {
+		message = message[:255]
+	}
+	err := r.db.WithContext(ctx).Model(&models.RecurringJournalRun{}).
+		Where("id = ? AND status <> ?", runID, models.RunCreated).
+		Updates(map[string]interface{}{"status": models.RunFailed, "error": message}).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error recording failure of recurring journal run %s: %v", runID, err)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to record failure of recurring journal run %s", runID), err)
+	}
+	return nil
+}
+
+func (r *gormRecurringJournalRepository) ListRuns(ctx context.Context, templateID uuid.UUID) ([]*models.RecurringJournalRun, error) {
+	var runs []*models.RecurringJournalRun
+	if err := r.db.WithContext(ctx).Where("template_id = ?", templateID).Order("run_date desc").Find(&runs).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing runs of recurring journal template %s: %v", templateID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to list runs of recurring journal template %s", templateID), err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduledReversalRepository defines the interface for database operations for scheduled automatic reversals.
+type ScheduledReversalRepository interface {
+	Create(ctx context.Context, reversal *models.ScheduledReversal) (*models.ScheduledReversal, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ScheduledReversal, error)
+	GetPendingByJournalEntryID(ctx context.Context, journalEntryID uuid.UUID) (*models.ScheduledReversal, error)
+	ListPending(ctx context.Context, dueOnOrBefore time.Time) ([]*models.ScheduledReversal, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.ReversalStatus) error
+	RecordFailure(ctx context.Context, id uuid.UUID, message string) error
+	CompleteWithEntry(ctx context.Context, id uuid.UUID, reversalEntry *models.JournalEntry, processedAt time.Time) (*models.JournalEntry, error)
+}
+
+// gormScheduledReversalRepository is an implementation of ScheduledReversalRepository using GORM.
+type gormScheduledReversalRepository struct {
+	db *gorm.DB
+}
+
+// NewScheduledReversalRepository creates a new GORM-based ScheduledReversalRepository.
+func NewScheduledReversalRepository(db *gorm.DB) ScheduledReversalRepository {
+	return &gormScheduledReversalRepository{db: db}
+}
+
+func (r *gormScheduledReversalRepository) Create(ctx context.Context, reversal *models.ScheduledReversal) (*models.ScheduledReversal, error) {
+	logger.InfoLogger.Printf("Repository: Scheduling reversal of journal entry %s on %s", reversal.JournalEntryID, reversal.ReverseOn.Format("2006-01-02"))
+	if err := r.db.WithContext(ctx).Create(reversal).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error scheduling reversal of journal entry %s: %v", reversal.JournalEntryID, err)
+		return nil, errors.NewInternalServerError("failed to schedule reversal", err)
+	}
+	return reversal, nil
+}
+
+func (r *gormScheduledReversalRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ScheduledReversal, error) {
+	var reversal models.ScheduledReversal
+	if err := r.db.WithContext(ctx).Preload("JournalEntry").First(&reversal, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			logger.WarnLogger.Printf("Repository: Scheduled reversal with ID %s not found", id)
+			return nil, errors.NewNotFoundError("scheduled_reversal", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving scheduled reversal by ID %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get scheduled reversal by ID %s", id), err)
+	}
+	return &reversal, nil
+}
+
+// GetPendingByJournalEntryID returns the PENDING reversal of a journal entry, or a NotFoundError.
+func (r *gormScheduledReversalRepository) GetPendingByJournalEntryID(ctx context.Context, journalEntryID uuid.UUID) (*models.ScheduledReversal, error) {
+	var reversal models.ScheduledReversal
+	err := r.db.WithContext(ctx).
+		Where("journal_entry_id = ? AND status = ?", journalEntryID, models.ReversalPending).
+		First(&reversal).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("scheduled_reversal_for_entry", journalEntryID.String())
+		}
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get scheduled reversal for journal entry %s", journalEntryID), err)
+	}
+	return &reversal, nil
+}
+
+// ListPending returns PENDING reversals ordered by date, with the original entry and its lines
+// preloaded so they can be reversed. A zero dueOnOrBefore returns all of them.
+func (r *gormScheduledReversalRepository) ListPending(ctx context.Context, dueOnOrBefore time.Time) ([]*models.ScheduledReversal, error) {
+	var reversals []*models.ScheduledReversal
+	query := r.db.WithContext(ctx).Preload("JournalEntry.JournalLines").Where("status = ?", models.ReversalPending)
+	if !dueOnOrBefore.IsZero() {
+		query = query.Where("reverse_on <= ?", dueOnOrBefore.Format("2006-01-02"))
+	}
+	if err := query.Order("reverse_on asc, created_at asc").Find(&reversals).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing pending reversals: %v", err)
+		return nil, errors.NewInternalServerError("failed to list pending reversals", err)
+	}
+	return reversals, nil
+}
+
+// UpdateStatus moves a reversal from status from to status to. It returns a ConflictError if the
+// reversal is no longer in status from.
+func (r *gormScheduledReversalRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to models.ReversalStatus) error {
+	result := r.db.WithContext(ctx).Model(&models.ScheduledReversal{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating scheduled reversal %s status: %v", id, result.Error)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to update status for scheduled reversal %s", id), result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewConflictError(fmt.Sprintf("scheduled reversal %s is no longer %s", id, from))
+	}
+	return nil
+}
+
+// RecordFailure stores why the last processing attempt failed; the reversal stays PENDING.
+func (r *gormScheduledReversalRepository) RecordFailure(ctx context.Context, id uuid.UUID, message string) error {
+	if len(message) > 255 {
+		message = message[:255]
+	}
+	if err := r.db.WithContext(ctx).Model(&models.ScheduledReversal{}).Where("id = ?", id).Update("last_error", message).Error; err != nil {
+		return errors.NewInternalServerError(fmt.Sprintf("failed to record failure for scheduled reversal %s", id), err)
+	}
+	return nil
+}
+
+// CompleteWithEntry creates the reversing entry, links it to the original entry and marks the
+// reversal POSTED in one transaction, so a reversal is never posted twice.
+func (r *gormScheduledReversalRepository) CompleteWithEntry(ctx context.Context, id uuid.UUID, reversalEntry *models.JournalEntry, processedAt time.Time) (*models.JournalEntry, error) {
+	logger.InfoLogger.Printf("Repository: Posting scheduled reversal %s", id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := numberJournalEntry(tx, reversalEntry); err != nil {
+			return err
+		}
+		if err := tx.Create(reversalEntry).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.ScheduledReversal{}).
+			Where("id = ? AND status = ?", id, models.ReversalPending).
+			Updates(map[string]interface{}{
+				"status":            models.ReversalPosted,
+				"reversal_entry_id": reversalEntry.ID,
+				"last_error":        "",
+				"processed_at":      processedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("scheduled reversal %s is no longer PENDING", id))
+		}
+		if reversalEntry.ReversalOfID != nil {
+			return tx.Model(&models.JournalEntry{}).Where("id = ?", *reversalEntry.ReversalOfID).Update("reversed_by_id", reversalEntry.ID).Error
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Transaction failed for posting scheduled reversal %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to post scheduled reversal %s", id), err)
+	}
+	return reversalEntry, nil
+}
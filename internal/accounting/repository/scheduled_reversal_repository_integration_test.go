@@ -0,0 +1,163 @@
+package repository_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/internal/accounting/repository"
+	"erp-system/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// ScheduledReversalRepositoryIntegrationTestSuite defines the suite for ScheduledReversalRepository integration tests.
+type ScheduledReversalRepositoryIntegrationTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	repo        repository.ScheduledReversalRepository
+	journalRepo repository.JournalEntryRepository
+	coaRepo     repository.ChartOfAccountRepository
+	ctx         context.Context
+
+	expenseAccount *models.ChartOfAccount
+	accruedAccount *models.ChartOfAccount
+}
+
+func (s *ScheduledReversalRepositoryIntegrationTestSuite) SetupSuite() {
+	s.ctx = companyCtx_acc_repo()
+	s.db = dbInstance_acc_repo.WithContext(s.ctx)
+	s.repo = repository.NewScheduledReversalRepository(s.db)
+	s.journalRepo = repository.NewJournalEntryRepository(s.db)
+	s.coaRepo = repository.NewChartOfAccountRepository(s.db)
+}
+
+func (s *ScheduledReversalRepositoryIntegrationTestSuite) SetupTest() {
+	resetAccRepoTables(s.T(), s.db)
+	s.expenseAccount = s.createTestAccount("5100", "Utilities Test", models.Expense)
+	s.accruedAccount = s.createTestAccount("2100", "Accrued Liabilities Test", models.Liability)
+}
+
+func (s *ScheduledReversalRepositoryIntegrationTestSuite) createTestAccount(code, name string, accType models.AccountType) *models.ChartOfAccount {
+	acc := models.ChartOfAccount{AccountCode: code, AccountName: name, AccountType: accType, IsActive: true}
+	createdAcc, err := s.coaRepo.Create(s.ctx, &acc)
+	s.Require().NoError(err, "Failed to create test account %s", code)
+	return createdAcc
+}
+
+// TestScheduledReversalRepositoryIntegration runs the entire suite.
+func TestScheduledReversalRepositoryIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping accounting repository integration tests in short mode.")
+	}
+	suite.Run(t, new(ScheduledReversalRepositoryIntegrationTestSuite))
+}
+
+func (s *ScheduledReversalRepositoryIntegrationTestSuite) TestListPending_PreloadsOriginalLines() {
+	accrual, err := s.journalRepo.Create(s.ctx, &models.JournalEntry{
+		EntryDate:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Description: "Accrued utilities",
+		Status:      models.StatusPosted,
+		JournalLines: []models.JournalLine{
+			{AccountID: s.expenseAccount.ID, Amount: money.MustParse("120.00"), IsDebit: true, Currency: "USD"},
+			{AccountID: s.accruedAccount.ID, Amount: money.MustParse("120.00"), IsDebit: false, Currency: "USD"},
+		},
+	})
+	s.Require().NoError(err)
+
+	reverseOn := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.repo.Create(s.ctx, &models.ScheduledReversal{JournalEntryID: accrual.ID, ReverseOn: reverseOn, Status: models.ReversalPending})
+	s.Require().NoError(err)
+
+	due, err := s.repo.ListPending(s.ctx, reverseOn)
+	s.Require().NoError(err)
+	s.Require().Len(due, 1)
+	s.Require().NotNil(due[0].JournalEntry)
+	s.Equal(accrual.ID, due[0].JournalEntry.ID)
+	s.Len(due[0].JournalEntry.JournalLines, 2, "The original's lines must be loaded or the reversal posts empty")
+
+	notYetDue, err := s.repo.ListPending(s.ctx, reverseOn.AddDate(0, 0, -1))
+	s.Require().NoError(err)
+	s.Empty(notYetDue)
+}
+
+func (s *ScheduledReversalRepositoryIntegrationTestSuite) TestVoidWithReversal_CancelsPendingReversal() {
+	accrual, err := s.journalRepo.Create(s.ctx, &models.JournalEntry{
+		EntryDate:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Description: "Accrued utilities",
+		Status:      models.StatusPosted,
+		JournalLines: []models.JournalLine{
+			{AccountID: s.expenseAccount.ID, Amount: money.MustParse("120.00"), IsDebit: true, Currency: "USD"},
+			{AccountID: s.accruedAccount.ID, Amount: money.MustParse("120.00"), IsDebit: false, Currency: "USD"},
+		},
+	})
+	s.Require().NoError(err)
+	scheduled, err := s.repo.Create(s.ctx, &models.ScheduledReversal{JournalEntryID: accrual.ID, ReverseOn: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Status: models.ReversalPending})
+	s.Require().NoError(err)
+
+	_, err = s.journalRepo.VoidWithReversal(s.ctx, accrual.ID, &models.JournalEntry{
+		EntryDate:   accrual.EntryDate,
+		Description: "Reversal of accrued utilities",
+		Status:      models.StatusPosted,
+		EntryType:   models.EntryTypeReversal,
+		JournalLines: []models.JournalLine{
+			{AccountID: s.expenseAccount.ID, Amount: money.MustParse("120.00"), IsDebit: false, Currency: "USD"},
+			{AccountID: s.accruedAccount.ID, Amount: money.MustParse("120.00"), IsDebit: true, Currency: "USD"},
+		},
+	}, "Posted twice", time.Now())
+	s.Require().NoError(err)
+
+	cancelled, err := s.repo.GetByID(s.ctx, scheduled.ID)
+	s.Require().NoError(err)
+	s.Equal(models.ReversalCancelled, cancelled.Status, "The voided accrual must not be reversed again")
+}
+
+func (s *ScheduledReversalRepositoryIntegrationTestSuite) TestCreate_SchedulesReversalWithPostedEntry() {
+	reverseOn := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	accrual, err := s.journalRepo.Create(s.ctx, &models.JournalEntry{
+		EntryDate:     time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Description:   "Accrued utilities",
+		Status:        models.StatusPosted,
+		AutoReverseOn: &reverseOn,
+		JournalLines: []models.JournalLine{
+			{AccountID: s.expenseAccount.ID, Amount: money.MustParse("120.00"), IsDebit: true, Currency: "USD"},
+			{AccountID: s.accruedAccount.ID, Amount: money.MustParse("120.00"), IsDebit: false, Currency: "USD"},
+		},
+	})
+	s.Require().NoError(err)
+
+	scheduled, err := s.repo.GetPendingByJournalEntryID(s.ctx, accrual.ID)
+	s.Require().NoError(err)
+	s.Equal(reverseOn, scheduled.ReverseOn.UTC())
+}
+
+func (s *ScheduledReversalRepositoryIntegrationTestSuite) TestCreate_RollsBackEntryWhenSchedulingFails() {
+	const callback = "test:fail_scheduled_reversal"
+	err := s.db.Callback().Create().Before("gorm:create").Register(callback, func(db *gorm.DB) {
+		if db.Statement.Table == "scheduled_reversals" {
+			db.AddError(errors.NewInternalServerError("scheduled reversal insert failed", nil))
+		}
+	})
+	s.Require().NoError(err)
+	defer func() { s.Require().NoError(s.db.Callback().Create().Remove(callback)) }()
+
+	reverseOn := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.journalRepo.Create(s.ctx, &models.JournalEntry{
+		EntryDate:     time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Description:   "Accrued utilities",
+		Reference:     "ACR-ROLLBACK",
+		Status:        models.StatusPosted,
+		AutoReverseOn: &reverseOn,
+		JournalLines: []models.JournalLine{
+			{AccountID: s.expenseAccount.ID, Amount: money.MustParse("120.00"), IsDebit: true, Currency: "USD"},
+			{AccountID: s.accruedAccount.ID, Amount: money.MustParse("120.00"), IsDebit: false, Currency: "USD"},
+		},
+	})
+	s.Require().Error(err)
+
+	var count int64
+	s.Require().NoError(s.db.Model(&models.JournalEntry{}).Where("reference = ?", "ACR-ROLLBACK").Count(&count).Error)
+	s.Zero(count, "The accrual must not stay posted without its scheduled reversal")
+}
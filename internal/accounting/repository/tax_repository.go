@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaxRepository defines the interface for database operations for tax codes and their rates.
+type TaxRepository interface {
+	CreateTaxCode(ctx context.Context, taxCode *models.TaxCode) (*models.TaxCode, error)
+	GetTaxCode(ctx context.Context, id uuid.UUID) (*models.TaxCode, error)
+	GetTaxCodeByCode(ctx context.Context, code string) (*models.TaxCode, error)
+	ListTaxCodes(ctx context.Context) ([]*models.TaxCode, error)
+	UpdateTaxCode(ctx context.Context, taxCode *models.TaxCode) (*models.TaxCode, error)
+	CreateRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error)
+}
+
+// gormTaxRepository is an implementation of TaxRepository using GORM.
+type gormTaxRepository struct {
+	db *gorm.DB
+}
+
+// NewTaxRepository creates a new GORM-based TaxRepository.
+func NewTaxRepository(db *gorm.DB) TaxRepository {
+	return &gormTaxRepository{db: db}
+}
+
+// preloadRates loads a tax code's rates, oldest first.
+func preloadRates(db *gorm.DB) *gorm.DB {
+	return db.Order("effective_from asc")
+}
+
+func (r *gormTaxRepository) CreateTaxCode(ctx context.Context, taxCode *models.TaxCode) (*models.TaxCode, error) {
+	logger.InfoLogger.Printf("Repository: Creating tax code %s", taxCode.Code)
+	if err := r.db.WithContext(ctx).Create(taxCode).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating tax code %s: %v", taxCode.Code, err)
+		return nil, errors.NewInternalServerError("failed to create tax code", err)
+	}
+	return taxCode, nil
+}
+
+func (r *gormTaxRepository) GetTaxCode(ctx context.Context, id uuid.UUID) (*models.TaxCode, error) {
+	var taxCode models.TaxCode
+	if err := r.db.WithContext(ctx).Preload("Rates", preloadRates).First(&taxCode, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("tax_code", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving tax code %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get tax code %s", id), err)
+	}
+	return &taxCode, nil
+}
+
+func (r *gormTaxRepository) GetTaxCodeByCode(ctx context.Context, code string) (*models.TaxCode, error) {
+	var taxCode models.TaxCode
+	if err := r.db.WithContext(ctx).Preload("Rates", preloadRates).First(&taxCode, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("tax_code_code", code)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving tax code %s: %v", code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get tax code %s", code), err)
+	}
+	return &taxCode, nil
+}
+
+// ListTaxCodes returns every tax code with its rates, ordered by code.
+func (r *gormTaxRepository) ListTaxCodes(ctx context.Context) ([]*models.TaxCode, error) {
+	var taxCodes []*models.TaxCode
+	if err := r.db.WithContext(ctx).Preload("Rates", preloadRates).Order("code asc").Find(&taxCodes).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing tax codes: %v", err)
+		return nil, errors.NewInternalServerError("failed to list tax codes", err)
+	}
+	return taxCodes, nil
+}
+
+// UpdateTaxCode saves the tax code itself; its rates are added with CreateRate.
+func (r *gormTaxRepository) UpdateTaxCode(ctx context.Context, taxCode *models.TaxCode) (*models.TaxCode, error) {
+	logger.InfoLogger.Printf("Repository: Updating tax code %s", taxCode.Code)
+	if err := r.db.WithContext(ctx).Omit("Rates").Save(taxCode).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating tax code %s: %v", taxCode.Code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update tax code %s", taxCode.Code), err)
+	}
+	return r.GetTaxCode(ctx, taxCode.ID)
+}
+
+func (r *gormTaxRepository) CreateRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	logger.InfoLogger.Printf("Repository: Creating rate of tax code %s from %s", rate.TaxCodeID, rate.EffectiveFrom.Format("2006-01-02"))
+	if err := r.db.WithContext(ctx).Create(rate).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating rate of tax code %s: %v", rate.TaxCodeID, err)
+		return nil, errors.NewInternalServerError("failed to create tax rate", err)
+	}
+	return rate, nil
+}
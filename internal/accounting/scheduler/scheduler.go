@@ -0,0 +1,89 @@
+// Package scheduler runs periodic accounting jobs, such as posting due automatic
+// reversals, inside the server process.
+package scheduler
+
+import (
+	"context"
+	"erp-system/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Job is a unit of periodic work. Run receives the time of the tick and must be safe to
+// repeat: every job is expected to persist its own progress so that ticks after a restart
+// do not redo work already done.
+type Job struct {
+	Name string
+	Run  func(ctx context.Context, now time.Time) error
+}
+
+// Scheduler runs its jobs once on Start and then on every interval until stopped.
+type Scheduler struct {
+	interval time.Duration
+	jobs     []Job
+	now      func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New creates a Scheduler that runs jobs every interval.
+func New(interval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{interval: interval, jobs: jobs, now: time.Now}
+}
+
+// Start runs the jobs in a background goroutine until ctx is cancelled or Stop is called.
+// Calling Start on a running scheduler has no effect.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.done = make(chan struct{})
+	logger.InfoLogger.Printf("Scheduler: Starting %d job(s) every %s", len(s.jobs), s.interval)
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		s.RunOnce(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.RunOnce(ctx)
+			}
+		}
+	}()
+}
+
+// Stop cancels the background goroutine and waits for the running tick to finish.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+	logger.InfoLogger.Println("Scheduler: Stopped")
+}
+
+// RunOnce runs every job once, in order. A failing job is logged and does not stop the others.
+func (s *Scheduler) RunOnce(ctx context.Context) {
+	now := s.now()
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := job.Run(ctx, now); err != nil {
+			logger.ErrorLogger.Printf("Scheduler: Job %s failed: %v", job.Name, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_RunOnce(t *testing.T) {
+	fixed := time.Date(2026, 2, 1, 6, 0, 0, 0, time.UTC)
+	var ran []string
+	var seen time.Time
+	s := New(time.Hour,
+		Job{Name: "failing", Run: func(_ context.Context, _ time.Time) error {
+			ran = append(ran, "failing")
+			return fmt.Errorf("boom")
+		}},
+		Job{Name: "second", Run: func(_ context.Context, now time.Time) error {
+			ran = append(ran, "second")
+			seen = now
+			return nil
+		}},
+	)
+	s.now = func() time.Time { return fixed }
+
+	s.RunOnce(context.Background())
+
+	assert.Equal(t, []string{"failing", "second"}, ran, "a failing job must not stop later jobs")
+	assert.Equal(t, fixed, seen)
+}
+
+func TestScheduler_StartRunsImmediatelyAndStops(t *testing.T) {
+	ticks := make(chan struct{}, 1)
+	s := New(time.Hour, Job{Name: "tick", Run: func(_ context.Context, _ time.Time) error {
+		select {
+		case ticks <- struct{}{}:
+		default:
+		}
+		return nil
+	}})
+
+	s.Start(context.Background())
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("job did not run on Start")
+	}
+	s.Stop()
+	s.Stop() // Stopping twice is harmless
+}
@@ -5,10 +5,15 @@ import (
 	"erp-system/internal/accounting/models"
 	"erp-system/internal/accounting/repository"
 	dto "erp-system/internal/accounting/service/dto" // Alias for DTOs
+	"erp-system/pkg/auth"
+	"erp-system/pkg/company"
 	"erp-system/pkg/errors"
 	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
 	"fmt"
-	"math" // For float comparisons with tolerance
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,40 +28,202 @@ type AccountingService interface {
 	UpdateChartOfAccount(ctx context.Context, id uuid.UUID, req dto.UpdateChartOfAccountRequest) (*models.ChartOfAccount, error)
 	DeleteChartOfAccount(ctx context.Context, id uuid.UUID) error
 	ListChartOfAccounts(ctx context.Context, req dto.ListChartOfAccountsRequest) ([]*models.ChartOfAccount, int64, error)
+	MoveChartOfAccount(ctx context.Context, id uuid.UUID, parentAccountID *uuid.UUID) (*models.ChartOfAccount, error)
+	GetChartOfAccountTree(ctx context.Context, asOfDate time.Time) (*dto.ChartOfAccountTreeResponse, error)
+	MergeChartOfAccount(ctx context.Context, sourceID uuid.UUID, req dto.MergeChartOfAccountRequest) (*models.AccountMerge, error)
+	ListChartOfAccountMerges(ctx context.Context, id uuid.UUID) ([]*models.AccountMerge, error)
 
 	// Journal Entries
 	CreateJournalEntry(ctx context.Context, req dto.CreateJournalEntryRequest) (*models.JournalEntry, error)
+	PrepareJournalEntry(ctx context.Context, req dto.CreateJournalEntryRequest) (*models.JournalEntry, error)
+	PrepareSubledgerEntry(ctx context.Context, req dto.CreateJournalEntryRequest) (*models.JournalEntry, error)
 	GetJournalEntryByID(ctx context.Context, id uuid.UUID) (*models.JournalEntry, error)
 	UpdateJournalEntry(ctx context.Context, id uuid.UUID, req dto.UpdateJournalEntryRequest) (*models.JournalEntry, error)
 	DeleteJournalEntry(ctx context.Context, id uuid.UUID) error
 	ListJournalEntries(ctx context.Context, req dto.ListJournalEntriesRequest) ([]*models.JournalEntry, int64, error)
 	PostJournalEntry(ctx context.Context, id uuid.UUID) (*models.JournalEntry, error)
+	VoidJournalEntry(ctx context.Context, id uuid.UUID, reason string, reversalDate time.Time) (*dto.VoidJournalEntryResponse, error)
+
+	// Journal Entry Approval
+	SubmitJournalEntry(ctx context.Context, id uuid.UUID, comment string) (*models.JournalEntry, error)
+	ApproveJournalEntry(ctx context.Context, id uuid.UUID, comment string) (*models.JournalEntry, error)
+	RejectJournalEntry(ctx context.Context, id uuid.UUID, comment string) (*models.JournalEntry, error)
+
+	// Automatic Reversals
+	ListPendingReversals(ctx context.Context) ([]*models.ScheduledReversal, error)
+	CancelScheduledReversal(ctx context.Context, id uuid.UUID) (*models.ScheduledReversal, error)
+	ProcessDueReversals(ctx context.Context, asOf time.Time) (int, error)
 
 	// Reporting
 	GetTrialBalance(ctx context.Context, req dto.TrialBalanceRequest) (*dto.TrialBalanceResponse, error)
-	// GetBalanceSheet(ctx context.Context, date time.Time) (*dto.BalanceSheetResponse, error)
-	// GetProfitAndLossStatement(ctx context.Context, startDate, endDate time.Time) (*dto.ProfitAndLossResponse, error)
+	GetBalanceSheet(ctx context.Context, date time.Time) (*dto.BalanceSheetResponse, error)
+	GetProfitAndLossStatement(ctx context.Context, req dto.ProfitAndLossRequest) (*dto.ProfitAndLossResponse, error)
+	GetCashFlowStatement(ctx context.Context, req dto.CashFlowRequest) (*dto.CashFlowResponse, error)
+	GetAccountLedger(ctx context.Context, accountID uuid.UUID, startDate, endDate time.Time, dimensions models.DimensionTags) (*dto.AccountLedgerResponse, error)
+	GetGeneralLedger(ctx context.Context, req dto.GeneralLedgerRequest) (*dto.GeneralLedgerResponse, error)
+
+	// Year-End Close
+	CloseFiscalYear(ctx context.Context, fiscalYearID uuid.UUID) (*dto.YearEndCloseResponse, error)
+	ReopenFiscalYear(ctx context.Context, fiscalYearID uuid.UUID) (*dto.YearEndCloseResponse, error)
+
+	// Foreign Currency Revaluation
+	RevalueForeignCurrencies(ctx context.Context, req dto.FXRevaluationRequest) (*dto.FXRevaluationResponse, error)
 
 	// Other specific methods
-	GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (float64, error)
+	GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (money.Amount, error)
+	BaseCurrency(ctx context.Context) string
 }
 
 // accountingService is an implementation of AccountingService.
 type accountingService struct {
-	coaRepo     repository.ChartOfAccountRepository
-	journalRepo repository.JournalEntryRepository
-	// Potentially other repositories if needed
+	coaRepo       repository.ChartOfAccountRepository
+	journalRepo   repository.JournalEntryRepository
+	periodChecker PostingPeriodChecker                   // Optional; nil means every date is open
+	fiscalRepo    repository.FiscalPeriodRepository      // Optional; required for year-end close
+	reversalRepo  repository.ScheduledReversalRepository // Optional; required for auto-reversing entries
+	currencyRepo  repository.CurrencyRepository          // Optional; nil allows only the base currency
+	dimensionRepo repository.DimensionRepository         // Optional; nil rejects lines tagged with dimensions
+	taxRepo       repository.TaxRepository               // Optional; nil rejects lines with a tax code
+	// retainedEarningsCode is the EQUITY account code that receives the year-end close.
+	retainedEarningsCode string
+	// fxGainAccountCode and fxLossAccountCode receive unrealized gains and losses from revaluation.
+	fxGainAccountCode string
+	fxLossAccountCode string
+	// configuredAccounts maps the account codes other modules post to by code to what each is used
+	// for; such accounts cannot be merged away (see WithConfiguredAccounts).
+	configuredAccounts map[string]string
+	// baseCurrency is the functional currency of companies that do not set one (see BaseCurrency).
+	// Line amounts are converted into it, and it is used for lines without a currency and for
+	// the entries the service derives itself.
+	baseCurrency string
+	// approvalThresholds lists, by ascending MinAmount, the entry totals that need an approver.
+	approvalThresholds []ApprovalThreshold
+}
+
+// DefaultBaseCurrency is the base currency used unless WithBaseCurrency is given.
+const DefaultBaseCurrency = "USD"
+
+// AccountingServiceOption configures optional collaborators of the accounting service.
+type AccountingServiceOption func(*accountingService)
+
+// WithPostingPeriodChecker makes the service refuse to create, update, post or void
+// journal entries dated in locked fiscal periods.
+func WithPostingPeriodChecker(checker PostingPeriodChecker) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.periodChecker = checker
+	}
+}
+
+// WithYearEndClose enables CloseFiscalYear and ReopenFiscalYear, closing fiscal years
+// from fiscalRepo into the EQUITY account with code retainedEarningsCode.
+func WithYearEndClose(fiscalRepo repository.FiscalPeriodRepository, retainedEarningsCode string) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.fiscalRepo = fiscalRepo
+		s.retainedEarningsCode = retainedEarningsCode
+	}
+}
+
+// WithScheduledReversals enables auto_reverse_on for journal entries, tracking the pending
+// reversals in reversalRepo.
+func WithScheduledReversals(reversalRepo repository.ScheduledReversalRepository) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.reversalRepo = reversalRepo
+	}
+}
+
+// WithBaseCurrency sets the base currency (an ISO 4217 code) of companies that do not have one.
+func WithBaseCurrency(currency string) AccountingServiceOption {
+	return func(s *accountingService) {
+		if currency != "" {
+			s.baseCurrency = strings.ToUpper(currency)
+		}
+	}
+}
+
+// WithCurrencies enables journal lines in foreign currencies, validating them against the
+// currency master and converting them at the rates in currencyRepo.
+func WithCurrencies(currencyRepo repository.CurrencyRepository) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.currencyRepo = currencyRepo
+	}
+}
+
+// WithDimensions enables dimension tags on journal lines, validated against the value lists and
+// account rules in dimensionRepo, and the dimension filters and group-by of the reports.
+func WithDimensions(dimensionRepo repository.DimensionRepository) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.dimensionRepo = dimensionRepo
+	}
+}
+
+// WithTaxCodes enables tax codes on journal lines: a line with a tax code in taxRepo is the taxable
+// base, and the tax lines are added to the entry for it.
+func WithTaxCodes(taxRepo repository.TaxRepository) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.taxRepo = taxRepo
+	}
+}
+
+// WithFXRevaluation enables RevalueForeignCurrencies, posting unrealized gains to the account
+// with code gainAccountCode and losses to the one with code lossAccountCode. It needs
+// WithCurrencies for the rates and WithScheduledReversals to reverse the entries it posts.
+func WithFXRevaluation(gainAccountCode, lossAccountCode string) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.fxGainAccountCode = gainAccountCode
+		s.fxLossAccountCode = lossAccountCode
+	}
+}
+
+// WithConfiguredAccounts registers account codes that other modules are configured to post to,
+// mapped to what each is used for (e.g. "receivables control"). MergeChartOfAccount refuses to merge
+// such an account away, as that would deactivate it and break every posting to it.
+func WithConfiguredAccounts(uses map[string]string) AccountingServiceOption {
+	return func(s *accountingService) {
+		if s.configuredAccounts == nil {
+			s.configuredAccounts = map[string]string{}
+		}
+		for code, use := range uses {
+			if code != "" {
+				s.configuredAccounts[code] = use
+			}
+		}
+	}
+}
+
+// WithApprovalThresholds makes entries whose total debits reach a threshold's MinAmount go
+// through SubmitJournalEntry and ApproveJournalEntry instead of being posted directly.
+func WithApprovalThresholds(thresholds ...ApprovalThreshold) AccountingServiceOption {
+	return func(s *accountingService) {
+		s.approvalThresholds = append([]ApprovalThreshold(nil), thresholds...)
+		sort.SliceStable(s.approvalThresholds, func(i, j int) bool {
+			return s.approvalThresholds[i].MinAmount.Cmp(s.approvalThresholds[j].MinAmount) < 0
+		})
+	}
 }
 
-// NewAccountingService creates a new AccountingService.
 func NewAccountingService(
 	coaRepo repository.ChartOfAccountRepository,
 	journalRepo repository.JournalEntryRepository,
+	opts ...AccountingServiceOption,
 ) AccountingService {
-	return &accountingService{
-		coaRepo:     coaRepo,
-		journalRepo: journalRepo,
+	s := &accountingService{
+		coaRepo:      coaRepo,
+		journalRepo:  journalRepo,
+		baseCurrency: DefaultBaseCurrency,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// BaseCurrency returns the base currency of the active company, the default for lines without a
+// currency. Contexts without a company, or whose company has none, get the configured one.
+func (s *accountingService) BaseCurrency(ctx context.Context) string {
+	if active, ok := company.FromContext(ctx); ok && active.BaseCurrency != "" {
+		return active.BaseCurrency
+	}
+	return s.baseCurrency
 }
 
 // --- Chart of Accounts Methods ---
@@ -82,6 +249,11 @@ func (s *accountingService) CreateChartOfAccount(ctx context.Context, req dto.Cr
 		return nil, errors.NewValidationError(fmt.Sprintf("invalid account type: %s", req.AccountType), "account_type")
 	}
 
+	if err := validateCashFlowCategory(req.AccountType, req.CashFlowCategory); err != nil {
+		logger.WarnLogger.Printf("Service: Invalid cash flow category for account %s: %v", req.AccountCode, err)
+		return nil, err
+	}
+
 	// Check if account code already exists
 	existing, err := s.coaRepo.GetByCode(ctx, req.AccountCode)
 	if err != nil && !isNotFoundError(err) { // isNotFoundError checks if it's our custom NotFoundError
@@ -93,29 +265,22 @@ func (s *accountingService) CreateChartOfAccount(ctx context.Context, req dto.Cr
 		return nil, errors.NewConflictError(fmt.Sprintf("account with code %s already exists", req.AccountCode))
 	}
 
+	account := &models.ChartOfAccount{
+		AccountCode:      req.AccountCode,
+		AccountName:      req.AccountName,
+		AccountType:      req.AccountType,
+		ParentAccountID:  req.ParentAccountID,
+		IsActive:         req.IsActive, // Default true if not provided by DTO (DTO should have default)
+		CashFlowCategory: req.CashFlowCategory,
+	}
+
 	// Check parent account if provided
 	if req.ParentAccountID != nil && *req.ParentAccountID != uuid.Nil {
-		parentAccount, err := s.coaRepo.GetByID(ctx, *req.ParentAccountID)
-		if err != nil {
-			if isNotFoundError(err) {
-				logger.WarnLogger.Printf("Service: Parent account with ID %s not found.", *req.ParentAccountID)
-				return nil, errors.NewValidationError("parent account not found", "parent_account_id")
-			}
-			logger.ErrorLogger.Printf("Service: Error fetching parent account %s: %v", *req.ParentAccountID, err)
-			return nil, err // Propagate internal server error
-		}
-		if !parentAccount.IsActive {
-			logger.WarnLogger.Printf("Service: Parent account %s is not active.", parentAccount.AccountCode)
-			return nil, errors.NewValidationError("parent account is not active", "parent_account_id")
+		if err := s.validateParentAccount(ctx, account, *req.ParentAccountID); err != nil {
+			return nil, err
 		}
-	}
-
-	account := &models.ChartOfAccount{
-		AccountCode:     req.AccountCode,
-		AccountName:     req.AccountName,
-		AccountType:     req.AccountType,
-		ParentAccountID: req.ParentAccountID,
-		IsActive:        req.IsActive, // Default true if not provided by DTO (DTO should have default)
+	} else {
+		account.ParentAccountID = nil
 	}
 
 	createdAccount, err := s.coaRepo.Create(ctx, account)
@@ -162,6 +327,7 @@ func (s *accountingService) UpdateChartOfAccount(ctx context.Context, id uuid.UU
 	if req.AccountName != nil {
 		account.AccountName = *req.AccountName
 	}
+	typeChanged := req.AccountType != nil && *req.AccountType != account.AccountType
 	if req.AccountType != nil {
 		// Validate AccountType enum
 		validAccountType := false
@@ -182,25 +348,21 @@ func (s *accountingService) UpdateChartOfAccount(ctx context.Context, id uuid.UU
 			account.ParentAccountID = nil
 		} else {
 			// If setting to a new parent, validate the new parent
-			parentAccount, err := s.coaRepo.GetByID(ctx, *req.ParentAccountID)
-			if err != nil {
-				if isNotFoundError(err) {
-					logger.WarnLogger.Printf("Service: Parent account with ID %s not found for update.", *req.ParentAccountID)
-					return nil, errors.NewValidationError("parent account not found", "parent_account_id")
-				}
-				logger.ErrorLogger.Printf("Service: Error fetching parent account %s for update: %v", *req.ParentAccountID, err)
+			if err := s.validateParentAccount(ctx, account, *req.ParentAccountID); err != nil {
 				return nil, err
 			}
-			if !parentAccount.IsActive {
-				logger.WarnLogger.Printf("Service: Parent account %s is not active for update.", parentAccount.AccountCode)
-				return nil, errors.NewValidationError("parent account is not active", "parent_account_id")
-			}
-			if parentAccount.ID == account.ID { // Prevent self-referencing
-				logger.WarnLogger.Printf("Service: Cannot set account %s as its own parent.", account.AccountCode)
-				return nil, errors.NewValidationError("cannot set account as its own parent", "parent_account_id")
-			}
 			account.ParentAccountID = req.ParentAccountID
 		}
+	} else if typeChanged && account.ParentAccountID != nil {
+		// The account stays where it is, so its current parent must accept the new type.
+		if err := s.validateParentAccount(ctx, account, *account.ParentAccountID); err != nil {
+			return nil, err
+		}
+	}
+	if typeChanged {
+		if err := s.validateChildAccountTypes(ctx, account); err != nil {
+			return nil, err
+		}
 	}
 	if req.IsActive != nil {
 		account.IsActive = *req.IsActive
@@ -211,6 +373,14 @@ func (s *accountingService) UpdateChartOfAccount(ctx context.Context, id uuid.UU
 			logger.InfoLogger.Printf("Service: Account %s (ID: %s) is being deactivated.", account.AccountCode, account.ID)
 		}
 	}
+	if req.CashFlowCategory != nil {
+		account.CashFlowCategory = *req.CashFlowCategory
+	}
+	// Re-check even when only the type changed, since REVENUE/EXPENSE accounts cannot carry a category.
+	if err := validateCashFlowCategory(account.AccountType, account.CashFlowCategory); err != nil {
+		logger.WarnLogger.Printf("Service: Invalid cash flow category for account %s: %v", account.AccountCode, err)
+		return nil, err
+	}
 	// Note: AccountCode is typically not updatable. If it were, need to check for uniqueness.
 
 	updatedAccount, err := s.coaRepo.Update(ctx, account)
@@ -275,6 +445,9 @@ func (s *accountingService) ListChartOfAccounts(ctx context.Context, req dto.Lis
     if req.IsActive != nil { // Check if the pointer is not nil
         filters["is_active"] = *req.IsActive
     }
+	if req.ParentAccountID != nil {
+		filters["parent_account_id"] = *req.ParentAccountID
+	}
 
 
 	offset := 0
@@ -296,11 +469,308 @@ func (s *accountingService) ListChartOfAccounts(ctx context.Context, req dto.Lis
 	return accounts, total, nil
 }
 
+// MoveChartOfAccount moves an account under a new parent; the accounts below it move with it. A nil
+// or zero parentAccountID makes it a top-level account.
+func (s *accountingService) MoveChartOfAccount(ctx context.Context, id uuid.UUID, parentAccountID *uuid.UUID) (*models.ChartOfAccount, error) {
+	logger.InfoLogger.Printf("Service: Attempting to move chart of account %s", id)
+	account, err := s.coaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentAccountID == nil || *parentAccountID == uuid.Nil {
+		account.ParentAccountID = nil
+	} else {
+		if err := s.validateParentAccount(ctx, account, *parentAccountID); err != nil {
+			return nil, err
+		}
+		account.ParentAccountID = parentAccountID
+	}
+
+	moved, err := s.coaRepo.Update(ctx, account)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error moving chart of account %s: %v", id, err)
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Moved chart of account %s under %s", moved.AccountCode, formatParentAccount(moved.ParentAccountID))
+	return moved, nil
+}
+
+// validateParentAccount checks that parentID can be the parent of account: it must exist, be active,
+// have the same account type, and not be the account itself or one of its descendants.
+func (s *accountingService) validateParentAccount(ctx context.Context, account *models.ChartOfAccount, parentID uuid.UUID) error {
+	parent, err := s.coaRepo.GetByID(ctx, parentID)
+	if err != nil {
+		if isNotFoundError(err) {
+			logger.WarnLogger.Printf("Service: Parent account with ID %s not found.", parentID)
+			return errors.NewValidationError("parent account not found", "parent_account_id")
+		}
+		logger.ErrorLogger.Printf("Service: Error fetching parent account %s: %v", parentID, err)
+		return err
+	}
+	if parent.ID == account.ID { // Prevent self-referencing
+		logger.WarnLogger.Printf("Service: Cannot set account %s as its own parent.", account.AccountCode)
+		return errors.NewValidationError("cannot set account as its own parent", "parent_account_id")
+	}
+	if !parent.IsActive {
+		logger.WarnLogger.Printf("Service: Parent account %s is not active.", parent.AccountCode)
+		return errors.NewValidationError("parent account is not active", "parent_account_id")
+	}
+	if parent.AccountType != account.AccountType {
+		return errors.NewValidationError(fmt.Sprintf("parent account %s is %s; %s accounts cannot be placed under it", parent.AccountCode, parent.AccountType, account.AccountType), "parent_account_id")
+	}
+
+	if account.ID == uuid.Nil {
+		return nil // A new account has no descendants
+	}
+
+	// Walk up from the new parent; meeting the account itself means it would become its own ancestor.
+	seen := map[uuid.UUID]bool{parent.ID: true}
+	for ancestorID := parent.ParentAccountID; ancestorID != nil; {
+		if *ancestorID == account.ID {
+			logger.WarnLogger.Printf("Service: Moving account %s under %s would create a cycle.", account.AccountCode, parent.AccountCode)
+			return errors.NewValidationError(fmt.Sprintf("account %s is below %s; moving it there would create a cycle", parent.AccountCode, account.AccountCode), "parent_account_id")
+		}
+		if seen[*ancestorID] {
+			break // An existing cycle above the parent; it does not involve this account
+		}
+		seen[*ancestorID] = true
+		ancestor, err := s.coaRepo.GetByID(ctx, *ancestorID)
+		if err != nil {
+			if isNotFoundError(err) {
+				break // The chain ends at a deleted account
+			}
+			return err
+		}
+		ancestorID = ancestor.ParentAccountID
+	}
+	return nil
+}
+
+// validateChildAccountTypes checks that the direct children of account share its account type.
+func (s *accountingService) validateChildAccountTypes(ctx context.Context, account *models.ChartOfAccount) error {
+	children, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{"parent_account_id": account.ID})
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if child.AccountType != account.AccountType {
+			return errors.NewValidationError(fmt.Sprintf("account %s has %s child account %s; move it before changing the type to %s", account.AccountCode, child.AccountType, child.AccountCode, account.AccountType), "account_type")
+		}
+	}
+	return nil
+}
+
+// GetChartOfAccountTree returns every account, active or not, nested under its parent, with the
+// balances as of asOfDate rolled up from the children.
+func (s *accountingService) GetChartOfAccountTree(ctx context.Context, asOfDate time.Time) (*dto.ChartOfAccountTreeResponse, error) {
+	if asOfDate.IsZero() {
+		asOfDate = time.Now()
+	}
+	logger.InfoLogger.Printf("Service: Building chart of accounts tree as of %s", asOfDate.Format("2006-01-02"))
+
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching accounts for chart of accounts tree: %v", err)
+		return nil, err
+	}
+	balances, err := s.journalRepo.GetAccountBalancesBefore(ctx, nil, endOfDay(asOfDate).Add(time.Nanosecond))
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching balances for chart of accounts tree: %v", err)
+		return nil, err
+	}
+
+	// Accounts arrive ordered by code, so children are appended in code order too.
+	nodes := make(map[uuid.UUID]*dto.ChartOfAccountTreeNode, len(accounts))
+	for _, acc := range accounts {
+		nodes[acc.ID] = &dto.ChartOfAccountTreeNode{
+			AccountID:   acc.ID,
+			AccountCode: acc.AccountCode,
+			AccountName: acc.AccountName,
+			AccountType: acc.AccountType,
+			IsActive:    acc.IsActive,
+			Balance:     balances[acc.ID],
+			Children:    []*dto.ChartOfAccountTreeNode{},
+		}
+	}
+	response := &dto.ChartOfAccountTreeResponse{AsOfDate: asOfDate, Accounts: []*dto.ChartOfAccountTreeNode{}}
+	for _, acc := range accounts {
+		var parent *dto.ChartOfAccountTreeNode
+		if acc.ParentAccountID != nil {
+			parent = nodes[*acc.ParentAccountID]
+		}
+		if parent == nil { // Top-level, or the parent has been deleted
+			response.Accounts = append(response.Accounts, nodes[acc.ID])
+			continue
+		}
+		parent.Children = append(parent.Children, nodes[acc.ID])
+	}
+
+	// Accounts caught in a cycle saved before cycles were rejected are not reachable from a
+	// top-level account; list each cycle once at the top level so their balances are not lost.
+	visited := make(map[uuid.UUID]bool, len(nodes))
+	for _, root := range response.Accounts {
+		rollUp(root, visited)
+	}
+	for _, acc := range accounts {
+		if !visited[acc.ID] {
+			logger.WarnLogger.Printf("Service: Account %s is part of a parent cycle; listing it at the top level", acc.AccountCode)
+			rollUp(nodes[acc.ID], visited)
+			response.Accounts = append(response.Accounts, nodes[acc.ID])
+		}
+	}
+	return response, nil
+}
+
+// rollUp sets the roll-up balance of node and everything below it. A child already visited closes
+// a cycle and is dropped from the node's children.
+func rollUp(node *dto.ChartOfAccountTreeNode, visited map[uuid.UUID]bool) money.Amount {
+	visited[node.AccountID] = true
+	node.RollupBalance = node.Balance
+	children := node.Children[:0]
+	for _, child := range node.Children {
+		if visited[child.AccountID] {
+			continue
+		}
+		node.RollupBalance = node.RollupBalance.Add(rollUp(child, visited))
+		children = append(children, child)
+	}
+	node.Children = children
+	return node.RollupBalance
+}
+
+func formatParentAccount(parentID *uuid.UUID) string {
+	if parentID == nil {
+		return "the top level"
+	}
+	return parentID.String()
+}
+
+// AccountMergeRoles are the roles allowed to merge one account into another.
+var AccountMergeRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+// MergeChartOfAccount merges a duplicate source account into the target account given in req. Every
+// journal line, child account, recurring template line, bank account link and tax code account of
+// the source moves to the target in one transaction, the source is deactivated and the merge is recorded in the
+// audit log. Posted lines move too, so the target's history, closed periods included, becomes the
+// combined history of both accounts. Budget lines and dimension rules stay with the source. An
+// account configured by code, such as the retained earnings account, cannot be the source.
+func (s *accountingService) MergeChartOfAccount(ctx context.Context, sourceID uuid.UUID, req dto.MergeChartOfAccountRequest) (*models.AccountMerge, error) {
+	logger.InfoLogger.Printf("Service: Attempting to merge chart of account %s into %s", sourceID, req.TargetAccountID)
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || !auth.HasAnyRole(ctx, AccountMergeRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("merging accounts requires one of the roles: %s", strings.Join(AccountMergeRoles, ", ")))
+	}
+	if req.TargetAccountID == uuid.Nil {
+		return nil, errors.NewValidationError("target_account_id is required", "target_account_id")
+	}
+	if req.TargetAccountID == sourceID {
+		return nil, errors.NewValidationError("an account cannot be merged into itself", "target_account_id")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > 500 {
+		return nil, errors.NewValidationError("reason must be at most 500 characters", "reason")
+	}
+
+	source, err := s.coaRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.coaRepo.GetByID(ctx, req.TargetAccountID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError("target account not found", "target_account_id")
+		}
+		return nil, err
+	}
+	if !target.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("target account %s is not active", target.AccountCode), "target_account_id")
+	}
+	if source.AccountType != target.AccountType {
+		return nil, errors.NewValidationError(fmt.Sprintf("account %s is %s and account %s is %s; only accounts of the same type can be merged", source.AccountCode, source.AccountType, target.AccountCode, target.AccountType), "target_account_id")
+	}
+	if use := s.configuredAccountUse(source.AccountCode); use != "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("account %s is configured as the %s account; merge the other account into it instead", source.AccountCode, use), "id")
+	}
+	// The source's children move under the target, so the target must not be one of them.
+	seen := map[uuid.UUID]bool{target.ID: true}
+	for ancestorID := target.ParentAccountID; ancestorID != nil && !seen[*ancestorID]; {
+		if *ancestorID == source.ID {
+			return nil, errors.NewValidationError(fmt.Sprintf("account %s is below %s; move it out before merging", target.AccountCode, source.AccountCode), "target_account_id")
+		}
+		seen[*ancestorID] = true
+		ancestor, err := s.coaRepo.GetByID(ctx, *ancestorID)
+		if err != nil {
+			if isNotFoundError(err) {
+				break
+			}
+			return nil, err
+		}
+		ancestorID = ancestor.ParentAccountID
+	}
+
+	merge, err := s.coaRepo.MergeAccounts(ctx, &models.AccountMerge{
+		SourceAccountID:   source.ID,
+		SourceAccountCode: source.AccountCode,
+		TargetAccountID:   target.ID,
+		TargetAccountCode: target.AccountCode,
+		Reason:            reason,
+		MergedBy:          principal.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Merged chart of account %s into %s (%d journal lines, %d child accounts, %d template lines, %d tax codes)",
+		source.AccountCode, target.AccountCode, merge.JournalLinesMoved, merge.ChildAccountsMoved, merge.TemplateLinesMoved, merge.TaxCodesMoved)
+	return merge, nil
+}
+
+// configuredAccountUse returns what the account with code is configured to be used for, such as
+// "retained earnings", or "" if nothing posts to it by code.
+func (s *accountingService) configuredAccountUse(code string) string {
+	switch code {
+	case "":
+		return ""
+	case s.retainedEarningsCode:
+		return "retained earnings"
+	case s.fxGainAccountCode:
+		return "FX gain"
+	case s.fxLossAccountCode:
+		return "FX loss"
+	}
+	return s.configuredAccounts[code]
+}
+
+// ListChartOfAccountMerges returns the merge audit records of an account, as source or target.
+func (s *accountingService) ListChartOfAccountMerges(ctx context.Context, id uuid.UUID) ([]*models.AccountMerge, error) {
+	if _, err := s.coaRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.coaRepo.ListMerges(ctx, id)
+}
+
 // --- Journal Entries Methods ---
 
 func (s *accountingService) CreateJournalEntry(ctx context.Context, req dto.CreateJournalEntryRequest) (*models.JournalEntry, error) {
 	logger.InfoLogger.Printf("Service: Attempting to create journal entry with description: %s", req.Description)
 
+	entry, err := s.PrepareJournalEntry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	createdEntry, err := s.journalRepo.Create(ctx, entry)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error creating journal entry in repository: %v", err)
+		return nil, err // Propagate
+	}
+	logger.InfoLogger.Printf("Service: Successfully created journal entry with ID: %s", createdEntry.ID)
+	return createdEntry, nil
+}
+
+// PrepareJournalEntry validates req exactly as CreateJournalEntry does and returns the entry
+// without saving it, for callers that save it in the same transaction as their own records.
+// The entry's auto-reversal is scheduled by whichever repository method posts it.
+func (s *accountingService) PrepareJournalEntry(ctx context.Context, req dto.CreateJournalEntryRequest) (*models.JournalEntry, error) {
 	// Validate request
 	if req.EntryDate.IsZero() {
 		req.EntryDate = time.Now() // Default to now if not provided
@@ -309,9 +779,13 @@ func (s *accountingService) CreateJournalEntry(ctx context.Context, req dto.Crea
 		logger.WarnLogger.Println("Service: Journal entry must have at least one line.")
 		return nil, errors.NewValidationError("journal entry must have at least one line", "lines")
 	}
+	if err := s.validateAutoReverseOn(req.EntryDate, req.AutoReverseOn); err != nil {
+		return nil, err
+	}
+	if err := s.checkPostingPeriod(ctx, req.EntryDate); err != nil {
+		return nil, err
+	}
 
-	var totalDebits float64
-	var totalCredits float64
 	journalLines := make([]models.JournalLine, len(req.Lines))
 
 	for i, lineReq := range req.Lines {
@@ -319,10 +793,14 @@ func (s *accountingService) CreateJournalEntry(ctx context.Context, req dto.Crea
 			logger.WarnLogger.Printf("Service: Journal line %d has missing account ID.", i+1)
 			return nil, errors.NewValidationError(fmt.Sprintf("line %d: account_id is required", i+1), "lines.account_id")
 		}
-		if lineReq.Amount <= 0 { // Amounts should be positive, IsDebit determines effect
-			logger.WarnLogger.Printf("Service: Journal line %d has invalid amount: %.2f", i+1, lineReq.Amount)
+		if !lineReq.Amount.IsPositive() { // Amounts should be positive, IsDebit determines effect
+			logger.WarnLogger.Printf("Service: Journal line %d has invalid amount: %s", i+1, lineReq.Amount)
 			return nil, errors.NewValidationError(fmt.Sprintf("line %d: amount must be positive", i+1), "lines.amount")
 		}
+		line, err := s.convertLine(ctx, i, lineReq, req.EntryDate)
+		if err != nil {
+			return nil, err
+		}
 
 		// Validate account ID exists and is active
 		account, err := s.coaRepo.GetByID(ctx, lineReq.AccountID)
@@ -339,28 +817,18 @@ func (s *accountingService) CreateJournalEntry(ctx context.Context, req dto.Crea
 			return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s (%s) is not active", i+1, account.AccountCode, account.AccountName), "lines.account_id")
 		}
 
-		journalLines[i] = models.JournalLine{
-			AccountID: lineReq.AccountID,
-			Amount:    lineReq.Amount,
-			Currency:  lineReq.Currency, // TODO: Validate currency code if necessary
-			IsDebit:   lineReq.IsDebit,
-		}
-		if journalLines[i].Currency == "" {
-			journalLines[i].Currency = "USD" // Default currency
-		}
-
-		if lineReq.IsDebit {
-			totalDebits += lineReq.Amount
-		} else {
-			totalCredits += lineReq.Amount
-		}
+		journalLines[i] = line
+	}
+	if err := s.tagLines(ctx, journalLines, req.Lines); err != nil {
+		return nil, err
+	}
+	journalLines, err := s.addTaxLines(ctx, journalLines, req.Lines, req.EntryDate)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if debits equal credits (with a small tolerance for float comparison)
-	const tolerance = 1e-9 // Tolerance for floating point comparison
-	if math.Abs(totalDebits-totalCredits) > tolerance {
-		logger.WarnLogger.Printf("Service: Journal entry debits (%.2f) do not equal credits (%.2f).", totalDebits, totalCredits)
-		return nil, errors.NewValidationError(fmt.Sprintf("debits (%.2f) must equal credits (%.2f)", totalDebits, totalCredits), "lines")
+	if err := s.balanceInFunctionalCurrency(ctx, journalLines); err != nil {
+		return nil, err
 	}
 
 	entryStatus := models.StatusDraft // Default status for new entries, can be changed by PostJournalEntry
@@ -374,22 +842,38 @@ func (s *accountingService) CreateJournalEntry(ctx context.Context, req dto.Crea
 		}
 	}
 
-
 	entry := &models.JournalEntry{
-		EntryDate:   req.EntryDate,
-		Description: req.Description,
-		Reference:   req.Reference,
-		Status:      entryStatus,
-		JournalLines: journalLines,
+		EntryDate:     req.EntryDate,
+		Description:   req.Description,
+		Reference:     req.Reference,
+		Status:        entryStatus,
+		JournalLines:  journalLines,
+		AutoReverseOn: req.AutoReverseOn,
+	}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		entry.CreatedBy = principal.UserID
+	}
+	if entryStatus == models.StatusPosted {
+		if err := s.checkPostingWithoutApproval(entry); err != nil {
+			return nil, err
+		}
 	}
+	return entry, nil
+}
 
-	createdEntry, err := s.journalRepo.Create(ctx, entry)
+// PrepareSubledgerEntry validates req like PrepareJournalEntry and returns it, unsaved, as the
+// POSTED SUBLEDGER entry of a subledger document, which the subledger saves with the document.
+// Approval thresholds do not apply: the document the entry posts is what gets approved.
+func (s *accountingService) PrepareSubledgerEntry(ctx context.Context, req dto.CreateJournalEntryRequest) (*models.JournalEntry, error) {
+	req.Status = models.StatusDraft
+	req.AutoReverseOn = nil
+	entry, err := s.PrepareJournalEntry(ctx, req)
 	if err != nil {
-		logger.ErrorLogger.Printf("Service: Error creating journal entry in repository: %v", err)
-		return nil, err // Propagate
+		return nil, err
 	}
-	logger.InfoLogger.Printf("Service: Successfully created journal entry with ID: %s", createdEntry.ID)
-	return createdEntry, nil
+	entry.Status = models.StatusPosted
+	entry.EntryType = models.EntryTypeSubledger
+	return entry, nil
 }
 
 func (s *accountingService) GetJournalEntryByID(ctx context.Context, id uuid.UUID) (*models.JournalEntry, error) {
@@ -411,6 +895,23 @@ func (s *accountingService) UpdateJournalEntry(ctx context.Context, id uuid.UUID
 		logger.ErrorLogger.Printf("Service: Error finding journal entry %s for update: %v", id, err)
 		return nil, err // Propagate (could be NotFoundError)
 	}
+	// Entries in locked periods cannot be changed at all, nor moved into one.
+	if err := s.checkPostingPeriod(ctx, existingEntry.EntryDate); err != nil {
+		return nil, err
+	}
+	if req.EntryDate != nil && !(*req.EntryDate).IsZero() {
+		if err := s.checkPostingPeriod(ctx, *req.EntryDate); err != nil {
+			return nil, err
+		}
+	}
+	// Approvers must decide on the entry as it was submitted.
+	if existingEntry.Status == models.StatusPendingApproval {
+		return nil, errors.NewConflictError(fmt.Sprintf("journal entry %s is awaiting approval and cannot be changed until it is approved or rejected", id))
+	}
+	if req.Status != nil && *req.Status != existingEntry.Status &&
+		(*req.Status == models.StatusPendingApproval || *req.Status == models.StatusRejected) {
+		return nil, errors.NewValidationError(fmt.Sprintf("status %s is set by the submit, approve and reject operations", *req.Status), "status")
+	}
 
 	// Business rule: Cannot update a 'POSTED' or 'VOIDED' entry in certain ways.
 	// For example, lines might be uneditable, or only description/reference can change.
@@ -430,7 +931,8 @@ func (s *accountingService) UpdateJournalEntry(ctx context.Context, id uuid.UUID
 		// If only description/reference changed and other financial fields are nil/empty in request
 		if (req.EntryDate == nil || (*req.EntryDate).IsZero() || (*req.EntryDate).Equal(existingEntry.EntryDate)) &&
 		   (req.Lines == nil || len(*req.Lines) == 0) &&
-		   (req.Status == nil || *req.Status == existingEntry.Status) {
+		   (req.Status == nil || *req.Status == existingEntry.Status) &&
+		   req.AutoReverseOn == nil {
 			if !canUpdate { // No actual changes requested for allowed fields
 				logger.InfoLogger.Printf("Service: No updatable fields provided for posted/voided journal entry %s.", id)
 				return existingEntry, nil // No change, return existing
@@ -459,6 +961,12 @@ func (s *accountingService) UpdateJournalEntry(ctx context.Context, id uuid.UUID
 	if req.Reference != nil {
 		existingEntry.Reference = *req.Reference
 	}
+	if req.AutoReverseOn != nil {
+		existingEntry.AutoReverseOn = req.AutoReverseOn
+	}
+	if err := s.validateAutoReverseOn(existingEntry.EntryDate, existingEntry.AutoReverseOn); err != nil {
+		return nil, err
+	}
 	if req.Status != nil { // Handle status change, e.g., DRAFT to DRAFT (no change), or DRAFT to POSTED (use PostJournalEntry)
 		if *req.Status == models.StatusPosted && existingEntry.Status == models.StatusDraft {
 			// If trying to post via update, redirect to PostJournalEntry logic or handle here
@@ -476,17 +984,19 @@ func (s *accountingService) UpdateJournalEntry(ctx context.Context, id uuid.UUID
 
 
 	if req.Lines != nil && len(*req.Lines) > 0 {
-		var totalDebits float64
-		var totalCredits float64
 		updatedLines := make([]models.JournalLine, len(*req.Lines))
 
 		for i, lineReq := range *req.Lines {
 			if lineReq.AccountID == uuid.Nil {
 				return nil, errors.NewValidationError(fmt.Sprintf("line %d: account_id is required", i+1), "lines.account_id")
 			}
-			if lineReq.Amount <= 0 {
+			if !lineReq.Amount.IsPositive() {
 				return nil, errors.NewValidationError(fmt.Sprintf("line %d: amount must be positive", i+1), "lines.amount")
 			}
+			line, err := s.convertLine(ctx, i, lineReq, existingEntry.EntryDate)
+			if err != nil {
+				return nil, err
+			}
 			account, err := s.coaRepo.GetByID(ctx, lineReq.AccountID)
 			if err != nil { /* ... error handling ... */
 				if isNotFoundError(err) { return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s not found", i+1, lineReq.AccountID), "")}
@@ -496,29 +1006,21 @@ func (s *accountingService) UpdateJournalEntry(ctx context.Context, id uuid.UUID
 				return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s not active", i+1, account.AccountCode), "")
 			}
 
-			updatedLines[i] = models.JournalLine{
-				// ID might be needed if repo is matching lines by ID for update vs create.
-				// If lineReq includes an ID, use it. GORM's association replace handles this.
-				ID:        lineReq.ID, // Assumes DTO line includes ID for existing lines
-				JournalID: existingEntry.ID, // Ensure JournalID is set for new lines
-				AccountID: lineReq.AccountID,
-				Amount:    lineReq.Amount,
-				Currency:  lineReq.Currency,
-				IsDebit:   lineReq.IsDebit,
-			}
-			if updatedLines[i].Currency == "" {
-				updatedLines[i].Currency = "USD"
-			}
-
-			if lineReq.IsDebit {
-				totalDebits += lineReq.Amount
-			} else {
-				totalCredits += lineReq.Amount
-			}
+			// ID might be needed if repo is matching lines by ID for update vs create.
+			// If lineReq includes an ID, use it. GORM's association replace handles this.
+			line.ID = lineReq.ID              // Assumes DTO line includes ID for existing lines
+			line.JournalID = existingEntry.ID // Ensure JournalID is set for new lines
+			updatedLines[i] = line
+		}
+		if err := s.tagLines(ctx, updatedLines, *req.Lines); err != nil {
+			return nil, err
 		}
-		const tolerance = 1e-9
-		if math.Abs(totalDebits-totalCredits) > tolerance {
-			return nil, errors.NewValidationError(fmt.Sprintf("debits (%.2f) must equal credits (%.2f)", totalDebits, totalCredits), "lines")
+		updatedLines, err = s.addTaxLines(ctx, updatedLines, *req.Lines, existingEntry.EntryDate)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.balanceInFunctionalCurrency(ctx, updatedLines); err != nil {
+			return nil, err
 		}
 		existingEntry.JournalLines = updatedLines
 	} else if req.Lines != nil && len(*req.Lines) == 0 { // Explicitly empty lines array
@@ -526,21 +1028,16 @@ func (s *accountingService) UpdateJournalEntry(ctx context.Context, id uuid.UUID
     }
 
 
-	// If the entry is being marked as POSTED, ensure it's balanced.
-    // This check is also done if lines were updated. If only status changed to POSTED, we need to re-check balance.
-    if existingEntry.Status == models.StatusPosted {
-        if !existingEntry.IsBalanced() { // IsBalanced method on JournalEntry model
-            // If lines were not part of this update request, IsBalanced() uses existing lines.
-            // If lines were part of request, it uses the new lines.
-            currentDebits, currentCredits := existingEntry.TotalDebits(), existingEntry.TotalCredits()
-            logger.WarnLogger.Printf("Service: Journal entry %s cannot be posted. Debits (%.2f) do not equal credits (%.2f).", id, currentDebits, currentCredits)
-            return nil, errors.NewValidationError(
-                fmt.Sprintf("cannot post entry, debits (%.2f) must equal credits (%.2f)", currentDebits, currentCredits),
-                "lines",
-            )
-        }
-    }
-
+	// An entry posted through an update passes the same checks as PostJournalEntry: it must balance
+	// and none of its accounts may have been deactivated since it was drafted.
+	if existingEntry.Status == models.StatusPosted {
+		if err := s.checkPostingWithoutApproval(existingEntry); err != nil {
+			return nil, err
+		}
+		if err := s.validateForPosting(ctx, existingEntry); err != nil {
+			return nil, err
+		}
+	}
 
 	updatedEntry, err := s.journalRepo.Update(ctx, existingEntry)
 	if err != nil {
@@ -559,12 +1056,17 @@ func (s *accountingService) DeleteJournalEntry(ctx context.Context, id uuid.UUID
 		return err // Propagate (could be NotFoundError)
 	}
 
-	// Business rule: Cannot delete a 'POSTED' entry. It must be 'VOIDED' or 'UNPOSTED' first.
+	// Business rule: Cannot delete a 'POSTED' entry. It must be voided instead.
 	if entry.Status == models.StatusPosted {
-		logger.WarnLogger.Printf("Service: Cannot delete journal entry %s because it is POSTED. Void or un-post first.", id)
-		return errors.NewConflictError(fmt.Sprintf("cannot delete a POSTED journal entry (ID: %s). Void or un-post first.", id))
+		logger.WarnLogger.Printf("Service: Cannot delete journal entry %s because it is POSTED. Void it instead.", id)
+		return errors.NewConflictError(fmt.Sprintf("cannot delete a POSTED journal entry (ID: %s). Void it instead.", id))
 	}
-	// VOIDED entries can often be deleted (archived by soft delete). DRAFT entries can be deleted.
+	// A voided entry and its reversing entry cancel each other in the ledger and form the audit trail.
+	if entry.AffectsBalances() {
+		logger.WarnLogger.Printf("Service: Cannot delete journal entry %s because it was voided by reversing entry %s.", id, *entry.ReversedByID)
+		return errors.NewConflictError(fmt.Sprintf("cannot delete voided journal entry %s: it is kept with its reversing entry %s for the audit trail", id, *entry.ReversedByID))
+	}
+	// DRAFT entries, and VOIDED drafts, can be deleted.
 
 	if err := s.journalRepo.Delete(ctx, id); err != nil {
 		logger.ErrorLogger.Printf("Service: Error deleting journal entry %s from repository: %v", id, err)
@@ -583,6 +1085,9 @@ func (s *accountingService) ListJournalEntries(ctx context.Context, req dto.List
 	if req.Reference != "" {
 		filters["reference"] = req.Reference
 	}
+	if req.DocumentNumber != "" {
+		filters["document_number"] = strings.ToUpper(strings.TrimSpace(req.DocumentNumber))
+	}
 	if req.Status != "" {
 		filters["status"] = req.Status
 	}
@@ -592,7 +1097,9 @@ func (s *accountingService) ListJournalEntries(ctx context.Context, req dto.List
 	if !req.DateTo.IsZero() {
 		filters["date_to"] = req.DateTo
 	}
-	// TODO: Add filter by account_id if DTO supports it
+	if req.AccountID != uuid.Nil {
+		filters["account_id"] = req.AccountID
+	}
 
 	offset := 0
 	if req.Page > 0 && req.Limit > 0 {
@@ -628,26 +1135,17 @@ func (s *accountingService) PostJournalEntry(ctx context.Context, id uuid.UUID)
 		logger.WarnLogger.Printf("Service: Journal entry %s is VOIDED and cannot be posted.", id)
 		return nil, errors.NewConflictError(fmt.Sprintf("cannot post a VOIDED journal entry (ID: %s)", id))
 	}
-
-	// Ensure entry is balanced before posting
-	if !entry.IsBalanced() {
-		debits, credits := entry.TotalDebits(), entry.TotalCredits()
-		logger.WarnLogger.Printf("Service: Journal entry %s is not balanced. Debits: %.2f, Credits: %.2f. Cannot post.", id, debits, credits)
-		return nil, errors.NewValidationError(fmt.Sprintf("entry is not balanced (Debits: %.2f, Credits: %.2f)", debits, credits), "lines")
+	if entry.Status == models.StatusPendingApproval {
+		return nil, errors.NewConflictError(fmt.Sprintf("journal entry %s is awaiting approval; an approver posts it by approving it", id))
 	}
-
-	// Additional checks before posting (e.g., all accounts in lines are active)
-	for _, line := range entry.JournalLines {
-		// Account should have been validated on creation/update, but a check here is good defense
-		account, err := s.coaRepo.GetByID(ctx, line.AccountID)
-		if err != nil { // Should not happen if data integrity is maintained
-			logger.ErrorLogger.Printf("Service: Critical error - account %s in journal entry %s not found during posting: %v", line.AccountID, id, err)
-			return nil, errors.NewInternalServerError("error validating account during posting", err)
-		}
-		if !account.IsActive {
-			logger.WarnLogger.Printf("Service: Account %s (%s) in journal entry %s is inactive. Cannot post.", account.AccountCode, account.AccountName, id)
-			return nil, errors.NewConflictError(fmt.Sprintf("account %s (%s) is inactive", account.AccountCode, account.AccountName))
-		}
+	if entry.Status == models.StatusRejected {
+		return nil, errors.NewConflictError(fmt.Sprintf("journal entry %s was rejected; correct it and submit it for approval again", id))
+	}
+	if err := s.checkPostingWithoutApproval(entry); err != nil {
+		return nil, err
+	}
+	if err := s.validateForPosting(ctx, entry); err != nil {
+		return nil, err
 	}
 
 	// Update status to POSTED
@@ -658,167 +1156,1665 @@ func (s *accountingService) PostJournalEntry(ctx context.Context, id uuid.UUID)
 		logger.ErrorLogger.Printf("Service: Error updating journal entry %s status to POSTED in repository: %v", id, err)
 		return nil, err
 	}
-
-	// Reload the entry to confirm the change
-	postedEntry, err := s.journalRepo.GetByID(ctx, id)
-	if err != nil {
-		logger.ErrorLogger.Printf("Service: Error reloading journal entry %s after posting: %v", id, err)
-		return nil, err
+
+	// Reload the entry to confirm the change
+	postedEntry, err := s.journalRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error reloading journal entry %s after posting: %v", id, err)
+		return nil, err
+	}
+
+	logger.InfoLogger.Printf("Service: Successfully posted journal entry with ID: %s", postedEntry.ID)
+	return postedEntry, nil
+}
+
+// validateForPosting checks that entry may be posted as it stands: its date is in a period that
+// accepts postings, it balances, and all its accounts are still active.
+func (s *accountingService) validateForPosting(ctx context.Context, entry *models.JournalEntry) error {
+	if err := s.checkPostingPeriod(ctx, entry.EntryDate); err != nil {
+		return err
+	}
+
+	// Ensure entry is balanced before posting
+	if !entry.IsBalanced() {
+		debits, credits := entry.TotalDebits(), entry.TotalCredits()
+		logger.WarnLogger.Printf("Service: Journal entry %s is not balanced. Debits: %s, Credits: %s. Cannot post.", entry.ID, debits, credits)
+		return errors.NewValidationError(fmt.Sprintf("entry is not balanced (Debits: %s, Credits: %s)", debits, credits), "lines")
+	}
+
+	// Additional checks before posting (e.g., all accounts in lines are active)
+	for _, line := range entry.JournalLines {
+		// Account should have been validated on creation/update, but a check here is good defense
+		account, err := s.coaRepo.GetByID(ctx, line.AccountID)
+		if err != nil { // Should not happen if data integrity is maintained
+			logger.ErrorLogger.Printf("Service: Critical error - account %s in journal entry %s not found during posting: %v", line.AccountID, entry.ID, err)
+			return errors.NewInternalServerError("error validating account during posting", err)
+		}
+		if !account.IsActive {
+			logger.WarnLogger.Printf("Service: Account %s (%s) in journal entry %s is inactive. Cannot post.", account.AccountCode, account.AccountName, entry.ID)
+			return errors.NewConflictError(fmt.Sprintf("account %s (%s) is inactive", account.AccountCode, account.AccountName))
+		}
+	}
+	return nil
+}
+
+// VoidJournalEntry voids a POSTED entry by posting a reversing entry dated reversalDate (the original
+// entry date if zero) with debits and credits swapped. The original is marked VOIDED and both entries
+// reference each other. Voiding changes the status of the original entry, so both its entry date and
+// the reversal date must fall in periods that accept postings.
+func (s *accountingService) VoidJournalEntry(ctx context.Context, id uuid.UUID, reason string, reversalDate time.Time) (*dto.VoidJournalEntryResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting to void journal entry with ID: %s", id)
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.NewValidationError("a reason is required to void a journal entry", "reason")
+	}
+	if len(reason) > 255 {
+		return nil, errors.NewValidationError("reason must be at most 255 characters", "reason")
+	}
+
+	entry, err := s.journalRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error finding journal entry %s for voiding: %v", id, err)
+		return nil, err
+	}
+	switch {
+	case entry.Status == models.StatusVoided:
+		return nil, errors.NewConflictError(fmt.Sprintf("journal entry %s is already VOIDED", id))
+	case entry.Status != models.StatusPosted:
+		return nil, errors.NewConflictError(fmt.Sprintf("only POSTED journal entries can be voided; delete %s entry %s instead", entry.Status, id))
+	case entry.EntryType == models.EntryTypeClosing:
+		return nil, errors.NewConflictError("year-end closing entries are reversed by reopening the fiscal year")
+	case entry.EntryType == models.EntryTypeSubledger:
+		return nil, errors.NewConflictError(fmt.Sprintf("journal entry %s posts a subledger document; correct the document instead, e.g. with a credit note", id))
+	case entry.ReversalOfID != nil:
+		return nil, errors.NewConflictError(fmt.Sprintf("journal entry %s is a reversing entry and cannot itself be voided", id))
+	case entry.ReversedByID != nil:
+		return nil, errors.NewConflictError(fmt.Sprintf("journal entry %s has already been reversed by entry %s", id, *entry.ReversedByID))
+	}
+
+	if reversalDate.IsZero() {
+		reversalDate = entry.EntryDate
+	}
+	if dateOnly(reversalDate).Before(dateOnly(entry.EntryDate)) {
+		return nil, errors.NewValidationError("reversal_date cannot be before the original entry date", "reversal_date")
+	}
+	if err := s.checkPostingPeriod(ctx, entry.EntryDate); err != nil {
+		return nil, err
+	}
+	if err := s.checkPostingPeriod(ctx, reversalDate); err != nil {
+		return nil, err
+	}
+
+	reversal := newReversalEntry(entry, reversalDate, models.EntryTypeReversal, fmt.Sprintf("Reversal of journal entry %s", id))
+	created, err := s.journalRepo.VoidWithReversal(ctx, id, reversal, reason, time.Now())
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error voiding journal entry %s: %v", id, err)
+		return nil, err
+	}
+	voided, err := s.journalRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Voided journal entry %s with reversing entry %s", id, created.ID)
+	return &dto.VoidJournalEntryResponse{VoidedEntry: voided, ReversalEntry: created}, nil
+}
+
+// --- Journal Entry Approval Methods ---
+
+// JournalApprovalRoles are the roles allowed to approve or reject submitted journal entries
+// that no approval threshold covers, or whose threshold names no roles.
+var JournalApprovalRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+// ApprovalThreshold requires entries whose total debits are at least MinAmount to be approved,
+// before they are posted, by a user holding one of Roles (JournalApprovalRoles if empty).
+type ApprovalThreshold struct {
+	MinAmount money.Amount
+	Roles     []string
+}
+
+// ParseApprovalThresholds parses a comma-separated list of amount:ROLE|ROLE thresholds, such as
+// "10000:ACCOUNTING_MANAGER|ADMIN,100000:ADMIN". The roles part may be omitted.
+func ParseApprovalThresholds(spec string) ([]ApprovalThreshold, error) {
+	var thresholds []ApprovalThreshold
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		amountStr, rolesStr, _ := strings.Cut(part, ":")
+		amount, err := money.Parse(strings.TrimSpace(amountStr))
+		if err != nil {
+			return nil, fmt.Errorf("approval threshold %q: invalid amount: %w", part, err)
+		}
+		if amount.IsNegative() {
+			return nil, fmt.Errorf("approval threshold %q: amount cannot be negative", part)
+		}
+		if seen[amount.String()] {
+			return nil, fmt.Errorf("approval threshold %q: amount %s is listed twice", part, amount)
+		}
+		seen[amount.String()] = true
+		threshold := ApprovalThreshold{MinAmount: amount}
+		for _, role := range strings.Split(rolesStr, "|") {
+			if role = strings.TrimSpace(role); role != "" {
+				threshold.Roles = append(threshold.Roles, strings.ToUpper(role))
+			}
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
+
+// SubmitJournalEntry sends a DRAFT or REJECTED entry to the approvers. Entries covered by an
+// approval threshold can only be posted this way, but any entry may be submitted for review.
+func (s *accountingService) SubmitJournalEntry(ctx context.Context, id uuid.UUID, comment string) (*models.JournalEntry, error) {
+	logger.InfoLogger.Printf("Service: Attempting to submit journal entry %s for approval", id)
+	comment, err := approvalComment(comment, false)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.journalRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error finding journal entry %s for submission: %v", id, err)
+		return nil, err
+	}
+	if entry.Status != models.StatusDraft && entry.Status != models.StatusRejected {
+		return nil, errors.NewConflictError(fmt.Sprintf("only DRAFT or REJECTED journal entries can be submitted for approval; entry %s is %s", id, entry.Status))
+	}
+	// Approvers should only see entries that could be posted as they stand.
+	if err := s.validateForPosting(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	step := &models.JournalEntryApproval{Action: models.ApprovalSubmitted, Comment: comment}
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		step.UserID = principal.UserID
+	}
+	entry.Status = models.StatusPendingApproval
+	entry.ApprovedBy = ""
+	entry.ApprovedAt = nil
+	return s.saveApprovalStep(ctx, entry, step)
+}
+
+// ApproveJournalEntry approves a PENDING_APPROVAL entry and posts it. The approver must hold one
+// of the roles the entry's approval threshold names and must not have created or submitted it.
+func (s *accountingService) ApproveJournalEntry(ctx context.Context, id uuid.UUID, comment string) (*models.JournalEntry, error) {
+	logger.InfoLogger.Printf("Service: Attempting to approve journal entry %s", id)
+	comment, err := approvalComment(comment, false)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.journalRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error finding journal entry %s for approval: %v", id, err)
+		return nil, err
+	}
+	approver, err := s.authorizeApprover(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	// Periods may have been locked or accounts deactivated since the entry was submitted.
+	if err := s.validateForPosting(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry.Status = models.StatusPosted
+	entry.ApprovedBy = approver
+	entry.ApprovedAt = &now
+	return s.saveApprovalStep(ctx, entry, &models.JournalEntryApproval{Action: models.ApprovalApproved, UserID: approver, Comment: comment})
+}
+
+// RejectJournalEntry sends a PENDING_APPROVAL entry back to its author, who can correct and
+// resubmit it. A comment explaining the rejection is required.
+func (s *accountingService) RejectJournalEntry(ctx context.Context, id uuid.UUID, comment string) (*models.JournalEntry, error) {
+	logger.InfoLogger.Printf("Service: Attempting to reject journal entry %s", id)
+	comment, err := approvalComment(comment, true)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.journalRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error finding journal entry %s for rejection: %v", id, err)
+		return nil, err
+	}
+	approver, err := s.authorizeApprover(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Status = models.StatusRejected
+	return s.saveApprovalStep(ctx, entry, &models.JournalEntryApproval{Action: models.ApprovalRejected, UserID: approver, Comment: comment})
+}
+
+// requiredApproval returns the highest approval threshold entry reaches, or nil if none applies.
+func (s *accountingService) requiredApproval(entry *models.JournalEntry) *ApprovalThreshold {
+	total := entry.TotalDebits()
+	var required *ApprovalThreshold
+	for i := range s.approvalThresholds {
+		if total.Cmp(s.approvalThresholds[i].MinAmount) >= 0 {
+			required = &s.approvalThresholds[i]
+		}
+	}
+	return required
+}
+
+// checkPostingWithoutApproval refuses to post entry directly when an approval threshold applies.
+func (s *accountingService) checkPostingWithoutApproval(entry *models.JournalEntry) error {
+	if threshold := s.requiredApproval(entry); threshold != nil {
+		logger.WarnLogger.Printf("Service: Journal entry %s totals %s and requires approval before posting.", entry.ID, entry.TotalDebits())
+		return errors.NewConflictError(fmt.Sprintf("journal entries of %s or more require approval; submit the entry for approval instead of posting it", threshold.MinAmount))
+	}
+	return nil
+}
+
+// authorizeApprover checks that the caller may approve or reject entry and returns their user ID.
+func (s *accountingService) authorizeApprover(ctx context.Context, entry *models.JournalEntry) (string, error) {
+	if entry.Status != models.StatusPendingApproval {
+		return "", errors.NewConflictError(fmt.Sprintf("journal entry %s is %s, not awaiting approval", entry.ID, entry.Status))
+	}
+	roles := JournalApprovalRoles
+	if threshold := s.requiredApproval(entry); threshold != nil && len(threshold.Roles) > 0 {
+		roles = threshold.Roles
+	}
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || !auth.HasAnyRole(ctx, roles...) {
+		return "", errors.NewForbiddenError(fmt.Sprintf("approving this journal entry requires one of the roles: %s", strings.Join(roles, ", ")))
+	}
+	if principal.UserID == entry.CreatedBy || principal.UserID == lastSubmitter(entry) {
+		logger.WarnLogger.Printf("Service: User %s tried to approve or reject their own journal entry %s.", principal.UserID, entry.ID)
+		return "", errors.NewForbiddenError("journal entries must be approved or rejected by someone other than their creator or submitter")
+	}
+	return principal.UserID, nil
+}
+
+// saveApprovalStep saves entry's new approval state with step and returns the reloaded entry.
+func (s *accountingService) saveApprovalStep(ctx context.Context, entry *models.JournalEntry, step *models.JournalEntryApproval) (*models.JournalEntry, error) {
+	if err := s.journalRepo.SaveApprovalStep(ctx, entry, step); err != nil {
+		logger.ErrorLogger.Printf("Service: Error saving %s step for journal entry %s: %v", step.Action, entry.ID, err)
+		return nil, err
+	}
+	saved, err := s.journalRepo.GetByID(ctx, entry.ID)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error reloading journal entry %s after %s step: %v", entry.ID, step.Action, err)
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Journal entry %s is now %s", saved.ID, saved.Status)
+	return saved, nil
+}
+
+// lastSubmitter returns the user who last submitted entry for approval.
+func lastSubmitter(entry *models.JournalEntry) string {
+	for i := len(entry.Approvals) - 1; i >= 0; i-- {
+		if entry.Approvals[i].Action == models.ApprovalSubmitted {
+			return entry.Approvals[i].UserID
+		}
+	}
+	return ""
+}
+
+// approvalComment trims comment and checks that it fits the approval history.
+func approvalComment(comment string, required bool) (string, error) {
+	comment = strings.TrimSpace(comment)
+	if required && comment == "" {
+		return "", errors.NewValidationError("a comment is required to reject a journal entry", "comment")
+	}
+	if len(comment) > 500 {
+		return "", errors.NewValidationError("comment must be at most 500 characters", "comment")
+	}
+	return comment, nil
+}
+
+// --- Automatic Reversal Methods ---
+
+// ListPendingReversals returns scheduled reversals that have not been posted or cancelled yet.
+func (s *accountingService) ListPendingReversals(ctx context.Context) ([]*models.ScheduledReversal, error) {
+	if s.reversalRepo == nil {
+		return []*models.ScheduledReversal{}, nil
+	}
+	return s.reversalRepo.ListPending(ctx, time.Time{})
+}
+
+// CancelScheduledReversal stops a PENDING reversal from being posted. The original entry keeps its
+// auto_reverse_on date for reference.
+func (s *accountingService) CancelScheduledReversal(ctx context.Context, id uuid.UUID) (*models.ScheduledReversal, error) {
+	logger.InfoLogger.Printf("Service: Attempting to cancel scheduled reversal %s", id)
+	if s.reversalRepo == nil {
+		return nil, errors.NewNotFoundError("scheduled_reversal", id.String())
+	}
+	reversal, err := s.reversalRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reversal.Status != models.ReversalPending {
+		return nil, errors.NewConflictError(fmt.Sprintf("scheduled reversal %s is %s and cannot be cancelled", id, reversal.Status))
+	}
+	if err := s.reversalRepo.UpdateStatus(ctx, id, models.ReversalPending, models.ReversalCancelled); err != nil {
+		logger.ErrorLogger.Printf("Service: Error cancelling scheduled reversal %s: %v", id, err)
+		return nil, err
+	}
+	reversal.Status = models.ReversalCancelled
+	logger.InfoLogger.Printf("Service: Cancelled scheduled reversal %s of journal entry %s", id, reversal.JournalEntryID)
+	return reversal, nil
+}
+
+// ProcessDueReversals posts every PENDING reversal dated on or before asOf and returns how many were
+// posted. A reversal that cannot be posted yet, e.g. because its period is locked, stays PENDING with
+// the reason recorded and is retried on the next run.
+func (s *accountingService) ProcessDueReversals(ctx context.Context, asOf time.Time) (int, error) {
+	if s.reversalRepo == nil {
+		return 0, nil
+	}
+	due, err := s.reversalRepo.ListPending(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, pending := range due {
+		original := pending.JournalEntry
+		if original == nil || len(original.JournalLines) == 0 {
+			// A reversal without lines would post an empty entry; always reverse the full original.
+			if original, err = s.journalRepo.GetByID(ctx, pending.JournalEntryID); err != nil {
+				return posted, err
+			}
+		}
+		if original.Status != models.StatusPosted || original.ReversedByID != nil {
+			// The entry was voided or reversed some other way; reversing it again would double count.
+			logger.WarnLogger.Printf("Service: Cancelling scheduled reversal %s, journal entry %s is %s", pending.ID, original.ID, original.Status)
+			if err := s.reversalRepo.UpdateStatus(ctx, pending.ID, models.ReversalPending, models.ReversalCancelled); err != nil {
+				return posted, err
+			}
+			continue
+		}
+		if err := s.checkPostingPeriod(ctx, pending.ReverseOn); err != nil {
+			logger.WarnLogger.Printf("Service: Scheduled reversal %s not posted: %v", pending.ID, err)
+			if err := s.reversalRepo.RecordFailure(ctx, pending.ID, err.Error()); err != nil {
+				return posted, err
+			}
+			continue
+		}
+
+		reversal := newReversalEntry(original, pending.ReverseOn, models.EntryTypeReversal, fmt.Sprintf("Auto-reversal of journal entry %s", original.ID))
+		reversal.ReversalOfID = &original.ID
+		created, err := s.reversalRepo.CompleteWithEntry(ctx, pending.ID, reversal, time.Now())
+		if err != nil {
+			if _, ok := err.(*errors.ConflictError); ok {
+				continue // Cancelled or posted concurrently
+			}
+			logger.ErrorLogger.Printf("Service: Error posting scheduled reversal %s: %v", pending.ID, err)
+			return posted, err
+		}
+		posted++
+		logger.InfoLogger.Printf("Service: Posted auto-reversal %s of journal entry %s dated %s", created.ID, original.ID, pending.ReverseOn.Format("2006-01-02"))
+	}
+	return posted, nil
+}
+
+// --- Year-End Close Methods ---
+
+// CloseFiscalYear zeroes every REVENUE and EXPENSE account for the fiscal year into the configured
+// retained earnings account with a posted CLOSING entry dated on the last day of the year, then marks
+// the year and all of its periods CLOSED. Only SoftClosePostingRoles may close a year.
+func (s *accountingService) CloseFiscalYear(ctx context.Context, fiscalYearID uuid.UUID) (*dto.YearEndCloseResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting year-end close of fiscal year %s", fiscalYearID)
+	year, err := s.loadFiscalYearForClose(ctx, fiscalYearID)
+	if err != nil {
+		return nil, err
+	}
+	if year.Status == models.PeriodClosed {
+		return nil, errors.NewConflictError(fmt.Sprintf("fiscal year %s is already closed", year.Name))
+	}
+
+	reAccount, err := s.coaRepo.GetByCode(ctx, s.retainedEarningsCode)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("retained earnings account %s does not exist", s.retainedEarningsCode), "retained_earnings_account_code")
+		}
+		return nil, err
+	}
+	if reAccount.AccountType != models.Equity || !reAccount.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("retained earnings account %s must be an active EQUITY account", reAccount.AccountCode), "retained_earnings_account_code")
+	}
+
+	net, err := s.netActivityByAccount(ctx, year.StartDate, year.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching accounts for year-end close: %v", err)
+		return nil, err
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].AccountCode < accounts[j].AccountCode })
+
+	// Each P&L account is closed with a line opposite to its balance; the debit-positive sum of
+	// those balances is the loss (or, negated, the profit) carried to retained earnings.
+	var lines []models.JournalLine
+	plBalance := money.Zero
+	for _, acc := range accounts {
+		if acc.AccountType != models.Revenue && acc.AccountType != models.Expense {
+			continue
+		}
+		balance := net[acc.ID]
+		if balance.IsZero() {
+			continue
+		}
+		lines = append(lines, s.functionalLine(ctx, acc.ID, balance.Abs(), balance.IsNegative()))
+		plBalance = plBalance.Add(balance)
+	}
+	if !plBalance.IsZero() {
+		lines = append(lines, s.functionalLine(ctx, reAccount.ID, plBalance.Abs(), plBalance.IsPositive()))
+	}
+
+	resp := &dto.YearEndCloseResponse{RetainedEarningsAccountID: reAccount.ID, NetIncome: plBalance.Neg()}
+	var closing *models.JournalEntry
+	if len(lines) > 0 {
+		// The close is itself the privileged period-end operation, so it bypasses the period locks.
+		closing = &models.JournalEntry{
+			EntryDate:    year.EndDate,
+			Description:  "Year-end close " + year.Name,
+			Reference:    "YEC-" + year.Name,
+			Status:       models.StatusPosted,
+			EntryType:    models.EntryTypeClosing,
+			JournalLines: lines,
+		}
+	}
+	// The closing entry and the year's status change commit together; a concurrent or retried
+	// close finds the year no longer OPEN and fails without posting a second closing entry.
+	if err := s.fiscalRepo.MarkFiscalYearClosed(ctx, year.ID, closing, time.Now()); err != nil {
+		logger.ErrorLogger.Printf("Service: Error closing fiscal year %s: %v", year.Name, err)
+		return nil, err
+	}
+	resp.ClosingEntry = closing
+	if resp.FiscalYear, err = s.fiscalRepo.GetFiscalYearByID(ctx, year.ID); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Fiscal year %s closed, net income %s carried to account %s", year.Name, resp.NetIncome, reAccount.AccountCode)
+	return resp, nil
+}
+
+// ReopenFiscalYear undoes CloseFiscalYear: it voids the closing entry with a linked reversing entry,
+// reopens the year and leaves its periods SOFT_CLOSED so that only SoftClosePostingRoles can adjust them.
+// A year cannot be reopened while a later fiscal year is still closed.
+func (s *accountingService) ReopenFiscalYear(ctx context.Context, fiscalYearID uuid.UUID) (*dto.YearEndCloseResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting to reopen fiscal year %s", fiscalYearID)
+	year, err := s.loadFiscalYearForClose(ctx, fiscalYearID)
+	if err != nil {
+		return nil, err
+	}
+	if year.Status != models.PeriodClosed {
+		return nil, errors.NewConflictError(fmt.Sprintf("fiscal year %s is not closed", year.Name))
+	}
+	years, err := s.fiscalRepo.ListFiscalYears(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range years {
+		if other.ID != year.ID && other.Status == models.PeriodClosed && other.StartDate.After(year.EndDate) {
+			return nil, errors.NewConflictError(fmt.Sprintf("fiscal year %s must be reopened before %s", other.Name, year.Name))
+		}
+	}
+
+	resp := &dto.YearEndCloseResponse{}
+	var reversal *models.JournalEntry
+	if year.ClosingEntryID != nil {
+		closing, err := s.journalRepo.GetByID(ctx, *year.ClosingEntryID)
+		if err != nil {
+			logger.ErrorLogger.Printf("Service: Error loading closing entry %s of fiscal year %s: %v", *year.ClosingEntryID, year.Name, err)
+			return nil, err
+		}
+		reversal = newReversalEntry(closing, closing.EntryDate, models.EntryTypeClosing, "Reversal of year-end close "+year.Name)
+		reversal.Reference = "YEC-REV-" + year.Name
+		for _, line := range closing.JournalLines {
+			// The only line not on a P&L account is the retained earnings line.
+			if line.ChartOfAccount != nil && line.ChartOfAccount.AccountType != models.Revenue && line.ChartOfAccount.AccountType != models.Expense {
+				resp.RetainedEarningsAccountID = line.AccountID
+				resp.NetIncome = line.Amount
+				if line.IsDebit {
+					resp.NetIncome = line.Amount.Neg()
+				}
+			}
+		}
+	}
+
+	// Voiding the closing entry and reopening the year commit together; a failed reopen leaves the
+	// closing entry POSTED so that it can simply be retried.
+	if err := s.fiscalRepo.MarkFiscalYearReopened(ctx, year.ID, reversal, "Fiscal year "+year.Name+" reopened", models.PeriodSoftClosed, time.Now()); err != nil {
+		logger.ErrorLogger.Printf("Service: Error reopening fiscal year %s: %v", year.Name, err)
+		return nil, err
+	}
+	if reversal != nil {
+		if resp.ReversalEntry, err = s.journalRepo.GetByID(ctx, reversal.ID); err != nil {
+			return nil, err
+		}
+	}
+	if resp.FiscalYear, err = s.fiscalRepo.GetFiscalYearByID(ctx, year.ID); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Fiscal year %s reopened", year.Name)
+	return resp, nil
+}
+
+// loadFiscalYearForClose checks that year-end close is configured and permitted for the caller,
+// then loads the fiscal year.
+func (s *accountingService) loadFiscalYearForClose(ctx context.Context, fiscalYearID uuid.UUID) (*models.FiscalYear, error) {
+	if s.fiscalRepo == nil || s.retainedEarningsCode == "" {
+		return nil, errors.NewInternalServerError("year-end close is not configured: a fiscal calendar and retained earnings account code are required", nil)
+	}
+	if !auth.HasAnyRole(ctx, SoftClosePostingRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("closing or reopening a fiscal year requires one of the roles: %s", strings.Join(SoftClosePostingRoles, ", ")))
+	}
+	return s.fiscalRepo.GetFiscalYearByID(ctx, fiscalYearID)
+}
+
+// --- Foreign Currency Revaluation Methods ---
+
+// RevalueForeignCurrencies restates each account's balance in each foreign currency at the latest
+// rate on or before the revaluation date. The difference from the functional balance the lines
+// were booked at is an unrealized gain or loss; unless req.DryRun is set, the adjustments are
+// posted in one entry dated on the revaluation date that reverses automatically the next day,
+// so the following period starts again from the booked amounts.
+func (s *accountingService) RevalueForeignCurrencies(ctx context.Context, req dto.FXRevaluationRequest) (*dto.FXRevaluationResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting foreign currency revaluation as of %s (dry run: %t)", req.RevaluationDate.Format("2006-01-02"), req.DryRun)
+
+	if s.currencyRepo == nil || s.reversalRepo == nil || s.fxGainAccountCode == "" || s.fxLossAccountCode == "" {
+		return nil, errors.NewInternalServerError("foreign currency revaluation is not configured: currencies, automatic reversals and FX gain and loss account codes are required", nil)
+	}
+	if req.RevaluationDate.IsZero() {
+		return nil, errors.NewValidationError("revaluation_date is required", "revaluation_date")
+	}
+	date := dateOnly(req.RevaluationDate)
+	functional := s.BaseCurrency(ctx)
+	if !req.DryRun {
+		if err := s.checkPostingPeriod(ctx, date); err != nil {
+			return nil, err
+		}
+	}
+	gainAccount, err := s.fxAccount(ctx, s.fxGainAccountCode, "fx_gain_account_code")
+	if err != nil {
+		return nil, err
+	}
+	lossAccount, err := s.fxAccount(ctx, s.fxLossAccountCode, "fx_loss_account_code")
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching accounts for revaluation: %v", err)
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.ChartOfAccount, len(accounts))
+	selected := make(map[uuid.UUID]bool)
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+		if len(req.AccountIDs) == 0 && (acc.AccountType == models.Asset || acc.AccountType == models.Liability) {
+			selected[acc.ID] = true
+		}
+	}
+	for _, id := range req.AccountIDs {
+		if _, ok := byID[id]; !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("account with ID %s not found", id), "account_ids")
+		}
+		selected[id] = true
+	}
+
+	balances, err := s.journalRepo.GetCurrencyBalances(ctx, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	resp := &dto.FXRevaluationResponse{
+		RevaluationDate:    date,
+		FunctionalCurrency: functional,
+		Lines:              []dto.FXRevaluationLine{},
+		GainAccountID:      gainAccount.ID,
+		LossAccountID:      lossAccount.ID,
+		DryRun:             req.DryRun,
+	}
+	rates := make(map[string]*models.ExchangeRate)
+	var missingRates []string
+	for _, balance := range balances {
+		if balance.Currency == functional || !selected[balance.AccountID] {
+			continue
+		}
+		// A settled balance that still carries a functional amount is revalued to zero too.
+		if balance.TransactionBalance.IsZero() && balance.FunctionalBalance.IsZero() {
+			continue
+		}
+		rate, ok := rates[balance.Currency]
+		if !ok {
+			rate, err = s.currencyRepo.GetExchangeRate(ctx, balance.Currency, functional, date)
+			if err != nil && !isNotFoundError(err) {
+				return nil, err
+			}
+			rates[balance.Currency] = rate
+			if rate == nil {
+				missingRates = append(missingRates, balance.Currency)
+			}
+		}
+		if rate == nil {
+			continue
+		}
+		acc := byID[balance.AccountID]
+		revalued := balance.TransactionBalance.Convert(rate.Rate).Round(functional)
+		line := dto.FXRevaluationLine{
+			AccountID:          acc.ID,
+			AccountCode:        acc.AccountCode,
+			AccountName:        acc.AccountName,
+			AccountType:        acc.AccountType,
+			Currency:           balance.Currency,
+			TransactionBalance: balance.TransactionBalance,
+			Rate:               rate.Rate,
+			RateDate:           rate.RateDate,
+			RevaluedBalance:    revalued,
+			BookedBalance:      balance.FunctionalBalance,
+			Adjustment:         revalued.Sub(balance.FunctionalBalance),
+		}
+		line.Computation = describeRevaluation(line, functional)
+		resp.Lines = append(resp.Lines, line)
+	}
+	if len(missingRates) > 0 {
+		sort.Strings(missingRates)
+		logger.WarnLogger.Printf("Service: Revaluation as of %s is missing rates for %s", date.Format("2006-01-02"), strings.Join(missingRates, ", "))
+		return nil, errors.NewValidationError(fmt.Sprintf("no exchange rate into %s on or before %s for: %s", functional, date.Format("2006-01-02"), strings.Join(missingRates, ", ")), "revaluation_date")
+	}
+	sort.Slice(resp.Lines, func(i, j int) bool {
+		if resp.Lines[i].AccountCode != resp.Lines[j].AccountCode {
+			return resp.Lines[i].AccountCode < resp.Lines[j].AccountCode
+		}
+		return resp.Lines[i].Currency < resp.Lines[j].Currency
+	})
+
+	// Each adjustment moves only the functional amount of its account and currency; the gains and
+	// losses are carried gross to their accounts.
+	var lines []models.JournalLine
+	for _, line := range resp.Lines {
+		if line.Adjustment.IsZero() {
+			continue
+		}
+		lines = append(lines, models.JournalLine{
+			AccountID:         line.AccountID,
+			Amount:            line.Adjustment.Abs(),
+			TransactionAmount: money.Zero,
+			Currency:          line.Currency,
+			ExchangeRate:      line.Rate,
+			IsDebit:           line.Adjustment.IsPositive(),
+		})
+		if line.Adjustment.IsPositive() {
+			resp.TotalGain = resp.TotalGain.Add(line.Adjustment)
+		} else {
+			resp.TotalLoss = resp.TotalLoss.Sub(line.Adjustment)
+		}
+	}
+	resp.NetAdjustment = resp.TotalGain.Sub(resp.TotalLoss)
+	if resp.TotalGain.IsPositive() {
+		lines = append(lines, s.functionalLine(ctx, gainAccount.ID, resp.TotalGain, false))
+	}
+	if resp.TotalLoss.IsPositive() {
+		lines = append(lines, s.functionalLine(ctx, lossAccount.ID, resp.TotalLoss, true))
+	}
+	if req.DryRun || len(lines) == 0 {
+		logger.InfoLogger.Printf("Service: Revaluation as of %s computed %d balances, net %s %s; nothing posted", date.Format("2006-01-02"), len(resp.Lines), resp.NetAdjustment, functional)
+		return resp, nil
+	}
+
+	// The entry and its scheduled reversal commit together, so the unrealized gain or loss is never
+	// left in the books without the reversal that takes it out again.
+	reverseOn := date.AddDate(0, 0, 1)
+	entry, err := s.journalRepo.Create(ctx, &models.JournalEntry{
+		EntryDate:     date,
+		Description:   "Foreign currency revaluation as of " + date.Format("2006-01-02"),
+		Reference:     "FXREV-" + date.Format("2006-01-02"),
+		Status:        models.StatusPosted,
+		EntryType:     models.EntryTypeRevaluation,
+		JournalLines:  lines,
+		AutoReverseOn: &reverseOn,
+	})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error posting revaluation entry as of %s: %v", date.Format("2006-01-02"), err)
+		return nil, err
+	}
+	resp.Entry = entry
+	resp.ReverseOn = &reverseOn
+	logger.InfoLogger.Printf("Service: Posted revaluation entry %s as of %s: gain %s, loss %s %s", entry.ID, date.Format("2006-01-02"), resp.TotalGain, resp.TotalLoss, functional)
+	return resp, nil
+}
+
+// fxAccount loads a configured FX gain or loss account, which must be an active P&L account.
+func (s *accountingService) fxAccount(ctx context.Context, code, field string) (*models.ChartOfAccount, error) {
+	account, err := s.coaRepo.GetByCode(ctx, code)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("FX account %s does not exist", code), field)
+		}
+		return nil, err
+	}
+	if !account.IsActive || (account.AccountType != models.Revenue && account.AccountType != models.Expense) {
+		return nil, errors.NewValidationError(fmt.Sprintf("FX account %s must be an active REVENUE or EXPENSE account", code), field)
+	}
+	return account, nil
+}
+
+// describeRevaluation spells out how a revaluation line was computed, e.g.
+// "1000.00 EUR x 1.1000 (rate of 2026-03-31) = 1100.00 USD; booked 1082.50 USD; gain 17.50 USD".
+func describeRevaluation(line dto.FXRevaluationLine, functional string) string {
+	outcome := "no change"
+	switch {
+	case line.Adjustment.IsPositive():
+		outcome = fmt.Sprintf("gain %s %s", line.Adjustment, functional)
+	case line.Adjustment.IsNegative():
+		outcome = fmt.Sprintf("loss %s %s", line.Adjustment.Abs(), functional)
+	}
+	return fmt.Sprintf("%s %s x %s (rate of %s) = %s %s; booked %s %s; %s",
+		line.TransactionBalance, line.Currency, line.Rate, line.RateDate.Format("2006-01-02"),
+		line.RevaluedBalance, functional, line.BookedBalance, functional, outcome)
+}
+
+// --- Reporting Methods ---
+
+func (s *accountingService) GetTrialBalance(ctx context.Context, req dto.TrialBalanceRequest) (*dto.TrialBalanceResponse, error) {
+	logger.InfoLogger.Printf("Service: Generating Trial Balance for period ending %s", req.EndDate.Format("2006-01-02"))
+
+	if req.EndDate.IsZero() {
+		req.EndDate = time.Now() // Default to current date if not specified
+	}
+	// Start date for trial balance is effectively the beginning of time for cumulative balances,
+	// or a specific start if it's a period-specific TB (less common for standard TB).
+	// For simplicity, let's assume it's cumulative up to EndDate.
+	// The repository method GetJournalEntriesForTrialBalance needs a start and end.
+	// For a cumulative trial balance, startDate could be very early, or the logic adapted.
+	// Let's make startDate configurable or default to a very early date for full history.
+	var startDate time.Time
+	if req.StartDate.IsZero() {
+		startDate = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC) // A very early date
+	} else {
+		startDate = req.StartDate
+	}
+	dimensions, groupBy, err := s.dimensionFilter(ctx, req.Dimensions, req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Fetch all posted journal entries up to the EndDate
+	// The repo method GetJournalEntriesForTrialBalance should handle this.
+	// It needs to fetch entries with status "POSTED" and entry_date <= req.EndDate.
+	// It should also preload JournalLines and their associated ChartOfAccount.
+	entries, err := s.journalRepo.GetJournalEntriesForTrialBalance(ctx, startDate, req.EndDate)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching journal entries for trial balance: %v", err)
+		return nil, err
+	}
+
+	// 2. Aggregate balances for each account, and for each value of the group-by dimension ("" if not grouped)
+	accountBalances := make(map[uuid.UUID]map[string]money.Amount) // K: AccountID, V: Balance per value (positive for debit, negative for credit normal balance)
+	accountDetails := make(map[uuid.UUID]models.ChartOfAccount) // K: AccountID, V: Account details
+
+	for _, entry := range entries {
+		for _, line := range entry.JournalLines {
+			if line.ChartOfAccount == nil { // Defensive check
+				logger.ErrorLogger.Printf("Service: TrialBalance - Journal line %s is missing ChartOfAccount details. Skipping.", line.ID)
+				// This indicates an issue with preloading or data integrity.
+				// Potentially fetch it:
+				// acc, accErr := s.coaRepo.GetByID(ctx, line.AccountID)
+				// if accErr != nil { continue }
+				// line.ChartOfAccount = acc
+				continue
+			}
+
+			if !lineHasDimensions(line, dimensions) {
+				continue
+			}
+			if _, exists := accountDetails[line.AccountID]; !exists {
+				accountDetails[line.AccountID] = *line.ChartOfAccount
+			}
+			if accountBalances[line.AccountID] == nil {
+				accountBalances[line.AccountID] = make(map[string]money.Amount)
+			}
+			value := ""
+			if groupBy != "" {
+				value = lineDimensionValue(line, groupBy)
+			}
+
+			amount := line.Amount
+			if line.IsDebit {
+				accountBalances[line.AccountID][value] = accountBalances[line.AccountID][value].Add(amount)
+			} else {
+				accountBalances[line.AccountID][value] = accountBalances[line.AccountID][value].Sub(amount)
+			}
+		}
+	}
+
+	// 3. Format for response
+	var trialBalanceLines []dto.TrialBalanceLine
+	totalDebits := money.Zero
+	totalCredits := money.Zero
+
+	// It's good practice to list all accounts from CoA, even those with zero balance.
+    // So, fetch all active accounts first.
+    allAccounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{"is_active": true}) // Get all active accounts
+    if err != nil {
+        logger.ErrorLogger.Printf("Service: Error fetching all active accounts for trial balance: %v", err)
+        return nil, errors.NewInternalServerError("failed to fetch accounts for trial balance", err)
+    }
+
+    for _, acc := range allAccounts {
+        values := make([]string, 0, len(accountBalances[acc.ID]))
+        for value := range accountBalances[acc.ID] {
+            values = append(values, value)
+        }
+        if len(values) == 0 {
+            values = append(values, "") // No transactions for this account
+        }
+        sort.Strings(values)
+        for _, value := range values {
+            balance := accountBalances[acc.ID][value] // Will be zero if no transactions for this account
+
+            debitAmount := money.Zero
+            creditAmount := money.Zero
+
+            // Determine if balance is debit or credit based on account type's normal balance
+            // Assets, Expenses normally have Debit balances.
+            // Liabilities, Equity, Revenue normally have Credit balances.
+            isDebitNormalBalance := acc.AccountType == models.Asset || acc.AccountType == models.Expense
+
+            if isDebitNormalBalance {
+                if !balance.IsNegative() { // Normal debit balance or zero
+                    debitAmount = balance
+                } else { // Abnormal credit balance
+                    creditAmount = balance.Neg() // Show as positive credit
+                }
+            } else { // Credit normal balance (Liability, Equity, Revenue)
+                if !balance.IsPositive() { // Normal credit balance or zero
+                    creditAmount = balance.Neg()
+                } else { // Abnormal debit balance
+                    debitAmount = balance // Show as positive debit
+                }
+            }
+
+            // Only add lines if there's a non-zero balance, or if req.IncludeZeroBalance is true
+            if req.IncludeZeroBalanceAccounts || !debitAmount.IsZero() || !creditAmount.IsZero() {
+                trialBalanceLines = append(trialBalanceLines, dto.TrialBalanceLine{
+                    AccountCode:    acc.AccountCode,
+                    AccountName:    acc.AccountName,
+                    DimensionValue: value,
+                    Debit:          debitAmount,
+                    Credit:         creditAmount,
+                })
+            }
+
+            totalDebits = totalDebits.Add(debitAmount)
+            totalCredits = totalCredits.Add(creditAmount)
+        }
+    }
+
+
+	// Sort lines by account code for consistent output (optional)
+	// sort.Slice(trialBalanceLines, func(i, j int) bool {
+	// 	return trialBalanceLines[i].AccountCode < trialBalanceLines[j].AccountCode
+	// })
+
+
+	// Final check for balance (should always balance if accounting is correct). A dimension filter
+	// keeps only some lines of each entry, so a filtered trial balance need not balance.
+	if len(dimensions) == 0 && !totalDebits.Equal(totalCredits) {
+		logger.ErrorLogger.Printf("Service: Trial Balance is out of balance! Debits: %s, Credits: %s", totalDebits, totalCredits)
+		// This is a critical system error if it happens.
+		return nil, errors.NewInternalServerError(fmt.Sprintf("trial balance generation failed: totals are unbalanced (D:%s, C:%s)", totalDebits, totalCredits), nil)
+	}
+
+	response := &dto.TrialBalanceResponse{
+		ReportDate:   req.EndDate,
+		Currency:     s.BaseCurrency(ctx),
+		Dimensions:   dimensions,
+		GroupBy:      groupBy,
+		Lines:        trialBalanceLines,
+		TotalDebits:  totalDebits,
+		TotalCredits: totalCredits,
+	}
+
+	logger.InfoLogger.Printf("Service: Successfully generated Trial Balance for period ending %s. Total Debits: %s, Total Credits: %s", req.EndDate.Format("2006-01-02"), totalDebits, totalCredits)
+	return response, nil
+}
+
+
+// GetBalanceSheet reports ASSET, LIABILITY and EQUITY balances as of the end of the given day.
+// Revenue and expense activity is folded into equity: the net result since the start of the
+// fiscal year containing the date (the calendar year if no fiscal calendar covers it) is shown as
+// "Current Year Earnings", and any earlier result that has not been closed out to an equity account
+// is shown as "Retained Earnings (Unclosed Prior Years)".
+func (s *accountingService) GetBalanceSheet(ctx context.Context, date time.Time) (*dto.BalanceSheetResponse, error) {
+	if date.IsZero() {
+		date = time.Now()
+	}
+	logger.InfoLogger.Printf("Service: Generating Balance Sheet as of %s", date.Format("2006-01-02"))
+
+	asOf := endOfDay(date)
+	yearStart, err := s.fiscalYearStart(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	veryEarlyDate := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entries, err := s.journalRepo.GetJournalEntriesForTrialBalance(ctx, veryEarlyDate, asOf)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching journal entries for balance sheet: %v", err)
+		return nil, err
+	}
+
+	// Net debit-minus-credit per account, plus the same for revenue/expense split at the year start.
+	balances := make(map[uuid.UUID]money.Amount)
+	priorEarnings := money.Zero   // Credit-positive
+	currentEarnings := money.Zero // Credit-positive
+	for _, entry := range entries {
+		for _, line := range entry.JournalLines {
+			signed := line.Amount
+			if !line.IsDebit {
+				signed = signed.Neg()
+			}
+			balances[line.AccountID] = balances[line.AccountID].Add(signed)
+
+			if line.ChartOfAccount == nil {
+				continue
+			}
+			if line.ChartOfAccount.AccountType == models.Revenue || line.ChartOfAccount.AccountType == models.Expense {
+				if entry.EntryDate.Before(yearStart) {
+					priorEarnings = priorEarnings.Sub(signed)
+				} else {
+					currentEarnings = currentEarnings.Sub(signed)
+				}
+			}
+		}
+	}
+
+	// Inactive accounts are included so that balances left on them are not dropped from the report.
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching accounts for balance sheet: %v", err)
+		return nil, errors.NewInternalServerError("failed to fetch accounts for balance sheet", err)
+	}
+
+	response := &dto.BalanceSheetResponse{
+		ReportDate:  date,
+		Assets:      dto.BalanceSheetSection{Title: "Assets", Accounts: []dto.BalanceSheetLine{}},
+		Liabilities: dto.BalanceSheetSection{Title: "Liabilities", Accounts: []dto.BalanceSheetLine{}},
+		Equity:      dto.BalanceSheetSection{Title: "Equity", Accounts: []dto.BalanceSheetLine{}},
+	}
+
+	for _, acc := range accounts {
+		balance := balances[acc.ID]
+		var section *dto.BalanceSheetSection
+		switch acc.AccountType {
+		case models.Asset:
+			section = &response.Assets
+		case models.Liability:
+			section = &response.Liabilities
+			balance = balance.Neg() // Present credit balances as positive
+		case models.Equity:
+			section = &response.Equity
+			balance = balance.Neg()
+		default:
+			continue
+		}
+		if balance.IsZero() {
+			continue
+		}
+		section.Accounts = append(section.Accounts, dto.BalanceSheetLine{
+			AccountCode: acc.AccountCode,
+			AccountName: acc.AccountName,
+			Amount:      balance,
+		})
+		section.Total = section.Total.Add(balance)
+	}
+
+	if !priorEarnings.IsZero() {
+		response.Equity.Accounts = append(response.Equity.Accounts, dto.BalanceSheetLine{
+			AccountName: "Retained Earnings (Unclosed Prior Years)",
+			Amount:      priorEarnings,
+		})
+		response.Equity.Total = response.Equity.Total.Add(priorEarnings)
+	}
+	response.Equity.Accounts = append(response.Equity.Accounts, dto.BalanceSheetLine{
+		AccountName: "Current Year Earnings",
+		Amount:      currentEarnings,
+	})
+	response.Equity.Total = response.Equity.Total.Add(currentEarnings)
+
+	response.TotalLiabilitiesAndEquity = response.Liabilities.Total.Add(response.Equity.Total)
+
+	// Assets = Liabilities + Equity must hold if every posted entry balances.
+	if !response.Assets.Total.Equal(response.TotalLiabilitiesAndEquity) {
+		logger.ErrorLogger.Printf("Service: Balance Sheet is out of balance! Assets: %s, Liabilities + Equity: %s", response.Assets.Total, response.TotalLiabilitiesAndEquity)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("balance sheet generation failed: assets (%s) do not equal liabilities and equity (%s)", response.Assets.Total, response.TotalLiabilitiesAndEquity), nil)
+	}
+
+	logger.InfoLogger.Printf("Service: Successfully generated Balance Sheet as of %s. Total Assets: %s", date.Format("2006-01-02"), response.Assets.Total)
+	return response, nil
+}
+
+
+// fiscalYearStart returns the first day of the fiscal year containing date, or of its calendar
+// year when no fiscal calendar is configured or no fiscal year covers the date.
+func (s *accountingService) fiscalYearStart(ctx context.Context, date time.Time) (time.Time, error) {
+	if s.fiscalRepo != nil {
+		day := dateOnly(date)
+		years, err := s.fiscalRepo.FindOverlappingFiscalYears(ctx, day, day)
+		if err != nil {
+			logger.ErrorLogger.Printf("Service: Error finding fiscal year for %s: %v", day.Format("2006-01-02"), err)
+			return time.Time{}, err
+		}
+		if len(years) > 0 {
+			return dateOnly(years[0].StartDate), nil
+		}
+	}
+	return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location()), nil
+}
+
+// GetProfitAndLossStatement reports REVENUE and EXPENSE activity from posted entries between
+// StartDate and EndDate (inclusive). Lines follow the ParentAccountID hierarchy, with parent
+// accounts carrying subtotals of their descendants. Optional comparison columns cover the
+// preceding period of equal length and the same dates one year earlier.
+func (s *accountingService) GetProfitAndLossStatement(ctx context.Context, req dto.ProfitAndLossRequest) (*dto.ProfitAndLossResponse, error) {
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return nil, errors.NewValidationError("start_date and end_date are required", "")
+	}
+	if req.EndDate.Before(req.StartDate) {
+		return nil, errors.NewValidationError("end_date must not be before start_date", "end_date")
+	}
+	logger.InfoLogger.Printf("Service: Generating Profit and Loss for %s to %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"))
+
+	current, err := s.netActivityByAccount(ctx, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ProfitAndLossResponse{
+		ReportPeriod: fmt.Sprintf("For the period %s to %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02")),
+		Period:       dto.ProfitAndLossPeriod{StartDate: req.StartDate, EndDate: req.EndDate},
+	}
+
+	var priorPeriod, priorYear map[uuid.UUID]money.Amount
+	if req.CompareToPriorPeriod {
+		start, end := priorPeriodRange(req.StartDate, req.EndDate)
+		response.PriorPeriod = &dto.ProfitAndLossPeriod{StartDate: start, EndDate: end}
+		if priorPeriod, err = s.netActivityByAccount(ctx, start, end); err != nil {
+			return nil, err
+		}
+	}
+	if req.CompareToPriorYear {
+		start, end := shiftMonths(req.StartDate, -12), shiftMonths(req.EndDate, -12)
+		response.PriorYear = &dto.ProfitAndLossPeriod{StartDate: start, EndDate: end}
+		if priorYear, err = s.netActivityByAccount(ctx, start, end); err != nil {
+			return nil, err
+		}
+	}
+
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching accounts for profit and loss: %v", err)
+		return nil, errors.NewInternalServerError("failed to fetch accounts for profit and loss", err)
+	}
+
+	columns := []map[uuid.UUID]money.Amount{current, priorPeriod, priorYear}
+	response.Revenue = buildProfitAndLossSection("Revenue", models.Revenue, accounts, columns)
+	response.Expenses = buildProfitAndLossSection("Expenses", models.Expense, accounts, columns)
+
+	response.NetIncome = response.Revenue.Total.Sub(response.Expenses.Total)
+	if priorPeriod != nil {
+		response.NetIncomePriorPeriod = newProfitAndLossComparison(response.NetIncome,
+			response.Revenue.PriorPeriod.Amount.Sub(response.Expenses.PriorPeriod.Amount))
+	}
+	if priorYear != nil {
+		response.NetIncomePriorYear = newProfitAndLossComparison(response.NetIncome,
+			response.Revenue.PriorYear.Amount.Sub(response.Expenses.PriorYear.Amount))
+	}
+
+	logger.InfoLogger.Printf("Service: Successfully generated Profit and Loss for %s to %s. Net Income: %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"), response.NetIncome)
+	return response, nil
+}
+
+// GetCashFlowStatement builds a statement of cash flows using the indirect method: net income
+// for the period, adjusted by the change in every non-cash balance sheet account, grouped by the
+// account's cash flow category. The result must reconcile to the change in CASH accounts.
+func (s *accountingService) GetCashFlowStatement(ctx context.Context, req dto.CashFlowRequest) (*dto.CashFlowResponse, error) {
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return nil, errors.NewValidationError("start_date and end_date are required", "")
+	}
+	if req.EndDate.Before(req.StartDate) {
+		return nil, errors.NewValidationError("end_date must not be before start_date", "end_date")
+	}
+	logger.InfoLogger.Printf("Service: Generating Cash Flow Statement for %s to %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"))
+
+	veryEarlyDate := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	opening, err := s.netActivityByAccount(ctx, veryEarlyDate, req.StartDate.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	period, err := s.netActivityByAccount(ctx, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching accounts for cash flow statement: %v", err)
+		return nil, errors.NewInternalServerError("failed to fetch accounts for cash flow statement", err)
+	}
+
+	response := &dto.CashFlowResponse{
+		ReportPeriod: fmt.Sprintf("For the period %s to %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02")),
+		Operating: dto.CashFlowOperatingSection{
+			NonCashAdjustments:    []dto.CashFlowLine{},
+			WorkingCapitalChanges: []dto.CashFlowLine{},
+		},
+		Investing: dto.CashFlowSection{Title: "Investing Activities", Lines: []dto.CashFlowLine{}},
+		Financing: dto.CashFlowSection{Title: "Financing Activities", Lines: []dto.CashFlowLine{}},
+	}
+
+	hasCashAccount := false
+	cashChange := money.Zero
+	for _, acc := range accounts {
+		change := period[acc.ID] // Debit-positive
+		category := acc.EffectiveCashFlowCategory()
+		if category == models.CashFlowCash {
+			hasCashAccount = true
+			response.OpeningCash = response.OpeningCash.Add(opening[acc.ID])
+			cashChange = cashChange.Add(change)
+			continue
+		}
+		if category == "" { // REVENUE or EXPENSE
+			response.Operating.NetIncome = response.Operating.NetIncome.Sub(change)
+			continue
+		}
+		if change.IsZero() {
+			continue
+		}
+		// A debit increase in a non-cash balance uses cash; a credit increase provides it.
+		line := dto.CashFlowLine{AccountCode: acc.AccountCode, AccountName: acc.AccountName, Amount: change.Neg()}
+		switch category {
+		case models.CashFlowNonCash:
+			response.Operating.NonCashAdjustments = append(response.Operating.NonCashAdjustments, line)
+		case models.CashFlowInvesting:
+			response.Investing.Lines = append(response.Investing.Lines, line)
+			response.Investing.Total = response.Investing.Total.Add(line.Amount)
+		case models.CashFlowFinancing:
+			response.Financing.Lines = append(response.Financing.Lines, line)
+			response.Financing.Total = response.Financing.Total.Add(line.Amount)
+		default:
+			response.Operating.WorkingCapitalChanges = append(response.Operating.WorkingCapitalChanges, line)
+		}
+	}
+	if !hasCashAccount {
+		return nil, errors.NewValidationError("no accounts are classified with cash flow category CASH", "cash_flow_category")
+	}
+
+	response.Operating.Total = response.Operating.NetIncome
+	for _, line := range response.Operating.NonCashAdjustments {
+		response.Operating.Total = response.Operating.Total.Add(line.Amount)
+	}
+	for _, line := range response.Operating.WorkingCapitalChanges {
+		response.Operating.Total = response.Operating.Total.Add(line.Amount)
+	}
+	response.NetChangeInCash = response.Operating.Total.Add(response.Investing.Total).Add(response.Financing.Total)
+	response.ClosingCash = response.OpeningCash.Add(cashChange)
+
+	if !response.NetChangeInCash.Equal(cashChange) {
+		logger.ErrorLogger.Printf("Service: Cash Flow Statement does not reconcile! Statement: %s, Cash accounts: %s", response.NetChangeInCash, cashChange)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("cash flow statement does not reconcile: net change %s, change in cash accounts %s", response.NetChangeInCash, cashChange), nil)
+	}
+
+	logger.InfoLogger.Printf("Service: Successfully generated Cash Flow Statement for %s to %s. Net change in cash: %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"), response.NetChangeInCash)
+	return response, nil
+}
+
+// GetAccountLedger lists every line of an account that affects its balance between startDate and
+// endDate (inclusive), with the counter-accounts of each line and a running balance that starts
+// from the balance carried into the period. A zero startDate covers the account's whole history.
+// With dimensions, only the lines tagged with every one of those values are included.
+func (s *accountingService) GetAccountLedger(ctx context.Context, accountID uuid.UUID, startDate, endDate time.Time, dimensions models.DimensionTags) (*dto.AccountLedgerResponse, error) {
+	logger.InfoLogger.Printf("Service: Generating ledger for account %s from %s to %s", accountID, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	startDate, endDate, err := ledgerPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	dimensions, _, err = s.dimensionFilter(ctx, dimensions, "")
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.coaRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountIDs := []uuid.UUID{accountID}
+	openingBalances, err := s.ledgerOpeningBalances(ctx, accountIDs, startDate, dimensions, "")
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching opening balance for ledger of account %s: %v", accountID, err)
+		return nil, err
+	}
+	entries, err := s.journalRepo.GetLedgerEntries(ctx, accountIDs, startDate, endOfDay(endDate))
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching journal entries for ledger of account %s: %v", accountID, err)
+		return nil, err
+	}
+	include := func(line models.JournalLine) bool { return lineHasDimensions(line, dimensions) }
+	ledger := buildAccountLedger(account, openingBalances[ledgerKey{AccountID: accountID}], entries, startDate, endDate, include)
+	return &ledger, nil
+}
+
+// GetGeneralLedger builds the ledgers of the requested accounts, or of every account with an
+// opening balance or activity in the period. Opening balances are summed by the database and only
+// the entries dated within the period are loaded. With req.GroupBy, each account gets one ledger
+// per value of that dimension, including one without a value for untagged lines.
+func (s *accountingService) GetGeneralLedger(ctx context.Context, req dto.GeneralLedgerRequest) (*dto.GeneralLedgerResponse, error) {
+	logger.InfoLogger.Printf("Service: Generating general ledger from %s to %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"))
+	startDate, endDate, err := ledgerPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	dimensions, groupBy, err := s.dimensionFilter(ctx, req.Dimensions, req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []*models.ChartOfAccount
+	requested := uniqueIDs(req.AccountIDs)
+	if len(requested) > 0 {
+		accounts, err = s.coaRepo.GetByIDs(ctx, requested)
+		if err != nil {
+			logger.ErrorLogger.Printf("Service: Error fetching accounts for general ledger: %v", err)
+			return nil, err
+		}
+		if len(accounts) != len(requested) {
+			found := make(map[uuid.UUID]bool, len(accounts))
+			for _, acc := range accounts {
+				found[acc.ID] = true
+			}
+			for _, id := range requested {
+				if !found[id] {
+					return nil, errors.NewValidationError(fmt.Sprintf("account %s does not exist", id), "account_ids")
+				}
+			}
+		}
+	}
+
+	openingBalances, err := s.ledgerOpeningBalances(ctx, requested, startDate, dimensions, groupBy)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching opening balances for general ledger: %v", err)
+		return nil, err
+	}
+	entries, err := s.journalRepo.GetLedgerEntries(ctx, requested, startDate, endOfDay(endDate))
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching journal entries for general ledger: %v", err)
+		return nil, err
+	}
+	groupValue := func(line models.JournalLine) string {
+		if groupBy == "" {
+			return ""
+		}
+		return lineDimensionValue(line, groupBy)
+	}
+	entriesByLedger := make(map[ledgerKey][]models.JournalEntry)
+	for _, entry := range entries {
+		seen := make(map[ledgerKey]bool)
+		for _, line := range entry.JournalLines {
+			if !lineHasDimensions(line, dimensions) {
+				continue
+			}
+			key := ledgerKey{AccountID: line.AccountID, Value: groupValue(line)}
+			if !seen[key] {
+				seen[key] = true
+				entriesByLedger[key] = append(entriesByLedger[key], entry)
+			}
+		}
+	}
+	valuesByAccount := make(map[uuid.UUID][]string)
+	for key := range entriesByLedger {
+		valuesByAccount[key.AccountID] = append(valuesByAccount[key.AccountID], key.Value)
+	}
+	for key, balance := range openingBalances {
+		if _, ok := entriesByLedger[key]; !ok && !balance.IsZero() {
+			valuesByAccount[key.AccountID] = append(valuesByAccount[key.AccountID], key.Value)
+		}
 	}
 
+	if len(requested) == 0 {
+		// Without an account list, report every account with a balance or activity.
+		active := make([]uuid.UUID, 0, len(valuesByAccount))
+		for id := range valuesByAccount {
+			active = append(active, id)
+		}
+		accounts, err = s.coaRepo.GetByIDs(ctx, active)
+		if err != nil {
+			logger.ErrorLogger.Printf("Service: Error fetching accounts for general ledger: %v", err)
+			return nil, err
+		}
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].AccountCode < accounts[j].AccountCode })
 
-	logger.InfoLogger.Printf("Service: Successfully posted journal entry with ID: %s", postedEntry.ID)
-	return postedEntry, nil
+	report := &dto.GeneralLedgerResponse{StartDate: startDate, EndDate: endDate, Dimensions: dimensions, GroupBy: groupBy,
+		Accounts: []dto.AccountLedgerResponse{}, TotalDebits: money.Zero, TotalCredits: money.Zero}
+	for _, acc := range accounts {
+		values := valuesByAccount[acc.ID]
+		if len(values) == 0 {
+			values = []string{""} // A requested account without activity still gets its ledger
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			include := func(line models.JournalLine) bool {
+				return lineHasDimensions(line, dimensions) && groupValue(line) == value
+			}
+			key := ledgerKey{AccountID: acc.ID, Value: value}
+			ledger := buildAccountLedger(acc, openingBalances[key], entriesByLedger[key], startDate, endDate, include)
+			ledger.DimensionValue = value
+			report.Accounts = append(report.Accounts, ledger)
+			report.TotalDebits = report.TotalDebits.Add(ledger.TotalDebits)
+			report.TotalCredits = report.TotalCredits.Add(ledger.TotalCredits)
+		}
+	}
+	logger.InfoLogger.Printf("Service: General ledger generated for %d accounts", len(report.Accounts))
+	return report, nil
 }
 
-// --- Reporting Methods ---
-
-func (s *accountingService) GetTrialBalance(ctx context.Context, req dto.TrialBalanceRequest) (*dto.TrialBalanceResponse, error) {
-	logger.InfoLogger.Printf("Service: Generating Trial Balance for period ending %s", req.EndDate.Format("2006-01-02"))
+// ledgerKey identifies one ledger of a report: an account, and the value of the group-by
+// dimension when the report is grouped ("" otherwise, and for untagged lines).
+type ledgerKey struct {
+	AccountID uuid.UUID
+	Value     string
+}
 
-	if req.EndDate.IsZero() {
-		req.EndDate = time.Now() // Default to current date if not specified
-	}
-	// Start date for trial balance is effectively the beginning of time for cumulative balances,
-	// or a specific start if it's a period-specific TB (less common for standard TB).
-	// For simplicity, let's assume it's cumulative up to EndDate.
-	// The repository method GetJournalEntriesForTrialBalance needs a start and end.
-	// For a cumulative trial balance, startDate could be very early, or the logic adapted.
-	// Let's make startDate configurable or default to a very early date for full history.
-	var startDate time.Time
-	if req.StartDate.IsZero() {
-		startDate = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC) // A very early date
-	} else {
-		startDate = req.StartDate
+// ledgerOpeningBalances sums the balances carried into a ledger, per account and group-by value.
+// Without a dimension filter or group-by it uses the plain per-account sums.
+func (s *accountingService) ledgerOpeningBalances(ctx context.Context, accountIDs []uuid.UUID, before time.Time, dimensions models.DimensionTags, groupBy string) (map[ledgerKey]money.Amount, error) {
+	balances := make(map[ledgerKey]money.Amount)
+	if len(dimensions) == 0 && groupBy == "" {
+		byAccount, err := s.journalRepo.GetAccountBalancesBefore(ctx, accountIDs, before)
+		if err != nil {
+			return nil, err
+		}
+		for id, balance := range byAccount {
+			balances[ledgerKey{AccountID: id}] = balance
+		}
+		return balances, nil
 	}
-
-
-	// 1. Fetch all posted journal entries up to the EndDate
-	// The repo method GetJournalEntriesForTrialBalance should handle this.
-	// It needs to fetch entries with status "POSTED" and entry_date <= req.EndDate.
-	// It should also preload JournalLines and their associated ChartOfAccount.
-	entries, err := s.journalRepo.GetJournalEntriesForTrialBalance(ctx, startDate, req.EndDate)
+	rows, err := s.journalRepo.GetDimensionBalancesBefore(ctx, accountIDs, before, dimensions, groupBy)
 	if err != nil {
-		logger.ErrorLogger.Printf("Service: Error fetching journal entries for trial balance: %v", err)
 		return nil, err
 	}
+	for _, row := range rows {
+		key := ledgerKey{AccountID: row.AccountID, Value: row.ValueCode}
+		balances[key] = balances[key].Add(row.Balance)
+	}
+	return balances, nil
+}
 
-	// 2. Aggregate balances for each account
-	accountBalances := make(map[uuid.UUID]float64)    // K: AccountID, V: Balance (positive for debit, negative for credit normal balance)
-	accountDetails := make(map[uuid.UUID]models.ChartOfAccount) // K: AccountID, V: Account details
+// uniqueIDs returns ids without duplicates, keeping their first-seen order.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// ledgerPeriod validates a ledger's date range, defaulting the end to today.
+func ledgerPeriod(startDate, endDate time.Time) (time.Time, time.Time, error) {
+	if endDate.IsZero() {
+		endDate = time.Now()
+	}
+	endDate = dateOnly(endDate)
+	if !startDate.IsZero() {
+		startDate = dateOnly(startDate)
+		if endDate.Before(startDate) {
+			return time.Time{}, time.Time{}, errors.NewValidationError("to must not be before from", "to")
+		}
+	}
+	return startDate, endDate, nil
+}
 
+// buildAccountLedger lays out an account's ledger from its balance carried into the period and the
+// entries within the period that affect balances and touch the account, keeping only the lines
+// include accepts.
+func buildAccountLedger(account *models.ChartOfAccount, openingBalance money.Amount, entries []models.JournalEntry, startDate, endDate time.Time, include func(models.JournalLine) bool) dto.AccountLedgerResponse {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].EntryDate.Equal(entries[j].EntryDate) {
+			return entries[i].EntryDate.Before(entries[j].EntryDate)
+		}
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	ledger := dto.AccountLedgerResponse{
+		AccountID:      account.ID,
+		AccountCode:    account.AccountCode,
+		AccountName:    account.AccountName,
+		AccountType:    account.AccountType,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		OpeningBalance: openingBalance,
+		TotalDebits:    money.Zero,
+		TotalCredits:   money.Zero,
+		Lines:          []dto.AccountLedgerLine{},
+	}
+	balance := openingBalance
 	for _, entry := range entries {
 		for _, line := range entry.JournalLines {
-			if line.ChartOfAccount == nil { // Defensive check
-				logger.ErrorLogger.Printf("Service: TrialBalance - Journal line %s is missing ChartOfAccount details. Skipping.", line.ID)
-				// This indicates an issue with preloading or data integrity.
-				// Potentially fetch it:
-				// acc, accErr := s.coaRepo.GetByID(ctx, line.AccountID)
-				// if accErr != nil { continue }
-				// line.ChartOfAccount = acc
+			if line.AccountID != account.ID || !include(line) {
 				continue
 			}
-
-			if _, exists := accountDetails[line.AccountID]; !exists {
-				accountDetails[line.AccountID] = *line.ChartOfAccount
-			}
-
-			amount := line.Amount
+			debit, credit := money.Zero, money.Zero
 			if line.IsDebit {
-				accountBalances[line.AccountID] += amount
+				debit = line.Amount
+				balance = balance.Add(line.Amount)
 			} else {
-				accountBalances[line.AccountID] -= amount
+				credit = line.Amount
+				balance = balance.Sub(line.Amount)
 			}
+			ledger.TotalDebits = ledger.TotalDebits.Add(debit)
+			ledger.TotalCredits = ledger.TotalCredits.Add(credit)
+			ledger.Lines = append(ledger.Lines, dto.AccountLedgerLine{
+				JournalEntryID:  entry.ID,
+				JournalLineID:   line.ID,
+				EntryDate:       entry.EntryDate,
+				Description:     entry.Description,
+				Reference:       entry.Reference,
+				EntryType:       entry.EntryType,
+				Debit:           debit,
+				Credit:          credit,
+				RunningBalance:  balance,
+				CounterAccounts: counterAccounts(entry, account.ID),
+				Dimensions:      line.Dimensions,
+			})
 		}
 	}
+	ledger.ClosingBalance = balance
+	return ledger
+}
 
-	// 3. Format for response
-	var trialBalanceLines []dto.TrialBalanceLine
-	var totalDebits float64
-	var totalCredits float64
-
-	// It's good practice to list all accounts from CoA, even those with zero balance.
-    // So, fetch all active accounts first.
-    allAccounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{"is_active": true}) // Get all active accounts
-    if err != nil {
-        logger.ErrorLogger.Printf("Service: Error fetching all active accounts for trial balance: %v", err)
-        return nil, errors.NewInternalServerError("failed to fetch accounts for trial balance", err)
-    }
-
-    for _, acc := range allAccounts {
-        balance := accountBalances[acc.ID] // Will be 0.0 if no transactions for this account
-
-        debitAmount := 0.0
-        creditAmount := 0.0
-
-        // Determine if balance is debit or credit based on account type's normal balance
-        // Assets, Expenses normally have Debit balances.
-        // Liabilities, Equity, Revenue normally have Credit balances.
-        isDebitNormalBalance := acc.AccountType == models.Asset || acc.AccountType == models.Expense
+// counterAccounts lists the other accounts of an entry, in line order and without duplicates.
+func counterAccounts(entry models.JournalEntry, accountID uuid.UUID) []dto.LedgerCounterAccount {
+	counters := []dto.LedgerCounterAccount{}
+	seen := map[uuid.UUID]bool{accountID: true}
+	for _, line := range entry.JournalLines {
+		if seen[line.AccountID] {
+			continue
+		}
+		seen[line.AccountID] = true
+		counter := dto.LedgerCounterAccount{AccountID: line.AccountID}
+		if line.ChartOfAccount != nil {
+			counter.AccountCode = line.ChartOfAccount.AccountCode
+			counter.AccountName = line.ChartOfAccount.AccountName
+		}
+		counters = append(counters, counter)
+	}
+	return counters
+}
 
-        if isDebitNormalBalance {
-            if balance >= 0 { // Normal debit balance or zero
-                debitAmount = balance
-            } else { // Abnormal credit balance
-                creditAmount = -balance // Show as positive credit
-            }
-        } else { // Credit normal balance (Liability, Equity, Revenue)
-            if balance <= 0 { // Normal credit balance or zero
-                creditAmount = -balance
-            } else { // Abnormal debit balance
-                debitAmount = balance // Show as positive debit
-            }
-        }
+// netActivityByAccount returns debits minus credits per account for posted, non-closing entries dated
+// within [startDate, endDate], where endDate includes the whole day.
+func (s *accountingService) netActivityByAccount(ctx context.Context, startDate, endDate time.Time) (map[uuid.UUID]money.Amount, error) {
+	entries, err := s.journalRepo.GetJournalEntriesForTrialBalance(ctx, startDate, endOfDay(endDate))
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching journal entries for %s to %s: %v", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), err)
+		return nil, err
+	}
+	net := make(map[uuid.UUID]money.Amount)
+	for _, entry := range entries {
+		if entry.EntryType == models.EntryTypeClosing {
+			continue // Year-end closing entries are not operating activity
+		}
+		for _, line := range entry.JournalLines {
+			if line.IsDebit {
+				net[line.AccountID] = net[line.AccountID].Add(line.Amount)
+			} else {
+				net[line.AccountID] = net[line.AccountID].Sub(line.Amount)
+			}
+		}
+	}
+	return net, nil
+}
 
-        // Only add lines if there's a non-zero balance, or if req.IncludeZeroBalance is true
-        if req.IncludeZeroBalanceAccounts || debitAmount != 0 || creditAmount != 0 {
-            trialBalanceLines = append(trialBalanceLines, dto.TrialBalanceLine{
-                AccountCode: acc.AccountCode,
-                AccountName: acc.AccountName,
-                Debit:       debitAmount,
-                Credit:      creditAmount,
-            })
-        }
+// buildProfitAndLossSection lays out all accounts of one type as a tree. columns holds the
+// current period followed by the optional prior-period and prior-year activity (nil if not requested).
+func buildProfitAndLossSection(title string, accountType models.AccountType, accounts []*models.ChartOfAccount, columns []map[uuid.UUID]money.Amount) dto.ProfitAndLossSection {
+	section := dto.ProfitAndLossSection{Title: title, Accounts: []dto.ProfitAndLossLine{}}
 
-        totalDebits += debitAmount
-        totalCredits += creditAmount
-    }
+	inSection := make(map[uuid.UUID]bool)
+	for _, acc := range accounts {
+		if acc.AccountType == accountType {
+			inSection[acc.ID] = true
+		}
+	}
+	children := make(map[uuid.UUID][]*models.ChartOfAccount)
+	var roots []*models.ChartOfAccount
+	for _, acc := range accounts {
+		if !inSection[acc.ID] {
+			continue
+		}
+		// A parent of a different type cannot carry this section's subtotal, so treat the account as a root.
+		if acc.ParentAccountID != nil && inSection[*acc.ParentAccountID] && *acc.ParentAccountID != acc.ID {
+			children[*acc.ParentAccountID] = append(children[*acc.ParentAccountID], acc)
+		} else {
+			roots = append(roots, acc)
+		}
+	}
 
+	// Revenue is naturally a credit balance; present it as positive.
+	natural := func(column map[uuid.UUID]money.Amount, id uuid.UUID) money.Amount {
+		if accountType == models.Revenue {
+			return column[id].Neg()
+		}
+		return column[id]
+	}
+
+	totals := make([]money.Amount, len(columns))
+	visited := make(map[uuid.UUID]bool)
+	var walk func(acc *models.ChartOfAccount, level int) []money.Amount
+	walk = func(acc *models.ChartOfAccount, level int) []money.Amount {
+		visited[acc.ID] = true
+		subtotals := make([]money.Amount, len(columns))
+		for i, column := range columns {
+			if column != nil {
+				subtotals[i] = natural(column, acc.ID)
+			}
+		}
+		lineIndex := len(section.Accounts)
+		section.Accounts = append(section.Accounts, dto.ProfitAndLossLine{
+			AccountID:   acc.ID,
+			AccountCode: acc.AccountCode,
+			AccountName: acc.AccountName,
+			Level:       level,
+			IsSubtotal:  len(children[acc.ID]) > 0,
+		})
+		for _, child := range children[acc.ID] {
+			if visited[child.ID] { // Guard against cycles in ParentAccountID
+				continue
+			}
+			for i, amount := range walk(child, level+1) {
+				subtotals[i] = subtotals[i].Add(amount)
+			}
+		}
 
-	// Sort lines by account code for consistent output (optional)
-	// sort.Slice(trialBalanceLines, func(i, j int) bool {
-	// 	return trialBalanceLines[i].AccountCode < trialBalanceLines[j].AccountCode
-	// })
+		allZero := true
+		for _, amount := range subtotals {
+			allZero = allZero && amount.IsZero()
+		}
+		if allZero {
+			section.Accounts = section.Accounts[:lineIndex] // Drop this account and its (all-zero) descendants
+			return subtotals
+		}
+		line := &section.Accounts[lineIndex]
+		line.Amount = subtotals[0]
+		if columns[1] != nil {
+			line.PriorPeriod = newProfitAndLossComparison(subtotals[0], subtotals[1])
+		}
+		if columns[2] != nil {
+			line.PriorYear = newProfitAndLossComparison(subtotals[0], subtotals[2])
+		}
+		return subtotals
+	}
+	for _, root := range roots {
+		for i, amount := range walk(root, 0) {
+			totals[i] = totals[i].Add(amount)
+		}
+	}
 
+	section.Total = totals[0]
+	if columns[1] != nil {
+		section.PriorPeriod = newProfitAndLossComparison(totals[0], totals[1])
+	}
+	if columns[2] != nil {
+		section.PriorYear = newProfitAndLossComparison(totals[0], totals[2])
+	}
+	return section
+}
 
-	// Final check for balance (should always balance if accounting is correct)
-	const tolerance = 1e-9
-	if math.Abs(totalDebits-totalCredits) > tolerance {
-		logger.ErrorLogger.Printf("Service: Trial Balance is out of balance! Debits: %.2f, Credits: %.2f", totalDebits, totalCredits)
-		// This is a critical system error if it happens.
-		return nil, errors.NewInternalServerError(fmt.Sprintf("trial balance generation failed: totals are unbalanced (D:%.2f, C:%.2f)", totalDebits, totalCredits), nil)
+// newProfitAndLossComparison computes the variance of current against a comparison amount.
+func newProfitAndLossComparison(current, comparison money.Amount) *dto.ProfitAndLossComparison {
+	c := &dto.ProfitAndLossComparison{Amount: comparison, Variance: current.Sub(comparison)}
+	if !comparison.IsZero() {
+		pct := math.Round(c.Variance.Float64()/comparison.Abs().Float64()*10000) / 100
+		c.VariancePercent = &pct
 	}
+	return c
+}
 
-	response := &dto.TrialBalanceResponse{
-		ReportDate: req.EndDate,
-		Lines:      trialBalanceLines,
-		TotalDebits:  totalDebits,
-		TotalCredits: totalCredits,
+// priorPeriodRange returns the period of equal length immediately before [start, end].
+// Whole-month ranges shift by whole months so that, e.g., Q2 compares to Q1.
+func priorPeriodRange(start, end time.Time) (time.Time, time.Time) {
+	if start.Day() == 1 && endOfDay(end).AddDate(0, 0, 1).Day() == 1 {
+		months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+		return shiftMonths(start, -months), shiftMonths(end, -months)
 	}
+	days := int(end.Sub(start).Hours()/24) + 1
+	return start.AddDate(0, 0, -days), end.AddDate(0, 0, -days)
+}
 
-	logger.InfoLogger.Printf("Service: Successfully generated Trial Balance for period ending %s. Total Debits: %.2f, Total Credits: %.2f", req.EndDate.Format("2006-01-02"), totalDebits, totalCredits)
-	return response, nil
+// shiftMonths moves t by n months, clamping to the last day of the target month
+// (e.g. 31 March minus one month is 29 or 28 February, not 2 or 3 March).
+// A date on the last day of its month stays on the last day of the target month.
+func shiftMonths(t time.Time, n int) time.Time {
+	firstOfTarget := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).AddDate(0, n, 0)
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay || t.AddDate(0, 0, 1).Day() == 1 {
+		day = lastDay
+	}
+	return firstOfTarget.AddDate(0, 0, day-1)
 }
 
 
-func (s *accountingService) GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (float64, error) {
+func (s *accountingService) GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (money.Amount, error) {
     logger.InfoLogger.Printf("Service: Calculating balance for account %s as of %s", accountID, date.Format("2006-01-02"))
 
     // Validate account
     account, err := s.coaRepo.GetByID(ctx, accountID)
     if err != nil {
         logger.ErrorLogger.Printf("Service: Error fetching account %s for balance calculation: %v", accountID, err)
-        return 0, err // Propagate NotFound or InternalServerError
+        return money.Zero, err // Propagate NotFound or InternalServerError
     }
 
     // Fetch all journal entries involving this account, up to the specified date, that are POSTED.
@@ -831,20 +2827,20 @@ func (s *accountingService) GetAccountBalance(ctx context.Context, accountID uui
     entries, _, err := s.journalRepo.GetJournalEntriesByAccountID(ctx, accountID, 0, 0, veryEarlyDate, date)
     if err != nil {
         logger.ErrorLogger.Printf("Service: Error fetching journal entries for account %s: %v", accountID, err)
-        return 0, errors.NewInternalServerError("failed to fetch journal entries for account balance", err)
+        return money.Zero, errors.NewInternalServerError("failed to fetch journal entries for account balance", err)
     }
 
-    var balance float64
+    balance := money.Zero
     for _, entry := range entries {
-        if entry.Status != models.StatusPosted { // Double check, though repo method should filter
+        if !entry.AffectsBalances() { // The repo method returns entries of every status
             continue
         }
         for _, line := range entry.JournalLines {
             if line.AccountID == accountID {
                 if line.IsDebit {
-                    balance += line.Amount
+                    balance = balance.Add(line.Amount)
                 } else {
-                    balance -= line.Amount
+                    balance = balance.Sub(line.Amount)
                 }
             }
         }
@@ -859,7 +2855,7 @@ func (s *accountingService) GetAccountBalance(ctx context.Context, accountID uui
     // If it's a Liability account, balance = -100 means $100 Credit (since calc is Debit-Credit).
     // The current calculation (sum of debits - sum of credits for that account) is standard.
 
-    logger.InfoLogger.Printf("Service: Calculated balance for account %s (%s) as of %s: %.2f", account.AccountCode, account.AccountName, date.Format("2006-01-02"), balance)
+    logger.InfoLogger.Printf("Service: Calculated balance for account %s (%s) as of %s: %s", account.AccountCode, account.AccountName, date.Format("2006-01-02"), balance)
     return balance, nil
 }
 
@@ -874,3 +2870,410 @@ func isNotFoundError(err error) bool {
 	_, ok := err.(*errors.NotFoundError)
 	return ok
 }
+
+// checkPostingPeriod applies the fiscal period locks, if a checker is configured.
+func (s *accountingService) checkPostingPeriod(ctx context.Context, date time.Time) error {
+	if s.periodChecker == nil {
+		return nil
+	}
+	return s.periodChecker.CheckPostingAllowed(ctx, date)
+}
+
+// convertLine validates the currency of a journal line request and converts its amount into the
+// functional currency at the line's own rate or, if none is given, the latest rate on or before
+// the entry date. n is the zero-based line index used in error messages.
+func (s *accountingService) convertLine(ctx context.Context, n int, lineReq dto.JournalLineRequest, entryDate time.Time) (models.JournalLine, error) {
+	functional := s.BaseCurrency(ctx)
+	currency := strings.ToUpper(strings.TrimSpace(lineReq.Currency))
+	if currency == "" {
+		currency = functional // Default currency
+	}
+	if err := lineReq.Amount.CheckPrecision(currency); err != nil {
+		logger.WarnLogger.Printf("Service: Journal line %d has invalid precision: %v", n+1, err)
+		return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: %v", n+1, err), "lines.amount")
+	}
+	line := models.JournalLine{
+		AccountID:         lineReq.AccountID,
+		Amount:            lineReq.Amount,
+		TransactionAmount: lineReq.Amount,
+		Currency:          currency,
+		ExchangeRate:      money.One,
+		IsDebit:           lineReq.IsDebit,
+	}
+	if currency == functional {
+		if lineReq.ExchangeRate != nil && !lineReq.ExchangeRate.Equal(money.One) {
+			return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: a %s line cannot have an exchange rate other than 1", n+1, currency), "lines.exchange_rate")
+		}
+		return line, nil
+	}
+
+	if s.currencyRepo == nil {
+		return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: only %s lines are supported", n+1, functional), "lines.currency")
+	}
+	master, err := s.currencyRepo.GetCurrency(ctx, currency)
+	if err != nil {
+		if isNotFoundError(err) {
+			return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: unknown currency %s", n+1, currency), "lines.currency")
+		}
+		return models.JournalLine{}, err
+	}
+	if !master.IsActive {
+		return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: currency %s is not active", n+1, currency), "lines.currency")
+	}
+
+	if lineReq.ExchangeRate != nil {
+		if !lineReq.ExchangeRate.IsPositive() {
+			return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: exchange_rate must be positive", n+1), "lines.exchange_rate")
+		}
+		line.ExchangeRate = *lineReq.ExchangeRate
+	} else {
+		rate, err := s.currencyRepo.GetExchangeRate(ctx, currency, functional, entryDate)
+		if err != nil {
+			if isNotFoundError(err) {
+				logger.WarnLogger.Printf("Service: No %s/%s exchange rate on or before %s for line %d", currency, functional, entryDate.Format("2006-01-02"), n+1)
+				return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: no %s/%s exchange rate on or before %s", n+1, currency, functional, entryDate.Format("2006-01-02")), "lines.currency")
+			}
+			return models.JournalLine{}, err
+		}
+		line.ExchangeRate = rate.Rate
+	}
+	line.Amount = lineReq.Amount.Convert(line.ExchangeRate).Round(functional)
+	if !line.Amount.IsPositive() {
+		return models.JournalLine{}, errors.NewValidationError(fmt.Sprintf("line %d: %s %s is zero in %s", n+1, lineReq.Amount, currency, functional), "lines.amount")
+	}
+	return line, nil
+}
+
+// functionalLine returns a line in the functional currency, for entries the service derives itself.
+func (s *accountingService) functionalLine(ctx context.Context, accountID uuid.UUID, amount money.Amount, isDebit bool) models.JournalLine {
+	return models.JournalLine{
+		AccountID:         accountID,
+		Amount:            amount,
+		TransactionAmount: amount,
+		Currency:          s.BaseCurrency(ctx),
+		ExchangeRate:      money.One,
+		IsDebit:           isDebit,
+	}
+}
+
+// balanceInFunctionalCurrency checks that the lines' functional amounts balance. When every line
+// is in the same foreign currency and the entry balances in that currency, a difference left by
+// rounding the converted amounts is added to the largest line on the short side.
+func (s *accountingService) balanceInFunctionalCurrency(ctx context.Context, lines []models.JournalLine) error {
+	functional := s.BaseCurrency(ctx)
+	totalDebits, totalCredits := money.Zero, money.Zero
+	txDebits, txCredits := money.Zero, money.Zero
+	singleCurrency := true
+	for _, line := range lines {
+		if line.IsDebit {
+			totalDebits = totalDebits.Add(line.Amount)
+			txDebits = txDebits.Add(line.TransactionAmount)
+		} else {
+			totalCredits = totalCredits.Add(line.Amount)
+			txCredits = txCredits.Add(line.TransactionAmount)
+		}
+		singleCurrency = singleCurrency && line.Currency == lines[0].Currency
+	}
+	// Debits must equal credits exactly; amounts are fixed-point so no tolerance is needed.
+	if totalDebits.Equal(totalCredits) {
+		return nil
+	}
+	if !singleCurrency || !txDebits.Equal(txCredits) {
+		logger.WarnLogger.Printf("Service: Journal entry debits (%s) do not equal credits (%s) in %s.", totalDebits, totalCredits, functional)
+		return errors.NewValidationError(fmt.Sprintf("debits (%s) must equal credits (%s) in %s", totalDebits, totalCredits, functional), "lines")
+	}
+	shortSideIsDebit := totalDebits.Cmp(totalCredits) < 0
+	difference := totalDebits.Sub(totalCredits).Abs()
+	largest := -1
+	for i, line := range lines {
+		if line.IsDebit == shortSideIsDebit && (largest < 0 || line.Amount.Cmp(lines[largest].Amount) > 0) {
+			largest = i
+		}
+	}
+	lines[largest].Amount = lines[largest].Amount.Add(difference)
+	logger.InfoLogger.Printf("Service: Added a %s %s rounding difference to line %d", difference, functional, largest+1)
+	return nil
+}
+
+// tagLines resolves the dimension codes of each request line into tags on the journal line at the
+// same index, and checks that every line carries the dimensions its account requires. Dimensions
+// and values must exist and be active.
+func (s *accountingService) tagLines(ctx context.Context, lines []models.JournalLine, reqLines []dto.JournalLineRequest) error {
+	if s.dimensionRepo == nil {
+		for i, lineReq := range reqLines {
+			if len(lineReq.Dimensions) > 0 {
+				return errors.NewValidationError(fmt.Sprintf("line %d: dimensions are not enabled", i+1), "lines.dimensions")
+			}
+		}
+		return nil
+	}
+	dimensions, err := s.dimensionRepo.ListDimensions(ctx)
+	if err != nil {
+		return err
+	}
+	accountIDs := make([]uuid.UUID, len(lines))
+	for i, line := range lines {
+		accountIDs[i] = line.AccountID
+	}
+	rules, err := s.dimensionRepo.ListAccountRules(ctx, uniqueIDs(accountIDs))
+	if err != nil {
+		return err
+	}
+	byCode := make(map[string]*models.Dimension, len(dimensions))
+	byID := make(map[uuid.UUID]*models.Dimension, len(dimensions))
+	for _, dim := range dimensions {
+		byCode[dim.Code] = dim
+		byID[dim.ID] = dim
+	}
+	required := make(map[uuid.UUID][]*models.Dimension)
+	for _, rule := range rules {
+		if dim, ok := byID[rule.DimensionID]; ok && dim.IsActive {
+			required[rule.AccountID] = append(required[rule.AccountID], dim)
+		}
+	}
+
+	for i := range lines {
+		tags := make(map[string]models.JournalLineDimension, len(reqLines[i].Dimensions))
+		for code, valueCode := range reqLines[i].Dimensions {
+			code, valueCode = strings.ToUpper(strings.TrimSpace(code)), strings.ToUpper(strings.TrimSpace(valueCode))
+			dim, ok := byCode[code]
+			if !ok {
+				return errors.NewValidationError(fmt.Sprintf("line %d: unknown dimension %s", i+1, code), "lines.dimensions")
+			}
+			if !dim.IsActive {
+				return errors.NewValidationError(fmt.Sprintf("line %d: dimension %s is not active", i+1, code), "lines.dimensions")
+			}
+			value := findDimensionValue(dim, valueCode)
+			if value == nil {
+				return errors.NewValidationError(fmt.Sprintf("line %d: %s is not a value of dimension %s", i+1, valueCode, code), "lines.dimensions")
+			}
+			if !value.IsActive {
+				return errors.NewValidationError(fmt.Sprintf("line %d: value %s of dimension %s is not active", i+1, valueCode, code), "lines.dimensions")
+			}
+			tags[code] = models.JournalLineDimension{DimensionID: dim.ID, DimensionValueID: value.ID, DimensionCode: code, ValueCode: valueCode}
+		}
+		for _, dim := range required[lines[i].AccountID] {
+			if _, ok := tags[dim.Code]; !ok {
+				return errors.NewValidationError(fmt.Sprintf("line %d: the account requires a value for dimension %s", i+1, dim.Code), "lines.dimensions")
+			}
+		}
+		lines[i].Dimensions = nil
+		for _, tag := range tags {
+			lines[i].Dimensions = append(lines[i].Dimensions, tag)
+		}
+		sort.Slice(lines[i].Dimensions, func(a, b int) bool {
+			return lines[i].Dimensions[a].DimensionCode < lines[i].Dimensions[b].DimensionCode
+		})
+	}
+	return nil
+}
+
+// addTaxLines makes each line whose request names a tax code the code's taxable base and returns
+// the lines followed by the tax lines: the tax at the code's rate on the entry date, on the base
+// line's side, in its currency and at its exchange rate, and for reverse-charge codes the output tax
+// that offsets it. Lines sent in a request are the ones entered; tax lines are always regenerated.
+func (s *accountingService) addTaxLines(ctx context.Context, lines []models.JournalLine, reqLines []dto.JournalLineRequest, entryDate time.Time) ([]models.JournalLine, error) {
+	functional := s.BaseCurrency(ctx)
+	taxCodes := make(map[string]*models.TaxCode)
+	var taxLines []models.JournalLine
+	for i := range lines {
+		code := strings.ToUpper(strings.TrimSpace(reqLines[i].TaxCode))
+		if code == "" {
+			continue
+		}
+		if s.taxRepo == nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: tax codes are not enabled", i+1), "lines.tax_code")
+		}
+		taxCode, ok := taxCodes[code]
+		if !ok {
+			var err error
+			if taxCode, err = s.taxRepo.GetTaxCodeByCode(ctx, code); err != nil {
+				if isNotFoundError(err) {
+					return nil, errors.NewValidationError(fmt.Sprintf("line %d: unknown tax code %s", i+1, code), "lines.tax_code")
+				}
+				return nil, err
+			}
+			taxCodes[code] = taxCode
+		}
+		rate, err := taxRateOn(taxCode, entryDate)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", i+1, err), "lines.tax_code")
+		}
+		lines[i].TaxCodeID = &taxCode.ID
+		lines[i].TaxCode = taxCode.Code
+		lines[i].TaxLineType = models.TaxLineBase
+
+		tax := lines[i].TransactionAmount.Convert(rate).Round(lines[i].Currency)
+		taxLine := lines[i]
+		taxLine.ID = uuid.Nil
+		taxLine.AccountID = taxCode.TaxAccountID
+		taxLine.TransactionAmount = tax
+		taxLine.Amount = tax.Convert(lines[i].ExchangeRate).Round(functional)
+		taxLine.TaxLineType = models.TaxLineTax
+		taxLine.Dimensions = nil
+		if !taxLine.Amount.IsPositive() {
+			continue // Zero-rated, or too small to round to a cent
+		}
+		taxLines = append(taxLines, taxLine)
+		if taxCode.IsReverseCharge && taxCode.ReverseChargeAccountID != nil {
+			reverseCharge := taxLine
+			reverseCharge.AccountID = *taxCode.ReverseChargeAccountID
+			reverseCharge.IsDebit = !taxLine.IsDebit
+			reverseCharge.TaxLineType = models.TaxLineReverseCharge
+			taxLines = append(taxLines, reverseCharge)
+		}
+	}
+	return append(lines, taxLines...), nil
+}
+
+// findDimensionValue returns the value of dim with the given code, or nil.
+func findDimensionValue(dim *models.Dimension, code string) *models.DimensionValue {
+	for i := range dim.Values {
+		if dim.Values[i].Code == code {
+			return &dim.Values[i]
+		}
+	}
+	return nil
+}
+
+// dimensionFilter validates a report's dimension filter and group-by against the dimensions
+// configured, returning them with upper-cased codes.
+func (s *accountingService) dimensionFilter(ctx context.Context, filter models.DimensionTags, groupBy string) (models.DimensionTags, string, error) {
+	groupBy = strings.ToUpper(strings.TrimSpace(groupBy))
+	if len(filter) == 0 && groupBy == "" {
+		return nil, "", nil
+	}
+	if s.dimensionRepo == nil {
+		return nil, "", errors.NewValidationError("dimensions are not enabled", "dimensions")
+	}
+	dimensions, err := s.dimensionRepo.ListDimensions(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	byCode := make(map[string]*models.Dimension, len(dimensions))
+	for _, dim := range dimensions {
+		byCode[dim.Code] = dim
+	}
+	normalized := make(models.DimensionTags, len(filter))
+	for code, valueCode := range filter {
+		code, valueCode = strings.ToUpper(strings.TrimSpace(code)), strings.ToUpper(strings.TrimSpace(valueCode))
+		dim, ok := byCode[code]
+		if !ok {
+			return nil, "", errors.NewValidationError(fmt.Sprintf("unknown dimension %s", code), "dimensions")
+		}
+		if findDimensionValue(dim, valueCode) == nil {
+			return nil, "", errors.NewValidationError(fmt.Sprintf("%s is not a value of dimension %s", valueCode, code), "dimensions")
+		}
+		normalized[code] = valueCode
+	}
+	if groupBy != "" {
+		if _, ok := byCode[groupBy]; !ok {
+			return nil, "", errors.NewValidationError(fmt.Sprintf("unknown dimension %s", groupBy), "group_by")
+		}
+		if _, ok := normalized[groupBy]; ok {
+			return nil, "", errors.NewValidationError(fmt.Sprintf("cannot group by dimension %s while filtering on it", groupBy), "group_by")
+		}
+	}
+	if len(normalized) == 0 {
+		normalized = nil
+	}
+	return normalized, groupBy, nil
+}
+
+// lineHasDimensions reports whether a line carries every value in filter.
+func lineHasDimensions(line models.JournalLine, filter models.DimensionTags) bool {
+	for code, valueCode := range filter {
+		if lineDimensionValue(line, code) != valueCode {
+			return false
+		}
+	}
+	return true
+}
+
+// lineDimensionValue returns the line's value code for a dimension, or "" if it has none.
+func lineDimensionValue(line models.JournalLine, dimensionCode string) string {
+	for _, tag := range line.Dimensions {
+		if tag.DimensionCode == dimensionCode {
+			return tag.ValueCode
+		}
+	}
+	return ""
+}
+
+// validateAutoReverseOn checks that an auto-reversal date, if any, falls after the entry date and
+// that automatic reversals are enabled.
+func (s *accountingService) validateAutoReverseOn(entryDate time.Time, autoReverseOn *time.Time) error {
+	if autoReverseOn == nil {
+		return nil
+	}
+	if s.reversalRepo == nil {
+		return errors.NewValidationError("automatic reversals are not enabled", "auto_reverse_on")
+	}
+	if !dateOnly(*autoReverseOn).After(dateOnly(entryDate)) {
+		return errors.NewValidationError("auto_reverse_on must be after the entry date", "auto_reverse_on")
+	}
+	return nil
+}
+
+// newReversalEntry builds a POSTED entry dated date that mirrors original with debits and credits swapped.
+// Reversals deliberately skip the active-account check that CreateJournalEntry applies: they only undo
+// lines that were valid when posted, and an account deactivated since then must still be clearable.
+func newReversalEntry(original *models.JournalEntry, date time.Time, entryType models.JournalEntryType, description string) *models.JournalEntry {
+	reversal := &models.JournalEntry{
+		EntryDate:   date,
+		Description: description,
+		Reference:   original.Reference,
+		Status:      models.StatusPosted,
+		EntryType:   entryType,
+	}
+	for _, line := range original.JournalLines {
+		// The reversal keeps the original rate, dimension values and tax code so it cancels the
+		// original in both currencies, in every dimension report and on the tax return.
+		var tags []models.JournalLineDimension
+		for _, tag := range line.Dimensions {
+			tag.JournalLineID = uuid.Nil
+			tags = append(tags, tag)
+		}
+		reversal.JournalLines = append(reversal.JournalLines, models.JournalLine{
+			AccountID:         line.AccountID,
+			Amount:            line.Amount,
+			TransactionAmount: line.TransactionAmount,
+			Currency:          line.Currency,
+			ExchangeRate:      line.ExchangeRate,
+			IsDebit:           !line.IsDebit,
+			Dimensions:        tags,
+			TaxCodeID:         line.TaxCodeID,
+			TaxCode:           line.TaxCode,
+			TaxLineType:       line.TaxLineType,
+		})
+	}
+	return reversal
+}
+
+// validateCashFlowCategory checks that category is empty or a known value, and that only
+// balance sheet accounts are classified.
+func validateCashFlowCategory(accountType models.AccountType, category models.CashFlowCategory) error {
+	if category == "" {
+		return nil
+	}
+	valid := false
+	for _, c := range models.ValidCashFlowCategories {
+		if category == c {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return errors.NewValidationError(fmt.Sprintf("invalid cash flow category: %s", category), "cash_flow_category")
+	}
+	if accountType == models.Revenue || accountType == models.Expense {
+		return errors.NewValidationError(fmt.Sprintf("cash flow category cannot be set on %s accounts", accountType), "cash_flow_category")
+	}
+	return nil
+}
+
+// endOfDay returns the last instant of t's calendar day, so that date-only report
+// parameters include every entry made during that day.
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
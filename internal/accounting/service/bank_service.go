@@ -0,0 +1,586 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// autoMatchWindow is how far apart the bank date of a statement line and the date of a journal
+// entry may be for auto-matching to pair them.
+const autoMatchWindow = 3 * 24 * time.Hour
+
+// BankService manages bank accounts and their imported statements, matches statement lines to
+// journal lines and reports bank reconciliations.
+type BankService interface {
+	CreateBankAccount(ctx context.Context, req dto.CreateBankAccountRequest) (*models.BankAccount, error)
+	GetBankAccount(ctx context.Context, id uuid.UUID) (*models.BankAccount, error)
+	ListBankAccounts(ctx context.Context) ([]*models.BankAccount, error)
+	UpdateBankAccount(ctx context.Context, id uuid.UUID, req dto.UpdateBankAccountRequest) (*models.BankAccount, error)
+	ImportStatement(ctx context.Context, bankAccountID uuid.UUID, format models.StatementFormat, r io.Reader) (*dto.ImportStatementResponse, error)
+	ListStatements(ctx context.Context, bankAccountID uuid.UUID) ([]*models.BankStatement, error)
+	ListStatementLines(ctx context.Context, bankAccountID uuid.UUID, matched *bool) ([]*models.BankStatementLine, error)
+	AutoMatch(ctx context.Context, bankAccountID uuid.UUID) (*dto.AutoMatchResponse, error)
+	MatchStatementLine(ctx context.Context, lineID uuid.UUID, req dto.MatchStatementLineRequest) (*models.BankStatementLine, error)
+	UnmatchStatementLine(ctx context.Context, lineID uuid.UUID) (*models.BankStatementLine, error)
+	GetReconciliation(ctx context.Context, bankAccountID uuid.UUID, asOfDate time.Time) (*dto.BankReconciliationResponse, error)
+}
+
+// bankService is an implementation of BankService.
+type bankService struct {
+	bankRepo    repository.BankRepository
+	coaRepo     repository.ChartOfAccountRepository
+	journalRepo repository.JournalEntryRepository
+	accounting  AccountingService
+}
+
+// NewBankService creates a new BankService. Bank accounts default to the accounting service's
+// base currency.
+func NewBankService(
+	bankRepo repository.BankRepository,
+	coaRepo repository.ChartOfAccountRepository,
+	journalRepo repository.JournalEntryRepository,
+	accounting AccountingService,
+) BankService {
+	return &bankService{bankRepo: bankRepo, coaRepo: coaRepo, journalRepo: journalRepo, accounting: accounting}
+}
+
+// CreateBankAccount links a bank account to an active ASSET ledger account that no other bank
+// account uses.
+func (s *bankService) CreateBankAccount(ctx context.Context, req dto.CreateBankAccountRequest) (*models.BankAccount, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create bank account %s", req.Name)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	currency := s.accounting.BaseCurrency(ctx)
+	if req.Currency != "" {
+		code, err := normalizeCurrencyCode(req.Currency)
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("currency code %q must be three letters", req.Currency), "currency")
+		}
+		currency = code
+	}
+	ledgerAccount, err := s.coaRepo.GetByID(ctx, req.ChartOfAccountID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("ledger account %s not found", req.ChartOfAccountID), "chart_of_account_id")
+		}
+		return nil, err
+	}
+	if ledgerAccount.AccountType != models.Asset {
+		return nil, errors.NewValidationError(fmt.Sprintf("account %s is a %s account; bank accounts must be booked on an ASSET account", ledgerAccount.AccountCode, ledgerAccount.AccountType), "chart_of_account_id")
+	}
+	if !ledgerAccount.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("account %s is inactive", ledgerAccount.AccountCode), "chart_of_account_id")
+	}
+	if existing, err := s.bankRepo.GetBankAccountByChartOfAccount(ctx, ledgerAccount.ID); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("account %s is already linked to bank account %s", ledgerAccount.AccountCode, existing.Name))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	account := &models.BankAccount{
+		Name:             name,
+		AccountNumber:    normalizeBankAccountNumber(req.AccountNumber),
+		Currency:         currency,
+		ChartOfAccountID: ledgerAccount.ID,
+		IsActive:         true,
+	}
+	created, err := s.bankRepo.CreateBankAccount(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	created.ChartOfAccount = ledgerAccount
+	logger.InfoLogger.Printf("Service: Created bank account %s (%s) on account %s", created.Name, created.ID, ledgerAccount.AccountCode)
+	return created, nil
+}
+
+func (s *bankService) GetBankAccount(ctx context.Context, id uuid.UUID) (*models.BankAccount, error) {
+	return s.bankRepo.GetBankAccount(ctx, id)
+}
+
+func (s *bankService) ListBankAccounts(ctx context.Context) ([]*models.BankAccount, error) {
+	return s.bankRepo.ListBankAccounts(ctx)
+}
+
+// UpdateBankAccount renames a bank account, changes its number or deactivates it. Its ledger
+// account and currency are fixed, as imported statements and matches depend on them.
+func (s *bankService) UpdateBankAccount(ctx context.Context, id uuid.UUID, req dto.UpdateBankAccountRequest) (*models.BankAccount, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update bank account %s", id)
+
+	account, err := s.bankRepo.GetBankAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		account.Name = name
+	}
+	if req.AccountNumber != nil {
+		account.AccountNumber = normalizeBankAccountNumber(*req.AccountNumber)
+	}
+	if req.IsActive != nil {
+		account.IsActive = *req.IsActive
+	}
+	return s.bankRepo.UpdateBankAccount(ctx, account)
+}
+
+// ImportStatement reads a statement file into a bank account and auto-matches the account's
+// unmatched lines. An empty format is detected from the content. Lines already imported, by the
+// bank's transaction ID or by a fingerprint when the file has none, are skipped.
+func (s *bankService) ImportStatement(ctx context.Context, bankAccountID uuid.UUID, format models.StatementFormat, r io.Reader) (*dto.ImportStatementResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting to import a statement into bank account %s", bankAccountID)
+
+	account, err := s.bankRepo.GetBankAccount(ctx, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("bank account %s is inactive", account.Name), "bank_account_id")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("could not read the file: %v", err), "file")
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, errors.NewValidationError("the file is empty", "file")
+	}
+	format = models.StatementFormat(strings.ToUpper(strings.TrimSpace(string(format))))
+	if format == "" {
+		format = detectStatementFormat(data)
+	}
+	parsed, err := parseStatement(format, data)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Currency != "" && parsed.Currency != account.Currency {
+		return nil, errors.NewValidationError(fmt.Sprintf("the statement is in %s but bank account %s is in %s", parsed.Currency, account.Name, account.Currency), "file")
+	}
+	if !sameBankAccountNumber(parsed.AccountNumber, account.AccountNumber) {
+		return nil, errors.NewValidationError(fmt.Sprintf("the statement is for account %s, not %s", parsed.AccountNumber, account.AccountNumber), "file")
+	}
+	if len(parsed.Lines) == 0 && parsed.ClosingBalance == nil {
+		return nil, errors.NewValidationError("the statement has no transactions", "file")
+	}
+
+	statement := &models.BankStatement{
+		BankAccountID:  account.ID,
+		Format:         parsed.Format,
+		StatementRef:   parsed.Reference,
+		StartDate:      parsed.StartDate,
+		EndDate:        parsed.EndDate,
+		OpeningBalance: parsed.OpeningBalance,
+		ClosingBalance: parsed.ClosingBalance,
+	}
+	duplicates, err := s.newStatementLines(ctx, account, parsed, statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed.Lines) > 0 && len(statement.Lines) == 0 {
+		return nil, errors.NewConflictError("every line of the statement has already been imported")
+	}
+	if statement.StartDate.IsZero() {
+		statement.StartDate = dateOnly(time.Now())
+		statement.EndDate = statement.StartDate
+	}
+
+	created, err := s.bankRepo.CreateStatement(ctx, statement)
+	if err != nil {
+		return nil, err
+	}
+	matched, _, err := s.autoMatch(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Imported %d lines (%d duplicates skipped, %d matched) into bank account %s", len(created.Lines), duplicates, matched, account.Name)
+	return &dto.ImportStatementResponse{
+		StatementID: created.ID,
+		Format:      created.Format,
+		Imported:    len(created.Lines),
+		Duplicates:  duplicates,
+		AutoMatched: matched,
+	}, nil
+}
+
+// newStatementLines adds to statement the parsed lines the bank account does not hold yet and
+// returns how many were skipped as duplicates.
+func (s *bankService) newStatementLines(ctx context.Context, account *models.BankAccount, parsed *parsedStatement, statement *models.BankStatement) (int, error) {
+	occurrences := make(map[string]int)
+	seen := make(map[string]bool)
+	var lines []models.BankStatementLine
+	var externalIDs []string
+	duplicates := 0
+	for _, line := range parsed.Lines {
+		externalID := line.ExternalID
+		if externalID == "" {
+			key := statementLineFingerprint(line, 0)
+			occurrences[key]++
+			externalID = statementLineFingerprint(line, occurrences[key])
+		}
+		if seen[externalID] {
+			duplicates++
+			continue
+		}
+		seen[externalID] = true
+		externalIDs = append(externalIDs, externalID)
+		lines = append(lines, models.BankStatementLine{
+			BankAccountID:   account.ID,
+			TransactionDate: dateOnly(line.Date),
+			Amount:          line.Amount,
+			Description:     truncate(line.Description, 255),
+			Reference:       truncate(line.Reference, 100),
+			ExternalID:      externalID,
+		})
+	}
+
+	existing, err := s.bankRepo.FindExternalIDs(ctx, account.ID, externalIDs)
+	if err != nil {
+		return 0, err
+	}
+	held := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		held[id] = true
+	}
+	for _, line := range lines {
+		if held[line.ExternalID] {
+			duplicates++
+			continue
+		}
+		statement.Lines = append(statement.Lines, line)
+	}
+	return duplicates, nil
+}
+
+func (s *bankService) ListStatements(ctx context.Context, bankAccountID uuid.UUID) ([]*models.BankStatement, error) {
+	if _, err := s.bankRepo.GetBankAccount(ctx, bankAccountID); err != nil {
+		return nil, err
+	}
+	return s.bankRepo.ListStatements(ctx, bankAccountID)
+}
+
+// ListStatementLines lists a bank account's statement lines; matched, when set, selects matched or
+// unmatched lines only.
+func (s *bankService) ListStatementLines(ctx context.Context, bankAccountID uuid.UUID, matched *bool) ([]*models.BankStatementLine, error) {
+	if _, err := s.bankRepo.GetBankAccount(ctx, bankAccountID); err != nil {
+		return nil, err
+	}
+	return s.bankRepo.ListStatementLines(ctx, bankAccountID, matched, time.Time{})
+}
+
+// AutoMatch matches the bank account's unmatched statement lines to uncleared journal lines.
+func (s *bankService) AutoMatch(ctx context.Context, bankAccountID uuid.UUID) (*dto.AutoMatchResponse, error) {
+	account, err := s.bankRepo.GetBankAccount(ctx, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	matched, unmatched, err := s.autoMatch(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AutoMatchResponse{Matched: matched, Unmatched: unmatched}, nil
+}
+
+// autoMatch pairs each unmatched statement line with an uncleared journal line on the bank's
+// ledger account for the same amount and direction, dated within autoMatchWindow of it. A
+// candidate whose entry reference or description shares the line's reference is preferred, then
+// the closest date; a line is left for manual matching when that still leaves a tie. It returns
+// how many lines it matched and how many remain unmatched.
+func (s *bankService) autoMatch(ctx context.Context, account *models.BankAccount) (int, int, error) {
+	notMatched := false
+	lines, err := s.bankRepo.ListStatementLines(ctx, account.ID, &notMatched, time.Time{})
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(lines) == 0 {
+		return 0, 0, nil
+	}
+	latest := lines[len(lines)-1].TransactionDate
+	candidates, err := s.bankRepo.ListUnclearedJournalLines(ctx, account.ChartOfAccountID, endOfDay(latest.Add(autoMatchWindow)))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	taken := make(map[uuid.UUID]bool)
+	now := time.Now()
+	var matches []*models.BankStatementLine
+	for _, line := range lines {
+		best := bestJournalLineMatch(line, candidates, account.Currency, taken)
+		if best == nil {
+			continue
+		}
+		taken[best.ID] = true
+		journalLineID := best.ID
+		line.JournalLineID = &journalLineID
+		line.MatchMethod = models.MatchAuto
+		line.MatchedAt = &now
+		matches = append(matches, line)
+	}
+	if len(matches) > 0 {
+		if err := s.bankRepo.SaveMatches(ctx, matches); err != nil {
+			return 0, 0, err
+		}
+		logger.InfoLogger.Printf("Service: Auto-matched %d of %d statement lines of bank account %s", len(matches), len(lines), account.Name)
+	}
+	return len(matches), len(lines) - len(matches), nil
+}
+
+// bestJournalLineMatch returns the journal line auto-matching pairs with the statement line, or nil
+// when there is none or no single best one.
+func bestJournalLineMatch(line *models.BankStatementLine, candidates []models.JournalLine, currency string, taken map[uuid.UUID]bool) *models.JournalLine {
+	var best *models.JournalLine
+	bestByReference, bestDistance, tied := false, time.Duration(0), false
+	for i := range candidates {
+		candidate := &candidates[i]
+		if taken[candidate.ID] || candidate.JournalEntry == nil {
+			continue
+		}
+		amount, ok := bankAmount(*candidate, currency)
+		if !ok || !amount.Equal(line.Amount) {
+			continue
+		}
+		distance := dateOnly(candidate.JournalEntry.EntryDate).Sub(line.TransactionDate)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance > autoMatchWindow {
+			continue
+		}
+		byReference := referencesMatch(line, candidate.JournalEntry)
+		switch {
+		case best == nil, byReference && !bestByReference, byReference == bestByReference && distance < bestDistance:
+			best, bestByReference, bestDistance, tied = candidate, byReference, distance, false
+		case byReference == bestByReference && distance == bestDistance:
+			tied = true
+		}
+	}
+	if tied {
+		return nil
+	}
+	return best
+}
+
+// referencesMatch reports whether a statement line and a journal entry share a reference: the
+// line's reference appears in the entry's reference or description, or the entry's reference
+// appears in the line's description.
+func referencesMatch(line *models.BankStatementLine, entry *models.JournalEntry) bool {
+	lineReference := strings.ToUpper(strings.TrimSpace(line.Reference))
+	entryReference := strings.ToUpper(strings.TrimSpace(entry.Reference))
+	if lineReference != "" && (strings.Contains(entryReference, lineReference) || strings.Contains(strings.ToUpper(entry.Description), lineReference)) {
+		return true
+	}
+	return entryReference != "" && strings.Contains(strings.ToUpper(line.Description), entryReference)
+}
+
+// bankAmount returns a journal line's amount as the bank sees it: in the bank account's currency,
+// positive for a debit to the bank's ledger account and negative for a credit. ok is false for a
+// line booked in another currency.
+func bankAmount(line models.JournalLine, currency string) (money.Amount, bool) {
+	if line.Currency != currency {
+		return money.Zero, false
+	}
+	if line.IsDebit {
+		return line.TransactionAmount, true
+	}
+	return line.TransactionAmount.Neg(), true
+}
+
+// MatchStatementLine matches a statement line by hand to a posted journal line on the bank's
+// ledger account for the same amount and direction.
+func (s *bankService) MatchStatementLine(ctx context.Context, lineID uuid.UUID, req dto.MatchStatementLineRequest) (*models.BankStatementLine, error) {
+	logger.InfoLogger.Printf("Service: Attempting to match statement line %s to journal line %s", lineID, req.JournalLineID)
+
+	line, err := s.bankRepo.GetStatementLine(ctx, lineID)
+	if err != nil {
+		return nil, err
+	}
+	if line.IsMatched() {
+		return nil, errors.NewConflictError(fmt.Sprintf("statement line %s is already matched to journal line %s; unmatch it first", line.ID, line.JournalLineID))
+	}
+	account, err := s.bankRepo.GetBankAccount(ctx, line.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	journalLine, err := s.bankRepo.GetJournalLine(ctx, req.JournalLineID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("journal line %s not found", req.JournalLineID), "journal_line_id")
+		}
+		return nil, err
+	}
+	if journalLine.AccountID != account.ChartOfAccountID {
+		return nil, errors.NewValidationError(fmt.Sprintf("journal line %s is not on the ledger account of bank account %s", journalLine.ID, account.Name), "journal_line_id")
+	}
+	if journalLine.JournalEntry == nil || journalLine.JournalEntry.Status != models.StatusPosted {
+		return nil, errors.NewValidationError(fmt.Sprintf("journal line %s is not on a posted entry", journalLine.ID), "journal_line_id")
+	}
+	amount, ok := bankAmount(*journalLine, account.Currency)
+	if !ok {
+		return nil, errors.NewValidationError(fmt.Sprintf("journal line %s is in %s, not the bank account's currency %s", journalLine.ID, journalLine.Currency, account.Currency), "journal_line_id")
+	}
+	if !amount.Equal(line.Amount) {
+		return nil, errors.NewValidationError(fmt.Sprintf("journal line %s is for %s but the statement line is for %s", journalLine.ID, amount, line.Amount), "journal_line_id")
+	}
+	if other, err := s.bankRepo.GetStatementLineByJournalLine(ctx, journalLine.ID); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("journal line %s is already matched to statement line %s", journalLine.ID, other.ID))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	now := time.Now()
+	line.JournalLineID = &journalLine.ID
+	line.MatchMethod = models.MatchManual
+	line.MatchedAt = &now
+	if err := s.bankRepo.SaveMatches(ctx, []*models.BankStatementLine{line}); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Matched statement line %s to journal line %s", line.ID, journalLine.ID)
+	return line, nil
+}
+
+// UnmatchStatementLine removes a statement line's match, whether it was made automatically or by
+// hand.
+func (s *bankService) UnmatchStatementLine(ctx context.Context, lineID uuid.UUID) (*models.BankStatementLine, error) {
+	logger.InfoLogger.Printf("Service: Attempting to unmatch statement line %s", lineID)
+
+	line, err := s.bankRepo.GetStatementLine(ctx, lineID)
+	if err != nil {
+		return nil, err
+	}
+	if !line.IsMatched() {
+		return nil, errors.NewValidationError(fmt.Sprintf("statement line %s is not matched", line.ID), "id")
+	}
+	line.JournalLineID = nil
+	line.MatchMethod = ""
+	line.MatchedAt = nil
+	if err := s.bankRepo.SaveMatches(ctx, []*models.BankStatementLine{line}); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// GetReconciliation reconciles a bank account at the end of asOfDate (today when zero). Journal
+// lines no statement line has cleared are listed as deposits in transit or outstanding payments,
+// and the book balance adjusted for them is compared with the closing balance of the latest
+// statement ending by that date.
+func (s *bankService) GetReconciliation(ctx context.Context, bankAccountID uuid.UUID, asOfDate time.Time) (*dto.BankReconciliationResponse, error) {
+	if asOfDate.IsZero() {
+		asOfDate = time.Now()
+	}
+	asOfDate = dateOnly(asOfDate)
+	logger.InfoLogger.Printf("Service: Reconciling bank account %s as of %s", bankAccountID, asOfDate.Format("2006-01-02"))
+
+	account, err := s.bankRepo.GetBankAccount(ctx, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.BankReconciliationResponse{
+		BankAccountID:       account.ID,
+		BankAccountName:     account.Name,
+		Currency:            account.Currency,
+		AsOfDate:            asOfDate,
+		DepositsInTransit:   []dto.BankReconciliationItem{},
+		OutstandingPayments: []dto.BankReconciliationItem{},
+		UnmatchedLines:      []models.BankStatementLine{},
+	}
+
+	balances, err := s.journalRepo.GetCurrencyBalances(ctx, endOfDay(asOfDate).Add(time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+	for _, balance := range balances {
+		if balance.AccountID == account.ChartOfAccountID && balance.Currency == account.Currency {
+			response.BookBalance = balance.TransactionBalance
+		}
+	}
+
+	uncleared, err := s.bankRepo.ListUnclearedJournalLines(ctx, account.ChartOfAccountID, endOfDay(asOfDate))
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range uncleared {
+		amount, ok := bankAmount(line, account.Currency)
+		if !ok {
+			continue
+		}
+		item := dto.BankReconciliationItem{JournalLineID: line.ID, JournalEntryID: line.JournalID, Amount: amount.Abs()}
+		if line.JournalEntry != nil {
+			item.EntryDate = line.JournalEntry.EntryDate
+			item.Reference = line.JournalEntry.Reference
+			item.Description = line.JournalEntry.Description
+		}
+		if line.IsDebit {
+			response.DepositsInTransit = append(response.DepositsInTransit, item)
+			response.TotalInTransit = response.TotalInTransit.Add(item.Amount)
+		} else {
+			response.OutstandingPayments = append(response.OutstandingPayments, item)
+			response.TotalOutstanding = response.TotalOutstanding.Add(item.Amount)
+		}
+	}
+
+	notMatched := false
+	unmatched, err := s.bankRepo.ListStatementLines(ctx, account.ID, &notMatched, asOfDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range unmatched {
+		response.UnmatchedLines = append(response.UnmatchedLines, *line)
+		response.TotalUnmatched = response.TotalUnmatched.Add(line.Amount)
+	}
+	response.ReconciledBalance = response.BookBalance.Sub(response.TotalInTransit).Add(response.TotalOutstanding)
+
+	statement, err := s.bankRepo.GetLatestStatement(ctx, account.ID, asOfDate)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+	if statement != nil {
+		difference := statement.ClosingBalance.Sub(response.ReconciledBalance)
+		response.StatementID = &statement.ID
+		response.StatementBalance = statement.ClosingBalance
+		response.Difference = &difference
+		response.IsReconciled = difference.IsZero() && len(unmatched) == 0
+	}
+	return response, nil
+}
+
+// normalizeBankAccountNumber removes the spaces IBANs are usually printed with.
+func normalizeBankAccountNumber(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), ""))
+}
+
+// sameBankAccountNumber reports whether a statement's account number fits the bank account's.
+// Either may be missing, and a local account number matches the IBAN that ends with it.
+func sameBankAccountNumber(statementNumber, accountNumber string) bool {
+	statementNumber = normalizeBankAccountNumber(statementNumber)
+	accountNumber = normalizeBankAccountNumber(accountNumber)
+	if statementNumber == "" || accountNumber == "" {
+		return true
+	}
+	return strings.HasSuffix(accountNumber, statementNumber) || strings.HasSuffix(statementNumber, accountNumber)
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
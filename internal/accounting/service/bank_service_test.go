@@ -0,0 +1,404 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var unmatchedOnly = mock.MatchedBy(func(matched *bool) bool { return matched != nil && !*matched })
+
+// newBankAccount returns a USD bank account booked on a fresh ASSET account.
+func newBankAccount() *models.BankAccount {
+	ledger := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1010", AccountName: "Operating Bank", AccountType: models.Asset, IsActive: true}
+	return &models.BankAccount{ID: uuid.New(), Name: "Operating", AccountNumber: "DE89370400440532013000", Currency: "USD", ChartOfAccountID: ledger.ID, ChartOfAccount: ledger, IsActive: true}
+}
+
+// bankJournalLine returns a journal line on the bank's ledger account; positive amounts are debits.
+func bankJournalLine(account *models.BankAccount, date string, amount string, reference string) models.JournalLine {
+	value := money.MustParse(amount)
+	entryDate, _ := time.Parse("2006-01-02", date)
+	entry := &models.JournalEntry{ID: uuid.New(), EntryDate: entryDate, Reference: reference, Status: models.StatusPosted}
+	return models.JournalLine{
+		ID: uuid.New(), JournalID: entry.ID, AccountID: account.ChartOfAccountID, Currency: "USD",
+		Amount: value.Abs(), TransactionAmount: value.Abs(), IsDebit: value.IsPositive(), JournalEntry: entry,
+	}
+}
+
+func TestBankService_CreateBankAccount(t *testing.T) {
+	ctx := context.Background()
+	ledger := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1010", AccountType: models.Asset, IsActive: true}
+
+	t.Run("Success - Defaults To Base Currency", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		coaRepo.On("GetByID", ctx, ledger.ID).Return(ledger, nil).Once()
+		bankRepo.On("GetBankAccountByChartOfAccount", ctx, ledger.ID).Return(nil, app_errors.NewNotFoundError("bank_account", ledger.ID.String())).Once()
+		bankRepo.On("CreateBankAccount", ctx, mock.AnythingOfType("*models.BankAccount")).Return(func(_ context.Context, a *models.BankAccount) *models.BankAccount { return a }, nil).Once()
+
+		account, err := service.NewBankService(bankRepo, coaRepo, nil, &stubAccountingService{}).CreateBankAccount(ctx, dto.CreateBankAccountRequest{
+			Name: "Operating", AccountNumber: "de89 3704 0044 0532 0130 00", ChartOfAccountID: ledger.ID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "USD", account.Currency)
+		assert.Equal(t, "DE89370400440532013000", account.AccountNumber)
+		assert.True(t, account.IsActive)
+	})
+
+	t.Run("Validation Error - Not An Asset Account", func(t *testing.T) {
+		revenue := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "4000", AccountType: models.Revenue, IsActive: true}
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		coaRepo.On("GetByID", ctx, revenue.ID).Return(revenue, nil).Once()
+
+		_, err := service.NewBankService(mocks.NewBankRepositoryMock(t), coaRepo, nil, &stubAccountingService{}).CreateBankAccount(ctx, dto.CreateBankAccountRequest{Name: "Operating", ChartOfAccountID: revenue.ID})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "ASSET")
+	})
+
+	t.Run("Conflict Error - Ledger Account Already Linked", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		coaRepo.On("GetByID", ctx, ledger.ID).Return(ledger, nil).Once()
+		bankRepo.On("GetBankAccountByChartOfAccount", ctx, ledger.ID).Return(&models.BankAccount{Name: "Payroll"}, nil).Once()
+
+		_, err := service.NewBankService(bankRepo, coaRepo, nil, &stubAccountingService{}).CreateBankAccount(ctx, dto.CreateBankAccountRequest{Name: "Operating", ChartOfAccountID: ledger.ID})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+}
+
+func TestBankService_ImportStatement(t *testing.T) {
+	ctx := context.Background()
+
+	// expectImport lets CreateStatement store the statement, then serves its lines to auto-match
+	// against the given journal lines.
+	expectImport := func(bankRepo *mocks.BankRepository, account *models.BankAccount, held []string, journalLines []models.JournalLine) *models.BankStatement {
+		saved := &models.BankStatement{}
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		bankRepo.On("FindExternalIDs", ctx, account.ID, mock.Anything).Return(held, nil).Once()
+		bankRepo.On("CreateStatement", ctx, mock.AnythingOfType("*models.BankStatement")).Return(func(_ context.Context, s *models.BankStatement) *models.BankStatement {
+			*saved = *s
+			return s
+		}, nil).Once()
+		bankRepo.On("ListStatementLines", ctx, account.ID, unmatchedOnly, time.Time{}).Return(func(context.Context, uuid.UUID, *bool, time.Time) []*models.BankStatementLine {
+			lines := make([]*models.BankStatementLine, len(saved.Lines))
+			for i := range saved.Lines {
+				saved.Lines[i].ID = uuid.New()
+				lines[i] = &saved.Lines[i]
+			}
+			return lines
+		}, nil).Once()
+		bankRepo.On("ListUnclearedJournalLines", ctx, account.ChartOfAccountID, mock.AnythingOfType("time.Time")).Return(journalLines, nil).Maybe()
+		return saved
+	}
+
+	t.Run("Success - CSV Imported, Duplicates Skipped And Matched", func(t *testing.T) {
+		account := newBankAccount()
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		payment := bankJournalLine(account, "2026-03-04", "-250.00", "INV-77")
+		csvFile := "Date,Amount,Description,Reference\n" +
+			"2026-03-02,1000.00,Opening deposit,\n" +
+			"2026-03-05,-250.00,Supplier payment,INV-77\n"
+		saved := expectImport(bankRepo, account, nil, []models.JournalLine{payment})
+		bankRepo.On("SaveMatches", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).ImportStatement(ctx, account.ID, "", strings.NewReader(csvFile))
+		require.NoError(t, err)
+		assert.Equal(t, models.StatementCSV, result.Format)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, 1, result.AutoMatched)
+		assert.Equal(t, "2026-03-02", saved.StartDate.Format("2006-01-02"))
+		assert.Equal(t, "2026-03-05", saved.EndDate.Format("2006-01-02"))
+		require.Len(t, saved.Lines, 2)
+		assert.Len(t, saved.Lines[0].ExternalID, 40, "lines without an id column get a fingerprint")
+		assert.Equal(t, &payment.ID, saved.Lines[1].JournalLineID)
+		assert.Equal(t, models.MatchAuto, saved.Lines[1].MatchMethod)
+
+		// Importing the same file again finds every fingerprint held already.
+		again := mocks.NewBankRepositoryMock(t)
+		again.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		again.On("FindExternalIDs", ctx, account.ID, mock.Anything).Return([]string{saved.Lines[0].ExternalID, saved.Lines[1].ExternalID}, nil).Once()
+		_, err = service.NewBankService(again, nil, nil, &stubAccountingService{}).ImportStatement(ctx, account.ID, "CSV", strings.NewReader(csvFile))
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Success - OFX SGML Statement", func(t *testing.T) {
+		account := newBankAccount()
+		account.AccountNumber = "0532013000"
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		saved := expectImport(bankRepo, account, []string{"T2"}, nil)
+
+		ofxFile := `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>37040044<ACCTID>0532013000<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20260301
+<DTEND>20260331120000[-5:EST]
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20260310<TRNAMT>-42.50<FITID>T1<NAME>Coffee &amp; Co<MEMO>Card 1234</STMTTRN>
+<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20260312<TRNAMT>100.00<FITID>T2<CHECKNUM>1001</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>1057.50<DTASOF>20260331</LEDGERBAL>
+<AVAILBAL><BALAMT>900.00<DTASOF>20260331</AVAILBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+		result, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).ImportStatement(ctx, account.ID, "", strings.NewReader(ofxFile))
+		require.NoError(t, err)
+		assert.Equal(t, models.StatementOFX, result.Format)
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 1, result.Duplicates)
+		require.Len(t, saved.Lines, 1)
+		assert.Equal(t, "T1", saved.Lines[0].ExternalID)
+		assert.Equal(t, "Coffee & Co - Card 1234", saved.Lines[0].Description)
+		assert.True(t, saved.Lines[0].Amount.Equal(money.MustParse("-42.50")))
+		assert.Equal(t, "2026-03-01", saved.StartDate.Format("2006-01-02"))
+		assert.Equal(t, "2026-03-31", saved.EndDate.Format("2006-01-02"))
+		require.NotNil(t, saved.ClosingBalance)
+		assert.True(t, saved.ClosingBalance.Equal(money.MustParse("1057.50")), "the ledger balance, not the available balance")
+	})
+
+	t.Run("Success - camt.053 Statement", func(t *testing.T) {
+		account := newBankAccount()
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		saved := expectImport(bankRepo, account, nil, nil)
+
+		camtFile := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+ <BkToCstmrStmt>
+  <Stmt>
+   <Id>STMT-2026-03</Id>
+   <Acct><Id><IBAN>DE89 3704 0044 0532 0130 00</IBAN></Id><Ccy>USD</Ccy></Acct>
+   <Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="USD">20.00</Amt><CdtDbtInd>DBIT</CdtDbtInd></Bal>
+   <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="USD">480.00</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+   <Ntry>
+    <Amt Ccy="USD">500.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts>BOOK</Sts>
+    <BookgDt><Dt>2026-03-03</Dt></BookgDt><AcctSvcrRef>BANK-1</AcctSvcrRef>
+    <NtryDtls><TxDtls><Refs><EndToEndId>INV-1001</EndToEndId></Refs><RmtInf><Ustrd>Invoice 1001</Ustrd></RmtInf></TxDtls></NtryDtls>
+   </Ntry>
+   <Ntry>
+    <Amt Ccy="USD">75.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts>PDNG</Sts>
+    <BookgDt><Dt>2026-03-04</Dt></BookgDt><AcctSvcrRef>BANK-2</AcctSvcrRef>
+   </Ntry>
+  </Stmt>
+ </BkToCstmrStmt>
+</Document>`
+		result, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).ImportStatement(ctx, account.ID, "", strings.NewReader(camtFile))
+		require.NoError(t, err)
+		assert.Equal(t, models.StatementCAMT053, result.Format)
+		assert.Equal(t, 1, result.Imported, "pending entries are not imported")
+		assert.Equal(t, "STMT-2026-03", saved.StatementRef)
+		assert.Equal(t, "INV-1001", saved.Lines[0].Reference)
+		assert.Equal(t, "Invoice 1001", saved.Lines[0].Description)
+		assert.Equal(t, "BANK-1", saved.Lines[0].ExternalID)
+		assert.True(t, saved.OpeningBalance.Equal(money.MustParse("-20.00")))
+		assert.True(t, saved.ClosingBalance.Equal(money.MustParse("480.00")))
+	})
+
+	t.Run("Validation Error - Statement In Another Currency", func(t *testing.T) {
+		account := newBankAccount()
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+
+		ofxFile := "<OFX><CURDEF>EUR<BANKTRANLIST><STMTTRN><DTPOSTED>20260310<TRNAMT>1.00<FITID>X</STMTTRN></BANKTRANLIST></OFX>"
+		_, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).ImportStatement(ctx, account.ID, "ofx", strings.NewReader(ofxFile))
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "EUR")
+	})
+
+	t.Run("Validation Error - Invalid CSV Rows Listed", func(t *testing.T) {
+		account := newBankAccount()
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+
+		csvFile := "date,amount\n2026-03-01,ten\n03/02/2026,5.00\n"
+		_, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).ImportStatement(ctx, account.ID, "", strings.NewReader(csvFile))
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "row 2: amount")
+		assert.Contains(t, err.Error(), "row 3: date")
+	})
+}
+
+func TestBankService_AutoMatch(t *testing.T) {
+	ctx := context.Background()
+	account := newBankAccount()
+	statementLine := func(date string, amount string, reference string) *models.BankStatementLine {
+		transactionDate, _ := time.Parse("2006-01-02", date)
+		return &models.BankStatementLine{ID: uuid.New(), BankAccountID: account.ID, TransactionDate: transactionDate, Amount: money.MustParse(amount), Reference: reference}
+	}
+
+	t.Run("Success - Reference Preferred Over Closer Date", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		closer := bankJournalLine(account, "2026-03-10", "-80.00", "")
+		byReference := bankJournalLine(account, "2026-03-08", "-80.00", "PO-5")
+		line := statementLine("2026-03-10", "-80.00", "PO-5")
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		bankRepo.On("ListStatementLines", ctx, account.ID, unmatchedOnly, time.Time{}).Return([]*models.BankStatementLine{line}, nil).Once()
+		bankRepo.On("ListUnclearedJournalLines", ctx, account.ChartOfAccountID, mock.AnythingOfType("time.Time")).Return([]models.JournalLine{closer, byReference}, nil).Once()
+		bankRepo.On("SaveMatches", ctx, []*models.BankStatementLine{line}).Return(nil).Once()
+
+		result, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).AutoMatch(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Matched)
+		assert.Equal(t, &byReference.ID, line.JournalLineID)
+	})
+
+	t.Run("Success - Ties, Wrong Direction And Distant Dates Left Unmatched", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		tied := statementLine("2026-03-10", "-30.00", "")
+		deposit := statementLine("2026-03-10", "45.00", "")
+		late := statementLine("2026-03-20", "60.00", "")
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		bankRepo.On("ListStatementLines", ctx, account.ID, unmatchedOnly, time.Time{}).Return([]*models.BankStatementLine{tied, deposit, late}, nil).Once()
+		bankRepo.On("ListUnclearedJournalLines", ctx, account.ChartOfAccountID, mock.AnythingOfType("time.Time")).Return([]models.JournalLine{
+			bankJournalLine(account, "2026-03-09", "-30.00", ""),
+			bankJournalLine(account, "2026-03-11", "-30.00", ""),
+			bankJournalLine(account, "2026-03-10", "-45.00", ""),
+			bankJournalLine(account, "2026-03-16", "60.00", ""),
+		}, nil).Once()
+
+		result, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).AutoMatch(ctx, account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Matched)
+		assert.Equal(t, 3, result.Unmatched)
+		bankRepo.AssertNotCalled(t, "SaveMatches", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBankService_MatchStatementLine(t *testing.T) {
+	ctx := context.Background()
+	account := newBankAccount()
+	newLine := func() *models.BankStatementLine {
+		return &models.BankStatementLine{ID: uuid.New(), BankAccountID: account.ID, Amount: money.MustParse("-99.00")}
+	}
+
+	t.Run("Success - Matched By Hand", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		line := newLine()
+		journalLine := bankJournalLine(account, "2026-02-01", "-99.00", "")
+		bankRepo.On("GetStatementLine", ctx, line.ID).Return(line, nil).Once()
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		bankRepo.On("GetJournalLine", ctx, journalLine.ID).Return(&journalLine, nil).Once()
+		bankRepo.On("GetStatementLineByJournalLine", ctx, journalLine.ID).Return(nil, app_errors.NewNotFoundError("bank_statement_line", journalLine.ID.String())).Once()
+		bankRepo.On("SaveMatches", ctx, []*models.BankStatementLine{line}).Return(nil).Once()
+
+		matched, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).MatchStatementLine(ctx, line.ID, dto.MatchStatementLineRequest{JournalLineID: journalLine.ID})
+		require.NoError(t, err)
+		assert.Equal(t, &journalLine.ID, matched.JournalLineID)
+		assert.Equal(t, models.MatchManual, matched.MatchMethod)
+		assert.NotNil(t, matched.MatchedAt)
+	})
+
+	t.Run("Validation Error - Amount Or Direction Differs", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		line := newLine()
+		journalLine := bankJournalLine(account, "2026-02-01", "99.00", "")
+		bankRepo.On("GetStatementLine", ctx, line.ID).Return(line, nil).Once()
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		bankRepo.On("GetJournalLine", ctx, journalLine.ID).Return(&journalLine, nil).Once()
+
+		_, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).MatchStatementLine(ctx, line.ID, dto.MatchStatementLineRequest{JournalLineID: journalLine.ID})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Validation Error - Journal Line On Another Account", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		line := newLine()
+		journalLine := bankJournalLine(account, "2026-02-01", "-99.00", "")
+		journalLine.AccountID = uuid.New()
+		bankRepo.On("GetStatementLine", ctx, line.ID).Return(line, nil).Once()
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		bankRepo.On("GetJournalLine", ctx, journalLine.ID).Return(&journalLine, nil).Once()
+
+		_, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).MatchStatementLine(ctx, line.ID, dto.MatchStatementLineRequest{JournalLineID: journalLine.ID})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Conflict Error - Journal Line Already Matched", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		line := newLine()
+		journalLine := bankJournalLine(account, "2026-02-01", "-99.00", "")
+		bankRepo.On("GetStatementLine", ctx, line.ID).Return(line, nil).Once()
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		bankRepo.On("GetJournalLine", ctx, journalLine.ID).Return(&journalLine, nil).Once()
+		bankRepo.On("GetStatementLineByJournalLine", ctx, journalLine.ID).Return(&models.BankStatementLine{ID: uuid.New()}, nil).Once()
+
+		_, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).MatchStatementLine(ctx, line.ID, dto.MatchStatementLineRequest{JournalLineID: journalLine.ID})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Success - Unmatched", func(t *testing.T) {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		line := newLine()
+		journalLineID := uuid.New()
+		line.JournalLineID, line.MatchMethod = &journalLineID, models.MatchAuto
+		bankRepo.On("GetStatementLine", ctx, line.ID).Return(line, nil).Once()
+		bankRepo.On("SaveMatches", ctx, []*models.BankStatementLine{line}).Return(nil).Once()
+
+		unmatched, err := service.NewBankService(bankRepo, nil, nil, &stubAccountingService{}).UnmatchStatementLine(ctx, line.ID)
+		require.NoError(t, err)
+		assert.False(t, unmatched.IsMatched())
+		assert.Empty(t, unmatched.MatchMethod)
+	})
+}
+
+func TestBankService_GetReconciliation(t *testing.T) {
+	ctx := context.Background()
+	account := newBankAccount()
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	newService := func(t *testing.T, unmatched []*models.BankStatementLine, closing string) service.BankService {
+		bankRepo := mocks.NewBankRepositoryMock(t)
+		journalRepo := mocks.NewJournalEntryRepositoryMock(t)
+		bankRepo.On("GetBankAccount", ctx, account.ID).Return(account, nil).Once()
+		journalRepo.On("GetCurrencyBalances", ctx, asOf.AddDate(0, 0, 1)).Return([]models.CurrencyBalance{
+			{AccountID: account.ChartOfAccountID, Currency: "USD", TransactionBalance: money.MustParse("1000.00"), FunctionalBalance: money.MustParse("1000.00")},
+			{AccountID: uuid.New(), Currency: "USD", TransactionBalance: money.MustParse("-5000.00")},
+		}, nil).Once()
+		bankRepo.On("ListUnclearedJournalLines", ctx, account.ChartOfAccountID, asOf.AddDate(0, 0, 1).Add(-time.Nanosecond)).Return([]models.JournalLine{
+			bankJournalLine(account, "2026-03-30", "300.00", "DEP-9"),
+			bankJournalLine(account, "2026-03-29", "-120.00", "CHK-1002"),
+		}, nil).Once()
+		bankRepo.On("ListStatementLines", ctx, account.ID, unmatchedOnly, asOf).Return(unmatched, nil).Once()
+		closingBalance := money.MustParse(closing)
+		bankRepo.On("GetLatestStatement", ctx, account.ID, asOf).Return(&models.BankStatement{ID: uuid.New(), ClosingBalance: &closingBalance}, nil).Once()
+		return service.NewBankService(bankRepo, nil, journalRepo, &stubAccountingService{})
+	}
+
+	t.Run("Success - Reconciled", func(t *testing.T) {
+		report, err := newService(t, nil, "820.00").GetReconciliation(ctx, account.ID, asOf)
+		require.NoError(t, err)
+		assert.True(t, report.BookBalance.Equal(money.MustParse("1000.00")))
+		require.Len(t, report.DepositsInTransit, 1)
+		require.Len(t, report.OutstandingPayments, 1)
+		assert.Equal(t, "CHK-1002", report.OutstandingPayments[0].Reference)
+		assert.True(t, report.TotalInTransit.Equal(money.MustParse("300.00")))
+		assert.True(t, report.TotalOutstanding.Equal(money.MustParse("120.00")))
+		assert.True(t, report.ReconciledBalance.Equal(money.MustParse("820.00")))
+		assert.True(t, report.Difference.IsZero())
+		assert.True(t, report.IsReconciled)
+	})
+
+	t.Run("Success - Unbooked Bank Fee Explains Difference", func(t *testing.T) {
+		fee := &models.BankStatementLine{ID: uuid.New(), TransactionDate: asOf, Amount: money.MustParse("-15.00"), Description: "Account fee"}
+		report, err := newService(t, []*models.BankStatementLine{fee}, "805.00").GetReconciliation(ctx, account.ID, asOf)
+		require.NoError(t, err)
+		require.Len(t, report.UnmatchedLines, 1)
+		assert.True(t, report.TotalUnmatched.Equal(money.MustParse("-15.00")))
+		assert.True(t, report.Difference.Equal(money.MustParse("-15.00")))
+		assert.False(t, report.IsReconciled)
+	})
+}
@@ -0,0 +1,600 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetApprovalRoles are the roles allowed to approve a budget version.
+var BudgetApprovalRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+// BudgetService manages budget versions, their lines and CSV imports, and compares budgets with
+// posted journal lines.
+type BudgetService interface {
+	CreateBudget(ctx context.Context, req dto.CreateBudgetRequest) (*models.Budget, error)
+	GetBudget(ctx context.Context, id uuid.UUID) (*models.Budget, error)
+	ListBudgets(ctx context.Context, fiscalYearID *uuid.UUID) ([]*models.Budget, error)
+	UpdateBudget(ctx context.Context, id uuid.UUID, req dto.UpdateBudgetRequest) (*models.Budget, error)
+	ImportBudgetLines(ctx context.Context, id uuid.UUID, r io.Reader) (*dto.ImportBudgetLinesResponse, error)
+	ApproveBudget(ctx context.Context, id uuid.UUID) (*models.Budget, error)
+	CreateBudgetVersion(ctx context.Context, id uuid.UUID) (*models.Budget, error)
+	GetBudgetVsActual(ctx context.Context, req dto.BudgetVsActualRequest) (*dto.BudgetVsActualResponse, error)
+}
+
+// budgetService is an implementation of BudgetService.
+type budgetService struct {
+	budgetRepo    repository.BudgetRepository
+	fiscalRepo    repository.FiscalPeriodRepository
+	coaRepo       repository.ChartOfAccountRepository
+	journalRepo   repository.JournalEntryRepository
+	dimensionRepo repository.DimensionRepository
+}
+
+// NewBudgetService creates a new BudgetService. dimensionRepo may be nil, in which case budget
+// lines cannot name a dimension.
+func NewBudgetService(
+	budgetRepo repository.BudgetRepository,
+	fiscalRepo repository.FiscalPeriodRepository,
+	coaRepo repository.ChartOfAccountRepository,
+	journalRepo repository.JournalEntryRepository,
+	dimensionRepo repository.DimensionRepository,
+) BudgetService {
+	return &budgetService{budgetRepo: budgetRepo, fiscalRepo: fiscalRepo, coaRepo: coaRepo, journalRepo: journalRepo, dimensionRepo: dimensionRepo}
+}
+
+func (s *budgetService) CreateBudget(ctx context.Context, req dto.CreateBudgetRequest) (*models.Budget, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create budget %s", req.Name)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	year, err := s.fiscalRepo.GetFiscalYearByID(ctx, req.FiscalYearID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("fiscal year %s not found", req.FiscalYearID), "fiscal_year_id")
+		}
+		return nil, err
+	}
+	if _, err := s.budgetRepo.GetLatestVersion(ctx, name, year.ID); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("budget %s already exists for %s; create a new version to change it", name, year.Name))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+	lines, err := s.buildLines(ctx, year, req.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &models.Budget{
+		Name:         name,
+		FiscalYearID: year.ID,
+		Version:      1,
+		Description:  req.Description,
+		Status:       models.BudgetDraft,
+		Lines:        lines,
+	}
+	created, err := s.budgetRepo.CreateBudget(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Created budget %s version 1 (%s) with %d lines", created.Name, created.ID, len(created.Lines))
+	return created, nil
+}
+
+func (s *budgetService) GetBudget(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	return s.budgetRepo.GetBudget(ctx, id)
+}
+
+func (s *budgetService) ListBudgets(ctx context.Context, fiscalYearID *uuid.UUID) ([]*models.Budget, error) {
+	return s.budgetRepo.ListBudgets(ctx, fiscalYearID)
+}
+
+// UpdateBudget changes a draft budget version. Approved and superseded versions are read-only.
+func (s *budgetService) UpdateBudget(ctx context.Context, id uuid.UUID, req dto.UpdateBudgetRequest) (*models.Budget, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update budget %s", id)
+
+	budget, year, err := s.draftBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Description != nil {
+		budget.Description = *req.Description
+	}
+	if req.Lines != nil {
+		lines, err := s.buildLines(ctx, year, req.Lines)
+		if err != nil {
+			return nil, err
+		}
+		budget.Lines = lines
+	}
+	return s.budgetRepo.UpdateBudget(ctx, budget)
+}
+
+// ImportBudgetLines replaces the lines of a draft budget with the rows of a CSV file with the
+// header "account_code,period,amount", optionally followed by "dimension,value". period is the
+// period number within the budget's fiscal year. Either every row is saved or, if any row is
+// invalid, none is.
+func (s *budgetService) ImportBudgetLines(ctx context.Context, id uuid.UUID, r io.Reader) (*dto.ImportBudgetLinesResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting to import lines into budget %s", id)
+
+	budget, year, err := s.draftBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.NewValidationError("the file is empty", "file")
+	}
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid CSV: %v", err), "file")
+	}
+	columns := []string{"account_code", "period", "amount", "dimension", "value"}
+	if len(header) != 3 && len(header) != 5 {
+		return nil, errors.NewValidationError("the header must be account_code,period,amount with optional dimension,value", "file")
+	}
+	for i := range header {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), columns[i]) {
+			return nil, errors.NewValidationError("the header must be account_code,period,amount with optional dimension,value", "file")
+		}
+	}
+
+	lookups := newBudgetLookups(year)
+	seen := make(map[budgetLineKey]int)
+	var lines []models.BudgetLine
+	var problems []string
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", row, err))
+			continue
+		}
+		lineReq, err := s.parseBudgetRecord(ctx, record, lookups)
+		if err == nil {
+			var line models.BudgetLine
+			if line, err = s.budgetLine(ctx, lineReq, lookups); err == nil {
+				key := newBudgetLineKey(line)
+				if first, ok := seen[key]; ok {
+					problems = append(problems, fmt.Sprintf("row %d: duplicates row %d", row, first))
+					continue
+				}
+				seen[key] = row
+				lines = append(lines, line)
+				continue
+			}
+		}
+		invalid, ok := err.(*errors.ValidationError)
+		if !ok {
+			return nil, err
+		}
+		problems = append(problems, fmt.Sprintf("row %d: %s", row, invalid.Message))
+	}
+	if len(problems) > 0 {
+		logger.WarnLogger.Printf("Service: Budget import into %s rejected with %d invalid rows", id, len(problems))
+		if len(problems) > maxImportErrors {
+			problems = append(problems[:maxImportErrors], fmt.Sprintf("and %d more", len(problems)-maxImportErrors))
+		}
+		return nil, errors.NewValidationError("no lines were imported: "+strings.Join(problems, "; "), "file")
+	}
+	if len(lines) == 0 {
+		return nil, errors.NewValidationError("the file has no lines", "file")
+	}
+
+	budget.Lines = lines
+	if _, err := s.budgetRepo.UpdateBudget(ctx, budget); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Imported %d lines into budget %s version %d", len(lines), budget.Name, budget.Version)
+	return &dto.ImportBudgetLinesResponse{BudgetID: budget.ID, Imported: len(lines)}, nil
+}
+
+// parseBudgetRecord turns one CSV record into a line request, resolving the account code.
+func (s *budgetService) parseBudgetRecord(ctx context.Context, record []string, lookups *budgetLookups) (dto.BudgetLineRequest, error) {
+	var req dto.BudgetLineRequest
+	code := strings.TrimSpace(record[0])
+	account, ok := lookups.accountsByCode[code]
+	if !ok {
+		var err error
+		account, err = s.coaRepo.GetByCode(ctx, code)
+		if err != nil {
+			if isNotFoundError(err) {
+				return req, errors.NewValidationError(fmt.Sprintf("unknown account %s", code), "account_code")
+			}
+			return req, err
+		}
+		lookups.accountsByCode[code] = account
+		lookups.accounts[account.ID] = account
+	}
+	period, err := strconv.Atoi(strings.TrimSpace(record[1]))
+	if err != nil {
+		return req, errors.NewValidationError(fmt.Sprintf("period %q is not a period number", record[1]), "period")
+	}
+	amount, err := money.Parse(strings.TrimSpace(record[2]))
+	if err != nil {
+		return req, errors.NewValidationError(fmt.Sprintf("amount %q is not a decimal", record[2]), "amount")
+	}
+	req = dto.BudgetLineRequest{AccountID: account.ID, PeriodNumber: period, Amount: amount}
+	if len(record) == 5 {
+		req.DimensionCode, req.ValueCode = record[3], record[4]
+	}
+	return req, nil
+}
+
+// ApproveBudget approves a draft budget version, which makes it read-only. The previously
+// approved version of the same budget, if any, becomes SUPERSEDED.
+func (s *budgetService) ApproveBudget(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	logger.InfoLogger.Printf("Service: Attempting to approve budget %s", id)
+
+	if !auth.HasAnyRole(ctx, BudgetApprovalRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("approving a budget requires one of the roles: %s", strings.Join(BudgetApprovalRoles, ", ")))
+	}
+	budget, _, err := s.draftBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(budget.Lines) == 0 {
+		return nil, errors.NewValidationError("a budget without lines cannot be approved", "lines")
+	}
+
+	now := time.Now()
+	budget.Status = models.BudgetApproved
+	budget.ApprovedAt = &now
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		budget.ApprovedBy = principal.UserID
+	}
+	approved, err := s.budgetRepo.ApproveBudget(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Approved budget %s version %d", approved.Name, approved.Version)
+	return approved, nil
+}
+
+// CreateBudgetVersion starts a new draft version of a budget with a copy of the given version's
+// lines. A budget has at most one draft version at a time.
+func (s *budgetService) CreateBudgetVersion(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create a new version of budget %s", id)
+
+	source, err := s.budgetRepo.GetBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.budgetRepo.GetLatestVersion(ctx, source.Name, source.FiscalYearID)
+	if err != nil {
+		return nil, err
+	}
+	if latest.Status == models.BudgetDraft {
+		return nil, errors.NewConflictError(fmt.Sprintf("budget %s already has a draft version %d", latest.Name, latest.Version))
+	}
+
+	budget := &models.Budget{
+		Name:         source.Name,
+		FiscalYearID: source.FiscalYearID,
+		Version:      latest.Version + 1,
+		Description:  source.Description,
+		Status:       models.BudgetDraft,
+		Lines:        make([]models.BudgetLine, len(source.Lines)),
+	}
+	for i, line := range source.Lines {
+		line.ID, line.BudgetID = uuid.Nil, uuid.Nil
+		budget.Lines[i] = line
+	}
+	created, err := s.budgetRepo.CreateBudget(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Created budget %s version %d from version %d", created.Name, created.Version, source.Version)
+	return created, nil
+}
+
+// draftBudget loads a budget version that may still be changed, with its fiscal year.
+func (s *budgetService) draftBudget(ctx context.Context, id uuid.UUID) (*models.Budget, *models.FiscalYear, error) {
+	budget, err := s.budgetRepo.GetBudget(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if budget.Status != models.BudgetDraft {
+		return nil, nil, errors.NewConflictError(fmt.Sprintf("budget %s version %d is %s; create a new version to change it", budget.Name, budget.Version, budget.Status))
+	}
+	year, err := s.fiscalRepo.GetFiscalYearByID(ctx, budget.FiscalYearID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return budget, year, nil
+}
+
+// budgetLookups caches what validating a batch of budget lines looks up.
+type budgetLookups struct {
+	periods        map[int]uuid.UUID // Period number to ID within the budget's fiscal year
+	accounts       map[uuid.UUID]*models.ChartOfAccount
+	accountsByCode map[string]*models.ChartOfAccount
+	dimensions     map[string]*models.Dimension // Loaded on first use
+}
+
+func newBudgetLookups(year *models.FiscalYear) *budgetLookups {
+	lookups := &budgetLookups{
+		periods:        make(map[int]uuid.UUID, len(year.Periods)),
+		accounts:       make(map[uuid.UUID]*models.ChartOfAccount),
+		accountsByCode: make(map[string]*models.ChartOfAccount),
+	}
+	for _, period := range year.Periods {
+		lookups.periods[period.PeriodNumber] = period.ID
+	}
+	return lookups
+}
+
+// budgetLineKey identifies a budget line; a budget holds one amount per key.
+type budgetLineKey struct {
+	AccountID      uuid.UUID
+	FiscalPeriodID uuid.UUID
+	DimensionCode  string
+	ValueCode      string
+}
+
+func newBudgetLineKey(line models.BudgetLine) budgetLineKey {
+	return budgetLineKey{AccountID: line.AccountID, FiscalPeriodID: line.FiscalPeriodID, DimensionCode: line.DimensionCode, ValueCode: line.ValueCode}
+}
+
+// buildLines validates line requests against the fiscal year, the chart of accounts and the
+// dimensions.
+func (s *budgetService) buildLines(ctx context.Context, year *models.FiscalYear, reqs []dto.BudgetLineRequest) ([]models.BudgetLine, error) {
+	lookups := newBudgetLookups(year)
+	seen := make(map[budgetLineKey]int, len(reqs))
+	lines := make([]models.BudgetLine, 0, len(reqs))
+	for i, req := range reqs {
+		line, err := s.budgetLine(ctx, req, lookups)
+		if err != nil {
+			if invalid, ok := err.(*errors.ValidationError); ok {
+				return nil, errors.NewValidationError(fmt.Sprintf("line %d: %s", i+1, invalid.Message), "lines."+invalid.Field)
+			}
+			return nil, err
+		}
+		key := newBudgetLineKey(line)
+		if first, ok := seen[key]; ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: duplicates line %d", i+1, first), "lines")
+		}
+		seen[key] = i + 1
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// budgetLine validates one line request. Validation errors carry no line or row prefix.
+func (s *budgetService) budgetLine(ctx context.Context, req dto.BudgetLineRequest, lookups *budgetLookups) (models.BudgetLine, error) {
+	var line models.BudgetLine
+	periodID, ok := lookups.periods[req.PeriodNumber]
+	if !ok {
+		return line, errors.NewValidationError(fmt.Sprintf("the fiscal year has no period %d", req.PeriodNumber), "period_number")
+	}
+	account, ok := lookups.accounts[req.AccountID]
+	if !ok {
+		var err error
+		account, err = s.coaRepo.GetByID(ctx, req.AccountID)
+		if err != nil {
+			if isNotFoundError(err) {
+				return line, errors.NewValidationError(fmt.Sprintf("account with ID %s not found", req.AccountID), "account_id")
+			}
+			return line, err
+		}
+		lookups.accounts[account.ID] = account
+	}
+	if !account.IsActive {
+		return line, errors.NewValidationError(fmt.Sprintf("account %s (%s) is not active", account.AccountCode, account.AccountName), "account_id")
+	}
+
+	line = models.BudgetLine{AccountID: account.ID, FiscalPeriodID: periodID, Amount: req.Amount}
+	dimensionCode, valueCode := strings.ToUpper(strings.TrimSpace(req.DimensionCode)), strings.ToUpper(strings.TrimSpace(req.ValueCode))
+	if dimensionCode == "" && valueCode == "" {
+		return line, nil
+	}
+	if dimensionCode == "" || valueCode == "" {
+		return line, errors.NewValidationError("dimension_code and value_code must be given together", "dimension_code")
+	}
+	if lookups.dimensions == nil {
+		if s.dimensionRepo == nil {
+			return line, errors.NewValidationError("dimensions are not enabled", "dimension_code")
+		}
+		dimensions, err := s.dimensionRepo.ListDimensions(ctx)
+		if err != nil {
+			return line, err
+		}
+		lookups.dimensions = make(map[string]*models.Dimension, len(dimensions))
+		for _, dim := range dimensions {
+			lookups.dimensions[dim.Code] = dim
+		}
+	}
+	dim, ok := lookups.dimensions[dimensionCode]
+	if !ok {
+		return line, errors.NewValidationError(fmt.Sprintf("unknown dimension %s", dimensionCode), "dimension_code")
+	}
+	if findDimensionValue(dim, valueCode) == nil {
+		return line, errors.NewValidationError(fmt.Sprintf("%s is not a value of dimension %s", valueCode, dimensionCode), "value_code")
+	}
+	line.DimensionCode, line.ValueCode = dimensionCode, valueCode
+	return line, nil
+}
+
+// budgetActualKey is one row of the budget-vs-actual report: an account, or one dimension value on it.
+type budgetActualKey struct {
+	AccountID     uuid.UUID
+	DimensionCode string
+	ValueCode     string
+}
+
+// GetBudgetVsActual compares a budget version with the posted journal lines over a range of its
+// periods. A posted line counts toward each budgeted dimension value of its account that it
+// carries; lines carrying none count toward the account's row without a dimension. Revenue and
+// expense accounts with postings but no budget are listed with a zero budget.
+func (s *budgetService) GetBudgetVsActual(ctx context.Context, req dto.BudgetVsActualRequest) (*dto.BudgetVsActualResponse, error) {
+	budget, err := s.budgetRepo.GetBudget(ctx, req.BudgetID)
+	if err != nil {
+		return nil, err
+	}
+	year, err := s.fiscalRepo.GetFiscalYearByID(ctx, budget.FiscalYearID)
+	if err != nil {
+		return nil, err
+	}
+	if len(year.Periods) == 0 {
+		return nil, errors.NewValidationError(fmt.Sprintf("fiscal year %s has no periods", year.Name), "budget_id")
+	}
+	if req.FromPeriod == 0 {
+		req.FromPeriod = 1
+	}
+	if req.ToPeriod == 0 {
+		req.ToPeriod = len(year.Periods)
+	}
+	if req.FromPeriod < 1 || req.ToPeriod > len(year.Periods) || req.FromPeriod > req.ToPeriod {
+		return nil, errors.NewValidationError(fmt.Sprintf("from_period and to_period must be between 1 and %d, from_period first", len(year.Periods)), "from_period")
+	}
+	selected := make(map[uuid.UUID]bool)
+	var startDate, endDate time.Time
+	for _, period := range year.Periods {
+		if period.PeriodNumber < req.FromPeriod || period.PeriodNumber > req.ToPeriod {
+			continue
+		}
+		selected[period.ID] = true
+		if startDate.IsZero() || period.StartDate.Before(startDate) {
+			startDate = period.StartDate
+		}
+		if period.EndDate.After(endDate) {
+			endDate = period.EndDate
+		}
+	}
+	logger.InfoLogger.Printf("Service: Generating budget vs actual for budget %s version %d, %s to %s", budget.Name, budget.Version, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	budgeted := make(map[budgetActualKey]money.Amount)
+	dimensionKeys := make(map[uuid.UUID][]budgetActualKey) // Budgeted dimension values per account
+	for _, line := range budget.Lines {
+		key := budgetActualKey{AccountID: line.AccountID, DimensionCode: line.DimensionCode, ValueCode: line.ValueCode}
+		if _, ok := budgeted[key]; !ok && key.DimensionCode != "" {
+			dimensionKeys[key.AccountID] = append(dimensionKeys[key.AccountID], key)
+		}
+		if selected[line.FiscalPeriodID] {
+			budgeted[key] = budgeted[key].Add(line.Amount)
+		} else if _, ok := budgeted[key]; !ok {
+			budgeted[key] = money.Zero // Listed even when budgeted only outside the range
+		}
+	}
+
+	entries, err := s.journalRepo.GetJournalEntriesForTrialBalance(ctx, startDate, endOfDay(endDate))
+	if err != nil {
+		return nil, err
+	}
+	actual := make(map[budgetActualKey]money.Amount) // Debits minus credits
+	for _, entry := range entries {
+		for _, line := range entry.JournalLines {
+			amount := line.Amount
+			if !line.IsDebit {
+				amount = amount.Neg()
+			}
+			matched := false
+			for _, key := range dimensionKeys[line.AccountID] {
+				if lineDimensionValue(line, key.DimensionCode) == key.ValueCode {
+					actual[key] = actual[key].Add(amount)
+					matched = true
+				}
+			}
+			if !matched {
+				key := budgetActualKey{AccountID: line.AccountID}
+				actual[key] = actual[key].Add(amount)
+			}
+		}
+	}
+
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error listing accounts for budget vs actual: %v", err)
+		return nil, errors.NewInternalServerError("failed to fetch accounts for budget vs actual", err)
+	}
+	byID := make(map[uuid.UUID]*models.ChartOfAccount, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+	}
+
+	response := &dto.BudgetVsActualResponse{
+		BudgetID:   budget.ID,
+		BudgetName: budget.Name,
+		Version:    budget.Version,
+		Status:     budget.Status,
+		FromPeriod: req.FromPeriod,
+		ToPeriod:   req.ToPeriod,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Lines:      []dto.BudgetVsActualLine{},
+	}
+	addLine := func(key budgetActualKey) {
+		acc, ok := byID[key.AccountID]
+		if !ok {
+			return
+		}
+		budgetAmount := budgeted[key]
+		actualAmount := actual[key]
+		if acc.AccountType != models.Asset && acc.AccountType != models.Expense {
+			actualAmount = actualAmount.Neg() // Credit-normal accounts
+		}
+		line := dto.BudgetVsActualLine{
+			AccountID:     acc.ID,
+			AccountCode:   acc.AccountCode,
+			AccountName:   acc.AccountName,
+			AccountType:   acc.AccountType,
+			DimensionCode: key.DimensionCode,
+			ValueCode:     key.ValueCode,
+			Budget:        budgetAmount,
+			Actual:        actualAmount,
+			Variance:      actualAmount.Sub(budgetAmount),
+		}
+		if !budgetAmount.IsZero() {
+			pct := math.Round(actualAmount.Float64()/budgetAmount.Float64()*10000) / 100
+			line.PercentConsumed = &pct
+		}
+		response.Lines = append(response.Lines, line)
+	}
+	for key := range budgeted {
+		addLine(key)
+	}
+	for key, amount := range actual {
+		if _, ok := budgeted[key]; ok || amount.IsZero() {
+			continue
+		}
+		acc, ok := byID[key.AccountID]
+		if !ok {
+			continue
+		}
+		if _, isBudgeted := dimensionKeys[key.AccountID]; isBudgeted || acc.AccountType == models.Revenue || acc.AccountType == models.Expense {
+			addLine(key)
+		}
+	}
+	sort.Slice(response.Lines, func(i, j int) bool {
+		a, b := response.Lines[i], response.Lines[j]
+		if a.AccountCode != b.AccountCode {
+			return a.AccountCode < b.AccountCode
+		}
+		if a.DimensionCode != b.DimensionCode {
+			return a.DimensionCode < b.DimensionCode
+		}
+		return a.ValueCode < b.ValueCode
+	})
+	return response, nil
+}
@@ -0,0 +1,312 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// budgetFixture is a fiscal year of three monthly periods and the accounts budgets refer to.
+type budgetFixture struct {
+	year    *models.FiscalYear
+	travel  *models.ChartOfAccount
+	revenue *models.ChartOfAccount
+	rent    *models.ChartOfAccount
+	cash    *models.ChartOfAccount
+}
+
+func newBudgetFixture() budgetFixture {
+	year := &models.FiscalYear{ID: uuid.New(), Name: "FY2026", StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)}
+	for i := 0; i < 3; i++ {
+		start := year.StartDate.AddDate(0, i, 0)
+		year.Periods = append(year.Periods, models.FiscalPeriod{ID: uuid.New(), FiscalYearID: year.ID, PeriodNumber: i + 1, StartDate: start, EndDate: start.AddDate(0, 1, -1)})
+	}
+	return budgetFixture{
+		year:    year,
+		travel:  &models.ChartOfAccount{ID: uuid.New(), AccountCode: "6100", AccountName: "Travel", AccountType: models.Expense, IsActive: true},
+		revenue: &models.ChartOfAccount{ID: uuid.New(), AccountCode: "4000", AccountName: "Revenue", AccountType: models.Revenue, IsActive: true},
+		rent:    &models.ChartOfAccount{ID: uuid.New(), AccountCode: "6200", AccountName: "Rent", AccountType: models.Expense, IsActive: true},
+		cash:    &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1010", AccountName: "Cash", AccountType: models.Asset, IsActive: true},
+	}
+}
+
+func TestBudgetService_CreateBudget(t *testing.T) {
+	ctx := context.Background()
+	f := newBudgetFixture()
+
+	newService := func(t *testing.T) (service.BudgetService, *mocks.BudgetRepository, *mocks.ChartOfAccountRepository) {
+		budgetRepo := mocks.NewBudgetRepositoryMock(t)
+		periodRepo := mocks.NewFiscalPeriodRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		periodRepo.On("GetFiscalYearByID", ctx, f.year.ID).Return(f.year, nil).Maybe()
+		return service.NewBudgetService(budgetRepo, periodRepo, coaRepo, nil, nil), budgetRepo, coaRepo
+	}
+
+	t.Run("Success - Version 1 Created As Draft", func(t *testing.T) {
+		budgetService, budgetRepo, coaRepo := newService(t)
+		budgetRepo.On("GetLatestVersion", ctx, "Operating", f.year.ID).Return(nil, app_errors.NewNotFoundError("budget", "Operating")).Once()
+		coaRepo.On("GetByID", ctx, f.travel.ID).Return(f.travel, nil).Once() // Looked up once per request
+		budgetRepo.On("CreateBudget", ctx, mock.AnythingOfType("*models.Budget")).Return(func(_ context.Context, b *models.Budget) *models.Budget { return b }, nil).Once()
+
+		budget, err := budgetService.CreateBudget(ctx, dto.CreateBudgetRequest{
+			Name:         " Operating ",
+			FiscalYearID: f.year.ID,
+			Lines: []dto.BudgetLineRequest{
+				{AccountID: f.travel.ID, PeriodNumber: 1, Amount: money.MustParse("100.00")},
+				{AccountID: f.travel.ID, PeriodNumber: 2, Amount: money.MustParse("120.00")},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Operating", budget.Name)
+		assert.Equal(t, 1, budget.Version)
+		assert.Equal(t, models.BudgetDraft, budget.Status)
+		require.Len(t, budget.Lines, 2)
+		assert.Equal(t, f.year.Periods[1].ID, budget.Lines[1].FiscalPeriodID)
+	})
+
+	t.Run("Conflict Error - Budget Exists For Fiscal Year", func(t *testing.T) {
+		budgetService, budgetRepo, _ := newService(t)
+		budgetRepo.On("GetLatestVersion", ctx, "Operating", f.year.ID).Return(&models.Budget{Name: "Operating", Version: 2}, nil).Once()
+
+		_, err := budgetService.CreateBudget(ctx, dto.CreateBudgetRequest{Name: "Operating", FiscalYearID: f.year.ID})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Contains(t, err.Error(), "create a new version")
+	})
+
+	t.Run("Validation Error - Unknown Period And Duplicate Line", func(t *testing.T) {
+		budgetService, budgetRepo, coaRepo := newService(t)
+		budgetRepo.On("GetLatestVersion", ctx, "Operating", f.year.ID).Return(nil, app_errors.NewNotFoundError("budget", "Operating"))
+		coaRepo.On("GetByID", ctx, f.travel.ID).Return(f.travel, nil)
+
+		_, err := budgetService.CreateBudget(ctx, dto.CreateBudgetRequest{Name: "Operating", FiscalYearID: f.year.ID,
+			Lines: []dto.BudgetLineRequest{{AccountID: f.travel.ID, PeriodNumber: 13, Amount: money.MustParse("1.00")}}})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "line 1: the fiscal year has no period 13")
+
+		_, err = budgetService.CreateBudget(ctx, dto.CreateBudgetRequest{Name: "Operating", FiscalYearID: f.year.ID,
+			Lines: []dto.BudgetLineRequest{
+				{AccountID: f.travel.ID, PeriodNumber: 1, Amount: money.MustParse("1.00")},
+				{AccountID: f.travel.ID, PeriodNumber: 1, Amount: money.MustParse("2.00")},
+			}})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "line 2: duplicates line 1")
+		budgetRepo.AssertNotCalled(t, "CreateBudget", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBudgetService_Versions(t *testing.T) {
+	f := newBudgetFixture()
+	manager := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "m1", Roles: []string{auth.RoleAccountingManager}})
+	accountant := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "a1", Roles: []string{auth.RoleAccountant}})
+	lines := []models.BudgetLine{{AccountID: f.travel.ID, FiscalPeriodID: f.year.Periods[0].ID, Amount: money.MustParse("100.00")}}
+
+	t.Run("Conflict Error - Approved Budget Cannot Be Changed", func(t *testing.T) {
+		budgetRepo := mocks.NewBudgetRepositoryMock(t)
+		budgetService := service.NewBudgetService(budgetRepo, mocks.NewFiscalPeriodRepositoryMock(t), nil, nil, nil)
+		approved := &models.Budget{ID: uuid.New(), Name: "Operating", Version: 1, Status: models.BudgetApproved, Lines: lines}
+		budgetRepo.On("GetBudget", manager, approved.ID).Return(approved, nil).Twice()
+
+		description := "Revised"
+		_, err := budgetService.UpdateBudget(manager, approved.ID, dto.UpdateBudgetRequest{Description: &description})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Contains(t, err.Error(), "budget Operating version 1 is APPROVED; create a new version to change it")
+
+		_, err = budgetService.ImportBudgetLines(manager, approved.ID, strings.NewReader("account_code,period,amount\n6100,1,10\n"))
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Success - Approval Records Approver", func(t *testing.T) {
+		budgetRepo := mocks.NewBudgetRepositoryMock(t)
+		periodRepo := mocks.NewFiscalPeriodRepositoryMock(t)
+		budgetService := service.NewBudgetService(budgetRepo, periodRepo, nil, nil, nil)
+		draft := &models.Budget{ID: uuid.New(), Name: "Operating", FiscalYearID: f.year.ID, Version: 2, Status: models.BudgetDraft, Lines: lines}
+		budgetRepo.On("GetBudget", manager, draft.ID).Return(draft, nil).Once()
+		periodRepo.On("GetFiscalYearByID", manager, f.year.ID).Return(f.year, nil).Once()
+		budgetRepo.On("ApproveBudget", manager, draft).Return(draft, nil).Once()
+
+		approved, err := budgetService.ApproveBudget(manager, draft.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.BudgetApproved, approved.Status)
+		assert.Equal(t, "m1", approved.ApprovedBy)
+		assert.NotNil(t, approved.ApprovedAt)
+	})
+
+	t.Run("Forbidden Error - Accountant Cannot Approve", func(t *testing.T) {
+		budgetService := service.NewBudgetService(mocks.NewBudgetRepositoryMock(t), nil, nil, nil, nil)
+		_, err := budgetService.ApproveBudget(accountant, uuid.New())
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+
+	t.Run("Success - New Version Copies Lines", func(t *testing.T) {
+		budgetRepo := mocks.NewBudgetRepositoryMock(t)
+		budgetService := service.NewBudgetService(budgetRepo, nil, nil, nil, nil)
+		approved := &models.Budget{ID: uuid.New(), Name: "Operating", FiscalYearID: f.year.ID, Version: 1, Status: models.BudgetApproved, Lines: lines}
+		budgetRepo.On("GetBudget", manager, approved.ID).Return(approved, nil).Once()
+		budgetRepo.On("GetLatestVersion", manager, "Operating", f.year.ID).Return(approved, nil).Once()
+		budgetRepo.On("CreateBudget", manager, mock.AnythingOfType("*models.Budget")).Return(func(_ context.Context, b *models.Budget) *models.Budget { return b }, nil).Once()
+
+		next, err := budgetService.CreateBudgetVersion(manager, approved.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, next.Version)
+		assert.Equal(t, models.BudgetDraft, next.Status)
+		require.Len(t, next.Lines, 1)
+		assert.Equal(t, uuid.Nil, next.Lines[0].ID)
+		assert.Equal(t, "100.00", next.Lines[0].Amount.String())
+	})
+
+	t.Run("Conflict Error - Draft Version Already Exists", func(t *testing.T) {
+		budgetRepo := mocks.NewBudgetRepositoryMock(t)
+		budgetService := service.NewBudgetService(budgetRepo, nil, nil, nil, nil)
+		approved := &models.Budget{ID: uuid.New(), Name: "Operating", FiscalYearID: f.year.ID, Version: 1, Status: models.BudgetApproved}
+		budgetRepo.On("GetBudget", manager, approved.ID).Return(approved, nil).Once()
+		budgetRepo.On("GetLatestVersion", manager, "Operating", f.year.ID).Return(&models.Budget{Name: "Operating", Version: 2, Status: models.BudgetDraft}, nil).Once()
+
+		_, err := budgetService.CreateBudgetVersion(manager, approved.ID)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Contains(t, err.Error(), "already has a draft version 2")
+	})
+}
+
+func TestBudgetService_ImportBudgetLines(t *testing.T) {
+	ctx := context.Background()
+	f := newBudgetFixture()
+	department := &models.Dimension{ID: uuid.New(), Code: "DEPARTMENT", IsActive: true, Values: []models.DimensionValue{{Code: "SALES", IsActive: true}}}
+
+	newService := func(t *testing.T) (service.BudgetService, *mocks.BudgetRepository, *mocks.ChartOfAccountRepository, *models.Budget) {
+		budgetRepo := mocks.NewBudgetRepositoryMock(t)
+		periodRepo := mocks.NewFiscalPeriodRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		dimensionRepo := mocks.NewDimensionRepositoryMock(t)
+		draft := &models.Budget{ID: uuid.New(), Name: "Operating", FiscalYearID: f.year.ID, Version: 1, Status: models.BudgetDraft}
+		budgetRepo.On("GetBudget", ctx, draft.ID).Return(draft, nil).Once()
+		periodRepo.On("GetFiscalYearByID", ctx, f.year.ID).Return(f.year, nil).Once()
+		dimensionRepo.On("ListDimensions", ctx).Return([]*models.Dimension{department}, nil).Maybe()
+		return service.NewBudgetService(budgetRepo, periodRepo, coaRepo, nil, dimensionRepo), budgetRepo, coaRepo, draft
+	}
+
+	t.Run("Success - Lines Replaced", func(t *testing.T) {
+		budgetService, budgetRepo, coaRepo, draft := newService(t)
+		coaRepo.On("GetByCode", ctx, "6100").Return(f.travel, nil).Once()
+		coaRepo.On("GetByCode", ctx, "4000").Return(f.revenue, nil).Once()
+		budgetRepo.On("UpdateBudget", ctx, draft).Run(func(args mock.Arguments) {
+			lines := args.Get(1).(*models.Budget).Lines
+			require.Len(t, lines, 3)
+			assert.Equal(t, "DEPARTMENT", lines[1].DimensionCode)
+			assert.Equal(t, "SALES", lines[1].ValueCode)
+			assert.Equal(t, f.year.Periods[2].ID, lines[2].FiscalPeriodID)
+		}).Return(draft, nil).Once()
+
+		csv := "account_code,period,amount,dimension,value\n" +
+			"6100,1,100.00,,\n" +
+			"6100,1,40.00,department,sales\n" +
+			"4000,3,5000,,\n"
+		result, err := budgetService.ImportBudgetLines(ctx, draft.ID, strings.NewReader(csv))
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Imported)
+	})
+
+	t.Run("Validation Error - Nothing Saved When Any Row Is Invalid", func(t *testing.T) {
+		budgetService, budgetRepo, coaRepo, draft := newService(t)
+		coaRepo.On("GetByCode", ctx, "6100").Return(f.travel, nil).Once()
+		coaRepo.On("GetByCode", ctx, "9999").Return(nil, app_errors.NewNotFoundError("chart_of_account", "9999")).Once()
+
+		csv := "account_code,period,amount,dimension,value\n" +
+			"6100,1,100.00,,\n" +
+			"9999,1,10.00,,\n" +
+			"6100,x,10.00,,\n" +
+			"6100,2,10.00,DEPARTMENT,HR\n" +
+			"6100,1,50.00,,\n"
+		_, err := budgetService.ImportBudgetLines(ctx, draft.ID, strings.NewReader(csv))
+		require.IsType(t, &app_errors.ValidationError{}, err)
+		for _, want := range []string{
+			"row 3: unknown account 9999",
+			"row 4: period \"x\" is not a period number",
+			"row 5: HR is not a value of dimension DEPARTMENT",
+			"row 6: duplicates row 2",
+		} {
+			assert.Contains(t, err.Error(), want)
+		}
+		budgetRepo.AssertNotCalled(t, "UpdateBudget", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBudgetService_GetBudgetVsActual(t *testing.T) {
+	ctx := context.Background()
+	f := newBudgetFixture()
+	budgetRepo := mocks.NewBudgetRepositoryMock(t)
+	periodRepo := mocks.NewFiscalPeriodRepositoryMock(t)
+	coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+	journalRepo := mocks.NewJournalEntryRepositoryMock(t)
+	budgetService := service.NewBudgetService(budgetRepo, periodRepo, coaRepo, journalRepo, nil)
+
+	jan, feb, mar := f.year.Periods[0].ID, f.year.Periods[1].ID, f.year.Periods[2].ID
+	budget := &models.Budget{ID: uuid.New(), Name: "Operating", FiscalYearID: f.year.ID, Version: 1, Status: models.BudgetApproved, Lines: []models.BudgetLine{
+		{AccountID: f.travel.ID, FiscalPeriodID: jan, Amount: money.MustParse("100.00")},
+		{AccountID: f.travel.ID, FiscalPeriodID: feb, Amount: money.MustParse("100.00")},
+		{AccountID: f.travel.ID, FiscalPeriodID: jan, DimensionCode: "DEPARTMENT", ValueCode: "SALES", Amount: money.MustParse("50.00")},
+		{AccountID: f.revenue.ID, FiscalPeriodID: jan, Amount: money.MustParse("1000.00")},
+		{AccountID: f.revenue.ID, FiscalPeriodID: mar, Amount: money.MustParse("1000.00")},
+	}}
+	sales := []models.JournalLineDimension{{DimensionCode: "DEPARTMENT", ValueCode: "SALES"}}
+	entries := []models.JournalEntry{
+		{Status: models.StatusPosted, JournalLines: []models.JournalLine{
+			{AccountID: f.travel.ID, Amount: money.MustParse("150.00"), IsDebit: true},
+			{AccountID: f.travel.ID, Amount: money.MustParse("60.00"), IsDebit: true, Dimensions: sales},
+			{AccountID: f.rent.ID, Amount: money.MustParse("300.00"), IsDebit: true},
+			{AccountID: f.cash.ID, Amount: money.MustParse("510.00"), IsDebit: false},
+		}},
+		{Status: models.StatusPosted, JournalLines: []models.JournalLine{
+			{AccountID: f.cash.ID, Amount: money.MustParse("1100.00"), IsDebit: true},
+			{AccountID: f.revenue.ID, Amount: money.MustParse("1100.00"), IsDebit: false},
+		}},
+	}
+
+	budgetRepo.On("GetBudget", ctx, budget.ID).Return(budget, nil).Once()
+	periodRepo.On("GetFiscalYearByID", ctx, f.year.ID).Return(f.year, nil).Once()
+	journalRepo.On("GetJournalEntriesForTrialBalance", ctx, f.year.Periods[0].StartDate, mock.MatchedBy(func(end time.Time) bool {
+		return end.After(f.year.Periods[1].EndDate) && end.Before(f.year.Periods[2].StartDate)
+	})).Return(entries, nil).Once()
+	coaRepo.On("List", ctx, 0, 0, map[string]interface{}{}).Return([]*models.ChartOfAccount{f.cash, f.revenue, f.travel, f.rent}, int64(4), nil).Once()
+
+	report, err := budgetService.GetBudgetVsActual(ctx, dto.BudgetVsActualRequest{BudgetID: budget.ID, ToPeriod: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.FromPeriod)
+	require.Len(t, report.Lines, 4, "Cash is neither budgeted nor a P&L account")
+
+	revenue := report.Lines[0]
+	assert.Equal(t, "4000", revenue.AccountCode)
+	assert.Equal(t, "1000.00", revenue.Budget.String(), "March is outside the range")
+	assert.Equal(t, "1100.00", revenue.Actual.String(), "Revenue is reported credit-positive")
+	assert.Equal(t, "100.00", revenue.Variance.String())
+	require.NotNil(t, revenue.PercentConsumed)
+	assert.Equal(t, 110.0, *revenue.PercentConsumed)
+
+	travel := report.Lines[1]
+	assert.Equal(t, "", travel.ValueCode)
+	assert.Equal(t, "200.00", travel.Budget.String())
+	assert.Equal(t, "150.00", travel.Actual.String(), "The SALES line counts toward its own row only")
+	assert.Equal(t, 75.0, *travel.PercentConsumed)
+
+	travelSales := report.Lines[2]
+	assert.Equal(t, "SALES", travelSales.ValueCode)
+	assert.Equal(t, "60.00", travelSales.Actual.String())
+	assert.Equal(t, "10.00", travelSales.Variance.String())
+
+	rent := report.Lines[3]
+	assert.Equal(t, "6200", rent.AccountCode)
+	assert.True(t, rent.Budget.IsZero(), "Unbudgeted expenses are listed")
+	assert.Nil(t, rent.PercentConsumed)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"erp-system/internal/accounting/models"
+	dto "erp-system/internal/accounting/service/dto"
+)
+
+// chartTemplate is a bundled chart of accounts that can be applied to an empty database.
+type chartTemplate struct {
+	Name        string
+	Description string
+	Accounts    []dto.ChartOfAccountRecord
+}
+
+// chartTemplates are the bundled templates, in the order they are listed.
+var chartTemplates = []chartTemplate{
+	{
+		Name:        "standard",
+		Description: "General chart for trading and service companies",
+		Accounts:    standardChartAccounts(),
+	},
+	{
+		Name:        "manufacturing",
+		Description: "Standard chart extended with raw materials, work in progress, production equipment and cost of production",
+		Accounts:    manufacturingChartAccounts(),
+	},
+	{
+		Name:        "retail",
+		Description: "Standard chart extended with merchandise inventory, store operations and card settlements",
+		Accounts:    retailChartAccounts(),
+	},
+}
+
+// findChartTemplate returns the bundled template called name.
+func findChartTemplate(name string) (*chartTemplate, bool) {
+	for i := range chartTemplates {
+		if chartTemplates[i].Name == name {
+			return &chartTemplates[i], true
+		}
+	}
+	return nil, false
+}
+
+// templateAccount builds one template record; parent is the code of the account above it.
+func templateAccount(code, name string, accountType models.AccountType, parent string, cashFlow models.CashFlowCategory) dto.ChartOfAccountRecord {
+	return dto.ChartOfAccountRecord{AccountCode: code, AccountName: name, AccountType: accountType, ParentCode: parent, CashFlowCategory: cashFlow}
+}
+
+func standardChartAccounts() []dto.ChartOfAccountRecord {
+	return []dto.ChartOfAccountRecord{
+		templateAccount("1000", "Assets", models.Asset, "", ""),
+		templateAccount("1100", "Cash and Cash Equivalents", models.Asset, "1000", models.CashFlowCash),
+		templateAccount("1110", "Cash on Hand", models.Asset, "1100", models.CashFlowCash),
+		templateAccount("1120", "Bank Accounts", models.Asset, "1100", models.CashFlowCash),
+		templateAccount("1200", "Accounts Receivable", models.Asset, "1000", models.CashFlowOperating),
+		templateAccount("1210", "Allowance for Doubtful Accounts", models.Asset, "1200", models.CashFlowOperating),
+		templateAccount("1300", "Inventory", models.Asset, "1000", models.CashFlowOperating),
+		templateAccount("1400", "Prepaid Expenses", models.Asset, "1000", models.CashFlowOperating),
+		templateAccount("1500", "Input VAT Receivable", models.Asset, "1000", models.CashFlowOperating),
+		templateAccount("1600", "Property, Plant and Equipment", models.Asset, "1000", models.CashFlowInvesting),
+		templateAccount("1610", "Office Equipment", models.Asset, "1600", models.CashFlowInvesting),
+		templateAccount("1620", "Vehicles", models.Asset, "1600", models.CashFlowInvesting),
+		templateAccount("1690", "Accumulated Depreciation", models.Asset, "1600", models.CashFlowNonCash),
+
+		templateAccount("2000", "Liabilities", models.Liability, "", ""),
+		templateAccount("2100", "Accounts Payable", models.Liability, "2000", models.CashFlowOperating),
+		templateAccount("2200", "Accrued Liabilities", models.Liability, "2000", models.CashFlowOperating),
+		templateAccount("2300", "Output VAT Payable", models.Liability, "2000", models.CashFlowOperating),
+		templateAccount("2400", "Payroll Liabilities", models.Liability, "2000", models.CashFlowOperating),
+		templateAccount("2500", "Long-term Loans", models.Liability, "2000", models.CashFlowFinancing),
+
+		templateAccount("3000", "Equity", models.Equity, "", ""),
+		templateAccount("3100", "Share Capital", models.Equity, "3000", models.CashFlowFinancing),
+		templateAccount("3200", "Retained Earnings", models.Equity, "3000", models.CashFlowFinancing),
+		templateAccount("3300", "Dividends", models.Equity, "3000", models.CashFlowFinancing),
+
+		templateAccount("4000", "Revenue", models.Revenue, "", ""),
+		templateAccount("4100", "Sales Revenue", models.Revenue, "4000", ""),
+		templateAccount("4200", "Service Revenue", models.Revenue, "4000", ""),
+		templateAccount("4900", "Other Income", models.Revenue, "4000", ""),
+
+		templateAccount("5000", "Cost of Sales", models.Expense, "", ""),
+		templateAccount("5100", "Cost of Goods Sold", models.Expense, "5000", ""),
+
+		templateAccount("6000", "Operating Expenses", models.Expense, "", ""),
+		templateAccount("6100", "Salaries and Wages", models.Expense, "6000", ""),
+		templateAccount("6200", "Rent", models.Expense, "6000", ""),
+		templateAccount("6300", "Utilities", models.Expense, "6000", ""),
+		templateAccount("6400", "Office Supplies", models.Expense, "6000", ""),
+		templateAccount("6500", "Depreciation Expense", models.Expense, "6000", ""),
+		templateAccount("6600", "Bank Charges", models.Expense, "6000", ""),
+		templateAccount("6700", "Foreign Exchange Gains and Losses", models.Expense, "6000", ""),
+		templateAccount("6800", "Income Tax Expense", models.Expense, "6000", ""),
+	}
+}
+
+func manufacturingChartAccounts() []dto.ChartOfAccountRecord {
+	return append(standardChartAccounts(),
+		templateAccount("1310", "Raw Materials", models.Asset, "1300", models.CashFlowOperating),
+		templateAccount("1320", "Work in Progress", models.Asset, "1300", models.CashFlowOperating),
+		templateAccount("1330", "Finished Goods", models.Asset, "1300", models.CashFlowOperating),
+		templateAccount("1630", "Production Machinery", models.Asset, "1600", models.CashFlowInvesting),
+		templateAccount("1640", "Factory Buildings", models.Asset, "1600", models.CashFlowInvesting),
+		templateAccount("2110", "Goods Received Not Invoiced", models.Liability, "2100", models.CashFlowOperating),
+		templateAccount("4110", "Sales of Finished Goods", models.Revenue, "4100", ""),
+		templateAccount("4120", "Sales of Scrap", models.Revenue, "4100", ""),
+		templateAccount("5200", "Cost of Production", models.Expense, "5000", ""),
+		templateAccount("5210", "Direct Materials", models.Expense, "5200", ""),
+		templateAccount("5220", "Direct Labour", models.Expense, "5200", ""),
+		templateAccount("5230", "Manufacturing Overhead", models.Expense, "5200", ""),
+		templateAccount("5240", "Factory Depreciation", models.Expense, "5200", ""),
+		templateAccount("5300", "Purchase Price Variance", models.Expense, "5000", ""),
+		templateAccount("5400", "Production Variances", models.Expense, "5000", ""),
+	)
+}
+
+func retailChartAccounts() []dto.ChartOfAccountRecord {
+	return append(standardChartAccounts(),
+		templateAccount("1130", "Card Settlements in Transit", models.Asset, "1100", models.CashFlowCash),
+		templateAccount("1310", "Merchandise Inventory", models.Asset, "1300", models.CashFlowOperating),
+		templateAccount("1630", "Store Fixtures and Fittings", models.Asset, "1600", models.CashFlowInvesting),
+		templateAccount("2210", "Gift Card Liabilities", models.Liability, "2200", models.CashFlowOperating),
+		templateAccount("4110", "Store Sales", models.Revenue, "4100", ""),
+		templateAccount("4120", "Online Sales", models.Revenue, "4100", ""),
+		templateAccount("4130", "Sales Returns and Allowances", models.Revenue, "4100", ""),
+		templateAccount("5110", "Inventory Shrinkage", models.Expense, "5100", ""),
+		templateAccount("5120", "Freight In", models.Expense, "5100", ""),
+		templateAccount("6900", "Card Processing Fees", models.Expense, "6000", ""),
+	)
+}
@@ -0,0 +1,454 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ChartImportRoles are the roles allowed to import a chart of accounts or apply a chart template.
+var ChartImportRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+// ChartFileFormat is the file format of a chart of accounts import or export.
+type ChartFileFormat string
+
+const (
+	ChartCSV  ChartFileFormat = "CSV"
+	ChartJSON ChartFileFormat = "JSON" // An array of dto.ChartOfAccountRecord
+)
+
+// ChartOfAccountsCSVColumns are the columns of an exported chart of accounts. An import needs the
+// account_code, account_name and account_type columns; the others may be left out or reordered.
+var ChartOfAccountsCSVColumns = []string{"account_code", "account_name", "account_type", "parent_code", "is_active", "cash_flow_category", "description"}
+
+// ChartTransferService moves whole charts of accounts in and out: CSV and JSON export and import,
+// and the bundled chart templates for setting up a new company.
+type ChartTransferService interface {
+	ExportChartOfAccounts(ctx context.Context) ([]dto.ChartOfAccountRecord, error)
+	ImportChartOfAccounts(ctx context.Context, format ChartFileFormat, r io.Reader, dryRun bool) (*dto.ImportChartOfAccountsResponse, error)
+	ListChartTemplates(ctx context.Context) ([]dto.ChartTemplateResponse, error)
+	GetChartTemplate(ctx context.Context, name string) (*dto.ChartTemplateResponse, error)
+	ApplyChartTemplate(ctx context.Context, name string) (*dto.ImportChartOfAccountsResponse, error)
+}
+
+// chartTransferService is an implementation of ChartTransferService.
+type chartTransferService struct {
+	coaRepo repository.ChartOfAccountRepository
+}
+
+// NewChartTransferService creates a new ChartTransferService.
+func NewChartTransferService(coaRepo repository.ChartOfAccountRepository) ChartTransferService {
+	return &chartTransferService{coaRepo: coaRepo}
+}
+
+// chartImportRecord is a record read from an import file, with the row it was read from.
+type chartImportRecord struct {
+	row    int
+	record dto.ChartOfAccountRecord
+}
+
+// ExportChartOfAccounts returns every account, active or not, ordered by code.
+func (s *chartTransferService) ExportChartOfAccounts(ctx context.Context) ([]dto.ChartOfAccountRecord, error) {
+	logger.InfoLogger.Println("Service: Exporting the chart of accounts")
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error listing accounts for export: %v", err)
+		return nil, err
+	}
+	codes := make(map[uuid.UUID]string, len(accounts))
+	for _, acc := range accounts {
+		codes[acc.ID] = acc.AccountCode
+	}
+	records := make([]dto.ChartOfAccountRecord, 0, len(accounts))
+	for _, acc := range accounts {
+		isActive := acc.IsActive
+		record := dto.ChartOfAccountRecord{
+			AccountCode:      acc.AccountCode,
+			AccountName:      acc.AccountName,
+			AccountType:      acc.AccountType,
+			IsActive:         &isActive,
+			CashFlowCategory: acc.CashFlowCategory,
+			Description:      acc.Description,
+		}
+		if acc.ParentAccountID != nil {
+			record.ParentCode = codes[*acc.ParentAccountID]
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ImportChartOfAccounts creates the accounts of a CSV or JSON file; an empty format is detected
+// from the file. Parents are named by code and may be in the file or already in the database.
+// The import is all-or-nothing: with any invalid record, nothing is created. A dry run creates
+// nothing either and reports every problem found.
+func (s *chartTransferService) ImportChartOfAccounts(ctx context.Context, format ChartFileFormat, r io.Reader, dryRun bool) (*dto.ImportChartOfAccountsResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting to import a chart of accounts (dry run: %t)", dryRun)
+	if !dryRun && !auth.HasAnyRole(ctx, ChartImportRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("importing a chart of accounts requires one of the roles: %s", strings.Join(ChartImportRoles, ", ")))
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("could not read the file: %v", err), "file")
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.NewValidationError("the file is empty", "file")
+	}
+	format = ChartFileFormat(strings.ToUpper(strings.TrimSpace(string(format))))
+	if format == "" {
+		format = ChartCSV
+		if data[0] == '[' {
+			format = ChartJSON
+		}
+	}
+
+	var records []chartImportRecord
+	var problems []dto.ChartImportProblem
+	switch format {
+	case ChartCSV:
+		records, problems, err = parseChartCSV(data)
+	case ChartJSON:
+		records, err = parseChartJSON(data)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported format %q; use CSV or JSON", format), "format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 && len(problems) == 0 {
+		return nil, errors.NewValidationError("the file has no accounts", "file")
+	}
+
+	accounts, planProblems, err := s.planChart(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, planProblems...)
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Row < problems[j].Row })
+	response := &dto.ImportChartOfAccountsResponse{
+		DryRun:   dryRun,
+		Valid:    len(problems) == 0,
+		Accounts: len(records),
+		Problems: problems,
+	}
+	if dryRun {
+		return response, nil
+	}
+	if len(problems) > 0 {
+		logger.WarnLogger.Printf("Service: Chart of accounts import rejected with %d problems", len(problems))
+		messages := make([]string, 0, min(len(problems), maxImportErrors)+1)
+		for _, problem := range problems[:min(len(problems), maxImportErrors)] {
+			messages = append(messages, fmt.Sprintf("row %d: %s", problem.Row, problem.Message))
+		}
+		if len(problems) > maxImportErrors {
+			messages = append(messages, fmt.Sprintf("and %d more", len(problems)-maxImportErrors))
+		}
+		return nil, errors.NewValidationError("no accounts were imported: "+strings.Join(messages, "; "), "file")
+	}
+
+	if err := s.coaRepo.CreateAll(ctx, accounts); err != nil {
+		return nil, err
+	}
+	response.Created = len(accounts)
+	logger.InfoLogger.Printf("Service: Imported %d accounts into the chart of accounts", len(accounts))
+	return response, nil
+}
+
+func (s *chartTransferService) ListChartTemplates(ctx context.Context) ([]dto.ChartTemplateResponse, error) {
+	templates := make([]dto.ChartTemplateResponse, 0, len(chartTemplates))
+	for _, template := range chartTemplates {
+		templates = append(templates, dto.ChartTemplateResponse{Name: template.Name, Description: template.Description, AccountCount: len(template.Accounts)})
+	}
+	return templates, nil
+}
+
+func (s *chartTransferService) GetChartTemplate(ctx context.Context, name string) (*dto.ChartTemplateResponse, error) {
+	template, ok := findChartTemplate(strings.ToLower(strings.TrimSpace(name)))
+	if !ok {
+		return nil, errors.NewNotFoundError("chart_template", name)
+	}
+	return &dto.ChartTemplateResponse{Name: template.Name, Description: template.Description, AccountCount: len(template.Accounts), Accounts: template.Accounts}, nil
+}
+
+// ApplyChartTemplate creates the accounts of a bundled template. It only applies to an empty chart
+// of accounts, so a template never mixes with accounts set up by hand.
+func (s *chartTransferService) ApplyChartTemplate(ctx context.Context, name string) (*dto.ImportChartOfAccountsResponse, error) {
+	logger.InfoLogger.Printf("Service: Attempting to apply chart template %s", name)
+	if !auth.HasAnyRole(ctx, ChartImportRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("applying a chart template requires one of the roles: %s", strings.Join(ChartImportRoles, ", ")))
+	}
+	template, ok := findChartTemplate(strings.ToLower(strings.TrimSpace(name)))
+	if !ok {
+		return nil, errors.NewNotFoundError("chart_template", name)
+	}
+	_, total, err := s.coaRepo.List(ctx, 0, 1, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	if total > 0 {
+		return nil, errors.NewConflictError(fmt.Sprintf("the chart of accounts already has %d accounts; templates only apply to an empty chart", total))
+	}
+
+	records := make([]chartImportRecord, len(template.Accounts))
+	for i, record := range template.Accounts {
+		records[i] = chartImportRecord{row: i + 1, record: record}
+	}
+	accounts, problems, err := s.planChart(ctx, records)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		logger.ErrorLogger.Printf("Service: Chart template %s is invalid: %v", template.Name, problems)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("chart template %s is invalid", template.Name), nil)
+	}
+	if err := s.coaRepo.CreateAll(ctx, accounts); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Applied chart template %s with %d accounts", template.Name, len(accounts))
+	return &dto.ImportChartOfAccountsResponse{Valid: true, Accounts: len(records), Created: len(accounts), Problems: []dto.ChartImportProblem{}}, nil
+}
+
+// planChart validates records against each other and the accounts already in the database, and
+// returns the accounts to create with parents ahead of their children. Accounts are only returned
+// when no record has a problem.
+func (s *chartTransferService) planChart(ctx context.Context, records []chartImportRecord) ([]*models.ChartOfAccount, []dto.ChartImportProblem, error) {
+	existing, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error listing accounts for import: %v", err)
+		return nil, nil, err
+	}
+	existingByCode := make(map[string]*models.ChartOfAccount, len(existing))
+	for _, acc := range existing {
+		existingByCode[acc.AccountCode] = acc
+	}
+
+	problems := []dto.ChartImportProblem{}
+	report := func(rec chartImportRecord, format string, args ...interface{}) {
+		problems = append(problems, dto.ChartImportProblem{Row: rec.row, AccountCode: rec.record.AccountCode, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// First pass: each record on its own.
+	rowOfCode := make(map[string]int)
+	accounts := make(map[string]*models.ChartOfAccount)
+	parents := make(map[string]string)
+	var valid []chartImportRecord
+	for _, rec := range records {
+		rec.record = normalizeChartRecord(rec.record)
+		if err := validateChartRecord(rec.record); err != nil {
+			invalid, ok := err.(*errors.ValidationError)
+			if !ok {
+				return nil, nil, err
+			}
+			report(rec, "%s", invalid.Message)
+			continue
+		}
+		code := rec.record.AccountCode
+		if first, ok := rowOfCode[code]; ok {
+			report(rec, "account code %s duplicates row %d", code, first)
+			continue
+		}
+		rowOfCode[code] = rec.row
+		if _, ok := existingByCode[code]; ok {
+			report(rec, "account %s already exists", code)
+			continue
+		}
+		isActive := rec.record.IsActive == nil || *rec.record.IsActive
+		accounts[code] = &models.ChartOfAccount{
+			ID:               uuid.New(),
+			AccountCode:      code,
+			AccountName:      rec.record.AccountName,
+			AccountType:      rec.record.AccountType,
+			IsActive:         isActive,
+			Description:      rec.record.Description,
+			CashFlowCategory: rec.record.CashFlowCategory,
+		}
+		parents[code] = rec.record.ParentCode
+		valid = append(valid, rec)
+	}
+
+	// Second pass: the parents, which may come later in the file.
+	for _, rec := range valid {
+		account := accounts[rec.record.AccountCode]
+		parentCode := rec.record.ParentCode
+		if parentCode == "" {
+			continue
+		}
+		var parent *models.ChartOfAccount
+		switch {
+		case parentCode == account.AccountCode:
+			report(rec, "account %s cannot be its own parent", parentCode)
+			continue
+		case accounts[parentCode] != nil:
+			parent = accounts[parentCode]
+		case existingByCode[parentCode] != nil:
+			parent = existingByCode[parentCode]
+		case rowOfCode[parentCode] != 0:
+			report(rec, "parent account %s on row %d is invalid", parentCode, rowOfCode[parentCode])
+			continue
+		default:
+			report(rec, "parent account %s not found", parentCode)
+			continue
+		}
+		if !parent.IsActive {
+			report(rec, "parent account %s is not active", parentCode)
+			continue
+		}
+		if parent.AccountType != account.AccountType {
+			report(rec, "parent account %s is %s; %s accounts cannot be placed under it", parentCode, parent.AccountType, account.AccountType)
+			continue
+		}
+		account.ParentAccountID = &parent.ID
+	}
+
+	// Accounts in the file may only form a cycle among themselves; depth orders parents first.
+	depth := make(map[string]int, len(valid))
+	for _, rec := range valid {
+		code := rec.record.AccountCode
+		seen := map[string]bool{code: true}
+		for parent := parents[code]; accounts[parent] != nil; parent = parents[parent] {
+			if seen[parent] {
+				report(rec, "the parents of account %s loop back to %s", code, parent)
+				break
+			}
+			seen[parent] = true
+			depth[code]++
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems, nil
+	}
+
+	ordered := make([]*models.ChartOfAccount, 0, len(valid))
+	for _, rec := range valid {
+		ordered = append(ordered, accounts[rec.record.AccountCode])
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return depth[ordered[i].AccountCode] < depth[ordered[j].AccountCode] })
+	return ordered, problems, nil
+}
+
+// normalizeChartRecord trims a record's fields and upper-cases its enumerations.
+func normalizeChartRecord(record dto.ChartOfAccountRecord) dto.ChartOfAccountRecord {
+	record.AccountCode = strings.TrimSpace(record.AccountCode)
+	record.AccountName = strings.TrimSpace(record.AccountName)
+	record.AccountType = models.AccountType(strings.ToUpper(strings.TrimSpace(string(record.AccountType))))
+	record.ParentCode = strings.TrimSpace(record.ParentCode)
+	record.CashFlowCategory = models.CashFlowCategory(strings.ToUpper(strings.TrimSpace(string(record.CashFlowCategory))))
+	record.Description = strings.TrimSpace(record.Description)
+	return record
+}
+
+// validateChartRecord applies the rules of CreateChartOfAccount that need no other account.
+func validateChartRecord(record dto.ChartOfAccountRecord) error {
+	if record.AccountCode == "" || record.AccountName == "" || record.AccountType == "" {
+		return errors.NewValidationError("account_code, account_name and account_type are required", "")
+	}
+	if len(record.AccountCode) > 20 {
+		return errors.NewValidationError(fmt.Sprintf("account code %s is longer than 20 characters", record.AccountCode), "account_code")
+	}
+	if len(record.AccountName) > 100 {
+		return errors.NewValidationError("account name is longer than 100 characters", "account_name")
+	}
+	if len(record.Description) > 255 {
+		return errors.NewValidationError("description is longer than 255 characters", "description")
+	}
+	switch record.AccountType {
+	case models.Asset, models.Liability, models.Equity, models.Revenue, models.Expense:
+	default:
+		return errors.NewValidationError(fmt.Sprintf("invalid account type: %s", record.AccountType), "account_type")
+	}
+	return validateCashFlowCategory(record.AccountType, record.CashFlowCategory)
+}
+
+// parseChartCSV reads a CSV chart of accounts. Columns are matched by the header row, which is
+// required; records that cannot be read are returned as problems.
+func parseChartCSV(data []byte) ([]chartImportRecord, []dto.ChartImportProblem, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("invalid CSV: %v", err), "file")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(ChartOfAccountsCSVColumns, name) {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("unknown column %q; use %s", name, strings.Join(ChartOfAccountsCSVColumns, ",")), "file")
+		}
+		columns[name] = i
+	}
+	for _, required := range ChartOfAccountsCSVColumns[:3] {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("the header must include %s", required), "file")
+		}
+	}
+	reader.FieldsPerRecord = len(header)
+
+	var records []chartImportRecord
+	var problems []dto.ChartImportProblem
+	for row := 2; ; row++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			problems = append(problems, dto.ChartImportProblem{Row: row, Message: err.Error()})
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return fields[i]
+			}
+			return ""
+		}
+		record := dto.ChartOfAccountRecord{
+			AccountCode:      field("account_code"),
+			AccountName:      field("account_name"),
+			AccountType:      models.AccountType(field("account_type")),
+			ParentCode:       field("parent_code"),
+			CashFlowCategory: models.CashFlowCategory(field("cash_flow_category")),
+			Description:      field("description"),
+		}
+		if value := strings.TrimSpace(field("is_active")); value != "" {
+			isActive, err := strconv.ParseBool(value)
+			if err != nil {
+				problems = append(problems, dto.ChartImportProblem{Row: row, AccountCode: strings.TrimSpace(record.AccountCode), Message: fmt.Sprintf("is_active %q must be true or false", value)})
+				continue
+			}
+			record.IsActive = &isActive
+		}
+		records = append(records, chartImportRecord{row: row, record: record})
+	}
+	return records, problems, nil
+}
+
+// parseChartJSON reads a JSON array of records; the row of a record is its position in the array.
+func parseChartJSON(data []byte) ([]chartImportRecord, error) {
+	var list []dto.ChartOfAccountRecord
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&list); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid JSON: %v", err), "file")
+	}
+	records := make([]chartImportRecord, len(list))
+	for i, record := range list {
+		records[i] = chartImportRecord{row: i + 1, record: record}
+	}
+	return records, nil
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	"erp-system/pkg/auth"
+	app_errors "erp-system/pkg/errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartTransferService_ImportChartOfAccounts(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "manager", Roles: []string{auth.RoleAccountingManager}})
+	cash := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1100", AccountName: "Cash", AccountType: models.Asset, IsActive: true}
+	noFilters := map[string]interface{}{}
+
+	t.Run("Success - CSV With Parents Later In The File", func(t *testing.T) {
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		transferService := service.NewChartTransferService(coaRepo)
+		coaRepo.On("List", ctx, 0, 0, noFilters).Return([]*models.ChartOfAccount{cash}, int64(1), nil).Once()
+		var created []*models.ChartOfAccount
+		coaRepo.On("CreateAll", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			created = args.Get(1).([]*models.ChartOfAccount)
+		}).Return(nil).Once()
+
+		file := "account_code,account_name,account_type,parent_code,is_active,cash_flow_category\n" +
+			"1121,Bank EUR,asset,1120,,cash\n" +
+			"1120,Bank Accounts,ASSET,1100,true,CASH\n" +
+			"4000,Revenue,REVENUE,,false,\n"
+		result, err := transferService.ImportChartOfAccounts(ctx, "", strings.NewReader(file), false)
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.Equal(t, 3, result.Accounts)
+		assert.Equal(t, 3, result.Created)
+		require.Len(t, created, 3)
+
+		byCode := make(map[string]int)
+		for i, acc := range created {
+			byCode[acc.AccountCode] = i
+		}
+		assert.Less(t, byCode["1120"], byCode["1121"], "parents are created first")
+		bank, eur, revenue := created[byCode["1120"]], created[byCode["1121"]], created[byCode["4000"]]
+		assert.Equal(t, cash.ID, *bank.ParentAccountID)
+		assert.Equal(t, bank.ID, *eur.ParentAccountID)
+		assert.Equal(t, models.CashFlowCash, eur.CashFlowCategory)
+		assert.True(t, eur.IsActive)
+		assert.False(t, revenue.IsActive)
+		assert.Nil(t, revenue.ParentAccountID)
+	})
+
+	t.Run("Success - JSON", func(t *testing.T) {
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		transferService := service.NewChartTransferService(coaRepo)
+		coaRepo.On("List", ctx, 0, 0, noFilters).Return([]*models.ChartOfAccount{}, int64(0), nil).Once()
+		coaRepo.On("CreateAll", ctx, mock.MatchedBy(func(accounts []*models.ChartOfAccount) bool {
+			return len(accounts) == 2 && accounts[0].AccountCode == "5000" && *accounts[1].ParentAccountID == accounts[0].ID
+		})).Return(nil).Once()
+
+		file := `[{"account_code":"5100","account_name":"Materials","account_type":"EXPENSE","parent_code":"5000"},
+			{"account_code":"5000","account_name":"Cost of Sales","account_type":"EXPENSE"}]`
+		result, err := transferService.ImportChartOfAccounts(ctx, "", strings.NewReader(file), false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Created)
+	})
+
+	t.Run("Dry Run - Reports Every Problem", func(t *testing.T) {
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		transferService := service.NewChartTransferService(coaRepo)
+		coaRepo.On("List", ctx, 0, 0, noFilters).Return([]*models.ChartOfAccount{cash}, int64(1), nil).Once()
+
+		file := "account_code,account_name,account_type,parent_code\n" +
+			"1100,Cash,ASSET,\n" + // row 2: already exists
+			"2000,Liabilities,LIABILITY,\n" +
+			"2000,Liabilities again,LIABILITY,\n" + // row 4: duplicate
+			"2100,Payables,LIABILITY,9999\n" + // row 5: unknown parent
+			"2200,Loan,LIABILITY,1100\n" + // row 6: parent of another type
+			"3000,Capital,CAPITAL,\n" + // row 7: bad type
+			"4100,A,REVENUE,4200\n" + // rows 8 and 9: cycle
+			"4200,B,REVENUE,4100\n"
+		result, err := transferService.ImportChartOfAccounts(ctx, service.ChartCSV, strings.NewReader(file), true)
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.False(t, result.Valid)
+		assert.Equal(t, 8, result.Accounts)
+		assert.Zero(t, result.Created)
+		rows := make([]int, 0, len(result.Problems))
+		for _, problem := range result.Problems {
+			rows = append(rows, problem.Row)
+		}
+		assert.Equal(t, []int{2, 4, 5, 6, 7, 8, 9}, rows)
+		assert.Contains(t, result.Problems[3].Message, "parent account 1100 is ASSET")
+	})
+
+	t.Run("Validation Error - Nothing Imported With Problems", func(t *testing.T) {
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		transferService := service.NewChartTransferService(coaRepo)
+		coaRepo.On("List", ctx, 0, 0, noFilters).Return([]*models.ChartOfAccount{}, int64(0), nil).Once()
+
+		file := "account_code,account_name,account_type\n1000,Assets,ASSET\n2000,,LIABILITY\n"
+		_, err := transferService.ImportChartOfAccounts(ctx, service.ChartCSV, strings.NewReader(file), false)
+		require.Error(t, err)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "row 3")
+		coaRepo.AssertNotCalled(t, "CreateAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Validation Error - Missing Required Column", func(t *testing.T) {
+		transferService := service.NewChartTransferService(mocks.NewChartOfAccountRepositoryMock(t))
+		_, err := transferService.ImportChartOfAccounts(ctx, service.ChartCSV, strings.NewReader("account_code,account_name\n1000,Assets\n"), true)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Forbidden Error - Import Without Role", func(t *testing.T) {
+		transferService := service.NewChartTransferService(mocks.NewChartOfAccountRepositoryMock(t))
+		accountant := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "clerk", Roles: []string{auth.RoleAccountant}})
+		_, err := transferService.ImportChartOfAccounts(accountant, service.ChartCSV, strings.NewReader("account_code,account_name,account_type\n"), false)
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+}
+
+func TestChartTransferService_ExportChartOfAccounts(t *testing.T) {
+	ctx := context.Background()
+	coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+	transferService := service.NewChartTransferService(coaRepo)
+	parent := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1000", AccountName: "Assets", AccountType: models.Asset, IsActive: true}
+	child := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1100", AccountName: "Cash", AccountType: models.Asset, ParentAccountID: &parent.ID, CashFlowCategory: models.CashFlowCash}
+	coaRepo.On("List", ctx, 0, 0, map[string]interface{}{}).Return([]*models.ChartOfAccount{parent, child}, int64(2), nil).Once()
+
+	records, err := transferService.ExportChartOfAccounts(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "", records[0].ParentCode)
+	assert.Equal(t, "1000", records[1].ParentCode)
+	assert.False(t, *records[1].IsActive)
+	assert.Equal(t, models.CashFlowCash, records[1].CashFlowCategory)
+}
+
+func TestChartTransferService_ApplyChartTemplate(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "admin", Roles: []string{auth.RoleAdmin}})
+
+	templates, err := service.NewChartTransferService(mocks.NewChartOfAccountRepositoryMock(t)).ListChartTemplates(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, templates)
+	for _, template := range templates {
+		t.Run("Success - "+template.Name, func(t *testing.T) {
+			coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+			transferService := service.NewChartTransferService(coaRepo)
+			coaRepo.On("List", ctx, 0, 1, map[string]interface{}{}).Return([]*models.ChartOfAccount{}, int64(0), nil).Once()
+			coaRepo.On("List", ctx, 0, 0, map[string]interface{}{}).Return([]*models.ChartOfAccount{}, int64(0), nil).Once()
+			coaRepo.On("CreateAll", ctx, mock.AnythingOfType("[]*models.ChartOfAccount")).Return(nil).Once()
+
+			result, err := transferService.ApplyChartTemplate(ctx, template.Name)
+			require.NoError(t, err)
+			assert.Equal(t, template.AccountCount, result.Created)
+		})
+	}
+
+	t.Run("Conflict Error - Chart Not Empty", func(t *testing.T) {
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		transferService := service.NewChartTransferService(coaRepo)
+		coaRepo.On("List", ctx, 0, 1, map[string]interface{}{}).Return([]*models.ChartOfAccount{{AccountCode: "1000"}}, int64(12), nil).Once()
+
+		_, err := transferService.ApplyChartTemplate(ctx, "manufacturing")
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Not Found Error - Unknown Template", func(t *testing.T) {
+		transferService := service.NewChartTransferService(mocks.NewChartOfAccountRepositoryMock(t))
+		_, err := transferService.ApplyChartTemplate(ctx, "banking")
+		assert.IsType(t, &app_errors.NotFoundError{}, err)
+	})
+}
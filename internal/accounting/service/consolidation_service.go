@@ -0,0 +1,650 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	companyModels "erp-system/internal/company/models"
+	companyRepository "erp-system/internal/company/repository"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/company"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConsolidationRoles are the roles allowed to maintain the group chart of accounts, the account
+// mappings and the elimination rules, and to run consolidation reports, which read every company.
+var ConsolidationRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+var groupAccountCodePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,19}$`)
+
+// consolidationSectionTypes is the order of a consolidation report's sections.
+var consolidationSectionTypes = []models.AccountType{models.Asset, models.Liability, models.Equity, models.Revenue, models.Expense}
+
+// ConsolidationService keeps the group chart of accounts that each company's accounts map to and
+// the rules that eliminate intercompany balances, and reports the group's consolidated trial
+// balance.
+type ConsolidationService interface {
+	CreateGroupAccount(ctx context.Context, req dto.CreateGroupAccountRequest) (*models.GroupAccount, error)
+	ListGroupAccounts(ctx context.Context) ([]*models.GroupAccount, error)
+	UpdateGroupAccount(ctx context.Context, id uuid.UUID, req dto.UpdateGroupAccountRequest) (*models.GroupAccount, error)
+	ListGroupAccountMappings(ctx context.Context) ([]*models.GroupAccountMapping, error)
+	MapGroupAccount(ctx context.Context, req dto.MapGroupAccountRequest) (*models.GroupAccountMapping, error)
+	UnmapGroupAccount(ctx context.Context, accountID uuid.UUID) error
+	CreateEliminationRule(ctx context.Context, req dto.CreateEliminationRuleRequest) (*models.EliminationRule, error)
+	ListEliminationRules(ctx context.Context) ([]*models.EliminationRule, error)
+	UpdateEliminationRule(ctx context.Context, id uuid.UUID, req dto.UpdateEliminationRuleRequest) (*models.EliminationRule, error)
+	GetConsolidationReport(ctx context.Context, req dto.ConsolidationRequest) (*dto.ConsolidationResponse, error)
+}
+
+// consolidationService is an implementation of ConsolidationService.
+type consolidationService struct {
+	consolidationRepo repository.ConsolidationRepository
+	coaRepo           repository.ChartOfAccountRepository
+	journalRepo       repository.JournalEntryRepository
+	currencyRepo      repository.CurrencyRepository
+	companyRepo       companyRepository.CompanyRepository
+	groupCurrency     string // Presentation currency of reports that do not name one
+}
+
+// NewConsolidationService creates a new ConsolidationService. Reports are presented in
+// groupCurrency unless they ask for another currency.
+func NewConsolidationService(
+	consolidationRepo repository.ConsolidationRepository,
+	coaRepo repository.ChartOfAccountRepository,
+	journalRepo repository.JournalEntryRepository,
+	currencyRepo repository.CurrencyRepository,
+	companyRepo companyRepository.CompanyRepository,
+	groupCurrency string,
+) ConsolidationService {
+	return &consolidationService{
+		consolidationRepo: consolidationRepo,
+		coaRepo:           coaRepo,
+		journalRepo:       journalRepo,
+		currencyRepo:      currencyRepo,
+		companyRepo:       companyRepo,
+		groupCurrency:     strings.ToUpper(groupCurrency),
+	}
+}
+
+func consolidationForbidden(action string) error {
+	return errors.NewForbiddenError(fmt.Sprintf("%s requires one of the roles: %s", action, strings.Join(ConsolidationRoles, ", ")))
+}
+
+func (s *consolidationService) CreateGroupAccount(ctx context.Context, req dto.CreateGroupAccountRequest) (*models.GroupAccount, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create group account %s", req.Code)
+	if !auth.HasAnyRole(ctx, ConsolidationRoles...) {
+		return nil, consolidationForbidden("changing the group chart of accounts")
+	}
+
+	code := strings.TrimSpace(req.Code)
+	if !groupAccountCodePattern.MatchString(code) {
+		return nil, errors.NewValidationError("code must be 1 to 20 letters, digits, dots, dashes or underscores", "code")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, errors.NewValidationError("name is required and must be at most 100 characters", "name")
+	}
+	validType := false
+	for _, at := range consolidationSectionTypes {
+		validType = validType || req.AccountType == at
+	}
+	if !validType {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid account type: %s", req.AccountType), "account_type")
+	}
+	if _, err := s.consolidationRepo.GetGroupAccountByCode(ctx, code); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("group account %s already exists", code))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	created, err := s.consolidationRepo.CreateGroupAccount(ctx, &models.GroupAccount{Code: code, Name: name, AccountType: req.AccountType, IsActive: true})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Successfully created group account %s (%s)", created.Code, created.ID)
+	return created, nil
+}
+
+func (s *consolidationService) ListGroupAccounts(ctx context.Context) ([]*models.GroupAccount, error) {
+	return s.consolidationRepo.ListGroupAccounts(ctx)
+}
+
+// UpdateGroupAccount renames or (de)activates a group account. Nothing new can be mapped to an
+// inactive group account; existing mappings keep consolidating into it.
+func (s *consolidationService) UpdateGroupAccount(ctx context.Context, id uuid.UUID, req dto.UpdateGroupAccountRequest) (*models.GroupAccount, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update group account %s", id)
+	if !auth.HasAnyRole(ctx, ConsolidationRoles...) {
+		return nil, consolidationForbidden("changing the group chart of accounts")
+	}
+
+	account, err := s.consolidationRepo.GetGroupAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 100 {
+			return nil, errors.NewValidationError("name cannot be empty and must be at most 100 characters", "name")
+		}
+		account.Name = name
+	}
+	if req.IsActive != nil {
+		account.IsActive = *req.IsActive
+	}
+	return s.consolidationRepo.UpdateGroupAccount(ctx, account)
+}
+
+// ListGroupAccountMappings lists the active company's explicit mappings. Accounts not listed map
+// to the group account with the same code.
+func (s *consolidationService) ListGroupAccountMappings(ctx context.Context) ([]*models.GroupAccountMapping, error) {
+	return s.consolidationRepo.ListMappings(ctx)
+}
+
+// MapGroupAccount maps one of the active company's accounts to an active group account of the
+// same type.
+func (s *consolidationService) MapGroupAccount(ctx context.Context, req dto.MapGroupAccountRequest) (*models.GroupAccountMapping, error) {
+	logger.InfoLogger.Printf("Service: Attempting to map account %s to group account %s", req.AccountID, req.GroupAccountCode)
+	if !auth.HasAnyRole(ctx, ConsolidationRoles...) {
+		return nil, consolidationForbidden("mapping accounts to the group chart of accounts")
+	}
+
+	if req.AccountID == uuid.Nil {
+		return nil, errors.NewValidationError("account_id is required", "account_id")
+	}
+	account, err := s.coaRepo.GetByID(ctx, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	groupAccount, err := s.activeGroupAccount(ctx, req.GroupAccountCode, "group_account_code")
+	if err != nil {
+		return nil, err
+	}
+	if groupAccount.AccountType != account.AccountType {
+		return nil, errors.NewValidationError(fmt.Sprintf("account %s is %s but group account %s is %s", account.AccountCode, account.AccountType, groupAccount.Code, groupAccount.AccountType), "group_account_code")
+	}
+	return s.consolidationRepo.SaveMapping(ctx, &models.GroupAccountMapping{AccountID: account.ID, GroupAccountID: groupAccount.ID})
+}
+
+// UnmapGroupAccount removes an account's mapping, so it maps by code again.
+func (s *consolidationService) UnmapGroupAccount(ctx context.Context, accountID uuid.UUID) error {
+	logger.InfoLogger.Printf("Service: Attempting to remove the group account mapping of account %s", accountID)
+	if !auth.HasAnyRole(ctx, ConsolidationRoles...) {
+		return consolidationForbidden("mapping accounts to the group chart of accounts")
+	}
+	return s.consolidationRepo.DeleteMapping(ctx, accountID)
+}
+
+// CreateEliminationRule pairs two different group accounts for elimination.
+func (s *consolidationService) CreateEliminationRule(ctx context.Context, req dto.CreateEliminationRuleRequest) (*models.EliminationRule, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create elimination rule %s", req.Name)
+	if !auth.HasAnyRole(ctx, ConsolidationRoles...) {
+		return nil, consolidationForbidden("changing elimination rules")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, errors.NewValidationError("name is required and must be at most 100 characters", "name")
+	}
+	first, err := s.activeGroupAccount(ctx, req.GroupAccountCode, "group_account_code")
+	if err != nil {
+		return nil, err
+	}
+	counter, err := s.activeGroupAccount(ctx, req.CounterGroupAccountCode, "counter_group_account_code")
+	if err != nil {
+		return nil, err
+	}
+	if first.ID == counter.ID {
+		return nil, errors.NewValidationError("an elimination rule needs two different group accounts", "counter_group_account_code")
+	}
+	difference, err := s.activeGroupAccount(ctx, req.DifferenceGroupAccountCode, "difference_group_account_code")
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.consolidationRepo.ListEliminationRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if strings.EqualFold(rule.Name, name) {
+			return nil, errors.NewConflictError(fmt.Sprintf("elimination rule %s already exists", name))
+		}
+	}
+
+	created, err := s.consolidationRepo.CreateEliminationRule(ctx, &models.EliminationRule{
+		Name:                     name,
+		GroupAccountID:           first.ID,
+		CounterGroupAccountID:    counter.ID,
+		DifferenceGroupAccountID: difference.ID,
+		IsActive:                 true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Successfully created elimination rule %s (%s)", created.Name, created.ID)
+	return created, nil
+}
+
+func (s *consolidationService) ListEliminationRules(ctx context.Context) ([]*models.EliminationRule, error) {
+	return s.consolidationRepo.ListEliminationRules(ctx)
+}
+
+// UpdateEliminationRule renames or (de)activates a rule. Inactive rules are not applied.
+func (s *consolidationService) UpdateEliminationRule(ctx context.Context, id uuid.UUID, req dto.UpdateEliminationRuleRequest) (*models.EliminationRule, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update elimination rule %s", id)
+	if !auth.HasAnyRole(ctx, ConsolidationRoles...) {
+		return nil, consolidationForbidden("changing elimination rules")
+	}
+
+	rule, err := s.consolidationRepo.GetEliminationRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 100 {
+			return nil, errors.NewValidationError("name cannot be empty and must be at most 100 characters", "name")
+		}
+		rule.Name = name
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	return s.consolidationRepo.UpdateEliminationRule(ctx, rule)
+}
+
+// activeGroupAccount looks up a group account by code for use in a mapping or rule.
+func (s *consolidationService) activeGroupAccount(ctx context.Context, code, field string) (*models.GroupAccount, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("%s is required", field), field)
+	}
+	account, err := s.consolidationRepo.GetGroupAccountByCode(ctx, code)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("group account %s does not exist", code), field)
+		}
+		return nil, err
+	}
+	if !account.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("group account %s is inactive", code), field)
+	}
+	return account, nil
+}
+
+// consolidatedColumn is one company's balances translated into the group currency.
+type consolidatedColumn struct {
+	company       dto.ConsolidationCompany
+	balances      map[uuid.UUID]money.Amount // Per group account, debits minus credits
+	priorEarnings money.Amount               // Revenue and expenses before the period that were not closed out
+	translation   money.Amount               // What the different rates leave unbalanced
+}
+
+// GetConsolidationReport consolidates the books of the selected companies. Each company's
+// balances are mapped to the group chart and translated into the group currency: balance sheet
+// accounts at the closing rate on the end date, revenue and expenses at the average rate over the
+// period, and revenue and expenses from before the period that were never closed out at the
+// closing rate, as prior-year retained earnings. What the different rates leave unbalanced is the
+// company's currency translation reserve. Year-end closing entries dated within the period are
+// left out, so the period's result shows on the revenue and expense lines. Active elimination
+// rules then clear intercompany balances from the group total.
+func (s *consolidationService) GetConsolidationReport(ctx context.Context, req dto.ConsolidationRequest) (*dto.ConsolidationResponse, error) {
+	if !auth.HasAnyRole(ctx, ConsolidationRoles...) {
+		return nil, consolidationForbidden("consolidation reports")
+	}
+	if req.EndDate.IsZero() {
+		return nil, errors.NewValidationError("end_date is required", "end_date")
+	}
+	startDate := req.StartDate
+	if startDate.IsZero() {
+		startDate = time.Date(req.EndDate.Year(), 1, 1, 0, 0, 0, 0, req.EndDate.Location())
+	}
+	if req.EndDate.Before(startDate) {
+		return nil, errors.NewValidationError("end_date must not be before start_date", "end_date")
+	}
+	currency := s.groupCurrency
+	if strings.TrimSpace(req.Currency) != "" {
+		currency = req.Currency
+	}
+	currency, err := normalizeCurrencyCode(currency)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), "currency")
+	}
+	logger.InfoLogger.Printf("Service: Generating consolidation in %s for %s to %s", currency, startDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"))
+
+	companies, err := s.consolidatedCompanies(ctx, req.CompanyIDs)
+	if err != nil {
+		return nil, err
+	}
+	groupAccounts, err := s.consolidationRepo.ListGroupAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.consolidationRepo.ListEliminationRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []consolidatedColumn
+	var unmapped []string
+	for _, c := range companies {
+		column, missing, err := s.consolidateCompany(ctx, c, currency, startDate, req.EndDate, groupAccounts)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+		unmapped = append(unmapped, missing...)
+	}
+	if len(unmapped) > 0 {
+		if len(unmapped) > maxImportErrors {
+			unmapped = append(unmapped[:maxImportErrors], fmt.Sprintf("and %d more", len(unmapped)-maxImportErrors))
+		}
+		return nil, errors.NewValidationError("accounts with balances are not mapped to the group chart of accounts: "+strings.Join(unmapped, "; "), "group_account_mappings")
+	}
+
+	eliminations := eliminateIntercompany(columns, rules)
+	response := buildConsolidationReport(columns, eliminations, groupAccounts)
+	response.StartDate = startDate
+	response.EndDate = req.EndDate
+	response.Currency = currency
+	logger.InfoLogger.Printf("Service: Successfully generated consolidation of %d companies in %s", len(columns), currency)
+	return response, nil
+}
+
+// consolidatedCompanies returns the requested companies, or every active one, ordered by code.
+func (s *consolidationService) consolidatedCompanies(ctx context.Context, ids []uuid.UUID) ([]*companyModels.Company, error) {
+	active, err := s.companyRepo.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		if len(active) == 0 {
+			return nil, errors.NewValidationError("there are no active companies to consolidate", "company_ids")
+		}
+		return active, nil
+	}
+	requested := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		requested[id] = true
+	}
+	var selected []*companyModels.Company
+	for _, c := range active {
+		if requested[c.ID] {
+			selected = append(selected, c)
+			delete(requested, c.ID)
+		}
+	}
+	for id := range requested {
+		return nil, errors.NewValidationError(fmt.Sprintf("company %s is not an active company", id), "company_ids")
+	}
+	return selected, nil
+}
+
+// consolidateCompany maps and translates one company's balances. It reads the company's books in
+// a context where that company is active, and returns its accounts that have balances but no
+// group account.
+func (s *consolidationService) consolidateCompany(ctx context.Context, c *companyModels.Company, currency string, startDate, endDate time.Time, groupAccounts []*models.GroupAccount) (consolidatedColumn, []string, error) {
+	column := consolidatedColumn{
+		company:  dto.ConsolidationCompany{CompanyID: c.ID, CompanyCode: c.Code, Currency: c.BaseCurrency},
+		balances: make(map[uuid.UUID]money.Amount),
+	}
+	closingRate, averageRate, err := s.translationRates(ctx, c, currency, startDate, endDate)
+	if err != nil {
+		return column, nil, err
+	}
+	column.company.ClosingRate = closingRate
+	column.company.AverageRate = averageRate
+
+	companyCtx := company.WithCompany(ctx, company.Company{ID: c.ID, Code: c.Code, BaseCurrency: c.BaseCurrency})
+	accounts, _, err := s.coaRepo.List(companyCtx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching accounts of company %s for consolidation: %v", c.Code, err)
+		return column, nil, errors.NewInternalServerError(fmt.Sprintf("failed to fetch accounts of company %s", c.Code), err)
+	}
+	mappings, err := s.consolidationRepo.ListMappings(companyCtx)
+	if err != nil {
+		return column, nil, err
+	}
+	entries, err := s.journalRepo.GetJournalEntriesForTrialBalance(companyCtx, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), endOfDay(endDate))
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error fetching journal entries of company %s for consolidation: %v", c.Code, err)
+		return column, nil, err
+	}
+
+	accountTypes := make(map[uuid.UUID]models.AccountType, len(accounts))
+	for _, acc := range accounts {
+		accountTypes[acc.ID] = acc.AccountType
+	}
+	balances := make(map[uuid.UUID]money.Amount) // Debits minus credits per company account
+	priorEarnings := money.Zero
+	for _, entry := range entries {
+		inPeriod := !entry.EntryDate.Before(startDate)
+		if inPeriod && entry.EntryType == models.EntryTypeClosing {
+			continue
+		}
+		for _, line := range entry.JournalLines {
+			signed := line.Amount
+			if !line.IsDebit {
+				signed = signed.Neg()
+			}
+			accountType := accountTypes[line.AccountID]
+			if (accountType == models.Revenue || accountType == models.Expense) && !inPeriod {
+				priorEarnings = priorEarnings.Add(signed)
+				continue
+			}
+			balances[line.AccountID] = balances[line.AccountID].Add(signed)
+		}
+	}
+
+	groupByID := make(map[uuid.UUID]*models.GroupAccount, len(groupAccounts))
+	groupByCode := make(map[string]*models.GroupAccount, len(groupAccounts))
+	for _, ga := range groupAccounts {
+		groupByID[ga.ID] = ga
+		groupByCode[ga.Code] = ga
+	}
+	mapped := make(map[uuid.UUID]*models.GroupAccount, len(mappings))
+	for _, m := range mappings {
+		if ga := groupByID[m.GroupAccountID]; ga != nil {
+			mapped[m.AccountID] = ga
+		}
+	}
+
+	var unmapped []string
+	translated := money.Zero
+	for _, acc := range accounts {
+		balance := balances[acc.ID]
+		if balance.IsZero() {
+			continue
+		}
+		groupAccount := mapped[acc.ID]
+		if groupAccount == nil {
+			groupAccount = groupByCode[acc.AccountCode]
+		}
+		if groupAccount == nil {
+			unmapped = append(unmapped, fmt.Sprintf("%s %s", c.Code, acc.AccountCode))
+			continue
+		}
+		rate := closingRate
+		if acc.AccountType == models.Revenue || acc.AccountType == models.Expense {
+			rate = averageRate
+		}
+		amount := balance.Convert(rate).Round(currency)
+		column.balances[groupAccount.ID] = column.balances[groupAccount.ID].Add(amount)
+		translated = translated.Add(amount)
+	}
+	column.priorEarnings = priorEarnings.Convert(closingRate).Round(currency)
+	column.translation = translated.Add(column.priorEarnings).Neg()
+	return column, unmapped, nil
+}
+
+// translationRates returns the closing and average rates from the company's functional currency
+// into the group currency. The average weighs each rate by the days of the period it applied.
+func (s *consolidationService) translationRates(ctx context.Context, c *companyModels.Company, currency string, startDate, endDate time.Time) (money.Rate, money.Rate, error) {
+	if strings.EqualFold(c.BaseCurrency, currency) {
+		return money.One, money.One, nil
+	}
+	from := strings.ToUpper(c.BaseCurrency)
+	closing, err := s.currencyRepo.GetExchangeRate(ctx, from, currency, endDate)
+	if err != nil {
+		if isNotFoundError(err) {
+			return money.Rate{}, money.Rate{}, errors.NewValidationError(fmt.Sprintf("no %s/%s exchange rate on or before %s to translate company %s", from, currency, endDate.Format("2006-01-02"), c.Code), "currency")
+		}
+		return money.Rate{}, money.Rate{}, err
+	}
+
+	day := func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC) }
+	days := func(from, to time.Time) int64 { return int64(to.Sub(from).Hours() / 24) }
+	start, end := day(startDate), day(endDate).AddDate(0, 0, 1)
+
+	var rates []money.Rate
+	var weights []int64
+	var current *models.ExchangeRate
+	opening, err := s.currencyRepo.GetExchangeRate(ctx, from, currency, startDate)
+	if err == nil {
+		current = opening
+	} else if !isNotFoundError(err) {
+		return money.Rate{}, money.Rate{}, err
+	}
+	changes, err := s.currencyRepo.ListExchangeRates(ctx, from, currency, startDate, endDate)
+	if err != nil {
+		return money.Rate{}, money.Rate{}, err
+	}
+	since := start
+	for _, change := range changes {
+		changedOn := day(change.RateDate)
+		if !changedOn.After(start) {
+			continue // The opening rate already covers the first day
+		}
+		if current != nil {
+			rates = append(rates, current.Rate)
+			weights = append(weights, days(since, changedOn))
+		}
+		current, since = change, changedOn
+	}
+	if current == nil {
+		current = closing
+	}
+	rates = append(rates, current.Rate)
+	weights = append(weights, days(since, end))
+	return closing.Rate, money.WeightedAverageRate(rates, weights), nil
+}
+
+// eliminateIntercompany applies the active rules in name order to the group totals, each rule
+// seeing the eliminations of the rules before it, and returns the eliminations per group account.
+func eliminateIntercompany(columns []consolidatedColumn, rules []*models.EliminationRule) map[uuid.UUID]money.Amount {
+	totals := make(map[uuid.UUID]money.Amount)
+	for _, column := range columns {
+		for id, amount := range column.balances {
+			totals[id] = totals[id].Add(amount)
+		}
+	}
+	eliminations := make(map[uuid.UUID]money.Amount)
+	for _, rule := range rules {
+		if !rule.IsActive {
+			continue
+		}
+		first := totals[rule.GroupAccountID].Add(eliminations[rule.GroupAccountID])
+		counter := totals[rule.CounterGroupAccountID].Add(eliminations[rule.CounterGroupAccountID])
+		eliminations[rule.GroupAccountID] = eliminations[rule.GroupAccountID].Sub(first)
+		eliminations[rule.CounterGroupAccountID] = eliminations[rule.CounterGroupAccountID].Sub(counter)
+		eliminations[rule.DifferenceGroupAccountID] = eliminations[rule.DifferenceGroupAccountID].Add(first.Add(counter))
+	}
+	return eliminations
+}
+
+// buildConsolidationReport lays the columns and eliminations out in sections by account type.
+// Group accounts without any amount are left out.
+func buildConsolidationReport(columns []consolidatedColumn, eliminations map[uuid.UUID]money.Amount, groupAccounts []*models.GroupAccount) *dto.ConsolidationResponse {
+	response := &dto.ConsolidationResponse{Companies: make([]dto.ConsolidationCompany, len(columns))}
+	for i, column := range columns {
+		response.Companies[i] = column.company
+	}
+
+	newLine := func(name string, accountType models.AccountType, amounts []money.Amount, elimination money.Amount) dto.ConsolidationLine {
+		line := dto.ConsolidationLine{GroupAccountName: name, AccountType: accountType, Companies: amounts, Eliminations: elimination, Total: elimination}
+		for _, amount := range amounts {
+			line.Total = line.Total.Add(amount)
+		}
+		return line
+	}
+	isEmpty := func(amounts []money.Amount, elimination money.Amount) bool {
+		for _, amount := range amounts {
+			if !amount.IsZero() {
+				return false
+			}
+		}
+		return elimination.IsZero()
+	}
+
+	sorted := append([]*models.GroupAccount(nil), groupAccounts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Code < sorted[j].Code })
+	sections := make(map[models.AccountType]*dto.ConsolidationSection, len(consolidationSectionTypes))
+	for _, accountType := range consolidationSectionTypes {
+		sections[accountType] = &dto.ConsolidationSection{AccountType: accountType, Lines: []dto.ConsolidationLine{}}
+	}
+	for _, ga := range sorted {
+		section := sections[ga.AccountType]
+		if section == nil {
+			continue
+		}
+		amounts := make([]money.Amount, len(columns))
+		for i, column := range columns {
+			amounts[i] = column.balances[ga.ID]
+		}
+		if isEmpty(amounts, eliminations[ga.ID]) {
+			continue
+		}
+		line := newLine(ga.Name, ga.AccountType, amounts, eliminations[ga.ID])
+		id := ga.ID
+		line.GroupAccountID = &id
+		line.GroupAccountCode = ga.Code
+		section.Lines = append(section.Lines, line)
+	}
+
+	prior := make([]money.Amount, len(columns))
+	translation := make([]money.Amount, len(columns))
+	for i, column := range columns {
+		prior[i] = column.priorEarnings
+		translation[i] = column.translation
+	}
+	equity := sections[models.Equity]
+	if !isEmpty(prior, money.Zero) {
+		equity.Lines = append(equity.Lines, newLine("Retained Earnings (Unclosed Prior Years)", models.Equity, prior, money.Zero))
+	}
+	if !isEmpty(translation, money.Zero) {
+		equity.Lines = append(equity.Lines, newLine("Currency Translation Reserve", models.Equity, translation, money.Zero))
+	}
+
+	netIncome := dto.ConsolidationLine{GroupAccountName: "Net Income", Companies: make([]money.Amount, len(columns))}
+	for _, accountType := range consolidationSectionTypes {
+		section := sections[accountType]
+		section.Companies = make([]money.Amount, len(columns))
+		for _, line := range section.Lines {
+			for i, amount := range line.Companies {
+				section.Companies[i] = section.Companies[i].Add(amount)
+			}
+			section.Eliminations = section.Eliminations.Add(line.Eliminations)
+			section.Total = section.Total.Add(line.Total)
+		}
+		if accountType == models.Revenue || accountType == models.Expense {
+			for i, amount := range section.Companies {
+				netIncome.Companies[i] = netIncome.Companies[i].Sub(amount)
+			}
+			netIncome.Eliminations = netIncome.Eliminations.Sub(section.Eliminations)
+			netIncome.Total = netIncome.Total.Sub(section.Total)
+		}
+		response.Sections = append(response.Sections, *section)
+	}
+	response.NetIncome = netIncome
+	return response
+}
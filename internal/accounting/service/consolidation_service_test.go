@@ -0,0 +1,326 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	companyModels "erp-system/internal/company/models"
+	companyMocks "erp-system/internal/company/repository/mocks"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/company"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// inCompany matches a context in which the given company is active.
+func inCompany(id uuid.UUID) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		active, err := company.IDFromContext(ctx)
+		return err == nil && active == id
+	})
+}
+
+// consolidationFixture is a US parent and a euro subsidiary trading with each other, and the group
+// chart their accounts map to.
+type consolidationFixture struct {
+	us, eu                             *companyModels.Company
+	cash, icReceivable, icPayable      *models.GroupAccount
+	equity, icRevenue, icExpense, diff *models.GroupAccount
+	rules                              []*models.EliminationRule
+}
+
+func newConsolidationFixture() consolidationFixture {
+	group := func(code, name string, accountType models.AccountType) *models.GroupAccount {
+		return &models.GroupAccount{ID: uuid.New(), Code: code, Name: name, AccountType: accountType, IsActive: true}
+	}
+	f := consolidationFixture{
+		us:           &companyModels.Company{ID: uuid.New(), Code: "US01", BaseCurrency: "USD", IsActive: true},
+		eu:           &companyModels.Company{ID: uuid.New(), Code: "EU01", BaseCurrency: "EUR", IsActive: true},
+		cash:         group("1000", "Cash", models.Asset),
+		icReceivable: group("1200", "Intercompany Receivables", models.Asset),
+		icPayable:    group("2200", "Intercompany Payables", models.Liability),
+		equity:       group("3000", "Share Capital", models.Equity),
+		icRevenue:    group("4900", "Intercompany Revenue", models.Revenue),
+		icExpense:    group("5900", "Intercompany Expenses", models.Expense),
+		diff:         group("6999", "Intercompany Differences", models.Expense),
+	}
+	f.rules = []*models.EliminationRule{
+		{ID: uuid.New(), Name: "IC balances", GroupAccountID: f.icReceivable.ID, CounterGroupAccountID: f.icPayable.ID, DifferenceGroupAccountID: f.diff.ID, IsActive: true},
+		{ID: uuid.New(), Name: "IC trading", GroupAccountID: f.icRevenue.ID, CounterGroupAccountID: f.icExpense.ID, DifferenceGroupAccountID: f.diff.ID, IsActive: true},
+		{ID: uuid.New(), Name: "Retired", GroupAccountID: f.cash.ID, CounterGroupAccountID: f.equity.ID, DifferenceGroupAccountID: f.diff.ID, IsActive: false},
+	}
+	return f
+}
+
+func (f consolidationFixture) groupAccounts() []*models.GroupAccount {
+	return []*models.GroupAccount{f.diff, f.cash, f.icReceivable, f.icPayable, f.equity, f.icRevenue, f.icExpense}
+}
+
+func postedEntry(date time.Time, entryType models.JournalEntryType, lines ...models.JournalLine) models.JournalEntry {
+	return models.JournalEntry{ID: uuid.New(), EntryDate: date, Status: models.StatusPosted, EntryType: entryType, JournalLines: lines}
+}
+
+func debit(account *models.ChartOfAccount, amount string) models.JournalLine {
+	return models.JournalLine{AccountID: account.ID, Amount: money.MustParse(amount), IsDebit: true}
+}
+
+func credit(account *models.ChartOfAccount, amount string) models.JournalLine {
+	return models.JournalLine{AccountID: account.ID, Amount: money.MustParse(amount)}
+}
+
+func amounts(values ...string) []money.Amount {
+	out := make([]money.Amount, len(values))
+	for i, v := range values {
+		out[i] = money.MustParse(v)
+	}
+	return out
+}
+
+func TestConsolidationService_GetConsolidationReport(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "controller", Roles: []string{auth.RoleAccountingManager}})
+	f := newConsolidationFixture()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	veryEarly := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	endOfPeriod := end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	usCash := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1000", AccountType: models.Asset}
+	usReceivable := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1200", AccountType: models.Asset}
+	usCapital := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "3000", AccountType: models.Equity}
+	usSales := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "4100", AccountType: models.Revenue} // Mapped explicitly
+	usRetained := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "3900", AccountType: models.Equity}
+	euCash := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1000", AccountType: models.Asset}
+	euPayable := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "2200", AccountType: models.Liability}
+	euCapital := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "3000", AccountType: models.Equity}
+	euServices := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "5900", AccountType: models.Expense}
+
+	usEntries := []models.JournalEntry{
+		postedEntry(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), models.EntryTypeStandard, debit(usCash, "1000"), credit(usCapital, "1000")),
+		// Prior-year sales that were never closed out
+		postedEntry(time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC), models.EntryTypeStandard, debit(usCash, "200"), credit(usSales, "200")),
+		// Intercompany invoice to EU01
+		postedEntry(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), models.EntryTypeStandard, debit(usReceivable, "500"), credit(usSales, "500")),
+		// A year-end close inside the period is left out
+		postedEntry(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), models.EntryTypeClosing, debit(usSales, "500"), credit(usRetained, "500")),
+	}
+	euEntries := []models.JournalEntry{
+		postedEntry(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), models.EntryTypeStandard, debit(euCash, "800"), credit(euCapital, "800")),
+		postedEntry(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), models.EntryTypeStandard, debit(euServices, "450"), credit(euPayable, "450")),
+	}
+
+	newService := func(t *testing.T) (service.ConsolidationService, *mocks.ConsolidationRepository, *mocks.ChartOfAccountRepository, *mocks.JournalEntryRepository, *mocks.CurrencyRepository, *companyMocks.CompanyRepository) {
+		consolidationRepo := mocks.NewConsolidationRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		journalRepo := mocks.NewJournalEntryRepositoryMock(t)
+		currencyRepo := mocks.NewCurrencyRepositoryMock(t)
+		companyRepo := companyMocks.NewCompanyRepositoryMock(t)
+		return service.NewConsolidationService(consolidationRepo, coaRepo, journalRepo, currencyRepo, companyRepo, "usd"), consolidationRepo, coaRepo, journalRepo, currencyRepo, companyRepo
+	}
+
+	t.Run("Success - Translates, Maps And Eliminates", func(t *testing.T) {
+		consolidationService, consolidationRepo, coaRepo, journalRepo, currencyRepo, companyRepo := newService(t)
+		companyRepo.On("List", ctx, true).Return([]*companyModels.Company{f.eu, f.us}, nil).Once()
+		consolidationRepo.On("ListGroupAccounts", ctx).Return(f.groupAccounts(), nil).Once()
+		consolidationRepo.On("ListEliminationRules", ctx).Return(f.rules, nil).Once()
+
+		// EUR into USD: 1.10 until 20 January, 1.20 from 21 January
+		opening := &models.ExchangeRate{FromCurrency: "EUR", ToCurrency: "USD", RateDate: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), Rate: money.MustParseRate("1.10")}
+		closing := &models.ExchangeRate{FromCurrency: "EUR", ToCurrency: "USD", RateDate: time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC), Rate: money.MustParseRate("1.20")}
+		currencyRepo.On("GetExchangeRate", ctx, "EUR", "USD", end).Return(closing, nil).Once()
+		currencyRepo.On("GetExchangeRate", ctx, "EUR", "USD", start).Return(opening, nil).Once()
+		currencyRepo.On("ListExchangeRates", ctx, "EUR", "USD", start, end).Return([]*models.ExchangeRate{closing}, nil).Once()
+
+		coaRepo.On("List", inCompany(f.eu.ID), 0, 0, map[string]interface{}{}).Return([]*models.ChartOfAccount{euCash, euPayable, euCapital, euServices}, int64(4), nil).Once()
+		consolidationRepo.On("ListMappings", inCompany(f.eu.ID)).Return([]*models.GroupAccountMapping{}, nil).Once()
+		journalRepo.On("GetJournalEntriesForTrialBalance", inCompany(f.eu.ID), veryEarly, endOfPeriod).Return(euEntries, nil).Once()
+		coaRepo.On("List", inCompany(f.us.ID), 0, 0, map[string]interface{}{}).Return([]*models.ChartOfAccount{usCash, usReceivable, usCapital, usRetained, usSales}, int64(5), nil).Once()
+		consolidationRepo.On("ListMappings", inCompany(f.us.ID)).Return([]*models.GroupAccountMapping{{AccountID: usSales.ID, GroupAccountID: f.icRevenue.ID}}, nil).Once()
+		journalRepo.On("GetJournalEntriesForTrialBalance", inCompany(f.us.ID), veryEarly, endOfPeriod).Return(usEntries, nil).Once()
+
+		report, err := consolidationService.GetConsolidationReport(ctx, dto.ConsolidationRequest{EndDate: end})
+		require.NoError(t, err)
+		assert.Equal(t, start, report.StartDate, "defaults to the start of the year")
+		assert.Equal(t, "USD", report.Currency)
+		require.Len(t, report.Companies, 2)
+		assert.Equal(t, "EU01", report.Companies[0].CompanyCode)
+		assert.True(t, report.Companies[0].ClosingRate.Equal(money.MustParseRate("1.20")))
+		// 20 days at 1.10 and 11 days at 1.20
+		assert.Equal(t, "1.135483871", report.Companies[0].AverageRate.String())
+		assert.True(t, report.Companies[1].AverageRate.Equal(money.One))
+
+		require.Len(t, report.Sections, 5)
+		assets, liabilities, equity, revenue, expenses := report.Sections[0], report.Sections[1], report.Sections[2], report.Sections[3], report.Sections[4]
+		require.Len(t, assets.Lines, 2)
+		assert.Equal(t, "1000", assets.Lines[0].GroupAccountCode)
+		assert.Equal(t, amounts("960", "1200"), assets.Lines[0].Companies) // EUR 800 at the closing rate
+		assert.Equal(t, money.MustParse("2160"), assets.Lines[0].Total)
+		assert.Equal(t, amounts("0", "500"), assets.Lines[1].Companies)
+		assert.Equal(t, money.MustParse("-500"), assets.Lines[1].Eliminations)
+		assert.True(t, assets.Lines[1].Total.IsZero())
+
+		require.Len(t, liabilities.Lines, 1)
+		assert.Equal(t, amounts("-540", "0"), liabilities.Lines[0].Companies)
+		assert.Equal(t, money.MustParse("540"), liabilities.Lines[0].Eliminations)
+
+		require.Len(t, equity.Lines, 3)
+		assert.Equal(t, amounts("-960", "-1000"), equity.Lines[0].Companies)
+		assert.Nil(t, equity.Lines[1].GroupAccountID)
+		assert.Equal(t, "Retained Earnings (Unclosed Prior Years)", equity.Lines[1].GroupAccountName)
+		assert.Equal(t, amounts("0", "-200"), equity.Lines[1].Companies)
+		assert.Equal(t, "Currency Translation Reserve", equity.Lines[2].GroupAccountName)
+		assert.Equal(t, amounts("29.03", "0"), equity.Lines[2].Companies)
+
+		require.Len(t, revenue.Lines, 1)
+		assert.Equal(t, "4900", revenue.Lines[0].GroupAccountCode)
+		assert.Equal(t, amounts("0", "-500"), revenue.Lines[0].Companies)
+		assert.Equal(t, money.MustParse("500"), revenue.Lines[0].Eliminations)
+
+		require.Len(t, expenses.Lines, 2)
+		assert.Equal(t, "5900", expenses.Lines[0].GroupAccountCode)
+		assert.Equal(t, amounts("510.97", "0"), expenses.Lines[0].Companies) // EUR 450 at the average rate
+		assert.Equal(t, money.MustParse("-510.97"), expenses.Lines[0].Eliminations)
+		assert.Equal(t, "6999", expenses.Lines[1].GroupAccountCode)
+		assert.Equal(t, money.MustParse("-29.03"), expenses.Lines[1].Eliminations, "what the pairs leave goes to the difference account")
+
+		for i := range report.Companies {
+			columnTotal := money.Zero
+			for _, section := range report.Sections {
+				columnTotal = columnTotal.Add(section.Companies[i])
+			}
+			assert.True(t, columnTotal.IsZero(), "column %d adds up to zero", i)
+		}
+		eliminationTotal := money.Zero
+		for _, section := range report.Sections {
+			eliminationTotal = eliminationTotal.Add(section.Eliminations)
+		}
+		assert.True(t, eliminationTotal.IsZero())
+
+		assert.Equal(t, amounts("-510.97", "500"), report.NetIncome.Companies)
+		assert.Equal(t, money.MustParse("40"), report.NetIncome.Eliminations)
+		assert.Equal(t, money.MustParse("29.03"), report.NetIncome.Total)
+	})
+
+	t.Run("Validation Error - Unmapped Account With Balance", func(t *testing.T) {
+		consolidationService, consolidationRepo, coaRepo, journalRepo, _, companyRepo := newService(t)
+		companyRepo.On("List", ctx, true).Return([]*companyModels.Company{f.us}, nil).Once()
+		consolidationRepo.On("ListGroupAccounts", ctx).Return(f.groupAccounts(), nil).Once()
+		consolidationRepo.On("ListEliminationRules", ctx).Return(f.rules, nil).Once()
+		coaRepo.On("List", inCompany(f.us.ID), 0, 0, map[string]interface{}{}).Return([]*models.ChartOfAccount{usCash, usReceivable, usCapital, usRetained, usSales}, int64(5), nil).Once()
+		consolidationRepo.On("ListMappings", inCompany(f.us.ID)).Return([]*models.GroupAccountMapping{}, nil).Once()
+		journalRepo.On("GetJournalEntriesForTrialBalance", inCompany(f.us.ID), veryEarly, endOfPeriod).Return(usEntries, nil).Once()
+
+		_, err := consolidationService.GetConsolidationReport(ctx, dto.ConsolidationRequest{StartDate: start, EndDate: end})
+		require.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "US01 4100")
+	})
+
+	t.Run("Validation Error - Missing Exchange Rate", func(t *testing.T) {
+		consolidationService, consolidationRepo, _, _, currencyRepo, companyRepo := newService(t)
+		companyRepo.On("List", ctx, true).Return([]*companyModels.Company{f.eu, f.us}, nil).Once()
+		consolidationRepo.On("ListGroupAccounts", ctx).Return(f.groupAccounts(), nil).Once()
+		consolidationRepo.On("ListEliminationRules", ctx).Return(f.rules, nil).Once()
+		currencyRepo.On("GetExchangeRate", ctx, "EUR", "GBP", end).Return(nil, app_errors.NewNotFoundError("exchange_rate", "EUR/GBP")).Once()
+
+		_, err := consolidationService.GetConsolidationReport(ctx, dto.ConsolidationRequest{EndDate: end, Currency: "gbp", CompanyIDs: []uuid.UUID{f.eu.ID}})
+		require.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "EUR/GBP")
+	})
+
+	t.Run("Validation Error - Unknown Company", func(t *testing.T) {
+		consolidationService, _, _, _, _, companyRepo := newService(t)
+		companyRepo.On("List", ctx, true).Return([]*companyModels.Company{f.us}, nil).Once()
+		_, err := consolidationService.GetConsolidationReport(ctx, dto.ConsolidationRequest{EndDate: end, CompanyIDs: []uuid.UUID{uuid.New()}})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Forbidden Error - Accountant", func(t *testing.T) {
+		consolidationService, _, _, _, _, _ := newService(t)
+		accountant := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "a1", Roles: []string{auth.RoleAccountant}})
+		_, err := consolidationService.GetConsolidationReport(accountant, dto.ConsolidationRequest{EndDate: end})
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+}
+
+func TestConsolidationService_MapGroupAccount(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "controller", Roles: []string{auth.RoleAdmin}})
+	f := newConsolidationFixture()
+	sales := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "4100", AccountType: models.Revenue}
+
+	newService := func(t *testing.T) (service.ConsolidationService, *mocks.ConsolidationRepository, *mocks.ChartOfAccountRepository) {
+		consolidationRepo := mocks.NewConsolidationRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		return service.NewConsolidationService(consolidationRepo, coaRepo, nil, nil, nil, "USD"), consolidationRepo, coaRepo
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		consolidationService, consolidationRepo, coaRepo := newService(t)
+		coaRepo.On("GetByID", ctx, sales.ID).Return(sales, nil).Once()
+		consolidationRepo.On("GetGroupAccountByCode", ctx, "4900").Return(f.icRevenue, nil).Once()
+		consolidationRepo.On("SaveMapping", ctx, &models.GroupAccountMapping{AccountID: sales.ID, GroupAccountID: f.icRevenue.ID}).
+			Return(&models.GroupAccountMapping{ID: uuid.New(), AccountID: sales.ID, GroupAccountID: f.icRevenue.ID, GroupAccount: f.icRevenue}, nil).Once()
+
+		mapping, err := consolidationService.MapGroupAccount(ctx, dto.MapGroupAccountRequest{AccountID: sales.ID, GroupAccountCode: " 4900 "})
+		require.NoError(t, err)
+		assert.Equal(t, f.icRevenue, mapping.GroupAccount)
+	})
+
+	t.Run("Validation Error - Different Account Type", func(t *testing.T) {
+		consolidationService, consolidationRepo, coaRepo := newService(t)
+		coaRepo.On("GetByID", ctx, sales.ID).Return(sales, nil).Once()
+		consolidationRepo.On("GetGroupAccountByCode", ctx, "5900").Return(f.icExpense, nil).Once()
+
+		_, err := consolidationService.MapGroupAccount(ctx, dto.MapGroupAccountRequest{AccountID: sales.ID, GroupAccountCode: "5900"})
+		require.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "is REVENUE but group account 5900 is EXPENSE")
+	})
+
+	t.Run("Validation Error - Inactive Group Account", func(t *testing.T) {
+		consolidationService, consolidationRepo, coaRepo := newService(t)
+		retired := &models.GroupAccount{ID: uuid.New(), Code: "4999", AccountType: models.Revenue}
+		coaRepo.On("GetByID", ctx, sales.ID).Return(sales, nil).Once()
+		consolidationRepo.On("GetGroupAccountByCode", ctx, "4999").Return(retired, nil).Once()
+
+		_, err := consolidationService.MapGroupAccount(ctx, dto.MapGroupAccountRequest{AccountID: sales.ID, GroupAccountCode: "4999"})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestConsolidationService_CreateEliminationRule(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "controller", Roles: []string{auth.RoleAdmin}})
+	f := newConsolidationFixture()
+
+	t.Run("Success", func(t *testing.T) {
+		consolidationRepo := mocks.NewConsolidationRepositoryMock(t)
+		consolidationService := service.NewConsolidationService(consolidationRepo, nil, nil, nil, nil, "USD")
+		consolidationRepo.On("GetGroupAccountByCode", ctx, "1200").Return(f.icReceivable, nil).Once()
+		consolidationRepo.On("GetGroupAccountByCode", ctx, "2200").Return(f.icPayable, nil).Once()
+		consolidationRepo.On("GetGroupAccountByCode", ctx, "6999").Return(f.diff, nil).Once()
+		consolidationRepo.On("ListEliminationRules", ctx).Return([]*models.EliminationRule{}, nil).Once()
+		consolidationRepo.On("CreateEliminationRule", ctx, mock.AnythingOfType("*models.EliminationRule")).Return(func(_ context.Context, r *models.EliminationRule) *models.EliminationRule { return r }, nil).Once()
+
+		rule, err := consolidationService.CreateEliminationRule(ctx, dto.CreateEliminationRuleRequest{Name: "IC balances", GroupAccountCode: "1200", CounterGroupAccountCode: "2200", DifferenceGroupAccountCode: "6999"})
+		require.NoError(t, err)
+		assert.Equal(t, f.icReceivable.ID, rule.GroupAccountID)
+		assert.Equal(t, f.icPayable.ID, rule.CounterGroupAccountID)
+		assert.Equal(t, f.diff.ID, rule.DifferenceGroupAccountID)
+		assert.True(t, rule.IsActive)
+	})
+
+	t.Run("Validation Error - Same Account Twice", func(t *testing.T) {
+		consolidationRepo := mocks.NewConsolidationRepositoryMock(t)
+		consolidationService := service.NewConsolidationService(consolidationRepo, nil, nil, nil, nil, "USD")
+		consolidationRepo.On("GetGroupAccountByCode", ctx, "1200").Return(f.icReceivable, nil).Twice()
+
+		_, err := consolidationService.CreateEliminationRule(ctx, dto.CreateEliminationRuleRequest{Name: "Broken", GroupAccountCode: "1200", CounterGroupAccountCode: "1200", DifferenceGroupAccountCode: "6999"})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxImportErrors caps how many bad CSV rows are listed in an import's validation error.
+const maxImportErrors = 10
+
+// CurrencyService manages the currency master and the exchange-rate table, and reports account
+// balances per transaction currency.
+type CurrencyService interface {
+	CreateCurrency(ctx context.Context, req dto.CreateCurrencyRequest) (*models.Currency, error)
+	GetCurrency(ctx context.Context, code string) (*models.Currency, error)
+	ListCurrencies(ctx context.Context) ([]*models.Currency, error)
+	UpdateCurrency(ctx context.Context, code string, req dto.UpdateCurrencyRequest) (*models.Currency, error)
+	ListExchangeRates(ctx context.Context, currency string, startDate, endDate time.Time) ([]*models.ExchangeRate, error)
+	ImportExchangeRates(ctx context.Context, r io.Reader) (*dto.ImportExchangeRatesResponse, error)
+	GetCurrencyBalances(ctx context.Context, asOfDate time.Time) (*dto.CurrencyBalanceResponse, error)
+}
+
+// currencyService is an implementation of CurrencyService.
+type currencyService struct {
+	currencyRepo repository.CurrencyRepository
+	journalRepo  repository.JournalEntryRepository
+	coaRepo      repository.ChartOfAccountRepository
+	accounting   AccountingService
+}
+
+// NewCurrencyService creates a new CurrencyService. Rates are kept into the accounting service's
+// base currency, which is the functional currency of every journal line.
+func NewCurrencyService(
+	currencyRepo repository.CurrencyRepository,
+	journalRepo repository.JournalEntryRepository,
+	coaRepo repository.ChartOfAccountRepository,
+	accounting AccountingService,
+) CurrencyService {
+	return &currencyService{currencyRepo: currencyRepo, journalRepo: journalRepo, coaRepo: coaRepo, accounting: accounting}
+}
+
+func (s *currencyService) CreateCurrency(ctx context.Context, req dto.CreateCurrencyRequest) (*models.Currency, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create currency %s", req.Code)
+
+	code, err := normalizeCurrencyCode(req.Code)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	if _, err := s.currencyRepo.GetCurrency(ctx, code); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("currency %s already exists", code))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	currency := &models.Currency{Code: code, Name: name, IsActive: true}
+	if req.IsActive != nil {
+		currency.IsActive = *req.IsActive
+	}
+	created, err := s.currencyRepo.CreateCurrency(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Successfully created currency %s", created.Code)
+	return created, nil
+}
+
+func (s *currencyService) GetCurrency(ctx context.Context, code string) (*models.Currency, error) {
+	return s.currencyRepo.GetCurrency(ctx, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+func (s *currencyService) ListCurrencies(ctx context.Context) ([]*models.Currency, error) {
+	return s.currencyRepo.ListCurrencies(ctx)
+}
+
+// UpdateCurrency renames or (de)activates a currency. Lines already booked in an inactive
+// currency are kept; new lines in it are rejected.
+func (s *currencyService) UpdateCurrency(ctx context.Context, code string, req dto.UpdateCurrencyRequest) (*models.Currency, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update currency %s", code)
+
+	currency, err := s.GetCurrency(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		currency.Name = name
+	}
+	if req.IsActive != nil {
+		currency.IsActive = *req.IsActive
+	}
+	return s.currencyRepo.UpdateCurrency(ctx, currency)
+}
+
+// ListExchangeRates lists the rates from currency (or every currency if empty) into the
+// functional currency, dated within [startDate, endDate]. Zero dates leave that end open.
+func (s *currencyService) ListExchangeRates(ctx context.Context, currency string, startDate, endDate time.Time) ([]*models.ExchangeRate, error) {
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		return nil, errors.NewValidationError("end_date must not be before start_date", "end_date")
+	}
+	return s.currencyRepo.ListExchangeRates(ctx, strings.ToUpper(strings.TrimSpace(currency)), s.accounting.BaseCurrency(ctx), startDate, endDate)
+}
+
+// ImportExchangeRates reads CSV rows of "currency,rate_date,rate", each the value of one unit of
+// currency in the functional currency from rate_date on. The header row is required. Either
+// every row is saved or, if any row is invalid, none is; a rate already held for a currency and
+// date is replaced.
+func (s *currencyService) ImportExchangeRates(ctx context.Context, r io.Reader) (*dto.ImportExchangeRatesResponse, error) {
+	functional := s.accounting.BaseCurrency(ctx)
+	logger.InfoLogger.Printf("Service: Attempting to import exchange rates into %s", functional)
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.NewValidationError("the file is empty", "file")
+	}
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid CSV: %v", err), "file")
+	}
+	for i, want := range []string{"currency", "rate_date", "rate"} {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), want) {
+			return nil, errors.NewValidationError("the header must be currency,rate_date,rate", "file")
+		}
+	}
+
+	known := make(map[string]bool)
+	seen := make(map[string]int)
+	var rates []*models.ExchangeRate
+	var problems []string
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", row, err))
+			continue
+		}
+		rate, err := s.parseRateRecord(ctx, record, functional, known)
+		if err != nil {
+			invalid, ok := err.(*errors.ValidationError)
+			if !ok {
+				return nil, err
+			}
+			problems = append(problems, fmt.Sprintf("row %d: %s", row, invalid.Message))
+			continue
+		}
+		key := rate.FromCurrency + " " + rate.RateDate.Format("2006-01-02")
+		if first, ok := seen[key]; ok {
+			problems = append(problems, fmt.Sprintf("row %d: duplicates row %d", row, first))
+			continue
+		}
+		seen[key] = row
+		rates = append(rates, rate)
+	}
+	if len(problems) > 0 {
+		logger.WarnLogger.Printf("Service: Exchange rate import rejected with %d invalid rows", len(problems))
+		if len(problems) > maxImportErrors {
+			problems = append(problems[:maxImportErrors], fmt.Sprintf("and %d more", len(problems)-maxImportErrors))
+		}
+		return nil, errors.NewValidationError("no rates were imported: "+strings.Join(problems, "; "), "file")
+	}
+	if len(rates) == 0 {
+		return nil, errors.NewValidationError("the file has no rates", "file")
+	}
+
+	if err := s.currencyRepo.SaveExchangeRates(ctx, rates); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Imported %d exchange rates into %s", len(rates), functional)
+	return &dto.ImportExchangeRatesResponse{ToCurrency: functional, Imported: len(rates)}, nil
+}
+
+// parseRateRecord validates one CSV record. known caches the currencies already checked against
+// the master.
+func (s *currencyService) parseRateRecord(ctx context.Context, record []string, functional string, known map[string]bool) (*models.ExchangeRate, error) {
+	code, err := normalizeCurrencyCode(record[0])
+	if err != nil {
+		return nil, err
+	}
+	if code == functional {
+		return nil, errors.NewValidationError(fmt.Sprintf("%s is the functional currency", code), "currency")
+	}
+	if !known[code] {
+		if _, err := s.currencyRepo.GetCurrency(ctx, code); err != nil {
+			if isNotFoundError(err) {
+				return nil, errors.NewValidationError(fmt.Sprintf("unknown currency %s", code), "currency")
+			}
+			return nil, err
+		}
+		known[code] = true
+	}
+	rateDate, err := time.Parse("2006-01-02", strings.TrimSpace(record[1]))
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("rate_date %q is not a YYYY-MM-DD date", record[1]), "rate_date")
+	}
+	rate, err := money.ParseRate(record[2])
+	if err != nil || !rate.IsPositive() {
+		return nil, errors.NewValidationError(fmt.Sprintf("rate %q must be a positive decimal", record[2]), "rate")
+	}
+	return &models.ExchangeRate{FromCurrency: code, ToCurrency: functional, RateDate: rateDate, Rate: rate}, nil
+}
+
+// GetCurrencyBalances reports each account's balance per transaction currency at the end of
+// asOfDate, both in that currency and in the functional currency at the booked rates.
+func (s *currencyService) GetCurrencyBalances(ctx context.Context, asOfDate time.Time) (*dto.CurrencyBalanceResponse, error) {
+	if asOfDate.IsZero() {
+		asOfDate = time.Now()
+	}
+	logger.InfoLogger.Printf("Service: Generating currency balances as of %s", asOfDate.Format("2006-01-02"))
+
+	balances, err := s.journalRepo.GetCurrencyBalances(ctx, endOfDay(asOfDate).Add(time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+	accounts, _, err := s.coaRepo.List(ctx, 0, 0, map[string]interface{}{})
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error listing accounts for currency balances: %v", err)
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.ChartOfAccount, len(accounts))
+	for _, acc := range accounts {
+		byID[acc.ID] = acc
+	}
+
+	response := &dto.CurrencyBalanceResponse{
+		AsOfDate:           asOfDate,
+		FunctionalCurrency: s.accounting.BaseCurrency(ctx),
+		Lines:              []dto.CurrencyBalanceLine{},
+		Totals:             []dto.CurrencyBalanceTotal{},
+	}
+	totals := make(map[string]*dto.CurrencyBalanceTotal)
+	for _, balance := range balances {
+		if balance.TransactionBalance.IsZero() && balance.FunctionalBalance.IsZero() {
+			continue
+		}
+		line := dto.CurrencyBalanceLine{
+			AccountID:          balance.AccountID,
+			Currency:           balance.Currency,
+			TransactionBalance: balance.TransactionBalance,
+			FunctionalBalance:  balance.FunctionalBalance,
+		}
+		if acc, ok := byID[balance.AccountID]; ok {
+			line.AccountCode, line.AccountName, line.AccountType = acc.AccountCode, acc.AccountName, acc.AccountType
+		}
+		response.Lines = append(response.Lines, line)
+
+		total, ok := totals[balance.Currency]
+		if !ok {
+			total = &dto.CurrencyBalanceTotal{Currency: balance.Currency}
+			totals[balance.Currency] = total
+		}
+		total.TransactionBalance = total.TransactionBalance.Add(balance.TransactionBalance)
+		total.FunctionalBalance = total.FunctionalBalance.Add(balance.FunctionalBalance)
+	}
+	sort.Slice(response.Lines, func(i, j int) bool {
+		if response.Lines[i].AccountCode != response.Lines[j].AccountCode {
+			return response.Lines[i].AccountCode < response.Lines[j].AccountCode
+		}
+		return response.Lines[i].Currency < response.Lines[j].Currency
+	})
+	for _, total := range totals {
+		response.Totals = append(response.Totals, *total)
+	}
+	sort.Slice(response.Totals, func(i, j int) bool { return response.Totals[i].Currency < response.Totals[j].Currency })
+	return response, nil
+}
+
+// normalizeCurrencyCode upper-cases code and checks it is three letters.
+func normalizeCurrencyCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", errors.NewValidationError(fmt.Sprintf("currency code %q must be three letters", code), "code")
+	}
+	return code, nil
+}
@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyService_CreateCurrency(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Code Upper-Cased And Active By Default", func(t *testing.T) {
+		currencyRepo := mocks.NewCurrencyRepositoryMock(t)
+		currencyService := service.NewCurrencyService(currencyRepo, nil, nil, &stubAccountingService{})
+		currencyRepo.On("GetCurrency", ctx, "EUR").Return(nil, app_errors.NewNotFoundError("currency", "EUR")).Once()
+		currencyRepo.On("CreateCurrency", ctx, mock.AnythingOfType("*models.Currency")).Return(func(_ context.Context, c *models.Currency) *models.Currency { return c }, nil).Once()
+
+		currency, err := currencyService.CreateCurrency(ctx, dto.CreateCurrencyRequest{Code: " eur", Name: "Euro"})
+		require.NoError(t, err)
+		assert.Equal(t, "EUR", currency.Code)
+		assert.True(t, currency.IsActive)
+	})
+
+	t.Run("Validation Error - Code Not Three Letters", func(t *testing.T) {
+		currencyService := service.NewCurrencyService(mocks.NewCurrencyRepositoryMock(t), nil, nil, &stubAccountingService{})
+		_, err := currencyService.CreateCurrency(ctx, dto.CreateCurrencyRequest{Code: "EU1", Name: "Euro"})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Conflict Error - Code Exists", func(t *testing.T) {
+		currencyRepo := mocks.NewCurrencyRepositoryMock(t)
+		currencyService := service.NewCurrencyService(currencyRepo, nil, nil, &stubAccountingService{})
+		currencyRepo.On("GetCurrency", ctx, "EUR").Return(&models.Currency{Code: "EUR"}, nil).Once()
+		_, err := currencyService.CreateCurrency(ctx, dto.CreateCurrencyRequest{Code: "EUR", Name: "Euro"})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+}
+
+func TestCurrencyService_ImportExchangeRates(t *testing.T) {
+	ctx := context.Background()
+	euro := &models.Currency{Code: "EUR", Name: "Euro", IsActive: true}
+	yen := &models.Currency{Code: "JPY", Name: "Yen", IsActive: true}
+
+	t.Run("Success - Rates Saved Into Functional Currency", func(t *testing.T) {
+		currencyRepo := mocks.NewCurrencyRepositoryMock(t)
+		currencyService := service.NewCurrencyService(currencyRepo, nil, nil, &stubAccountingService{})
+		currencyRepo.On("GetCurrency", ctx, "EUR").Return(euro, nil).Once() // Looked up once per import
+		currencyRepo.On("GetCurrency", ctx, "JPY").Return(yen, nil).Once()
+		currencyRepo.On("SaveExchangeRates", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			rates := args.Get(1).([]*models.ExchangeRate)
+			require.Len(t, rates, 3)
+			assert.Equal(t, "EUR", rates[0].FromCurrency)
+			assert.Equal(t, "USD", rates[0].ToCurrency)
+			assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), rates[0].RateDate)
+			assert.True(t, rates[0].Rate.Equal(money.MustParseRate("1.0825")))
+			assert.Equal(t, "JPY", rates[2].FromCurrency)
+		}).Return(nil).Once()
+
+		csv := "currency,rate_date,rate\nEUR,2026-03-01,1.0825\neur,2026-03-02,1.0831\nJPY,2026-03-01,0.0067114094\n"
+		result, err := currencyService.ImportExchangeRates(ctx, strings.NewReader(csv))
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Imported)
+		assert.Equal(t, "USD", result.ToCurrency)
+	})
+
+	t.Run("Validation Error - Nothing Saved When Any Row Is Invalid", func(t *testing.T) {
+		currencyRepo := mocks.NewCurrencyRepositoryMock(t)
+		currencyService := service.NewCurrencyService(currencyRepo, nil, nil, &stubAccountingService{})
+		currencyRepo.On("GetCurrency", ctx, "EUR").Return(euro, nil).Once()
+		currencyRepo.On("GetCurrency", ctx, "GBP").Return(nil, app_errors.NewNotFoundError("currency", "GBP")).Once()
+
+		csv := "currency,rate_date,rate\n" +
+			"EUR,2026-03-01,1.0825\n" +
+			"GBP,2026-03-01,1.27\n" +
+			"EUR,01/03/2026,1.08\n" +
+			"EUR,2026-03-02,-1\n" +
+			"USD,2026-03-01,1\n" +
+			"EUR,2026-03-01,1.09\n"
+		_, err := currencyService.ImportExchangeRates(ctx, strings.NewReader(csv))
+		require.IsType(t, &app_errors.ValidationError{}, err)
+		for _, want := range []string{
+			"row 3: unknown currency GBP",
+			"row 4: rate_date \"01/03/2026\" is not a YYYY-MM-DD date",
+			"row 5: rate \"-1\" must be a positive decimal",
+			"row 6: USD is the functional currency",
+			"row 7: duplicates row 2",
+		} {
+			assert.Contains(t, err.Error(), want)
+		}
+		currencyRepo.AssertNotCalled(t, "SaveExchangeRates", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Validation Error - Missing Header", func(t *testing.T) {
+		currencyService := service.NewCurrencyService(mocks.NewCurrencyRepositoryMock(t), nil, nil, &stubAccountingService{})
+		_, err := currencyService.ImportExchangeRates(ctx, strings.NewReader("EUR,2026-03-01,1.0825\n"))
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "header must be currency,rate_date,rate")
+	})
+}
+
+func TestCurrencyService_GetCurrencyBalances(t *testing.T) {
+	ctx := context.Background()
+	journalRepo := mocks.NewJournalEntryRepositoryMock(t)
+	coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+	currencyService := service.NewCurrencyService(mocks.NewCurrencyRepositoryMock(t), journalRepo, coaRepo, &stubAccountingService{})
+
+	bank := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1010", AccountName: "Bank EUR", AccountType: models.Asset}
+	receivable := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1200", AccountName: "Receivables", AccountType: models.Asset}
+	asOf := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	journalRepo.On("GetCurrencyBalances", ctx, asOf.AddDate(0, 0, 1)).Return([]models.CurrencyBalance{
+		{AccountID: receivable.ID, Currency: "EUR", TransactionBalance: money.MustParse("200.00"), FunctionalBalance: money.MustParse("216.50")},
+		{AccountID: bank.ID, Currency: "EUR", TransactionBalance: money.MustParse("1000.00"), FunctionalBalance: money.MustParse("1082.50")},
+		{AccountID: receivable.ID, Currency: "USD", TransactionBalance: money.MustParse("50.00"), FunctionalBalance: money.MustParse("50.00")},
+		{AccountID: bank.ID, Currency: "USD", TransactionBalance: money.Zero, FunctionalBalance: money.Zero},
+	}, nil).Once()
+	coaRepo.On("List", ctx, 0, 0, map[string]interface{}{}).Return([]*models.ChartOfAccount{bank, receivable}, int64(2), nil).Once()
+
+	report, err := currencyService.GetCurrencyBalances(ctx, asOf)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", report.FunctionalCurrency)
+	require.Len(t, report.Lines, 3, "Accounts that net to zero in a currency are left out")
+	assert.Equal(t, "1010", report.Lines[0].AccountCode)
+	assert.Equal(t, "1200", report.Lines[1].AccountCode)
+	assert.Equal(t, "EUR", report.Lines[1].Currency)
+	assert.Equal(t, "USD", report.Lines[2].Currency)
+	require.Len(t, report.Totals, 2)
+	assert.Equal(t, dto.CurrencyBalanceTotal{Currency: "EUR", TransactionBalance: money.MustParse("1200.00"), FunctionalBalance: money.MustParse("1299.00")}, report.Totals[0])
+}
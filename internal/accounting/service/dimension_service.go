@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// dimensionCodePattern is what dimension and value codes may look like once upper-cased.
+var dimensionCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{0,29}$`)
+
+// DimensionService manages analytical dimensions (cost centers, departments, projects, ...), their
+// value lists and the dimensions each account requires on its journal lines.
+type DimensionService interface {
+	CreateDimension(ctx context.Context, req dto.CreateDimensionRequest) (*models.Dimension, error)
+	GetDimension(ctx context.Context, id uuid.UUID) (*models.Dimension, error)
+	ListDimensions(ctx context.Context) ([]*models.Dimension, error)
+	UpdateDimension(ctx context.Context, id uuid.UUID, req dto.UpdateDimensionRequest) (*models.Dimension, error)
+	CreateDimensionValue(ctx context.Context, dimensionID uuid.UUID, req dto.CreateDimensionValueRequest) (*models.DimensionValue, error)
+	UpdateDimensionValue(ctx context.Context, dimensionID, valueID uuid.UUID, req dto.UpdateDimensionValueRequest) (*models.DimensionValue, error)
+	GetRequiredDimensions(ctx context.Context, accountID uuid.UUID) ([]*models.Dimension, error)
+	SetRequiredDimensions(ctx context.Context, accountID uuid.UUID, req dto.SetRequiredDimensionsRequest) ([]*models.Dimension, error)
+}
+
+// dimensionService is an implementation of DimensionService.
+type dimensionService struct {
+	dimensionRepo repository.DimensionRepository
+	coaRepo       repository.ChartOfAccountRepository
+}
+
+// NewDimensionService creates a new DimensionService.
+func NewDimensionService(dimensionRepo repository.DimensionRepository, coaRepo repository.ChartOfAccountRepository) DimensionService {
+	return &dimensionService{dimensionRepo: dimensionRepo, coaRepo: coaRepo}
+}
+
+func (s *dimensionService) CreateDimension(ctx context.Context, req dto.CreateDimensionRequest) (*models.Dimension, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create dimension %s", req.Code)
+
+	code, err := normalizeDimensionCode(req.Code, "code")
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	if _, err := s.dimensionRepo.GetDimensionByCode(ctx, code); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("dimension %s already exists", code))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	dimension := &models.Dimension{Code: code, Name: name, IsActive: true, Values: []models.DimensionValue{}}
+	if req.IsActive != nil {
+		dimension.IsActive = *req.IsActive
+	}
+	created, err := s.dimensionRepo.CreateDimension(ctx, dimension)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Successfully created dimension %s", created.Code)
+	return created, nil
+}
+
+func (s *dimensionService) GetDimension(ctx context.Context, id uuid.UUID) (*models.Dimension, error) {
+	return s.dimensionRepo.GetDimension(ctx, id)
+}
+
+func (s *dimensionService) ListDimensions(ctx context.Context) ([]*models.Dimension, error) {
+	return s.dimensionRepo.ListDimensions(ctx)
+}
+
+// UpdateDimension renames or (de)activates a dimension. Lines already tagged with an inactive
+// dimension keep their tags; new tags are rejected and accounts stop requiring it.
+func (s *dimensionService) UpdateDimension(ctx context.Context, id uuid.UUID, req dto.UpdateDimensionRequest) (*models.Dimension, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update dimension %s", id)
+
+	dimension, err := s.dimensionRepo.GetDimension(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		dimension.Name = name
+	}
+	if req.IsActive != nil {
+		dimension.IsActive = *req.IsActive
+	}
+	return s.dimensionRepo.UpdateDimension(ctx, dimension)
+}
+
+func (s *dimensionService) CreateDimensionValue(ctx context.Context, dimensionID uuid.UUID, req dto.CreateDimensionValueRequest) (*models.DimensionValue, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create value %s of dimension %s", req.Code, dimensionID)
+
+	dimension, err := s.dimensionRepo.GetDimension(ctx, dimensionID)
+	if err != nil {
+		return nil, err
+	}
+	code, err := normalizeDimensionCode(req.Code, "code")
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	if findDimensionValue(dimension, code) != nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("dimension %s already has a value %s", dimension.Code, code))
+	}
+
+	value := &models.DimensionValue{DimensionID: dimension.ID, Code: code, Name: name, IsActive: true}
+	if req.IsActive != nil {
+		value.IsActive = *req.IsActive
+	}
+	return s.dimensionRepo.CreateValue(ctx, value)
+}
+
+func (s *dimensionService) UpdateDimensionValue(ctx context.Context, dimensionID, valueID uuid.UUID, req dto.UpdateDimensionValueRequest) (*models.DimensionValue, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update value %s of dimension %s", valueID, dimensionID)
+
+	value, err := s.dimensionRepo.GetValue(ctx, valueID)
+	if err != nil {
+		return nil, err
+	}
+	if value.DimensionID != dimensionID {
+		return nil, errors.NewNotFoundError("dimension_value", valueID.String())
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		value.Name = name
+	}
+	if req.IsActive != nil {
+		value.IsActive = *req.IsActive
+	}
+	return s.dimensionRepo.UpdateValue(ctx, value)
+}
+
+// GetRequiredDimensions lists the dimensions every journal line on the account must carry.
+func (s *dimensionService) GetRequiredDimensions(ctx context.Context, accountID uuid.UUID) ([]*models.Dimension, error) {
+	if _, err := s.coaRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	rules, err := s.dimensionRepo.ListAccountRules(ctx, []uuid.UUID{accountID})
+	if err != nil {
+		return nil, err
+	}
+	dimensions, err := s.dimensionRepo.ListDimensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	requiredIDs := make(map[uuid.UUID]bool, len(rules))
+	for _, rule := range rules {
+		requiredIDs[rule.DimensionID] = true
+	}
+	required := []*models.Dimension{}
+	for _, dim := range dimensions {
+		if requiredIDs[dim.ID] {
+			required = append(required, dim)
+		}
+	}
+	return required, nil
+}
+
+// SetRequiredDimensions replaces the dimensions required on the account. It only affects lines
+// created or edited afterwards.
+func (s *dimensionService) SetRequiredDimensions(ctx context.Context, accountID uuid.UUID, req dto.SetRequiredDimensionsRequest) ([]*models.Dimension, error) {
+	logger.InfoLogger.Printf("Service: Attempting to set required dimensions on account %s", accountID)
+
+	if _, err := s.coaRepo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	dimensionIDs := make([]uuid.UUID, 0, len(req.DimensionCodes))
+	for _, rawCode := range req.DimensionCodes {
+		code := strings.ToUpper(strings.TrimSpace(rawCode))
+		dim, err := s.dimensionRepo.GetDimensionByCode(ctx, code)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, errors.NewValidationError(fmt.Sprintf("unknown dimension %s", code), "dimension_codes")
+			}
+			return nil, err
+		}
+		if !dim.IsActive {
+			return nil, errors.NewValidationError(fmt.Sprintf("dimension %s is not active", code), "dimension_codes")
+		}
+		dimensionIDs = append(dimensionIDs, dim.ID)
+	}
+	if err := s.dimensionRepo.SetAccountRules(ctx, accountID, uniqueIDs(dimensionIDs)); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Account %s now requires %d dimensions", accountID, len(dimensionIDs))
+	return s.GetRequiredDimensions(ctx, accountID)
+}
+
+// normalizeDimensionCode upper-cases a dimension or value code and checks its format.
+func normalizeDimensionCode(code, field string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !dimensionCodePattern.MatchString(code) {
+		return "", errors.NewValidationError("code must be 1 to 30 letters, digits, '-' or '_'", field)
+	}
+	return code, nil
+}
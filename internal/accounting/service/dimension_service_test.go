@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	app_errors "erp-system/pkg/errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDimensionService_CreateDimension(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Code Upper-Cased And Active By Default", func(t *testing.T) {
+		dimensionRepo := mocks.NewDimensionRepositoryMock(t)
+		dimensionService := service.NewDimensionService(dimensionRepo, nil)
+		dimensionRepo.On("GetDimensionByCode", ctx, "COST_CENTER").Return(nil, app_errors.NewNotFoundError("dimension_code", "COST_CENTER")).Once()
+		dimensionRepo.On("CreateDimension", ctx, mock.AnythingOfType("*models.Dimension")).Return(func(_ context.Context, d *models.Dimension) *models.Dimension { return d }, nil).Once()
+
+		dimension, err := dimensionService.CreateDimension(ctx, dto.CreateDimensionRequest{Code: " cost_center", Name: "Cost center"})
+		require.NoError(t, err)
+		assert.Equal(t, "COST_CENTER", dimension.Code)
+		assert.True(t, dimension.IsActive)
+	})
+
+	t.Run("Validation Error - Invalid Code", func(t *testing.T) {
+		dimensionService := service.NewDimensionService(mocks.NewDimensionRepositoryMock(t), nil)
+		_, err := dimensionService.CreateDimension(ctx, dto.CreateDimensionRequest{Code: "cost center", Name: "Cost center"})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Conflict Error - Code Exists", func(t *testing.T) {
+		dimensionRepo := mocks.NewDimensionRepositoryMock(t)
+		dimensionService := service.NewDimensionService(dimensionRepo, nil)
+		dimensionRepo.On("GetDimensionByCode", ctx, "PROJECT").Return(&models.Dimension{Code: "PROJECT"}, nil).Once()
+		_, err := dimensionService.CreateDimension(ctx, dto.CreateDimensionRequest{Code: "project", Name: "Project"})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+}
+
+func TestDimensionService_CreateDimensionValue(t *testing.T) {
+	ctx := context.Background()
+	department := &models.Dimension{ID: uuid.New(), Code: "DEPARTMENT", IsActive: true, Values: []models.DimensionValue{{Code: "SALES"}}}
+
+	t.Run("Success", func(t *testing.T) {
+		dimensionRepo := mocks.NewDimensionRepositoryMock(t)
+		dimensionService := service.NewDimensionService(dimensionRepo, nil)
+		dimensionRepo.On("GetDimension", ctx, department.ID).Return(department, nil).Once()
+		dimensionRepo.On("CreateValue", ctx, mock.AnythingOfType("*models.DimensionValue")).Return(func(_ context.Context, v *models.DimensionValue) *models.DimensionValue { return v }, nil).Once()
+
+		value, err := dimensionService.CreateDimensionValue(ctx, department.ID, dto.CreateDimensionValueRequest{Code: "ops", Name: "Operations"})
+		require.NoError(t, err)
+		assert.Equal(t, "OPS", value.Code)
+		assert.Equal(t, department.ID, value.DimensionID)
+	})
+
+	t.Run("Conflict Error - Value Exists", func(t *testing.T) {
+		dimensionRepo := mocks.NewDimensionRepositoryMock(t)
+		dimensionService := service.NewDimensionService(dimensionRepo, nil)
+		dimensionRepo.On("GetDimension", ctx, department.ID).Return(department, nil).Once()
+		_, err := dimensionService.CreateDimensionValue(ctx, department.ID, dto.CreateDimensionValueRequest{Code: "Sales", Name: "Sales"})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Contains(t, err.Error(), "dimension DEPARTMENT already has a value SALES")
+	})
+}
+
+func TestDimensionService_SetRequiredDimensions(t *testing.T) {
+	ctx := context.Background()
+	account := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "6000", AccountType: models.Expense, IsActive: true}
+	department := &models.Dimension{ID: uuid.New(), Code: "DEPARTMENT", IsActive: true}
+	project := &models.Dimension{ID: uuid.New(), Code: "PROJECT", IsActive: true}
+
+	t.Run("Success - Rules Replaced", func(t *testing.T) {
+		dimensionRepo := mocks.NewDimensionRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		dimensionService := service.NewDimensionService(dimensionRepo, coaRepo)
+		coaRepo.On("GetByID", ctx, account.ID).Return(account, nil).Twice()
+		dimensionRepo.On("GetDimensionByCode", ctx, "DEPARTMENT").Return(department, nil).Twice()
+		dimensionRepo.On("SetAccountRules", ctx, account.ID, []uuid.UUID{department.ID}).Return(nil).Once()
+		dimensionRepo.On("ListAccountRules", ctx, []uuid.UUID{account.ID}).Return([]models.AccountDimensionRule{{AccountID: account.ID, DimensionID: department.ID}}, nil).Once()
+		dimensionRepo.On("ListDimensions", ctx).Return([]*models.Dimension{department, project}, nil).Once()
+
+		required, err := dimensionService.SetRequiredDimensions(ctx, account.ID, dto.SetRequiredDimensionsRequest{DimensionCodes: []string{"department", "DEPARTMENT"}})
+		require.NoError(t, err)
+		require.Len(t, required, 1)
+		assert.Equal(t, "DEPARTMENT", required[0].Code)
+	})
+
+	t.Run("Validation Error - Unknown Dimension", func(t *testing.T) {
+		dimensionRepo := mocks.NewDimensionRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		dimensionService := service.NewDimensionService(dimensionRepo, coaRepo)
+		coaRepo.On("GetByID", ctx, account.ID).Return(account, nil).Once()
+		dimensionRepo.On("GetDimensionByCode", ctx, "REGION").Return(nil, app_errors.NewNotFoundError("dimension_code", "REGION")).Once()
+
+		_, err := dimensionService.SetRequiredDimensions(ctx, account.ID, dto.SetRequiredDimensionsRequest{DimensionCodes: []string{"region"}})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "unknown dimension REGION")
+		dimensionRepo.AssertNotCalled(t, "SetAccountRules", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DocumentSequenceRoles are the roles allowed to change how documents are numbered.
+var DocumentSequenceRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+// maxDocumentSequencePadding keeps document numbers within their 50 characters.
+const maxDocumentSequencePadding = 12
+
+var (
+	documentTypePattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,49}$`)
+	documentPrefixPattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+)
+
+// DocumentSequenceService configures the gap-free numbering of documents, such as the journal
+// entry numbers given on posting. Other modules number their own documents the same way by
+// calling repository.NextDocumentNumber with their document type.
+type DocumentSequenceService interface {
+	ListDocumentSequences(ctx context.Context) ([]*models.DocumentSequence, error)
+	GetDocumentSequence(ctx context.Context, documentType string) (*dto.DocumentSequenceResponse, error)
+	UpdateDocumentSequence(ctx context.Context, documentType string, req dto.UpdateDocumentSequenceRequest) (*models.DocumentSequence, error)
+}
+
+// documentSequenceService is an implementation of DocumentSequenceService.
+type documentSequenceService struct {
+	sequenceRepo repository.DocumentSequenceRepository
+}
+
+// NewDocumentSequenceService creates a new DocumentSequenceService.
+func NewDocumentSequenceService(sequenceRepo repository.DocumentSequenceRepository) DocumentSequenceService {
+	return &documentSequenceService{sequenceRepo: sequenceRepo}
+}
+
+func (s *documentSequenceService) ListDocumentSequences(ctx context.Context) ([]*models.DocumentSequence, error) {
+	return s.sequenceRepo.ListSequences(ctx)
+}
+
+func (s *documentSequenceService) GetDocumentSequence(ctx context.Context, documentType string) (*dto.DocumentSequenceResponse, error) {
+	documentType = strings.ToUpper(strings.TrimSpace(documentType))
+	sequence, err := s.sequenceRepo.GetSequence(ctx, documentType)
+	if err != nil {
+		return nil, err
+	}
+	counters, err := s.sequenceRepo.ListCounters(ctx, documentType)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.DocumentSequenceResponse{DocumentSequence: sequence, Counters: counters}, nil
+}
+
+// UpdateDocumentSequence creates or changes the sequence of documentType. The prefix and padding
+// may change at any time and apply to the next number; whether the sequence resets yearly is
+// fixed once it has issued numbers, as changing it would restart the count.
+func (s *documentSequenceService) UpdateDocumentSequence(ctx context.Context, documentType string, req dto.UpdateDocumentSequenceRequest) (*models.DocumentSequence, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update document sequence %s", documentType)
+	if !auth.HasAnyRole(ctx, DocumentSequenceRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("changing document sequences requires one of the roles: %s", strings.Join(DocumentSequenceRoles, ", ")))
+	}
+
+	documentType = strings.ToUpper(strings.TrimSpace(documentType))
+	if !documentTypePattern.MatchString(documentType) {
+		return nil, errors.NewValidationError("document type must be up to 50 letters, digits or underscores, starting with a letter", "document_type")
+	}
+	prefix := strings.ToUpper(strings.TrimSpace(req.Prefix))
+	if len(prefix) > 20 || !documentPrefixPattern.MatchString(prefix) {
+		return nil, errors.NewValidationError("prefix must be 1 to 20 letters or digits", "prefix")
+	}
+	padding := req.Padding
+	if padding == 0 {
+		padding = models.DefaultDocumentSequencePadding
+	}
+	if padding < 1 || padding > maxDocumentSequencePadding {
+		return nil, errors.NewValidationError(fmt.Sprintf("padding must be between 1 and %d", maxDocumentSequencePadding), "padding")
+	}
+
+	resetYearly := true
+	existing, err := s.sequenceRepo.GetSequence(ctx, documentType)
+	if err == nil {
+		resetYearly = existing.ResetYearly
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+	if req.ResetYearly != nil && *req.ResetYearly != resetYearly {
+		counters, err := s.sequenceRepo.ListCounters(ctx, documentType)
+		if err != nil {
+			return nil, err
+		}
+		if len(counters) > 0 {
+			return nil, errors.NewConflictError(fmt.Sprintf("document sequence %s has issued numbers; reset_yearly can no longer be changed", documentType))
+		}
+		resetYearly = *req.ResetYearly
+	}
+
+	saved, err := s.sequenceRepo.SaveSequence(ctx, &models.DocumentSequence{
+		DocumentType: documentType,
+		Prefix:       prefix,
+		Padding:      padding,
+		ResetYearly:  resetYearly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Document sequence %s now numbers as %s", saved.DocumentType, saved.Format(time.Now().Year(), 1))
+	return saved, nil
+}
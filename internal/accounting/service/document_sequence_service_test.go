@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	app_errors "erp-system/pkg/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentSequenceService_UpdateDocumentSequence(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "admin", Roles: []string{auth.RoleAdmin}})
+	resetYearly := false
+
+	t.Run("Success - New Sequence Defaults", func(t *testing.T) {
+		sequenceRepo := mocks.NewDocumentSequenceRepositoryMock(t)
+		sequenceService := service.NewDocumentSequenceService(sequenceRepo)
+		sequenceRepo.On("GetSequence", ctx, "SALES_INVOICE").Return(nil, app_errors.NewNotFoundError("document_sequence", "SALES_INVOICE")).Once()
+		sequenceRepo.On("SaveSequence", ctx, mock.AnythingOfType("*models.DocumentSequence")).Return(func(_ context.Context, s *models.DocumentSequence) *models.DocumentSequence { return s }, nil).Once()
+
+		sequence, err := sequenceService.UpdateDocumentSequence(ctx, " sales_invoice", dto.UpdateDocumentSequenceRequest{Prefix: "inv"})
+		require.NoError(t, err)
+		assert.Equal(t, "INV", sequence.Prefix)
+		assert.Equal(t, models.DefaultDocumentSequencePadding, sequence.Padding)
+		assert.True(t, sequence.ResetYearly)
+		assert.Equal(t, "INV-2026-000042", sequence.Format(2026, 42))
+	})
+
+	t.Run("Success - Reset Yearly Changed Before First Number", func(t *testing.T) {
+		sequenceRepo := mocks.NewDocumentSequenceRepositoryMock(t)
+		sequenceService := service.NewDocumentSequenceService(sequenceRepo)
+		sequenceRepo.On("GetSequence", ctx, "SALES_INVOICE").Return(&models.DocumentSequence{DocumentType: "SALES_INVOICE", Prefix: "INV", Padding: 6, ResetYearly: true}, nil).Once()
+		sequenceRepo.On("ListCounters", ctx, "SALES_INVOICE").Return([]*models.DocumentSequenceCounter{}, nil).Once()
+		sequenceRepo.On("SaveSequence", ctx, mock.AnythingOfType("*models.DocumentSequence")).Return(func(_ context.Context, s *models.DocumentSequence) *models.DocumentSequence { return s }, nil).Once()
+
+		sequence, err := sequenceService.UpdateDocumentSequence(ctx, "SALES_INVOICE", dto.UpdateDocumentSequenceRequest{Prefix: "INV", Padding: 8, ResetYearly: &resetYearly})
+		require.NoError(t, err)
+		assert.Equal(t, "INV-00000042", sequence.Format(2026, 42))
+	})
+
+	t.Run("Conflict Error - Reset Yearly Fixed Once Numbers Issued", func(t *testing.T) {
+		sequenceRepo := mocks.NewDocumentSequenceRepositoryMock(t)
+		sequenceService := service.NewDocumentSequenceService(sequenceRepo)
+		sequenceRepo.On("GetSequence", ctx, models.DocumentTypeJournalStandard).Return(&models.DocumentSequence{DocumentType: models.DocumentTypeJournalStandard, Prefix: "GJ", Padding: 6, ResetYearly: true}, nil).Once()
+		sequenceRepo.On("ListCounters", ctx, models.DocumentTypeJournalStandard).Return([]*models.DocumentSequenceCounter{{DocumentType: models.DocumentTypeJournalStandard, Year: 2026, LastNumber: 12}}, nil).Once()
+
+		_, err := sequenceService.UpdateDocumentSequence(ctx, models.DocumentTypeJournalStandard, dto.UpdateDocumentSequenceRequest{Prefix: "GJ", ResetYearly: &resetYearly})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Validation Error - Prefix With Separator", func(t *testing.T) {
+		sequenceService := service.NewDocumentSequenceService(mocks.NewDocumentSequenceRepositoryMock(t))
+		_, err := sequenceService.UpdateDocumentSequence(ctx, "SALES_INVOICE", dto.UpdateDocumentSequenceRequest{Prefix: "IN-V"})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Forbidden Error - Accountant", func(t *testing.T) {
+		accountantCtx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "a1", Roles: []string{auth.RoleAccountant}})
+		sequenceService := service.NewDocumentSequenceService(mocks.NewDocumentSequenceRepositoryMock(t))
+		_, err := sequenceService.UpdateDocumentSequence(accountantCtx, "SALES_INVOICE", dto.UpdateDocumentSequenceRequest{Prefix: "INV"})
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"erp-system/internal/accounting/models"
+	"erp-system/pkg/money"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,30 +12,101 @@ import (
 
 // CreateChartOfAccountRequest defines the structure for creating a new chart of account.
 type CreateChartOfAccountRequest struct {
-	AccountCode     string               `json:"account_code" binding:"required,min=1,max=20"`
-	AccountName     string               `json:"account_name" binding:"required,min=1,max=100"`
-	AccountType     models.AccountType   `json:"account_type" binding:"required"` // Should be validated against enum values
-	ParentAccountID *uuid.UUID           `json:"parent_account_id,omitempty"`
-	IsActive        bool                 `json:"is_active"` // Defaults to true if omitted by user, handled by service/model
+	AccountCode      string                  `json:"account_code" binding:"required,min=1,max=20"`
+	AccountName      string                  `json:"account_name" binding:"required,min=1,max=100"`
+	AccountType      models.AccountType      `json:"account_type" binding:"required"` // Should be validated against enum values
+	ParentAccountID  *uuid.UUID              `json:"parent_account_id,omitempty"`
+	IsActive         bool                    `json:"is_active"`                    // Defaults to true if omitted by user, handled by service/model
+	CashFlowCategory models.CashFlowCategory `json:"cash_flow_category,omitempty"` // Optional; balance sheet accounts only
 }
 
 // UpdateChartOfAccountRequest defines the structure for updating an existing chart of account.
 // Pointers are used to distinguish between a field not being provided and a field being set to its zero value.
 type UpdateChartOfAccountRequest struct {
-	AccountName     *string              `json:"account_name,omitempty" binding:"omitempty,min=1,max=100"`
-	AccountType     *models.AccountType  `json:"account_type,omitempty"` // Should be validated against enum values
-	ParentAccountID *uuid.UUID           `json:"parent_account_id,omitempty"` // Allows setting to null by passing explicit null or omitting, or changing
-	IsActive        *bool                `json:"is_active,omitempty"`
+	AccountName      *string                  `json:"account_name,omitempty" binding:"omitempty,min=1,max=100"`
+	AccountType      *models.AccountType      `json:"account_type,omitempty"`      // Should be validated against enum values
+	ParentAccountID  *uuid.UUID               `json:"parent_account_id,omitempty"` // Allows setting to null by passing explicit null or omitting, or changing
+	IsActive         *bool                    `json:"is_active,omitempty"`
+	CashFlowCategory *models.CashFlowCategory `json:"cash_flow_category,omitempty"` // Empty string clears the classification
 }
 
 // ListChartOfAccountsRequest defines parameters for listing chart of accounts.
 type ListChartOfAccountsRequest struct {
-	Page          int                `form:"page,default=1"`
-	Limit         int                `form:"limit,default=20"`
-	AccountName   string             `form:"account_name,omitempty"`
-	AccountType   models.AccountType `form:"account_type,omitempty"`
-	IsActive      *bool              `form:"is_active,omitempty"` // Pointer to differentiate not set, true, false
-	// Add other filter fields like ParentAccountID, etc. if needed
+	Page            int                `form:"page,default=1"`
+	Limit           int                `form:"limit,default=20"`
+	AccountName     string             `form:"account_name,omitempty"`
+	AccountType     models.AccountType `form:"account_type,omitempty"`
+	IsActive        *bool              `form:"is_active,omitempty"`         // Pointer to differentiate not set, true, false
+	ParentAccountID *uuid.UUID         `form:"parent_account_id,omitempty"` // Direct children of this account
+}
+
+// MoveChartOfAccountRequest moves an account, with all accounts below it, under a new parent.
+type MoveChartOfAccountRequest struct {
+	ParentAccountID *uuid.UUID `json:"parent_account_id"` // Null or omitted makes the account a top-level account
+}
+
+// MergeChartOfAccountRequest merges the account in the path into TargetAccountID.
+type MergeChartOfAccountRequest struct {
+	TargetAccountID uuid.UUID `json:"target_account_id" binding:"required"`
+	Reason          string    `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// ChartOfAccountTreeNode is an account in the chart of accounts tree. Balances are debits minus
+// credits as of the tree's date, so a credit balance is negative, as in GetAccountBalance.
+type ChartOfAccountTreeNode struct {
+	AccountID     uuid.UUID                 `json:"account_id"`
+	AccountCode   string                    `json:"account_code"`
+	AccountName   string                    `json:"account_name"`
+	AccountType   models.AccountType        `json:"account_type"`
+	IsActive      bool                      `json:"is_active"`
+	Balance       money.Amount              `json:"balance"`        // Lines posted to this account itself
+	RollupBalance money.Amount              `json:"rollup_balance"` // Balance plus the roll-up balances of all children
+	Children      []*ChartOfAccountTreeNode `json:"children"`
+}
+
+// ChartOfAccountTreeResponse is the chart of accounts as a tree of top-level accounts, ordered by code.
+type ChartOfAccountTreeResponse struct {
+	AsOfDate time.Time                 `json:"as_of_date"`
+	Accounts []*ChartOfAccountTreeNode `json:"accounts"`
+}
+
+// ChartOfAccountRecord is one account of an exported or imported chart of accounts, or of a chart
+// template. The parent is named by its account code, so a chart can move between databases.
+type ChartOfAccountRecord struct {
+	AccountCode      string                  `json:"account_code"`
+	AccountName      string                  `json:"account_name"`
+	AccountType      models.AccountType      `json:"account_type"`
+	ParentCode       string                  `json:"parent_code,omitempty"`
+	IsActive         *bool                   `json:"is_active,omitempty"` // Omitted means active
+	CashFlowCategory models.CashFlowCategory `json:"cash_flow_category,omitempty"`
+	Description      string                  `json:"description,omitempty"`
+}
+
+// ChartImportProblem is a record that an import cannot create. Row is the CSV line number, or the
+// 1-based position of the record in a JSON array.
+type ChartImportProblem struct {
+	Row         int    `json:"row"`
+	AccountCode string `json:"account_code,omitempty"`
+	Message     string `json:"message"`
+}
+
+// ImportChartOfAccountsResponse is the validation report of a chart of accounts import. A dry run
+// reports every problem and creates nothing; otherwise Created counts the accounts saved.
+type ImportChartOfAccountsResponse struct {
+	DryRun   bool                 `json:"dry_run"`
+	Valid    bool                 `json:"valid"`
+	Accounts int                  `json:"accounts"` // Records read from the file
+	Created  int                  `json:"created"`
+	Problems []ChartImportProblem `json:"problems"`
+}
+
+// ChartTemplateResponse describes a bundled chart of accounts template. Accounts is only filled
+// in when a single template is requested.
+type ChartTemplateResponse struct {
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	AccountCount int                    `json:"account_count"`
+	Accounts     []ChartOfAccountRecord `json:"accounts,omitempty"`
 }
 
 
@@ -42,68 +114,330 @@ type ListChartOfAccountsRequest struct {
 
 // JournalLineRequest defines a line item within a journal entry request.
 type JournalLineRequest struct {
-	ID        uuid.UUID `json:"id,omitempty"` // Used for updates if lines can be individually identified
-	AccountID uuid.UUID `json:"account_id" binding:"required"`
-	Amount    float64   `json:"amount" binding:"required,gt=0"` // Amount should be positive
-	Currency  string    `json:"currency,omitempty"`             // Defaults to USD if empty
-	IsDebit   bool      `json:"is_debit"`                       // True for Debit, False for Credit
+	ID           uuid.UUID            `json:"id,omitempty"` // Used for updates if lines can be individually identified
+	AccountID    uuid.UUID            `json:"account_id" binding:"required"`
+	Amount       money.Amount         `json:"amount"`                  // In Currency; must be positive and within its decimal places; checked by the service
+	Currency     string               `json:"currency,omitempty"`      // Defaults to the functional currency if empty
+	ExchangeRate *money.Rate          `json:"exchange_rate,omitempty"` // Optional: overrides the dated rate into the functional currency
+	IsDebit      bool                 `json:"is_debit"`                // True for Debit, False for Credit
+	Dimensions   models.DimensionTags `json:"dimensions,omitempty"`    // Dimension code to value code, e.g. {"DEPARTMENT": "SALES"}
+	TaxCode      string               `json:"tax_code,omitempty"`      // Taxes Amount as the base; the tax lines are added to the entry
 }
 
 // CreateJournalEntryRequest defines the structure for creating a new journal entry.
 type CreateJournalEntryRequest struct {
-	EntryDate   time.Time            `json:"entry_date,omitempty"` // Defaults to Now if omitted
-	Description string               `json:"description" binding:"max=255"`
-	Reference   string               `json:"reference,omitempty" binding:"max=100"`
-	Status      models.JournalStatus `json:"status,omitempty"`      // Optional: e.g. "DRAFT", "POSTED". Defaults to DRAFT in service.
-	Lines       []JournalLineRequest `json:"lines" binding:"required,min=1,dive"` // dive validates each element in slice
+	EntryDate     time.Time            `json:"entry_date,omitempty"` // Defaults to Now if omitted
+	Description   string               `json:"description" binding:"max=255"`
+	Reference     string               `json:"reference,omitempty" binding:"max=100"`
+	Status        models.JournalStatus `json:"status,omitempty"`                    // Optional: e.g. "DRAFT", "POSTED". Defaults to DRAFT in service.
+	Lines         []JournalLineRequest `json:"lines" binding:"required,min=1,dive"` // dive validates each element in slice
+	AutoReverseOn *time.Time           `json:"auto_reverse_on,omitempty"`           // Optional: post a mirror entry on this date once posted
 }
 
 // UpdateJournalEntryRequest defines the structure for updating an existing journal entry.
 type UpdateJournalEntryRequest struct {
-	EntryDate   *time.Time           `json:"entry_date,omitempty"`
-	Description *string              `json:"description,omitempty" binding:"omitempty,max=255"`
-	Reference   *string              `json:"reference,omitempty" binding:"omitempty,max=100"`
-	Status      *models.JournalStatus `json:"status,omitempty"` // e.g. "DRAFT", "POSTED", "VOIDED"
-	Lines       *[]JournalLineRequest `json:"lines,omitempty" binding:"omitempty,min=1,dive"` // Pointer to allow omitting lines update
+	EntryDate     *time.Time            `json:"entry_date,omitempty"`
+	Description   *string               `json:"description,omitempty" binding:"omitempty,max=255"`
+	Reference     *string               `json:"reference,omitempty" binding:"omitempty,max=100"`
+	Status        *models.JournalStatus `json:"status,omitempty"`                               // e.g. "DRAFT", "POSTED", "VOIDED"
+	Lines         *[]JournalLineRequest `json:"lines,omitempty" binding:"omitempty,min=1,dive"` // Pointer to allow omitting lines update
+	AutoReverseOn *time.Time            `json:"auto_reverse_on,omitempty"`                      // DRAFT entries only
 }
 
 // ListJournalEntriesRequest defines parameters for listing journal entries.
 type ListJournalEntriesRequest struct {
-	Page        int                  `form:"page,default=1"`
-	Limit       int                  `form:"limit,default=20"`
-	Description string               `form:"description,omitempty"`
-	Reference   string               `form:"reference,omitempty"`
-	Status      models.JournalStatus `form:"status,omitempty"`
-	DateFrom    time.Time            `form:"date_from,omitempty" time_format:"2006-01-02"`
-	DateTo      time.Time            `form:"date_to,omitempty" time_format:"2006-01-02"`
-	AccountID   uuid.UUID            `form:"account_id,omitempty"` // To filter entries affecting a specific account
+	Page           int                  `form:"page,default=1"`
+	Limit          int                  `form:"limit,default=20"`
+	Description    string               `form:"description,omitempty"`
+	Reference      string               `form:"reference,omitempty"`
+	DocumentNumber string               `form:"document_number,omitempty"` // Exact number given on posting, e.g. GJ-2026-000123
+	Status         models.JournalStatus `form:"status,omitempty"`
+	DateFrom       time.Time            `form:"date_from,omitempty" time_format:"2006-01-02"`
+	DateTo         time.Time            `form:"date_to,omitempty" time_format:"2006-01-02"`
+	AccountID      uuid.UUID            `form:"account_id,omitempty"` // To filter entries affecting a specific account
+}
+
+// VoidJournalEntryRequest defines the body for voiding a posted journal entry.
+type VoidJournalEntryRequest struct {
+	Reason       string    `json:"reason" binding:"required,max=255"`
+	ReversalDate time.Time `json:"reversal_date,omitempty"` // Defaults to the original entry date
+}
+
+// JournalApprovalRequest defines the optional body for submitting, approving or rejecting a
+// journal entry. Rejections require a comment.
+type JournalApprovalRequest struct {
+	Comment string `json:"comment,omitempty" binding:"max=500"`
+}
+
+// VoidJournalEntryResponse returns the voided entry together with its reversing entry.
+type VoidJournalEntryResponse struct {
+	VoidedEntry   *models.JournalEntry `json:"voided_entry"`
+	ReversalEntry *models.JournalEntry `json:"reversal_entry"`
+}
+
+
+// --- Fiscal Calendar DTOs ---
+
+// CreateFiscalYearRequest defines a fiscal year to be split into monthly periods.
+type CreateFiscalYearRequest struct {
+	Name      string    `json:"name" binding:"required,max=50"`
+	StartDate time.Time `json:"start_date" binding:"required"`
+	EndDate   time.Time `json:"end_date,omitempty"` // Defaults to one year after StartDate, less one day
 }
 
+// UpdateFiscalPeriodStatusRequest changes whether a period accepts postings.
+type UpdateFiscalPeriodStatusRequest struct {
+	Status models.PeriodStatus `json:"status" binding:"required"` // OPEN, SOFT_CLOSED or CLOSED
+}
+
+// YearEndCloseResponse reports the outcome of closing or reopening a fiscal year.
+// ClosingEntry is set by a close; ReversalEntry by a reopen. Both are nil when the
+// year had no revenue or expense activity.
+type YearEndCloseResponse struct {
+	FiscalYear                *models.FiscalYear   `json:"fiscal_year"`
+	RetainedEarningsAccountID uuid.UUID            `json:"retained_earnings_account_id"`
+	NetIncome                 money.Amount         `json:"net_income"`
+	ClosingEntry              *models.JournalEntry `json:"closing_entry,omitempty"`
+	ReversalEntry             *models.JournalEntry `json:"reversal_entry,omitempty"`
+}
+
+
+// --- Recurring Journal DTOs ---
+
+// CreateRecurringJournalTemplateRequest defines a journal entry to be created on a schedule.
+type CreateRecurringJournalTemplateRequest struct {
+	Name           string                     `json:"name" binding:"required,max=100"`
+	Description    string                     `json:"description" binding:"max=255"`
+	Reference      string                     `json:"reference,omitempty" binding:"max=100"`
+	Frequency      models.RecurrenceFrequency `json:"frequency" binding:"required"`  // MONTHLY, QUARTERLY or CRON
+	CronExpression string                     `json:"cron_expression,omitempty"`     // Required for CRON, e.g. "0 0 1,15 * *"; only the day fields matter
+	StartDate      time.Time                  `json:"start_date" binding:"required"` // First occurrence for MONTHLY and QUARTERLY
+	EndDate        *time.Time                 `json:"end_date,omitempty"`            // No occurrences after this date
+	EntryStatus    models.JournalStatus       `json:"entry_status,omitempty"`        // DRAFT (default) or POSTED
+	Lines          []JournalLineRequest       `json:"lines" binding:"required,min=2,dive"`
+}
+
+// UpdateRecurringJournalTemplateRequest changes a recurring journal template. Omitted fields are
+// left unchanged; Lines, when given, replaces all lines.
+type UpdateRecurringJournalTemplateRequest struct {
+	Name           *string                     `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description    *string                     `json:"description,omitempty" binding:"omitempty,max=255"`
+	Reference      *string                     `json:"reference,omitempty" binding:"omitempty,max=100"`
+	Frequency      *models.RecurrenceFrequency `json:"frequency,omitempty"`
+	CronExpression *string                     `json:"cron_expression,omitempty"`
+	StartDate      *time.Time                  `json:"start_date,omitempty"`
+	EndDate        *time.Time                  `json:"end_date,omitempty"`
+	EntryStatus    *models.JournalStatus       `json:"entry_status,omitempty"`
+	IsActive       *bool                       `json:"is_active,omitempty"`
+	Lines          []JournalLineRequest        `json:"lines,omitempty" binding:"omitempty,min=2,dive"`
+}
+
+// --- Currency DTOs ---
+
+// CreateCurrencyRequest adds a currency to the currency master.
+type CreateCurrencyRequest struct {
+	Code     string `json:"code" binding:"required,len=3"` // ISO 4217, e.g. "EUR"
+	Name     string `json:"name" binding:"required,max=100"`
+	IsActive *bool  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// UpdateCurrencyRequest changes a currency's name or deactivates it. Omitted fields are left unchanged.
+type UpdateCurrencyRequest struct {
+	Name     *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// ImportExchangeRatesResponse reports how many rates an import saved.
+type ImportExchangeRatesResponse struct {
+	ToCurrency string `json:"to_currency"` // The functional currency the rates convert into
+	Imported   int    `json:"imported"`
+}
+
+// FXRevaluationRequest selects the date and accounts of a foreign-currency revaluation.
+type FXRevaluationRequest struct {
+	RevaluationDate time.Time   `json:"revaluation_date" binding:"required"` // Usually a period end; balances at the end of this day are revalued
+	AccountIDs      []uuid.UUID `json:"account_ids,omitempty"`               // Defaults to every ASSET and LIABILITY account
+	DryRun          bool        `json:"dry_run,omitempty"`                   // Report the figures without posting
+}
+
+// FXRevaluationLine shows how one account's balance in one foreign currency was revalued.
+// Balances are debits minus credits; a positive adjustment is a gain.
+type FXRevaluationLine struct {
+	AccountID          uuid.UUID          `json:"account_id"`
+	AccountCode        string             `json:"account_code"`
+	AccountName        string             `json:"account_name"`
+	AccountType        models.AccountType `json:"account_type"`
+	Currency           string             `json:"currency"`
+	TransactionBalance money.Amount       `json:"transaction_balance"` // In Currency
+	Rate               money.Rate         `json:"rate"`                // Period-end rate into the functional currency
+	RateDate           time.Time          `json:"rate_date"`           // Date of the rate used, on or before the revaluation date
+	RevaluedBalance    money.Amount       `json:"revalued_balance"`    // TransactionBalance at Rate, rounded to the functional currency
+	BookedBalance      money.Amount       `json:"booked_balance"`      // Functional balance at the rates the lines were booked at
+	Adjustment         money.Amount       `json:"adjustment"`          // RevaluedBalance minus BookedBalance
+	Computation        string             `json:"computation"`         // The calculation in words
+}
+
+// FXRevaluationResponse reports a revaluation run and the entry it posted, if any.
+type FXRevaluationResponse struct {
+	RevaluationDate    time.Time            `json:"revaluation_date"`
+	FunctionalCurrency string               `json:"functional_currency"`
+	Lines              []FXRevaluationLine  `json:"lines"` // Ordered by account code, then currency
+	TotalGain          money.Amount         `json:"total_gain"`
+	TotalLoss          money.Amount         `json:"total_loss"`
+	NetAdjustment      money.Amount         `json:"net_adjustment"` // Gain minus loss
+	GainAccountID      uuid.UUID            `json:"gain_account_id"`
+	LossAccountID      uuid.UUID            `json:"loss_account_id"`
+	DryRun             bool                 `json:"dry_run"`
+	Entry              *models.JournalEntry `json:"entry,omitempty"`      // Absent for a dry run or when nothing needed adjusting
+	ReverseOn          *time.Time           `json:"reverse_on,omitempty"` // The day after the revaluation date
+}
+
+// --- Dimension DTOs ---
+
+// CreateDimensionRequest adds an analytical dimension, such as a cost center or project.
+type CreateDimensionRequest struct {
+	Code     string `json:"code" binding:"required,max=30"` // Upper-cased; cannot be changed later
+	Name     string `json:"name" binding:"required,max=100"`
+	IsActive *bool  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// UpdateDimensionRequest renames or deactivates a dimension. Omitted fields are left unchanged.
+type UpdateDimensionRequest struct {
+	Name     *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// CreateDimensionValueRequest adds a value to a dimension's value list.
+type CreateDimensionValueRequest struct {
+	Code     string `json:"code" binding:"required,max=30"` // Upper-cased; unique within the dimension and cannot be changed later
+	Name     string `json:"name" binding:"required,max=100"`
+	IsActive *bool  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// UpdateDimensionValueRequest renames or deactivates a dimension value. Omitted fields are left unchanged.
+type UpdateDimensionValueRequest struct {
+	Name     *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// SetRequiredDimensionsRequest replaces the dimensions every line on an account must carry.
+type SetRequiredDimensionsRequest struct {
+	DimensionCodes []string `json:"dimension_codes"` // Empty removes every requirement
+}
+
+// --- Budget DTOs ---
+
+// BudgetLineRequest is the amount budgeted for an account in one period of the budget's fiscal year.
+type BudgetLineRequest struct {
+	AccountID     uuid.UUID    `json:"account_id" binding:"required"`
+	PeriodNumber  int          `json:"period_number" binding:"required"` // 1 for the fiscal year's first period
+	Amount        money.Amount `json:"amount"`                           // In the account's normal balance direction
+	DimensionCode string       `json:"dimension_code,omitempty"`         // With value_code, budgets a single value of a dimension
+	ValueCode     string       `json:"value_code,omitempty"`
+}
+
+// CreateBudgetRequest creates version 1 of a budget as a draft.
+type CreateBudgetRequest struct {
+	Name         string              `json:"name" binding:"required,max=100"`
+	FiscalYearID uuid.UUID           `json:"fiscal_year_id" binding:"required"`
+	Description  string              `json:"description" binding:"max=255"`
+	Lines        []BudgetLineRequest `json:"lines,omitempty" binding:"omitempty,dive"`
+}
+
+// UpdateBudgetRequest changes a draft budget. Omitted fields are left unchanged; Lines, when
+// given, replaces all lines.
+type UpdateBudgetRequest struct {
+	Description *string             `json:"description,omitempty" binding:"omitempty,max=255"`
+	Lines       []BudgetLineRequest `json:"lines,omitempty" binding:"omitempty,dive"`
+}
+
+// ImportBudgetLinesResponse reports how many lines an import saved into a draft budget.
+type ImportBudgetLinesResponse struct {
+	BudgetID uuid.UUID `json:"budget_id"`
+	Imported int       `json:"imported"`
+}
+
+// --- Bank Reconciliation DTOs ---
+
+// CreateBankAccountRequest links a bank account to the ASSET ledger account it is booked on.
+type CreateBankAccountRequest struct {
+	Name             string    `json:"name" binding:"required,max=100"`
+	AccountNumber    string    `json:"account_number,omitempty" binding:"max=50"` // IBAN or local number; statements for another account are rejected
+	Currency         string    `json:"currency,omitempty"`                        // Defaults to the base currency
+	ChartOfAccountID uuid.UUID `json:"chart_of_account_id" binding:"required"`
+}
+
+// UpdateBankAccountRequest renames or deactivates a bank account. Omitted fields are left unchanged.
+type UpdateBankAccountRequest struct {
+	Name          *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	AccountNumber *string `json:"account_number,omitempty" binding:"omitempty,max=50"`
+	IsActive      *bool   `json:"is_active,omitempty"`
+}
+
+// ImportStatementResponse reports what a statement import saved and how many lines it matched.
+type ImportStatementResponse struct {
+	StatementID uuid.UUID              `json:"statement_id"`
+	Format      models.StatementFormat `json:"format"`
+	Imported    int                    `json:"imported"`
+	Duplicates  int                    `json:"duplicates"`   // Lines already imported from an earlier statement, skipped
+	AutoMatched int                    `json:"auto_matched"` // Imported lines matched to journal lines
+}
+
+// AutoMatchResponse reports an auto-match run over a bank account's unmatched statement lines.
+type AutoMatchResponse struct {
+	Matched   int `json:"matched"`
+	Unmatched int `json:"unmatched"` // Lines still left for manual matching
+}
+
+// MatchStatementLineRequest matches a statement line to a journal line by hand.
+type MatchStatementLineRequest struct {
+	JournalLineID uuid.UUID `json:"journal_line_id" binding:"required"`
+}
+
+// --- Document Sequence DTOs ---
+
+// UpdateDocumentSequenceRequest configures how a document type is numbered.
+type UpdateDocumentSequenceRequest struct {
+	Prefix      string `json:"prefix" binding:"required,max=20"`
+	Padding     int    `json:"padding,omitempty"`      // Digits of the number, 6 if omitted
+	ResetYearly *bool  `json:"reset_yearly,omitempty"` // Defaults to true for a new sequence; fixed once numbers are issued
+}
+
+// DocumentSequenceResponse is a sequence with the last number it issued in each year.
+type DocumentSequenceResponse struct {
+	*models.DocumentSequence
+	Counters []*models.DocumentSequenceCounter `json:"counters"`
+}
 
 // --- Reporting DTOs ---
 
 // TrialBalanceRequest defines parameters for generating a trial balance report.
 type TrialBalanceRequest struct {
-	StartDate                time.Time `json:"start_date,omitempty" form:"start_date,omitempty" time_format:"2006-01-02"` // For period-specific changes, not typical for TB itself
-	EndDate                  time.Time `json:"end_date" form:"end_date" binding:"required" time_format:"2006-01-02"`
-	IncludeZeroBalanceAccounts bool    `json:"include_zero_balance_accounts,omitempty" form:"include_zero_balance_accounts,omitempty"`
-	// Add other parameters like specific subsidiary, department, etc.
+	StartDate                  time.Time            `json:"start_date,omitempty" form:"start_date,omitempty" time_format:"2006-01-02"` // For period-specific changes, not typical for TB itself
+	EndDate                    time.Time            `json:"end_date" form:"end_date" binding:"required" time_format:"2006-01-02"`
+	IncludeZeroBalanceAccounts bool                 `json:"include_zero_balance_accounts,omitempty" form:"include_zero_balance_accounts,omitempty"`
+	Dimensions                 models.DimensionTags `json:"dimensions,omitempty"` // Only lines tagged with every one of these values
+	GroupBy                    string               `json:"group_by,omitempty"`   // Dimension code to break each account down by
 }
 
 // TrialBalanceLine represents a single line in the trial balance report.
 type TrialBalanceLine struct {
-	AccountCode string  `json:"account_code"`
-	AccountName string  `json:"account_name"`
-	Debit       float64 `json:"debit"`
-	Credit      float64 `json:"credit"`
+	AccountCode    string       `json:"account_code"`
+	AccountName    string       `json:"account_name"`
+	DimensionValue string       `json:"dimension_value,omitempty"` // With GroupBy, the value code; empty for lines without one
+	Debit          money.Amount `json:"debit"`
+	Credit         money.Amount `json:"credit"`
 }
 
 // TrialBalanceResponse is the structure for the trial balance report.
 type TrialBalanceResponse struct {
-	ReportDate   time.Time          `json:"report_date"`
-	Lines        []TrialBalanceLine `json:"lines"`
-	TotalDebits  float64            `json:"total_debits"`
-	TotalCredits float64            `json:"total_credits"`
+	ReportDate   time.Time            `json:"report_date"`
+	Currency     string               `json:"currency"` // The functional currency all amounts are in
+	Dimensions   models.DimensionTags `json:"dimensions,omitempty"`
+	GroupBy      string               `json:"group_by,omitempty"`
+	Lines        []TrialBalanceLine   `json:"lines"`
+	TotalDebits  money.Amount         `json:"total_debits"`
+	TotalCredits money.Amount         `json:"total_credits"`
 }
 
 
@@ -117,13 +451,14 @@ type BalanceSheetRequest struct {
 type BalanceSheetSection struct {
     Title    string             `json:"title"`
     Accounts []BalanceSheetLine `json:"accounts"`
-    Total    float64            `json:"total"`
+    Total    money.Amount       `json:"total"`
 }
 
 // BalanceSheetLine (Example for BS line)
 type BalanceSheetLine struct {
-    AccountName string  `json:"account_name"`
-    Amount      float64 `json:"amount"`
+    AccountCode string       `json:"account_code,omitempty"` // Empty for computed lines such as current year earnings
+    AccountName string       `json:"account_name"`
+    Amount      money.Amount `json:"amount"`
     // SubLines if there's a hierarchy within the report
 }
 
@@ -133,39 +468,437 @@ type BalanceSheetResponse struct {
     Assets         BalanceSheetSection   `json:"assets"`
     Liabilities    BalanceSheetSection   `json:"liabilities"`
     Equity         BalanceSheetSection   `json:"equity"`
-    TotalLiabilitiesAndEquity float64    `json:"total_liabilities_and_equity"`
+    TotalLiabilitiesAndEquity money.Amount `json:"total_liabilities_and_equity"`
     // Verification (TotalAssets == TotalLiabilitiesAndEquity)
 }
 
 
-// ProfitAndLossRequest (Example structure)
+// ProfitAndLossRequest defines the period and optional comparison columns for a P&L statement.
 type ProfitAndLossRequest struct {
-    StartDate time.Time `json:"start_date" binding:"required" time_format:"2006-01-02"`
-    EndDate   time.Time `json:"end_date" binding:"required" time_format:"2006-01-02"`
+    StartDate            time.Time `json:"start_date" binding:"required" time_format:"2006-01-02"`
+    EndDate              time.Time `json:"end_date" binding:"required" time_format:"2006-01-02"`
+    CompareToPriorPeriod bool      `json:"compare_prior_period,omitempty"` // Period of equal length immediately before StartDate
+    CompareToPriorYear   bool      `json:"compare_prior_year,omitempty"`   // Same dates one year earlier
     // Filters: Department, Project, etc.
 }
 
-// ProfitAndLossSection (Example for P&L structure)
+// ProfitAndLossComparison is one comparison column for a line or total.
+// Variance is current minus comparison; VariancePercent is nil when the comparison amount is zero.
+type ProfitAndLossComparison struct {
+    Amount          money.Amount `json:"amount"`
+    Variance        money.Amount `json:"variance"`
+    VariancePercent *float64     `json:"variance_percent"`
+}
+
+// ProfitAndLossSection groups the Revenue or Expense accounts of a P&L statement.
 type ProfitAndLossSection struct {
-    Title    string                `json:"title"` // e.g., "Revenue", "Cost of Goods Sold", "Operating Expenses"
-    Accounts []ProfitAndLossLine   `json:"accounts"`
-    Total    float64               `json:"total"`
+    Title       string                   `json:"title"` // "Revenue" or "Expenses"
+    Accounts    []ProfitAndLossLine      `json:"accounts"`
+    Total       money.Amount             `json:"total"`
+    PriorPeriod *ProfitAndLossComparison `json:"prior_period,omitempty"`
+    PriorYear   *ProfitAndLossComparison `json:"prior_year,omitempty"`
 }
 
-// ProfitAndLossLine (Example for P&L line)
+// ProfitAndLossLine is one account in a P&L section, listed depth-first by ParentAccountID.
+// For accounts with children, Amount is a subtotal that includes all descendants,
+// so only Level 0 lines add up to the section total.
 type ProfitAndLossLine struct {
-    AccountName string  `json:"account_name"`
-    Amount      float64 `json:"amount"`
+    AccountID   uuid.UUID                `json:"account_id"`
+    AccountCode string                   `json:"account_code"`
+    AccountName string                   `json:"account_name"`
+    Level       int                      `json:"level"`
+    IsSubtotal  bool                     `json:"is_subtotal"`
+    Amount      money.Amount             `json:"amount"`
+    PriorPeriod *ProfitAndLossComparison `json:"prior_period,omitempty"`
+    PriorYear   *ProfitAndLossComparison `json:"prior_year,omitempty"`
 }
 
-// ProfitAndLossResponse (Example)
+// ProfitAndLossPeriod is a date range covered by a P&L column.
+type ProfitAndLossPeriod struct {
+    StartDate time.Time `json:"start_date"`
+    EndDate   time.Time `json:"end_date"`
+}
+
+// ProfitAndLossResponse is the structure for the profit and loss statement.
 type ProfitAndLossResponse struct {
-    ReportPeriod string                 `json:"report_period"` // e.g., "For the period DD/MM/YYYY to DD/MM/YYYY"
-    Revenue      ProfitAndLossSection   `json:"revenue"`
-    COGS         ProfitAndLossSection   `json:"cogs"`
-    GrossProfit  float64                `json:"gross_profit"`
-    Expenses     ProfitAndLossSection   `json:"expenses"` // Operating Expenses
-    NetIncome    float64                `json:"net_income"`   // Or Net Loss
+    ReportPeriod         string                   `json:"report_period"` // e.g., "For the period 2024-01-01 to 2024-03-31"
+    Period               ProfitAndLossPeriod      `json:"period"`
+    PriorPeriod          *ProfitAndLossPeriod     `json:"prior_period,omitempty"`
+    PriorYear            *ProfitAndLossPeriod     `json:"prior_year,omitempty"`
+    Revenue              ProfitAndLossSection     `json:"revenue"`
+    Expenses             ProfitAndLossSection     `json:"expenses"`
+    NetIncome            money.Amount             `json:"net_income"` // Negative for a net loss
+    NetIncomePriorPeriod *ProfitAndLossComparison `json:"net_income_prior_period,omitempty"`
+    NetIncomePriorYear   *ProfitAndLossComparison `json:"net_income_prior_year,omitempty"`
+}
+
+// CashFlowRequest defines the period for a statement of cash flows.
+type CashFlowRequest struct {
+    StartDate time.Time `json:"start_date" binding:"required" time_format:"2006-01-02"`
+    EndDate   time.Time `json:"end_date" binding:"required" time_format:"2006-01-02"`
+}
+
+// CashFlowLine is one item of the statement of cash flows. Positive amounts increase cash.
+type CashFlowLine struct {
+    AccountCode string       `json:"account_code,omitempty"`
+    AccountName string       `json:"account_name"`
+    Amount      money.Amount `json:"amount"`
+}
+
+// CashFlowOperatingSection reconciles net income to cash from operating activities (indirect method).
+type CashFlowOperatingSection struct {
+    NetIncome             money.Amount   `json:"net_income"`
+    NonCashAdjustments    []CashFlowLine `json:"non_cash_adjustments"`
+    WorkingCapitalChanges []CashFlowLine `json:"working_capital_changes"`
+    Total                 money.Amount   `json:"total"`
+}
+
+// CashFlowSection lists the investing or financing flows of the period.
+type CashFlowSection struct {
+    Title string         `json:"title"`
+    Lines []CashFlowLine `json:"lines"`
+    Total money.Amount   `json:"total"`
+}
+
+// CashFlowResponse is the statement of cash flows. NetChangeInCash always equals
+// ClosingCash minus OpeningCash, where cash is the sum of accounts classified as CASH.
+type CashFlowResponse struct {
+    ReportPeriod    string                   `json:"report_period"`
+    Operating       CashFlowOperatingSection `json:"operating"`
+    Investing       CashFlowSection          `json:"investing"`
+    Financing       CashFlowSection          `json:"financing"`
+    NetChangeInCash money.Amount             `json:"net_change_in_cash"`
+    OpeningCash     money.Amount             `json:"opening_cash"`
+    ClosingCash     money.Amount             `json:"closing_cash"`
+}
+
+// LedgerCounterAccount is an account on the other side of a ledger line's journal entry.
+type LedgerCounterAccount struct {
+    AccountID   uuid.UUID `json:"account_id"`
+    AccountCode string    `json:"account_code,omitempty"`
+    AccountName string    `json:"account_name,omitempty"`
+}
+
+// AccountLedgerLine is one posted journal line of an account ledger.
+type AccountLedgerLine struct {
+	JournalEntryID  uuid.UUID                     `json:"journal_entry_id"`
+	JournalLineID   uuid.UUID                     `json:"journal_line_id"`
+	EntryDate       time.Time                     `json:"entry_date"`
+	Description     string                        `json:"description"`
+	Reference       string                        `json:"reference,omitempty"`
+	EntryType       models.JournalEntryType       `json:"entry_type"`
+	Debit           money.Amount                  `json:"debit"`
+	Credit          money.Amount                  `json:"credit"`
+	RunningBalance  money.Amount                  `json:"running_balance"`
+	CounterAccounts []LedgerCounterAccount        `json:"counter_accounts"`
+	Dimensions      []models.JournalLineDimension `json:"dimensions,omitempty"`
+}
+
+// AccountLedgerResponse lists an account's posted lines between two dates. Balances are debits
+// minus credits, so a credit balance is negative, as in GetAccountBalance.
+type AccountLedgerResponse struct {
+	AccountID      uuid.UUID           `json:"account_id"`
+	AccountCode    string              `json:"account_code"`
+	AccountName    string              `json:"account_name"`
+	AccountType    models.AccountType  `json:"account_type"`
+	DimensionValue string              `json:"dimension_value,omitempty"` // Set when a general ledger is grouped by a dimension
+	StartDate      time.Time           `json:"start_date,omitempty"`      // Zero means from the first entry
+	EndDate        time.Time           `json:"end_date"`
+	OpeningBalance money.Amount        `json:"opening_balance"`
+	TotalDebits    money.Amount        `json:"total_debits"`
+	TotalCredits   money.Amount        `json:"total_credits"`
+	ClosingBalance money.Amount        `json:"closing_balance"`
+	Lines          []AccountLedgerLine `json:"lines"`
+}
+
+// GeneralLedgerRequest selects the period and accounts of a general ledger export.
+type GeneralLedgerRequest struct {
+	StartDate  time.Time            `json:"start_date,omitempty" time_format:"2006-01-02"`
+	EndDate    time.Time            `json:"end_date" time_format:"2006-01-02"` // Defaults to today
+	AccountIDs []uuid.UUID          `json:"account_ids,omitempty"`             // Empty means every account with a balance or activity
+	Dimensions models.DimensionTags `json:"dimensions,omitempty"`              // Only lines tagged with every one of these values
+	GroupBy    string               `json:"group_by,omitempty"`                // Dimension code; each account gets one ledger per value
+}
+
+// GeneralLedgerResponse holds the ledgers of several accounts, ordered by account code.
+type GeneralLedgerResponse struct {
+	StartDate    time.Time               `json:"start_date,omitempty"`
+	EndDate      time.Time               `json:"end_date"`
+	Dimensions   models.DimensionTags    `json:"dimensions,omitempty"`
+	GroupBy      string                  `json:"group_by,omitempty"`
+	Accounts     []AccountLedgerResponse `json:"accounts"`
+	TotalDebits  money.Amount            `json:"total_debits"`
+	TotalCredits money.Amount            `json:"total_credits"`
+}
+
+// CurrencyBalanceLine is what one account holds in one transaction currency.
+type CurrencyBalanceLine struct {
+	AccountID          uuid.UUID          `json:"account_id"`
+	AccountCode        string             `json:"account_code"`
+	AccountName        string             `json:"account_name"`
+	AccountType        models.AccountType `json:"account_type"`
+	Currency           string             `json:"currency"`
+	TransactionBalance money.Amount       `json:"transaction_balance"` // Debits minus credits in Currency
+	FunctionalBalance  money.Amount       `json:"functional_balance"`  // The same lines at the rates they were booked at
+}
+
+// CurrencyBalanceTotal sums the balances of every account in one transaction currency.
+type CurrencyBalanceTotal struct {
+	Currency           string       `json:"currency"`
+	TransactionBalance money.Amount `json:"transaction_balance"`
+	FunctionalBalance  money.Amount `json:"functional_balance"`
+}
+
+// CurrencyBalanceResponse breaks account balances down by transaction currency. The functional
+// balances of an account's lines add up to its balance in the trial balance.
+type CurrencyBalanceResponse struct {
+	AsOfDate           time.Time              `json:"as_of_date"`
+	FunctionalCurrency string                 `json:"functional_currency"`
+	Lines              []CurrencyBalanceLine  `json:"lines"`  // Ordered by account code, then currency
+	Totals             []CurrencyBalanceTotal `json:"totals"` // Ordered by currency
+}
+
+// BudgetVsActualRequest selects a budget version and a range of its fiscal year's periods.
+type BudgetVsActualRequest struct {
+	BudgetID   uuid.UUID `json:"budget_id" binding:"required"`
+	FromPeriod int       `json:"from_period,omitempty"` // Defaults to the first period
+	ToPeriod   int       `json:"to_period,omitempty"`   // Defaults to the last period
+}
+
+// BudgetVsActualLine compares the budget of an account, or of one dimension value on it, with the
+// posted lines over the selected periods. Amounts are in the account's normal balance direction.
+type BudgetVsActualLine struct {
+	AccountID       uuid.UUID          `json:"account_id"`
+	AccountCode     string             `json:"account_code"`
+	AccountName     string             `json:"account_name"`
+	AccountType     models.AccountType `json:"account_type"`
+	DimensionCode   string             `json:"dimension_code,omitempty"`
+	ValueCode       string             `json:"value_code,omitempty"`
+	Budget          money.Amount       `json:"budget"`
+	Actual          money.Amount       `json:"actual"`
+	Variance        money.Amount       `json:"variance"`         // Actual minus budget
+	PercentConsumed *float64           `json:"percent_consumed"` // Actual as a percentage of budget; nil when the budget is zero
+}
+
+// BudgetVsActualResponse is the budget-vs-actual report of one budget version.
+type BudgetVsActualResponse struct {
+	BudgetID   uuid.UUID            `json:"budget_id"`
+	BudgetName string               `json:"budget_name"`
+	Version    int                  `json:"version"`
+	Status     models.BudgetStatus  `json:"status"`
+	FromPeriod int                  `json:"from_period"`
+	ToPeriod   int                  `json:"to_period"`
+	StartDate  time.Time            `json:"start_date"`
+	EndDate    time.Time            `json:"end_date"`
+	Lines      []BudgetVsActualLine `json:"lines"` // Ordered by account code, then dimension and value
+}
+
+// BankReconciliationItem is a journal line on the bank's ledger account that no statement line
+// has cleared yet. Amount is in the bank account's currency.
+type BankReconciliationItem struct {
+	JournalEntryID uuid.UUID    `json:"journal_entry_id"`
+	JournalLineID  uuid.UUID    `json:"journal_line_id"`
+	EntryDate      time.Time    `json:"entry_date"`
+	Reference      string       `json:"reference"`
+	Description    string       `json:"description"`
+	Amount         money.Amount `json:"amount"`
+}
+
+// BankReconciliationResponse reconciles a bank account's ledger balance with its statements.
+// ReconciledBalance is the book balance less deposits in transit plus outstanding payments, which
+// should equal the bank's closing balance once every statement line is matched.
+type BankReconciliationResponse struct {
+	BankAccountID       uuid.UUID                  `json:"bank_account_id"`
+	BankAccountName     string                     `json:"bank_account_name"`
+	Currency            string                     `json:"currency"`
+	AsOfDate            time.Time                  `json:"as_of_date"`
+	BookBalance         money.Amount               `json:"book_balance"` // Ledger balance in Currency
+	DepositsInTransit   []BankReconciliationItem   `json:"deposits_in_transit"`
+	OutstandingPayments []BankReconciliationItem   `json:"outstanding_payments"`
+	UnmatchedLines      []models.BankStatementLine `json:"unmatched_statement_lines"` // Bank transactions not yet booked or matched
+	TotalInTransit      money.Amount               `json:"total_in_transit"`
+	TotalOutstanding    money.Amount               `json:"total_outstanding"`
+	TotalUnmatched      money.Amount               `json:"total_unmatched"`
+	ReconciledBalance   money.Amount               `json:"reconciled_balance"`
+	StatementID         *uuid.UUID                 `json:"statement_id,omitempty"`      // Latest statement ending on or before AsOfDate with a closing balance
+	StatementBalance    *money.Amount              `json:"statement_balance,omitempty"` // That statement's closing balance
+	Difference          *money.Amount              `json:"difference,omitempty"`        // StatementBalance minus ReconciledBalance
+	IsReconciled        bool                       `json:"is_reconciled"`               // No unmatched statement lines and no difference
+}
+
+// CreateGroupAccountRequest adds an account to the group chart of accounts.
+type CreateGroupAccountRequest struct {
+	Code        string             `json:"code"`
+	Name        string             `json:"name"`
+	AccountType models.AccountType `json:"account_type"`
+}
+
+// UpdateGroupAccountRequest renames or (de)activates a group account; nil fields are left
+// unchanged. The code and type are fixed once created.
+type UpdateGroupAccountRequest struct {
+	Name     *string `json:"name,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// MapGroupAccountRequest maps one of the active company's accounts to a group account of the
+// same type, replacing any earlier mapping of the account.
+type MapGroupAccountRequest struct {
+	AccountID        uuid.UUID `json:"account_id"`
+	GroupAccountCode string    `json:"group_account_code"`
+}
+
+// CreateEliminationRuleRequest pairs two group accounts to eliminate against each other. The
+// accounts are named by group account code.
+type CreateEliminationRuleRequest struct {
+	Name                       string `json:"name"`
+	GroupAccountCode           string `json:"group_account_code"`
+	CounterGroupAccountCode    string `json:"counter_group_account_code"`
+	DifferenceGroupAccountCode string `json:"difference_group_account_code"` // Receives what the pair does not cancel out
+}
+
+// UpdateEliminationRuleRequest renames or (de)activates an elimination rule; nil fields are left
+// unchanged.
+type UpdateEliminationRuleRequest struct {
+	Name     *string `json:"name,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// ConsolidationRequest selects the period, currency and companies of a consolidation report.
+type ConsolidationRequest struct {
+	StartDate  time.Time   `json:"start_date"`            // Start of the period whose result is reported; defaults to 1 January of EndDate's year
+	EndDate    time.Time   `json:"end_date"`              // Balance sheet date
+	Currency   string      `json:"currency,omitempty"`    // Group presentation currency; defaults to GROUP_CURRENCY
+	CompanyIDs []uuid.UUID `json:"company_ids,omitempty"` // Defaults to every active company
+}
+
+// ConsolidationCompany is one company column of a consolidation report, with the rates its
+// balances were translated at.
+type ConsolidationCompany struct {
+	CompanyID   uuid.UUID  `json:"company_id"`
+	CompanyCode string     `json:"company_code"`
+	Currency    string     `json:"currency"`     // The company's functional currency
+	ClosingRate money.Rate `json:"closing_rate"` // Into the group currency on EndDate; used for the balance sheet
+	AverageRate money.Rate `json:"average_rate"` // Into the group currency over the period; used for revenue and expenses
+}
+
+// ConsolidationLine is one group account, or one of the equity lines consolidation adds, across
+// the report's columns. Amounts are debits minus credits in the group currency, so credit
+// balances are negative.
+type ConsolidationLine struct {
+	GroupAccountID   *uuid.UUID         `json:"group_account_id,omitempty"` // Nil for the lines consolidation adds
+	GroupAccountCode string             `json:"group_account_code,omitempty"`
+	GroupAccountName string             `json:"group_account_name"`
+	AccountType      models.AccountType `json:"account_type"`
+	Companies        []money.Amount     `json:"companies"` // One per company, in the order of the report's companies
+	Eliminations     money.Amount       `json:"eliminations"`
+	Total            money.Amount       `json:"total"` // Companies plus eliminations
+}
+
+// ConsolidationSection groups the lines of one account type, with their totals per column.
+type ConsolidationSection struct {
+	AccountType  models.AccountType  `json:"account_type"`
+	Lines        []ConsolidationLine `json:"lines"` // Ordered by group account code, added lines last
+	Companies    []money.Amount      `json:"companies"`
+	Eliminations money.Amount        `json:"eliminations"`
+	Total        money.Amount        `json:"total"`
+}
+
+// ConsolidationResponse is the consolidated trial balance of the group, laid out as statements:
+// the ASSET, LIABILITY and EQUITY sections form the balance sheet at EndDate and the REVENUE and
+// EXPENSE sections the result for the period. Every column adds up to zero.
+type ConsolidationResponse struct {
+	StartDate time.Time              `json:"start_date"`
+	EndDate   time.Time              `json:"end_date"`
+	Currency  string                 `json:"currency"`
+	Companies []ConsolidationCompany `json:"companies"`
+	Sections  []ConsolidationSection `json:"sections"`
+	NetIncome ConsolidationLine      `json:"net_income"` // Revenue less expenses for the period, positive for a profit
+}
+
+// --- Tax DTOs ---
+
+// TaxRateRequest sets a tax code's rate from a date on.
+type TaxRateRequest struct {
+	EffectiveFrom time.Time  `json:"effective_from"`
+	Rate          money.Rate `json:"rate"` // A fraction of the base, e.g. "0.2" for 20%; "0" for zero-rated supplies
+}
+
+// CreateTaxCodeRequest adds a tax code with its accounts, return boxes and rates.
+type CreateTaxCodeRequest struct {
+	Code                   string           `json:"code" binding:"required,max=20"` // Upper-cased; cannot be changed later
+	Name                   string           `json:"name" binding:"required,max=100"`
+	TaxType                models.TaxType   `json:"tax_type"`
+	TaxAccountID           uuid.UUID        `json:"tax_account_id"`
+	IsReverseCharge        bool             `json:"is_reverse_charge,omitempty"`         // INPUT codes only
+	ReverseChargeAccountID *uuid.UUID       `json:"reverse_charge_account_id,omitempty"` // Required for reverse-charge codes
+	BaseBox                string           `json:"base_box,omitempty"`
+	TaxBox                 string           `json:"tax_box,omitempty"`
+	ReverseChargeBox       string           `json:"reverse_charge_box,omitempty"`
+	Rates                  []TaxRateRequest `json:"rates,omitempty"`
+}
+
+// UpdateTaxCodeRequest changes a tax code's name, accounts, boxes or status. The type and reverse
+// charge cannot change, as lines already posted were taxed by them. Omitted fields are left unchanged.
+type UpdateTaxCodeRequest struct {
+	Name                   *string    `json:"name,omitempty" binding:"omitempty,max=100"`
+	TaxAccountID           *uuid.UUID `json:"tax_account_id,omitempty"`
+	ReverseChargeAccountID *uuid.UUID `json:"reverse_charge_account_id,omitempty"`
+	BaseBox                *string    `json:"base_box,omitempty"`
+	TaxBox                 *string    `json:"tax_box,omitempty"`
+	ReverseChargeBox       *string    `json:"reverse_charge_box,omitempty"`
+	IsActive               *bool      `json:"is_active,omitempty"`
+}
+
+// TaxCalculation is the tax a tax code charges on a base amount on a date, for documents such as
+// invoices that show it before it is posted.
+type TaxCalculation struct {
+	TaxCode     string         `json:"tax_code"`
+	TaxType     models.TaxType `json:"tax_type"`
+	Rate        money.Rate     `json:"rate"`
+	Currency    string         `json:"currency"`
+	TaxableBase money.Amount   `json:"taxable_base"`
+	Tax         money.Amount   `json:"tax"` // Rounded to Currency
+	// ReverseCharge tax is accounted for by the buyer, not paid to the supplier.
+	ReverseCharge bool `json:"reverse_charge"`
+}
+
+// TaxReturnRequest selects the period of a tax return.
+type TaxReturnRequest struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// TaxReturnBox is the total of one box of the tax return.
+type TaxReturnBox struct {
+	Box         string       `json:"box"`
+	TaxableBase money.Amount `json:"taxable_base"`
+	Tax         money.Amount `json:"tax"`
+}
+
+// TaxReturnCode is what one tax code contributed to the return. Output amounts are credits less
+// debits and input amounts debits less credits, so sales and purchases are positive and credit
+// notes reduce them.
+type TaxReturnCode struct {
+	TaxCodeID        uuid.UUID      `json:"tax_code_id"`
+	Code             string         `json:"code"`
+	Name             string         `json:"name"`
+	TaxType          models.TaxType `json:"tax_type"`
+	TaxableBase      money.Amount   `json:"taxable_base"`
+	Tax              money.Amount   `json:"tax"`
+	ReverseChargeTax money.Amount   `json:"reverse_charge_tax"` // Output tax self-assessed on reverse-charge purchases
+}
+
+// TaxReturnResponse sums the taxable base and tax of the posted lines in a period per tax code
+// and per return box.
+type TaxReturnResponse struct {
+	StartDate time.Time       `json:"start_date"`
+	EndDate   time.Time       `json:"end_date"`
+	Currency  string          `json:"currency"` // The functional currency all amounts are in
+	Boxes     []TaxReturnBox  `json:"boxes"`
+	Codes     []TaxReturnCode `json:"codes"`
+	OutputTax money.Amount    `json:"output_tax"` // Including reverse-charge tax
+	InputTax  money.Amount    `json:"input_tax"`
+	NetTax    money.Amount    `json:"net_tax"` // Output less input tax; positive is payable, negative reclaimable
 }
 
 // General API Response Wrappers (Optional, but good practice)
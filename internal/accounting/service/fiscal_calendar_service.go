@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SoftClosePostingRoles are the roles allowed to post into SOFT_CLOSED periods
+// and to change the status of fiscal periods.
+var SoftClosePostingRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+// PostingPeriodChecker decides whether a transaction dated on a given day may be recorded.
+type PostingPeriodChecker interface {
+	CheckPostingAllowed(ctx context.Context, date time.Time) error
+}
+
+// FiscalCalendarService manages fiscal years, their periods and the posting locks they impose.
+type FiscalCalendarService interface {
+	PostingPeriodChecker
+
+	CreateFiscalYear(ctx context.Context, req dto.CreateFiscalYearRequest) (*models.FiscalYear, error)
+	GetFiscalYear(ctx context.Context, id uuid.UUID) (*models.FiscalYear, error)
+	ListFiscalYears(ctx context.Context) ([]*models.FiscalYear, error)
+	SetPeriodStatus(ctx context.Context, periodID uuid.UUID, status models.PeriodStatus) (*models.FiscalPeriod, error)
+}
+
+// fiscalCalendarService is an implementation of FiscalCalendarService.
+type fiscalCalendarService struct {
+	periodRepo repository.FiscalPeriodRepository
+}
+
+// NewFiscalCalendarService creates a new FiscalCalendarService.
+func NewFiscalCalendarService(periodRepo repository.FiscalPeriodRepository) FiscalCalendarService {
+	return &fiscalCalendarService{periodRepo: periodRepo}
+}
+
+func (s *fiscalCalendarService) CreateFiscalYear(ctx context.Context, req dto.CreateFiscalYearRequest) (*models.FiscalYear, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create fiscal year %s", req.Name)
+
+	if strings.TrimSpace(req.Name) == "" || req.StartDate.IsZero() {
+		return nil, errors.NewValidationError("name and start_date are required", "")
+	}
+	start := dateOnly(req.StartDate)
+	end := shiftMonths(start, 12).AddDate(0, 0, -1)
+	if !req.EndDate.IsZero() {
+		end = dateOnly(req.EndDate)
+	}
+	if end.Before(start) {
+		return nil, errors.NewValidationError("end_date must not be before start_date", "end_date")
+	}
+	if end.After(start.AddDate(2, 0, 0)) {
+		return nil, errors.NewValidationError("a fiscal year cannot span more than 24 months", "end_date")
+	}
+
+	overlapping, err := s.periodRepo.FindOverlappingFiscalYears(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		logger.WarnLogger.Printf("Service: Fiscal year %s overlaps existing fiscal year %s", req.Name, overlapping[0].Name)
+		return nil, errors.NewConflictError(fmt.Sprintf("fiscal year overlaps existing fiscal year %s", overlapping[0].Name))
+	}
+
+	year := &models.FiscalYear{Name: req.Name, StartDate: start, EndDate: end}
+	// One period per month from the start date; the last period is cut short at the year end.
+	for n := 0; ; n++ {
+		periodStart := shiftMonths(start, n)
+		if periodStart.After(end) {
+			break
+		}
+		periodEnd := shiftMonths(start, n+1).AddDate(0, 0, -1)
+		if periodEnd.After(end) {
+			periodEnd = end
+		}
+		year.Periods = append(year.Periods, models.FiscalPeriod{
+			PeriodNumber: n + 1,
+			Name:         periodStart.Format("Jan 2006"),
+			StartDate:    periodStart,
+			EndDate:      periodEnd,
+			Status:       models.PeriodOpen,
+		})
+	}
+
+	created, err := s.periodRepo.CreateFiscalYear(ctx, year)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error creating fiscal year %s in repository: %v", req.Name, err)
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Successfully created fiscal year %s with %d periods", created.Name, len(created.Periods))
+	return created, nil
+}
+
+func (s *fiscalCalendarService) GetFiscalYear(ctx context.Context, id uuid.UUID) (*models.FiscalYear, error) {
+	return s.periodRepo.GetFiscalYearByID(ctx, id)
+}
+
+func (s *fiscalCalendarService) ListFiscalYears(ctx context.Context) ([]*models.FiscalYear, error) {
+	return s.periodRepo.ListFiscalYears(ctx)
+}
+
+func (s *fiscalCalendarService) SetPeriodStatus(ctx context.Context, periodID uuid.UUID, status models.PeriodStatus) (*models.FiscalPeriod, error) {
+	logger.InfoLogger.Printf("Service: Attempting to set fiscal period %s status to %s", periodID, status)
+
+	switch status {
+	case models.PeriodOpen, models.PeriodSoftClosed, models.PeriodClosed:
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid period status: %s", status), "status")
+	}
+	if !auth.HasAnyRole(ctx, SoftClosePostingRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("changing period status requires one of the roles: %s", strings.Join(SoftClosePostingRoles, ", ")))
+	}
+
+	period, err := s.periodRepo.GetPeriodByID(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if period.Status == status {
+		return period, nil
+	}
+	// A closed year's periods stay closed so its closing entry remains accurate; the year must be
+	// reopened, which reverses the closing entry, before any of its periods can change.
+	year, err := s.periodRepo.GetFiscalYearByID(ctx, period.FiscalYearID)
+	if err != nil {
+		return nil, err
+	}
+	if year.Status == models.PeriodClosed {
+		return nil, errors.NewConflictError(fmt.Sprintf("fiscal year %s is closed; reopen the year before changing the status of %s", year.Name, period.Name))
+	}
+	if err := s.periodRepo.UpdatePeriodStatus(ctx, periodID, status); err != nil {
+		logger.ErrorLogger.Printf("Service: Error updating fiscal period %s status: %v", periodID, err)
+		return nil, err
+	}
+	period.Status = status
+	logger.InfoLogger.Printf("Service: Fiscal period %s (%s) is now %s", period.Name, periodID, status)
+	return period, nil
+}
+
+// CheckPostingAllowed returns nil when no fiscal period covers date or the period is OPEN.
+// SOFT_CLOSED periods accept postings only from SoftClosePostingRoles; CLOSED periods accept none.
+func (s *fiscalCalendarService) CheckPostingAllowed(ctx context.Context, date time.Time) error {
+	period, err := s.periodRepo.GetPeriodForDate(ctx, date)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		logger.ErrorLogger.Printf("Service: Error looking up fiscal period for %s: %v", date.Format("2006-01-02"), err)
+		return err
+	}
+
+	switch period.Status {
+	case models.PeriodClosed:
+		logger.WarnLogger.Printf("Service: Posting rejected for %s, fiscal period %s is CLOSED", date.Format("2006-01-02"), period.Name)
+		return errors.NewConflictError(fmt.Sprintf("fiscal period %s is closed; transactions dated %s cannot be recorded or changed", period.Name, date.Format("2006-01-02")))
+	case models.PeriodSoftClosed:
+		if !auth.HasAnyRole(ctx, SoftClosePostingRoles...) {
+			logger.WarnLogger.Printf("Service: Posting rejected for %s, fiscal period %s is SOFT_CLOSED", date.Format("2006-01-02"), period.Name)
+			return errors.NewForbiddenError(fmt.Sprintf("fiscal period %s is soft-closed; only %s may record transactions dated %s", period.Name, strings.Join(SoftClosePostingRoles, ", "), date.Format("2006-01-02")))
+		}
+	}
+	return nil
+}
+
+// dateOnly truncates t to midnight UTC of its calendar day.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
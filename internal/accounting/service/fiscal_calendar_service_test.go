@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	app_errors "erp-system/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFiscalCalendarService_CreateFiscalYear(t *testing.T) {
+	mockPeriodRepo := mocks.NewFiscalPeriodRepositoryMock(t)
+	fiscalService := service.NewFiscalCalendarService(mockPeriodRepo)
+	ctx := context.Background()
+
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Twelve Monthly Periods", func(t *testing.T) {
+		mockPeriodRepo.On("FindOverlappingFiscalYears", ctx, start, end).Return([]*models.FiscalYear{}, nil).Once()
+		mockPeriodRepo.On("CreateFiscalYear", ctx, mock.AnythingOfType("*models.FiscalYear")).Return(func(_ context.Context, fy *models.FiscalYear) *models.FiscalYear {
+			return fy
+		}, nil).Once()
+
+		year, err := fiscalService.CreateFiscalYear(ctx, dto.CreateFiscalYearRequest{Name: "FY2027", StartDate: start})
+		assert.NoError(t, err)
+		assert.Equal(t, end, year.EndDate)
+		assert.Len(t, year.Periods, 12)
+		assert.Equal(t, "Apr 2026", year.Periods[0].Name)
+		assert.Equal(t, time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), year.Periods[0].EndDate)
+		assert.Equal(t, 12, year.Periods[11].PeriodNumber)
+		assert.Equal(t, time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC), year.Periods[11].StartDate)
+		assert.Equal(t, end, year.Periods[11].EndDate)
+		for _, p := range year.Periods {
+			assert.Equal(t, models.PeriodOpen, p.Status)
+		}
+		mockPeriodRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Overlaps Existing Year", func(t *testing.T) {
+		mockPeriodRepo.On("FindOverlappingFiscalYears", ctx, start, end).Return([]*models.FiscalYear{{ID: uuid.New(), Name: "FY2026"}}, nil).Once()
+
+		_, err := fiscalService.CreateFiscalYear(ctx, dto.CreateFiscalYearRequest{Name: "FY2027", StartDate: start, EndDate: end})
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		mockPeriodRepo.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - End Before Start", func(t *testing.T) {
+		_, err := fiscalService.CreateFiscalYear(ctx, dto.CreateFiscalYearRequest{Name: "FY2027", StartDate: start, EndDate: start.AddDate(0, 0, -1)})
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestFiscalCalendarService_SetPeriodStatus(t *testing.T) {
+	mockPeriodRepo := mocks.NewFiscalPeriodRepositoryMock(t)
+	fiscalService := service.NewFiscalCalendarService(mockPeriodRepo)
+
+	periodID, yearID := uuid.New(), uuid.New()
+	managerCtx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "m1", Roles: []string{auth.RoleAccountingManager}})
+	accountantCtx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "a1", Roles: []string{auth.RoleAccountant}})
+
+	t.Run("Success - Manager Closes Period", func(t *testing.T) {
+		mockPeriodRepo.On("GetPeriodByID", managerCtx, periodID).Return(&models.FiscalPeriod{ID: periodID, FiscalYearID: yearID, Name: "Jan 2026", Status: models.PeriodOpen}, nil).Once()
+		mockPeriodRepo.On("GetFiscalYearByID", managerCtx, yearID).Return(&models.FiscalYear{ID: yearID, Name: "FY2026", Status: models.PeriodOpen}, nil).Once()
+		mockPeriodRepo.On("UpdatePeriodStatus", managerCtx, periodID, models.PeriodClosed).Return(nil).Once()
+
+		period, err := fiscalService.SetPeriodStatus(managerCtx, periodID, models.PeriodClosed)
+		assert.NoError(t, err)
+		assert.Equal(t, models.PeriodClosed, period.Status)
+		mockPeriodRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Period Of Closed Year Cannot Be Reopened", func(t *testing.T) {
+		mockPeriodRepo.On("GetPeriodByID", managerCtx, periodID).Return(&models.FiscalPeriod{ID: periodID, FiscalYearID: yearID, Name: "Jan 2026", Status: models.PeriodClosed}, nil).Once()
+		mockPeriodRepo.On("GetFiscalYearByID", managerCtx, yearID).Return(&models.FiscalYear{ID: yearID, Name: "FY2026", Status: models.PeriodClosed}, nil).Once()
+
+		_, err := fiscalService.SetPeriodStatus(managerCtx, periodID, models.PeriodOpen)
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Contains(t, err.Error(), "reopen the year")
+		mockPeriodRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - Accountant Cannot Change Status", func(t *testing.T) {
+		_, err := fiscalService.SetPeriodStatus(accountantCtx, periodID, models.PeriodSoftClosed)
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+
+	t.Run("Validation Error - Invalid Status", func(t *testing.T) {
+		_, err := fiscalService.SetPeriodStatus(managerCtx, periodID, "LOCKED")
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestFiscalCalendarService_CheckPostingAllowed(t *testing.T) {
+	mockPeriodRepo := mocks.NewFiscalPeriodRepositoryMock(t)
+	fiscalService := service.NewFiscalCalendarService(mockPeriodRepo)
+
+	date := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	period := func(status models.PeriodStatus) *models.FiscalPeriod {
+		return &models.FiscalPeriod{ID: uuid.New(), Name: "Jan 2026", Status: status}
+	}
+	adminCtx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "admin", Roles: []string{auth.RoleAdmin}})
+	accountantCtx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "a1", Roles: []string{auth.RoleAccountant}})
+
+	t.Run("No Period Defined", func(t *testing.T) {
+		mockPeriodRepo.On("GetPeriodForDate", accountantCtx, date).Return(nil, app_errors.NewNotFoundError("fiscal_period_for_date", "2026-01-15")).Once()
+		assert.NoError(t, fiscalService.CheckPostingAllowed(accountantCtx, date))
+	})
+
+	t.Run("Open Period", func(t *testing.T) {
+		mockPeriodRepo.On("GetPeriodForDate", accountantCtx, date).Return(period(models.PeriodOpen), nil).Once()
+		assert.NoError(t, fiscalService.CheckPostingAllowed(accountantCtx, date))
+	})
+
+	t.Run("Soft-Closed Period - Privileged Role", func(t *testing.T) {
+		mockPeriodRepo.On("GetPeriodForDate", adminCtx, date).Return(period(models.PeriodSoftClosed), nil).Once()
+		assert.NoError(t, fiscalService.CheckPostingAllowed(adminCtx, date))
+	})
+
+	t.Run("Soft-Closed Period - Unprivileged Role", func(t *testing.T) {
+		mockPeriodRepo.On("GetPeriodForDate", accountantCtx, date).Return(period(models.PeriodSoftClosed), nil).Once()
+		err := fiscalService.CheckPostingAllowed(accountantCtx, date)
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+
+	t.Run("Closed Period - Even For Admin", func(t *testing.T) {
+		mockPeriodRepo.On("GetPeriodForDate", adminCtx, date).Return(period(models.PeriodClosed), nil).Once()
+		err := fiscalService.CheckPostingAllowed(adminCtx, date)
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Contains(t, err.Error(), "Jan 2026 is closed")
+	})
+	mockPeriodRepo.AssertExpectations(t)
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"erp-system/internal/accounting/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearchDays bounds the search for the next day matching a cron rule. Ten years covers
+// rules such as "29 February" that only match on some years.
+const maxCronSearchDays = 3653
+
+// recurrence yields the dates on which a recurring journal template produces an entry.
+type recurrence struct {
+	frequency models.RecurrenceFrequency
+	start     time.Time
+	end       *time.Time
+	cron      *cronRule
+}
+
+func newRecurrence(frequency models.RecurrenceFrequency, cronExpression string, start time.Time, end *time.Time) (*recurrence, error) {
+	r := &recurrence{frequency: frequency, start: dateOnly(start)}
+	if end != nil {
+		e := dateOnly(*end)
+		r.end = &e
+	}
+	switch frequency {
+	case models.FrequencyMonthly, models.FrequencyQuarterly:
+	case models.FrequencyCron:
+		rule, err := parseCronRule(cronExpression)
+		if err != nil {
+			return nil, err
+		}
+		r.cron = rule
+	default:
+		return nil, fmt.Errorf("frequency must be MONTHLY, QUARTERLY or CRON")
+	}
+	return r, nil
+}
+
+// next returns the first occurrence after the given date, or the first occurrence on or after the
+// start date when after is nil. ok is false once the schedule has no occurrences left.
+func (r *recurrence) next(after *time.Time) (time.Time, bool) {
+	var occurrence time.Time
+	if r.cron != nil {
+		from := r.start
+		if after != nil && !dateOnly(*after).Before(from) {
+			from = dateOnly(*after).AddDate(0, 0, 1)
+		}
+		found := false
+		for i := 0; i < maxCronSearchDays && !found; i++ {
+			occurrence = from.AddDate(0, 0, i)
+			found = r.cron.matches(occurrence)
+		}
+		if !found {
+			return time.Time{}, false
+		}
+	} else {
+		step := 1
+		if r.frequency == models.FrequencyQuarterly {
+			step = 3
+		}
+		k := 0
+		if after != nil {
+			// Start just before the occurrence that follows after instead of walking from the start date
+			months := (after.Year()-r.start.Year())*12 + int(after.Month()) - int(r.start.Month())
+			if months/step > 1 {
+				k = months/step - 1
+			}
+		}
+		for occurrence = shiftMonths(r.start, k*step); after != nil && !occurrence.After(dateOnly(*after)); {
+			k++
+			occurrence = shiftMonths(r.start, k*step)
+		}
+	}
+	if r.end != nil && occurrence.After(*r.end) {
+		return time.Time{}, false
+	}
+	return occurrence, true
+}
+
+// cronRule is the day part of a five-field cron expression (minute hour day-of-month month day-of-week).
+// The minute and hour fields are validated but ignored, as journal entries are dated by day.
+type cronRule struct {
+	daysOfMonth   map[int]bool
+	months        map[int]bool
+	daysOfWeek    map[int]bool
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+}
+
+func parseCronRule(expression string) (*cronRule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := cronMacros[expression]; ok {
+		expression = macro
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have five fields", expression)
+	}
+	if _, err := parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("cron minute: %w", err)
+	}
+	if _, err := parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("cron hour: %w", err)
+	}
+	dom, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("cron day of month: %w", err)
+	}
+	months, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("cron month: %w", err)
+	}
+	dow, err := parseCronField(fields[4], 0, 7)
+	if err != nil {
+		return nil, fmt.Errorf("cron day of week: %w", err)
+	}
+	if dow[7] {
+		dow[0] = true // 7 is also Sunday
+	}
+	return &cronRule{
+		daysOfMonth:   dom,
+		months:        months,
+		daysOfWeek:    dow,
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField expands a cron field made of "*", values, ranges "a-b" and steps "/n",
+// separated by commas, into the set of values it matches.
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil || lo > hi {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = n, n
+			if step > 1 {
+				hi = max // "5/15" means every 15 starting at 5
+			}
+		}
+		if lo < min || hi > max {
+			return nil, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// matches follows the usual cron rule: when both the day-of-month and day-of-week fields are
+// restricted, a day matching either of them matches.
+func (c *cronRule) matches(day time.Time) bool {
+	if !c.months[int(day.Month())] {
+		return false
+	}
+	domMatch := c.daysOfMonth[day.Day()]
+	dowMatch := c.daysOfWeek[int(day.Weekday())]
+	if c.anyDayOfMonth || c.anyDayOfWeek {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
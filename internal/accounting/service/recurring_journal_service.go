@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurringJournalService manages recurring journal templates and turns their due occurrences into
+// journal entries through the AccountingService.
+type RecurringJournalService interface {
+	CreateTemplate(ctx context.Context, req dto.CreateRecurringJournalTemplateRequest) (*models.RecurringJournalTemplate, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (*models.RecurringJournalTemplate, error)
+	ListTemplates(ctx context.Context) ([]*models.RecurringJournalTemplate, error)
+	UpdateTemplate(ctx context.Context, id uuid.UUID, req dto.UpdateRecurringJournalTemplateRequest) (*models.RecurringJournalTemplate, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+	ListRuns(ctx context.Context, templateID uuid.UUID) ([]*models.RecurringJournalRun, error)
+	ProcessDueTemplates(ctx context.Context, asOf time.Time) (int, error)
+}
+
+// recurringJournalService is an implementation of RecurringJournalService.
+type recurringJournalService struct {
+	repo       repository.RecurringJournalRepository
+	accounting AccountingService
+}
+
+// NewRecurringJournalService creates a new RecurringJournalService.
+func NewRecurringJournalService(repo repository.RecurringJournalRepository, accounting AccountingService) RecurringJournalService {
+	return &recurringJournalService{repo: repo, accounting: accounting}
+}
+
+func (s *recurringJournalService) CreateTemplate(ctx context.Context, req dto.CreateRecurringJournalTemplateRequest) (*models.RecurringJournalTemplate, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create recurring journal template %s", req.Name)
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || req.StartDate.IsZero() {
+		return nil, errors.NewValidationError("name and start_date are required", "")
+	}
+	if _, err := s.repo.GetTemplateByName(ctx, req.Name); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("recurring journal template %s already exists", req.Name))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	template := &models.RecurringJournalTemplate{
+		Name:           req.Name,
+		Description:    req.Description,
+		Reference:      req.Reference,
+		Frequency:      req.Frequency,
+		CronExpression: strings.TrimSpace(req.CronExpression),
+		StartDate:      dateOnly(req.StartDate),
+		EntryStatus:    req.EntryStatus,
+		IsActive:       true,
+	}
+	if req.EndDate != nil {
+		end := dateOnly(*req.EndDate)
+		template.EndDate = &end
+	}
+	if template.EntryStatus == "" {
+		template.EntryStatus = models.StatusDraft
+	}
+	lines, err := s.buildLines(ctx, req.Lines)
+	if err != nil {
+		return nil, err
+	}
+	template.Lines = lines
+	if err := s.schedule(template); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.CreateTemplate(ctx, template)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error creating recurring journal template %s: %v", req.Name, err)
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Created recurring journal template %s (%s), next run %s", created.Name, created.ID, formatRunDate(created.NextRunOn))
+	return created, nil
+}
+
+func (s *recurringJournalService) GetTemplate(ctx context.Context, id uuid.UUID) (*models.RecurringJournalTemplate, error) {
+	return s.repo.GetTemplateByID(ctx, id)
+}
+
+func (s *recurringJournalService) ListTemplates(ctx context.Context) ([]*models.RecurringJournalTemplate, error) {
+	return s.repo.ListTemplates(ctx)
+}
+
+// UpdateTemplate applies the given changes and recomputes the next occurrence after the last run,
+// so changing the schedule never repeats an occurrence that already produced an entry. Reactivating
+// a paused template resumes it from today.
+func (s *recurringJournalService) UpdateTemplate(ctx context.Context, id uuid.UUID, req dto.UpdateRecurringJournalTemplateRequest) (*models.RecurringJournalTemplate, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update recurring journal template %s", id)
+	template, err := s.repo.GetTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		if name != template.Name {
+			if _, err := s.repo.GetTemplateByName(ctx, name); err == nil {
+				return nil, errors.NewConflictError(fmt.Sprintf("recurring journal template %s already exists", name))
+			} else if !isNotFoundError(err) {
+				return nil, err
+			}
+		}
+		template.Name = name
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Reference != nil {
+		template.Reference = *req.Reference
+	}
+	if req.Frequency != nil {
+		template.Frequency = *req.Frequency
+	}
+	if req.CronExpression != nil {
+		template.CronExpression = strings.TrimSpace(*req.CronExpression)
+	}
+	if req.StartDate != nil {
+		template.StartDate = dateOnly(*req.StartDate)
+	}
+	if req.EndDate != nil {
+		end := dateOnly(*req.EndDate)
+		template.EndDate = &end
+	}
+	if req.EntryStatus != nil {
+		template.EntryStatus = *req.EntryStatus
+	}
+	resumed := false
+	if req.IsActive != nil {
+		resumed = *req.IsActive && !template.IsActive
+		template.IsActive = *req.IsActive
+	}
+	if req.Lines != nil {
+		lines, err := s.buildLines(ctx, req.Lines)
+		if err != nil {
+			return nil, err
+		}
+		template.Lines = lines
+	}
+	if resumed {
+		// Occurrences that fell due while the template was paused are skipped, not back-filled:
+		// pausing means no entries for that time. They are marked as run so that later changes to
+		// the schedule do not bring them back.
+		today := dateOnly(time.Now())
+		if template.NextRunOn != nil && template.NextRunOn.Before(today) {
+			yesterday := today.AddDate(0, 0, -1)
+			template.LastRunOn = &yesterday
+			logger.InfoLogger.Printf("Service: Recurring journal template %s resumed; occurrences from %s to %s are skipped", id, formatRunDate(template.NextRunOn), formatRunDate(&yesterday))
+		}
+	}
+	if err := s.schedule(template); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateTemplate(ctx, template)
+	if err != nil {
+		logger.ErrorLogger.Printf("Service: Error updating recurring journal template %s: %v", id, err)
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Updated recurring journal template %s, next run %s", id, formatRunDate(updated.NextRunOn))
+	return updated, nil
+}
+
+// DeleteTemplate stops the schedule. Entries already created from the template are kept.
+func (s *recurringJournalService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	logger.InfoLogger.Printf("Service: Attempting to delete recurring journal template %s", id)
+	if _, err := s.repo.GetTemplateByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteTemplate(ctx, id)
+}
+
+func (s *recurringJournalService) ListRuns(ctx context.Context, templateID uuid.UUID) ([]*models.RecurringJournalRun, error) {
+	if _, err := s.repo.GetTemplateByID(ctx, templateID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRuns(ctx, templateID)
+}
+
+// ProcessDueTemplates creates the entries of every occurrence due on or before asOf and returns how
+// many were created. Missed occurrences, e.g. while the server was down, are caught up in date order.
+// Each occurrence is first claimed as a PENDING run, so it is never claimed twice; its entry is then
+// created and the run completed in one transaction. Runs left FAILED, or left PENDING by a crash
+// between the two steps, are retried on every call until their entry can be created.
+func (s *recurringJournalService) ProcessDueTemplates(ctx context.Context, asOf time.Time) (int, error) {
+	asOf = dateOnly(asOf)
+	created := 0
+
+	retryable, err := s.repo.ListRetryableRuns(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+	for _, run := range retryable {
+		ok, err := s.completeRun(ctx, run.Template, run)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+
+	templates, err := s.repo.ListDueTemplates(ctx, asOf)
+	if err != nil {
+		return created, err
+	}
+	for _, template := range templates {
+		rule, err := newRecurrence(template.Frequency, template.CronExpression, template.StartDate, template.EndDate)
+		if err != nil {
+			logger.ErrorLogger.Printf("Service: Recurring journal template %s has an invalid schedule: %v", template.ID, err)
+			continue
+		}
+		for template.NextRunOn != nil && !template.NextRunOn.After(asOf) {
+			runDate := dateOnly(*template.NextRunOn)
+			var nextRunOn *time.Time
+			if next, ok := rule.next(&runDate); ok {
+				nextRunOn = &next
+			}
+
+			run, err := s.repo.ClaimRun(ctx, template.ID, runDate, nextRunOn)
+			if err != nil {
+				if _, ok := err.(*errors.ConflictError); ok {
+					break // Claimed by another scheduler; it continues from here
+				}
+				return created, err
+			}
+			template.LastRunOn, template.NextRunOn = &runDate, nextRunOn
+
+			ok, err := s.completeRun(ctx, template, run)
+			if err != nil {
+				return created, err
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+	return created, nil
+}
+
+// completeRun creates the entry of a claimed run and reports whether it did. If the entry cannot be
+// created the run is recorded as FAILED and retried on the next call.
+func (s *recurringJournalService) completeRun(ctx context.Context, template *models.RecurringJournalTemplate, run *models.RecurringJournalRun) (bool, error) {
+	runDate := dateOnly(run.RunDate)
+	entry, err := s.accounting.PrepareJournalEntry(ctx, entryRequestFromTemplate(template, runDate))
+	if err == nil {
+		entry, err = s.repo.CompleteRun(ctx, run.ID, entry)
+		if _, ok := err.(*errors.ConflictError); ok {
+			return false, nil // Completed by another scheduler
+		}
+	}
+	if err != nil {
+		logger.WarnLogger.Printf("Service: Recurring journal template %s failed for %s: %v", template.Name, runDate.Format("2006-01-02"), err)
+		if err := s.repo.RecordRunFailure(ctx, run.ID, err.Error()); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	logger.InfoLogger.Printf("Service: Recurring journal template %s created %s entry %s for %s", template.Name, entry.Status, entry.ID, runDate.Format("2006-01-02"))
+	return true, nil
+}
+
+// buildLines validates template lines the way a journal entry is validated: active accounts,
+// positive amounts within the currency's decimal places and equal debits and credits.
+func (s *recurringJournalService) buildLines(ctx context.Context, reqLines []dto.JournalLineRequest) ([]models.RecurringJournalLine, error) {
+	if len(reqLines) < 2 {
+		return nil, errors.NewValidationError("a recurring journal template needs at least two lines", "lines")
+	}
+	totalDebits, totalCredits := money.Zero, money.Zero
+	lines := make([]models.RecurringJournalLine, len(reqLines))
+	for i, lineReq := range reqLines {
+		if lineReq.AccountID == uuid.Nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: account_id is required", i+1), "lines.account_id")
+		}
+		if !lineReq.Amount.IsPositive() {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: amount must be positive", i+1), "lines.amount")
+		}
+		account, err := s.accounting.GetChartOfAccountByID(ctx, lineReq.AccountID)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s not found", i+1, lineReq.AccountID), "lines.account_id")
+			}
+			return nil, err
+		}
+		if !account.IsActive {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s (%s) is inactive", i+1, account.AccountCode, account.AccountName), "lines.account_id")
+		}
+		currency := lineReq.Currency
+		if currency == "" {
+			currency = s.accounting.BaseCurrency(ctx)
+		}
+		if err := lineReq.Amount.CheckPrecision(currency); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", i+1, err), "lines.amount")
+		}
+		lines[i] = models.RecurringJournalLine{AccountID: lineReq.AccountID, Amount: lineReq.Amount, Currency: currency, IsDebit: lineReq.IsDebit, Dimensions: lineReq.Dimensions}
+		if lineReq.IsDebit {
+			totalDebits = totalDebits.Add(lineReq.Amount)
+		} else {
+			totalCredits = totalCredits.Add(lineReq.Amount)
+		}
+	}
+	if !totalDebits.Equal(totalCredits) {
+		return nil, errors.NewValidationError(fmt.Sprintf("template is not balanced (Debits: %s, Credits: %s)", totalDebits, totalCredits), "lines")
+	}
+	return lines, nil
+}
+
+// schedule validates the template's schedule and sets NextRunOn to the first occurrence after
+// LastRunOn, or to the first occurrence of the schedule if the template has never run.
+func (s *recurringJournalService) schedule(template *models.RecurringJournalTemplate) error {
+	if template.EntryStatus != models.StatusDraft && template.EntryStatus != models.StatusPosted {
+		return errors.NewValidationError("entry_status must be DRAFT or POSTED", "entry_status")
+	}
+	if template.EndDate != nil && template.EndDate.Before(template.StartDate) {
+		return errors.NewValidationError("end_date must not be before start_date", "end_date")
+	}
+	if template.Frequency != models.FrequencyCron {
+		template.CronExpression = ""
+	}
+	rule, err := newRecurrence(template.Frequency, template.CronExpression, template.StartDate, template.EndDate)
+	if err != nil {
+		field := "frequency"
+		if template.Frequency == models.FrequencyCron {
+			field = "cron_expression"
+		}
+		return errors.NewValidationError(err.Error(), field)
+	}
+	template.NextRunOn = nil
+	if next, ok := rule.next(template.LastRunOn); ok {
+		template.NextRunOn = &next
+	}
+	return nil
+}
+
+func entryRequestFromTemplate(template *models.RecurringJournalTemplate, runDate time.Time) dto.CreateJournalEntryRequest {
+	req := dto.CreateJournalEntryRequest{
+		EntryDate:   runDate,
+		Description: template.Description,
+		Reference:   template.Reference,
+		Status:      template.EntryStatus,
+	}
+	if req.Description == "" {
+		req.Description = template.Name
+	}
+	for _, line := range template.Lines {
+		req.Lines = append(req.Lines, dto.JournalLineRequest{AccountID: line.AccountID, Amount: line.Amount, Currency: line.Currency, IsDebit: line.IsDebit, Dimensions: line.Dimensions})
+	}
+	return req
+}
+
+func formatRunDate(date *time.Time) string {
+	if date == nil {
+		return "none"
+	}
+	return date.Format("2006-01-02")
+}
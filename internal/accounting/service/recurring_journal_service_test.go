@@ -0,0 +1,292 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// stubAccountingService records the journal entries requested by the recurring journal service.
+type stubAccountingService struct {
+	service.AccountingService
+	created   []dto.CreateJournalEntryRequest
+	createErr error
+}
+
+func (s *stubAccountingService) BaseCurrency(_ context.Context) string {
+	return service.DefaultBaseCurrency
+}
+
+func (s *stubAccountingService) GetChartOfAccountByID(_ context.Context, id uuid.UUID) (*models.ChartOfAccount, error) {
+	return &models.ChartOfAccount{ID: id, AccountCode: "6100", IsActive: true}, nil
+}
+
+func (s *stubAccountingService) PrepareJournalEntry(_ context.Context, req dto.CreateJournalEntryRequest) (*models.JournalEntry, error) {
+	if s.createErr != nil {
+		return nil, s.createErr
+	}
+	s.created = append(s.created, req)
+	return &models.JournalEntry{EntryDate: req.EntryDate, Status: req.Status}, nil
+}
+
+// completeRun stands in for the repository saving the prepared entry.
+func completeRun(_ context.Context, _ uuid.UUID, entry *models.JournalEntry) *models.JournalEntry {
+	entry.ID = uuid.New()
+	return entry
+}
+
+func rentLines(rentAccountID, cashAccountID uuid.UUID) []dto.JournalLineRequest {
+	return []dto.JournalLineRequest{
+		{AccountID: rentAccountID, Amount: money.MustParse("1500.00"), IsDebit: true},
+		{AccountID: cashAccountID, Amount: money.MustParse("1500.00"), IsDebit: false},
+	}
+}
+
+func TestRecurringJournalService_CreateTemplate(t *testing.T) {
+	mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+	recurringService := service.NewRecurringJournalService(mockRepo, &stubAccountingService{})
+	ctx := context.Background()
+	rentAccountID, cashAccountID := uuid.New(), uuid.New()
+	returnCreated := func(_ context.Context, tpl *models.RecurringJournalTemplate) *models.RecurringJournalTemplate {
+		return tpl
+	}
+
+	t.Run("Success - Monthly From Start Date", func(t *testing.T) {
+		mockRepo.On("GetTemplateByName", ctx, "Office rent").Return(nil, app_errors.NewNotFoundError("recurring_journal_template_name", "Office rent")).Once()
+		mockRepo.On("CreateTemplate", ctx, mock.AnythingOfType("*models.RecurringJournalTemplate")).Return(returnCreated, nil).Once()
+
+		tpl, err := recurringService.CreateTemplate(ctx, dto.CreateRecurringJournalTemplateRequest{
+			Name: "Office rent", Frequency: models.FrequencyMonthly,
+			StartDate: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), Lines: rentLines(rentAccountID, cashAccountID),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusDraft, tpl.EntryStatus)
+		assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), *tpl.NextRunOn)
+		assert.Len(t, tpl.Lines, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Cron First Matching Day", func(t *testing.T) {
+		mockRepo.On("GetTemplateByName", ctx, "Software").Return(nil, app_errors.NewNotFoundError("recurring_journal_template_name", "Software")).Once()
+		mockRepo.On("CreateTemplate", ctx, mock.AnythingOfType("*models.RecurringJournalTemplate")).Return(returnCreated, nil).Once()
+
+		// 15th of every month, or any Monday
+		tpl, err := recurringService.CreateTemplate(ctx, dto.CreateRecurringJournalTemplateRequest{
+			Name: "Software", Frequency: models.FrequencyCron, CronExpression: "0 0 15 * 1",
+			StartDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Lines: rentLines(rentAccountID, cashAccountID),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), *tpl.NextRunOn)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Invalid Cron Expression", func(t *testing.T) {
+		mockRepo.On("GetTemplateByName", ctx, "Broken").Return(nil, app_errors.NewNotFoundError("recurring_journal_template_name", "Broken")).Once()
+
+		_, err := recurringService.CreateTemplate(ctx, dto.CreateRecurringJournalTemplateRequest{
+			Name: "Broken", Frequency: models.FrequencyCron, CronExpression: "0 0 32 * *",
+			StartDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), Lines: rentLines(rentAccountID, cashAccountID),
+		})
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Validation Error - Unbalanced Lines", func(t *testing.T) {
+		mockRepo.On("GetTemplateByName", ctx, "Unbalanced").Return(nil, app_errors.NewNotFoundError("recurring_journal_template_name", "Unbalanced")).Once()
+		lines := rentLines(rentAccountID, cashAccountID)
+		lines[1].Amount = money.MustParse("1400.00")
+
+		_, err := recurringService.CreateTemplate(ctx, dto.CreateRecurringJournalTemplateRequest{
+			Name: "Unbalanced", Frequency: models.FrequencyMonthly, StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Lines: lines,
+		})
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - Duplicate Name", func(t *testing.T) {
+		mockRepo.On("GetTemplateByName", ctx, "Office rent").Return(&models.RecurringJournalTemplate{ID: uuid.New(), Name: "Office rent"}, nil).Once()
+
+		_, err := recurringService.CreateTemplate(ctx, dto.CreateRecurringJournalTemplateRequest{
+			Name: "Office rent", Frequency: models.FrequencyMonthly, StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Lines: rentLines(rentAccountID, cashAccountID),
+		})
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+}
+
+func TestRecurringJournalService_ProcessDueTemplates(t *testing.T) {
+	ctx := context.Background()
+	rentAccountID, cashAccountID := uuid.New(), uuid.New()
+	quarterly := func(nextRunOn time.Time, end *time.Time) *models.RecurringJournalTemplate {
+		return &models.RecurringJournalTemplate{
+			ID: uuid.New(), Name: "Insurance", Description: "Quarterly insurance", Frequency: models.FrequencyQuarterly,
+			StartDate: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), EndDate: end, EntryStatus: models.StatusPosted, IsActive: true,
+			NextRunOn: &nextRunOn,
+			Lines: []models.RecurringJournalLine{
+				{AccountID: rentAccountID, Amount: money.MustParse("900.00"), Currency: "USD", IsDebit: true},
+				{AccountID: cashAccountID, Amount: money.MustParse("900.00"), Currency: "USD", IsDebit: false},
+			},
+		}
+	}
+
+	t.Run("Success - Catches Up Missed Occurrences", func(t *testing.T) {
+		mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+		accounting := &stubAccountingService{}
+		recurringService := service.NewRecurringJournalService(mockRepo, accounting)
+
+		asOf := time.Date(2026, 5, 2, 9, 30, 0, 0, time.UTC)
+		jan31, apr30, jul31 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)
+		tpl := quarterly(jan31, nil)
+		may2 := time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("ListRetryableRuns", ctx, may2).Return(nil, nil).Once()
+		mockRepo.On("ListDueTemplates", ctx, may2).Return([]*models.RecurringJournalTemplate{tpl}, nil).Once()
+		runA, runB := &models.RecurringJournalRun{ID: uuid.New(), RunDate: jan31}, &models.RecurringJournalRun{ID: uuid.New(), RunDate: apr30}
+		mockRepo.On("ClaimRun", ctx, tpl.ID, jan31, &apr30).Return(runA, nil).Once()
+		mockRepo.On("ClaimRun", ctx, tpl.ID, apr30, &jul31).Return(runB, nil).Once()
+		mockRepo.On("CompleteRun", ctx, runA.ID, mock.AnythingOfType("*models.JournalEntry")).Return(completeRun, nil).Once()
+		mockRepo.On("CompleteRun", ctx, runB.ID, mock.AnythingOfType("*models.JournalEntry")).Return(completeRun, nil).Once()
+
+		created, err := recurringService.ProcessDueTemplates(ctx, asOf)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, created)
+		if assert.Len(t, accounting.created, 2) {
+			assert.Equal(t, jan31, accounting.created[0].EntryDate)
+			assert.Equal(t, apr30, accounting.created[1].EntryDate)
+			assert.Equal(t, models.StatusPosted, accounting.created[1].Status)
+			assert.Equal(t, "Quarterly insurance", accounting.created[1].Description)
+			assert.Len(t, accounting.created[1].Lines, 2)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Already Claimed - No Duplicate Entry", func(t *testing.T) {
+		mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+		accounting := &stubAccountingService{}
+		recurringService := service.NewRecurringJournalService(mockRepo, accounting)
+
+		jan31, apr30 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+		tpl := quarterly(jan31, nil)
+		mockRepo.On("ListRetryableRuns", ctx, jan31).Return(nil, nil).Once()
+		mockRepo.On("ListDueTemplates", ctx, jan31).Return([]*models.RecurringJournalTemplate{tpl}, nil).Once()
+		mockRepo.On("ClaimRun", ctx, tpl.ID, jan31, &apr30).Return(nil, app_errors.NewConflictError("already claimed")).Once()
+
+		created, err := recurringService.ProcessDueTemplates(ctx, jan31)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		assert.Empty(t, accounting.created)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Entry Rejected - Run Recorded As Failed For Retry", func(t *testing.T) {
+		mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+		accounting := &stubAccountingService{createErr: app_errors.NewConflictError("fiscal period Jan 2026 is closed")}
+		recurringService := service.NewRecurringJournalService(mockRepo, accounting)
+
+		jan31 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+		tpl := quarterly(jan31, &end)
+		run := &models.RecurringJournalRun{ID: uuid.New(), RunDate: jan31}
+		mockRepo.On("ListRetryableRuns", ctx, jan31).Return(nil, nil).Once()
+		mockRepo.On("ListDueTemplates", ctx, jan31).Return([]*models.RecurringJournalTemplate{tpl}, nil).Once()
+		mockRepo.On("ClaimRun", ctx, tpl.ID, jan31, (*time.Time)(nil)).Return(run, nil).Once()
+		mockRepo.On("RecordRunFailure", ctx, run.ID, "conflict: fiscal period Jan 2026 is closed").Return(nil).Once()
+
+		created, err := recurringService.ProcessDueTemplates(ctx, jan31)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		assert.Nil(t, tpl.NextRunOn)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Run - Retried On Next Call", func(t *testing.T) {
+		mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+		accounting := &stubAccountingService{}
+		recurringService := service.NewRecurringJournalService(mockRepo, accounting)
+
+		jan31, feb2 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+		apr30 := time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)
+		tpl := quarterly(apr30, nil)
+		run := &models.RecurringJournalRun{ID: uuid.New(), TemplateID: tpl.ID, RunDate: jan31, Status: models.RunFailed, Template: tpl}
+		mockRepo.On("ListRetryableRuns", ctx, feb2).Return([]*models.RecurringJournalRun{run}, nil).Once()
+		mockRepo.On("CompleteRun", ctx, run.ID, mock.AnythingOfType("*models.JournalEntry")).Return(completeRun, nil).Once()
+		mockRepo.On("ListDueTemplates", ctx, feb2).Return(nil, nil).Once()
+
+		created, err := recurringService.ProcessDueTemplates(ctx, feb2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, created)
+		if assert.Len(t, accounting.created, 1) {
+			assert.Equal(t, jan31, accounting.created[0].EntryDate)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Pending Run Completed Concurrently - Not Counted", func(t *testing.T) {
+		mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+		recurringService := service.NewRecurringJournalService(mockRepo, &stubAccountingService{})
+
+		jan31 := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		tpl := quarterly(time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), nil)
+		run := &models.RecurringJournalRun{ID: uuid.New(), TemplateID: tpl.ID, RunDate: jan31, Status: models.RunPending, Template: tpl}
+		mockRepo.On("ListRetryableRuns", ctx, jan31).Return([]*models.RecurringJournalRun{run}, nil).Once()
+		mockRepo.On("CompleteRun", ctx, run.ID, mock.AnythingOfType("*models.JournalEntry")).Return(nil, app_errors.NewConflictError("already completed")).Once()
+		mockRepo.On("ListDueTemplates", ctx, jan31).Return(nil, nil).Once()
+
+		created, err := recurringService.ProcessDueTemplates(ctx, jan31)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, created)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRecurringJournalService_UpdateTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Reactivated Template Skips Occurrences Missed While Paused", func(t *testing.T) {
+		mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+		recurringService := service.NewRecurringJournalService(mockRepo, &stubAccountingService{})
+
+		lastRun, nextRun := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+		tpl := &models.RecurringJournalTemplate{
+			ID: uuid.New(), Name: "Office rent", Frequency: models.FrequencyMonthly, StartDate: lastRun,
+			EntryStatus: models.StatusDraft, IsActive: false, LastRunOn: &lastRun, NextRunOn: &nextRun,
+		}
+		mockRepo.On("GetTemplateByID", ctx, tpl.ID).Return(tpl, nil).Once()
+		mockRepo.On("UpdateTemplate", ctx, tpl).Return(tpl, nil).Once()
+
+		active := true
+		updated, err := recurringService.UpdateTemplate(ctx, tpl.ID, dto.UpdateRecurringJournalTemplateRequest{IsActive: &active})
+		assert.NoError(t, err)
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		assert.True(t, updated.IsActive)
+		assert.Equal(t, today.AddDate(0, 0, -1), *updated.LastRunOn)
+		if assert.NotNil(t, updated.NextRunOn) {
+			assert.False(t, updated.NextRunOn.Before(today), "paused occurrences must not be back-filled")
+		}
+	})
+
+	t.Run("Validation Error - Amount Beyond Currency Precision", func(t *testing.T) {
+		mockRepo := mocks.NewRecurringJournalRepositoryMock(t)
+		recurringService := service.NewRecurringJournalService(mockRepo, &stubAccountingService{})
+
+		tpl := &models.RecurringJournalTemplate{ID: uuid.New(), Name: "Office rent", Frequency: models.FrequencyMonthly, StartDate: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), IsActive: true}
+		mockRepo.On("GetTemplateByID", ctx, tpl.ID).Return(tpl, nil).Once()
+		lines := rentLines(uuid.New(), uuid.New())
+		lines[0].Amount, lines[1].Amount = money.MustParse("1500.005"), money.MustParse("1500.005")
+
+		_, err := recurringService.UpdateTemplate(ctx, tpl.ID, dto.UpdateRecurringJournalTemplateRequest{Lines: lines})
+		assert.Error(t, err)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		mockRepo.AssertNotCalled(t, "UpdateTemplate", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,486 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/xml"
+	"erp-system/internal/accounting/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// parsedStatement is a bank statement read from a file, before it is checked against the bank
+// account. Fields the format does not carry are left empty.
+type parsedStatement struct {
+	Format         models.StatementFormat
+	Reference      string
+	AccountNumber  string
+	Currency       string
+	StartDate      time.Time
+	EndDate        time.Time
+	OpeningBalance *money.Amount
+	ClosingBalance *money.Amount
+	Lines          []parsedStatementLine
+}
+
+// parsedStatementLine is one transaction of a parsed statement. Amount is positive for money
+// received and negative for money paid out.
+type parsedStatementLine struct {
+	Date        time.Time
+	Amount      money.Amount
+	Description string
+	Reference   string
+	ExternalID  string // Empty when the file does not identify its transactions
+}
+
+// detectStatementFormat guesses a statement file's format from its content: OFX files start with
+// an OFX header or element, camt.053 files hold a BkToCstmrStmt element, and anything else is
+// read as CSV.
+func detectStatementFormat(data []byte) models.StatementFormat {
+	head := strings.ToUpper(string(data[:min(len(data), 4096)]))
+	switch {
+	case strings.Contains(head, "OFXHEADER") || strings.Contains(head, "<OFX>"):
+		return models.StatementOFX
+	case strings.Contains(string(data), "BkToCstmrStmt"):
+		return models.StatementCAMT053
+	default:
+		return models.StatementCSV
+	}
+}
+
+// parseStatement reads a statement file in the given format.
+func parseStatement(format models.StatementFormat, data []byte) (*parsedStatement, error) {
+	var statement *parsedStatement
+	var err error
+	switch format {
+	case models.StatementCSV:
+		statement, err = parseCSVStatement(data)
+	case models.StatementOFX:
+		statement, err = parseOFXStatement(data)
+	case models.StatementCAMT053:
+		statement, err = parseCAMT053Statement(data)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported statement format %q; use CSV, OFX or CAMT053", format), "format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	statement.Format = format
+	for _, line := range statement.Lines {
+		if statement.StartDate.IsZero() || line.Date.Before(statement.StartDate) {
+			statement.StartDate = line.Date
+		}
+		if line.Date.After(statement.EndDate) {
+			statement.EndDate = line.Date
+		}
+	}
+	return statement, nil
+}
+
+// parseCSVStatement reads a CSV statement. The header names the columns, in any order: date
+// (YYYY-MM-DD) and amount (signed) are required; description, reference and id are optional.
+func parseCSVStatement(data []byte) (*parsedStatement, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.NewValidationError("the file is empty", "file")
+	}
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid CSV: %v", err), "file")
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["date"]; !ok {
+		return nil, errors.NewValidationError("the header must name a date and an amount column", "file")
+	}
+	if _, ok := columns["amount"]; !ok {
+		return nil, errors.NewValidationError("the header must name a date and an amount column", "file")
+	}
+	reader.FieldsPerRecord = len(header)
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	statement := &parsedStatement{}
+	var problems []string
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", row, err))
+			continue
+		}
+		date, err := time.Parse("2006-01-02", field(record, "date"))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: date %q is not a YYYY-MM-DD date", row, field(record, "date")))
+			continue
+		}
+		amount, err := parseStatementAmount(field(record, "amount"))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: amount %q is not a number", row, field(record, "amount")))
+			continue
+		}
+		statement.Lines = append(statement.Lines, parsedStatementLine{
+			Date:        date,
+			Amount:      amount,
+			Description: field(record, "description"),
+			Reference:   field(record, "reference"),
+			ExternalID:  field(record, "id"),
+		})
+	}
+	if len(problems) > 0 {
+		if len(problems) > maxImportErrors {
+			problems = append(problems[:maxImportErrors], fmt.Sprintf("and %d more", len(problems)-maxImportErrors))
+		}
+		return nil, errors.NewValidationError("no lines were imported: "+strings.Join(problems, "; "), "file")
+	}
+	return statement, nil
+}
+
+// parseOFXStatement reads the bank statement of an OFX file, in either the SGML (OFX 1.x,
+// where leaf elements are not closed) or the XML (OFX 2.x) dialect.
+func parseOFXStatement(data []byte) (*parsedStatement, error) {
+	text := string(data)
+	start := strings.Index(strings.ToUpper(text), "<OFX>")
+	if start < 0 {
+		return nil, errors.NewValidationError("the file has no OFX element", "file")
+	}
+
+	statement := &parsedStatement{}
+	var line *parsedStatementLine
+	var name, memo string
+	var aggregates []string
+	for _, element := range scanOFXElements(text[start:]) {
+		if element.closing {
+			if len(aggregates) > 0 && aggregates[len(aggregates)-1] == element.tag {
+				aggregates = aggregates[:len(aggregates)-1]
+			}
+			if element.tag == "STMTTRN" && line != nil {
+				line.Description = strings.TrimSpace(strings.Join(nonEmpty(name, memo), " - "))
+				statement.Lines = append(statement.Lines, *line)
+				line = nil
+			}
+			continue
+		}
+		if element.value == "" {
+			aggregates = append(aggregates, element.tag)
+			if element.tag == "STMTTRN" {
+				line, name, memo = &parsedStatementLine{}, "", ""
+			}
+			continue
+		}
+
+		parent := ""
+		if len(aggregates) > 0 {
+			parent = aggregates[len(aggregates)-1]
+		}
+		var err error
+		switch {
+		case parent == "STMTTRN" && line != nil:
+			switch element.tag {
+			case "DTPOSTED":
+				line.Date, err = parseOFXDate(element.value)
+			case "TRNAMT":
+				line.Amount, err = parseStatementAmount(element.value)
+			case "FITID":
+				line.ExternalID = element.value
+			case "NAME":
+				name = element.value
+			case "MEMO":
+				memo = element.value
+			case "CHECKNUM", "REFNUM":
+				if line.Reference == "" {
+					line.Reference = element.value
+				}
+			}
+		case parent == "LEDGERBAL" && element.tag == "BALAMT":
+			var balance money.Amount
+			balance, err = parseStatementAmount(element.value)
+			statement.ClosingBalance = &balance
+		case parent == "BANKTRANLIST" && element.tag == "DTSTART":
+			statement.StartDate, err = parseOFXDate(element.value)
+		case parent == "BANKTRANLIST" && element.tag == "DTEND":
+			statement.EndDate, err = parseOFXDate(element.value)
+		case element.tag == "CURDEF":
+			statement.Currency = strings.ToUpper(element.value)
+		case parent == "BANKACCTFROM" && element.tag == "ACCTID":
+			statement.AccountNumber = element.value
+		}
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("invalid OFX %s %q", element.tag, element.value), "file")
+		}
+	}
+	for _, line := range statement.Lines {
+		if line.Date.IsZero() {
+			return nil, errors.NewValidationError("an OFX transaction has no DTPOSTED", "file")
+		}
+	}
+	return statement, nil
+}
+
+// ofxElement is a start or end tag of an OFX file and the text that follows it.
+type ofxElement struct {
+	tag     string
+	closing bool
+	value   string
+}
+
+// scanOFXElements splits OFX markup into its tags. A start tag followed by text is a leaf
+// element; its end tag, if the file has one, comes back as a closing element and is ignored by
+// the caller because no aggregate is open under that name.
+func scanOFXElements(text string) []ofxElement {
+	var elements []ofxElement
+	for {
+		open := strings.IndexByte(text, '<')
+		if open < 0 {
+			return elements
+		}
+		end := strings.IndexByte(text[open:], '>')
+		if end < 0 {
+			return elements
+		}
+		tag := strings.ToUpper(strings.TrimSpace(text[open+1 : open+end]))
+		text = text[open+end+1:]
+		next := strings.IndexByte(text, '<')
+		if next < 0 {
+			next = len(text)
+		}
+		value := strings.TrimSpace(xmlUnescape(text[:next]))
+		if strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") || strings.HasSuffix(tag, "/") {
+			continue
+		}
+		if strings.HasPrefix(tag, "/") {
+			elements = append(elements, ofxElement{tag: strings.TrimPrefix(tag, "/"), closing: true})
+			continue
+		}
+		elements = append(elements, ofxElement{tag: tag, value: value})
+	}
+}
+
+// parseOFXDate reads the date part of an OFX date-time such as 20240131120000[-5:EST].
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("date %q is too short", value)
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+// camtDocument is the part of an ISO 20022 camt.053 document the import reads. Element names are
+// matched without their namespace, so every camt.053 version is accepted.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID       string        `xml:"Id"`
+	IBAN     string        `xml:"Acct>Id>IBAN"`
+	Other    string        `xml:"Acct>Id>Othr>Id"`
+	Currency string        `xml:"Acct>Ccy"`
+	From     string        `xml:"FrToDt>FrDtTm"`
+	To       string        `xml:"FrToDt>ToDtTm"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtBalance struct {
+	Type      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtEntry struct {
+	Amount         camtAmount `xml:"Amt"`
+	Indicator      string     `xml:"CdtDbtInd"`
+	Status         camtStatus `xml:"Sts"`
+	BookingDate    camtDate   `xml:"BookgDt"`
+	ValueDate      camtDate   `xml:"ValDt"`
+	ServicerRef    string     `xml:"AcctSvcrRef"`
+	EndToEndID     string     `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+	Remittance     []string   `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+	AdditionalInfo string     `xml:"AddtlNtryInf"`
+}
+
+// camtStatus holds an entry status, which camt.053.001.02 gives as text and later versions as a
+// Cd element.
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// parseCAMT053Statement reads an ISO 20022 camt.053 statement. Only booked entries are imported.
+func parseCAMT053Statement(data []byte) (*parsedStatement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid camt.053 XML: %v", err), "file")
+	}
+	if len(document.Statements) != 1 {
+		return nil, errors.NewValidationError(fmt.Sprintf("the file holds %d statements; import exactly one at a time", len(document.Statements)), "file")
+	}
+	stmt := document.Statements[0]
+
+	statement := &parsedStatement{
+		Reference:     strings.TrimSpace(stmt.ID),
+		AccountNumber: strings.TrimSpace(stmt.IBAN),
+		Currency:      strings.ToUpper(strings.TrimSpace(stmt.Currency)),
+	}
+	if statement.AccountNumber == "" {
+		statement.AccountNumber = strings.TrimSpace(stmt.Other)
+	}
+	if stmt.From != "" && stmt.To != "" {
+		from, fromErr := parseCAMTDate(stmt.From)
+		to, toErr := parseCAMTDate(stmt.To)
+		if fromErr != nil || toErr != nil {
+			return nil, errors.NewValidationError("invalid camt.053 statement period", "file")
+		}
+		statement.StartDate, statement.EndDate = from, to
+	}
+	for _, balance := range stmt.Balances {
+		amount, err := camtSignedAmount(balance.Amount, balance.Indicator)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.TrimSpace(balance.Type) {
+		case "OPBD", "PRCD":
+			if statement.OpeningBalance == nil {
+				statement.OpeningBalance = &amount
+			}
+		case "CLBD":
+			statement.ClosingBalance = &amount
+		}
+		if statement.Currency == "" {
+			statement.Currency = strings.ToUpper(balance.Amount.Currency)
+		}
+	}
+
+	for i, entry := range stmt.Entries {
+		status := strings.TrimSpace(entry.Status.Code)
+		if status == "" {
+			status = strings.TrimSpace(entry.Status.Value)
+		}
+		if status != "" && status != "BOOK" {
+			continue // Pending and informational entries are not on the account yet
+		}
+		amount, err := camtSignedAmount(entry.Amount, entry.Indicator)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Amount.Currency != "" && statement.Currency != "" && !strings.EqualFold(entry.Amount.Currency, statement.Currency) {
+			return nil, errors.NewValidationError(fmt.Sprintf("entry %d is in %s, not the statement currency %s", i+1, entry.Amount.Currency, statement.Currency), "file")
+		}
+		date, err := entry.BookingDate.parse()
+		if err != nil {
+			date, err = entry.ValueDate.parse()
+		}
+		if err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("entry %d has no booking date", i+1), "file")
+		}
+		reference := strings.TrimSpace(entry.EndToEndID)
+		if reference == "NOTPROVIDED" {
+			reference = ""
+		}
+		description := strings.TrimSpace(strings.Join(entry.Remittance, " "))
+		if description == "" {
+			description = strings.TrimSpace(entry.AdditionalInfo)
+		}
+		statement.Lines = append(statement.Lines, parsedStatementLine{
+			Date:        date,
+			Amount:      amount,
+			Description: description,
+			Reference:   reference,
+			ExternalID:  strings.TrimSpace(entry.ServicerRef),
+		})
+	}
+	return statement, nil
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	if d.Date != "" {
+		return parseCAMTDate(d.Date)
+	}
+	return parseCAMTDate(d.DateTime)
+}
+
+// parseCAMTDate reads an ISO date or the date part of an ISO date-time.
+func parseCAMTDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 10 {
+		return time.Time{}, fmt.Errorf("date %q is too short", value)
+	}
+	return time.Parse("2006-01-02", value[:10])
+}
+
+// camtSignedAmount applies a credit/debit indicator to a camt.053 amount.
+func camtSignedAmount(amount camtAmount, indicator string) (money.Amount, error) {
+	value, err := parseStatementAmount(amount.Value)
+	if err != nil {
+		return money.Zero, errors.NewValidationError(fmt.Sprintf("invalid camt.053 amount %q", amount.Value), "file")
+	}
+	switch strings.TrimSpace(indicator) {
+	case "CRDT":
+		return value, nil
+	case "DBIT":
+		return value.Neg(), nil
+	default:
+		return money.Zero, errors.NewValidationError(fmt.Sprintf("invalid camt.053 credit/debit indicator %q", indicator), "file")
+	}
+}
+
+// parseStatementAmount reads a bank amount, accepting a leading plus sign and a decimal comma.
+func parseStatementAmount(value string) (money.Amount, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "+")
+	if strings.Contains(value, ",") && !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return money.Parse(value)
+}
+
+// statementLineFingerprint identifies a line of a file that has no transaction IDs. occurrence
+// tells identical lines of one file apart, so a statement imported twice is recognised while two
+// equal payments on the same day are both kept.
+func statementLineFingerprint(line parsedStatementLine, occurrence int) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%s|%s|%d",
+		line.Date.Format("2006-01-02"), line.Amount.String(), line.Reference, line.Description, occurrence)))
+	return hex.EncodeToString(sum[:])
+}
+
+// xmlUnescape decodes the character entities OFX files use in text.
+func xmlUnescape(s string) string {
+	if !strings.Contains(s, "&") {
+		return s
+	}
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'", "&nbsp;", " ").Replace(s)
+}
+
+func nonEmpty(values ...string) []string {
+	var kept []string
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,438 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaxAdminRoles are the roles allowed to create and change tax codes and their rates.
+var TaxAdminRoles = []string{auth.RoleAdmin, auth.RoleAccountingManager}
+
+// taxCodePattern is what tax codes and return boxes may look like once upper-cased.
+var taxCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9._-]{0,19}$`)
+
+// TaxService manages the tax codes journal lines are taxed with, calculates tax for documents,
+// and reports the tax return of a period.
+type TaxService interface {
+	CreateTaxCode(ctx context.Context, req dto.CreateTaxCodeRequest) (*models.TaxCode, error)
+	GetTaxCode(ctx context.Context, id uuid.UUID) (*models.TaxCode, error)
+	ListTaxCodes(ctx context.Context) ([]*models.TaxCode, error)
+	UpdateTaxCode(ctx context.Context, id uuid.UUID, req dto.UpdateTaxCodeRequest) (*models.TaxCode, error)
+	AddTaxRate(ctx context.Context, id uuid.UUID, req dto.TaxRateRequest) (*models.TaxCode, error)
+	CalculateTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (*dto.TaxCalculation, error)
+	GetTaxReturn(ctx context.Context, req dto.TaxReturnRequest) (*dto.TaxReturnResponse, error)
+}
+
+// taxService is an implementation of TaxService.
+type taxService struct {
+	taxRepo     repository.TaxRepository
+	coaRepo     repository.ChartOfAccountRepository
+	journalRepo repository.JournalEntryRepository
+	accounting  AccountingService // For the functional currency of the active company
+}
+
+// NewTaxService creates a new TaxService.
+func NewTaxService(
+	taxRepo repository.TaxRepository,
+	coaRepo repository.ChartOfAccountRepository,
+	journalRepo repository.JournalEntryRepository,
+	accounting AccountingService,
+) TaxService {
+	return &taxService{taxRepo: taxRepo, coaRepo: coaRepo, journalRepo: journalRepo, accounting: accounting}
+}
+
+func taxAdminForbidden(action string) error {
+	return errors.NewForbiddenError(fmt.Sprintf("%s requires one of the roles: %s", action, strings.Join(TaxAdminRoles, ", ")))
+}
+
+func (s *taxService) CreateTaxCode(ctx context.Context, req dto.CreateTaxCodeRequest) (*models.TaxCode, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create tax code %s", req.Code)
+	if !auth.HasAnyRole(ctx, TaxAdminRoles...) {
+		return nil, taxAdminForbidden("creating tax codes")
+	}
+
+	code, err := normalizeTaxCode(req.Code, "code")
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	if req.TaxType != models.TaxTypeOutput && req.TaxType != models.TaxTypeInput {
+		return nil, errors.NewValidationError(fmt.Sprintf("tax_type must be %s or %s", models.TaxTypeOutput, models.TaxTypeInput), "tax_type")
+	}
+	if _, err := s.taxRepo.GetTaxCodeByCode(ctx, code); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("tax code %s already exists", code))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	taxCode := &models.TaxCode{
+		Code:                   code,
+		Name:                   name,
+		TaxType:                req.TaxType,
+		TaxAccountID:           req.TaxAccountID,
+		IsReverseCharge:        req.IsReverseCharge,
+		ReverseChargeAccountID: req.ReverseChargeAccountID,
+		IsActive:               true,
+		Rates:                  []models.TaxRate{},
+	}
+	if taxCode.BaseBox, err = normalizeTaxBox(req.BaseBox, "base_box"); err != nil {
+		return nil, err
+	}
+	if taxCode.TaxBox, err = normalizeTaxBox(req.TaxBox, "tax_box"); err != nil {
+		return nil, err
+	}
+	if taxCode.ReverseChargeBox, err = normalizeTaxBox(req.ReverseChargeBox, "reverse_charge_box"); err != nil {
+		return nil, err
+	}
+	if err := s.validateTaxCodeAccounts(ctx, taxCode); err != nil {
+		return nil, err
+	}
+	for i, rateReq := range req.Rates {
+		rate, err := newTaxRate(rateReq)
+		if err != nil {
+			return nil, err
+		}
+		if hasRateFrom(taxCode, rate.EffectiveFrom) {
+			return nil, errors.NewValidationError(fmt.Sprintf("rate %d: there is already a rate from %s", i+1, rate.EffectiveFrom.Format("2006-01-02")), "rates.effective_from")
+		}
+		taxCode.Rates = append(taxCode.Rates, *rate)
+	}
+
+	created, err := s.taxRepo.CreateTaxCode(ctx, taxCode)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Successfully created tax code %s (%s)", created.Code, created.ID)
+	return created, nil
+}
+
+func (s *taxService) GetTaxCode(ctx context.Context, id uuid.UUID) (*models.TaxCode, error) {
+	return s.taxRepo.GetTaxCode(ctx, id)
+}
+
+func (s *taxService) ListTaxCodes(ctx context.Context) ([]*models.TaxCode, error) {
+	return s.taxRepo.ListTaxCodes(ctx)
+}
+
+// UpdateTaxCode changes a tax code's name, accounts, return boxes or status. Lines already posted
+// keep their tax lines; new lines use the code as changed.
+func (s *taxService) UpdateTaxCode(ctx context.Context, id uuid.UUID, req dto.UpdateTaxCodeRequest) (*models.TaxCode, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update tax code %s", id)
+	if !auth.HasAnyRole(ctx, TaxAdminRoles...) {
+		return nil, taxAdminForbidden("changing tax codes")
+	}
+
+	taxCode, err := s.taxRepo.GetTaxCode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		taxCode.Name = name
+	}
+	if req.TaxAccountID != nil {
+		taxCode.TaxAccountID = *req.TaxAccountID
+	}
+	if req.ReverseChargeAccountID != nil {
+		taxCode.ReverseChargeAccountID = req.ReverseChargeAccountID
+	}
+	if req.BaseBox != nil {
+		if taxCode.BaseBox, err = normalizeTaxBox(*req.BaseBox, "base_box"); err != nil {
+			return nil, err
+		}
+	}
+	if req.TaxBox != nil {
+		if taxCode.TaxBox, err = normalizeTaxBox(*req.TaxBox, "tax_box"); err != nil {
+			return nil, err
+		}
+	}
+	if req.ReverseChargeBox != nil {
+		if taxCode.ReverseChargeBox, err = normalizeTaxBox(*req.ReverseChargeBox, "reverse_charge_box"); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		taxCode.IsActive = *req.IsActive
+	}
+	if req.TaxAccountID != nil || req.ReverseChargeAccountID != nil {
+		if err := s.validateTaxCodeAccounts(ctx, taxCode); err != nil {
+			return nil, err
+		}
+	}
+	return s.taxRepo.UpdateTaxCode(ctx, taxCode)
+}
+
+// AddTaxRate sets a new rate of the tax code from req.EffectiveFrom on. Entries dated before then
+// keep being taxed at the earlier rate.
+func (s *taxService) AddTaxRate(ctx context.Context, id uuid.UUID, req dto.TaxRateRequest) (*models.TaxCode, error) {
+	logger.InfoLogger.Printf("Service: Attempting to add a rate of %s to tax code %s", req.Rate, id)
+	if !auth.HasAnyRole(ctx, TaxAdminRoles...) {
+		return nil, taxAdminForbidden("changing tax rates")
+	}
+
+	taxCode, err := s.taxRepo.GetTaxCode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := newTaxRate(req)
+	if err != nil {
+		return nil, err
+	}
+	if hasRateFrom(taxCode, rate.EffectiveFrom) {
+		return nil, errors.NewConflictError(fmt.Sprintf("tax code %s already has a rate from %s", taxCode.Code, rate.EffectiveFrom.Format("2006-01-02")))
+	}
+	rate.TaxCodeID = taxCode.ID
+	if _, err := s.taxRepo.CreateRate(ctx, rate); err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Tax code %s is %s from %s", taxCode.Code, rate.Rate, rate.EffectiveFrom.Format("2006-01-02"))
+	return s.taxRepo.GetTaxCode(ctx, id)
+}
+
+// CalculateTax returns the tax the code charges on base, an amount in currency, on date.
+func (s *taxService) CalculateTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (*dto.TaxCalculation, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		currency = s.accounting.BaseCurrency(ctx)
+	}
+	taxCode, err := s.taxRepo.GetTaxCodeByCode(ctx, code)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("unknown tax code %s", code), "tax_code")
+		}
+		return nil, err
+	}
+	rate, err := taxRateOn(taxCode, date)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), "tax_code")
+	}
+	return &dto.TaxCalculation{
+		TaxCode:       taxCode.Code,
+		TaxType:       taxCode.TaxType,
+		Rate:          rate,
+		Currency:      currency,
+		TaxableBase:   base,
+		Tax:           base.Convert(rate).Round(currency),
+		ReverseCharge: taxCode.IsReverseCharge,
+	}, nil
+}
+
+// GetTaxReturn sums, per tax code and per return box, the taxable base and the tax of the posted
+// lines dated in the period, in the functional currency.
+func (s *taxService) GetTaxReturn(ctx context.Context, req dto.TaxReturnRequest) (*dto.TaxReturnResponse, error) {
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return nil, errors.NewValidationError("start_date and end_date are required", "start_date")
+	}
+	if req.EndDate.Before(req.StartDate) {
+		return nil, errors.NewValidationError("end_date cannot be before start_date", "end_date")
+	}
+	logger.InfoLogger.Printf("Service: Generating tax return for %s to %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"))
+
+	taxCodes, err := s.taxRepo.ListTaxCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.journalRepo.GetJournalEntriesForTrialBalance(ctx, req.StartDate, endOfDay(req.EndDate))
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[uuid.UUID]*dto.TaxReturnCode, len(taxCodes))
+	for _, taxCode := range taxCodes {
+		totals[taxCode.ID] = &dto.TaxReturnCode{
+			TaxCodeID: taxCode.ID, Code: taxCode.Code, Name: taxCode.Name, TaxType: taxCode.TaxType,
+			TaxableBase: money.Zero, Tax: money.Zero, ReverseChargeTax: money.Zero,
+		}
+	}
+	used := make(map[uuid.UUID]bool)
+	for _, entry := range entries {
+		for _, line := range entry.JournalLines {
+			if line.TaxCodeID == nil {
+				continue
+			}
+			total, ok := totals[*line.TaxCodeID]
+			if !ok {
+				logger.WarnLogger.Printf("Service: Journal line %s has unknown tax code %s", line.ID, *line.TaxCodeID)
+				continue
+			}
+			used[total.TaxCodeID] = true
+			// Output amounts count credits, input amounts debits; the reverse charge is output tax.
+			amount := line.Amount
+			if line.IsDebit {
+				amount = amount.Neg()
+			}
+			if total.TaxType == models.TaxTypeInput && line.TaxLineType != models.TaxLineReverseCharge {
+				amount = amount.Neg()
+			}
+			switch line.TaxLineType {
+			case models.TaxLineBase:
+				total.TaxableBase = total.TaxableBase.Add(amount)
+			case models.TaxLineTax:
+				total.Tax = total.Tax.Add(amount)
+			case models.TaxLineReverseCharge:
+				total.ReverseChargeTax = total.ReverseChargeTax.Add(amount)
+			}
+		}
+	}
+
+	response := &dto.TaxReturnResponse{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Currency:  s.accounting.BaseCurrency(ctx),
+		Boxes:     []dto.TaxReturnBox{},
+		Codes:     []dto.TaxReturnCode{},
+		OutputTax: money.Zero,
+		InputTax:  money.Zero,
+	}
+	boxes := make(map[string]*dto.TaxReturnBox)
+	addToBox := func(box string, base, tax money.Amount) {
+		if box == "" {
+			return
+		}
+		if boxes[box] == nil {
+			boxes[box] = &dto.TaxReturnBox{Box: box, TaxableBase: money.Zero, Tax: money.Zero}
+		}
+		boxes[box].TaxableBase = boxes[box].TaxableBase.Add(base)
+		boxes[box].Tax = boxes[box].Tax.Add(tax)
+	}
+	for _, taxCode := range taxCodes { // Ordered by code
+		if !used[taxCode.ID] {
+			continue
+		}
+		total := totals[taxCode.ID]
+		response.Codes = append(response.Codes, *total)
+		addToBox(taxCode.BaseBox, total.TaxableBase, money.Zero)
+		addToBox(taxCode.TaxBox, money.Zero, total.Tax)
+		addToBox(taxCode.ReverseChargeBox, money.Zero, total.ReverseChargeTax)
+		if taxCode.TaxType == models.TaxTypeOutput {
+			response.OutputTax = response.OutputTax.Add(total.Tax)
+		} else {
+			response.InputTax = response.InputTax.Add(total.Tax)
+		}
+		response.OutputTax = response.OutputTax.Add(total.ReverseChargeTax)
+	}
+	for _, box := range boxes {
+		response.Boxes = append(response.Boxes, *box)
+	}
+	sort.Slice(response.Boxes, func(i, j int) bool { return response.Boxes[i].Box < response.Boxes[j].Box })
+	response.NetTax = response.OutputTax.Sub(response.InputTax)
+
+	logger.InfoLogger.Printf("Service: Tax return for %s to %s has net tax %s", req.StartDate.Format("2006-01-02"), req.EndDate.Format("2006-01-02"), response.NetTax)
+	return response, nil
+}
+
+// validateTaxCodeAccounts checks that the tax code's accounts exist, are active balance sheet
+// accounts, and that only reverse-charge input codes have, and must have, a reverse-charge account.
+func (s *taxService) validateTaxCodeAccounts(ctx context.Context, taxCode *models.TaxCode) error {
+	if taxCode.IsReverseCharge && taxCode.TaxType != models.TaxTypeInput {
+		return errors.NewValidationError("only INPUT tax codes can be reverse charge", "is_reverse_charge")
+	}
+	if taxCode.IsReverseCharge && taxCode.ReverseChargeAccountID == nil {
+		return errors.NewValidationError("reverse_charge_account_id is required for reverse-charge tax codes", "reverse_charge_account_id")
+	}
+	if !taxCode.IsReverseCharge && taxCode.ReverseChargeAccountID != nil {
+		return errors.NewValidationError("reverse_charge_account_id is only allowed on reverse-charge tax codes", "reverse_charge_account_id")
+	}
+	if err := s.validateTaxAccount(ctx, taxCode.TaxAccountID, "tax_account_id"); err != nil {
+		return err
+	}
+	if taxCode.ReverseChargeAccountID != nil {
+		if *taxCode.ReverseChargeAccountID == taxCode.TaxAccountID {
+			return errors.NewValidationError("reverse_charge_account_id must differ from tax_account_id", "reverse_charge_account_id")
+		}
+		return s.validateTaxAccount(ctx, *taxCode.ReverseChargeAccountID, "reverse_charge_account_id")
+	}
+	return nil
+}
+
+func (s *taxService) validateTaxAccount(ctx context.Context, accountID uuid.UUID, field string) error {
+	if accountID == uuid.Nil {
+		return errors.NewValidationError(fmt.Sprintf("%s is required", field), field)
+	}
+	account, err := s.coaRepo.GetByID(ctx, accountID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return errors.NewValidationError(fmt.Sprintf("account %s not found", accountID), field)
+		}
+		return err
+	}
+	if !account.IsActive {
+		return errors.NewValidationError(fmt.Sprintf("account %s (%s) is not active", account.AccountCode, account.AccountName), field)
+	}
+	if account.AccountType != models.Asset && account.AccountType != models.Liability {
+		return errors.NewValidationError(fmt.Sprintf("account %s is %s; tax accounts must be ASSET or LIABILITY accounts", account.AccountCode, account.AccountType), field)
+	}
+	return nil
+}
+
+// newTaxRate validates a rate request.
+func newTaxRate(req dto.TaxRateRequest) (*models.TaxRate, error) {
+	if req.EffectiveFrom.IsZero() {
+		return nil, errors.NewValidationError("effective_from is required", "effective_from")
+	}
+	if req.Rate.Cmp(money.One) >= 0 {
+		return nil, errors.NewValidationError(fmt.Sprintf("rate %s must be a fraction below 1, e.g. 0.2 for 20%%", req.Rate), "rate")
+	}
+	effectiveFrom := time.Date(req.EffectiveFrom.Year(), req.EffectiveFrom.Month(), req.EffectiveFrom.Day(), 0, 0, 0, 0, time.UTC)
+	return &models.TaxRate{EffectiveFrom: effectiveFrom, Rate: req.Rate}, nil
+}
+
+// hasRateFrom reports whether the tax code already has a rate taking effect on date.
+func hasRateFrom(taxCode *models.TaxCode, date time.Time) bool {
+	for _, rate := range taxCode.Rates {
+		if rate.EffectiveFrom.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// taxRateOn returns the rate of an active tax code in force on date.
+func taxRateOn(taxCode *models.TaxCode, date time.Time) (money.Rate, error) {
+	if !taxCode.IsActive {
+		return money.Rate{}, fmt.Errorf("tax code %s is not active", taxCode.Code)
+	}
+	rate := taxCode.RateOn(date)
+	if rate == nil {
+		return money.Rate{}, fmt.Errorf("tax code %s has no rate on %s", taxCode.Code, date.Format("2006-01-02"))
+	}
+	return rate.Rate, nil
+}
+
+// normalizeTaxCode upper-cases a tax code and checks its format.
+func normalizeTaxCode(code, field string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !taxCodePattern.MatchString(code) {
+		return "", errors.NewValidationError("code must be 1 to 20 letters, digits, '.', '-' or '_'", field)
+	}
+	return code, nil
+}
+
+// normalizeTaxBox upper-cases a return box, which may be empty for amounts not reported.
+func normalizeTaxBox(box, field string) (string, error) {
+	box = strings.ToUpper(strings.TrimSpace(box))
+	if box != "" && !taxCodePattern.MatchString(box) {
+		return "", errors.NewValidationError(fmt.Sprintf("%s must be 1 to 20 letters, digits, '.', '-' or '_'", field), field)
+	}
+	return box, nil
+}
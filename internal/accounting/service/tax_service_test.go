@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/accounting/models"
+	"erp-system/internal/accounting/repository/mocks"
+	"erp-system/internal/accounting/service"
+	dto "erp-system/internal/accounting/service/dto"
+	"erp-system/pkg/auth"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxService_CreateTaxCode(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "controller", Roles: []string{auth.RoleAccountingManager}})
+	outputVAT := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "2200", AccountType: models.Liability, IsActive: true}
+	inputVAT := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "1400", AccountType: models.Asset, IsActive: true}
+	sales := &models.ChartOfAccount{ID: uuid.New(), AccountCode: "4000", AccountType: models.Revenue, IsActive: true}
+
+	newService := func(t *testing.T) (service.TaxService, *mocks.TaxRepository, *mocks.ChartOfAccountRepository) {
+		taxRepo := mocks.NewTaxRepositoryMock(t)
+		coaRepo := mocks.NewChartOfAccountRepositoryMock(t)
+		return service.NewTaxService(taxRepo, coaRepo, nil, &stubAccountingService{}), taxRepo, coaRepo
+	}
+
+	t.Run("Success - Reverse Charge Code With Rates", func(t *testing.T) {
+		taxService, taxRepo, coaRepo := newService(t)
+		taxRepo.On("GetTaxCodeByCode", ctx, "RC20").Return(nil, app_errors.NewNotFoundError("tax_code_code", "RC20")).Once()
+		coaRepo.On("GetByID", ctx, inputVAT.ID).Return(inputVAT, nil).Once()
+		coaRepo.On("GetByID", ctx, outputVAT.ID).Return(outputVAT, nil).Once()
+		taxRepo.On("CreateTaxCode", ctx, mock.AnythingOfType("*models.TaxCode")).Return(func(_ context.Context, tc *models.TaxCode) *models.TaxCode { return tc }, nil).Once()
+
+		taxCode, err := taxService.CreateTaxCode(ctx, dto.CreateTaxCodeRequest{
+			Code: " rc20 ", Name: "Reverse charge services", TaxType: models.TaxTypeInput,
+			TaxAccountID: inputVAT.ID, IsReverseCharge: true, ReverseChargeAccountID: &outputVAT.ID,
+			BaseBox: "7", TaxBox: "4", ReverseChargeBox: "1",
+			Rates: []dto.TaxRateRequest{{EffectiveFrom: time.Date(2011, 1, 4, 15, 0, 0, 0, time.UTC), Rate: money.MustParseRate("0.2")}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "RC20", taxCode.Code)
+		assert.True(t, taxCode.IsActive)
+		require.Len(t, taxCode.Rates, 1)
+		assert.Equal(t, time.Date(2011, 1, 4, 0, 0, 0, 0, time.UTC), taxCode.Rates[0].EffectiveFrom, "Rates take effect from the start of the day")
+	})
+
+	t.Run("Validation Error - Reverse Charge On Output Code", func(t *testing.T) {
+		taxService, taxRepo, _ := newService(t)
+		taxRepo.On("GetTaxCodeByCode", ctx, "S20").Return(nil, app_errors.NewNotFoundError("tax_code_code", "S20")).Once()
+		_, err := taxService.CreateTaxCode(ctx, dto.CreateTaxCodeRequest{Code: "S20", Name: "Standard", TaxType: models.TaxTypeOutput, TaxAccountID: outputVAT.ID, IsReverseCharge: true, ReverseChargeAccountID: &inputVAT.ID})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "only INPUT tax codes can be reverse charge")
+	})
+
+	t.Run("Validation Error - Tax Account On The Income Statement", func(t *testing.T) {
+		taxService, taxRepo, coaRepo := newService(t)
+		taxRepo.On("GetTaxCodeByCode", ctx, "S20").Return(nil, app_errors.NewNotFoundError("tax_code_code", "S20")).Once()
+		coaRepo.On("GetByID", ctx, sales.ID).Return(sales, nil).Once()
+		_, err := taxService.CreateTaxCode(ctx, dto.CreateTaxCodeRequest{Code: "S20", Name: "Standard", TaxType: models.TaxTypeOutput, TaxAccountID: sales.ID})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "tax accounts must be ASSET or LIABILITY accounts")
+	})
+
+	t.Run("Validation Error - Rate Given As A Percentage", func(t *testing.T) {
+		taxService, taxRepo, coaRepo := newService(t)
+		taxRepo.On("GetTaxCodeByCode", ctx, "S20").Return(nil, app_errors.NewNotFoundError("tax_code_code", "S20")).Once()
+		coaRepo.On("GetByID", ctx, outputVAT.ID).Return(outputVAT, nil).Once()
+		_, err := taxService.CreateTaxCode(ctx, dto.CreateTaxCodeRequest{Code: "S20", Name: "Standard", TaxType: models.TaxTypeOutput, TaxAccountID: outputVAT.ID,
+			Rates: []dto.TaxRateRequest{{EffectiveFrom: time.Date(2011, 1, 4, 0, 0, 0, 0, time.UTC), Rate: money.MustParseRate("20")}}})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "must be a fraction below 1")
+	})
+
+	t.Run("Forbidden - Accountant", func(t *testing.T) {
+		taxService, _, _ := newService(t)
+		accountant := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "clerk", Roles: []string{auth.RoleAccountant}})
+		_, err := taxService.CreateTaxCode(accountant, dto.CreateTaxCodeRequest{Code: "S20", Name: "Standard", TaxType: models.TaxTypeOutput, TaxAccountID: outputVAT.ID})
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+}
+
+func TestTaxService_AddTaxRate(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "controller", Roles: []string{auth.RoleAdmin}})
+	taxCode := &models.TaxCode{ID: uuid.New(), Code: "S20", TaxType: models.TaxTypeOutput, IsActive: true, Rates: []models.TaxRate{
+		{EffectiveFrom: time.Date(2011, 1, 4, 0, 0, 0, 0, time.UTC), Rate: money.MustParseRate("0.2")},
+	}}
+
+	t.Run("Success - New Rate From A Later Date", func(t *testing.T) {
+		taxRepo := mocks.NewTaxRepositoryMock(t)
+		taxRepo.On("GetTaxCode", ctx, taxCode.ID).Return(taxCode, nil).Twice()
+		taxRepo.On("CreateRate", ctx, mock.MatchedBy(func(rate *models.TaxRate) bool {
+			return rate.TaxCodeID == taxCode.ID && rate.Rate.Equal(money.MustParseRate("0.21"))
+		})).Return(func(_ context.Context, rate *models.TaxRate) *models.TaxRate { return rate }, nil).Once()
+
+		_, err := service.NewTaxService(taxRepo, nil, nil, &stubAccountingService{}).AddTaxRate(ctx, taxCode.ID, dto.TaxRateRequest{EffectiveFrom: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), Rate: money.MustParseRate("0.21")})
+		require.NoError(t, err)
+	})
+
+	t.Run("Conflict - Rate From The Same Date", func(t *testing.T) {
+		taxRepo := mocks.NewTaxRepositoryMock(t)
+		taxRepo.On("GetTaxCode", ctx, taxCode.ID).Return(taxCode, nil).Once()
+
+		_, err := service.NewTaxService(taxRepo, nil, nil, &stubAccountingService{}).AddTaxRate(ctx, taxCode.ID, dto.TaxRateRequest{EffectiveFrom: time.Date(2011, 1, 4, 0, 0, 0, 0, time.UTC), Rate: money.MustParseRate("0.21")})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		taxRepo.AssertNotCalled(t, "CreateRate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTaxService_CalculateTax(t *testing.T) {
+	ctx := context.Background()
+	taxCode := &models.TaxCode{ID: uuid.New(), Code: "R5", TaxType: models.TaxTypeOutput, IsActive: true, Rates: []models.TaxRate{
+		{EffectiveFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Rate: money.MustParseRate("0.05")},
+	}}
+	taxRepo := mocks.NewTaxRepositoryMock(t)
+	taxRepo.On("GetTaxCodeByCode", ctx, "R5").Return(taxCode, nil).Once()
+
+	calculation, err := service.NewTaxService(taxRepo, nil, nil, &stubAccountingService{}).CalculateTax(ctx, "r5", money.MustParse("33.30"), "", time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "1.67", calculation.Tax.String(), "1.665 rounds half away from zero")
+	assert.Equal(t, "USD", calculation.Currency)
+	assert.Equal(t, models.TaxTypeOutput, calculation.TaxType)
+	assert.False(t, calculation.ReverseCharge)
+}
+
+func TestTaxService_GetTaxReturn(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	standard := &models.TaxCode{ID: uuid.New(), Code: "S20", Name: "Standard sales", TaxType: models.TaxTypeOutput, BaseBox: "6", TaxBox: "1", IsActive: true}
+	purchases := &models.TaxCode{ID: uuid.New(), Code: "P20", Name: "Standard purchases", TaxType: models.TaxTypeInput, BaseBox: "7", TaxBox: "4", IsActive: true}
+	reverseCharge := &models.TaxCode{ID: uuid.New(), Code: "RC20", Name: "Reverse charge", TaxType: models.TaxTypeInput, IsReverseCharge: true, BaseBox: "7", TaxBox: "4", ReverseChargeBox: "1", IsActive: true}
+	unused := &models.TaxCode{ID: uuid.New(), Code: "Z0", TaxType: models.TaxTypeOutput, BaseBox: "6", IsActive: true}
+
+	line := func(taxCode *models.TaxCode, lineType models.TaxLineType, amount string, isDebit bool) models.JournalLine {
+		return models.JournalLine{AccountID: uuid.New(), Amount: money.MustParse(amount), IsDebit: isDebit, TaxCodeID: &taxCode.ID, TaxCode: taxCode.Code, TaxLineType: lineType}
+	}
+	entries := []models.JournalEntry{
+		{Status: models.StatusPosted, JournalLines: []models.JournalLine{ // Sale
+			{AccountID: uuid.New(), Amount: money.MustParse("1200.00"), IsDebit: true},
+			line(standard, models.TaxLineBase, "1000.00", false),
+			line(standard, models.TaxLineTax, "200.00", false),
+		}},
+		{Status: models.StatusPosted, JournalLines: []models.JournalLine{ // Credit note
+			{AccountID: uuid.New(), Amount: money.MustParse("120.00"), IsDebit: false},
+			line(standard, models.TaxLineBase, "100.00", true),
+			line(standard, models.TaxLineTax, "20.00", true),
+		}},
+		{Status: models.StatusPosted, JournalLines: []models.JournalLine{ // Purchase
+			{AccountID: uuid.New(), Amount: money.MustParse("360.00"), IsDebit: false},
+			line(purchases, models.TaxLineBase, "300.00", true),
+			line(purchases, models.TaxLineTax, "60.00", true),
+		}},
+		{Status: models.StatusPosted, JournalLines: []models.JournalLine{ // Services from abroad
+			{AccountID: uuid.New(), Amount: money.MustParse("500.00"), IsDebit: false},
+			line(reverseCharge, models.TaxLineBase, "500.00", true),
+			line(reverseCharge, models.TaxLineTax, "100.00", true),
+			line(reverseCharge, models.TaxLineReverseCharge, "100.00", false),
+		}},
+	}
+
+	taxRepo := mocks.NewTaxRepositoryMock(t)
+	journalRepo := mocks.NewJournalEntryRepositoryMock(t)
+	taxRepo.On("ListTaxCodes", ctx).Return([]*models.TaxCode{purchases, reverseCharge, standard, unused}, nil).Once()
+	journalRepo.On("GetJournalEntriesForTrialBalance", ctx, start, end.AddDate(0, 0, 1).Add(-time.Nanosecond)).Return(entries, nil).Once()
+
+	taxReturn, err := service.NewTaxService(taxRepo, nil, journalRepo, &stubAccountingService{}).GetTaxReturn(ctx, dto.TaxReturnRequest{StartDate: start, EndDate: end})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", taxReturn.Currency)
+
+	require.Len(t, taxReturn.Codes, 3, "Codes without lines are left out")
+	assert.Equal(t, "P20", taxReturn.Codes[0].Code)
+	assert.Equal(t, "300.00", taxReturn.Codes[0].TaxableBase.String())
+	assert.Equal(t, "60.00", taxReturn.Codes[0].Tax.String())
+	assert.Equal(t, "100.00", taxReturn.Codes[1].Tax.String())
+	assert.Equal(t, "100.00", taxReturn.Codes[1].ReverseChargeTax.String())
+	assert.Equal(t, "900.00", taxReturn.Codes[2].TaxableBase.String(), "The credit note reduces sales")
+	assert.Equal(t, "180.00", taxReturn.Codes[2].Tax.String())
+
+	boxes := make(map[string][2]string)
+	for _, box := range taxReturn.Boxes {
+		boxes[box.Box] = [2]string{box.TaxableBase.String(), box.Tax.String()}
+	}
+	assert.Equal(t, map[string][2]string{
+		"1": {"0.00", "280.00"},
+		"4": {"0.00", "160.00"},
+		"6": {"900.00", "0.00"},
+		"7": {"800.00", "0.00"},
+	}, boxes)
+	assert.Equal(t, "280.00", taxReturn.OutputTax.String())
+	assert.Equal(t, "160.00", taxReturn.InputTax.String())
+	assert.Equal(t, "120.00", taxReturn.NetTax.String())
+}
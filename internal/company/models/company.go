@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Company is a legal entity with its own books. Every accounting and inventory record belongs to
+// exactly one company, and codes such as account codes and SKUs are unique within it.
+type Company struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	Code         string    `gorm:"type:varchar(20);not null;uniqueIndex" json:"code"` // e.g. "EU01"; sent in the X-Company-ID header
+	Name         string    `gorm:"type:varchar(255);not null" json:"name"`
+	BaseCurrency string    `gorm:"type:varchar(3);not null" json:"base_currency"` // Functional currency (ISO 4217); fixed once created
+	IsActive     bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Company model.
+func (Company) TableName() string {
+	return "companies"
+}
+
+// BeforeCreate will set a UUID for the new company.
+func (c *Company) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/company/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CompanyRepository defines the interface for database operations for Company. Companies are not
+// company scoped themselves, so these work without an active company.
+type CompanyRepository interface {
+	Create(ctx context.Context, company *models.Company) (*models.Company, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Company, error)
+	GetByCode(ctx context.Context, code string) (*models.Company, error)
+	Update(ctx context.Context, company *models.Company) (*models.Company, error)
+	List(ctx context.Context, activeOnly bool) ([]*models.Company, error)
+}
+
+// gormCompanyRepository is an implementation of CompanyRepository using GORM.
+type gormCompanyRepository struct {
+	db *gorm.DB
+}
+
+// NewCompanyRepository creates a new GORM-based CompanyRepository.
+func NewCompanyRepository(db *gorm.DB) CompanyRepository {
+	return &gormCompanyRepository{db: db}
+}
+
+func (r *gormCompanyRepository) Create(ctx context.Context, company *models.Company) (*models.Company, error) {
+	logger.InfoLogger.Printf("Repository: Creating company %s", company.Code)
+	if err := r.db.WithContext(ctx).Create(company).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating company %s: %v", company.Code, err)
+		return nil, errors.NewInternalServerError("failed to create company", err)
+	}
+	return company, nil
+}
+
+func (r *gormCompanyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Company, error) {
+	var company models.Company
+	if err := r.db.WithContext(ctx).First(&company, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("company", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving company %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get company %s", id), err)
+	}
+	return &company, nil
+}
+
+func (r *gormCompanyRepository) GetByCode(ctx context.Context, code string) (*models.Company, error) {
+	var company models.Company
+	if err := r.db.WithContext(ctx).First(&company, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("company_code", code)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving company %s: %v", code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get company %s", code), err)
+	}
+	return &company, nil
+}
+
+func (r *gormCompanyRepository) Update(ctx context.Context, company *models.Company) (*models.Company, error) {
+	logger.InfoLogger.Printf("Repository: Updating company %s", company.Code)
+	if err := r.db.WithContext(ctx).Save(company).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating company %s: %v", company.Code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update company %s", company.Code), err)
+	}
+	return company, nil
+}
+
+// List returns the companies ordered by code, only the active ones if activeOnly is set.
+func (r *gormCompanyRepository) List(ctx context.Context, activeOnly bool) ([]*models.Company, error) {
+	var companies []*models.Company
+	query := r.db.WithContext(ctx).Order("code asc")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	if err := query.Find(&companies).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing companies: %v", err)
+		return nil, errors.NewInternalServerError("failed to list companies", err)
+	}
+	return companies, nil
+}
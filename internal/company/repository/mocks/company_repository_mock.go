@@ -0,0 +1,146 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/company/models"
+	"erp-system/internal/company/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// CompanyRepository is an autogenerated mock type for the CompanyRepository type
+type CompanyRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, company
+func (_m *CompanyRepository) Create(ctx context.Context, company *models.Company) (*models.Company, error) {
+	ret := _m.Called(ctx, company)
+
+	var r0 *models.Company
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Company) *models.Company); ok {
+		r0 = rf(ctx, company)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Company)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Company) error); ok {
+		r1 = rf(ctx, company)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCode provides a mock function with given fields: ctx, code
+func (_m *CompanyRepository) GetByCode(ctx context.Context, code string) (*models.Company, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *models.Company
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Company); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Company)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CompanyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Company, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Company
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Company); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Company)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, activeOnly
+func (_m *CompanyRepository) List(ctx context.Context, activeOnly bool) ([]*models.Company, error) {
+	ret := _m.Called(ctx, activeOnly)
+
+	var r0 []*models.Company
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []*models.Company); ok {
+		r0 = rf(ctx, activeOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Company)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, activeOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, company
+func (_m *CompanyRepository) Update(ctx context.Context, company *models.Company) (*models.Company, error) {
+	ret := _m.Called(ctx, company)
+
+	var r0 *models.Company
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Company) *models.Company); ok {
+		r0 = rf(ctx, company)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Company)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Company) error); ok {
+		r1 = rf(ctx, company)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCompanyRepository creates a new instance of CompanyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCompanyRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CompanyRepository {
+	mock := &CompanyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.CompanyRepository = (*CompanyRepository)(nil)
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"erp-system/internal/company/models"
+	"erp-system/internal/company/repository"
+	"erp-system/internal/company/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CompanyRoles are the roles allowed to create and change companies.
+var CompanyRoles = []string{auth.RoleAdmin}
+
+var (
+	companyCodePattern  = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{0,19}$`)
+	currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// CompanyService manages the legal entities whose books the ledgers and stock are kept in, and
+// resolves the company a request works on.
+type CompanyService interface {
+	CreateCompany(ctx context.Context, req dto.CreateCompanyRequest) (*models.Company, error)
+	GetCompany(ctx context.Context, id uuid.UUID) (*models.Company, error)
+	ListCompanies(ctx context.Context, activeOnly bool) ([]*models.Company, error)
+	UpdateCompany(ctx context.Context, id uuid.UUID, req dto.UpdateCompanyRequest) (*models.Company, error)
+	ResolveCompany(ctx context.Context, ref string) (*models.Company, error)
+}
+
+// companyService is an implementation of CompanyService.
+type companyService struct {
+	companyRepo         repository.CompanyRepository
+	defaultBaseCurrency string
+}
+
+// NewCompanyService creates a new CompanyService. Companies created without a base currency get
+// defaultBaseCurrency.
+func NewCompanyService(companyRepo repository.CompanyRepository, defaultBaseCurrency string) CompanyService {
+	return &companyService{companyRepo: companyRepo, defaultBaseCurrency: strings.ToUpper(defaultBaseCurrency)}
+}
+
+func (s *companyService) CreateCompany(ctx context.Context, req dto.CreateCompanyRequest) (*models.Company, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create company %s", req.Code)
+	if !auth.HasAnyRole(ctx, CompanyRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("creating companies requires one of the roles: %s", strings.Join(CompanyRoles, ", ")))
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if !companyCodePattern.MatchString(code) {
+		return nil, errors.NewValidationError("code must be 1 to 20 letters, digits, dashes or underscores", "code")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 255 {
+		return nil, errors.NewValidationError("name is required and must be at most 255 characters", "name")
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.BaseCurrency))
+	if currency == "" {
+		currency = s.defaultBaseCurrency
+	}
+	if !currencyCodePattern.MatchString(currency) {
+		return nil, errors.NewValidationError("base currency must be a three-letter ISO 4217 code", "base_currency")
+	}
+	if _, err := s.companyRepo.GetByCode(ctx, code); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("company %s already exists", code))
+	} else if _, ok := err.(*errors.NotFoundError); !ok {
+		return nil, err
+	}
+
+	created, err := s.companyRepo.Create(ctx, &models.Company{Code: code, Name: name, BaseCurrency: currency, IsActive: true})
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Successfully created company %s (%s)", created.Code, created.ID)
+	return created, nil
+}
+
+func (s *companyService) GetCompany(ctx context.Context, id uuid.UUID) (*models.Company, error) {
+	return s.companyRepo.GetByID(ctx, id)
+}
+
+func (s *companyService) ListCompanies(ctx context.Context, activeOnly bool) ([]*models.Company, error) {
+	return s.companyRepo.List(ctx, activeOnly)
+}
+
+// UpdateCompany renames or (de)activates a company. An inactive company keeps its books, but
+// requests can no longer work on it.
+func (s *companyService) UpdateCompany(ctx context.Context, id uuid.UUID, req dto.UpdateCompanyRequest) (*models.Company, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update company %s", id)
+	if !auth.HasAnyRole(ctx, CompanyRoles...) {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("changing companies requires one of the roles: %s", strings.Join(CompanyRoles, ", ")))
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 255 {
+			return nil, errors.NewValidationError("name cannot be empty and must be at most 255 characters", "name")
+		}
+		company.Name = name
+	}
+	if req.IsActive != nil {
+		company.IsActive = *req.IsActive
+	}
+	return s.companyRepo.Update(ctx, company)
+}
+
+// ResolveCompany finds the active company that ref names, either by ID or by code.
+func (s *companyService) ResolveCompany(ctx context.Context, ref string) (*models.Company, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, errors.NewValidationError("a company ID or code is required", "company")
+	}
+	var company *models.Company
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		company, err = s.companyRepo.GetByID(ctx, id)
+	} else {
+		company, err = s.companyRepo.GetByCode(ctx, strings.ToUpper(ref))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !company.IsActive {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("company %s is inactive", company.Code))
+	}
+	return company, nil
+}
@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"erp-system/internal/company/models"
+	"erp-system/internal/company/repository/mocks"
+	"erp-system/internal/company/service"
+	"erp-system/internal/company/service/dto"
+	"erp-system/pkg/auth"
+	app_errors "erp-system/pkg/errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanyService_CreateCompany(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "admin", Roles: []string{auth.RoleAdmin}})
+
+	t.Run("Success - Default Base Currency", func(t *testing.T) {
+		companyRepo := mocks.NewCompanyRepositoryMock(t)
+		companyService := service.NewCompanyService(companyRepo, "usd")
+		companyRepo.On("GetByCode", ctx, "EU01").Return(nil, app_errors.NewNotFoundError("company_code", "EU01")).Once()
+		companyRepo.On("Create", ctx, mock.AnythingOfType("*models.Company")).Return(func(_ context.Context, c *models.Company) *models.Company { return c }, nil).Once()
+
+		company, err := companyService.CreateCompany(ctx, dto.CreateCompanyRequest{Code: " eu01", Name: "Example Europe GmbH"})
+		require.NoError(t, err)
+		assert.Equal(t, "EU01", company.Code)
+		assert.Equal(t, "USD", company.BaseCurrency)
+		assert.True(t, company.IsActive)
+	})
+
+	t.Run("Conflict Error - Code Taken", func(t *testing.T) {
+		companyRepo := mocks.NewCompanyRepositoryMock(t)
+		companyService := service.NewCompanyService(companyRepo, "USD")
+		companyRepo.On("GetByCode", ctx, "US01").Return(&models.Company{Code: "US01"}, nil).Once()
+
+		_, err := companyService.CreateCompany(ctx, dto.CreateCompanyRequest{Code: "US01", Name: "Example Inc.", BaseCurrency: "USD"})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Validation Error - Base Currency", func(t *testing.T) {
+		companyService := service.NewCompanyService(mocks.NewCompanyRepositoryMock(t), "USD")
+		_, err := companyService.CreateCompany(ctx, dto.CreateCompanyRequest{Code: "UK01", Name: "Example Ltd", BaseCurrency: "POUND"})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Forbidden Error - Not An Admin", func(t *testing.T) {
+		companyService := service.NewCompanyService(mocks.NewCompanyRepositoryMock(t), "USD")
+		manager := auth.WithPrincipal(context.Background(), auth.Principal{UserID: "manager", Roles: []string{auth.RoleAccountingManager}})
+		_, err := companyService.CreateCompany(manager, dto.CreateCompanyRequest{Code: "UK01", Name: "Example Ltd"})
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+}
+
+func TestCompanyService_ResolveCompany(t *testing.T) {
+	ctx := context.Background()
+	europe := &models.Company{ID: uuid.New(), Code: "EU01", BaseCurrency: "EUR", IsActive: true}
+
+	t.Run("Success - By ID", func(t *testing.T) {
+		companyRepo := mocks.NewCompanyRepositoryMock(t)
+		companyRepo.On("GetByID", ctx, europe.ID).Return(europe, nil).Once()
+		company, err := service.NewCompanyService(companyRepo, "USD").ResolveCompany(ctx, europe.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, europe, company)
+	})
+
+	t.Run("Success - By Code", func(t *testing.T) {
+		companyRepo := mocks.NewCompanyRepositoryMock(t)
+		companyRepo.On("GetByCode", ctx, "EU01").Return(europe, nil).Once()
+		company, err := service.NewCompanyService(companyRepo, "USD").ResolveCompany(ctx, "eu01")
+		require.NoError(t, err)
+		assert.Equal(t, europe.ID, company.ID)
+	})
+
+	t.Run("Forbidden Error - Inactive Company", func(t *testing.T) {
+		companyRepo := mocks.NewCompanyRepositoryMock(t)
+		companyRepo.On("GetByCode", ctx, "OLD").Return(&models.Company{ID: uuid.New(), Code: "OLD"}, nil).Once()
+		_, err := service.NewCompanyService(companyRepo, "USD").ResolveCompany(ctx, "OLD")
+		assert.IsType(t, &app_errors.ForbiddenError{}, err)
+	})
+
+	t.Run("Not Found Error - Unknown Code", func(t *testing.T) {
+		companyRepo := mocks.NewCompanyRepositoryMock(t)
+		companyRepo.On("GetByCode", ctx, "XX").Return(nil, app_errors.NewNotFoundError("company_code", "XX")).Once()
+		_, err := service.NewCompanyService(companyRepo, "USD").ResolveCompany(ctx, "XX")
+		assert.IsType(t, &app_errors.NotFoundError{}, err)
+	})
+}
@@ -0,0 +1,17 @@
+package dto
+
+// --- Company DTOs ---
+
+// CreateCompanyRequest adds a legal entity.
+type CreateCompanyRequest struct {
+	Code         string `json:"code" binding:"required,max=20"` // e.g. "EU01"
+	Name         string `json:"name" binding:"required,max=255"`
+	BaseCurrency string `json:"base_currency,omitempty" binding:"omitempty,len=3"` // Defaults to the configured base currency
+}
+
+// UpdateCompanyRequest renames or deactivates a company. Omitted fields are left unchanged; the
+// code and base currency cannot change.
+type UpdateCompanyRequest struct {
+	Name     *string `json:"name,omitempty" binding:"omitempty,max=255"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"erp-system/api" // For NewRouter
+	"erp-system/api/middleware"
 	"erp-system/configs"
 	accModels "erp-system/internal/accounting/models"      // For full schema setup
+	companyModels "erp-system/internal/company/models"
 	invModels "erp-system/internal/inventory/models"       // Actual models being tested
 	invRepo "erp-system/internal/inventory/repository"     // For direct seeding if needed
 	invServiceDTO "erp-system/internal/inventory/service/dto" // DTOs for requests/responses
+	"erp-system/pkg/auth"
+	"erp-system/pkg/company"
 	"erp-system/pkg/database"
 	"erp-system/pkg/logger"
 	"fmt"
@@ -35,6 +39,8 @@ var (
 	dbInstance_inventory_api *gorm.DB
 	testConfig_inventory_api configs.AppConfig
 	globalRouter_inventory   *mux.Router // Global router for inventory API tests
+	// testCompany_inventory_api is the company every inventory API test works in.
+	testCompany_inventory_api = companyModels.Company{ID: uuid.MustParse("00000000-0000-0000-0000-00000000d001"), Code: "TEST", Name: "Inventory Test Company", BaseCurrency: "USD", IsActive: true}
 )
 
 // setupInventoryAPITestDB is similar to other setups but used for API handler tests for inventory.
@@ -59,11 +65,13 @@ func setupInventoryAPITestDB(ctx context.Context) (*gorm.DB, configs.AppConfig,
 
 	// Migrate all known schemas
 	err = gormDB.AutoMigrate(
+		&companyModels.Company{},
 		&accModels.ChartOfAccount{}, &accModels.JournalEntry{}, &accModels.JournalLine{},
 		&invModels.Item{}, &invModels.Warehouse{}, &invModels.InventoryTransaction{},
 	)
 	if err != nil { sqlDB, _ := gormDB.DB(); sqlDB.Close(); pgContainer.Terminate(ctx); return nil, configs.AppConfig{}, nil, nil, fmt.Errorf("automigrate: %w", err)}
 
+	configs.GlobalConfig.AuthTokenSecret = "integration-test-secret"
 	router := api.NewRouter(gormDB) // Initialize router with this specific DB for inventory API tests
 
 	cleanupFunc := func() {
@@ -109,7 +117,10 @@ type InventoryAPIHandlersIntegrationTestSuite struct {
 
 func (s *InventoryAPIHandlersIntegrationTestSuite) SetupSuite() {
 	s.T().Log("Setting up suite for Inventory API Handlers integration tests...")
-	s.db = dbInstance_inventory_api
+	// Direct queries work in the test company, like requests carrying its X-Company-ID header.
+	s.db = dbInstance_inventory_api.WithContext(company.WithCompany(context.Background(), company.Company{
+		ID: testCompany_inventory_api.ID, Code: testCompany_inventory_api.Code, BaseCurrency: testCompany_inventory_api.BaseCurrency,
+	}))
 	s.router = globalRouter_inventory
 	s.itemRepo = invRepo.NewItemRepository(s.db)
 	s.whRepo = invRepo.NewWarehouseRepository(s.db)
@@ -118,11 +129,13 @@ func (s *InventoryAPIHandlersIntegrationTestSuite) SetupSuite() {
 
 func (s *InventoryAPIHandlersIntegrationTestSuite) SetupTest() {
 	s.T().Logf("Setting up test: %s", s.T().Name())
-	tables := []string{"inventory_transactions", "items", "warehouses", "journal_lines", "journal_entries", "chart_of_accounts"}
+	tables := []string{"inventory_transactions", "items", "warehouses", "journal_lines", "journal_entries", "chart_of_accounts", "companies"}
 	for _, table := range tables {
 		err := s.db.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", table)).Error
 		s.Require().NoError(err, "Failed to truncate table %s", table)
 	}
+	seed := testCompany_inventory_api
+	s.Require().NoError(s.db.Create(&seed).Error, "Failed to seed the test company")
 	s.T().Logf("Test setup complete for: %s (tables truncated)", s.T().Name())
 }
 
@@ -143,6 +156,10 @@ func (s *InventoryAPIHandlersIntegrationTestSuite) performRequest(method, path s
 	req, err := http.NewRequest(method, path, reqBody)
 	s.Require().NoError(err)
 	req.Header.Set("Content-Type", "application/json")
+	token, err := auth.SignToken([]byte(configs.GlobalConfig.AuthTokenSecret), auth.Principal{UserID: "integration-test", Roles: []string{auth.RoleAdmin}}, time.Now().Add(time.Hour))
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(middleware.CompanyHeader, testCompany_inventory_api.Code)
 
 	rr := httptest.NewRecorder()
 	s.router.ServeHTTP(rr, req)
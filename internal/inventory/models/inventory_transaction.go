@@ -26,6 +26,7 @@ const (
 // InventoryTransaction records movements of items in and out of warehouses.
 type InventoryTransaction struct {
 	ID               uuid.UUID                `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID        uuid.UUID                `gorm:"type:uuid;not null;index" json:"company_id"`
 	ItemID           uuid.UUID                `gorm:"type:uuid;not null;index" json:"item_id"`     // Foreign key to Item
 	WarehouseID      uuid.UUID                `gorm:"type:uuid;not null;index" json:"warehouse_id"` // Foreign key to Warehouse
 	Quantity         float64                  `gorm:"type:numeric(10,3);not null" json:"quantity"` // Quantity of the transaction (positive for IN, can be positive for OUT and type defines direction)
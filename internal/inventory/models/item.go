@@ -21,7 +21,8 @@ const (
 // Item represents an inventory item.
 type Item struct {
 	ID            uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
-	SKU           string         `gorm:"type:varchar(50);not null;uniqueIndex" json:"sku"` // Stock Keeping Unit, unique
+	CompanyID     uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_items_company_sku" json:"company_id"`
+	SKU           string         `gorm:"type:varchar(50);not null;uniqueIndex:idx_items_company_sku" json:"sku"` // Stock Keeping Unit, unique within the company
 	Name          string         `gorm:"type:varchar(100);not null" json:"name"`
 	Description   string         `gorm:"type:text" json:"description,omitempty"`
 	UnitOfMeasure string         `gorm:"type:varchar(20);not null" json:"unit_of_measure"` // e.g., PCS, KG, LTR, MTR
@@ -10,7 +10,8 @@ import (
 // Warehouse represents a physical or logical location where inventory is stored.
 type Warehouse struct {
 	ID        uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
-	Code      string         `gorm:"type:varchar(20);not null;uniqueIndex" json:"code"` // Unique code for the warehouse
+	CompanyID uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_warehouses_company_code" json:"company_id"`
+	Code      string         `gorm:"type:varchar(20);not null;uniqueIndex:idx_warehouses_company_code" json:"code"` // Unique code for the warehouse within the company
 	Name      string         `gorm:"type:varchar(100);not null" json:"name"`
 	Location  string         `gorm:"type:varchar(255)" json:"location,omitempty"` // Address or description of location
 	IsActive  bool           `gorm:"default:true" json:"is_active"`
@@ -35,11 +35,11 @@ type InventoryTransactionRepositoryIntegrationTestSuite struct {
 // SetupSuite runs once before all tests in the suite.
 func (s *InventoryTransactionRepositoryIntegrationTestSuite) SetupSuite() {
 	s.T().Log("Setting up suite for InventoryTransactionRepository integration tests...")
-	s.db = dbInstance_inventory // Use the DB instance from item_integration_test_setup_test.go
+	s.ctx = companyCtx_inventory()
+	s.db = dbInstance_inventory.WithContext(s.ctx) // Use the DB instance from item_integration_test_setup_test.go
 	s.repo = repository.NewInventoryTransactionRepository(s.db)
 	s.itemRepo = repository.NewItemRepository(s.db)
 	s.warehouseRepo = repository.NewWarehouseRepository(s.db)
-	s.ctx = context.Background()
 	s.T().Log("InventoryTransactionRepository Suite setup complete.")
 }
 
@@ -5,6 +5,7 @@ import (
 	"erp-system/configs"
 	accModels "erp-system/internal/accounting/models" // Accounting models for full schema if needed by FKs from inventory
 	"erp-system/internal/inventory/models"           // Inventory models
+	"erp-system/pkg/company"
 	"erp-system/pkg/database"
 	"erp-system/pkg/logger"
 	"fmt"
@@ -25,8 +26,15 @@ import (
 var (
 	dbInstance_inventory *gorm.DB // Suffix to distinguish from accounting's global if they were in same scope
 	testConfig_inventory configs.AppConfig
+	// testCompany_inventory is the company every inventory repository test works in.
+	testCompany_inventory = company.Company{ID: uuid.MustParse("00000000-0000-0000-0000-00000000b001"), Code: "TEST", BaseCurrency: "USD"}
 )
 
+// companyCtx_inventory returns a context with the test company active, as the API middleware sets it.
+func companyCtx_inventory() context.Context {
+	return company.WithCompany(context.Background(), testCompany_inventory)
+}
+
 // setupInventoryTestDB sets up a PostgreSQL test container for inventory tests.
 func setupInventoryTestDB(ctx context.Context) (*gorm.DB, configs.AppConfig, func(), error) {
 	logger.InfoLogger.Println("Setting up INVENTORY test database container...")
@@ -80,9 +80,9 @@ type ItemRepositoryIntegrationTestSuite struct {
 func (s *ItemRepositoryIntegrationTestSuite) SetupSuite() {
 	s.T().Log("Setting up suite for ItemRepository integration tests...")
 	// This will use the dbInstance configured by TestMain in item_integration_test_setup_test.go (sibling file)
-	s.db = dbInstance_inventory // Use the specific DB instance for inventory tests
+	s.ctx = companyCtx_inventory()
+	s.db = dbInstance_inventory.WithContext(s.ctx) // Use the specific DB instance for inventory tests
 	s.repo = repository.NewItemRepository(s.db)
-	s.ctx = context.Background()
 	s.T().Log("ItemRepository Suite setup complete.")
 }
 
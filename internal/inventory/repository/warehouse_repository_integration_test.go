@@ -23,9 +23,9 @@ type WarehouseRepositoryIntegrationTestSuite struct {
 // SetupSuite runs once before all tests in the suite.
 func (s *WarehouseRepositoryIntegrationTestSuite) SetupSuite() {
 	s.T().Log("Setting up suite for WarehouseRepository integration tests...")
-	s.db = dbInstance_inventory // Use the DB instance from item_integration_test_setup_test.go
+	s.ctx = companyCtx_inventory()
+	s.db = dbInstance_inventory.WithContext(s.ctx) // Use the DB instance from item_integration_test_setup_test.go
 	s.repo = repository.NewWarehouseRepository(s.db)
-	s.ctx = context.Background()
 	s.T().Log("WarehouseRepository Suite setup complete.")
 }
 
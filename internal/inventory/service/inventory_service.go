@@ -45,6 +45,24 @@ type inventoryService struct {
 	itemRepo         repo.ItemRepository
 	warehouseRepo    repo.WarehouseRepository
 	transactionRepo  repo.InventoryTransactionRepository
+	periodChecker    PostingPeriodChecker // Optional; nil means every date is open
+}
+
+// PostingPeriodChecker decides whether a transaction dated on a given day may be recorded.
+// The accounting fiscal calendar service satisfies it.
+type PostingPeriodChecker interface {
+	CheckPostingAllowed(ctx context.Context, date time.Time) error
+}
+
+// InventoryServiceOption configures optional collaborators of the inventory service.
+type InventoryServiceOption func(*inventoryService)
+
+// WithPostingPeriodChecker makes inventory transactions obey the fiscal period locks
+// through their TransactionDate.
+func WithPostingPeriodChecker(checker PostingPeriodChecker) InventoryServiceOption {
+	return func(s *inventoryService) {
+		s.periodChecker = checker
+	}
 }
 
 // NewInventoryService creates a new InventoryService.
@@ -52,12 +70,17 @@ func NewInventoryService(
 	itemRepo repo.ItemRepository,
 	warehouseRepo repo.WarehouseRepository,
 	transactionRepo repo.InventoryTransactionRepository,
+	opts ...InventoryServiceOption,
 ) InventoryService {
-	return &inventoryService{
+	s := &inventoryService{
 		itemRepo:        itemRepo,
 		warehouseRepo:   warehouseRepo,
 		transactionRepo: transactionRepo,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // --- Item Management Methods ---
@@ -370,6 +393,16 @@ func (s *inventoryService) CreateInventoryAdjustment(ctx context.Context, req dt
 		return nil, app_errors.NewValidationError(fmt.Sprintf("invalid adjustment type: %s. Must be ADJUST_STOCK_IN or ADJUST_STOCK_OUT.", req.AdjustmentType), "adjustment_type")
 	}
 
+	transactionDate := time.Now()
+	if req.TransactionDate != nil && !(*req.TransactionDate).IsZero() {
+		transactionDate = *req.TransactionDate
+	}
+	if s.periodChecker != nil {
+		if err := s.periodChecker.CheckPostingAllowed(ctx, transactionDate); err != nil {
+			return nil, err
+		}
+	}
+
 	// For AdjustStockOut, check if sufficient stock exists (optional, depending on allow negative stock setting)
 	// This check is simplified; a real system might have an "allow negative inventory" setting per item/warehouse.
 	if req.AdjustmentType == models.AdjustStockOut {
@@ -385,11 +418,6 @@ func (s *inventoryService) CreateInventoryAdjustment(ctx context.Context, req dt
 		}
 	}
 
-	transactionDate := time.Now()
-	if req.TransactionDate != nil && !(*req.TransactionDate).IsZero() {
-		transactionDate = *req.TransactionDate
-	}
-
 	transaction := &models.InventoryTransaction{
 		ItemID:          req.ItemID,
 		WarehouseID:     req.WarehouseID,
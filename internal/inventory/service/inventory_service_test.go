@@ -303,6 +303,38 @@ func TestInventoryService_CreateInventoryAdjustment(t *testing.T) {
     })
 }
 
+// closedPeriodChecker rejects every transaction date, like a CLOSED fiscal period.
+type closedPeriodChecker struct{}
+
+func (closedPeriodChecker) CheckPostingAllowed(_ context.Context, date time.Time) error {
+	return app_errors.NewConflictError("fiscal period is closed for " + date.Format("2006-01-02"))
+}
+
+func TestInventoryService_CreateInventoryAdjustment_ClosedPeriod(t *testing.T) {
+	mockItemRepo := invRepoMock.NewItemRepositoryMock(t)
+	mockWarehouseRepo := invRepoMock.NewWarehouseRepositoryMock(t)
+	mockTxnRepo := invRepoMock.NewInventoryTransactionRepositoryMock(t)
+	invService := service.NewInventoryService(mockItemRepo, mockWarehouseRepo, mockTxnRepo, service.WithPostingPeriodChecker(closedPeriodChecker{}))
+	ctx := context.Background()
+
+	itemID := uuid.New()
+	warehouseID := uuid.New()
+	txDate := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	mockItemRepo.On("GetByID", ctx, itemID).Return(&models.Item{ID: itemID, IsActive: true, ItemType: models.FinishedGood}, nil).Once()
+	mockWarehouseRepo.On("GetByID", ctx, warehouseID).Return(&models.Warehouse{ID: warehouseID, IsActive: true}, nil).Once()
+
+	_, err := invService.CreateInventoryAdjustment(ctx, dto.CreateInventoryAdjustmentRequest{
+		ItemID:          itemID,
+		WarehouseID:     warehouseID,
+		AdjustmentType:  models.AdjustStockOut,
+		Quantity:        1.0,
+		TransactionDate: &txDate,
+	})
+	assert.Error(t, err)
+	assert.IsType(t, &app_errors.ConflictError{}, err)
+	mockTxnRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestInventoryService_GetInventoryLevels(t *testing.T) {
     mockItemRepo := invRepoMock.NewItemRepositoryMock(t)
     mockWarehouseRepo := invRepoMock.NewWarehouseRepositoryMock(t)
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BillStatus represents the status of a vendor bill.
+type BillStatus string
+
+const (
+	BillStatusDraft BillStatus = "DRAFT" // Can still be deleted; not yet in the general ledger
+	// BillStatusPosted bills have a number and a journal entry. They are never changed again.
+	BillStatusPosted BillStatus = "POSTED"
+)
+
+// Bill is a vendor's invoice to the company. Posting it debits the expense or asset accounts of its
+// lines, with the input tax of their tax codes, and credits the payables control account with the
+// total. Amounts are in the company's functional currency.
+type Bill struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID  `gorm:"type:uuid;not null;index;index:idx_purchase_bills_number,unique,where:bill_number <> '';index:idx_purchase_bills_vendor_invoice,unique" json:"company_id"`
+	VendorID  uuid.UUID  `gorm:"type:uuid;not null;index;index:idx_purchase_bills_vendor_invoice,unique" json:"vendor_id"`
+	Status    BillStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	// BillNumber is the gap-free number, such as BILL-2026-000042, given to the bill when it is posted.
+	BillNumber string `gorm:"type:varchar(50);index:idx_purchase_bills_number,unique,where:bill_number <> ''" json:"bill_number,omitempty"`
+	// VendorInvoiceNumber is the vendor's own number for the invoice; a vendor's invoice can only be
+	// entered once.
+	VendorInvoiceNumber string    `gorm:"type:varchar(100);not null;index:idx_purchase_bills_vendor_invoice,unique" json:"vendor_invoice_number"`
+	BillDate            time.Time `gorm:"type:date;not null" json:"bill_date"`
+	DueDate             time.Time `gorm:"type:date;not null" json:"due_date"`
+	// DiscountRate is taken off the total when the bill is paid in full by DiscountDate.
+	DiscountRate money.Rate   `gorm:"type:numeric(18,10);not null;default:0" json:"discount_rate"`
+	DiscountDate *time.Time   `gorm:"type:date" json:"discount_date,omitempty"` // Set only with a discount
+	Description  string       `gorm:"type:varchar(255)" json:"description,omitempty"`
+	Currency     string       `gorm:"type:varchar(3);not null" json:"currency"`
+	NetAmount    money.Amount `gorm:"type:numeric(18,4);not null" json:"net_amount"`
+	TaxAmount    money.Amount `gorm:"type:numeric(18,4);not null" json:"tax_amount"`
+	TotalAmount  money.Amount `gorm:"type:numeric(18,4);not null" json:"total_amount"`
+	// PaidAmount is what payments have settled of the bill, including the discounts taken.
+	PaidAmount     money.Amount `gorm:"type:numeric(18,4);not null;default:0" json:"paid_amount"`
+	JournalEntryID *uuid.UUID   `gorm:"type:uuid;index" json:"journal_entry_id,omitempty"`
+	PostedAt       *time.Time   `json:"posted_at,omitempty"`
+	CreatedBy      string       `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	Lines          []BillLine   `gorm:"foreignKey:BillID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines"`
+	CreatedAt      time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Bill model.
+func (Bill) TableName() string {
+	return "purchase_bills"
+}
+
+// BeforeCreate will set a UUID for the new bill.
+func (b *Bill) BeforeCreate(tx *gorm.DB) (err error) {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return
+}
+
+// OpenAmount is what is left to pay of the bill.
+func (b *Bill) OpenAmount() money.Amount {
+	return b.TotalAmount.Sub(b.PaidAmount)
+}
+
+// DiscountAvailable returns the early-payment discount on the bill's total for a payment dated
+// paymentDate, rounded to the bill's currency, and whether it can still be taken.
+func (b *Bill) DiscountAvailable(paymentDate time.Time) (money.Amount, bool) {
+	if !b.DiscountRate.IsPositive() || b.DiscountDate == nil || paymentDate.After(*b.DiscountDate) || paymentDate.Before(b.BillDate) {
+		return money.Zero, false
+	}
+	return b.TotalAmount.Convert(b.DiscountRate).Round(b.Currency), true
+}
+
+// BillLine is an expense or asset line of a bill. Amount is net of tax; TaxAmount is the input tax
+// its TaxCode charges on it on the bill date.
+type BillLine struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"-"`
+	BillID      uuid.UUID    `gorm:"type:uuid;not null;index" json:"bill_id"`
+	LineNumber  int          `gorm:"not null" json:"line_number"`
+	Description string       `gorm:"type:varchar(255)" json:"description,omitempty"`
+	AccountID   uuid.UUID    `gorm:"type:uuid;not null" json:"account_id"` // The expense or asset account debited
+	Amount      money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"`
+	TaxCode     string       `gorm:"type:varchar(20)" json:"tax_code,omitempty"`
+	TaxAmount   money.Amount `gorm:"type:numeric(18,4);not null" json:"tax_amount"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for BillLine model.
+func (BillLine) TableName() string {
+	return "purchase_bill_lines"
+}
+
+// BeforeCreate will set a UUID for the new bill line.
+func (l *BillLine) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
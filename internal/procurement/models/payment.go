@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Payment is a payment made to a vendor. Posting it debits the payables control account with the
+// amount paid plus the early-payment discounts taken, and credits the bank account it was paid from
+// and, with the discounts, the purchase discount account. It settles the vendor's bills through its
+// allocations; what is not allocated stays on the vendor's account as an advance.
+type Payment struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID `gorm:"type:uuid;not null;index;index:idx_vendor_payments_number,unique" json:"company_id"`
+	VendorID  uuid.UUID `gorm:"type:uuid;not null;index" json:"vendor_id"`
+	// PaymentNumber is the gap-free number, such as PAY-2026-000007, given to the payment.
+	PaymentNumber   string       `gorm:"type:varchar(50);not null;index:idx_vendor_payments_number,unique" json:"payment_number"`
+	PaymentDate     time.Time    `gorm:"type:date;not null" json:"payment_date"`
+	BankAccountID   uuid.UUID    `gorm:"type:uuid;not null" json:"bank_account_id"` // The bank or cash account credited
+	Amount          money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"` // Paid out of the bank
+	Currency        string       `gorm:"type:varchar(3);not null" json:"currency"`
+	AllocatedAmount money.Amount `gorm:"type:numeric(18,4);not null;default:0" json:"allocated_amount"`
+	// DiscountAmount is the total early-payment discount taken on the bills the payment settled.
+	DiscountAmount money.Amount `gorm:"type:numeric(18,4);not null;default:0" json:"discount_amount"`
+	Reference      string       `gorm:"type:varchar(100)" json:"reference,omitempty"` // e.g. the bank transfer reference
+	JournalEntryID *uuid.UUID   `gorm:"type:uuid;index" json:"journal_entry_id,omitempty"`
+	CreatedBy      string       `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt      time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Payment model.
+func (Payment) TableName() string {
+	return "vendor_payments"
+}
+
+// BeforeCreate will set a UUID for the new payment.
+func (p *Payment) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return
+}
+
+// UnallocatedAmount is what is left of the payment to apply to bills.
+func (p *Payment) UnallocatedAmount() money.Amount {
+	return p.Amount.Sub(p.AllocatedAmount)
+}
+
+// Allocation applies part of a payment to a bill of the same vendor. Amount comes out of the
+// payment; the bill is settled by Amount plus DiscountAmount. Allocations only move amounts between
+// documents on the payables control account, so they post nothing to the general ledger.
+type Allocation struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID    `gorm:"type:uuid;not null;index" json:"-"`
+	VendorID  uuid.UUID    `gorm:"type:uuid;not null;index" json:"vendor_id"`
+	BillID    uuid.UUID    `gorm:"type:uuid;not null;index" json:"bill_id"`
+	PaymentID uuid.UUID    `gorm:"type:uuid;not null;index" json:"payment_id"`
+	Amount    money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"`
+	// DiscountAmount is the early-payment discount taken; only allocations made with their payment take one.
+	DiscountAmount money.Amount `gorm:"type:numeric(18,4);not null;default:0" json:"discount_amount"`
+	// AllocationDate is the later of the two documents' dates, from which the bill counts as paid.
+	AllocationDate time.Time `gorm:"type:date;not null" json:"allocation_date"`
+	CreatedBy      string    `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Allocation model.
+func (Allocation) TableName() string {
+	return "payable_allocations"
+}
+
+// BeforeCreate will set a UUID for the new allocation.
+func (a *Allocation) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
+
+// Settled is how much of the bill the allocation pays off.
+func (a *Allocation) Settled() money.Amount {
+	return a.Amount.Add(a.DiscountAmount)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultPaymentTermsDays is the time the company takes to pay vendors unless they have their own terms.
+const DefaultPaymentTermsDays = 30
+
+// Vendor is a party the company buys from on credit. Its bills and the payments made to it make up
+// its account in the payables subledger.
+type Vendor struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_vendors_company_code" json:"company_id"`
+	Code      string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_vendors_company_code" json:"code"` // e.g. "STEELCO"
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	TaxID     string    `gorm:"type:varchar(50)" json:"tax_id,omitempty"` // VAT or other tax registration number
+	Email     string    `gorm:"type:varchar(100)" json:"email,omitempty"`
+	// PaymentTermsDays is the number of days from a bill's date to its due date; 0 means due on
+	// receipt. It has no GORM default, so that 0 is saved as given.
+	PaymentTermsDays int `gorm:"not null" json:"payment_terms_days"`
+	// DiscountRate and DiscountDays are the vendor's early-payment discount, e.g. 0.02 and 10 for
+	// "2/10 net 30": 2% off a bill paid in full within 10 days of its date. A zero rate means none.
+	DiscountRate money.Rate `gorm:"type:numeric(18,10);not null;default:0" json:"discount_rate"`
+	DiscountDays int        `gorm:"not null;default:0" json:"discount_days"`
+	IsActive     bool       `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Vendor model.
+func (Vendor) TableName() string {
+	return "vendors"
+}
+
+// BeforeCreate will set a UUID for the new vendor.
+func (v *Vendor) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,314 @@
+package mocks
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	"erp-system/internal/procurement/models"
+	"erp-system/internal/procurement/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// PayableRepository is an autogenerated mock type for the PayableRepository type
+type PayableRepository struct {
+	mock.Mock
+}
+
+// Allocate provides a mock function with given fields: ctx, allocations
+func (_m *PayableRepository) Allocate(ctx context.Context, allocations []*models.Allocation) error {
+	ret := _m.Called(ctx, allocations)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Allocation) error); ok {
+		r0 = rf(ctx, allocations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateBill provides a mock function with given fields: ctx, bill
+func (_m *PayableRepository) CreateBill(ctx context.Context, bill *models.Bill) (*models.Bill, error) {
+	ret := _m.Called(ctx, bill)
+
+	var r0 *models.Bill
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Bill) *models.Bill); ok {
+		r0 = rf(ctx, bill)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Bill) error); ok {
+		r1 = rf(ctx, bill)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePayment provides a mock function with given fields: ctx, payment, entry, allocations
+func (_m *PayableRepository) CreatePayment(ctx context.Context, payment *models.Payment, entry *accModels.JournalEntry, allocations []*models.Allocation) (*models.Payment, error) {
+	ret := _m.Called(ctx, payment, entry, allocations)
+
+	var r0 *models.Payment
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Payment, *accModels.JournalEntry, []*models.Allocation) *models.Payment); ok {
+		r0 = rf(ctx, payment, entry, allocations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Payment, *accModels.JournalEntry, []*models.Allocation) error); ok {
+		r1 = rf(ctx, payment, entry, allocations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBill provides a mock function with given fields: ctx, id
+func (_m *PayableRepository) DeleteBill(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBill provides a mock function with given fields: ctx, id
+func (_m *PayableRepository) GetBill(ctx context.Context, id uuid.UUID) (*models.Bill, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Bill
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Bill); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBillByVendorInvoiceNumber provides a mock function with given fields: ctx, vendorID, number
+func (_m *PayableRepository) GetBillByVendorInvoiceNumber(ctx context.Context, vendorID uuid.UUID, number string) (*models.Bill, error) {
+	ret := _m.Called(ctx, vendorID, number)
+
+	var r0 *models.Bill
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *models.Bill); ok {
+		r0 = rf(ctx, vendorID, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, vendorID, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPayment provides a mock function with given fields: ctx, id
+func (_m *PayableRepository) GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Payment
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Payment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAllocations provides a mock function with given fields: ctx, vendorID, asOf
+func (_m *PayableRepository) ListAllocations(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Allocation, error) {
+	ret := _m.Called(ctx, vendorID, asOf)
+
+	var r0 []*models.Allocation
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time) []*models.Allocation); ok {
+		r0 = rf(ctx, vendorID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Allocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, vendorID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBills provides a mock function with given fields: ctx, filters
+func (_m *PayableRepository) ListBills(ctx context.Context, filters map[string]interface{}) ([]*models.Bill, error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 []*models.Bill
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) []*models.Bill); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Bill)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]interface{}) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDocumentAllocations provides a mock function with given fields: ctx, documentID
+func (_m *PayableRepository) ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error) {
+	ret := _m.Called(ctx, documentID)
+
+	var r0 []*models.Allocation
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.Allocation); ok {
+		r0 = rf(ctx, documentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Allocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, documentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPayments provides a mock function with given fields: ctx, vendorID, asOf
+func (_m *PayableRepository) ListPayments(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Payment, error) {
+	ret := _m.Called(ctx, vendorID, asOf)
+
+	var r0 []*models.Payment
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time) []*models.Payment); ok {
+		r0 = rf(ctx, vendorID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Payment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, vendorID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPostedBills provides a mock function with given fields: ctx, vendorID, asOf
+func (_m *PayableRepository) ListPostedBills(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Bill, error) {
+	ret := _m.Called(ctx, vendorID, asOf)
+
+	var r0 []*models.Bill
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time) []*models.Bill); ok {
+		r0 = rf(ctx, vendorID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Bill)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, vendorID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostBill provides a mock function with given fields: ctx, bill, entry
+func (_m *PayableRepository) PostBill(ctx context.Context, bill *models.Bill, entry *accModels.JournalEntry) (*models.Bill, error) {
+	ret := _m.Called(ctx, bill, entry)
+
+	var r0 *models.Bill
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Bill, *accModels.JournalEntry) *models.Bill); ok {
+		r0 = rf(ctx, bill, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bill)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Bill, *accModels.JournalEntry) error); ok {
+		r1 = rf(ctx, bill, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPayableRepository creates a new instance of PayableRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPayableRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PayableRepository {
+	mock := &PayableRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.PayableRepository = (*PayableRepository)(nil)
@@ -0,0 +1,146 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/procurement/models"
+	"erp-system/internal/procurement/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// VendorRepository is an autogenerated mock type for the VendorRepository type
+type VendorRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, vendor
+func (_m *VendorRepository) Create(ctx context.Context, vendor *models.Vendor) (*models.Vendor, error) {
+	ret := _m.Called(ctx, vendor)
+
+	var r0 *models.Vendor
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Vendor) *models.Vendor); ok {
+		r0 = rf(ctx, vendor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Vendor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Vendor) error); ok {
+		r1 = rf(ctx, vendor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCode provides a mock function with given fields: ctx, code
+func (_m *VendorRepository) GetByCode(ctx context.Context, code string) (*models.Vendor, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *models.Vendor
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Vendor); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Vendor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *VendorRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Vendor, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Vendor
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Vendor); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Vendor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *VendorRepository) List(ctx context.Context) ([]*models.Vendor, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.Vendor
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Vendor); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Vendor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, vendor
+func (_m *VendorRepository) Update(ctx context.Context, vendor *models.Vendor) (*models.Vendor, error) {
+	ret := _m.Called(ctx, vendor)
+
+	var r0 *models.Vendor
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Vendor) *models.Vendor); ok {
+		r0 = rf(ctx, vendor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Vendor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Vendor) error); ok {
+		r1 = rf(ctx, vendor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewVendorRepository creates a new instance of VendorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVendorRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *VendorRepository {
+	mock := &VendorRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.VendorRepository = (*VendorRepository)(nil)
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	accRepo "erp-system/internal/accounting/repository"
+	"erp-system/internal/procurement/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PayableRepository defines the interface for database operations for the payables subledger:
+// vendor bills, the payments made to vendors and the allocations between them.
+type PayableRepository interface {
+	CreateBill(ctx context.Context, bill *models.Bill) (*models.Bill, error)
+	GetBill(ctx context.Context, id uuid.UUID) (*models.Bill, error)
+	GetBillByVendorInvoiceNumber(ctx context.Context, vendorID uuid.UUID, number string) (*models.Bill, error)
+	ListBills(ctx context.Context, filters map[string]interface{}) ([]*models.Bill, error)
+	DeleteBill(ctx context.Context, id uuid.UUID) error
+	PostBill(ctx context.Context, bill *models.Bill, entry *accModels.JournalEntry) (*models.Bill, error)
+	// ListPostedBills returns the posted bills dated on or before asOf (all of them for a zero
+	// asOf), of one vendor if vendorID is set, in due date order.
+	ListPostedBills(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Bill, error)
+
+	CreatePayment(ctx context.Context, payment *models.Payment, entry *accModels.JournalEntry, allocations []*models.Allocation) (*models.Payment, error)
+	GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error)
+	// ListPayments returns the payments dated on or before asOf (all of them for a zero asOf), of
+	// one vendor if vendorID is set, oldest first.
+	ListPayments(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Payment, error)
+
+	Allocate(ctx context.Context, allocations []*models.Allocation) error
+	// ListAllocations returns the allocations dated on or before asOf (all of them for a zero
+	// asOf), of one vendor if vendorID is set.
+	ListAllocations(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Allocation, error)
+	// ListDocumentAllocations returns the allocations of a bill or payment.
+	ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error)
+}
+
+// gormPayableRepository is an implementation of PayableRepository using GORM.
+type gormPayableRepository struct {
+	db *gorm.DB
+}
+
+// NewPayableRepository creates a new GORM-based PayableRepository.
+func NewPayableRepository(db *gorm.DB) PayableRepository {
+	return &gormPayableRepository{db: db}
+}
+
+// preloadBillLines loads a bill's lines in line order.
+func preloadBillLines(db *gorm.DB) *gorm.DB {
+	return db.Order("line_number asc")
+}
+
+func (r *gormPayableRepository) CreateBill(ctx context.Context, bill *models.Bill) (*models.Bill, error) {
+	logger.InfoLogger.Printf("Repository: Creating bill %s of vendor %s", bill.VendorInvoiceNumber, bill.VendorID)
+	if err := r.db.WithContext(ctx).Create(bill).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating bill %s of vendor %s: %v", bill.VendorInvoiceNumber, bill.VendorID, err)
+		return nil, errors.NewInternalServerError("failed to create bill", err)
+	}
+	return bill, nil
+}
+
+func (r *gormPayableRepository) GetBill(ctx context.Context, id uuid.UUID) (*models.Bill, error) {
+	var bill models.Bill
+	if err := r.db.WithContext(ctx).Preload("Lines", preloadBillLines).First(&bill, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("bill", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving bill %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get bill %s", id), err)
+	}
+	return &bill, nil
+}
+
+func (r *gormPayableRepository) GetBillByVendorInvoiceNumber(ctx context.Context, vendorID uuid.UUID, number string) (*models.Bill, error) {
+	var bill models.Bill
+	if err := r.db.WithContext(ctx).First(&bill, "vendor_id = ? AND vendor_invoice_number = ?", vendorID, number).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("vendor_invoice_number", number)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving bill %s of vendor %s: %v", number, vendorID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get bill %s", number), err)
+	}
+	return &bill, nil
+}
+
+// ListBills returns the bills matching filters (column to value), latest first.
+func (r *gormPayableRepository) ListBills(ctx context.Context, filters map[string]interface{}) ([]*models.Bill, error) {
+	var bills []*models.Bill
+	if err := r.db.WithContext(ctx).Where(filters).Order("bill_date desc, created_at desc").Find(&bills).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing bills: %v", err)
+		return nil, errors.NewInternalServerError("failed to list bills", err)
+	}
+	return bills, nil
+}
+
+// DeleteBill deletes a DRAFT bill with its lines. It returns a ConflictError if the bill has been
+// posted in the meantime.
+func (r *gormPayableRepository) DeleteBill(ctx context.Context, id uuid.UUID) error {
+	logger.InfoLogger.Printf("Repository: Deleting draft bill %s", id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND status = ?", id, models.BillStatusDraft).Delete(&models.Bill{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("bill %s is no longer a DRAFT", id))
+		}
+		return tx.Where("bill_id = ?", id).Delete(&models.BillLine{}).Error
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return err
+		}
+		logger.ErrorLogger.Printf("Repository: Error deleting bill %s: %v", id, err)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to delete bill %s", id), err)
+	}
+	return nil
+}
+
+// PostBill gives a DRAFT bill its number, saves its journal entry with the number as reference,
+// and marks it POSTED, all in one transaction. It returns a ConflictError if the bill was posted
+// concurrently, so a bill never gets two entries.
+func (r *gormPayableRepository) PostBill(ctx context.Context, bill *models.Bill, entry *accModels.JournalEntry) (*models.Bill, error) {
+	logger.InfoLogger.Printf("Repository: Posting bill %s", bill.ID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		number, err := accRepo.NextDocumentNumber(tx, accModels.DocumentTypePurchaseBill, bill.BillDate)
+		if err != nil {
+			return err
+		}
+		entry.Reference = number
+		if err := accRepo.SaveJournalEntry(tx, entry); err != nil {
+			return err
+		}
+		postedAt := time.Now()
+		result := tx.Model(&models.Bill{}).Where("id = ? AND status = ?", bill.ID, models.BillStatusDraft).Updates(map[string]interface{}{
+			"status":           models.BillStatusPosted,
+			"bill_number":      number,
+			"journal_entry_id": entry.ID,
+			"posted_at":        postedAt,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("bill %s is no longer a DRAFT", bill.ID))
+		}
+		return nil
+	})
+	if err != nil {
+		switch err.(type) {
+		case *errors.ConflictError, *errors.ValidationError:
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Error posting bill %s: %v", bill.ID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to post bill %s", bill.ID), err)
+	}
+	return r.GetBill(ctx, bill.ID)
+}
+
+func (r *gormPayableRepository) ListPostedBills(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Bill, error) {
+	query := r.db.WithContext(ctx).Where("status = ?", models.BillStatusPosted)
+	if vendorID != nil {
+		query = query.Where("vendor_id = ?", *vendorID)
+	}
+	if !asOf.IsZero() {
+		query = query.Where("bill_date <= ?", asOf)
+	}
+	var bills []*models.Bill
+	if err := query.Order("due_date asc, bill_number asc").Find(&bills).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing posted bills: %v", err)
+		return nil, errors.NewInternalServerError("failed to list posted bills", err)
+	}
+	return bills, nil
+}
+
+// CreatePayment gives the payment its number, saves its journal entry with the number as
+// reference, creates the payment and applies its allocations, all in one transaction.
+func (r *gormPayableRepository) CreatePayment(ctx context.Context, payment *models.Payment, entry *accModels.JournalEntry, allocations []*models.Allocation) (*models.Payment, error) {
+	logger.InfoLogger.Printf("Repository: Creating payment of %s to vendor %s", payment.Amount, payment.VendorID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		number, err := accRepo.NextDocumentNumber(tx, accModels.DocumentTypeVendorPayment, payment.PaymentDate)
+		if err != nil {
+			return err
+		}
+		payment.PaymentNumber = number
+		entry.Reference = number
+		if err := accRepo.SaveJournalEntry(tx, entry); err != nil {
+			return err
+		}
+		payment.JournalEntryID = &entry.ID
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+		for _, allocation := range allocations {
+			allocation.PaymentID = payment.ID
+		}
+		return applyAllocations(tx, allocations)
+	})
+	if err != nil {
+		switch err.(type) {
+		case *errors.ConflictError, *errors.ValidationError:
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Error creating payment to vendor %s: %v", payment.VendorID, err)
+		return nil, errors.NewInternalServerError("failed to create payment", err)
+	}
+	return r.GetPayment(ctx, payment.ID)
+}
+
+func (r *gormPayableRepository) GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	var payment models.Payment
+	if err := r.db.WithContext(ctx).First(&payment, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("payment", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving payment %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get payment %s", id), err)
+	}
+	return &payment, nil
+}
+
+func (r *gormPayableRepository) ListPayments(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Payment, error) {
+	query := r.db.WithContext(ctx)
+	if vendorID != nil {
+		query = query.Where("vendor_id = ?", *vendorID)
+	}
+	if !asOf.IsZero() {
+		query = query.Where("payment_date <= ?", asOf)
+	}
+	var payments []*models.Payment
+	if err := query.Order("payment_date asc, payment_number asc").Find(&payments).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing payments: %v", err)
+		return nil, errors.NewInternalServerError("failed to list payments", err)
+	}
+	return payments, nil
+}
+
+// Allocate applies allocations in one transaction: all of them or none.
+func (r *gormPayableRepository) Allocate(ctx context.Context, allocations []*models.Allocation) error {
+	logger.InfoLogger.Printf("Repository: Saving %d payable allocations", len(allocations))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyAllocations(tx, allocations)
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return err
+		}
+		logger.ErrorLogger.Printf("Repository: Error saving payable allocations: %v", err)
+		return errors.NewInternalServerError("failed to save allocations", err)
+	}
+	return nil
+}
+
+// applyAllocations saves allocations within tx, adds what they settle to the paid amounts of their
+// bills and what they take from their payments to the payments' allocated amounts. Each update only
+// succeeds while the document still has the amount open, so concurrent allocations can never pay a
+// bill twice; otherwise a ConflictError is returned.
+func applyAllocations(tx *gorm.DB, allocations []*models.Allocation) error {
+	for _, allocation := range allocations {
+		settled := allocation.Settled()
+		result := tx.Model(&models.Bill{}).
+			Where("id = ? AND status = ? AND paid_amount + ? <= total_amount", allocation.BillID, models.BillStatusPosted, settled).
+			Update("paid_amount", gorm.Expr("paid_amount + ?", settled))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("bill %s does not have %s open", allocation.BillID, settled))
+		}
+
+		result = tx.Model(&models.Payment{}).
+			Where("id = ? AND allocated_amount + ? <= amount", allocation.PaymentID, allocation.Amount).
+			Update("allocated_amount", gorm.Expr("allocated_amount + ?", allocation.Amount))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("%s of payment %s is no longer unallocated", allocation.Amount, allocation.PaymentID))
+		}
+		if err := tx.Create(allocation).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *gormPayableRepository) ListAllocations(ctx context.Context, vendorID *uuid.UUID, asOf time.Time) ([]*models.Allocation, error) {
+	query := r.db.WithContext(ctx)
+	if vendorID != nil {
+		query = query.Where("vendor_id = ?", *vendorID)
+	}
+	if !asOf.IsZero() {
+		query = query.Where("allocation_date <= ?", asOf)
+	}
+	var allocations []*models.Allocation
+	if err := query.Order("allocation_date asc, created_at asc").Find(&allocations).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing payable allocations: %v", err)
+		return nil, errors.NewInternalServerError("failed to list allocations", err)
+	}
+	return allocations, nil
+}
+
+func (r *gormPayableRepository) ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error) {
+	var allocations []*models.Allocation
+	err := r.db.WithContext(ctx).
+		Where("bill_id = ? OR payment_id = ?", documentID, documentID).
+		Order("allocation_date asc, created_at asc").Find(&allocations).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing allocations of document %s: %v", documentID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to list allocations of %s", documentID), err)
+	}
+	return allocations, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/procurement/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VendorRepository defines the interface for database operations for vendors.
+type VendorRepository interface {
+	Create(ctx context.Context, vendor *models.Vendor) (*models.Vendor, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Vendor, error)
+	GetByCode(ctx context.Context, code string) (*models.Vendor, error)
+	Update(ctx context.Context, vendor *models.Vendor) (*models.Vendor, error)
+	List(ctx context.Context) ([]*models.Vendor, error)
+}
+
+// gormVendorRepository is an implementation of VendorRepository using GORM.
+type gormVendorRepository struct {
+	db *gorm.DB
+}
+
+// NewVendorRepository creates a new GORM-based VendorRepository.
+func NewVendorRepository(db *gorm.DB) VendorRepository {
+	return &gormVendorRepository{db: db}
+}
+
+func (r *gormVendorRepository) Create(ctx context.Context, vendor *models.Vendor) (*models.Vendor, error) {
+	logger.InfoLogger.Printf("Repository: Creating vendor %s", vendor.Code)
+	if err := r.db.WithContext(ctx).Create(vendor).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating vendor %s: %v", vendor.Code, err)
+		return nil, errors.NewInternalServerError("failed to create vendor", err)
+	}
+	return vendor, nil
+}
+
+func (r *gormVendorRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Vendor, error) {
+	var vendor models.Vendor
+	if err := r.db.WithContext(ctx).First(&vendor, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("vendor", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving vendor %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get vendor %s", id), err)
+	}
+	return &vendor, nil
+}
+
+func (r *gormVendorRepository) GetByCode(ctx context.Context, code string) (*models.Vendor, error) {
+	var vendor models.Vendor
+	if err := r.db.WithContext(ctx).First(&vendor, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("vendor_code", code)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving vendor %s: %v", code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get vendor %s", code), err)
+	}
+	return &vendor, nil
+}
+
+func (r *gormVendorRepository) Update(ctx context.Context, vendor *models.Vendor) (*models.Vendor, error) {
+	logger.InfoLogger.Printf("Repository: Updating vendor %s", vendor.Code)
+	if err := r.db.WithContext(ctx).Save(vendor).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating vendor %s: %v", vendor.Code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update vendor %s", vendor.Code), err)
+	}
+	return vendor, nil
+}
+
+// List returns every vendor, ordered by code.
+func (r *gormVendorRepository) List(ctx context.Context) ([]*models.Vendor, error) {
+	var vendors []*models.Vendor
+	if err := r.db.WithContext(ctx).Order("code asc").Find(&vendors).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing vendors: %v", err)
+		return nil, errors.NewInternalServerError("failed to list vendors", err)
+	}
+	return vendors, nil
+}
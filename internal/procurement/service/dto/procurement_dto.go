@@ -0,0 +1,194 @@
+package dto
+
+import (
+	"erp-system/internal/procurement/models"
+	"erp-system/pkg/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// --- Vendor DTOs ---
+
+// CreateVendorRequest defines the structure for creating a new vendor.
+type CreateVendorRequest struct {
+	Code             string      `json:"code" binding:"required,max=20"` // Upper-cased; cannot be changed later
+	Name             string      `json:"name" binding:"required,max=100"`
+	TaxID            string      `json:"tax_id,omitempty" binding:"max=50"`
+	Email            string      `json:"email,omitempty" binding:"max=100"`
+	PaymentTermsDays *int        `json:"payment_terms_days,omitempty"` // Defaults to 30; 0 means due on receipt
+	DiscountRate     *money.Rate `json:"discount_rate,omitempty"`      // Early-payment discount, e.g. 0.02 for 2%
+	DiscountDays     *int        `json:"discount_days,omitempty"`      // Days from the bill date the discount is offered
+}
+
+// UpdateVendorRequest changes a vendor's details. Omitted fields are left unchanged. Terms apply to
+// bills entered from now on.
+type UpdateVendorRequest struct {
+	Name             *string     `json:"name,omitempty" binding:"omitempty,max=100"`
+	TaxID            *string     `json:"tax_id,omitempty" binding:"omitempty,max=50"`
+	Email            *string     `json:"email,omitempty" binding:"omitempty,max=100"`
+	PaymentTermsDays *int        `json:"payment_terms_days,omitempty"`
+	DiscountRate     *money.Rate `json:"discount_rate,omitempty"`
+	DiscountDays     *int        `json:"discount_days,omitempty"`
+	IsActive         *bool       `json:"is_active,omitempty"` // Bills cannot be entered for inactive vendors
+}
+
+// --- Bill DTOs ---
+
+// BillLineRequest is an expense or asset line of a bill.
+type BillLineRequest struct {
+	Description string       `json:"description,omitempty" binding:"max=255"`
+	AccountID   uuid.UUID    `json:"account_id"`         // An EXPENSE or ASSET account
+	Amount      money.Amount `json:"amount"`             // Net of tax; must be positive
+	TaxCode     string       `json:"tax_code,omitempty"` // An INPUT tax code
+}
+
+// CreateBillRequest drafts a vendor bill.
+type CreateBillRequest struct {
+	VendorID            uuid.UUID  `json:"vendor_id"`
+	VendorInvoiceNumber string     `json:"vendor_invoice_number" binding:"required,max=100"`
+	BillDate            time.Time  `json:"bill_date"`
+	DueDate             *time.Time `json:"due_date,omitempty"` // Defaults to the bill date plus the vendor's payment terms
+	// DiscountRate and DiscountDays override the vendor's early-payment discount for this bill.
+	DiscountRate *money.Rate       `json:"discount_rate,omitempty"`
+	DiscountDays *int              `json:"discount_days,omitempty"`
+	Description  string            `json:"description,omitempty" binding:"max=255"`
+	Lines        []BillLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+// ListBillsRequest filters the bills listed.
+type ListBillsRequest struct {
+	VendorID *uuid.UUID
+	Status   models.BillStatus
+}
+
+// --- Payment and Allocation DTOs ---
+
+// PaymentAllocationRequest applies an amount of a payment to one bill. With TakeDiscount, the bill's
+// early-payment discount is taken and Amount must settle the rest of the bill; only a payment's own
+// allocations, made when it is recorded, can take a discount.
+type PaymentAllocationRequest struct {
+	BillID       uuid.UUID    `json:"bill_id"`
+	Amount       money.Amount `json:"amount"`
+	TakeDiscount bool         `json:"take_discount,omitempty"`
+}
+
+// AllocatePaymentRequest applies a payment to bills of its vendor, either to the given bills or,
+// with AutoAllocate, to the open bills in due date order.
+type AllocatePaymentRequest struct {
+	Allocations  []PaymentAllocationRequest `json:"allocations,omitempty"`
+	AutoAllocate bool                       `json:"auto_allocate,omitempty"`
+}
+
+// CreatePaymentRequest records a payment to a vendor and, optionally, applies it to bills.
+type CreatePaymentRequest struct {
+	VendorID      uuid.UUID                  `json:"vendor_id"`
+	PaymentDate   time.Time                  `json:"payment_date"`
+	BankAccountID uuid.UUID                  `json:"bank_account_id"` // The ASSET account the money was paid from
+	Amount        money.Amount               `json:"amount"`
+	Reference     string                     `json:"reference,omitempty" binding:"max=100"`
+	Allocations   []PaymentAllocationRequest `json:"allocations,omitempty"`
+	// AutoAllocate pays the bills due first, taking every discount still available on a bill the
+	// payment settles.
+	AutoAllocate bool `json:"auto_allocate,omitempty"`
+}
+
+// --- Aging DTOs ---
+
+// AgingRequest selects the payables aging report.
+type AgingRequest struct {
+	AsOfDate time.Time  `json:"as_of_date"`
+	VendorID *uuid.UUID `json:"vendor_id,omitempty"` // Optional: one vendor only
+}
+
+// AgingBuckets splits open amounts by how many days past their due date they are. Unapplied
+// payments are not aged; they reduce the total as a negative Unapplied amount.
+type AgingBuckets struct {
+	Current    money.Amount `json:"current"` // Not yet due
+	Days1To30  money.Amount `json:"days_1_30"`
+	Days31To60 money.Amount `json:"days_31_60"`
+	Days61To90 money.Amount `json:"days_61_90"`
+	Over90     money.Amount `json:"over_90"`
+	Unapplied  money.Amount `json:"unapplied"`
+	Total      money.Amount `json:"total"`
+}
+
+// AgingItem is an open document on the aging report: a bill with the amount still to pay, or a
+// payment with the amount not yet applied, as a negative amount.
+type AgingItem struct {
+	DocumentType string       `json:"document_type"` // BILL or PAYMENT
+	DocumentID   uuid.UUID    `json:"document_id"`
+	Number       string       `json:"number"`
+	Reference    string       `json:"reference,omitempty"` // The vendor's invoice number of a bill
+	Date         time.Time    `json:"date"`
+	DueDate      *time.Time   `json:"due_date,omitempty"`     // Bills only
+	DaysOverdue  int          `json:"days_overdue,omitempty"` // Bills only
+	Bucket       string       `json:"bucket"`                 // current, days_1_30, days_31_60, days_61_90, over_90 or unapplied
+	OpenAmount   money.Amount `json:"open_amount"`
+}
+
+// VendorAging is one vendor's line on the aging report.
+type VendorAging struct {
+	VendorID uuid.UUID    `json:"vendor_id"`
+	Code     string       `json:"code"`
+	Name     string       `json:"name"`
+	Buckets  AgingBuckets `json:"buckets"`
+	Items    []AgingItem  `json:"items"`
+}
+
+// AgingReport lists what the company owes each vendor as of a date. For a report of all vendors,
+// the total is compared with the credit balance of the payables control account in the general
+// ledger; a difference means the account was posted to outside the subledger.
+type AgingReport struct {
+	AsOfDate           time.Time     `json:"as_of_date"`
+	Currency           string        `json:"currency"`
+	ControlAccountID   uuid.UUID     `json:"control_account_id"`
+	ControlAccountCode string        `json:"control_account_code"`
+	Vendors            []VendorAging `json:"vendors"`
+	Totals             AgingBuckets  `json:"totals"`
+	LedgerBalance      *money.Amount `json:"ledger_balance,omitempty"` // All vendors only
+	Difference         *money.Amount `json:"difference,omitempty"`     // LedgerBalance less Totals.Total
+	Reconciled         *bool         `json:"reconciled,omitempty"`
+}
+
+// --- Vendor Statement DTOs ---
+
+// StatementRequest selects a vendor statement.
+type StatementRequest struct {
+	VendorID  uuid.UUID `json:"vendor_id"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// StatementLine is one movement on a vendor's account. Amount is positive for bills, which add to
+// what the company owes, and negative for payments and the discounts taken with them.
+type StatementLine struct {
+	Date         time.Time    `json:"date"`
+	DocumentType string       `json:"document_type"` // BILL, PAYMENT or DISCOUNT
+	DocumentID   uuid.UUID    `json:"document_id"`
+	Number       string       `json:"number"`
+	Reference    string       `json:"reference,omitempty"` // The vendor's invoice number, or the payment reference
+	Amount       money.Amount `json:"amount"`
+	Balance      money.Amount `json:"balance"` // Owed to the vendor after this line
+}
+
+// VendorStatement lists the movements on a vendor's account in a period between its opening and
+// closing balances. The closing balance is reconciled to the vendor's postings to the payables
+// control account in the general ledger.
+type VendorStatement struct {
+	VendorID           uuid.UUID       `json:"vendor_id"`
+	Code               string          `json:"code"`
+	Name               string          `json:"name"`
+	StartDate          time.Time       `json:"start_date"`
+	EndDate            time.Time       `json:"end_date"`
+	Currency           string          `json:"currency"`
+	ControlAccountCode string          `json:"control_account_code"`
+	OpeningBalance     money.Amount    `json:"opening_balance"`
+	Lines              []StatementLine `json:"lines"`
+	ClosingBalance     money.Amount    `json:"closing_balance"`
+	// LedgerBalance is the credit balance of the journal entries of the vendor's bills and payments
+	// on the payables control account up to EndDate.
+	LedgerBalance money.Amount `json:"ledger_balance"`
+	Difference    money.Amount `json:"difference"` // LedgerBalance less ClosingBalance
+	Reconciled    bool         `json:"reconciled"`
+}
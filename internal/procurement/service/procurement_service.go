@@ -0,0 +1,1076 @@
+package service
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	accDto "erp-system/internal/accounting/service/dto"
+	"erp-system/internal/procurement/models"
+	"erp-system/internal/procurement/repository"
+	dto "erp-system/internal/procurement/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// vendorCodePattern is what vendor codes may look like once upper-cased.
+var vendorCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9._-]{0,19}$`)
+
+// Aging buckets of the payables aging report.
+const (
+	BucketCurrent    = "current"
+	BucketDays1To30  = "days_1_30"
+	BucketDays31To60 = "days_31_60"
+	BucketDays61To90 = "days_61_90"
+	BucketOver90     = "over_90"
+	BucketUnapplied  = "unapplied"
+)
+
+// Document types on the aging report and vendor statements.
+const (
+	DocumentTypeBill     = "BILL"
+	DocumentTypePayment  = "PAYMENT"
+	DocumentTypeDiscount = "DISCOUNT"
+)
+
+// Ledger is the general ledger the payables subledger posts to. The accounting service satisfies it.
+type Ledger interface {
+	PrepareSubledgerEntry(ctx context.Context, req accDto.CreateJournalEntryRequest) (*accModels.JournalEntry, error)
+	GetJournalEntryByID(ctx context.Context, id uuid.UUID) (*accModels.JournalEntry, error)
+	GetChartOfAccountByID(ctx context.Context, id uuid.UUID) (*accModels.ChartOfAccount, error)
+	GetChartOfAccountByCode(ctx context.Context, code string) (*accModels.ChartOfAccount, error)
+	GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (money.Amount, error)
+	BaseCurrency(ctx context.Context) string
+}
+
+// TaxCalculator calculates the input tax of bill lines. The accounting tax service satisfies it.
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (*accDto.TaxCalculation, error)
+}
+
+// ProcurementService defines the interface for the accounts payable subledger: vendors, their
+// bills, the payments that settle them, and the aging and statements of what is still owed.
+type ProcurementService interface {
+	// Vendors
+	CreateVendor(ctx context.Context, req dto.CreateVendorRequest) (*models.Vendor, error)
+	GetVendor(ctx context.Context, id uuid.UUID) (*models.Vendor, error)
+	ListVendors(ctx context.Context) ([]*models.Vendor, error)
+	UpdateVendor(ctx context.Context, id uuid.UUID, req dto.UpdateVendorRequest) (*models.Vendor, error)
+
+	// Bills
+	CreateBill(ctx context.Context, req dto.CreateBillRequest) (*models.Bill, error)
+	GetBill(ctx context.Context, id uuid.UUID) (*models.Bill, error)
+	ListBills(ctx context.Context, req dto.ListBillsRequest) ([]*models.Bill, error)
+	DeleteBill(ctx context.Context, id uuid.UUID) error
+	PostBill(ctx context.Context, id uuid.UUID) (*models.Bill, error)
+
+	// Payments
+	CreatePayment(ctx context.Context, req dto.CreatePaymentRequest) (*models.Payment, error)
+	GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error)
+	ListPayments(ctx context.Context, vendorID *uuid.UUID) ([]*models.Payment, error)
+	AllocatePayment(ctx context.Context, id uuid.UUID, req dto.AllocatePaymentRequest) ([]*models.Allocation, error)
+	ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error)
+
+	// Reporting
+	GetAgingReport(ctx context.Context, req dto.AgingRequest) (*dto.AgingReport, error)
+	GetVendorStatement(ctx context.Context, req dto.StatementRequest) (*dto.VendorStatement, error)
+}
+
+// procurementService is an implementation of ProcurementService.
+type procurementService struct {
+	vendorRepo  repository.VendorRepository
+	payableRepo repository.PayableRepository
+	ledger      Ledger
+	taxes       TaxCalculator // Optional; nil rejects lines with a tax code
+	// payablesAccountCode is the LIABILITY account code of the payables control account.
+	payablesAccountCode string
+	// discountAccountCode is the account credited with early-payment discounts taken; it is only
+	// needed once a discount is taken.
+	discountAccountCode string
+}
+
+// NewProcurementService creates a new ProcurementService.
+func NewProcurementService(
+	vendorRepo repository.VendorRepository,
+	payableRepo repository.PayableRepository,
+	ledger Ledger,
+	taxes TaxCalculator,
+	payablesAccountCode string,
+	discountAccountCode string,
+) ProcurementService {
+	return &procurementService{
+		vendorRepo:          vendorRepo,
+		payableRepo:         payableRepo,
+		ledger:              ledger,
+		taxes:               taxes,
+		payablesAccountCode: payablesAccountCode,
+		discountAccountCode: discountAccountCode,
+	}
+}
+
+// --- Vendor Methods ---
+
+func (s *procurementService) CreateVendor(ctx context.Context, req dto.CreateVendorRequest) (*models.Vendor, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create vendor %s", req.Code)
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if !vendorCodePattern.MatchString(code) {
+		return nil, errors.NewValidationError("code must be 1-20 letters, digits, '.', '_' or '-'", "code")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	vendor := &models.Vendor{
+		Code:             code,
+		Name:             name,
+		TaxID:            strings.TrimSpace(req.TaxID),
+		Email:            strings.TrimSpace(req.Email),
+		PaymentTermsDays: models.DefaultPaymentTermsDays,
+		IsActive:         true,
+	}
+	if req.PaymentTermsDays != nil {
+		vendor.PaymentTermsDays = *req.PaymentTermsDays
+	}
+	if req.DiscountRate != nil {
+		vendor.DiscountRate = *req.DiscountRate
+	}
+	if req.DiscountDays != nil {
+		vendor.DiscountDays = *req.DiscountDays
+	}
+	if err := validateTerms(vendor); err != nil {
+		return nil, err
+	}
+	if _, err := s.vendorRepo.GetByCode(ctx, code); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("vendor %s already exists", code))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	created, err := s.vendorRepo.Create(ctx, vendor)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Created vendor %s (%s)", created.Code, created.ID)
+	return created, nil
+}
+
+func (s *procurementService) GetVendor(ctx context.Context, id uuid.UUID) (*models.Vendor, error) {
+	return s.vendorRepo.GetByID(ctx, id)
+}
+
+func (s *procurementService) ListVendors(ctx context.Context) ([]*models.Vendor, error) {
+	return s.vendorRepo.List(ctx)
+}
+
+func (s *procurementService) UpdateVendor(ctx context.Context, id uuid.UUID, req dto.UpdateVendorRequest) (*models.Vendor, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update vendor %s", id)
+	vendor, err := s.vendorRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		vendor.Name = name
+	}
+	if req.TaxID != nil {
+		vendor.TaxID = strings.TrimSpace(*req.TaxID)
+	}
+	if req.Email != nil {
+		vendor.Email = strings.TrimSpace(*req.Email)
+	}
+	if req.PaymentTermsDays != nil {
+		vendor.PaymentTermsDays = *req.PaymentTermsDays
+	}
+	if req.DiscountRate != nil {
+		vendor.DiscountRate = *req.DiscountRate
+	}
+	if req.DiscountDays != nil {
+		vendor.DiscountDays = *req.DiscountDays
+	}
+	if req.IsActive != nil {
+		vendor.IsActive = *req.IsActive
+	}
+	if err := validateTerms(vendor); err != nil {
+		return nil, err
+	}
+	return s.vendorRepo.Update(ctx, vendor)
+}
+
+// validateTerms checks a vendor's payment terms and early-payment discount.
+func validateTerms(vendor *models.Vendor) error {
+	if vendor.PaymentTermsDays < 0 {
+		return errors.NewValidationError("payment_terms_days cannot be negative", "payment_terms_days")
+	}
+	if vendor.DiscountRate.Cmp(money.One) >= 0 {
+		return errors.NewValidationError("discount_rate must be less than 1", "discount_rate")
+	}
+	if vendor.DiscountDays < 0 {
+		return errors.NewValidationError("discount_days cannot be negative", "discount_days")
+	}
+	if vendor.DiscountRate.IsPositive() && vendor.DiscountDays > vendor.PaymentTermsDays {
+		return errors.NewValidationError("discount_days cannot be more than payment_terms_days", "discount_days")
+	}
+	return nil
+}
+
+// --- Bill Methods ---
+
+// CreateBill drafts a vendor bill. Its due date and early-payment discount default to the vendor's
+// terms, and the input tax of each line is calculated at the rate of its tax code on the bill date;
+// nothing reaches the general ledger until it is posted.
+func (s *procurementService) CreateBill(ctx context.Context, req dto.CreateBillRequest) (*models.Bill, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create bill %s of vendor %s", req.VendorInvoiceNumber, req.VendorID)
+	vendor, err := s.activeVendor(ctx, req.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	invoiceNumber := strings.TrimSpace(req.VendorInvoiceNumber)
+	if invoiceNumber == "" {
+		return nil, errors.NewValidationError("vendor_invoice_number is required", "vendor_invoice_number")
+	}
+	if req.BillDate.IsZero() {
+		return nil, errors.NewValidationError("bill_date is required", "bill_date")
+	}
+	if len(req.Lines) == 0 {
+		return nil, errors.NewValidationError("a bill needs at least one line", "lines")
+	}
+	billDate := dateOnly(req.BillDate)
+	dueDate := billDate.AddDate(0, 0, vendor.PaymentTermsDays)
+	if req.DueDate != nil {
+		dueDate = dateOnly(*req.DueDate)
+		if dueDate.Before(billDate) {
+			return nil, errors.NewValidationError("due_date cannot be before bill_date", "due_date")
+		}
+	}
+	discountRate, discountDays := vendor.DiscountRate, vendor.DiscountDays
+	if req.DiscountRate != nil {
+		discountRate = *req.DiscountRate
+	}
+	if req.DiscountDays != nil {
+		discountDays = *req.DiscountDays
+	}
+	if discountRate.Cmp(money.One) >= 0 {
+		return nil, errors.NewValidationError("discount_rate must be less than 1", "discount_rate")
+	}
+	if discountDays < 0 {
+		return nil, errors.NewValidationError("discount_days cannot be negative", "discount_days")
+	}
+	if _, err := s.payableRepo.GetBillByVendorInvoiceNumber(ctx, vendor.ID, invoiceNumber); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("invoice %s of vendor %s has already been entered", invoiceNumber, vendor.Code))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	currency := s.ledger.BaseCurrency(ctx)
+	bill := &models.Bill{
+		VendorID:            vendor.ID,
+		Status:              models.BillStatusDraft,
+		VendorInvoiceNumber: invoiceNumber,
+		BillDate:            billDate,
+		DueDate:             dueDate,
+		Description:         strings.TrimSpace(req.Description),
+		Currency:            currency,
+		NetAmount:           money.Zero,
+		TaxAmount:           money.Zero,
+		TotalAmount:         money.Zero,
+		PaidAmount:          money.Zero,
+		CreatedBy:           currentUser(ctx),
+	}
+	if discountRate.IsPositive() {
+		discountDate := billDate.AddDate(0, 0, discountDays)
+		if discountDate.After(dueDate) {
+			return nil, errors.NewValidationError("the discount period cannot end after the due date", "discount_days")
+		}
+		bill.DiscountRate = discountRate
+		bill.DiscountDate = &discountDate
+	}
+	for i, lineReq := range req.Lines {
+		line, err := s.newBillLine(ctx, i+1, lineReq, billDate, currency)
+		if err != nil {
+			return nil, err
+		}
+		bill.Lines = append(bill.Lines, *line)
+		bill.NetAmount = bill.NetAmount.Add(line.Amount)
+		bill.TaxAmount = bill.TaxAmount.Add(line.TaxAmount)
+	}
+	bill.TotalAmount = bill.NetAmount.Add(bill.TaxAmount)
+
+	created, err := s.payableRepo.CreateBill(ctx, bill)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Created draft bill %s of %s for vendor %s", created.ID, created.TotalAmount, vendor.Code)
+	return created, nil
+}
+
+// newBillLine validates a line request and calculates its input tax.
+func (s *procurementService) newBillLine(ctx context.Context, lineNumber int, req dto.BillLineRequest, billDate time.Time, currency string) (*models.BillLine, error) {
+	account, err := s.ledger.GetChartOfAccountByID(ctx, req.AccountID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s does not exist", lineNumber, req.AccountID), "lines.account_id")
+		}
+		return nil, err
+	}
+	if (account.AccountType != accModels.Expense && account.AccountType != accModels.Asset) || !account.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s is not an active %s or %s account", lineNumber, account.AccountCode, accModels.Expense, accModels.Asset), "lines.account_id")
+	}
+	if !req.Amount.IsPositive() {
+		return nil, errors.NewValidationError(fmt.Sprintf("line %d: amount must be positive", lineNumber), "lines.amount")
+	}
+	if err := req.Amount.CheckPrecision(currency); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", lineNumber, err), "lines.amount")
+	}
+	line := &models.BillLine{
+		LineNumber:  lineNumber,
+		Description: strings.TrimSpace(req.Description),
+		AccountID:   account.ID,
+		Amount:      req.Amount,
+		TaxAmount:   money.Zero,
+	}
+	if code := strings.ToUpper(strings.TrimSpace(req.TaxCode)); code != "" {
+		if line.TaxCode, line.TaxAmount, err = s.lineTax(ctx, code, req.Amount, currency, billDate); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", lineNumber, err), "lines.tax_code")
+		}
+	}
+	return line, nil
+}
+
+// lineTax returns the tax the vendor charges on base with an INPUT tax code on date. It is zero
+// for reverse-charge codes, whose tax the company accounts for itself.
+func (s *procurementService) lineTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (string, money.Amount, error) {
+	if s.taxes == nil {
+		return "", money.Zero, fmt.Errorf("tax codes are not enabled")
+	}
+	calc, err := s.taxes.CalculateTax(ctx, code, base, currency, date)
+	if err != nil {
+		if vErr, ok := err.(*errors.ValidationError); ok {
+			return "", money.Zero, fmt.Errorf("%s", vErr.Message)
+		}
+		return "", money.Zero, err
+	}
+	if calc.TaxType != accModels.TaxTypeInput {
+		return "", money.Zero, fmt.Errorf("tax code %s is not an %s tax code", calc.TaxCode, accModels.TaxTypeInput)
+	}
+	if calc.ReverseCharge {
+		return calc.TaxCode, money.Zero, nil
+	}
+	return calc.TaxCode, calc.Tax, nil
+}
+
+func (s *procurementService) GetBill(ctx context.Context, id uuid.UUID) (*models.Bill, error) {
+	return s.payableRepo.GetBill(ctx, id)
+}
+
+func (s *procurementService) ListBills(ctx context.Context, req dto.ListBillsRequest) ([]*models.Bill, error) {
+	filters := make(map[string]interface{})
+	if req.VendorID != nil {
+		filters["vendor_id"] = *req.VendorID
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+	return s.payableRepo.ListBills(ctx, filters)
+}
+
+// DeleteBill deletes a DRAFT bill. Posted bills are part of the general ledger and stay.
+func (s *procurementService) DeleteBill(ctx context.Context, id uuid.UUID) error {
+	logger.InfoLogger.Printf("Service: Attempting to delete bill %s", id)
+	bill, err := s.payableRepo.GetBill(ctx, id)
+	if err != nil {
+		return err
+	}
+	if bill.Status != models.BillStatusDraft {
+		return errors.NewConflictError(fmt.Sprintf("bill %s is %s; only DRAFT bills can be deleted", bill.BillNumber, bill.Status))
+	}
+	return s.payableRepo.DeleteBill(ctx, id)
+}
+
+// PostBill numbers a DRAFT bill and posts it to the general ledger: the total to the credit of the
+// payables control account, and each line to the debit of its expense or asset account with its
+// tax code, so the ledger adds the input tax lines. Both happen in one transaction.
+func (s *procurementService) PostBill(ctx context.Context, id uuid.UUID) (*models.Bill, error) {
+	logger.InfoLogger.Printf("Service: Attempting to post bill %s", id)
+	bill, err := s.payableRepo.GetBill(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bill.Status != models.BillStatusDraft {
+		return nil, errors.NewConflictError(fmt.Sprintf("bill %s is already %s", bill.BillNumber, bill.Status))
+	}
+	vendor, err := s.activeVendor(ctx, bill.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	control, err := s.payablesAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entryReq := accDto.CreateJournalEntryRequest{
+		EntryDate:   bill.BillDate,
+		Description: truncate(fmt.Sprintf("Bill %s from %s %s", bill.VendorInvoiceNumber, vendor.Code, vendor.Name), 255),
+		Lines: []accDto.JournalLineRequest{{
+			AccountID: control.ID,
+			Amount:    bill.TotalAmount,
+			Currency:  bill.Currency,
+			IsDebit:   false,
+		}},
+	}
+	for _, line := range bill.Lines {
+		// The rate of a tax code can be changed after the bill was drafted with it; the bill must
+		// match what the ledger will post.
+		if line.TaxCode != "" {
+			_, tax, err := s.lineTax(ctx, line.TaxCode, line.Amount, bill.Currency, bill.BillDate)
+			if err != nil {
+				return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", line.LineNumber, err), "lines.tax_code")
+			}
+			if !tax.Equal(line.TaxAmount) {
+				return nil, errors.NewConflictError(fmt.Sprintf("line %d: the tax of tax code %s is now %s instead of %s; delete the draft and create it again", line.LineNumber, line.TaxCode, tax, line.TaxAmount))
+			}
+		}
+		entryReq.Lines = append(entryReq.Lines, accDto.JournalLineRequest{
+			AccountID: line.AccountID,
+			Amount:    line.Amount,
+			Currency:  bill.Currency,
+			IsDebit:   true,
+			TaxCode:   line.TaxCode,
+		})
+	}
+	entry, err := s.ledger.PrepareSubledgerEntry(ctx, entryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	posted, err := s.payableRepo.PostBill(ctx, bill, entry)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Posted bill %s with journal entry %s", posted.BillNumber, entry.DocumentNumber)
+	return posted, nil
+}
+
+// --- Payment Methods ---
+
+// CreatePayment records a payment to a vendor, posts it from the bank account to the payables
+// control account, and applies it to the vendor's bills, all in one transaction. Early-payment
+// discounts taken by its allocations are debited to the control account with the payment and
+// credited to the purchase discount account.
+func (s *procurementService) CreatePayment(ctx context.Context, req dto.CreatePaymentRequest) (*models.Payment, error) {
+	logger.InfoLogger.Printf("Service: Attempting to record payment of %s to vendor %s", req.Amount, req.VendorID)
+	vendor, err := s.vendorRepo.GetByID(ctx, req.VendorID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("vendor %s does not exist", req.VendorID), "vendor_id")
+		}
+		return nil, err
+	}
+	if req.PaymentDate.IsZero() {
+		return nil, errors.NewValidationError("payment_date is required", "payment_date")
+	}
+	currency := s.ledger.BaseCurrency(ctx)
+	if !req.Amount.IsPositive() {
+		return nil, errors.NewValidationError("amount must be positive", "amount")
+	}
+	if err := req.Amount.CheckPrecision(currency); err != nil {
+		return nil, errors.NewValidationError(err.Error(), "amount")
+	}
+	control, err := s.payablesAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bank, err := s.ledger.GetChartOfAccountByID(ctx, req.BankAccountID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("account %s does not exist", req.BankAccountID), "bank_account_id")
+		}
+		return nil, err
+	}
+	if bank.AccountType != accModels.Asset || !bank.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("bank account %s must be an active %s account", bank.AccountCode, accModels.Asset), "bank_account_id")
+	}
+
+	paymentDate := dateOnly(req.PaymentDate)
+	allocations, err := s.buildAllocations(ctx, vendor.ID, req.Amount, paymentDate, currency, dto.AllocatePaymentRequest{Allocations: req.Allocations, AutoAllocate: req.AutoAllocate}, false, true)
+	if err != nil {
+		return nil, err
+	}
+	discount := money.Zero
+	for _, allocation := range allocations {
+		discount = discount.Add(allocation.DiscountAmount)
+	}
+	entryReq := accDto.CreateJournalEntryRequest{
+		EntryDate:   paymentDate,
+		Description: truncate(fmt.Sprintf("Payment to %s %s", vendor.Code, vendor.Name), 255),
+		Lines: []accDto.JournalLineRequest{
+			{AccountID: control.ID, Amount: req.Amount.Add(discount), Currency: currency, IsDebit: true},
+			{AccountID: bank.ID, Amount: req.Amount, Currency: currency, IsDebit: false},
+		},
+	}
+	if discount.IsPositive() {
+		discountAccount, err := s.purchaseDiscountAccount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entryReq.Lines = append(entryReq.Lines, accDto.JournalLineRequest{AccountID: discountAccount.ID, Amount: discount, Currency: currency, IsDebit: false})
+	}
+	entry, err := s.ledger.PrepareSubledgerEntry(ctx, entryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &models.Payment{
+		VendorID:        vendor.ID,
+		PaymentDate:     paymentDate,
+		BankAccountID:   bank.ID,
+		Amount:          req.Amount,
+		Currency:        currency,
+		AllocatedAmount: money.Zero,
+		DiscountAmount:  discount,
+		Reference:       strings.TrimSpace(req.Reference),
+		CreatedBy:       currentUser(ctx),
+	}
+	created, err := s.payableRepo.CreatePayment(ctx, payment, entry, allocations)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Recorded payment %s of %s to vendor %s with %s discount taken", created.PaymentNumber, created.Amount, vendor.Code, discount)
+	return created, nil
+}
+
+func (s *procurementService) GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
+	return s.payableRepo.GetPayment(ctx, id)
+}
+
+func (s *procurementService) ListPayments(ctx context.Context, vendorID *uuid.UUID) ([]*models.Payment, error) {
+	return s.payableRepo.ListPayments(ctx, vendorID, time.Time{})
+}
+
+// AllocatePayment applies the unallocated part of a payment to bills of its vendor. Discounts
+// can no longer be taken: the payment's journal entry has already been posted without them.
+func (s *procurementService) AllocatePayment(ctx context.Context, id uuid.UUID, req dto.AllocatePaymentRequest) ([]*models.Allocation, error) {
+	logger.InfoLogger.Printf("Service: Attempting to allocate payment %s", id)
+	payment, err := s.payableRepo.GetPayment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.buildAllocations(ctx, payment.VendorID, payment.UnallocatedAmount(), payment.PaymentDate, payment.Currency, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocations) == 0 {
+		return allocations, nil
+	}
+	for _, allocation := range allocations {
+		allocation.PaymentID = payment.ID
+	}
+	if err := s.payableRepo.Allocate(ctx, allocations); err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+func (s *procurementService) ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error) {
+	return s.payableRepo.ListDocumentAllocations(ctx, documentID)
+}
+
+// buildAllocations turns an allocation request into allocations of up to available to posted bills
+// of the vendor: the given ones, or with AutoAllocate the open ones in due date order. required
+// rejects a request that allocates nothing. With withDiscounts, allocations that settle a bill
+// within its discount period take its early-payment discount: those asked to, or with
+// AutoAllocate every one the payment can afford.
+func (s *procurementService) buildAllocations(ctx context.Context, vendorID uuid.UUID, available money.Amount, paymentDate time.Time, currency string, req dto.AllocatePaymentRequest, required, withDiscounts bool) ([]*models.Allocation, error) {
+	if req.AutoAllocate && len(req.Allocations) > 0 {
+		return nil, errors.NewValidationError("give either allocations or auto_allocate, not both", "allocations")
+	}
+	if required && !req.AutoAllocate && len(req.Allocations) == 0 {
+		return nil, errors.NewValidationError("allocations or auto_allocate is required", "allocations")
+	}
+	createdBy := currentUser(ctx)
+	newAllocation := func(bill *models.Bill, amount, discount money.Amount) *models.Allocation {
+		date := paymentDate
+		if bill.BillDate.After(date) {
+			date = bill.BillDate
+		}
+		return &models.Allocation{
+			VendorID:       vendorID,
+			BillID:         bill.ID,
+			Amount:         amount,
+			DiscountAmount: discount,
+			AllocationDate: date,
+			CreatedBy:      createdBy,
+		}
+	}
+
+	allocations := []*models.Allocation{}
+	remaining := available
+	if req.AutoAllocate {
+		bills, err := s.payableRepo.ListPostedBills(ctx, &vendorID, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		for _, bill := range bills {
+			if !remaining.IsPositive() {
+				break
+			}
+			open := bill.OpenAmount()
+			if !open.IsPositive() {
+				continue
+			}
+			if withDiscounts {
+				if discount, ok := bill.DiscountAvailable(paymentDate); ok && discount.Cmp(open) < 0 {
+					if cash := open.Sub(discount); cash.Cmp(remaining) <= 0 {
+						allocations = append(allocations, newAllocation(bill, cash, discount))
+						remaining = remaining.Sub(cash)
+						continue
+					}
+				}
+			}
+			amount := open
+			if amount.Cmp(remaining) > 0 {
+				amount = remaining
+			}
+			allocations = append(allocations, newAllocation(bill, amount, money.Zero))
+			remaining = remaining.Sub(amount)
+		}
+		return allocations, nil
+	}
+
+	// Open amounts left on the bills this request allocates to, so that one bill given twice is
+	// not paid twice.
+	openByBill := make(map[uuid.UUID]money.Amount)
+	for i, allocReq := range req.Allocations {
+		if !allocReq.Amount.IsPositive() {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: amount must be positive", i+1), "allocations.amount")
+		}
+		if err := allocReq.Amount.CheckPrecision(currency); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: %v", i+1, err), "allocations.amount")
+		}
+		if allocReq.TakeDiscount && !withDiscounts {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: discounts can only be taken when the payment is recorded", i+1), "allocations.take_discount")
+		}
+		bill, err := s.payableRepo.GetBill(ctx, allocReq.BillID)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: bill %s does not exist", i+1, allocReq.BillID), "allocations.bill_id")
+			}
+			return nil, err
+		}
+		if bill.VendorID != vendorID || bill.Status != models.BillStatusPosted {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: %s is not a posted bill of the vendor", i+1, allocReq.BillID), "allocations.bill_id")
+		}
+		open, seen := openByBill[bill.ID]
+		if !seen {
+			open = bill.OpenAmount()
+		}
+		discount := money.Zero
+		if allocReq.TakeDiscount {
+			var ok bool
+			if discount, ok = bill.DiscountAvailable(paymentDate); !ok {
+				return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: bill %s has no early-payment discount on %s", i+1, bill.BillNumber, paymentDate.Format("2006-01-02")), "allocations.take_discount")
+			}
+			if cash := open.Sub(discount); !allocReq.Amount.Equal(cash) {
+				return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: taking the %s discount, %s settles bill %s, not %s", i+1, discount, cash, bill.BillNumber, allocReq.Amount), "allocations.amount")
+			}
+		} else if allocReq.Amount.Cmp(open) > 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: %s is more than the %s open on bill %s", i+1, allocReq.Amount, open, bill.BillNumber), "allocations.amount")
+		}
+		openByBill[bill.ID] = open.Sub(allocReq.Amount).Sub(discount)
+		remaining = remaining.Sub(allocReq.Amount)
+		allocations = append(allocations, newAllocation(bill, allocReq.Amount, discount))
+	}
+	if remaining.IsNegative() {
+		return nil, errors.NewValidationError(fmt.Sprintf("the allocations total more than the %s available", available), "allocations")
+	}
+	return allocations, nil
+}
+
+// --- Reporting Methods ---
+
+// GetAgingReport ages the open bills of each vendor as of a date by the days they are past due,
+// and lists unapplied payments against them. Open amounts are computed from the allocations made
+// by that date, so a report for a past date is the same whenever it is run. Without a vendor
+// filter, the total is reconciled to the payables control account.
+func (s *procurementService) GetAgingReport(ctx context.Context, req dto.AgingRequest) (*dto.AgingReport, error) {
+	if req.AsOfDate.IsZero() {
+		return nil, errors.NewValidationError("as_of_date is required", "as_of_date")
+	}
+	asOf := dateOnly(req.AsOfDate)
+	logger.InfoLogger.Printf("Service: Generating payables aging as of %s", asOf.Format("2006-01-02"))
+
+	control, err := s.payablesAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var vendors []*models.Vendor
+	if req.VendorID != nil {
+		vendor, err := s.vendorRepo.GetByID(ctx, *req.VendorID)
+		if err != nil {
+			return nil, err
+		}
+		vendors = []*models.Vendor{vendor}
+	} else if vendors, err = s.vendorRepo.List(ctx); err != nil {
+		return nil, err
+	}
+	bills, err := s.payableRepo.ListPostedBills(ctx, req.VendorID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.payableRepo.ListPayments(ctx, req.VendorID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.payableRepo.ListAllocations(ctx, req.VendorID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	// What had been settled by the as-of date, per bill, and allocated, per payment.
+	allocated := make(map[uuid.UUID]money.Amount)
+	addAllocated := func(id uuid.UUID, amount money.Amount) {
+		total, ok := allocated[id]
+		if !ok {
+			total = money.Zero
+		}
+		allocated[id] = total.Add(amount)
+	}
+	for _, allocation := range allocations {
+		addAllocated(allocation.BillID, allocation.Settled())
+		addAllocated(allocation.PaymentID, allocation.Amount)
+	}
+	openAmount := func(id uuid.UUID, total money.Amount) money.Amount {
+		if done, ok := allocated[id]; ok {
+			return total.Sub(done)
+		}
+		return total
+	}
+
+	itemsByVendor := make(map[uuid.UUID][]dto.AgingItem)
+	for _, bill := range bills {
+		open := openAmount(bill.ID, bill.TotalAmount)
+		if open.IsZero() {
+			continue
+		}
+		dueDate := bill.DueDate
+		daysOverdue := int(asOf.Sub(dateOnly(dueDate)).Hours() / 24)
+		itemsByVendor[bill.VendorID] = append(itemsByVendor[bill.VendorID], dto.AgingItem{
+			DocumentType: DocumentTypeBill,
+			DocumentID:   bill.ID,
+			Number:       bill.BillNumber,
+			Reference:    bill.VendorInvoiceNumber,
+			Date:         bill.BillDate,
+			DueDate:      &dueDate,
+			DaysOverdue:  daysOverdue,
+			Bucket:       agingBucket(daysOverdue),
+			OpenAmount:   open,
+		})
+	}
+	for _, payment := range payments {
+		open := openAmount(payment.ID, payment.Amount)
+		if open.IsZero() {
+			continue
+		}
+		itemsByVendor[payment.VendorID] = append(itemsByVendor[payment.VendorID], dto.AgingItem{
+			DocumentType: DocumentTypePayment,
+			DocumentID:   payment.ID,
+			Number:       payment.PaymentNumber,
+			Date:         payment.PaymentDate,
+			Bucket:       BucketUnapplied,
+			OpenAmount:   open.Neg(),
+		})
+	}
+
+	report := &dto.AgingReport{
+		AsOfDate:           asOf,
+		Currency:           s.ledger.BaseCurrency(ctx),
+		ControlAccountID:   control.ID,
+		ControlAccountCode: control.AccountCode,
+		Vendors:            []dto.VendorAging{},
+		Totals:             newAgingBuckets(),
+	}
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i].Code < vendors[j].Code })
+	for _, vendor := range vendors {
+		items := itemsByVendor[vendor.ID]
+		if len(items) == 0 {
+			continue
+		}
+		line := dto.VendorAging{VendorID: vendor.ID, Code: vendor.Code, Name: vendor.Name, Buckets: newAgingBuckets(), Items: items}
+		for _, item := range items {
+			addToBucket(&line.Buckets, item.Bucket, item.OpenAmount)
+			addToBucket(&report.Totals, item.Bucket, item.OpenAmount)
+		}
+		report.Vendors = append(report.Vendors, line)
+	}
+
+	if req.VendorID == nil {
+		// GetAccountBalance includes entries up to the given instant, so ask for the end of the day.
+		// It is debit less credit; what is owed to vendors is the credit balance.
+		balance, err := s.ledger.GetAccountBalance(ctx, control.ID, endOfDay(asOf))
+		if err != nil {
+			return nil, err
+		}
+		balance = balance.Neg()
+		difference := balance.Sub(report.Totals.Total)
+		reconciled := difference.IsZero()
+		report.LedgerBalance = &balance
+		report.Difference = &difference
+		report.Reconciled = &reconciled
+		if !reconciled {
+			logger.WarnLogger.Printf("Service: Payables aging as of %s is %s but account %s has %s", asOf.Format("2006-01-02"), report.Totals.Total, control.AccountCode, balance)
+		}
+	}
+	return report, nil
+}
+
+// GetVendorStatement lists a vendor's bills, payments and the discounts taken with them in a
+// period, with a running balance from what was owed at its start. The closing balance is
+// reconciled to the control account lines of the journal entries of the vendor's documents.
+func (s *procurementService) GetVendorStatement(ctx context.Context, req dto.StatementRequest) (*dto.VendorStatement, error) {
+	if req.StartDate.IsZero() || req.EndDate.IsZero() {
+		return nil, errors.NewValidationError("start_date and end_date are required", "start_date")
+	}
+	start, end := dateOnly(req.StartDate), dateOnly(req.EndDate)
+	if end.Before(start) {
+		return nil, errors.NewValidationError("end_date cannot be before start_date", "end_date")
+	}
+	logger.InfoLogger.Printf("Service: Generating statement of vendor %s from %s to %s", req.VendorID, start.Format("2006-01-02"), end.Format("2006-01-02"))
+
+	vendor, err := s.vendorRepo.GetByID(ctx, req.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	control, err := s.payablesAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bills, err := s.payableRepo.ListPostedBills(ctx, &vendor.ID, end)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.payableRepo.ListPayments(ctx, &vendor.ID, end)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &dto.VendorStatement{
+		VendorID:           vendor.ID,
+		Code:               vendor.Code,
+		Name:               vendor.Name,
+		StartDate:          start,
+		EndDate:            end,
+		Currency:           s.ledger.BaseCurrency(ctx),
+		ControlAccountCode: control.AccountCode,
+		OpeningBalance:     money.Zero,
+		Lines:              []dto.StatementLine{},
+		LedgerBalance:      money.Zero,
+	}
+	journalEntryIDs := []uuid.UUID{}
+	for _, bill := range bills {
+		if bill.JournalEntryID != nil {
+			journalEntryIDs = append(journalEntryIDs, *bill.JournalEntryID)
+		}
+		if bill.BillDate.Before(start) {
+			statement.OpeningBalance = statement.OpeningBalance.Add(bill.TotalAmount)
+			continue
+		}
+		statement.Lines = append(statement.Lines, dto.StatementLine{
+			Date: bill.BillDate, DocumentType: DocumentTypeBill, DocumentID: bill.ID,
+			Number: bill.BillNumber, Reference: bill.VendorInvoiceNumber, Amount: bill.TotalAmount,
+		})
+	}
+	for _, payment := range payments {
+		if payment.JournalEntryID != nil {
+			journalEntryIDs = append(journalEntryIDs, *payment.JournalEntryID)
+		}
+		if payment.PaymentDate.Before(start) {
+			statement.OpeningBalance = statement.OpeningBalance.Sub(payment.Amount).Sub(payment.DiscountAmount)
+			continue
+		}
+		statement.Lines = append(statement.Lines, dto.StatementLine{
+			Date: payment.PaymentDate, DocumentType: DocumentTypePayment, DocumentID: payment.ID,
+			Number: payment.PaymentNumber, Reference: payment.Reference, Amount: payment.Amount.Neg(),
+		})
+		if payment.DiscountAmount.IsPositive() {
+			statement.Lines = append(statement.Lines, dto.StatementLine{
+				Date: payment.PaymentDate, DocumentType: DocumentTypeDiscount, DocumentID: payment.ID,
+				Number: payment.PaymentNumber, Reference: payment.Reference, Amount: payment.DiscountAmount.Neg(),
+			})
+		}
+	}
+
+	// Bills before payments on the same day; the order of the lists is kept otherwise.
+	sort.SliceStable(statement.Lines, func(i, j int) bool {
+		a, b := statement.Lines[i], statement.Lines[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.DocumentType == DocumentTypeBill && b.DocumentType != DocumentTypeBill
+	})
+	balance := statement.OpeningBalance
+	for i := range statement.Lines {
+		balance = balance.Add(statement.Lines[i].Amount)
+		statement.Lines[i].Balance = balance
+	}
+	statement.ClosingBalance = balance
+
+	for _, id := range journalEntryIDs {
+		entry, err := s.ledger.GetJournalEntryByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range entry.JournalLines {
+			if line.AccountID != control.ID {
+				continue
+			}
+			if line.IsDebit {
+				statement.LedgerBalance = statement.LedgerBalance.Sub(line.Amount)
+			} else {
+				statement.LedgerBalance = statement.LedgerBalance.Add(line.Amount)
+			}
+		}
+	}
+	statement.Difference = statement.LedgerBalance.Sub(statement.ClosingBalance)
+	statement.Reconciled = statement.Difference.IsZero()
+	if !statement.Reconciled {
+		logger.WarnLogger.Printf("Service: Statement of vendor %s closes at %s but its entries on account %s total %s", vendor.Code, statement.ClosingBalance, control.AccountCode, statement.LedgerBalance)
+	}
+	return statement, nil
+}
+
+// agingBucket returns the bucket of a bill the given number of days past due.
+func agingBucket(daysOverdue int) string {
+	switch {
+	case daysOverdue <= 0:
+		return BucketCurrent
+	case daysOverdue <= 30:
+		return BucketDays1To30
+	case daysOverdue <= 60:
+		return BucketDays31To60
+	case daysOverdue <= 90:
+		return BucketDays61To90
+	default:
+		return BucketOver90
+	}
+}
+
+func newAgingBuckets() dto.AgingBuckets {
+	return dto.AgingBuckets{
+		Current: money.Zero, Days1To30: money.Zero, Days31To60: money.Zero, Days61To90: money.Zero,
+		Over90: money.Zero, Unapplied: money.Zero, Total: money.Zero,
+	}
+}
+
+func addToBucket(buckets *dto.AgingBuckets, bucket string, amount money.Amount) {
+	switch bucket {
+	case BucketCurrent:
+		buckets.Current = buckets.Current.Add(amount)
+	case BucketDays1To30:
+		buckets.Days1To30 = buckets.Days1To30.Add(amount)
+	case BucketDays31To60:
+		buckets.Days31To60 = buckets.Days31To60.Add(amount)
+	case BucketDays61To90:
+		buckets.Days61To90 = buckets.Days61To90.Add(amount)
+	case BucketOver90:
+		buckets.Over90 = buckets.Over90.Add(amount)
+	case BucketUnapplied:
+		buckets.Unapplied = buckets.Unapplied.Add(amount)
+	}
+	buckets.Total = buckets.Total.Add(amount)
+}
+
+// --- Helpers ---
+
+// activeVendor returns the vendor to bill, which must exist and be active.
+func (s *procurementService) activeVendor(ctx context.Context, id uuid.UUID) (*models.Vendor, error) {
+	vendor, err := s.vendorRepo.GetByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("vendor %s does not exist", id), "vendor_id")
+		}
+		return nil, err
+	}
+	if !vendor.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("vendor %s is inactive", vendor.Code), "vendor_id")
+	}
+	return vendor, nil
+}
+
+// payablesAccount returns the payables control account of the active company.
+func (s *procurementService) payablesAccount(ctx context.Context) (*accModels.ChartOfAccount, error) {
+	if s.payablesAccountCode == "" {
+		return nil, errors.NewInternalServerError("the payables subledger is not configured: a payables account code is required", nil)
+	}
+	account, err := s.ledger.GetChartOfAccountByCode(ctx, s.payablesAccountCode)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("payables account %s does not exist", s.payablesAccountCode), "payables_account_code")
+		}
+		return nil, err
+	}
+	if account.AccountType != accModels.Liability || !account.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("payables account %s is not an active %s account", account.AccountCode, accModels.Liability), "payables_account_code")
+	}
+	return account, nil
+}
+
+// purchaseDiscountAccount returns the account early-payment discounts are credited to: a REVENUE
+// account for discounts received, or an EXPENSE account they reduce.
+func (s *procurementService) purchaseDiscountAccount(ctx context.Context) (*accModels.ChartOfAccount, error) {
+	if s.discountAccountCode == "" {
+		return nil, errors.NewValidationError("early-payment discounts cannot be taken: no purchase discount account is configured", "allocations.take_discount")
+	}
+	account, err := s.ledger.GetChartOfAccountByCode(ctx, s.discountAccountCode)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("purchase discount account %s does not exist", s.discountAccountCode), "purchase_discount_account_code")
+		}
+		return nil, err
+	}
+	if (account.AccountType != accModels.Revenue && account.AccountType != accModels.Expense) || !account.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("purchase discount account %s is not an active %s or %s account", account.AccountCode, accModels.Revenue, accModels.Expense), "purchase_discount_account_code")
+	}
+	return account, nil
+}
+
+func currentUser(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return principal.UserID
+	}
+	return ""
+}
+
+// dateOnly truncates t to midnight UTC of its calendar day.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// endOfDay returns the last instant of the day starting at day.
+func endOfDay(day time.Time) time.Time {
+	return day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// truncate cuts s to at most n bytes, the size of the column it is stored in.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*errors.NotFoundError)
+	return ok
+}
@@ -0,0 +1,618 @@
+package service_test
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	accDto "erp-system/internal/accounting/service/dto"
+	"erp-system/internal/procurement/models"
+	procRepoMock "erp-system/internal/procurement/repository/mocks"
+	"erp-system/internal/procurement/service"
+	dto "erp-system/internal/procurement/service/dto"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	payablesCode = "2000"
+	discountCode = "4900"
+)
+
+// stubLedger is a general ledger with a fixed chart of accounts that records the entries the
+// procurement service prepares.
+type stubLedger struct {
+	accounts map[uuid.UUID]*accModels.ChartOfAccount
+	entries  map[uuid.UUID]*accModels.JournalEntry
+	balance  money.Amount
+	prepared []accDto.CreateJournalEntryRequest
+}
+
+func newStubLedger(accounts ...*accModels.ChartOfAccount) *stubLedger {
+	l := &stubLedger{
+		accounts: make(map[uuid.UUID]*accModels.ChartOfAccount),
+		entries:  make(map[uuid.UUID]*accModels.JournalEntry),
+		balance:  money.Zero,
+	}
+	for _, account := range accounts {
+		l.accounts[account.ID] = account
+	}
+	return l
+}
+
+func (l *stubLedger) PrepareSubledgerEntry(ctx context.Context, req accDto.CreateJournalEntryRequest) (*accModels.JournalEntry, error) {
+	l.prepared = append(l.prepared, req)
+	return &accModels.JournalEntry{ID: uuid.New(), EntryDate: req.EntryDate, Status: accModels.StatusPosted, EntryType: accModels.EntryTypeSubledger}, nil
+}
+
+func (l *stubLedger) GetJournalEntryByID(ctx context.Context, id uuid.UUID) (*accModels.JournalEntry, error) {
+	if entry, ok := l.entries[id]; ok {
+		return entry, nil
+	}
+	return nil, app_errors.NewNotFoundError("journal_entry", id.String())
+}
+
+func (l *stubLedger) GetChartOfAccountByID(ctx context.Context, id uuid.UUID) (*accModels.ChartOfAccount, error) {
+	if account, ok := l.accounts[id]; ok {
+		return account, nil
+	}
+	return nil, app_errors.NewNotFoundError("chart_of_account", id.String())
+}
+
+func (l *stubLedger) GetChartOfAccountByCode(ctx context.Context, code string) (*accModels.ChartOfAccount, error) {
+	for _, account := range l.accounts {
+		if account.AccountCode == code {
+			return account, nil
+		}
+	}
+	return nil, app_errors.NewNotFoundError("chart_of_account_code", code)
+}
+
+func (l *stubLedger) GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (money.Amount, error) {
+	return l.balance, nil
+}
+
+func (l *stubLedger) BaseCurrency(ctx context.Context) string { return "USD" }
+
+// addEntry records a posted entry with one line on account, a credit for a positive amount.
+func (l *stubLedger) addEntry(accountID uuid.UUID, amount string) *uuid.UUID {
+	a := money.MustParse(amount)
+	entry := &accModels.JournalEntry{ID: uuid.New(), JournalLines: []accModels.JournalLine{{AccountID: accountID, Amount: a.Abs(), IsDebit: a.IsNegative()}}}
+	l.entries[entry.ID] = entry
+	return &entry.ID
+}
+
+// stubTaxes charges 20% with INPUT code VATIN and has an OUTPUT code VAT20.
+type stubTaxes struct {
+	rate money.Rate
+}
+
+func (t *stubTaxes) CalculateTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (*accDto.TaxCalculation, error) {
+	taxType := accModels.TaxTypeInput
+	switch code {
+	case "VATIN":
+	case "VAT20":
+		taxType = accModels.TaxTypeOutput
+	default:
+		return nil, app_errors.NewValidationError("unknown tax code "+code, "tax_code")
+	}
+	return &accDto.TaxCalculation{TaxCode: code, TaxType: taxType, Rate: t.rate, Currency: currency, TaxableBase: base, Tax: base.Convert(t.rate).Round(currency)}, nil
+}
+
+type procurementFixture struct {
+	vendorRepo  *procRepoMock.VendorRepository
+	payableRepo *procRepoMock.PayableRepository
+	ledger      *stubLedger
+	taxes       *stubTaxes
+	service     service.ProcurementService
+	payables    *accModels.ChartOfAccount
+	bank        *accModels.ChartOfAccount
+	discounts   *accModels.ChartOfAccount
+	revenue     *accModels.ChartOfAccount
+	expense     *accModels.ChartOfAccount
+	vendor      *models.Vendor
+}
+
+func newProcurementFixture(t *testing.T) *procurementFixture {
+	f := &procurementFixture{
+		vendorRepo:  procRepoMock.NewVendorRepositoryMock(t),
+		payableRepo: procRepoMock.NewPayableRepositoryMock(t),
+		taxes:       &stubTaxes{rate: money.MustParseRate("0.2")},
+		payables:    &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: payablesCode, AccountType: accModels.Liability, IsActive: true},
+		bank:        &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: "1010", AccountType: accModels.Asset, IsActive: true},
+		discounts:   &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: discountCode, AccountType: accModels.Revenue, IsActive: true},
+		revenue:     &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: "4000", AccountType: accModels.Revenue, IsActive: true},
+		expense:     &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: "5000", AccountType: accModels.Expense, IsActive: true},
+		vendor: &models.Vendor{
+			ID: uuid.New(), Code: "STEELCO", Name: "Steel Co", PaymentTermsDays: 30,
+			DiscountRate: money.MustParseRate("0.02"), DiscountDays: 10, IsActive: true,
+		},
+	}
+	f.ledger = newStubLedger(f.payables, f.bank, f.discounts, f.revenue, f.expense)
+	f.service = service.NewProcurementService(f.vendorRepo, f.payableRepo, f.ledger, f.taxes, payablesCode, discountCode)
+	return f
+}
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// postedBill returns a posted bill; discountDate is empty for a bill without an early-payment discount.
+func postedBill(vendorID uuid.UUID, number, billDate, dueDate, discountDate, total, paid string) *models.Bill {
+	bill := &models.Bill{
+		ID: uuid.New(), VendorID: vendorID, Status: models.BillStatusPosted, BillNumber: number,
+		VendorInvoiceNumber: "V-" + number, BillDate: date(billDate), DueDate: date(dueDate), Currency: "USD",
+		TotalAmount: money.MustParse(total), PaidAmount: money.MustParse(paid),
+	}
+	if discountDate != "" {
+		d := date(discountDate)
+		bill.DiscountRate = money.MustParseRate("0.02")
+		bill.DiscountDate = &d
+	}
+	return bill
+}
+
+func TestProcurementService_CreateVendor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Code Upper-Cased And Default Terms", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		f.vendorRepo.On("GetByCode", ctx, "STEELCO").Return(nil, app_errors.NewNotFoundError("vendor_code", "STEELCO")).Once()
+		f.vendorRepo.On("Create", ctx, mock.AnythingOfType("*models.Vendor")).
+			Return(func(_ context.Context, v *models.Vendor) *models.Vendor { return v }, nil).Once()
+
+		vendor, err := f.service.CreateVendor(ctx, dto.CreateVendorRequest{Code: " steelco ", Name: "Steel Co"})
+		require.NoError(t, err)
+		assert.Equal(t, "STEELCO", vendor.Code)
+		assert.Equal(t, models.DefaultPaymentTermsDays, vendor.PaymentTermsDays)
+		assert.False(t, vendor.DiscountRate.IsPositive())
+		assert.True(t, vendor.IsActive)
+	})
+
+	t.Run("Error - Code Exists", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		f.vendorRepo.On("GetByCode", ctx, "STEELCO").Return(f.vendor, nil).Once()
+
+		_, err := f.service.CreateVendor(ctx, dto.CreateVendorRequest{Code: "STEELCO", Name: "Steel Co"})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Error - Discount Period Longer Than Terms", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		rate, days := money.MustParseRate("0.02"), 45
+		_, err := f.service.CreateVendor(ctx, dto.CreateVendorRequest{Code: "STEELCO", Name: "Steel Co", DiscountRate: &rate, DiscountDays: &days})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - Discount Rate Of 100%", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		rate := money.One
+		_, err := f.service.CreateVendor(ctx, dto.CreateVendorRequest{Code: "STEELCO", Name: "Steel Co", DiscountRate: &rate})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestProcurementService_CreateBill(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Input Tax, Due Date And Discount From Vendor Terms", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBillByVendorInvoiceNumber", ctx, f.vendor.ID, "SC-881").
+			Return(nil, app_errors.NewNotFoundError("vendor_invoice_number", "SC-881")).Once()
+		f.payableRepo.On("CreateBill", ctx, mock.AnythingOfType("*models.Bill")).
+			Return(func(_ context.Context, b *models.Bill) *models.Bill { return b }, nil).Once()
+
+		bill, err := f.service.CreateBill(ctx, dto.CreateBillRequest{
+			VendorID:            f.vendor.ID,
+			VendorInvoiceNumber: " SC-881 ",
+			BillDate:            time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC),
+			Lines: []dto.BillLineRequest{
+				{AccountID: f.expense.ID, Amount: money.MustParse("100.00"), TaxCode: "vatin"},
+				{AccountID: f.bank.ID, Amount: money.MustParse("50.00")},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.BillStatusDraft, bill.Status)
+		assert.Equal(t, "SC-881", bill.VendorInvoiceNumber)
+		assert.Equal(t, date("2026-03-10"), bill.BillDate)
+		assert.Equal(t, date("2026-04-09"), bill.DueDate)
+		require.NotNil(t, bill.DiscountDate)
+		assert.Equal(t, date("2026-03-20"), *bill.DiscountDate)
+		assert.True(t, bill.DiscountRate.Equal(money.MustParseRate("0.02")))
+		assert.Equal(t, "150.00", bill.NetAmount.String())
+		assert.Equal(t, "20.00", bill.TaxAmount.String())
+		assert.Equal(t, "170.00", bill.TotalAmount.String())
+		require.Len(t, bill.Lines, 2)
+		assert.Equal(t, "VATIN", bill.Lines[0].TaxCode)
+	})
+
+	t.Run("Error - Vendor Invoice Already Entered", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBillByVendorInvoiceNumber", ctx, f.vendor.ID, "SC-881").Return(&models.Bill{ID: uuid.New()}, nil).Once()
+
+		_, err := f.service.CreateBill(ctx, dto.CreateBillRequest{
+			VendorID: f.vendor.ID, VendorInvoiceNumber: "SC-881", BillDate: date("2026-03-10"),
+			Lines: []dto.BillLineRequest{{AccountID: f.expense.ID, Amount: money.MustParse("100.00")}},
+		})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Error - Output Tax Code", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBillByVendorInvoiceNumber", ctx, f.vendor.ID, "SC-881").
+			Return(nil, app_errors.NewNotFoundError("vendor_invoice_number", "SC-881")).Once()
+
+		_, err := f.service.CreateBill(ctx, dto.CreateBillRequest{
+			VendorID: f.vendor.ID, VendorInvoiceNumber: "SC-881", BillDate: date("2026-03-10"),
+			Lines: []dto.BillLineRequest{{AccountID: f.expense.ID, Amount: money.MustParse("100.00"), TaxCode: "VAT20"}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - Revenue Account", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBillByVendorInvoiceNumber", ctx, f.vendor.ID, "SC-881").
+			Return(nil, app_errors.NewNotFoundError("vendor_invoice_number", "SC-881")).Once()
+
+		_, err := f.service.CreateBill(ctx, dto.CreateBillRequest{
+			VendorID: f.vendor.ID, VendorInvoiceNumber: "SC-881", BillDate: date("2026-03-10"),
+			Lines: []dto.BillLineRequest{{AccountID: f.revenue.ID, Amount: money.MustParse("100.00")}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestProcurementService_PostBill(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Credits Payables And Debits Expense With Tax Code", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		bill := &models.Bill{
+			ID: uuid.New(), VendorID: f.vendor.ID, Status: models.BillStatusDraft, VendorInvoiceNumber: "SC-881",
+			BillDate: date("2026-03-10"), DueDate: date("2026-04-09"), Currency: "USD",
+			NetAmount: money.MustParse("100.00"), TaxAmount: money.MustParse("20.00"), TotalAmount: money.MustParse("120.00"),
+			Lines: []models.BillLine{{LineNumber: 1, AccountID: f.expense.ID, Amount: money.MustParse("100.00"), TaxCode: "VATIN", TaxAmount: money.MustParse("20.00")}},
+		}
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("PostBill", ctx, bill, mock.AnythingOfType("*models.JournalEntry")).
+			Return(func(_ context.Context, b *models.Bill, _ *accModels.JournalEntry) *models.Bill {
+				b.Status = models.BillStatusPosted
+				return b
+			}, nil).Once()
+
+		posted, err := f.service.PostBill(ctx, bill.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.BillStatusPosted, posted.Status)
+		require.Len(t, f.ledger.prepared, 1)
+		entry := f.ledger.prepared[0]
+		require.Len(t, entry.Lines, 2)
+		assert.Equal(t, f.payables.ID, entry.Lines[0].AccountID)
+		assert.False(t, entry.Lines[0].IsDebit)
+		assert.Equal(t, "120.00", entry.Lines[0].Amount.String())
+		assert.Equal(t, f.expense.ID, entry.Lines[1].AccountID)
+		assert.True(t, entry.Lines[1].IsDebit)
+		assert.Equal(t, "100.00", entry.Lines[1].Amount.String())
+		assert.Equal(t, "VATIN", entry.Lines[1].TaxCode)
+	})
+
+	t.Run("Error - Already Posted", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-10", "2026-04-09", "", "120.00", "0")
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+
+		_, err := f.service.PostBill(ctx, bill.ID)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Empty(t, f.ledger.prepared)
+	})
+}
+
+func TestProcurementService_CreatePayment(t *testing.T) {
+	ctx := context.Background()
+
+	capture := func(f *procurementFixture, allocations *[]*models.Allocation) {
+		f.payableRepo.On("CreatePayment", ctx, mock.AnythingOfType("*models.Payment"), mock.AnythingOfType("*models.JournalEntry"), mock.Anything).
+			Run(func(args mock.Arguments) { *allocations = args.Get(3).([]*models.Allocation) }).
+			Return(func(_ context.Context, p *models.Payment, _ *accModels.JournalEntry, _ []*models.Allocation) *models.Payment {
+				return p
+			}, nil).Once()
+	}
+
+	t.Run("Success - Discount Taken Settles Bill And Is Credited To Discount Account", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-10", "2026-04-09", "2026-03-20", "100.00", "0")
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+		var allocations []*models.Allocation
+		capture(f, &allocations)
+
+		payment, err := f.service.CreatePayment(ctx, dto.CreatePaymentRequest{
+			VendorID: f.vendor.ID, PaymentDate: date("2026-03-18"), BankAccountID: f.bank.ID,
+			Amount:      money.MustParse("98.00"),
+			Allocations: []dto.PaymentAllocationRequest{{BillID: bill.ID, Amount: money.MustParse("98.00"), TakeDiscount: true}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "2.00", payment.DiscountAmount.String())
+		require.Len(t, allocations, 1)
+		assert.Equal(t, "98.00", allocations[0].Amount.String())
+		assert.Equal(t, "2.00", allocations[0].DiscountAmount.String())
+		assert.Equal(t, "100.00", allocations[0].Settled().String())
+
+		entry := f.ledger.prepared[0]
+		require.Len(t, entry.Lines, 3)
+		assert.Equal(t, f.payables.ID, entry.Lines[0].AccountID)
+		assert.True(t, entry.Lines[0].IsDebit)
+		assert.Equal(t, "100.00", entry.Lines[0].Amount.String())
+		assert.Equal(t, f.bank.ID, entry.Lines[1].AccountID)
+		assert.False(t, entry.Lines[1].IsDebit)
+		assert.Equal(t, "98.00", entry.Lines[1].Amount.String())
+		assert.Equal(t, f.discounts.ID, entry.Lines[2].AccountID)
+		assert.False(t, entry.Lines[2].IsDebit)
+		assert.Equal(t, "2.00", entry.Lines[2].Amount.String())
+	})
+
+	t.Run("Success - Auto Allocation Takes Affordable Discounts In Due Date Order", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		// Discount period over by the payment date.
+		older := postedBill(f.vendor.ID, "BILL-1", "2026-02-01", "2026-03-03", "2026-02-11", "100.00", "40.00")
+		withDiscount := postedBill(f.vendor.ID, "BILL-2", "2026-03-10", "2026-04-09", "2026-03-20", "200.00", "0")
+		// Its discount is still open, but the payment cannot settle it.
+		partial := postedBill(f.vendor.ID, "BILL-3", "2026-03-12", "2026-04-11", "2026-03-22", "300.00", "0")
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("ListPostedBills", ctx, &f.vendor.ID, time.Time{}).Return([]*models.Bill{older, withDiscount, partial}, nil).Once()
+		var allocations []*models.Allocation
+		capture(f, &allocations)
+
+		payment, err := f.service.CreatePayment(ctx, dto.CreatePaymentRequest{
+			VendorID: f.vendor.ID, PaymentDate: date("2026-03-15"), BankAccountID: f.bank.ID,
+			Amount: money.MustParse("300.00"), AutoAllocate: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, allocations, 3)
+		assert.Equal(t, older.ID, allocations[0].BillID)
+		assert.Equal(t, "60.00", allocations[0].Amount.String())
+		assert.True(t, allocations[0].DiscountAmount.IsZero())
+		assert.Equal(t, withDiscount.ID, allocations[1].BillID)
+		assert.Equal(t, "196.00", allocations[1].Amount.String())
+		assert.Equal(t, "4.00", allocations[1].DiscountAmount.String())
+		assert.Equal(t, partial.ID, allocations[2].BillID)
+		assert.Equal(t, "44.00", allocations[2].Amount.String())
+		assert.True(t, allocations[2].DiscountAmount.IsZero())
+		assert.Equal(t, "4.00", payment.DiscountAmount.String())
+		assert.Equal(t, "304.00", f.ledger.prepared[0].Lines[0].Amount.String())
+	})
+
+	t.Run("Error - Discount Period Over", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-10", "2026-04-09", "2026-03-20", "100.00", "0")
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+
+		_, err := f.service.CreatePayment(ctx, dto.CreatePaymentRequest{
+			VendorID: f.vendor.ID, PaymentDate: date("2026-03-21"), BankAccountID: f.bank.ID,
+			Amount:      money.MustParse("98.00"),
+			Allocations: []dto.PaymentAllocationRequest{{BillID: bill.ID, Amount: money.MustParse("98.00"), TakeDiscount: true}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Empty(t, f.ledger.prepared)
+	})
+
+	t.Run("Error - Discount Without Settling Bill", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-10", "2026-04-09", "2026-03-20", "100.00", "0")
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+
+		_, err := f.service.CreatePayment(ctx, dto.CreatePaymentRequest{
+			VendorID: f.vendor.ID, PaymentDate: date("2026-03-18"), BankAccountID: f.bank.ID,
+			Amount:      money.MustParse("50.00"),
+			Allocations: []dto.PaymentAllocationRequest{{BillID: bill.ID, Amount: money.MustParse("50.00"), TakeDiscount: true}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - No Purchase Discount Account", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		f.service = service.NewProcurementService(f.vendorRepo, f.payableRepo, f.ledger, f.taxes, payablesCode, "")
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-10", "2026-04-09", "2026-03-20", "100.00", "0")
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+
+		_, err := f.service.CreatePayment(ctx, dto.CreatePaymentRequest{
+			VendorID: f.vendor.ID, PaymentDate: date("2026-03-18"), BankAccountID: f.bank.ID,
+			Amount:      money.MustParse("98.00"),
+			Allocations: []dto.PaymentAllocationRequest{{BillID: bill.ID, Amount: money.MustParse("98.00"), TakeDiscount: true}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Empty(t, f.ledger.prepared)
+	})
+}
+
+func TestProcurementService_AllocatePayment(t *testing.T) {
+	ctx := context.Background()
+
+	payment := func(f *procurementFixture) *models.Payment {
+		return &models.Payment{
+			ID: uuid.New(), VendorID: f.vendor.ID, PaymentNumber: "PAY-1", PaymentDate: date("2026-03-18"), Currency: "USD",
+			Amount: money.MustParse("100.00"), AllocatedAmount: money.MustParse("30.00"),
+		}
+	}
+
+	t.Run("Success - Partial Payment Of Bill", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		p := payment(f)
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-25", "2026-04-24", "", "100.00", "0")
+		f.payableRepo.On("GetPayment", ctx, p.ID).Return(p, nil).Once()
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+		f.payableRepo.On("Allocate", ctx, mock.Anything).Return(nil).Once()
+
+		allocations, err := f.service.AllocatePayment(ctx, p.ID, dto.AllocatePaymentRequest{
+			Allocations: []dto.PaymentAllocationRequest{{BillID: bill.ID, Amount: money.MustParse("70.00")}},
+		})
+		require.NoError(t, err)
+		require.Len(t, allocations, 1)
+		assert.Equal(t, p.ID, allocations[0].PaymentID)
+		assert.True(t, allocations[0].DiscountAmount.IsZero())
+		assert.Equal(t, date("2026-03-25"), allocations[0].AllocationDate)
+	})
+
+	t.Run("Error - Discount After Payment Was Recorded", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		p := payment(f)
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-10", "2026-04-09", "2026-03-20", "50.00", "0")
+		f.payableRepo.On("GetPayment", ctx, p.ID).Return(p, nil).Once()
+
+		_, err := f.service.AllocatePayment(ctx, p.ID, dto.AllocatePaymentRequest{
+			Allocations: []dto.PaymentAllocationRequest{{BillID: bill.ID, Amount: money.MustParse("49.00"), TakeDiscount: true}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - More Than Unallocated", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		p := payment(f)
+		bill := postedBill(f.vendor.ID, "BILL-1", "2026-03-10", "2026-04-09", "", "100.00", "0")
+		f.payableRepo.On("GetPayment", ctx, p.ID).Return(p, nil).Once()
+		f.payableRepo.On("GetBill", ctx, bill.ID).Return(bill, nil).Once()
+
+		_, err := f.service.AllocatePayment(ctx, p.ID, dto.AllocatePaymentRequest{
+			Allocations: []dto.PaymentAllocationRequest{{BillID: bill.ID, Amount: money.MustParse("80.00")}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestProcurementService_GetAgingReport(t *testing.T) {
+	ctx := context.Background()
+	asOf := date("2026-06-30")
+
+	setup := func(f *procurementFixture) {
+		current := postedBill(f.vendor.ID, "BILL-5", "2026-06-15", "2026-07-15", "", "100.00", "0")
+		days20 := postedBill(f.vendor.ID, "BILL-4", "2026-05-11", "2026-06-10", "", "200.00", "0")
+		days45 := postedBill(f.vendor.ID, "BILL-3", "2026-04-16", "2026-05-16", "", "300.00", "0")
+		// Paid in full after the as-of date, so still open on the report.
+		days75 := postedBill(f.vendor.ID, "BILL-2", "2026-03-17", "2026-04-16", "", "400.00", "400.00")
+		// Settled by 490 paid and 10 discount.
+		over90 := postedBill(f.vendor.ID, "BILL-1", "2026-01-01", "2026-01-31", "2026-01-11", "500.00", "500.00")
+		paymentID := uuid.New()
+		payment := &models.Payment{ID: paymentID, VendorID: f.vendor.ID, PaymentNumber: "PAY-1", PaymentDate: date("2026-01-10"), Amount: money.MustParse("590.00"), AllocatedAmount: money.MustParse("490.00"), DiscountAmount: money.MustParse("10.00")}
+
+		f.vendorRepo.On("List", ctx).Return([]*models.Vendor{f.vendor}, nil).Once()
+		f.payableRepo.On("ListPostedBills", ctx, (*uuid.UUID)(nil), asOf).
+			Return([]*models.Bill{over90, days75, days45, days20, current}, nil).Once()
+		f.payableRepo.On("ListPayments", ctx, (*uuid.UUID)(nil), asOf).Return([]*models.Payment{payment}, nil).Once()
+		f.payableRepo.On("ListAllocations", ctx, (*uuid.UUID)(nil), asOf).Return([]*models.Allocation{
+			{VendorID: f.vendor.ID, BillID: over90.ID, PaymentID: paymentID, Amount: money.MustParse("490.00"), DiscountAmount: money.MustParse("10.00"), AllocationDate: date("2026-01-10")},
+		}, nil).Once()
+	}
+
+	t.Run("Success - Buckets And Reconciliation To Credit Balance", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		setup(f)
+		// 1,000 open on bills less 100 paid in advance, as a credit balance.
+		f.ledger.balance = money.MustParse("-900.00")
+
+		report, err := f.service.GetAgingReport(ctx, dto.AgingRequest{AsOfDate: asOf})
+		require.NoError(t, err)
+		require.Len(t, report.Vendors, 1)
+		totals := report.Totals
+		assert.Equal(t, "100.00", totals.Current.String())
+		assert.Equal(t, "200.00", totals.Days1To30.String())
+		assert.Equal(t, "300.00", totals.Days31To60.String())
+		assert.Equal(t, "400.00", totals.Days61To90.String())
+		assert.True(t, totals.Over90.IsZero())
+		assert.Equal(t, "-100.00", totals.Unapplied.String())
+		assert.Equal(t, "900.00", totals.Total.String())
+		assert.Len(t, report.Vendors[0].Items, 5)
+		assert.Equal(t, "V-BILL-5", report.Vendors[0].Items[3].Reference)
+		assert.Equal(t, payablesCode, report.ControlAccountCode)
+		assert.Equal(t, "900.00", report.LedgerBalance.String())
+		require.NotNil(t, report.Reconciled)
+		assert.True(t, *report.Reconciled)
+	})
+
+	t.Run("Success - Difference When Ledger Posted Outside Subledger", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		setup(f)
+		f.ledger.balance = money.MustParse("-950.00")
+
+		report, err := f.service.GetAgingReport(ctx, dto.AgingRequest{AsOfDate: asOf})
+		require.NoError(t, err)
+		assert.False(t, *report.Reconciled)
+		assert.Equal(t, "50.00", report.Difference.String())
+	})
+}
+
+func TestProcurementService_GetVendorStatement(t *testing.T) {
+	ctx := context.Background()
+	start, end := date("2026-03-01"), date("2026-03-31")
+
+	setup := func(f *procurementFixture) {
+		before := postedBill(f.vendor.ID, "BILL-1", "2026-02-10", "2026-03-12", "", "300.00", "300.00")
+		before.JournalEntryID = f.ledger.addEntry(f.payables.ID, "300.00")
+		inPeriod := postedBill(f.vendor.ID, "BILL-2", "2026-03-10", "2026-04-09", "2026-03-20", "200.00", "200.00")
+		inPeriod.JournalEntryID = f.ledger.addEntry(f.payables.ID, "200.00")
+		earlier := &models.Payment{ID: uuid.New(), VendorID: f.vendor.ID, PaymentNumber: "PAY-1", PaymentDate: date("2026-02-20"), Amount: money.MustParse("100.00"), DiscountAmount: money.Zero}
+		earlier.JournalEntryID = f.ledger.addEntry(f.payables.ID, "-100.00")
+		withDiscount := &models.Payment{ID: uuid.New(), VendorID: f.vendor.ID, PaymentNumber: "PAY-2", PaymentDate: date("2026-03-15"), Amount: money.MustParse("396.00"), DiscountAmount: money.MustParse("4.00"), Reference: "TRF-7"}
+		withDiscount.JournalEntryID = f.ledger.addEntry(f.payables.ID, "-400.00")
+
+		f.vendorRepo.On("GetByID", ctx, f.vendor.ID).Return(f.vendor, nil).Once()
+		f.payableRepo.On("ListPostedBills", ctx, &f.vendor.ID, end).Return([]*models.Bill{before, inPeriod}, nil).Once()
+		f.payableRepo.On("ListPayments", ctx, &f.vendor.ID, end).Return([]*models.Payment{earlier, withDiscount}, nil).Once()
+	}
+
+	t.Run("Success - Running Balance Reconciled To Ledger", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		setup(f)
+
+		statement, err := f.service.GetVendorStatement(ctx, dto.StatementRequest{VendorID: f.vendor.ID, StartDate: start, EndDate: end})
+		require.NoError(t, err)
+		assert.Equal(t, "200.00", statement.OpeningBalance.String())
+		require.Len(t, statement.Lines, 3)
+		assert.Equal(t, service.DocumentTypeBill, statement.Lines[0].DocumentType)
+		assert.Equal(t, "400.00", statement.Lines[0].Balance.String())
+		assert.Equal(t, service.DocumentTypePayment, statement.Lines[1].DocumentType)
+		assert.Equal(t, "-396.00", statement.Lines[1].Amount.String())
+		assert.Equal(t, "TRF-7", statement.Lines[1].Reference)
+		assert.Equal(t, service.DocumentTypeDiscount, statement.Lines[2].DocumentType)
+		assert.Equal(t, "-4.00", statement.Lines[2].Amount.String())
+		assert.True(t, statement.ClosingBalance.IsZero())
+		assert.True(t, statement.LedgerBalance.IsZero())
+		assert.True(t, statement.Reconciled)
+	})
+
+	t.Run("Success - Difference When Entry Does Not Match Document", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		setup(f)
+		for _, entry := range f.ledger.entries {
+			if entry.JournalLines[0].Amount.Equal(money.MustParse("200.00")) {
+				entry.JournalLines[0].Amount = money.MustParse("210.00")
+			}
+		}
+
+		statement, err := f.service.GetVendorStatement(ctx, dto.StatementRequest{VendorID: f.vendor.ID, StartDate: start, EndDate: end})
+		require.NoError(t, err)
+		assert.False(t, statement.Reconciled)
+		assert.Equal(t, "10.00", statement.Difference.String())
+	})
+
+	t.Run("Error - End Before Start", func(t *testing.T) {
+		f := newProcurementFixture(t)
+		_, err := f.service.GetVendorStatement(ctx, dto.StatementRequest{VendorID: f.vendor.ID, StartDate: end, EndDate: start})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultPaymentTermsDays is the time customers are given to pay unless they have their own terms.
+const DefaultPaymentTermsDays = 30
+
+// Customer is a party the company sells to on credit. Its invoices, credit notes and receipts make
+// up its account in the receivables subledger.
+type Customer struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_customers_company_code" json:"company_id"`
+	Code      string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_customers_company_code" json:"code"` // e.g. "ACME"
+	Name      string    `gorm:"type:varchar(100);not null" json:"name"`
+	TaxID     string    `gorm:"type:varchar(50)" json:"tax_id,omitempty"` // VAT or other tax registration number
+	Email     string    `gorm:"type:varchar(100)" json:"email,omitempty"`
+	// PaymentTermsDays is the number of days from an invoice's date to its due date; 0 means due on
+	// receipt. It has no GORM default, so that 0 is saved as given.
+	PaymentTermsDays int       `gorm:"not null" json:"payment_terms_days"`
+	IsActive         bool      `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Customer model.
+func (Customer) TableName() string {
+	return "customers"
+}
+
+// BeforeCreate will set a UUID for the new customer.
+func (c *Customer) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InvoiceType distinguishes invoices from the credit notes that reduce them.
+type InvoiceType string
+
+const (
+	InvoiceTypeInvoice InvoiceType = "INVOICE"
+	// InvoiceTypeCreditNote reduces what a customer owes. It is applied to the customer's invoices
+	// the way a receipt is.
+	InvoiceTypeCreditNote InvoiceType = "CREDIT_NOTE"
+)
+
+// InvoiceStatus represents the status of an invoice or credit note.
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft InvoiceStatus = "DRAFT" // Can still be deleted; not yet in the general ledger
+	// InvoiceStatusPosted documents have a number and a journal entry. They are never changed again;
+	// a posted invoice is corrected with a credit note.
+	InvoiceStatusPosted InvoiceStatus = "POSTED"
+)
+
+// Invoice is a customer invoice or credit note. Posting it debits (for a credit note, credits) the
+// receivables control account with the total and credits the revenue accounts of its lines, with
+// the tax of their tax codes. Amounts are in the company's functional currency.
+type Invoice struct {
+	ID          uuid.UUID     `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID   uuid.UUID     `gorm:"type:uuid;not null;index;index:idx_sales_invoices_number,unique,where:invoice_number <> ''" json:"company_id"`
+	CustomerID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"customer_id"`
+	InvoiceType InvoiceType   `gorm:"type:varchar(20);not null" json:"invoice_type"`
+	Status      InvoiceStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	// InvoiceNumber is the gap-free number, such as INV-2026-000042, given to the document when it is posted.
+	InvoiceNumber string       `gorm:"type:varchar(50);index:idx_sales_invoices_number,unique,where:invoice_number <> ''" json:"invoice_number,omitempty"`
+	InvoiceDate   time.Time    `gorm:"type:date;not null" json:"invoice_date"`
+	DueDate       time.Time    `gorm:"type:date;not null" json:"due_date"`           // The invoice date for credit notes
+	Reference     string       `gorm:"type:varchar(100)" json:"reference,omitempty"` // e.g. the customer's order number
+	Description   string       `gorm:"type:varchar(255)" json:"description,omitempty"`
+	Currency      string       `gorm:"type:varchar(3);not null" json:"currency"`
+	NetAmount     money.Amount `gorm:"type:numeric(18,4);not null" json:"net_amount"`
+	TaxAmount     money.Amount `gorm:"type:numeric(18,4);not null" json:"tax_amount"`
+	TotalAmount   money.Amount `gorm:"type:numeric(18,4);not null" json:"total_amount"`
+	// AllocatedAmount is what receipts and credit notes have paid of an invoice, or what a credit
+	// note has been applied to.
+	AllocatedAmount money.Amount  `gorm:"type:numeric(18,4);not null;default:0" json:"allocated_amount"`
+	JournalEntryID  *uuid.UUID    `gorm:"type:uuid;index" json:"journal_entry_id,omitempty"`
+	PostedAt        *time.Time    `json:"posted_at,omitempty"`
+	CreatedBy       string        `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	Lines           []InvoiceLine `gorm:"foreignKey:InvoiceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"lines"`
+	CreatedAt       time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Invoice model.
+func (Invoice) TableName() string {
+	return "sales_invoices"
+}
+
+// BeforeCreate will set a UUID for the new invoice.
+func (i *Invoice) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return
+}
+
+// OpenAmount is what is left to pay of an invoice, or to apply of a credit note.
+func (i *Invoice) OpenAmount() money.Amount {
+	return i.TotalAmount.Sub(i.AllocatedAmount)
+}
+
+// InvoiceLine is a revenue line of an invoice or credit note. Amount is net of tax; TaxAmount is
+// the tax its TaxCode charges on it on the invoice date.
+type InvoiceLine struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"-"`
+	InvoiceID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"invoice_id"`
+	LineNumber  int          `gorm:"not null" json:"line_number"`
+	Description string       `gorm:"type:varchar(255)" json:"description,omitempty"`
+	AccountID   uuid.UUID    `gorm:"type:uuid;not null" json:"account_id"` // The revenue account credited
+	Amount      money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"`
+	TaxCode     string       `gorm:"type:varchar(20)" json:"tax_code,omitempty"`
+	TaxAmount   money.Amount `gorm:"type:numeric(18,4);not null" json:"tax_amount"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for InvoiceLine model.
+func (InvoiceLine) TableName() string {
+	return "sales_invoice_lines"
+}
+
+// BeforeCreate will set a UUID for the new invoice line.
+func (l *InvoiceLine) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return
+}
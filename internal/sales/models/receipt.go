@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"erp-system/pkg/money"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Receipt is a payment received from a customer. Posting it debits the bank or cash account it was
+// paid into and credits the receivables control account. It pays the customer's invoices through
+// its allocations; what is not allocated stays on the customer's account as an unapplied credit.
+type Receipt struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID  uuid.UUID `gorm:"type:uuid;not null;index;index:idx_customer_receipts_number,unique" json:"company_id"`
+	CustomerID uuid.UUID `gorm:"type:uuid;not null;index" json:"customer_id"`
+	// ReceiptNumber is the gap-free number, such as RCT-2026-000007, given to the receipt.
+	ReceiptNumber    string       `gorm:"type:varchar(50);not null;index:idx_customer_receipts_number,unique" json:"receipt_number"`
+	ReceiptDate      time.Time    `gorm:"type:date;not null" json:"receipt_date"`
+	DepositAccountID uuid.UUID    `gorm:"type:uuid;not null" json:"deposit_account_id"` // The bank or cash account debited
+	Amount           money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"`
+	Currency         string       `gorm:"type:varchar(3);not null" json:"currency"`
+	AllocatedAmount  money.Amount `gorm:"type:numeric(18,4);not null;default:0" json:"allocated_amount"`
+	Reference        string       `gorm:"type:varchar(100)" json:"reference,omitempty"` // e.g. the bank transfer reference
+	JournalEntryID   *uuid.UUID   `gorm:"type:uuid;index" json:"journal_entry_id,omitempty"`
+	CreatedBy        string       `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt        time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Receipt model.
+func (Receipt) TableName() string {
+	return "customer_receipts"
+}
+
+// BeforeCreate will set a UUID for the new receipt.
+func (r *Receipt) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return
+}
+
+// UnallocatedAmount is what is left of the receipt to apply to invoices.
+func (r *Receipt) UnallocatedAmount() money.Amount {
+	return r.Amount.Sub(r.AllocatedAmount)
+}
+
+// Allocation applies part of a receipt, or of a credit note, to an invoice of the same customer.
+// Exactly one of ReceiptID and CreditNoteID is set. Allocations only move amounts between documents
+// on the receivables control account, so they post nothing to the general ledger.
+type Allocation struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primary_key;" json:"id"`
+	CompanyID    uuid.UUID    `gorm:"type:uuid;not null;index" json:"-"`
+	CustomerID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"customer_id"`
+	InvoiceID    uuid.UUID    `gorm:"type:uuid;not null;index" json:"invoice_id"`
+	ReceiptID    *uuid.UUID   `gorm:"type:uuid;index" json:"receipt_id,omitempty"`
+	CreditNoteID *uuid.UUID   `gorm:"type:uuid;index" json:"credit_note_id,omitempty"`
+	Amount       money.Amount `gorm:"type:numeric(18,4);not null" json:"amount"`
+	// AllocationDate is the later of the two documents' dates, from which the invoice counts as paid.
+	AllocationDate time.Time `gorm:"type:date;not null" json:"allocation_date"`
+	CreatedBy      string    `gorm:"type:varchar(100)" json:"created_by,omitempty"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Allocation model.
+func (Allocation) TableName() string {
+	return "receivable_allocations"
+}
+
+// BeforeCreate will set a UUID for the new allocation.
+func (a *Allocation) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return
+}
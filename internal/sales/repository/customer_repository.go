@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"erp-system/internal/sales/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerRepository defines the interface for database operations for customers.
+type CustomerRepository interface {
+	Create(ctx context.Context, customer *models.Customer) (*models.Customer, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Customer, error)
+	GetByCode(ctx context.Context, code string) (*models.Customer, error)
+	Update(ctx context.Context, customer *models.Customer) (*models.Customer, error)
+	List(ctx context.Context) ([]*models.Customer, error)
+}
+
+// gormCustomerRepository is an implementation of CustomerRepository using GORM.
+type gormCustomerRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomerRepository creates a new GORM-based CustomerRepository.
+func NewCustomerRepository(db *gorm.DB) CustomerRepository {
+	return &gormCustomerRepository{db: db}
+}
+
+func (r *gormCustomerRepository) Create(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	logger.InfoLogger.Printf("Repository: Creating customer %s", customer.Code)
+	if err := r.db.WithContext(ctx).Create(customer).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating customer %s: %v", customer.Code, err)
+		return nil, errors.NewInternalServerError("failed to create customer", err)
+	}
+	return customer, nil
+}
+
+func (r *gormCustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.WithContext(ctx).First(&customer, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("customer", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving customer %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get customer %s", id), err)
+	}
+	return &customer, nil
+}
+
+func (r *gormCustomerRepository) GetByCode(ctx context.Context, code string) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.WithContext(ctx).First(&customer, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("customer_code", code)
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving customer %s: %v", code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get customer %s", code), err)
+	}
+	return &customer, nil
+}
+
+func (r *gormCustomerRepository) Update(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	logger.InfoLogger.Printf("Repository: Updating customer %s", customer.Code)
+	if err := r.db.WithContext(ctx).Save(customer).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error updating customer %s: %v", customer.Code, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to update customer %s", customer.Code), err)
+	}
+	return customer, nil
+}
+
+// List returns every customer, ordered by code.
+func (r *gormCustomerRepository) List(ctx context.Context) ([]*models.Customer, error) {
+	var customers []*models.Customer
+	if err := r.db.WithContext(ctx).Order("code asc").Find(&customers).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing customers: %v", err)
+		return nil, errors.NewInternalServerError("failed to list customers", err)
+	}
+	return customers, nil
+}
@@ -0,0 +1,146 @@
+package mocks
+
+import (
+	"context"
+	"erp-system/internal/sales/models"
+	"erp-system/internal/sales/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// CustomerRepository is an autogenerated mock type for the CustomerRepository type
+type CustomerRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, customer
+func (_m *CustomerRepository) Create(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	ret := _m.Called(ctx, customer)
+
+	var r0 *models.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Customer) *models.Customer); ok {
+		r0 = rf(ctx, customer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Customer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Customer) error); ok {
+		r1 = rf(ctx, customer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCode provides a mock function with given fields: ctx, code
+func (_m *CustomerRepository) GetByCode(ctx context.Context, code string) (*models.Customer, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *models.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Customer); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Customer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *CustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Customer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Customer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *CustomerRepository) List(ctx context.Context) ([]*models.Customer, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.Customer
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.Customer); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Customer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, customer
+func (_m *CustomerRepository) Update(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	ret := _m.Called(ctx, customer)
+
+	var r0 *models.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Customer) *models.Customer); ok {
+		r0 = rf(ctx, customer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Customer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Customer) error); ok {
+		r1 = rf(ctx, customer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCustomerRepository creates a new instance of CustomerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCustomerRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CustomerRepository {
+	mock := &CustomerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.CustomerRepository = (*CustomerRepository)(nil)
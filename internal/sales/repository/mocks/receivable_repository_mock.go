@@ -0,0 +1,291 @@
+package mocks
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	"erp-system/internal/sales/models"
+	"erp-system/internal/sales/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// ReceivableRepository is an autogenerated mock type for the ReceivableRepository type
+type ReceivableRepository struct {
+	mock.Mock
+}
+
+// Allocate provides a mock function with given fields: ctx, allocations
+func (_m *ReceivableRepository) Allocate(ctx context.Context, allocations []*models.Allocation) error {
+	ret := _m.Called(ctx, allocations)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Allocation) error); ok {
+		r0 = rf(ctx, allocations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateInvoice provides a mock function with given fields: ctx, invoice
+func (_m *ReceivableRepository) CreateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+	ret := _m.Called(ctx, invoice)
+
+	var r0 *models.Invoice
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Invoice) *models.Invoice); ok {
+		r0 = rf(ctx, invoice)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invoice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Invoice) error); ok {
+		r1 = rf(ctx, invoice)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReceipt provides a mock function with given fields: ctx, receipt, entry, allocations
+func (_m *ReceivableRepository) CreateReceipt(ctx context.Context, receipt *models.Receipt, entry *accModels.JournalEntry, allocations []*models.Allocation) (*models.Receipt, error) {
+	ret := _m.Called(ctx, receipt, entry, allocations)
+
+	var r0 *models.Receipt
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Receipt, *accModels.JournalEntry, []*models.Allocation) *models.Receipt); ok {
+		r0 = rf(ctx, receipt, entry, allocations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Receipt, *accModels.JournalEntry, []*models.Allocation) error); ok {
+		r1 = rf(ctx, receipt, entry, allocations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteInvoice provides a mock function with given fields: ctx, id
+func (_m *ReceivableRepository) DeleteInvoice(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetInvoice provides a mock function with given fields: ctx, id
+func (_m *ReceivableRepository) GetInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Invoice
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Invoice); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invoice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceipt provides a mock function with given fields: ctx, id
+func (_m *ReceivableRepository) GetReceipt(ctx context.Context, id uuid.UUID) (*models.Receipt, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Receipt
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Receipt); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAllocations provides a mock function with given fields: ctx, customerID, asOf
+func (_m *ReceivableRepository) ListAllocations(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Allocation, error) {
+	ret := _m.Called(ctx, customerID, asOf)
+
+	var r0 []*models.Allocation
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time) []*models.Allocation); ok {
+		r0 = rf(ctx, customerID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Allocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, customerID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDocumentAllocations provides a mock function with given fields: ctx, documentID
+func (_m *ReceivableRepository) ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error) {
+	ret := _m.Called(ctx, documentID)
+
+	var r0 []*models.Allocation
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.Allocation); ok {
+		r0 = rf(ctx, documentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Allocation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, documentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInvoices provides a mock function with given fields: ctx, filters
+func (_m *ReceivableRepository) ListInvoices(ctx context.Context, filters map[string]interface{}) ([]*models.Invoice, error) {
+	ret := _m.Called(ctx, filters)
+
+	var r0 []*models.Invoice
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) []*models.Invoice); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Invoice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]interface{}) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPostedInvoices provides a mock function with given fields: ctx, customerID, asOf
+func (_m *ReceivableRepository) ListPostedInvoices(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Invoice, error) {
+	ret := _m.Called(ctx, customerID, asOf)
+
+	var r0 []*models.Invoice
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time) []*models.Invoice); ok {
+		r0 = rf(ctx, customerID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Invoice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, customerID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReceipts provides a mock function with given fields: ctx, customerID, asOf
+func (_m *ReceivableRepository) ListReceipts(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Receipt, error) {
+	ret := _m.Called(ctx, customerID, asOf)
+
+	var r0 []*models.Receipt
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time) []*models.Receipt); ok {
+		r0 = rf(ctx, customerID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, customerID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostInvoice provides a mock function with given fields: ctx, invoice, entry
+func (_m *ReceivableRepository) PostInvoice(ctx context.Context, invoice *models.Invoice, entry *accModels.JournalEntry) (*models.Invoice, error) {
+	ret := _m.Called(ctx, invoice, entry)
+
+	var r0 *models.Invoice
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Invoice, *accModels.JournalEntry) *models.Invoice); ok {
+		r0 = rf(ctx, invoice, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invoice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Invoice, *accModels.JournalEntry) error); ok {
+		r1 = rf(ctx, invoice, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReceivableRepository creates a new instance of ReceivableRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReceivableRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReceivableRepository {
+	mock := &ReceivableRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+var _ repository.ReceivableRepository = (*ReceivableRepository)(nil)
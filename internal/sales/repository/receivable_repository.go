@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	accRepo "erp-system/internal/accounting/repository"
+	"erp-system/internal/sales/models"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReceivableRepository defines the interface for database operations for the receivables
+// subledger: invoices, credit notes, receipts and the allocations between them.
+type ReceivableRepository interface {
+	CreateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error)
+	GetInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error)
+	ListInvoices(ctx context.Context, filters map[string]interface{}) ([]*models.Invoice, error)
+	DeleteInvoice(ctx context.Context, id uuid.UUID) error
+	PostInvoice(ctx context.Context, invoice *models.Invoice, entry *accModels.JournalEntry) (*models.Invoice, error)
+	// ListPostedInvoices returns the posted invoices and credit notes dated on or before asOf (all of
+	// them for a zero asOf), of one customer if customerID is set, in due date order.
+	ListPostedInvoices(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Invoice, error)
+
+	CreateReceipt(ctx context.Context, receipt *models.Receipt, entry *accModels.JournalEntry, allocations []*models.Allocation) (*models.Receipt, error)
+	GetReceipt(ctx context.Context, id uuid.UUID) (*models.Receipt, error)
+	// ListReceipts returns the receipts dated on or before asOf (all of them for a zero asOf), of
+	// one customer if customerID is set, oldest first.
+	ListReceipts(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Receipt, error)
+
+	Allocate(ctx context.Context, allocations []*models.Allocation) error
+	// ListAllocations returns the allocations dated on or before asOf (all of them for a zero
+	// asOf), of one customer if customerID is set.
+	ListAllocations(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Allocation, error)
+	// ListDocumentAllocations returns the allocations of an invoice, credit note or receipt.
+	ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error)
+}
+
+// gormReceivableRepository is an implementation of ReceivableRepository using GORM.
+type gormReceivableRepository struct {
+	db *gorm.DB
+}
+
+// NewReceivableRepository creates a new GORM-based ReceivableRepository.
+func NewReceivableRepository(db *gorm.DB) ReceivableRepository {
+	return &gormReceivableRepository{db: db}
+}
+
+// preloadInvoiceLines loads an invoice's lines in line order.
+func preloadInvoiceLines(db *gorm.DB) *gorm.DB {
+	return db.Order("line_number asc")
+}
+
+func (r *gormReceivableRepository) CreateInvoice(ctx context.Context, invoice *models.Invoice) (*models.Invoice, error) {
+	logger.InfoLogger.Printf("Repository: Creating %s for customer %s", invoice.InvoiceType, invoice.CustomerID)
+	if err := r.db.WithContext(ctx).Create(invoice).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error creating %s for customer %s: %v", invoice.InvoiceType, invoice.CustomerID, err)
+		return nil, errors.NewInternalServerError("failed to create invoice", err)
+	}
+	return invoice, nil
+}
+
+func (r *gormReceivableRepository) GetInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := r.db.WithContext(ctx).Preload("Lines", preloadInvoiceLines).First(&invoice, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("invoice", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving invoice %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get invoice %s", id), err)
+	}
+	return &invoice, nil
+}
+
+// ListInvoices returns the invoices and credit notes matching filters (column to value), latest first.
+func (r *gormReceivableRepository) ListInvoices(ctx context.Context, filters map[string]interface{}) ([]*models.Invoice, error) {
+	var invoices []*models.Invoice
+	if err := r.db.WithContext(ctx).Where(filters).Order("invoice_date desc, created_at desc").Find(&invoices).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing invoices: %v", err)
+		return nil, errors.NewInternalServerError("failed to list invoices", err)
+	}
+	return invoices, nil
+}
+
+// DeleteInvoice deletes a DRAFT invoice or credit note with its lines. It returns a ConflictError
+// if the document has been posted in the meantime.
+func (r *gormReceivableRepository) DeleteInvoice(ctx context.Context, id uuid.UUID) error {
+	logger.InfoLogger.Printf("Repository: Deleting draft invoice %s", id)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND status = ?", id, models.InvoiceStatusDraft).Delete(&models.Invoice{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("invoice %s is no longer a DRAFT", id))
+		}
+		return tx.Where("invoice_id = ?", id).Delete(&models.InvoiceLine{}).Error
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return err
+		}
+		logger.ErrorLogger.Printf("Repository: Error deleting invoice %s: %v", id, err)
+		return errors.NewInternalServerError(fmt.Sprintf("failed to delete invoice %s", id), err)
+	}
+	return nil
+}
+
+// PostInvoice gives a DRAFT invoice or credit note its number, saves its journal entry with the
+// number as reference, and marks it POSTED, all in one transaction. It returns a ConflictError if
+// the document was posted concurrently, so a document never gets two entries.
+func (r *gormReceivableRepository) PostInvoice(ctx context.Context, invoice *models.Invoice, entry *accModels.JournalEntry) (*models.Invoice, error) {
+	logger.InfoLogger.Printf("Repository: Posting invoice %s", invoice.ID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		documentType := accModels.DocumentTypeSalesInvoice
+		if invoice.InvoiceType == models.InvoiceTypeCreditNote {
+			documentType = accModels.DocumentTypeSalesCreditNote
+		}
+		number, err := accRepo.NextDocumentNumber(tx, documentType, invoice.InvoiceDate)
+		if err != nil {
+			return err
+		}
+		entry.Reference = number
+		if err := accRepo.SaveJournalEntry(tx, entry); err != nil {
+			return err
+		}
+		postedAt := time.Now()
+		result := tx.Model(&models.Invoice{}).Where("id = ? AND status = ?", invoice.ID, models.InvoiceStatusDraft).Updates(map[string]interface{}{
+			"status":           models.InvoiceStatusPosted,
+			"invoice_number":   number,
+			"journal_entry_id": entry.ID,
+			"posted_at":        postedAt,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("invoice %s is no longer a DRAFT", invoice.ID))
+		}
+		return nil
+	})
+	if err != nil {
+		switch err.(type) {
+		case *errors.ConflictError, *errors.ValidationError:
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Error posting invoice %s: %v", invoice.ID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to post invoice %s", invoice.ID), err)
+	}
+	return r.GetInvoice(ctx, invoice.ID)
+}
+
+func (r *gormReceivableRepository) ListPostedInvoices(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Invoice, error) {
+	query := r.db.WithContext(ctx).Where("status = ?", models.InvoiceStatusPosted)
+	if customerID != nil {
+		query = query.Where("customer_id = ?", *customerID)
+	}
+	if !asOf.IsZero() {
+		query = query.Where("invoice_date <= ?", asOf)
+	}
+	var invoices []*models.Invoice
+	if err := query.Order("due_date asc, invoice_number asc").Find(&invoices).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing posted invoices: %v", err)
+		return nil, errors.NewInternalServerError("failed to list posted invoices", err)
+	}
+	return invoices, nil
+}
+
+// CreateReceipt gives the receipt its number, saves its journal entry with the number as reference,
+// creates the receipt and applies its allocations, all in one transaction.
+func (r *gormReceivableRepository) CreateReceipt(ctx context.Context, receipt *models.Receipt, entry *accModels.JournalEntry, allocations []*models.Allocation) (*models.Receipt, error) {
+	logger.InfoLogger.Printf("Repository: Creating receipt of %s from customer %s", receipt.Amount, receipt.CustomerID)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		number, err := accRepo.NextDocumentNumber(tx, accModels.DocumentTypeCustomerReceipt, receipt.ReceiptDate)
+		if err != nil {
+			return err
+		}
+		receipt.ReceiptNumber = number
+		entry.Reference = number
+		if err := accRepo.SaveJournalEntry(tx, entry); err != nil {
+			return err
+		}
+		receipt.JournalEntryID = &entry.ID
+		if err := tx.Create(receipt).Error; err != nil {
+			return err
+		}
+		for _, allocation := range allocations {
+			allocation.ReceiptID = &receipt.ID
+		}
+		return applyAllocations(tx, allocations)
+	})
+	if err != nil {
+		switch err.(type) {
+		case *errors.ConflictError, *errors.ValidationError:
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return nil, err
+		}
+		logger.ErrorLogger.Printf("Repository: Error creating receipt from customer %s: %v", receipt.CustomerID, err)
+		return nil, errors.NewInternalServerError("failed to create receipt", err)
+	}
+	return r.GetReceipt(ctx, receipt.ID)
+}
+
+func (r *gormReceivableRepository) GetReceipt(ctx context.Context, id uuid.UUID) (*models.Receipt, error) {
+	var receipt models.Receipt
+	if err := r.db.WithContext(ctx).First(&receipt, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.NewNotFoundError("receipt", id.String())
+		}
+		logger.ErrorLogger.Printf("Repository: Error retrieving receipt %s: %v", id, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to get receipt %s", id), err)
+	}
+	return &receipt, nil
+}
+
+func (r *gormReceivableRepository) ListReceipts(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Receipt, error) {
+	query := r.db.WithContext(ctx)
+	if customerID != nil {
+		query = query.Where("customer_id = ?", *customerID)
+	}
+	if !asOf.IsZero() {
+		query = query.Where("receipt_date <= ?", asOf)
+	}
+	var receipts []*models.Receipt
+	if err := query.Order("receipt_date asc, receipt_number asc").Find(&receipts).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing receipts: %v", err)
+		return nil, errors.NewInternalServerError("failed to list receipts", err)
+	}
+	return receipts, nil
+}
+
+// Allocate applies allocations in one transaction: all of them or none.
+func (r *gormReceivableRepository) Allocate(ctx context.Context, allocations []*models.Allocation) error {
+	logger.InfoLogger.Printf("Repository: Saving %d receivable allocations", len(allocations))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyAllocations(tx, allocations)
+	})
+	if err != nil {
+		if _, ok := err.(*errors.ConflictError); ok {
+			logger.WarnLogger.Printf("Repository: %v", err)
+			return err
+		}
+		logger.ErrorLogger.Printf("Repository: Error saving receivable allocations: %v", err)
+		return errors.NewInternalServerError("failed to save allocations", err)
+	}
+	return nil
+}
+
+// applyAllocations saves allocations within tx and adds them to the allocated amounts of their
+// invoices, receipts and credit notes. Each update only succeeds while the document still has the
+// amount open, so concurrent allocations can never pay a document twice; otherwise a
+// ConflictError is returned.
+func applyAllocations(tx *gorm.DB, allocations []*models.Allocation) error {
+	for _, allocation := range allocations {
+		result := tx.Model(&models.Invoice{}).
+			Where("id = ? AND status = ? AND invoice_type = ? AND allocated_amount + ? <= total_amount",
+				allocation.InvoiceID, models.InvoiceStatusPosted, models.InvoiceTypeInvoice, allocation.Amount).
+			Update("allocated_amount", gorm.Expr("allocated_amount + ?", allocation.Amount))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("invoice %s does not have %s open", allocation.InvoiceID, allocation.Amount))
+		}
+
+		if allocation.ReceiptID != nil {
+			result = tx.Model(&models.Receipt{}).
+				Where("id = ? AND allocated_amount + ? <= amount", *allocation.ReceiptID, allocation.Amount).
+				Update("allocated_amount", gorm.Expr("allocated_amount + ?", allocation.Amount))
+		} else {
+			result = tx.Model(&models.Invoice{}).
+				Where("id = ? AND status = ? AND invoice_type = ? AND allocated_amount + ? <= total_amount",
+					allocation.CreditNoteID, models.InvoiceStatusPosted, models.InvoiceTypeCreditNote, allocation.Amount).
+				Update("allocated_amount", gorm.Expr("allocated_amount + ?", allocation.Amount))
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.NewConflictError(fmt.Sprintf("%s is no longer unapplied", allocation.Amount))
+		}
+		if err := tx.Create(allocation).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *gormReceivableRepository) ListAllocations(ctx context.Context, customerID *uuid.UUID, asOf time.Time) ([]*models.Allocation, error) {
+	query := r.db.WithContext(ctx)
+	if customerID != nil {
+		query = query.Where("customer_id = ?", *customerID)
+	}
+	if !asOf.IsZero() {
+		query = query.Where("allocation_date <= ?", asOf)
+	}
+	var allocations []*models.Allocation
+	if err := query.Order("allocation_date asc, created_at asc").Find(&allocations).Error; err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing receivable allocations: %v", err)
+		return nil, errors.NewInternalServerError("failed to list allocations", err)
+	}
+	return allocations, nil
+}
+
+func (r *gormReceivableRepository) ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error) {
+	var allocations []*models.Allocation
+	err := r.db.WithContext(ctx).
+		Where("invoice_id = ? OR receipt_id = ? OR credit_note_id = ?", documentID, documentID, documentID).
+		Order("allocation_date asc, created_at asc").Find(&allocations).Error
+	if err != nil {
+		logger.ErrorLogger.Printf("Repository: Error listing allocations of document %s: %v", documentID, err)
+		return nil, errors.NewInternalServerError(fmt.Sprintf("failed to list allocations of %s", documentID), err)
+	}
+	return allocations, nil
+}
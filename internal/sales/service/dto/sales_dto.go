@@ -0,0 +1,140 @@
+package dto
+
+import (
+	"erp-system/internal/sales/models"
+	"erp-system/pkg/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// --- Customer DTOs ---
+
+// CreateCustomerRequest defines the structure for creating a new customer.
+type CreateCustomerRequest struct {
+	Code             string `json:"code" binding:"required,max=20"` // Upper-cased; cannot be changed later
+	Name             string `json:"name" binding:"required,max=100"`
+	TaxID            string `json:"tax_id,omitempty" binding:"max=50"`
+	Email            string `json:"email,omitempty" binding:"max=100"`
+	PaymentTermsDays *int   `json:"payment_terms_days,omitempty"` // Defaults to 30; 0 means due on receipt
+}
+
+// UpdateCustomerRequest changes a customer's details. Omitted fields are left unchanged.
+type UpdateCustomerRequest struct {
+	Name             *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	TaxID            *string `json:"tax_id,omitempty" binding:"omitempty,max=50"`
+	Email            *string `json:"email,omitempty" binding:"omitempty,max=100"`
+	PaymentTermsDays *int    `json:"payment_terms_days,omitempty"` // Applies to invoices created from now on
+	IsActive         *bool   `json:"is_active,omitempty"`          // Inactive customers cannot be invoiced
+}
+
+// --- Invoice DTOs ---
+
+// InvoiceLineRequest is a revenue line of an invoice or credit note.
+type InvoiceLineRequest struct {
+	Description string       `json:"description,omitempty" binding:"max=255"`
+	AccountID   uuid.UUID    `json:"account_id"`         // A REVENUE account
+	Amount      money.Amount `json:"amount"`             // Net of tax; must be positive
+	TaxCode     string       `json:"tax_code,omitempty"` // An OUTPUT tax code
+}
+
+// CreateInvoiceRequest drafts an invoice or credit note for a customer.
+type CreateInvoiceRequest struct {
+	CustomerID  uuid.UUID            `json:"customer_id"`
+	InvoiceType models.InvoiceType   `json:"invoice_type,omitempty"` // INVOICE (default) or CREDIT_NOTE
+	InvoiceDate time.Time            `json:"invoice_date"`
+	DueDate     *time.Time           `json:"due_date,omitempty"` // Defaults to the invoice date plus the customer's payment terms
+	Reference   string               `json:"reference,omitempty" binding:"max=100"`
+	Description string               `json:"description,omitempty" binding:"max=255"`
+	Lines       []InvoiceLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+// ListInvoicesRequest filters the invoices and credit notes listed.
+type ListInvoicesRequest struct {
+	CustomerID  *uuid.UUID
+	InvoiceType models.InvoiceType
+	Status      models.InvoiceStatus
+}
+
+// --- Receipt and Allocation DTOs ---
+
+// AllocationRequest applies an amount to one invoice.
+type AllocationRequest struct {
+	InvoiceID uuid.UUID    `json:"invoice_id"`
+	Amount    money.Amount `json:"amount"`
+}
+
+// AllocateRequest applies a receipt or credit note to invoices of its customer, either to the
+// given invoices or, with AutoAllocate, to the open invoices in due date order.
+type AllocateRequest struct {
+	Allocations  []AllocationRequest `json:"allocations,omitempty"`
+	AutoAllocate bool                `json:"auto_allocate,omitempty"`
+}
+
+// CreateReceiptRequest records a payment from a customer and, optionally, applies it to invoices.
+type CreateReceiptRequest struct {
+	CustomerID       uuid.UUID           `json:"customer_id"`
+	ReceiptDate      time.Time           `json:"receipt_date"`
+	DepositAccountID uuid.UUID           `json:"deposit_account_id"` // The ASSET account the money was paid into
+	Amount           money.Amount        `json:"amount"`
+	Reference        string              `json:"reference,omitempty" binding:"max=100"`
+	Allocations      []AllocationRequest `json:"allocations,omitempty"`
+	AutoAllocate     bool                `json:"auto_allocate,omitempty"` // Pays the oldest open invoices first
+}
+
+// --- Aging DTOs ---
+
+// AgingRequest selects the receivables aging report.
+type AgingRequest struct {
+	AsOfDate   time.Time  `json:"as_of_date"`
+	CustomerID *uuid.UUID `json:"customer_id,omitempty"` // Optional: one customer only
+}
+
+// AgingBuckets splits open amounts by how many days past their due date they are. Unapplied
+// receipts and credit notes are not aged; they reduce the total as a negative Unapplied amount.
+type AgingBuckets struct {
+	Current    money.Amount `json:"current"` // Not yet due
+	Days1To30  money.Amount `json:"days_1_30"`
+	Days31To60 money.Amount `json:"days_31_60"`
+	Days61To90 money.Amount `json:"days_61_90"`
+	Over90     money.Amount `json:"over_90"`
+	Unapplied  money.Amount `json:"unapplied"`
+	Total      money.Amount `json:"total"`
+}
+
+// AgingItem is an open document on the aging report: an invoice with the amount still due, or a
+// receipt or credit note with the amount not yet applied, as a negative amount.
+type AgingItem struct {
+	DocumentType string       `json:"document_type"` // INVOICE, CREDIT_NOTE or RECEIPT
+	DocumentID   uuid.UUID    `json:"document_id"`
+	Number       string       `json:"number"`
+	Date         time.Time    `json:"date"`
+	DueDate      *time.Time   `json:"due_date,omitempty"`     // Invoices only
+	DaysOverdue  int          `json:"days_overdue,omitempty"` // Invoices only
+	Bucket       string       `json:"bucket"`                 // current, days_1_30, days_31_60, days_61_90, over_90 or unapplied
+	OpenAmount   money.Amount `json:"open_amount"`
+}
+
+// CustomerAging is one customer's line on the aging report.
+type CustomerAging struct {
+	CustomerID uuid.UUID    `json:"customer_id"`
+	Code       string       `json:"code"`
+	Name       string       `json:"name"`
+	Buckets    AgingBuckets `json:"buckets"`
+	Items      []AgingItem  `json:"items"`
+}
+
+// AgingReport lists what each customer owes as of a date. For a report of all customers, the total
+// is compared with the balance of the receivables control account in the general ledger; a
+// difference means the account was posted to outside the subledger.
+type AgingReport struct {
+	AsOfDate           time.Time       `json:"as_of_date"`
+	Currency           string          `json:"currency"`
+	ControlAccountID   uuid.UUID       `json:"control_account_id"`
+	ControlAccountCode string          `json:"control_account_code"`
+	Customers          []CustomerAging `json:"customers"`
+	Totals             AgingBuckets    `json:"totals"`
+	LedgerBalance      *money.Amount   `json:"ledger_balance,omitempty"` // All customers only
+	Difference         *money.Amount   `json:"difference,omitempty"`     // LedgerBalance less Totals.Total
+	Reconciled         *bool           `json:"reconciled,omitempty"`
+}
@@ -0,0 +1,878 @@
+package service
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	accDto "erp-system/internal/accounting/service/dto"
+	"erp-system/internal/sales/models"
+	"erp-system/internal/sales/repository"
+	dto "erp-system/internal/sales/service/dto"
+	"erp-system/pkg/auth"
+	"erp-system/pkg/errors"
+	"erp-system/pkg/logger"
+	"erp-system/pkg/money"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// customerCodePattern is what customer codes may look like once upper-cased.
+var customerCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9._-]{0,19}$`)
+
+// Aging buckets of the receivables aging report.
+const (
+	BucketCurrent    = "current"
+	BucketDays1To30  = "days_1_30"
+	BucketDays31To60 = "days_31_60"
+	BucketDays61To90 = "days_61_90"
+	BucketOver90     = "over_90"
+	BucketUnapplied  = "unapplied"
+)
+
+// Ledger is the general ledger the receivables subledger posts to. The accounting service
+// satisfies it.
+type Ledger interface {
+	PrepareSubledgerEntry(ctx context.Context, req accDto.CreateJournalEntryRequest) (*accModels.JournalEntry, error)
+	GetChartOfAccountByID(ctx context.Context, id uuid.UUID) (*accModels.ChartOfAccount, error)
+	GetChartOfAccountByCode(ctx context.Context, code string) (*accModels.ChartOfAccount, error)
+	GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (money.Amount, error)
+	BaseCurrency(ctx context.Context) string
+}
+
+// TaxCalculator calculates the tax of invoice lines. The accounting tax service satisfies it.
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (*accDto.TaxCalculation, error)
+}
+
+// SalesService defines the interface for the accounts receivable subledger: customers, their
+// invoices and credit notes, the receipts that pay them, and the aging of what is still owed.
+type SalesService interface {
+	// Customers
+	CreateCustomer(ctx context.Context, req dto.CreateCustomerRequest) (*models.Customer, error)
+	GetCustomer(ctx context.Context, id uuid.UUID) (*models.Customer, error)
+	ListCustomers(ctx context.Context) ([]*models.Customer, error)
+	UpdateCustomer(ctx context.Context, id uuid.UUID, req dto.UpdateCustomerRequest) (*models.Customer, error)
+
+	// Invoices and Credit Notes
+	CreateInvoice(ctx context.Context, req dto.CreateInvoiceRequest) (*models.Invoice, error)
+	GetInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error)
+	ListInvoices(ctx context.Context, req dto.ListInvoicesRequest) ([]*models.Invoice, error)
+	DeleteInvoice(ctx context.Context, id uuid.UUID) error
+	PostInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error)
+	ApplyCreditNote(ctx context.Context, id uuid.UUID, req dto.AllocateRequest) ([]*models.Allocation, error)
+
+	// Receipts
+	CreateReceipt(ctx context.Context, req dto.CreateReceiptRequest) (*models.Receipt, error)
+	GetReceipt(ctx context.Context, id uuid.UUID) (*models.Receipt, error)
+	ListReceipts(ctx context.Context, customerID *uuid.UUID) ([]*models.Receipt, error)
+	AllocateReceipt(ctx context.Context, id uuid.UUID, req dto.AllocateRequest) ([]*models.Allocation, error)
+	ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error)
+
+	// Reporting
+	GetAgingReport(ctx context.Context, req dto.AgingRequest) (*dto.AgingReport, error)
+}
+
+// salesService is an implementation of SalesService.
+type salesService struct {
+	customerRepo   repository.CustomerRepository
+	receivableRepo repository.ReceivableRepository
+	ledger         Ledger
+	taxes          TaxCalculator // Optional; nil rejects lines with a tax code
+	// receivablesAccountCode is the ASSET account code of the receivables control account.
+	receivablesAccountCode string
+}
+
+// NewSalesService creates a new SalesService.
+func NewSalesService(
+	customerRepo repository.CustomerRepository,
+	receivableRepo repository.ReceivableRepository,
+	ledger Ledger,
+	taxes TaxCalculator,
+	receivablesAccountCode string,
+) SalesService {
+	return &salesService{
+		customerRepo:           customerRepo,
+		receivableRepo:         receivableRepo,
+		ledger:                 ledger,
+		taxes:                  taxes,
+		receivablesAccountCode: receivablesAccountCode,
+	}
+}
+
+// --- Customer Methods ---
+
+func (s *salesService) CreateCustomer(ctx context.Context, req dto.CreateCustomerRequest) (*models.Customer, error) {
+	logger.InfoLogger.Printf("Service: Attempting to create customer %s", req.Code)
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if !customerCodePattern.MatchString(code) {
+		return nil, errors.NewValidationError("code must be 1-20 letters, digits, '.', '_' or '-'", "code")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required", "name")
+	}
+	terms := models.DefaultPaymentTermsDays
+	if req.PaymentTermsDays != nil {
+		terms = *req.PaymentTermsDays
+	}
+	if terms < 0 {
+		return nil, errors.NewValidationError("payment_terms_days cannot be negative", "payment_terms_days")
+	}
+	if _, err := s.customerRepo.GetByCode(ctx, code); err == nil {
+		return nil, errors.NewConflictError(fmt.Sprintf("customer %s already exists", code))
+	} else if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	customer := &models.Customer{
+		Code:             code,
+		Name:             name,
+		TaxID:            strings.TrimSpace(req.TaxID),
+		Email:            strings.TrimSpace(req.Email),
+		PaymentTermsDays: terms,
+		IsActive:         true,
+	}
+	created, err := s.customerRepo.Create(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Created customer %s (%s)", created.Code, created.ID)
+	return created, nil
+}
+
+func (s *salesService) GetCustomer(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
+	return s.customerRepo.GetByID(ctx, id)
+}
+
+func (s *salesService) ListCustomers(ctx context.Context) ([]*models.Customer, error) {
+	return s.customerRepo.List(ctx)
+}
+
+func (s *salesService) UpdateCustomer(ctx context.Context, id uuid.UUID, req dto.UpdateCustomerRequest) (*models.Customer, error) {
+	logger.InfoLogger.Printf("Service: Attempting to update customer %s", id)
+	customer, err := s.customerRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name cannot be empty", "name")
+		}
+		customer.Name = name
+	}
+	if req.TaxID != nil {
+		customer.TaxID = strings.TrimSpace(*req.TaxID)
+	}
+	if req.Email != nil {
+		customer.Email = strings.TrimSpace(*req.Email)
+	}
+	if req.PaymentTermsDays != nil {
+		if *req.PaymentTermsDays < 0 {
+			return nil, errors.NewValidationError("payment_terms_days cannot be negative", "payment_terms_days")
+		}
+		customer.PaymentTermsDays = *req.PaymentTermsDays
+	}
+	if req.IsActive != nil {
+		customer.IsActive = *req.IsActive
+	}
+	return s.customerRepo.Update(ctx, customer)
+}
+
+// --- Invoice Methods ---
+
+// CreateInvoice drafts an invoice or credit note. The tax of each line is calculated at the rate
+// of its tax code on the invoice date; nothing reaches the general ledger until it is posted.
+func (s *salesService) CreateInvoice(ctx context.Context, req dto.CreateInvoiceRequest) (*models.Invoice, error) {
+	invoiceType := req.InvoiceType
+	if invoiceType == "" {
+		invoiceType = models.InvoiceTypeInvoice
+	}
+	if invoiceType != models.InvoiceTypeInvoice && invoiceType != models.InvoiceTypeCreditNote {
+		return nil, errors.NewValidationError(fmt.Sprintf("invoice_type must be %s or %s", models.InvoiceTypeInvoice, models.InvoiceTypeCreditNote), "invoice_type")
+	}
+	logger.InfoLogger.Printf("Service: Attempting to create %s for customer %s", invoiceType, req.CustomerID)
+
+	customer, err := s.activeCustomer(ctx, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if req.InvoiceDate.IsZero() {
+		return nil, errors.NewValidationError("invoice_date is required", "invoice_date")
+	}
+	if len(req.Lines) == 0 {
+		return nil, errors.NewValidationError("an invoice needs at least one line", "lines")
+	}
+	invoiceDate := dateOnly(req.InvoiceDate)
+	dueDate := invoiceDate
+	if req.DueDate != nil {
+		dueDate = dateOnly(*req.DueDate)
+		if dueDate.Before(invoiceDate) {
+			return nil, errors.NewValidationError("due_date cannot be before invoice_date", "due_date")
+		}
+	} else if invoiceType == models.InvoiceTypeInvoice {
+		dueDate = invoiceDate.AddDate(0, 0, customer.PaymentTermsDays)
+	}
+
+	currency := s.ledger.BaseCurrency(ctx)
+	invoice := &models.Invoice{
+		CustomerID:      customer.ID,
+		InvoiceType:     invoiceType,
+		Status:          models.InvoiceStatusDraft,
+		InvoiceDate:     invoiceDate,
+		DueDate:         dueDate,
+		Reference:       strings.TrimSpace(req.Reference),
+		Description:     strings.TrimSpace(req.Description),
+		Currency:        currency,
+		NetAmount:       money.Zero,
+		TaxAmount:       money.Zero,
+		TotalAmount:     money.Zero,
+		AllocatedAmount: money.Zero,
+		CreatedBy:       currentUser(ctx),
+	}
+	for i, lineReq := range req.Lines {
+		line, err := s.newInvoiceLine(ctx, i+1, lineReq, invoiceDate, currency)
+		if err != nil {
+			return nil, err
+		}
+		invoice.Lines = append(invoice.Lines, *line)
+		invoice.NetAmount = invoice.NetAmount.Add(line.Amount)
+		invoice.TaxAmount = invoice.TaxAmount.Add(line.TaxAmount)
+	}
+	invoice.TotalAmount = invoice.NetAmount.Add(invoice.TaxAmount)
+
+	created, err := s.receivableRepo.CreateInvoice(ctx, invoice)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Created draft %s %s of %s for customer %s", created.InvoiceType, created.ID, created.TotalAmount, customer.Code)
+	return created, nil
+}
+
+// newInvoiceLine validates a line request and calculates its tax.
+func (s *salesService) newInvoiceLine(ctx context.Context, lineNumber int, req dto.InvoiceLineRequest, invoiceDate time.Time, currency string) (*models.InvoiceLine, error) {
+	account, err := s.ledger.GetChartOfAccountByID(ctx, req.AccountID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s does not exist", lineNumber, req.AccountID), "lines.account_id")
+		}
+		return nil, err
+	}
+	if account.AccountType != accModels.Revenue || !account.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("line %d: account %s is not an active %s account", lineNumber, account.AccountCode, accModels.Revenue), "lines.account_id")
+	}
+	if !req.Amount.IsPositive() {
+		return nil, errors.NewValidationError(fmt.Sprintf("line %d: amount must be positive", lineNumber), "lines.amount")
+	}
+	if err := req.Amount.CheckPrecision(currency); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", lineNumber, err), "lines.amount")
+	}
+	line := &models.InvoiceLine{
+		LineNumber:  lineNumber,
+		Description: strings.TrimSpace(req.Description),
+		AccountID:   account.ID,
+		Amount:      req.Amount,
+		TaxAmount:   money.Zero,
+	}
+	if code := strings.ToUpper(strings.TrimSpace(req.TaxCode)); code != "" {
+		if line.TaxCode, line.TaxAmount, err = s.lineTax(ctx, code, req.Amount, currency, invoiceDate); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", lineNumber, err), "lines.tax_code")
+		}
+	}
+	return line, nil
+}
+
+// lineTax returns the tax the customer is charged on base with an OUTPUT tax code on date. It is
+// zero for reverse-charge codes, whose tax the customer accounts for.
+func (s *salesService) lineTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (string, money.Amount, error) {
+	if s.taxes == nil {
+		return "", money.Zero, fmt.Errorf("tax codes are not enabled")
+	}
+	calc, err := s.taxes.CalculateTax(ctx, code, base, currency, date)
+	if err != nil {
+		if vErr, ok := err.(*errors.ValidationError); ok {
+			return "", money.Zero, fmt.Errorf("%s", vErr.Message)
+		}
+		return "", money.Zero, err
+	}
+	if calc.TaxType != accModels.TaxTypeOutput {
+		return "", money.Zero, fmt.Errorf("tax code %s is not an %s tax code", calc.TaxCode, accModels.TaxTypeOutput)
+	}
+	if calc.ReverseCharge {
+		return calc.TaxCode, money.Zero, nil
+	}
+	return calc.TaxCode, calc.Tax, nil
+}
+
+func (s *salesService) GetInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error) {
+	return s.receivableRepo.GetInvoice(ctx, id)
+}
+
+func (s *salesService) ListInvoices(ctx context.Context, req dto.ListInvoicesRequest) ([]*models.Invoice, error) {
+	filters := make(map[string]interface{})
+	if req.CustomerID != nil {
+		filters["customer_id"] = *req.CustomerID
+	}
+	if req.InvoiceType != "" {
+		filters["invoice_type"] = req.InvoiceType
+	}
+	if req.Status != "" {
+		filters["status"] = req.Status
+	}
+	return s.receivableRepo.ListInvoices(ctx, filters)
+}
+
+// DeleteInvoice deletes a DRAFT invoice or credit note. Posted ones are corrected with a credit note.
+func (s *salesService) DeleteInvoice(ctx context.Context, id uuid.UUID) error {
+	logger.InfoLogger.Printf("Service: Attempting to delete invoice %s", id)
+	invoice, err := s.receivableRepo.GetInvoice(ctx, id)
+	if err != nil {
+		return err
+	}
+	if invoice.Status != models.InvoiceStatusDraft {
+		return errors.NewConflictError(fmt.Sprintf("invoice %s is %s; only DRAFT invoices can be deleted, correct posted ones with a credit note", invoice.InvoiceNumber, invoice.Status))
+	}
+	return s.receivableRepo.DeleteInvoice(ctx, id)
+}
+
+// PostInvoice numbers a DRAFT invoice or credit note and posts it to the general ledger: the total
+// to the receivables control account, and each line to its revenue account with its tax code, so
+// the ledger adds the tax lines. Both happen in one transaction.
+func (s *salesService) PostInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error) {
+	logger.InfoLogger.Printf("Service: Attempting to post invoice %s", id)
+	invoice, err := s.receivableRepo.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != models.InvoiceStatusDraft {
+		return nil, errors.NewConflictError(fmt.Sprintf("invoice %s is already %s", invoice.InvoiceNumber, invoice.Status))
+	}
+	customer, err := s.activeCustomer(ctx, invoice.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	control, err := s.receivablesAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	isCreditNote := invoice.InvoiceType == models.InvoiceTypeCreditNote
+	entryReq := accDto.CreateJournalEntryRequest{
+		EntryDate:   invoice.InvoiceDate,
+		Description: invoiceEntryDescription(invoice, customer),
+		Lines: []accDto.JournalLineRequest{{
+			AccountID: control.ID,
+			Amount:    invoice.TotalAmount,
+			Currency:  invoice.Currency,
+			IsDebit:   !isCreditNote,
+		}},
+	}
+	for _, line := range invoice.Lines {
+		// The rate of a tax code can be changed after the invoice was drafted with it; the customer
+		// must be charged what the ledger will post.
+		if line.TaxCode != "" {
+			_, tax, err := s.lineTax(ctx, line.TaxCode, line.Amount, invoice.Currency, invoice.InvoiceDate)
+			if err != nil {
+				return nil, errors.NewValidationError(fmt.Sprintf("line %d: %v", line.LineNumber, err), "lines.tax_code")
+			}
+			if !tax.Equal(line.TaxAmount) {
+				return nil, errors.NewConflictError(fmt.Sprintf("line %d: the tax of tax code %s is now %s instead of %s; delete the draft and create it again", line.LineNumber, line.TaxCode, tax, line.TaxAmount))
+			}
+		}
+		entryReq.Lines = append(entryReq.Lines, accDto.JournalLineRequest{
+			AccountID: line.AccountID,
+			Amount:    line.Amount,
+			Currency:  invoice.Currency,
+			IsDebit:   isCreditNote,
+			TaxCode:   line.TaxCode,
+		})
+	}
+	entry, err := s.ledger.PrepareSubledgerEntry(ctx, entryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	posted, err := s.receivableRepo.PostInvoice(ctx, invoice, entry)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Posted %s %s with journal entry %s", posted.InvoiceType, posted.InvoiceNumber, entry.DocumentNumber)
+	return posted, nil
+}
+
+func invoiceEntryDescription(invoice *models.Invoice, customer *models.Customer) string {
+	kind := "Invoice"
+	if invoice.InvoiceType == models.InvoiceTypeCreditNote {
+		kind = "Credit note"
+	}
+	description := fmt.Sprintf("%s to %s %s", kind, customer.Code, customer.Name)
+	if len(description) > 255 {
+		description = description[:255]
+	}
+	return description
+}
+
+// ApplyCreditNote applies a posted credit note to invoices of its customer.
+func (s *salesService) ApplyCreditNote(ctx context.Context, id uuid.UUID, req dto.AllocateRequest) ([]*models.Allocation, error) {
+	logger.InfoLogger.Printf("Service: Attempting to apply credit note %s", id)
+	creditNote, err := s.receivableRepo.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if creditNote.InvoiceType != models.InvoiceTypeCreditNote || creditNote.Status != models.InvoiceStatusPosted {
+		return nil, errors.NewValidationError(fmt.Sprintf("document %s is not a posted credit note", id), "id")
+	}
+	allocations, err := s.buildAllocations(ctx, creditNote.CustomerID, creditNote.OpenAmount(), creditNote.InvoiceDate, creditNote.Currency, req, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocations) == 0 {
+		return allocations, nil
+	}
+	for _, allocation := range allocations {
+		allocation.CreditNoteID = &creditNote.ID
+	}
+	if err := s.receivableRepo.Allocate(ctx, allocations); err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+// --- Receipt Methods ---
+
+// CreateReceipt records a payment from a customer, posts it from the receivables control account
+// to the deposit account, and applies it to the customer's invoices, all in one transaction.
+func (s *salesService) CreateReceipt(ctx context.Context, req dto.CreateReceiptRequest) (*models.Receipt, error) {
+	logger.InfoLogger.Printf("Service: Attempting to record receipt of %s from customer %s", req.Amount, req.CustomerID)
+	customer, err := s.customerRepo.GetByID(ctx, req.CustomerID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("customer %s does not exist", req.CustomerID), "customer_id")
+		}
+		return nil, err
+	}
+	if req.ReceiptDate.IsZero() {
+		return nil, errors.NewValidationError("receipt_date is required", "receipt_date")
+	}
+	currency := s.ledger.BaseCurrency(ctx)
+	if !req.Amount.IsPositive() {
+		return nil, errors.NewValidationError("amount must be positive", "amount")
+	}
+	if err := req.Amount.CheckPrecision(currency); err != nil {
+		return nil, errors.NewValidationError(err.Error(), "amount")
+	}
+	control, err := s.receivablesAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deposit, err := s.ledger.GetChartOfAccountByID(ctx, req.DepositAccountID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("account %s does not exist", req.DepositAccountID), "deposit_account_id")
+		}
+		return nil, err
+	}
+	if deposit.AccountType != accModels.Asset || !deposit.IsActive || deposit.ID == control.ID {
+		return nil, errors.NewValidationError(fmt.Sprintf("deposit account %s must be an active %s account other than the receivables account", deposit.AccountCode, accModels.Asset), "deposit_account_id")
+	}
+
+	receiptDate := dateOnly(req.ReceiptDate)
+	allocations, err := s.buildAllocations(ctx, customer.ID, req.Amount, receiptDate, currency, dto.AllocateRequest{Allocations: req.Allocations, AutoAllocate: req.AutoAllocate}, false)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.ledger.PrepareSubledgerEntry(ctx, accDto.CreateJournalEntryRequest{
+		EntryDate:   receiptDate,
+		Description: fmt.Sprintf("Receipt from %s %s", customer.Code, customer.Name),
+		Lines: []accDto.JournalLineRequest{
+			{AccountID: deposit.ID, Amount: req.Amount, Currency: currency, IsDebit: true},
+			{AccountID: control.ID, Amount: req.Amount, Currency: currency, IsDebit: false},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := &models.Receipt{
+		CustomerID:       customer.ID,
+		ReceiptDate:      receiptDate,
+		DepositAccountID: deposit.ID,
+		Amount:           req.Amount,
+		Currency:         currency,
+		AllocatedAmount:  money.Zero,
+		Reference:        strings.TrimSpace(req.Reference),
+		CreatedBy:        currentUser(ctx),
+	}
+	created, err := s.receivableRepo.CreateReceipt(ctx, receipt, entry, allocations)
+	if err != nil {
+		return nil, err
+	}
+	logger.InfoLogger.Printf("Service: Recorded receipt %s of %s from customer %s", created.ReceiptNumber, created.Amount, customer.Code)
+	return created, nil
+}
+
+func (s *salesService) GetReceipt(ctx context.Context, id uuid.UUID) (*models.Receipt, error) {
+	return s.receivableRepo.GetReceipt(ctx, id)
+}
+
+func (s *salesService) ListReceipts(ctx context.Context, customerID *uuid.UUID) ([]*models.Receipt, error) {
+	return s.receivableRepo.ListReceipts(ctx, customerID, time.Time{})
+}
+
+// AllocateReceipt applies the unallocated part of a receipt to invoices of its customer.
+func (s *salesService) AllocateReceipt(ctx context.Context, id uuid.UUID, req dto.AllocateRequest) ([]*models.Allocation, error) {
+	logger.InfoLogger.Printf("Service: Attempting to allocate receipt %s", id)
+	receipt, err := s.receivableRepo.GetReceipt(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.buildAllocations(ctx, receipt.CustomerID, receipt.UnallocatedAmount(), receipt.ReceiptDate, receipt.Currency, req, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(allocations) == 0 {
+		return allocations, nil
+	}
+	for _, allocation := range allocations {
+		allocation.ReceiptID = &receipt.ID
+	}
+	if err := s.receivableRepo.Allocate(ctx, allocations); err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+func (s *salesService) ListDocumentAllocations(ctx context.Context, documentID uuid.UUID) ([]*models.Allocation, error) {
+	return s.receivableRepo.ListDocumentAllocations(ctx, documentID)
+}
+
+// buildAllocations turns an allocation request into allocations of up to available to posted
+// invoices of the customer: the given ones, or with AutoAllocate the open ones in due date order.
+// required rejects a request that allocates nothing.
+func (s *salesService) buildAllocations(ctx context.Context, customerID uuid.UUID, available money.Amount, sourceDate time.Time, currency string, req dto.AllocateRequest, required bool) ([]*models.Allocation, error) {
+	if req.AutoAllocate && len(req.Allocations) > 0 {
+		return nil, errors.NewValidationError("give either allocations or auto_allocate, not both", "allocations")
+	}
+	if required && !req.AutoAllocate && len(req.Allocations) == 0 {
+		return nil, errors.NewValidationError("allocations or auto_allocate is required", "allocations")
+	}
+	createdBy := currentUser(ctx)
+	newAllocation := func(invoice *models.Invoice, amount money.Amount) *models.Allocation {
+		date := sourceDate
+		if invoice.InvoiceDate.After(date) {
+			date = invoice.InvoiceDate
+		}
+		return &models.Allocation{
+			CustomerID:     customerID,
+			InvoiceID:      invoice.ID,
+			Amount:         amount,
+			AllocationDate: date,
+			CreatedBy:      createdBy,
+		}
+	}
+
+	allocations := []*models.Allocation{}
+	remaining := available
+	if req.AutoAllocate {
+		invoices, err := s.receivableRepo.ListPostedInvoices(ctx, &customerID, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		for _, invoice := range invoices {
+			if !remaining.IsPositive() {
+				break
+			}
+			open := invoice.OpenAmount()
+			if invoice.InvoiceType != models.InvoiceTypeInvoice || !open.IsPositive() {
+				continue
+			}
+			amount := open
+			if amount.Cmp(remaining) > 0 {
+				amount = remaining
+			}
+			allocations = append(allocations, newAllocation(invoice, amount))
+			remaining = remaining.Sub(amount)
+		}
+		return allocations, nil
+	}
+
+	// Open amounts left on the invoices this request allocates to, so that one invoice given
+	// twice is not paid twice.
+	openByInvoice := make(map[uuid.UUID]money.Amount)
+	for i, allocReq := range req.Allocations {
+		if !allocReq.Amount.IsPositive() {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: amount must be positive", i+1), "allocations.amount")
+		}
+		if err := allocReq.Amount.CheckPrecision(currency); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: %v", i+1, err), "allocations.amount")
+		}
+		invoice, err := s.receivableRepo.GetInvoice(ctx, allocReq.InvoiceID)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: invoice %s does not exist", i+1, allocReq.InvoiceID), "allocations.invoice_id")
+			}
+			return nil, err
+		}
+		if invoice.CustomerID != customerID || invoice.InvoiceType != models.InvoiceTypeInvoice || invoice.Status != models.InvoiceStatusPosted {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: %s is not a posted invoice of the customer", i+1, allocReq.InvoiceID), "allocations.invoice_id")
+		}
+		open, seen := openByInvoice[invoice.ID]
+		if !seen {
+			open = invoice.OpenAmount()
+		}
+		if allocReq.Amount.Cmp(open) > 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("allocation %d: %s is more than the %s open on invoice %s", i+1, allocReq.Amount, open, invoice.InvoiceNumber), "allocations.amount")
+		}
+		openByInvoice[invoice.ID] = open.Sub(allocReq.Amount)
+		remaining = remaining.Sub(allocReq.Amount)
+		allocations = append(allocations, newAllocation(invoice, allocReq.Amount))
+	}
+	if remaining.IsNegative() {
+		return nil, errors.NewValidationError(fmt.Sprintf("the allocations total more than the %s available", available), "allocations")
+	}
+	return allocations, nil
+}
+
+// --- Reporting Methods ---
+
+// GetAgingReport ages the open invoices of each customer as of a date by the days they are past
+// due, and lists unapplied receipts and credit notes against them. Open amounts are computed from
+// the allocations made by that date, so a report for a past date is the same whenever it is run.
+// Without a customer filter, the total is reconciled to the receivables control account.
+func (s *salesService) GetAgingReport(ctx context.Context, req dto.AgingRequest) (*dto.AgingReport, error) {
+	if req.AsOfDate.IsZero() {
+		return nil, errors.NewValidationError("as_of_date is required", "as_of_date")
+	}
+	asOf := dateOnly(req.AsOfDate)
+	logger.InfoLogger.Printf("Service: Generating receivables aging as of %s", asOf.Format("2006-01-02"))
+
+	control, err := s.receivablesAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var customers []*models.Customer
+	if req.CustomerID != nil {
+		customer, err := s.customerRepo.GetByID(ctx, *req.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		customers = []*models.Customer{customer}
+	} else if customers, err = s.customerRepo.List(ctx); err != nil {
+		return nil, err
+	}
+	invoices, err := s.receivableRepo.ListPostedInvoices(ctx, req.CustomerID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.receivableRepo.ListReceipts(ctx, req.CustomerID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.receivableRepo.ListAllocations(ctx, req.CustomerID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	// What had been allocated by the as-of date, per invoice, credit note and receipt.
+	allocated := make(map[uuid.UUID]money.Amount)
+	addAllocated := func(id uuid.UUID, amount money.Amount) {
+		total, ok := allocated[id]
+		if !ok {
+			total = money.Zero
+		}
+		allocated[id] = total.Add(amount)
+	}
+	for _, allocation := range allocations {
+		addAllocated(allocation.InvoiceID, allocation.Amount)
+		if allocation.ReceiptID != nil {
+			addAllocated(*allocation.ReceiptID, allocation.Amount)
+		}
+		if allocation.CreditNoteID != nil {
+			addAllocated(*allocation.CreditNoteID, allocation.Amount)
+		}
+	}
+	openAmount := func(id uuid.UUID, total money.Amount) money.Amount {
+		if done, ok := allocated[id]; ok {
+			return total.Sub(done)
+		}
+		return total
+	}
+
+	itemsByCustomer := make(map[uuid.UUID][]dto.AgingItem)
+	for _, invoice := range invoices {
+		open := openAmount(invoice.ID, invoice.TotalAmount)
+		if open.IsZero() {
+			continue
+		}
+		item := dto.AgingItem{
+			DocumentType: string(invoice.InvoiceType),
+			DocumentID:   invoice.ID,
+			Number:       invoice.InvoiceNumber,
+			Date:         invoice.InvoiceDate,
+		}
+		if invoice.InvoiceType == models.InvoiceTypeCreditNote {
+			item.Bucket = BucketUnapplied
+			item.OpenAmount = open.Neg()
+		} else {
+			dueDate := invoice.DueDate
+			item.DueDate = &dueDate
+			item.DaysOverdue = int(asOf.Sub(dateOnly(dueDate)).Hours() / 24)
+			item.Bucket = agingBucket(item.DaysOverdue)
+			item.OpenAmount = open
+		}
+		itemsByCustomer[invoice.CustomerID] = append(itemsByCustomer[invoice.CustomerID], item)
+	}
+	for _, receipt := range receipts {
+		open := openAmount(receipt.ID, receipt.Amount)
+		if open.IsZero() {
+			continue
+		}
+		itemsByCustomer[receipt.CustomerID] = append(itemsByCustomer[receipt.CustomerID], dto.AgingItem{
+			DocumentType: "RECEIPT",
+			DocumentID:   receipt.ID,
+			Number:       receipt.ReceiptNumber,
+			Date:         receipt.ReceiptDate,
+			Bucket:       BucketUnapplied,
+			OpenAmount:   open.Neg(),
+		})
+	}
+
+	report := &dto.AgingReport{
+		AsOfDate:           asOf,
+		Currency:           s.ledger.BaseCurrency(ctx),
+		ControlAccountID:   control.ID,
+		ControlAccountCode: control.AccountCode,
+		Customers:          []dto.CustomerAging{},
+		Totals:             newAgingBuckets(),
+	}
+	sort.Slice(customers, func(i, j int) bool { return customers[i].Code < customers[j].Code })
+	for _, customer := range customers {
+		items := itemsByCustomer[customer.ID]
+		if len(items) == 0 {
+			continue
+		}
+		line := dto.CustomerAging{CustomerID: customer.ID, Code: customer.Code, Name: customer.Name, Buckets: newAgingBuckets(), Items: items}
+		for _, item := range items {
+			addToBucket(&line.Buckets, item.Bucket, item.OpenAmount)
+			addToBucket(&report.Totals, item.Bucket, item.OpenAmount)
+		}
+		report.Customers = append(report.Customers, line)
+	}
+
+	if req.CustomerID == nil {
+		// GetAccountBalance includes entries up to the given instant, so ask for the end of the day.
+		balance, err := s.ledger.GetAccountBalance(ctx, control.ID, asOf.AddDate(0, 0, 1).Add(-time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+		difference := balance.Sub(report.Totals.Total)
+		reconciled := difference.IsZero()
+		report.LedgerBalance = &balance
+		report.Difference = &difference
+		report.Reconciled = &reconciled
+		if !reconciled {
+			logger.WarnLogger.Printf("Service: Receivables aging as of %s is %s but account %s has %s", asOf.Format("2006-01-02"), report.Totals.Total, control.AccountCode, balance)
+		}
+	}
+	return report, nil
+}
+
+// agingBucket returns the bucket of an invoice the given number of days past due.
+func agingBucket(daysOverdue int) string {
+	switch {
+	case daysOverdue <= 0:
+		return BucketCurrent
+	case daysOverdue <= 30:
+		return BucketDays1To30
+	case daysOverdue <= 60:
+		return BucketDays31To60
+	case daysOverdue <= 90:
+		return BucketDays61To90
+	default:
+		return BucketOver90
+	}
+}
+
+func newAgingBuckets() dto.AgingBuckets {
+	return dto.AgingBuckets{
+		Current: money.Zero, Days1To30: money.Zero, Days31To60: money.Zero, Days61To90: money.Zero,
+		Over90: money.Zero, Unapplied: money.Zero, Total: money.Zero,
+	}
+}
+
+func addToBucket(buckets *dto.AgingBuckets, bucket string, amount money.Amount) {
+	switch bucket {
+	case BucketCurrent:
+		buckets.Current = buckets.Current.Add(amount)
+	case BucketDays1To30:
+		buckets.Days1To30 = buckets.Days1To30.Add(amount)
+	case BucketDays31To60:
+		buckets.Days31To60 = buckets.Days31To60.Add(amount)
+	case BucketDays61To90:
+		buckets.Days61To90 = buckets.Days61To90.Add(amount)
+	case BucketOver90:
+		buckets.Over90 = buckets.Over90.Add(amount)
+	case BucketUnapplied:
+		buckets.Unapplied = buckets.Unapplied.Add(amount)
+	}
+	buckets.Total = buckets.Total.Add(amount)
+}
+
+// --- Helpers ---
+
+// activeCustomer returns the customer to invoice, which must exist and be active.
+func (s *salesService) activeCustomer(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
+	customer, err := s.customerRepo.GetByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("customer %s does not exist", id), "customer_id")
+		}
+		return nil, err
+	}
+	if !customer.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("customer %s is inactive", customer.Code), "customer_id")
+	}
+	return customer, nil
+}
+
+// receivablesAccount returns the receivables control account of the active company.
+func (s *salesService) receivablesAccount(ctx context.Context) (*accModels.ChartOfAccount, error) {
+	if s.receivablesAccountCode == "" {
+		return nil, errors.NewInternalServerError("the receivables subledger is not configured: a receivables account code is required", nil)
+	}
+	account, err := s.ledger.GetChartOfAccountByCode(ctx, s.receivablesAccountCode)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, errors.NewValidationError(fmt.Sprintf("receivables account %s does not exist", s.receivablesAccountCode), "receivables_account_code")
+		}
+		return nil, err
+	}
+	if account.AccountType != accModels.Asset || !account.IsActive {
+		return nil, errors.NewValidationError(fmt.Sprintf("receivables account %s is not an active %s account", account.AccountCode, accModels.Asset), "receivables_account_code")
+	}
+	return account, nil
+}
+
+func currentUser(ctx context.Context) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return principal.UserID
+	}
+	return ""
+}
+
+// dateOnly truncates t to midnight UTC of its calendar day.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*errors.NotFoundError)
+	return ok
+}
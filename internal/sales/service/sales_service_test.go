@@ -0,0 +1,506 @@
+package service_test
+
+import (
+	"context"
+	accModels "erp-system/internal/accounting/models"
+	accDto "erp-system/internal/accounting/service/dto"
+	"erp-system/internal/sales/models"
+	salesRepoMock "erp-system/internal/sales/repository/mocks"
+	"erp-system/internal/sales/service"
+	dto "erp-system/internal/sales/service/dto"
+	app_errors "erp-system/pkg/errors"
+	"erp-system/pkg/money"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const receivablesCode = "1200"
+
+// stubLedger is a general ledger with a fixed chart of accounts that records the entries the
+// sales service prepares.
+type stubLedger struct {
+	accounts map[uuid.UUID]*accModels.ChartOfAccount
+	balance  money.Amount
+	prepared []accDto.CreateJournalEntryRequest
+}
+
+func newStubLedger(accounts ...*accModels.ChartOfAccount) *stubLedger {
+	l := &stubLedger{accounts: make(map[uuid.UUID]*accModels.ChartOfAccount), balance: money.Zero}
+	for _, account := range accounts {
+		l.accounts[account.ID] = account
+	}
+	return l
+}
+
+func (l *stubLedger) PrepareSubledgerEntry(ctx context.Context, req accDto.CreateJournalEntryRequest) (*accModels.JournalEntry, error) {
+	l.prepared = append(l.prepared, req)
+	return &accModels.JournalEntry{ID: uuid.New(), EntryDate: req.EntryDate, Status: accModels.StatusPosted, EntryType: accModels.EntryTypeSubledger}, nil
+}
+
+func (l *stubLedger) GetChartOfAccountByID(ctx context.Context, id uuid.UUID) (*accModels.ChartOfAccount, error) {
+	if account, ok := l.accounts[id]; ok {
+		return account, nil
+	}
+	return nil, app_errors.NewNotFoundError("chart_of_account", id.String())
+}
+
+func (l *stubLedger) GetChartOfAccountByCode(ctx context.Context, code string) (*accModels.ChartOfAccount, error) {
+	for _, account := range l.accounts {
+		if account.AccountCode == code {
+			return account, nil
+		}
+	}
+	return nil, app_errors.NewNotFoundError("chart_of_account_code", code)
+}
+
+func (l *stubLedger) GetAccountBalance(ctx context.Context, accountID uuid.UUID, date time.Time) (money.Amount, error) {
+	return l.balance, nil
+}
+
+func (l *stubLedger) BaseCurrency(ctx context.Context) string { return "USD" }
+
+// stubTaxes charges 20% with OUTPUT code VAT20 and has an INPUT code VATIN.
+type stubTaxes struct {
+	rate money.Rate
+}
+
+func (t *stubTaxes) CalculateTax(ctx context.Context, code string, base money.Amount, currency string, date time.Time) (*accDto.TaxCalculation, error) {
+	taxType := accModels.TaxTypeOutput
+	switch code {
+	case "VAT20":
+	case "VATIN":
+		taxType = accModels.TaxTypeInput
+	default:
+		return nil, app_errors.NewValidationError("unknown tax code "+code, "tax_code")
+	}
+	return &accDto.TaxCalculation{TaxCode: code, TaxType: taxType, Rate: t.rate, Currency: currency, TaxableBase: base, Tax: base.Convert(t.rate).Round(currency)}, nil
+}
+
+type salesFixture struct {
+	customerRepo   *salesRepoMock.CustomerRepository
+	receivableRepo *salesRepoMock.ReceivableRepository
+	ledger         *stubLedger
+	taxes          *stubTaxes
+	service        service.SalesService
+	receivables    *accModels.ChartOfAccount
+	bank           *accModels.ChartOfAccount
+	revenue        *accModels.ChartOfAccount
+	expense        *accModels.ChartOfAccount
+	customer       *models.Customer
+}
+
+func newSalesFixture(t *testing.T) *salesFixture {
+	f := &salesFixture{
+		customerRepo:   salesRepoMock.NewCustomerRepositoryMock(t),
+		receivableRepo: salesRepoMock.NewReceivableRepositoryMock(t),
+		taxes:          &stubTaxes{rate: money.MustParseRate("0.2")},
+		receivables:    &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: receivablesCode, AccountType: accModels.Asset, IsActive: true},
+		bank:           &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: "1010", AccountType: accModels.Asset, IsActive: true},
+		revenue:        &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: "4000", AccountType: accModels.Revenue, IsActive: true},
+		expense:        &accModels.ChartOfAccount{ID: uuid.New(), AccountCode: "5000", AccountType: accModels.Expense, IsActive: true},
+		customer:       &models.Customer{ID: uuid.New(), Code: "ACME", Name: "Acme Ltd", PaymentTermsDays: 30, IsActive: true},
+	}
+	f.ledger = newStubLedger(f.receivables, f.bank, f.revenue, f.expense)
+	f.service = service.NewSalesService(f.customerRepo, f.receivableRepo, f.ledger, f.taxes, receivablesCode)
+	return f
+}
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func postedInvoice(customerID uuid.UUID, number, invoiceDate, dueDate, total, allocated string) *models.Invoice {
+	return &models.Invoice{
+		ID: uuid.New(), CustomerID: customerID, InvoiceType: models.InvoiceTypeInvoice, Status: models.InvoiceStatusPosted,
+		InvoiceNumber: number, InvoiceDate: date(invoiceDate), DueDate: date(dueDate), Currency: "USD",
+		TotalAmount: money.MustParse(total), AllocatedAmount: money.MustParse(allocated),
+	}
+}
+
+func TestSalesService_CreateCustomer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Code Upper-Cased And Default Terms", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.customerRepo.On("GetByCode", ctx, "ACME").Return(nil, app_errors.NewNotFoundError("customer_code", "ACME")).Once()
+		f.customerRepo.On("Create", ctx, mock.AnythingOfType("*models.Customer")).
+			Return(func(_ context.Context, c *models.Customer) *models.Customer { return c }, nil).Once()
+
+		customer, err := f.service.CreateCustomer(ctx, dto.CreateCustomerRequest{Code: " acme ", Name: "Acme Ltd"})
+		require.NoError(t, err)
+		assert.Equal(t, "ACME", customer.Code)
+		assert.Equal(t, models.DefaultPaymentTermsDays, customer.PaymentTermsDays)
+		assert.True(t, customer.IsActive)
+	})
+
+	t.Run("Error - Code Exists", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.customerRepo.On("GetByCode", ctx, "ACME").Return(f.customer, nil).Once()
+
+		_, err := f.service.CreateCustomer(ctx, dto.CreateCustomerRequest{Code: "ACME", Name: "Acme Ltd"})
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+	})
+
+	t.Run("Error - Negative Payment Terms", func(t *testing.T) {
+		f := newSalesFixture(t)
+		terms := -1
+		_, err := f.service.CreateCustomer(ctx, dto.CreateCustomerRequest{Code: "ACME", Name: "Acme Ltd", PaymentTermsDays: &terms})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestSalesService_CreateInvoice(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Tax And Due Date From Payment Terms", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+		f.receivableRepo.On("CreateInvoice", ctx, mock.AnythingOfType("*models.Invoice")).
+			Return(func(_ context.Context, i *models.Invoice) *models.Invoice { return i }, nil).Once()
+
+		invoice, err := f.service.CreateInvoice(ctx, dto.CreateInvoiceRequest{
+			CustomerID:  f.customer.ID,
+			InvoiceDate: time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC),
+			Lines: []dto.InvoiceLineRequest{
+				{AccountID: f.revenue.ID, Amount: money.MustParse("100.00"), TaxCode: "vat20"},
+				{AccountID: f.revenue.ID, Amount: money.MustParse("50.00")},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.InvoiceTypeInvoice, invoice.InvoiceType)
+		assert.Equal(t, models.InvoiceStatusDraft, invoice.Status)
+		assert.Equal(t, date("2026-03-10"), invoice.InvoiceDate)
+		assert.Equal(t, date("2026-04-09"), invoice.DueDate)
+		assert.Equal(t, "USD", invoice.Currency)
+		assert.Equal(t, "150.00", invoice.NetAmount.String())
+		assert.Equal(t, "20.00", invoice.TaxAmount.String())
+		assert.Equal(t, "170.00", invoice.TotalAmount.String())
+		require.Len(t, invoice.Lines, 2)
+		assert.Equal(t, "VAT20", invoice.Lines[0].TaxCode)
+		assert.Equal(t, 2, invoice.Lines[1].LineNumber)
+	})
+
+	t.Run("Success - Credit Note Due On Its Date", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+		f.receivableRepo.On("CreateInvoice", ctx, mock.AnythingOfType("*models.Invoice")).
+			Return(func(_ context.Context, i *models.Invoice) *models.Invoice { return i }, nil).Once()
+
+		creditNote, err := f.service.CreateInvoice(ctx, dto.CreateInvoiceRequest{
+			CustomerID:  f.customer.ID,
+			InvoiceType: models.InvoiceTypeCreditNote,
+			InvoiceDate: date("2026-03-10"),
+			Lines:       []dto.InvoiceLineRequest{{AccountID: f.revenue.ID, Amount: money.MustParse("10.00")}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, date("2026-03-10"), creditNote.DueDate)
+	})
+
+	t.Run("Error - Inactive Customer", func(t *testing.T) {
+		f := newSalesFixture(t)
+		inactive := *f.customer
+		inactive.IsActive = false
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(&inactive, nil).Once()
+
+		_, err := f.service.CreateInvoice(ctx, dto.CreateInvoiceRequest{
+			CustomerID:  f.customer.ID,
+			InvoiceDate: date("2026-03-10"),
+			Lines:       []dto.InvoiceLineRequest{{AccountID: f.revenue.ID, Amount: money.MustParse("10.00")}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - Line Account Not Revenue", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+
+		_, err := f.service.CreateInvoice(ctx, dto.CreateInvoiceRequest{
+			CustomerID:  f.customer.ID,
+			InvoiceDate: date("2026-03-10"),
+			Lines:       []dto.InvoiceLineRequest{{AccountID: f.expense.ID, Amount: money.MustParse("10.00")}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - Input Tax Code", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+
+		_, err := f.service.CreateInvoice(ctx, dto.CreateInvoiceRequest{
+			CustomerID:  f.customer.ID,
+			InvoiceDate: date("2026-03-10"),
+			Lines:       []dto.InvoiceLineRequest{{AccountID: f.revenue.ID, Amount: money.MustParse("10.00"), TaxCode: "VATIN"}},
+		})
+		require.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "is not an OUTPUT tax code")
+	})
+}
+
+func TestSalesService_PostInvoice(t *testing.T) {
+	ctx := context.Background()
+
+	draft := func(f *salesFixture, invoiceType models.InvoiceType, tax string) *models.Invoice {
+		return &models.Invoice{
+			ID: uuid.New(), CustomerID: f.customer.ID, InvoiceType: invoiceType, Status: models.InvoiceStatusDraft,
+			InvoiceDate: date("2026-03-10"), DueDate: date("2026-04-09"), Currency: "USD",
+			NetAmount: money.MustParse("100.00"), TaxAmount: money.MustParse(tax), TotalAmount: money.MustParse("100.00").Add(money.MustParse(tax)),
+			Lines: []models.InvoiceLine{{LineNumber: 1, AccountID: f.revenue.ID, Amount: money.MustParse("100.00"), TaxCode: "VAT20", TaxAmount: money.MustParse(tax)}},
+		}
+	}
+
+	t.Run("Success - Invoice Debits Receivables And Credits Revenue With Tax Code", func(t *testing.T) {
+		f := newSalesFixture(t)
+		invoice := draft(f, models.InvoiceTypeInvoice, "20.00")
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Once()
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+		f.receivableRepo.On("PostInvoice", ctx, invoice, mock.AnythingOfType("*models.JournalEntry")).
+			Return(func(_ context.Context, i *models.Invoice, _ *accModels.JournalEntry) *models.Invoice {
+				i.Status = models.InvoiceStatusPosted
+				return i
+			}, nil).Once()
+
+		posted, err := f.service.PostInvoice(ctx, invoice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.InvoiceStatusPosted, posted.Status)
+		require.Len(t, f.ledger.prepared, 1)
+		entry := f.ledger.prepared[0]
+		assert.Equal(t, date("2026-03-10"), entry.EntryDate)
+		require.Len(t, entry.Lines, 2)
+		assert.Equal(t, f.receivables.ID, entry.Lines[0].AccountID)
+		assert.True(t, entry.Lines[0].IsDebit)
+		assert.Equal(t, "120.00", entry.Lines[0].Amount.String())
+		assert.Equal(t, f.revenue.ID, entry.Lines[1].AccountID)
+		assert.False(t, entry.Lines[1].IsDebit)
+		assert.Equal(t, "100.00", entry.Lines[1].Amount.String())
+		assert.Equal(t, "VAT20", entry.Lines[1].TaxCode)
+	})
+
+	t.Run("Success - Credit Note Credits Receivables", func(t *testing.T) {
+		f := newSalesFixture(t)
+		creditNote := draft(f, models.InvoiceTypeCreditNote, "20.00")
+		f.receivableRepo.On("GetInvoice", ctx, creditNote.ID).Return(creditNote, nil).Once()
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+		f.receivableRepo.On("PostInvoice", ctx, creditNote, mock.AnythingOfType("*models.JournalEntry")).Return(creditNote, nil).Once()
+
+		_, err := f.service.PostInvoice(ctx, creditNote.ID)
+		require.NoError(t, err)
+		entry := f.ledger.prepared[0]
+		assert.False(t, entry.Lines[0].IsDebit)
+		assert.True(t, entry.Lines[1].IsDebit)
+	})
+
+	t.Run("Error - Already Posted", func(t *testing.T) {
+		f := newSalesFixture(t)
+		invoice := draft(f, models.InvoiceTypeInvoice, "20.00")
+		invoice.Status = models.InvoiceStatusPosted
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Once()
+
+		_, err := f.service.PostInvoice(ctx, invoice.ID)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Empty(t, f.ledger.prepared)
+	})
+
+	t.Run("Error - Tax Rate Changed Since Draft", func(t *testing.T) {
+		f := newSalesFixture(t)
+		invoice := draft(f, models.InvoiceTypeInvoice, "10.00")
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Once()
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+
+		_, err := f.service.PostInvoice(ctx, invoice.ID)
+		assert.IsType(t, &app_errors.ConflictError{}, err)
+		assert.Empty(t, f.ledger.prepared)
+	})
+
+	t.Run("Error - Receivables Account Missing", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.service = service.NewSalesService(f.customerRepo, f.receivableRepo, f.ledger, f.taxes, "1299")
+		invoice := draft(f, models.InvoiceTypeInvoice, "20.00")
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Once()
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+
+		_, err := f.service.PostInvoice(ctx, invoice.ID)
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestSalesService_CreateReceipt(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success - Auto Allocation Pays Invoices In Due Date Order", func(t *testing.T) {
+		f := newSalesFixture(t)
+		older := postedInvoice(f.customer.ID, "INV-1", "2026-01-05", "2026-02-04", "100.00", "40.00")
+		newer := postedInvoice(f.customer.ID, "INV-2", "2026-02-05", "2026-03-07", "200.00", "0")
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+		f.receivableRepo.On("ListPostedInvoices", ctx, &f.customer.ID, time.Time{}).Return([]*models.Invoice{older, newer}, nil).Once()
+		var allocations []*models.Allocation
+		f.receivableRepo.On("CreateReceipt", ctx, mock.AnythingOfType("*models.Receipt"), mock.AnythingOfType("*models.JournalEntry"), mock.Anything).
+			Run(func(args mock.Arguments) { allocations = args.Get(3).([]*models.Allocation) }).
+			Return(func(_ context.Context, r *models.Receipt, _ *accModels.JournalEntry, _ []*models.Allocation) *models.Receipt {
+				return r
+			}, nil).Once()
+
+		receipt, err := f.service.CreateReceipt(ctx, dto.CreateReceiptRequest{
+			CustomerID: f.customer.ID, ReceiptDate: date("2026-02-10"), DepositAccountID: f.bank.ID,
+			Amount: money.MustParse("100.00"), AutoAllocate: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "USD", receipt.Currency)
+		require.Len(t, allocations, 2)
+		assert.Equal(t, older.ID, allocations[0].InvoiceID)
+		assert.Equal(t, "60.00", allocations[0].Amount.String())
+		assert.Equal(t, date("2026-02-10"), allocations[0].AllocationDate)
+		assert.Equal(t, newer.ID, allocations[1].InvoiceID)
+		assert.Equal(t, "40.00", allocations[1].Amount.String())
+
+		entry := f.ledger.prepared[0]
+		assert.Equal(t, f.bank.ID, entry.Lines[0].AccountID)
+		assert.True(t, entry.Lines[0].IsDebit)
+		assert.Equal(t, f.receivables.ID, entry.Lines[1].AccountID)
+		assert.False(t, entry.Lines[1].IsDebit)
+	})
+
+	t.Run("Error - Allocation Over Open Amount", func(t *testing.T) {
+		f := newSalesFixture(t)
+		invoice := postedInvoice(f.customer.ID, "INV-1", "2026-01-05", "2026-02-04", "100.00", "40.00")
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Twice()
+
+		_, err := f.service.CreateReceipt(ctx, dto.CreateReceiptRequest{
+			CustomerID: f.customer.ID, ReceiptDate: date("2026-02-10"), DepositAccountID: f.bank.ID,
+			Amount: money.MustParse("100.00"),
+			Allocations: []dto.AllocationRequest{
+				{InvoiceID: invoice.ID, Amount: money.MustParse("50.00")},
+				{InvoiceID: invoice.ID, Amount: money.MustParse("20.00")},
+			},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+		assert.Empty(t, f.ledger.prepared)
+	})
+
+	t.Run("Error - Allocations Exceed Receipt", func(t *testing.T) {
+		f := newSalesFixture(t)
+		invoice := postedInvoice(f.customer.ID, "INV-1", "2026-01-05", "2026-02-04", "100.00", "0")
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Once()
+
+		_, err := f.service.CreateReceipt(ctx, dto.CreateReceiptRequest{
+			CustomerID: f.customer.ID, ReceiptDate: date("2026-02-10"), DepositAccountID: f.bank.ID,
+			Amount:      money.MustParse("50.00"),
+			Allocations: []dto.AllocationRequest{{InvoiceID: invoice.ID, Amount: money.MustParse("60.00")}},
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+
+	t.Run("Error - Deposit Into Receivables Account", func(t *testing.T) {
+		f := newSalesFixture(t)
+		f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil).Once()
+
+		_, err := f.service.CreateReceipt(ctx, dto.CreateReceiptRequest{
+			CustomerID: f.customer.ID, ReceiptDate: date("2026-02-10"), DepositAccountID: f.receivables.ID,
+			Amount: money.MustParse("50.00"),
+		})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestSalesService_ApplyCreditNote(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		f := newSalesFixture(t)
+		creditNote := postedInvoice(f.customer.ID, "CN-1", "2026-03-01", "2026-03-01", "30.00", "0")
+		creditNote.InvoiceType = models.InvoiceTypeCreditNote
+		invoice := postedInvoice(f.customer.ID, "INV-1", "2026-03-05", "2026-04-04", "100.00", "0")
+		f.receivableRepo.On("GetInvoice", ctx, creditNote.ID).Return(creditNote, nil).Once()
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Once()
+		f.receivableRepo.On("Allocate", ctx, mock.Anything).Return(nil).Once()
+
+		allocations, err := f.service.ApplyCreditNote(ctx, creditNote.ID, dto.AllocateRequest{
+			Allocations: []dto.AllocationRequest{{InvoiceID: invoice.ID, Amount: money.MustParse("30.00")}},
+		})
+		require.NoError(t, err)
+		require.Len(t, allocations, 1)
+		assert.Equal(t, creditNote.ID, *allocations[0].CreditNoteID)
+		assert.Nil(t, allocations[0].ReceiptID)
+		assert.Equal(t, date("2026-03-05"), allocations[0].AllocationDate)
+	})
+
+	t.Run("Error - Not A Credit Note", func(t *testing.T) {
+		f := newSalesFixture(t)
+		invoice := postedInvoice(f.customer.ID, "INV-1", "2026-03-05", "2026-04-04", "100.00", "0")
+		f.receivableRepo.On("GetInvoice", ctx, invoice.ID).Return(invoice, nil).Once()
+
+		_, err := f.service.ApplyCreditNote(ctx, invoice.ID, dto.AllocateRequest{AutoAllocate: true})
+		assert.IsType(t, &app_errors.ValidationError{}, err)
+	})
+}
+
+func TestSalesService_GetAgingReport(t *testing.T) {
+	ctx := context.Background()
+	asOf := date("2026-06-30")
+
+	setup := func(f *salesFixture) {
+		current := postedInvoice(f.customer.ID, "INV-5", "2026-06-15", "2026-07-15", "100.00", "0")
+		days20 := postedInvoice(f.customer.ID, "INV-4", "2026-05-11", "2026-06-10", "200.00", "0")
+		days45 := postedInvoice(f.customer.ID, "INV-3", "2026-04-16", "2026-05-16", "300.00", "0")
+		// Paid in full after the as-of date, so still open on the report.
+		days75 := postedInvoice(f.customer.ID, "INV-2", "2026-03-17", "2026-04-16", "400.00", "400.00")
+		over90 := postedInvoice(f.customer.ID, "INV-1", "2026-01-01", "2026-01-31", "500.00", "500.00")
+		creditNote := postedInvoice(f.customer.ID, "CN-1", "2026-06-20", "2026-06-20", "50.00", "0")
+		creditNote.InvoiceType = models.InvoiceTypeCreditNote
+		receiptID := uuid.New()
+		receipt := &models.Receipt{ID: receiptID, CustomerID: f.customer.ID, ReceiptNumber: "RCT-1", ReceiptDate: date("2026-06-01"), Amount: money.MustParse("600.00"), AllocatedAmount: money.MustParse("600.00")}
+
+		f.customerRepo.On("List", ctx).Return([]*models.Customer{f.customer}, nil).Once()
+		f.receivableRepo.On("ListPostedInvoices", ctx, (*uuid.UUID)(nil), asOf).
+			Return([]*models.Invoice{over90, days75, days45, days20, creditNote, current}, nil).Once()
+		f.receivableRepo.On("ListReceipts", ctx, (*uuid.UUID)(nil), asOf).Return([]*models.Receipt{receipt}, nil).Once()
+		f.receivableRepo.On("ListAllocations", ctx, (*uuid.UUID)(nil), asOf).Return([]*models.Allocation{
+			{CustomerID: f.customer.ID, InvoiceID: over90.ID, ReceiptID: &receiptID, Amount: money.MustParse("500.00"), AllocationDate: date("2026-06-01")},
+		}, nil).Once()
+	}
+
+	t.Run("Success - Buckets And Reconciliation", func(t *testing.T) {
+		f := newSalesFixture(t)
+		setup(f)
+		// 1,000 open on invoices less 50 on the credit note and 100 on the receipt.
+		f.ledger.balance = money.MustParse("850.00")
+
+		report, err := f.service.GetAgingReport(ctx, dto.AgingRequest{AsOfDate: asOf})
+		require.NoError(t, err)
+		require.Len(t, report.Customers, 1)
+		totals := report.Totals
+		assert.Equal(t, "100.00", totals.Current.String())
+		assert.Equal(t, "200.00", totals.Days1To30.String())
+		assert.Equal(t, "300.00", totals.Days31To60.String())
+		assert.Equal(t, "400.00", totals.Days61To90.String())
+		assert.True(t, totals.Over90.IsZero())
+		assert.Equal(t, "-150.00", totals.Unapplied.String())
+		assert.Equal(t, "850.00", totals.Total.String())
+		assert.Len(t, report.Customers[0].Items, 6)
+		assert.Equal(t, receivablesCode, report.ControlAccountCode)
+		require.NotNil(t, report.Reconciled)
+		assert.True(t, *report.Reconciled)
+		assert.True(t, report.Difference.IsZero())
+	})
+
+	t.Run("Success - Difference When Ledger Posted Outside Subledger", func(t *testing.T) {
+		f := newSalesFixture(t)
+		setup(f)
+		f.ledger.balance = money.MustParse("900.00")
+
+		report, err := f.service.GetAgingReport(ctx, dto.AgingRequest{AsOfDate: asOf})
+		require.NoError(t, err)
+		assert.False(t, *report.Reconciled)
+		assert.Equal(t, "50.00", report.Difference.String())
+	})
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	// "fmt" // No longer directly used, using logger
+	"context"
 	"erp-system/api"
 	"erp-system/configs"
 	"erp-system/pkg/database"
@@ -64,6 +65,11 @@ func main() {
 	router := api.NewRouter(db)
 	logger.InfoLogger.Println("HTTP router initialized.")
 
+	// Start background jobs (recurring journals, automatic reversals)
+	jobs := api.NewScheduler(db)
+	jobs.Start(context.Background())
+	defer jobs.Stop()
+
 	// Start server
 	// ServerPort is now from the loaded configuration
 	logger.InfoLogger.Printf("Server starting on port %s", cfg.ServerPort)
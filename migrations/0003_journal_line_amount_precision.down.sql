@@ -0,0 +1,5 @@
+-- Restore the original journal line amount precision.
+-- Values with more than 2 decimal places are rounded by PostgreSQL.
+ALTER TABLE journal_lines ALTER COLUMN amount TYPE NUMERIC(15, 2);
+
+COMMENT ON COLUMN journal_lines.amount IS 'Amount stored as positive value; is_debit flag determines debit/credit nature.';
@@ -0,0 +1,5 @@
+-- Widen journal line amounts so exact fixed-point values (4 decimal places)
+-- round-trip without loss. Per-currency precision is enforced by the service.
+ALTER TABLE journal_lines ALTER COLUMN amount TYPE NUMERIC(18, 4);
+
+COMMENT ON COLUMN journal_lines.amount IS 'Exact amount stored as positive value; is_debit flag determines debit/credit nature.';
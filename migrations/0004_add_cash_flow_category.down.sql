@@ -0,0 +1,2 @@
+-- Remove the cash flow classification from chart of accounts.
+ALTER TABLE chart_of_accounts DROP COLUMN IF EXISTS cash_flow_category;
@@ -0,0 +1,4 @@
+-- Classify balance sheet accounts for the statement of cash flows.
+ALTER TABLE chart_of_accounts ADD COLUMN IF NOT EXISTS cash_flow_category VARCHAR(20);
+
+COMMENT ON COLUMN chart_of_accounts.cash_flow_category IS 'Valid categories: CASH, OPERATING, NON_CASH, INVESTING, FINANCING. NULL defaults to OPERATING for assets/liabilities and FINANCING for equity.';
@@ -0,0 +1,3 @@
+-- Drop fiscal calendar tables
+DROP TABLE IF EXISTS fiscal_periods;
+DROP TABLE IF EXISTS fiscal_years;
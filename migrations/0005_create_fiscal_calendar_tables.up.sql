@@ -0,0 +1,38 @@
+-- Create Fiscal Years Table
+CREATE TABLE IF NOT EXISTS fiscal_years (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(50) NOT NULL UNIQUE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT chk_fiscal_years_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fiscal_years_start_date ON fiscal_years(start_date);
+CREATE INDEX IF NOT EXISTS idx_fiscal_years_end_date ON fiscal_years(end_date);
+CREATE INDEX IF NOT EXISTS idx_fiscal_years_deleted_at ON fiscal_years(deleted_at);
+
+-- Create Fiscal Periods Table
+CREATE TABLE IF NOT EXISTS fiscal_periods (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    fiscal_year_id UUID NOT NULL REFERENCES fiscal_years(id) ON DELETE CASCADE,
+    period_number INTEGER NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN', -- OPEN, SOFT_CLOSED, CLOSED
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT uq_fiscal_periods_year_number UNIQUE (fiscal_year_id, period_number),
+    CONSTRAINT chk_fiscal_periods_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fiscal_periods_fiscal_year_id ON fiscal_periods(fiscal_year_id);
+CREATE INDEX IF NOT EXISTS idx_fiscal_periods_start_date ON fiscal_periods(start_date);
+CREATE INDEX IF NOT EXISTS idx_fiscal_periods_end_date ON fiscal_periods(end_date);
+CREATE INDEX IF NOT EXISTS idx_fiscal_periods_status ON fiscal_periods(status);
+CREATE INDEX IF NOT EXISTS idx_fiscal_periods_deleted_at ON fiscal_periods(deleted_at);
+COMMENT ON COLUMN fiscal_periods.status IS 'Valid statuses: OPEN, SOFT_CLOSED (privileged roles only), CLOSED';
@@ -0,0 +1,7 @@
+-- Remove year-end close tracking.
+ALTER TABLE fiscal_years DROP COLUMN IF EXISTS closed_at;
+ALTER TABLE fiscal_years DROP COLUMN IF EXISTS closing_entry_id;
+ALTER TABLE fiscal_years DROP COLUMN IF EXISTS status;
+
+DROP INDEX IF EXISTS idx_journal_entries_entry_type;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS entry_type;
@@ -0,0 +1,10 @@
+-- Mark system-generated journal entries so reports can tell year-end closing entries apart.
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS entry_type VARCHAR(20) NOT NULL DEFAULT 'STANDARD';
+CREATE INDEX IF NOT EXISTS idx_journal_entries_entry_type ON journal_entries(entry_type);
+COMMENT ON COLUMN journal_entries.entry_type IS 'Valid types: STANDARD, CLOSING (year-end close into retained earnings and its reversal)';
+
+-- Track the year-end close on fiscal years.
+ALTER TABLE fiscal_years ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'OPEN';
+ALTER TABLE fiscal_years ADD COLUMN IF NOT EXISTS closing_entry_id UUID REFERENCES journal_entries(id) ON DELETE SET NULL;
+ALTER TABLE fiscal_years ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ;
+COMMENT ON COLUMN fiscal_years.status IS 'Valid statuses: OPEN, CLOSED';
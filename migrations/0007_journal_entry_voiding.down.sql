@@ -0,0 +1,7 @@
+-- Remove journal entry void links.
+DROP INDEX IF EXISTS idx_journal_entries_reversed_by_id;
+DROP INDEX IF EXISTS idx_journal_entries_reversal_of_id;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS voided_at;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS void_reason;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS reversed_by_id;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS reversal_of_id;
@@ -0,0 +1,9 @@
+-- Link voided journal entries and their reversing entries for the audit trail.
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS reversal_of_id UUID REFERENCES journal_entries(id);
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS reversed_by_id UUID REFERENCES journal_entries(id);
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS void_reason VARCHAR(255);
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS voided_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reversal_of_id ON journal_entries(reversal_of_id);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reversed_by_id ON journal_entries(reversed_by_id);
+COMMENT ON COLUMN journal_entries.reversed_by_id IS 'Set on VOIDED entries; the voided entry still counts in balances, cancelled by its reversing entry';
@@ -0,0 +1,3 @@
+-- Remove automatic reversal scheduling.
+DROP TABLE IF EXISTS scheduled_reversals;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS auto_reverse_on;
@@ -0,0 +1,19 @@
+-- Accruals that reverse themselves: the date is set on the entry, the schedule is created on posting.
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS auto_reverse_on DATE;
+
+CREATE TABLE IF NOT EXISTS scheduled_reversals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    journal_entry_id UUID NOT NULL UNIQUE REFERENCES journal_entries(id),
+    reverse_on DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, POSTED, CANCELLED
+    reversal_entry_id UUID REFERENCES journal_entries(id),
+    last_error VARCHAR(255),
+    processed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_reversals_reverse_on ON scheduled_reversals(reverse_on);
+CREATE INDEX IF NOT EXISTS idx_scheduled_reversals_status ON scheduled_reversals(status);
+CREATE INDEX IF NOT EXISTS idx_scheduled_reversals_deleted_at ON scheduled_reversals(deleted_at);
@@ -0,0 +1,4 @@
+-- Drop recurring journal tables
+DROP TABLE IF EXISTS recurring_journal_runs;
+DROP TABLE IF EXISTS recurring_journal_lines;
+DROP TABLE IF EXISTS recurring_journal_templates;
@@ -0,0 +1,53 @@
+-- Create Recurring Journal Templates Table
+CREATE TABLE IF NOT EXISTS recurring_journal_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255),
+    reference VARCHAR(100),
+    frequency VARCHAR(20) NOT NULL, -- MONTHLY, QUARTERLY, CRON
+    cron_expression VARCHAR(100),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    entry_status VARCHAR(20) NOT NULL DEFAULT 'DRAFT', -- DRAFT, POSTED
+    is_active BOOLEAN DEFAULT TRUE,
+    last_run_on DATE,
+    next_run_on DATE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT chk_recurring_journal_templates_dates CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+-- Names are unique among live templates only, so a deleted template's name can be reused
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recurring_journal_templates_name ON recurring_journal_templates(name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_recurring_journal_templates_next_run_on ON recurring_journal_templates(next_run_on);
+CREATE INDEX IF NOT EXISTS idx_recurring_journal_templates_deleted_at ON recurring_journal_templates(deleted_at);
+
+-- Create Recurring Journal Lines Table
+CREATE TABLE IF NOT EXISTS recurring_journal_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    template_id UUID NOT NULL REFERENCES recurring_journal_templates(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES chart_of_accounts(id),
+    amount NUMERIC(18, 4) NOT NULL,
+    currency VARCHAR(3) DEFAULT 'USD',
+    is_debit BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_journal_lines_template_id ON recurring_journal_lines(template_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_journal_lines_account_id ON recurring_journal_lines(account_id);
+
+-- Create Recurring Journal Runs Table; one row per occurrence so an occurrence never produces two entries
+CREATE TABLE IF NOT EXISTS recurring_journal_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    template_id UUID NOT NULL REFERENCES recurring_journal_templates(id),
+    run_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, CREATED, FAILED
+    journal_entry_id UUID REFERENCES journal_entries(id),
+    error VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recurring_journal_runs_occurrence ON recurring_journal_runs(template_id, run_date);
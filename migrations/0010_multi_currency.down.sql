@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_journal_lines_currency;
+ALTER TABLE journal_lines DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE journal_lines DROP COLUMN IF EXISTS transaction_amount;
+COMMENT ON COLUMN journal_lines.amount IS 'Exact amount stored as positive value; is_debit flag determines debit/credit nature.';
+
+DROP TABLE IF EXISTS exchange_rates;
+DROP TABLE IF EXISTS currencies;
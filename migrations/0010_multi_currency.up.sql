@@ -0,0 +1,33 @@
+-- Currency master; the functional currency (BASE_CURRENCY) is accepted even if it is not listed here
+CREATE TABLE IF NOT EXISTS currencies (
+    code VARCHAR(3) PRIMARY KEY, -- ISO 4217
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Dated exchange rates: one unit of from_currency is worth rate units of to_currency from rate_date on
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_currency VARCHAR(3) NOT NULL REFERENCES currencies(code),
+    to_currency VARCHAR(3) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate NUMERIC(18, 10) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_exchange_rates_rate CHECK (rate > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exchange_rates_pair_date ON exchange_rates(from_currency, to_currency, rate_date);
+
+-- journal_lines.amount becomes the functional-currency amount; existing lines were all entered in it
+ALTER TABLE journal_lines ADD COLUMN IF NOT EXISTS transaction_amount NUMERIC(18, 4);
+ALTER TABLE journal_lines ADD COLUMN IF NOT EXISTS exchange_rate NUMERIC(18, 10) NOT NULL DEFAULT 1;
+UPDATE journal_lines SET transaction_amount = amount WHERE transaction_amount IS NULL;
+ALTER TABLE journal_lines ALTER COLUMN transaction_amount SET NOT NULL;
+
+COMMENT ON COLUMN journal_lines.amount IS 'Functional-currency amount stored as positive value; is_debit flag determines debit/credit nature.';
+COMMENT ON COLUMN journal_lines.transaction_amount IS 'Amount in the line currency as entered.';
+
+CREATE INDEX IF NOT EXISTS idx_journal_lines_currency ON journal_lines(currency);
@@ -0,0 +1,6 @@
+ALTER TABLE recurring_journal_lines DROP COLUMN IF EXISTS dimensions;
+
+DROP TABLE IF EXISTS account_dimension_rules;
+DROP TABLE IF EXISTS journal_line_dimensions;
+DROP TABLE IF EXISTS dimension_values;
+DROP TABLE IF EXISTS dimensions;
@@ -0,0 +1,45 @@
+-- Analytical dimensions (cost center, department, project, ...) and their value lists
+CREATE TABLE IF NOT EXISTS dimensions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(30) NOT NULL UNIQUE, -- Never changes once created
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS dimension_values (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    dimension_id UUID NOT NULL REFERENCES dimensions(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    code VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dimension_values_code ON dimension_values(dimension_id, code);
+
+-- One value per dimension on a journal line; the codes are copied so reports need no joins
+CREATE TABLE IF NOT EXISTS journal_line_dimensions (
+    journal_line_id UUID NOT NULL REFERENCES journal_lines(id) ON DELETE CASCADE,
+    dimension_id UUID NOT NULL REFERENCES dimensions(id),
+    dimension_value_id UUID NOT NULL REFERENCES dimension_values(id),
+    dimension_code VARCHAR(30) NOT NULL,
+    value_code VARCHAR(30) NOT NULL,
+    PRIMARY KEY (journal_line_id, dimension_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_line_dimensions_dimension_value_id ON journal_line_dimensions(dimension_value_id);
+CREATE INDEX IF NOT EXISTS idx_journal_line_dimensions_codes ON journal_line_dimensions(dimension_code, value_code);
+
+-- Dimensions every journal line on an account must carry
+CREATE TABLE IF NOT EXISTS account_dimension_rules (
+    account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON DELETE CASCADE,
+    dimension_id UUID NOT NULL REFERENCES dimensions(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, dimension_id)
+);
+
+-- Recurring template lines keep their dimension codes until each entry is created
+ALTER TABLE recurring_journal_lines ADD COLUMN IF NOT EXISTS dimensions JSONB;
@@ -0,0 +1,29 @@
+-- Budget versions per fiscal year; approved versions are read-only and changed through a new version
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    fiscal_year_id UUID NOT NULL REFERENCES fiscal_years(id),
+    version INTEGER NOT NULL,
+    description VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT', -- DRAFT, APPROVED, SUPERSEDED
+    approved_by VARCHAR(100),
+    approved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_version ON budgets(name, fiscal_year_id, version);
+CREATE INDEX IF NOT EXISTS idx_budgets_status ON budgets(status);
+
+-- Amounts per account and fiscal period, optionally for one dimension value ('' when not)
+CREATE TABLE IF NOT EXISTS budget_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    budget_id UUID NOT NULL REFERENCES budgets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES chart_of_accounts(id),
+    fiscal_period_id UUID NOT NULL REFERENCES fiscal_periods(id),
+    dimension_code VARCHAR(30) NOT NULL DEFAULT '',
+    value_code VARCHAR(30) NOT NULL DEFAULT '',
+    amount NUMERIC(18, 4) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budget_lines_key ON budget_lines(budget_id, account_id, fiscal_period_id, dimension_code, value_code);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bank_statement_lines;
+DROP TABLE IF EXISTS bank_statements;
+DROP TABLE IF EXISTS bank_accounts;
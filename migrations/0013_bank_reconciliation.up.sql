@@ -0,0 +1,50 @@
+-- Bank accounts, each booked on one ASSET ledger account
+CREATE TABLE IF NOT EXISTS bank_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    account_number VARCHAR(50), -- IBAN or local account number, without spaces
+    currency VARCHAR(3) NOT NULL,
+    chart_of_account_id UUID NOT NULL REFERENCES chart_of_accounts(id),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_accounts_chart_of_account_id ON bank_accounts(chart_of_account_id);
+
+-- Imported statement files with the balances the bank reported, when the format carries them
+CREATE TABLE IF NOT EXISTS bank_statements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bank_account_id UUID NOT NULL REFERENCES bank_accounts(id),
+    format VARCHAR(10) NOT NULL, -- CSV, OFX, CAMT053
+    statement_ref VARCHAR(100),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    opening_balance NUMERIC(18, 4),
+    closing_balance NUMERIC(18, 4),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statements_bank_account_id ON bank_statements(bank_account_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statements_end_date ON bank_statements(end_date);
+
+-- Statement transactions (positive = received), each matched to at most one journal line
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    statement_id UUID NOT NULL REFERENCES bank_statements(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    bank_account_id UUID NOT NULL REFERENCES bank_accounts(id),
+    transaction_date DATE NOT NULL,
+    amount NUMERIC(18, 4) NOT NULL,
+    description VARCHAR(255),
+    reference VARCHAR(100),
+    external_id VARCHAR(100) NOT NULL, -- The bank's transaction ID, or a fingerprint of the line
+    journal_line_id UUID REFERENCES journal_lines(id),
+    match_method VARCHAR(10), -- AUTO, MANUAL
+    matched_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_statement_id ON bank_statement_lines(statement_id);
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_transaction_date ON bank_statement_lines(transaction_date);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_statement_lines_external_id ON bank_statement_lines(bank_account_id, external_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_statement_lines_journal_line_id ON bank_statement_lines(journal_line_id);
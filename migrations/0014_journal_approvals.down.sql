@@ -0,0 +1,6 @@
+-- Remove the journal entry approval workflow.
+DROP TABLE IF EXISTS journal_entry_approvals;
+COMMENT ON COLUMN journal_entries.status IS 'Valid statuses: DRAFT, POSTED, VOIDED';
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS approved_at;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS approved_by;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS created_by;
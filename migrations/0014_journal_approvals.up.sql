@@ -0,0 +1,18 @@
+-- Maker-checker approval: who created an entry and who approved it for posting.
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS created_by VARCHAR(100);
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS approved_by VARCHAR(100);
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS approved_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN journal_entries.status IS 'Valid statuses: DRAFT, PENDING_APPROVAL, REJECTED, POSTED, VOIDED';
+
+-- Submit, approve and reject steps of each entry, with the user and comment
+CREATE TABLE IF NOT EXISTS journal_entry_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    journal_entry_id UUID NOT NULL REFERENCES journal_entries(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL, -- SUBMITTED, APPROVED, REJECTED
+    user_id VARCHAR(100) NOT NULL,
+    comment VARCHAR(500),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entry_approvals_journal_entry_id ON journal_entry_approvals(journal_entry_id);
@@ -0,0 +1,5 @@
+-- Remove gap-free document numbering.
+DROP INDEX IF EXISTS idx_journal_entries_document_number;
+ALTER TABLE journal_entries DROP COLUMN IF EXISTS document_number;
+DROP TABLE IF EXISTS document_sequence_counters;
+DROP TABLE IF EXISTS document_sequences;
@@ -0,0 +1,30 @@
+-- Gap-free document numbering, e.g. GJ-2026-000123 for the 123rd general journal entry of FY2026.
+CREATE TABLE IF NOT EXISTS document_sequences (
+    document_type VARCHAR(50) PRIMARY KEY, -- e.g. JOURNAL_STANDARD
+    prefix VARCHAR(20) NOT NULL,
+    padding INTEGER NOT NULL DEFAULT 6,
+    reset_yearly BOOLEAN NOT NULL DEFAULT TRUE, -- Restart at 1 in every fiscal year
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Last number issued per document type and fiscal year (0 for sequences that do not reset).
+-- The row is locked by the transaction that takes a number until it commits or rolls back.
+CREATE TABLE IF NOT EXISTS document_sequence_counters (
+    document_type VARCHAR(50) NOT NULL,
+    year INTEGER NOT NULL,
+    last_number BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (document_type, year)
+);
+
+INSERT INTO document_sequences (document_type, prefix) VALUES
+    ('JOURNAL_STANDARD', 'GJ'),
+    ('JOURNAL_CLOSING', 'CJ'),
+    ('JOURNAL_REVERSAL', 'RJ'),
+    ('JOURNAL_REVALUATION', 'FX')
+ON CONFLICT (document_type) DO NOTHING;
+
+-- Numbers are given on posting; entries posted earlier keep none.
+ALTER TABLE journal_entries ADD COLUMN IF NOT EXISTS document_number VARCHAR(50);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_document_number ON journal_entries(document_number) WHERE document_number <> '';
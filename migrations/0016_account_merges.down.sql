@@ -0,0 +1,2 @@
+-- Remove the account merge audit log. Merged accounts stay merged.
+DROP TABLE IF EXISTS account_merges;
@@ -0,0 +1,19 @@
+-- Audit log of account merges: a duplicate source account's journal lines, child accounts,
+-- recurring template lines and bank account link moved to a target account, and the source deactivated.
+CREATE TABLE IF NOT EXISTS account_merges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    source_account_code VARCHAR(20) NOT NULL,
+    target_account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    target_account_code VARCHAR(20) NOT NULL,
+    journal_lines_moved BIGINT NOT NULL DEFAULT 0,
+    child_accounts_moved BIGINT NOT NULL DEFAULT 0,
+    template_lines_moved BIGINT NOT NULL DEFAULT 0,
+    bank_accounts_moved BIGINT NOT NULL DEFAULT 0,
+    reason VARCHAR(500),
+    merged_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_merges_source_account_id ON account_merges(source_account_id);
+CREATE INDEX IF NOT EXISTS idx_account_merges_target_account_id ON account_merges(target_account_id);
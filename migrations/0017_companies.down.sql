@@ -0,0 +1,40 @@
+-- Remove companies. This only works while a single company has books: codes become globally
+-- unique again and the company of every record is dropped.
+ALTER TABLE document_sequence_counters DROP CONSTRAINT IF EXISTS document_sequence_counters_pkey;
+ALTER TABLE document_sequence_counters ADD PRIMARY KEY (document_type, year);
+ALTER TABLE document_sequences DROP CONSTRAINT IF EXISTS document_sequences_pkey;
+ALTER TABLE document_sequences ADD PRIMARY KEY (document_type);
+DROP INDEX IF EXISTS idx_journal_entries_document_number;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_document_number ON journal_entries(document_number) WHERE document_number <> '';
+
+DROP INDEX IF EXISTS idx_recurring_journal_templates_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recurring_journal_templates_name ON recurring_journal_templates(name) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_dimensions_company_code;
+ALTER TABLE dimensions ADD CONSTRAINT dimensions_code_key UNIQUE (code);
+DROP INDEX IF EXISTS idx_fiscal_years_company_name;
+ALTER TABLE fiscal_years ADD CONSTRAINT fiscal_years_name_key UNIQUE (name);
+DROP INDEX IF EXISTS idx_warehouses_company_code;
+ALTER TABLE warehouses ADD CONSTRAINT warehouses_code_key UNIQUE (code);
+DROP INDEX IF EXISTS idx_items_company_sku;
+ALTER TABLE items ADD CONSTRAINT items_sku_key UNIQUE (sku);
+DROP INDEX IF EXISTS idx_chart_of_accounts_company_code;
+ALTER TABLE chart_of_accounts ADD CONSTRAINT chart_of_accounts_account_code_key UNIQUE (account_code);
+
+DO $$
+DECLARE
+    scoped_table TEXT;
+BEGIN
+    FOREACH scoped_table IN ARRAY ARRAY[
+        'chart_of_accounts', 'journal_entries', 'journal_lines', 'journal_entry_approvals',
+        'fiscal_years', 'fiscal_periods', 'scheduled_reversals',
+        'recurring_journal_templates', 'recurring_journal_lines', 'recurring_journal_runs',
+        'dimensions', 'dimension_values', 'journal_line_dimensions', 'account_dimension_rules',
+        'budgets', 'budget_lines', 'bank_accounts', 'bank_statements', 'bank_statement_lines',
+        'document_sequences', 'document_sequence_counters', 'account_merges',
+        'items', 'warehouses', 'inventory_transactions'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS company_id', scoped_table);
+    END LOOP;
+END $$;
+
+DROP TABLE IF EXISTS companies;
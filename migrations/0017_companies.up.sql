@@ -0,0 +1,58 @@
+-- Legal entities. Every accounting and inventory record belongs to one company; currencies and
+-- exchange rates stay shared.
+CREATE TABLE IF NOT EXISTS companies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(20) NOT NULL UNIQUE, -- Sent in the X-Company-ID header, e.g. EU01
+    name VARCHAR(255) NOT NULL,
+    base_currency VARCHAR(3) NOT NULL, -- Functional currency; fixed once created
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Existing books become those of the default company; rename it and set its currency as needed.
+INSERT INTO companies (id, code, name, base_currency) VALUES
+    ('00000000-0000-0000-0000-000000000001', 'DEFAULT', 'Default Company', 'USD')
+ON CONFLICT (code) DO NOTHING;
+
+DO $$
+DECLARE
+    scoped_table TEXT;
+BEGIN
+    FOREACH scoped_table IN ARRAY ARRAY[
+        'chart_of_accounts', 'journal_entries', 'journal_lines', 'journal_entry_approvals',
+        'fiscal_years', 'fiscal_periods', 'scheduled_reversals',
+        'recurring_journal_templates', 'recurring_journal_lines', 'recurring_journal_runs',
+        'dimensions', 'dimension_values', 'journal_line_dimensions', 'account_dimension_rules',
+        'budgets', 'budget_lines', 'bank_accounts', 'bank_statements', 'bank_statement_lines',
+        'document_sequences', 'document_sequence_counters', 'account_merges',
+        'items', 'warehouses', 'inventory_transactions'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS company_id UUID REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT', scoped_table);
+        EXECUTE format('UPDATE %I SET company_id = %L WHERE company_id IS NULL', scoped_table, '00000000-0000-0000-0000-000000000001');
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN company_id SET NOT NULL', scoped_table);
+        EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I(company_id)', 'idx_' || scoped_table || '_company_id', scoped_table);
+    END LOOP;
+END $$;
+
+-- Codes are unique within a company instead of globally
+ALTER TABLE chart_of_accounts DROP CONSTRAINT IF EXISTS chart_of_accounts_account_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chart_of_accounts_company_code ON chart_of_accounts(company_id, account_code);
+ALTER TABLE items DROP CONSTRAINT IF EXISTS items_sku_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_company_sku ON items(company_id, sku);
+ALTER TABLE warehouses DROP CONSTRAINT IF EXISTS warehouses_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_company_code ON warehouses(company_id, code);
+ALTER TABLE fiscal_years DROP CONSTRAINT IF EXISTS fiscal_years_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fiscal_years_company_name ON fiscal_years(company_id, name);
+ALTER TABLE dimensions DROP CONSTRAINT IF EXISTS dimensions_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dimensions_company_code ON dimensions(company_id, code);
+DROP INDEX IF EXISTS idx_recurring_journal_templates_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recurring_journal_templates_name ON recurring_journal_templates(company_id, name) WHERE deleted_at IS NULL;
+
+-- Each company numbers its documents on its own
+DROP INDEX IF EXISTS idx_journal_entries_document_number;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_document_number ON journal_entries(company_id, document_number) WHERE document_number <> '';
+ALTER TABLE document_sequences DROP CONSTRAINT IF EXISTS document_sequences_pkey;
+ALTER TABLE document_sequences ADD PRIMARY KEY (company_id, document_type);
+ALTER TABLE document_sequence_counters DROP CONSTRAINT IF EXISTS document_sequence_counters_pkey;
+ALTER TABLE document_sequence_counters ADD PRIMARY KEY (company_id, document_type, year);
@@ -0,0 +1,4 @@
+-- Remove the group chart of accounts, account mappings and elimination rules.
+DROP TABLE IF EXISTS elimination_rules;
+DROP TABLE IF EXISTS group_account_mappings;
+DROP TABLE IF EXISTS group_accounts;
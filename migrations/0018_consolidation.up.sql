@@ -0,0 +1,37 @@
+-- Group chart of accounts shared by all companies; each company's accounts map to it for consolidation.
+CREATE TABLE IF NOT EXISTS group_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    account_type VARCHAR(50) NOT NULL, -- ASSET, LIABILITY, EQUITY, REVENUE, EXPENSE
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Accounts without a mapping consolidate into the group account with the same code.
+CREATE TABLE IF NOT EXISTS group_account_mappings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    group_account_id UUID NOT NULL REFERENCES group_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_group_account_mappings_account UNIQUE (company_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_account_mappings_group_account_id ON group_account_mappings(group_account_id);
+
+-- Pairs of mirroring intercompany group accounts cleared on consolidation; what does not cancel
+-- out goes to the difference account.
+CREATE TABLE IF NOT EXISTS elimination_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    group_account_id UUID NOT NULL REFERENCES group_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    counter_group_account_id UUID NOT NULL REFERENCES group_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    difference_group_account_id UUID NOT NULL REFERENCES group_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (group_account_id <> counter_group_account_id)
+);
@@ -0,0 +1,8 @@
+-- Remove tax codes, their rates and the tax columns of journal lines.
+DROP INDEX IF EXISTS idx_journal_lines_tax_code_id;
+ALTER TABLE journal_lines DROP COLUMN IF EXISTS tax_line_type;
+ALTER TABLE journal_lines DROP COLUMN IF EXISTS tax_code;
+ALTER TABLE journal_lines DROP COLUMN IF EXISTS tax_code_id;
+
+DROP TABLE IF EXISTS tax_rates;
+DROP TABLE IF EXISTS tax_codes;
@@ -0,0 +1,41 @@
+-- Tax codes tag journal lines with a VAT/GST treatment; the tax lines are posted to their accounts.
+CREATE TABLE IF NOT EXISTS tax_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    tax_type VARCHAR(10) NOT NULL, -- OUTPUT, INPUT
+    tax_account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    is_reverse_charge BOOLEAN NOT NULL DEFAULT FALSE,
+    reverse_charge_account_id UUID REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    base_box VARCHAR(20),
+    tax_box VARCHAR(20),
+    reverse_charge_box VARCHAR(20),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_tax_codes_company_code UNIQUE (company_id, code),
+    CHECK (tax_type IN ('OUTPUT', 'INPUT')),
+    CHECK (is_reverse_charge = (reverse_charge_account_id IS NOT NULL))
+);
+
+-- A rate applies from effective_from until the code's next rate.
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    tax_code_id UUID NOT NULL REFERENCES tax_codes(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    effective_from DATE NOT NULL,
+    rate NUMERIC(18, 10) NOT NULL, -- A fraction of the base, e.g. 0.2 for 20%
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_tax_rates_code_date UNIQUE (tax_code_id, effective_from),
+    CHECK (rate >= 0 AND rate < 1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tax_rates_company_id ON tax_rates(company_id);
+
+-- Taxed lines and the tax lines generated for them carry the code; tax_line_type tells them apart.
+ALTER TABLE journal_lines ADD COLUMN IF NOT EXISTS tax_code_id UUID REFERENCES tax_codes(id) ON UPDATE CASCADE ON DELETE RESTRICT;
+ALTER TABLE journal_lines ADD COLUMN IF NOT EXISTS tax_code VARCHAR(20);
+ALTER TABLE journal_lines ADD COLUMN IF NOT EXISTS tax_line_type VARCHAR(20); -- BASE, TAX, REVERSE_CHARGE
+
+CREATE INDEX IF NOT EXISTS idx_journal_lines_tax_code_id ON journal_lines(tax_code_id);
@@ -0,0 +1,6 @@
+-- Remove the accounts receivable subledger.
+DROP TABLE IF EXISTS receivable_allocations;
+DROP TABLE IF EXISTS customer_receipts;
+DROP TABLE IF EXISTS sales_invoice_lines;
+DROP TABLE IF EXISTS sales_invoices;
+DROP TABLE IF EXISTS customers;
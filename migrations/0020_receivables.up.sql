@@ -0,0 +1,112 @@
+-- Accounts receivable subledger: customers, their invoices and credit notes, receipts, and the
+-- allocations that apply receipts and credit notes to invoices. Documents post to the general
+-- ledger through journal entries of type SUBLEDGER.
+CREATE TABLE IF NOT EXISTS customers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    tax_id VARCHAR(50),
+    email VARCHAR(100),
+    payment_terms_days INTEGER NOT NULL, -- Days from the invoice date to the due date
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_customers_company_code UNIQUE (company_id, code),
+    CHECK (payment_terms_days >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS sales_invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    invoice_type VARCHAR(20) NOT NULL, -- INVOICE, CREDIT_NOTE
+    status VARCHAR(20) NOT NULL, -- DRAFT, POSTED
+    invoice_number VARCHAR(50), -- Given on posting, e.g. INV-2026-000042
+    invoice_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    reference VARCHAR(100),
+    description VARCHAR(255),
+    currency VARCHAR(3) NOT NULL, -- The company's functional currency
+    net_amount NUMERIC(18, 4) NOT NULL,
+    tax_amount NUMERIC(18, 4) NOT NULL,
+    total_amount NUMERIC(18, 4) NOT NULL,
+    allocated_amount NUMERIC(18, 4) NOT NULL DEFAULT 0,
+    journal_entry_id UUID REFERENCES journal_entries(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    posted_at TIMESTAMPTZ,
+    created_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (invoice_type IN ('INVOICE', 'CREDIT_NOTE')),
+    CHECK (status IN ('DRAFT', 'POSTED')),
+    CHECK (due_date >= invoice_date),
+    CHECK (allocated_amount >= 0 AND allocated_amount <= total_amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sales_invoices_company_id ON sales_invoices(company_id);
+CREATE INDEX IF NOT EXISTS idx_sales_invoices_customer_id ON sales_invoices(customer_id);
+CREATE INDEX IF NOT EXISTS idx_sales_invoices_status ON sales_invoices(status);
+CREATE INDEX IF NOT EXISTS idx_sales_invoices_journal_entry_id ON sales_invoices(journal_entry_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sales_invoices_number ON sales_invoices(company_id, invoice_number) WHERE invoice_number <> '';
+
+CREATE TABLE IF NOT EXISTS sales_invoice_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    invoice_id UUID NOT NULL REFERENCES sales_invoices(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    line_number INTEGER NOT NULL,
+    description VARCHAR(255),
+    account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT, -- A REVENUE account
+    amount NUMERIC(18, 4) NOT NULL, -- Net of tax
+    tax_code VARCHAR(20),
+    tax_amount NUMERIC(18, 4) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sales_invoice_lines_company_id ON sales_invoice_lines(company_id);
+CREATE INDEX IF NOT EXISTS idx_sales_invoice_lines_invoice_id ON sales_invoice_lines(invoice_id);
+
+CREATE TABLE IF NOT EXISTS customer_receipts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    receipt_number VARCHAR(50) NOT NULL, -- e.g. RCT-2026-000007
+    receipt_date DATE NOT NULL,
+    deposit_account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    amount NUMERIC(18, 4) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    allocated_amount NUMERIC(18, 4) NOT NULL DEFAULT 0,
+    reference VARCHAR(100),
+    journal_entry_id UUID REFERENCES journal_entries(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    created_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_customer_receipts_number UNIQUE (company_id, receipt_number),
+    CHECK (amount > 0),
+    CHECK (allocated_amount >= 0 AND allocated_amount <= amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_customer_receipts_customer_id ON customer_receipts(customer_id);
+CREATE INDEX IF NOT EXISTS idx_customer_receipts_journal_entry_id ON customer_receipts(journal_entry_id);
+
+-- Applies part of a receipt or credit note to an invoice; allocations post nothing to the ledger.
+CREATE TABLE IF NOT EXISTS receivable_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    customer_id UUID NOT NULL REFERENCES customers(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    invoice_id UUID NOT NULL REFERENCES sales_invoices(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    receipt_id UUID REFERENCES customer_receipts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    credit_note_id UUID REFERENCES sales_invoices(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    amount NUMERIC(18, 4) NOT NULL,
+    allocation_date DATE NOT NULL, -- The later of the two documents' dates
+    created_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (amount > 0),
+    CHECK ((receipt_id IS NULL) <> (credit_note_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_receivable_allocations_company_id ON receivable_allocations(company_id);
+CREATE INDEX IF NOT EXISTS idx_receivable_allocations_customer_id ON receivable_allocations(customer_id);
+CREATE INDEX IF NOT EXISTS idx_receivable_allocations_invoice_id ON receivable_allocations(invoice_id);
+CREATE INDEX IF NOT EXISTS idx_receivable_allocations_receipt_id ON receivable_allocations(receipt_id);
+CREATE INDEX IF NOT EXISTS idx_receivable_allocations_credit_note_id ON receivable_allocations(credit_note_id);
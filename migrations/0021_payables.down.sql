@@ -0,0 +1,6 @@
+-- Remove the accounts payable subledger.
+DROP TABLE IF EXISTS payable_allocations;
+DROP TABLE IF EXISTS vendor_payments;
+DROP TABLE IF EXISTS purchase_bill_lines;
+DROP TABLE IF EXISTS purchase_bills;
+DROP TABLE IF EXISTS vendors;
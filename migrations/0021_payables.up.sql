@@ -0,0 +1,121 @@
+-- Accounts payable subledger: vendors, their bills, the payments made to them, and the allocations
+-- that apply payments to bills, with the early-payment discounts taken. Documents post to the
+-- general ledger through journal entries of type SUBLEDGER.
+CREATE TABLE IF NOT EXISTS vendors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    tax_id VARCHAR(50),
+    email VARCHAR(100),
+    payment_terms_days INTEGER NOT NULL, -- Days from the bill date to the due date
+    discount_rate NUMERIC(18, 10) NOT NULL DEFAULT 0, -- Early-payment discount, e.g. 0.02 for "2/10 net 30"
+    discount_days INTEGER NOT NULL DEFAULT 0, -- Days from the bill date the discount is offered
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_vendors_company_code UNIQUE (company_id, code),
+    CHECK (payment_terms_days >= 0),
+    CHECK (discount_rate >= 0 AND discount_rate < 1),
+    CHECK (discount_days >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS purchase_bills (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    vendor_id UUID NOT NULL REFERENCES vendors(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL, -- DRAFT, POSTED
+    bill_number VARCHAR(50), -- Given on posting, e.g. BILL-2026-000042
+    vendor_invoice_number VARCHAR(100) NOT NULL, -- The vendor's own number for the invoice
+    bill_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    discount_rate NUMERIC(18, 10) NOT NULL DEFAULT 0,
+    discount_date DATE, -- Last day the discount can be taken; set only with a discount
+    description VARCHAR(255),
+    currency VARCHAR(3) NOT NULL, -- The company's functional currency
+    net_amount NUMERIC(18, 4) NOT NULL,
+    tax_amount NUMERIC(18, 4) NOT NULL,
+    total_amount NUMERIC(18, 4) NOT NULL,
+    paid_amount NUMERIC(18, 4) NOT NULL DEFAULT 0, -- Settled by payments, including discounts taken
+    journal_entry_id UUID REFERENCES journal_entries(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    posted_at TIMESTAMPTZ,
+    created_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_purchase_bills_vendor_invoice UNIQUE (company_id, vendor_id, vendor_invoice_number),
+    CHECK (status IN ('DRAFT', 'POSTED')),
+    CHECK (due_date >= bill_date),
+    CHECK (discount_rate >= 0 AND discount_rate < 1),
+    CHECK (discount_date IS NULL OR discount_date BETWEEN bill_date AND due_date),
+    CHECK (paid_amount >= 0 AND paid_amount <= total_amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_bills_company_id ON purchase_bills(company_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_bills_vendor_id ON purchase_bills(vendor_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_bills_status ON purchase_bills(status);
+CREATE INDEX IF NOT EXISTS idx_purchase_bills_journal_entry_id ON purchase_bills(journal_entry_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_purchase_bills_number ON purchase_bills(company_id, bill_number) WHERE bill_number <> '';
+
+CREATE TABLE IF NOT EXISTS purchase_bill_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    bill_id UUID NOT NULL REFERENCES purchase_bills(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    line_number INTEGER NOT NULL,
+    description VARCHAR(255),
+    account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT, -- An EXPENSE or ASSET account
+    amount NUMERIC(18, 4) NOT NULL, -- Net of tax
+    tax_code VARCHAR(20),
+    tax_amount NUMERIC(18, 4) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_bill_lines_company_id ON purchase_bill_lines(company_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_bill_lines_bill_id ON purchase_bill_lines(bill_id);
+
+CREATE TABLE IF NOT EXISTS vendor_payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    vendor_id UUID NOT NULL REFERENCES vendors(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    payment_number VARCHAR(50) NOT NULL, -- e.g. PAY-2026-000007
+    payment_date DATE NOT NULL,
+    bank_account_id UUID NOT NULL REFERENCES chart_of_accounts(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    amount NUMERIC(18, 4) NOT NULL, -- Paid out of the bank
+    currency VARCHAR(3) NOT NULL,
+    allocated_amount NUMERIC(18, 4) NOT NULL DEFAULT 0,
+    discount_amount NUMERIC(18, 4) NOT NULL DEFAULT 0, -- Early-payment discounts taken with the payment
+    reference VARCHAR(100),
+    journal_entry_id UUID REFERENCES journal_entries(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    created_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_vendor_payments_number UNIQUE (company_id, payment_number),
+    CHECK (amount > 0),
+    CHECK (allocated_amount >= 0 AND allocated_amount <= amount),
+    CHECK (discount_amount >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendor_payments_vendor_id ON vendor_payments(vendor_id);
+CREATE INDEX IF NOT EXISTS idx_vendor_payments_journal_entry_id ON vendor_payments(journal_entry_id);
+
+-- Applies part of a payment to a bill; the bill is settled by the amount plus the discount taken.
+-- Allocations post nothing to the ledger.
+CREATE TABLE IF NOT EXISTS payable_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    vendor_id UUID NOT NULL REFERENCES vendors(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    bill_id UUID NOT NULL REFERENCES purchase_bills(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    payment_id UUID NOT NULL REFERENCES vendor_payments(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    amount NUMERIC(18, 4) NOT NULL,
+    discount_amount NUMERIC(18, 4) NOT NULL DEFAULT 0,
+    allocation_date DATE NOT NULL, -- The later of the two documents' dates
+    created_by VARCHAR(100),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (amount > 0),
+    CHECK (discount_amount >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payable_allocations_company_id ON payable_allocations(company_id);
+CREATE INDEX IF NOT EXISTS idx_payable_allocations_vendor_id ON payable_allocations(vendor_id);
+CREATE INDEX IF NOT EXISTS idx_payable_allocations_bill_id ON payable_allocations(bill_id);
+CREATE INDEX IF NOT EXISTS idx_payable_allocations_payment_id ON payable_allocations(payment_id);
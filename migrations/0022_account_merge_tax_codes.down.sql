@@ -0,0 +1,2 @@
+-- Remove the tax code count of account merges.
+ALTER TABLE account_merges DROP COLUMN IF EXISTS tax_codes_moved;
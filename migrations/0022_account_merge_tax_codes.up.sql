@@ -0,0 +1,2 @@
+-- Account merges repoint the tax and reverse-charge accounts of tax codes to the target as well.
+ALTER TABLE account_merges ADD COLUMN IF NOT EXISTS tax_codes_moved BIGINT NOT NULL DEFAULT 0;
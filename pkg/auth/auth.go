@@ -0,0 +1,56 @@
+// Package auth carries the authenticated principal through request contexts so that
+// services can make role-based decisions without depending on the HTTP layer.
+package auth
+
+import "context"
+
+// Well-known roles.
+const (
+	RoleAdmin             = "ADMIN"
+	RoleAccountingManager = "ACCOUNTING_MANAGER"
+	RoleAccountant        = "ACCOUNTANT"
+)
+
+// Principal identifies the user on whose behalf a request is made.
+type Principal struct {
+	UserID string
+	Roles  []string
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx that carries p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// HasRole reports whether the principal has the given role.
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyRole reports whether the principal in ctx has at least one of the given roles.
+// It returns false when ctx carries no principal.
+func HasAnyRole(ctx context.Context, roles ...string) bool {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, role := range roles {
+		if p.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
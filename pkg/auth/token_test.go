@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndParseToken(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	token, err := SignToken(secret, Principal{UserID: "u-1", Roles: []string{RoleAccountingManager}}, now.Add(time.Hour))
+	require.NoError(t, err)
+
+	p, err := ParseToken(secret, token, now)
+	require.NoError(t, err)
+	assert.Equal(t, "u-1", p.UserID)
+	assert.True(t, p.HasRole(RoleAccountingManager))
+
+	_, err = ParseToken(secret, token, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidToken, "expired")
+
+	_, err = ParseToken([]byte("other-secret"), token, now)
+	assert.ErrorIs(t, err, ErrInvalidToken, "wrong secret")
+
+	parts := strings.Split(token, ".")
+	forged, err := SignToken([]byte("attacker"), Principal{UserID: "u-1", Roles: []string{RoleAdmin}}, now.Add(time.Hour))
+	require.NoError(t, err)
+	_, err = ParseToken(secret, parts[0]+"."+strings.Split(forged, ".")[1]+"."+parts[2], now)
+	assert.ErrorIs(t, err, ErrInvalidToken, "claims swapped under the original signature")
+
+	_, err = ParseToken(nil, token, now)
+	assert.ErrorIs(t, err, ErrInvalidToken, "no secret configured")
+
+	_, err = ParseToken(secret, "test_token", now)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	noExpiry := encodeSegment([]byte(tokenHeader)) + "." + encodeSegment([]byte(`{"sub":"u-1","roles":["ADMIN"]}`))
+	_, err = ParseToken(secret, noExpiry+"."+encodeSegment(sign(secret, noExpiry)), now)
+	assert.ErrorIs(t, err, ErrInvalidToken, "signed token without exp")
+}
@@ -0,0 +1,45 @@
+// Package company carries the active company (legal entity) through request contexts. Every
+// accounting, inventory, sales and procurement record belongs to one company, and the database
+// layer reads the active company from the context to scope its queries (see
+// database.RegisterCompanyScope).
+package company
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrNoCompany is returned when company-scoped data is accessed without an active company.
+var ErrNoCompany = errors.New("company: no active company in context")
+
+// Company identifies the legal entity on whose books a request works.
+type Company struct {
+	ID   uuid.UUID
+	Code string
+	// BaseCurrency is the company's functional currency (ISO 4217), empty if not known.
+	BaseCurrency string
+}
+
+type companyKey struct{}
+
+// WithCompany returns a copy of ctx in which c is the active company.
+func WithCompany(ctx context.Context, c Company) context.Context {
+	return context.WithValue(ctx, companyKey{}, c)
+}
+
+// FromContext returns the active company stored in ctx, if any.
+func FromContext(ctx context.Context) (Company, bool) {
+	c, ok := ctx.Value(companyKey{}).(Company)
+	return c, ok && c.ID != uuid.Nil
+}
+
+// IDFromContext returns the ID of the active company in ctx, or ErrNoCompany.
+func IDFromContext(ctx context.Context) (uuid.UUID, error) {
+	c, ok := FromContext(ctx)
+	if !ok {
+		return uuid.Nil, ErrNoCompany
+	}
+	return c.ID, nil
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"erp-system/pkg/company"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// companyField is the field of the models that belong to a company.
+const companyField = "CompanyID"
+
+// RegisterCompanyScope makes every statement on a model with a CompanyID field work on the active
+// company of the statement's context (see package company): queries, updates and deletes are
+// filtered by company_id, and created records are given the active company. Statements on such
+// models fail with company.ErrNoCompany when the context has no active company. Raw SQL is not
+// rewritten and has to filter by company itself.
+func RegisterCompanyScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("company:assign", assignCompany); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("company:scope", scopeToCompany); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("company:scope", scopeToCompany); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("company:scope", scopeUpdateToCompany); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("company:scope", scopeDeleteToCompany)
+}
+
+// activeCompany returns the company field of the statement's model and the active company ID.
+// The field is nil for statements that are not company scoped.
+func activeCompany(db *gorm.DB) (*schema.Field, uuid.UUID, bool) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.SQL.Len() > 0 {
+		return nil, uuid.Nil, false
+	}
+	field := db.Statement.Schema.LookUpField(companyField)
+	if field == nil {
+		return nil, uuid.Nil, false
+	}
+	companyID, err := company.IDFromContext(db.Statement.Context)
+	if err != nil {
+		db.AddError(fmt.Errorf("%w: %s", err, db.Statement.Schema.Table))
+		return nil, uuid.Nil, false
+	}
+	return field, companyID, true
+}
+
+func scopeToCompany(db *gorm.DB) {
+	if field, companyID, ok := activeCompany(db); ok {
+		addCompanyCondition(db, field, companyID)
+	}
+}
+
+// scopeUpdateToCompany also fills in the company of the model being saved, so that Save of a
+// record built without one keeps it in the active company.
+func scopeUpdateToCompany(db *gorm.DB) {
+	field, companyID, ok := activeCompany(db)
+	if !ok {
+		return
+	}
+	if err := setCompany(db, field, companyID); err != nil {
+		db.AddError(err)
+		return
+	}
+	if hasConditions(db) {
+		addCompanyCondition(db, field, companyID)
+	}
+}
+
+func scopeDeleteToCompany(db *gorm.DB) {
+	if field, companyID, ok := activeCompany(db); ok && hasConditions(db) {
+		addCompanyCondition(db, field, companyID)
+	}
+}
+
+// addCompanyCondition ANDs company_id = companyID to the statement's conditions. These are grouped
+// first, so that an OR among them cannot reach records of other companies.
+func addCompanyCondition(db *gorm.DB, field *schema.Field, companyID uuid.UUID) {
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 1 {
+			c.Expression = clause.Where{Exprs: []clause.Expression{clause.And(where.Exprs...)}}
+			db.Statement.Clauses["WHERE"] = c
+		}
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: companyID},
+	}})
+}
+
+// hasConditions reports whether an update or delete is limited by conditions or by the primary key
+// of its model. Without either, the company condition is left out, so that GORM still rejects the
+// statement with ErrMissingWhereClause instead of it changing every record of the company.
+func hasConditions(db *gorm.DB) bool {
+	if db.AllowGlobalUpdate {
+		return true
+	}
+	if where, ok := db.Statement.Clauses["WHERE"].Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+		return true
+	}
+	_, primaryKeys := schema.GetIdentityFieldValuesMap(db.Statement.Context, db.Statement.ReflectValue, db.Statement.Schema.PrimaryFields)
+	return len(primaryKeys) > 0
+}
+
+func assignCompany(db *gorm.DB) {
+	field, companyID, ok := activeCompany(db)
+	if !ok {
+		return
+	}
+	if err := setCompany(db, field, companyID); err != nil {
+		db.AddError(err)
+	}
+}
+
+// setCompany gives the records of the statement without a company the active one. A record of
+// another company is an error: it cannot be written on the active company's books.
+func setCompany(db *gorm.DB, field *schema.Field, companyID uuid.UUID) error {
+	ctx := db.Statement.Context
+	set := func(record reflect.Value) error {
+		current, isZero := field.ValueOf(ctx, record)
+		if isZero {
+			return field.Set(ctx, record, companyID)
+		}
+		if current != companyID {
+			return fmt.Errorf("%s record belongs to company %v, not the active company %s", db.Statement.Schema.Table, current, companyID)
+		}
+		return nil
+	}
+
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			record := reflect.Indirect(value.Index(i))
+			if record.Kind() != reflect.Struct {
+				continue
+			}
+			if err := set(record); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		return set(value)
+	case reflect.Map:
+		if values, ok := db.Statement.Dest.(map[string]interface{}); ok {
+			if _, given := values[field.DBName]; !given {
+				values[field.DBName] = companyID
+			}
+		}
+	}
+	return nil
+}
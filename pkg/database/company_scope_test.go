@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"erp-system/pkg/company"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type scopedRecord struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+	CompanyID uuid.UUID `gorm:"type:uuid;not null"`
+	Code      string
+	DeletedAt gorm.DeletedAt
+}
+
+type sharedRecord struct {
+	ID   uuid.UUID `gorm:"type:uuid;primary_key"`
+	Code string
+}
+
+// dryRunDB returns a database that only builds statements, which never connects to a server.
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost dbname=none"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	require.NoError(t, RegisterCompanyScope(db))
+	return db
+}
+
+func TestRegisterCompanyScope(t *testing.T) {
+	db := dryRunDB(t)
+	companyID := uuid.New()
+	ctx := company.WithCompany(context.Background(), company.Company{ID: companyID, Code: "EU01"})
+
+	t.Run("Query Is Filtered With OR Conditions Grouped", func(t *testing.T) {
+		var records []scopedRecord
+		stmt := db.WithContext(ctx).Where("code = ?", "A").Or("code = ?", "B").Find(&records).Statement
+		assert.Equal(t, `SELECT * FROM "scoped_records" WHERE (code = $1 OR code = $2) AND "scoped_records"."company_id" = $3 AND "scoped_records"."deleted_at" IS NULL`, stmt.SQL.String())
+		assert.Equal(t, companyID, stmt.Vars[2])
+	})
+
+	t.Run("Subquery Is Filtered", func(t *testing.T) {
+		var count int64
+		sub := db.WithContext(ctx).Model(&scopedRecord{}).Select("id").Where("code = ?", "A")
+		stmt := db.WithContext(ctx).Model(&scopedRecord{}).Where("id IN (?)", sub).Count(&count).Statement
+		assert.Contains(t, stmt.SQL.String(), `(SELECT "id" FROM "scoped_records" WHERE code = $1 AND "scoped_records"."company_id" = $2`)
+	})
+
+	t.Run("Create Assigns The Active Company", func(t *testing.T) {
+		records := []*scopedRecord{{ID: uuid.New()}, {ID: uuid.New(), CompanyID: companyID}}
+		require.NoError(t, db.WithContext(ctx).Create(&records).Error)
+		assert.Equal(t, companyID, records[0].CompanyID)
+
+		other := &scopedRecord{ID: uuid.New(), CompanyID: uuid.New()}
+		assert.ErrorContains(t, db.WithContext(ctx).Create(other).Error, "not the active company")
+	})
+
+	t.Run("Save Keeps The Record In The Active Company", func(t *testing.T) {
+		record := &scopedRecord{ID: uuid.New(), Code: "A"}
+		stmt := db.WithContext(ctx).Save(record).Statement
+		assert.Equal(t, companyID, record.CompanyID)
+		assert.Contains(t, stmt.SQL.String(), `WHERE "scoped_records"."company_id" = $4 AND "scoped_records"."deleted_at" IS NULL AND "id" = $5`)
+	})
+
+	t.Run("Delete Is Filtered", func(t *testing.T) {
+		stmt := db.WithContext(ctx).Delete(&scopedRecord{}, "code = ?", "A").Statement
+		assert.Contains(t, stmt.SQL.String(), `WHERE code = $2 AND "scoped_records"."company_id" = $3`)
+	})
+
+	t.Run("Update Without Conditions Is Still Rejected", func(t *testing.T) {
+		err := db.WithContext(ctx).Model(&scopedRecord{}).Update("code", "A").Error
+		assert.ErrorIs(t, err, gorm.ErrMissingWhereClause)
+	})
+
+	t.Run("No Active Company", func(t *testing.T) {
+		var records []scopedRecord
+		assert.ErrorIs(t, db.WithContext(context.Background()).Find(&records).Error, company.ErrNoCompany)
+		assert.ErrorIs(t, db.Create(&scopedRecord{ID: uuid.New()}).Error, company.ErrNoCompany)
+	})
+
+	t.Run("Models Without A Company Are Not Scoped", func(t *testing.T) {
+		var records []sharedRecord
+		stmt := db.Where("code = ?", "A").Find(&records).Statement
+		require.NoError(t, stmt.Error)
+		assert.Equal(t, `SELECT * FROM "shared_records" WHERE code = $1`, stmt.SQL.String())
+	})
+}
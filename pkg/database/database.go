@@ -45,6 +45,12 @@ func InitDB(appCfg configs.AppConfig) (*gorm.DB, error) {
 
 		log.Println("Database connection established successfully.")
 
+		// Accounting and inventory records belong to the company of the request context
+		if err = RegisterCompanyScope(DB); err != nil {
+			log.Printf("Failed to register company scope: %v\n", err)
+			return
+		}
+
 		// Optional: Configure connection pool
 		sqlDB, sqlErr := DB.DB()
 		if sqlErr != nil {
@@ -86,6 +92,9 @@ func InitTestDB(cfg configs.AppConfig) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to test database: %w", err)
 	}
+	if err := RegisterCompanyScope(testDb); err != nil {
+		return nil, fmt.Errorf("failed to register company scope for test DB: %w", err)
+	}
 
 	// Optional: Configure connection pool for test DB if needed, though often not necessary for tests
 	sqlDB, sqlErr := testDb.DB()
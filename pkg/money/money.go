@@ -0,0 +1,382 @@
+// Package money provides an exact fixed-point type for monetary amounts.
+//
+// Amounts are held as a signed integer count of 1/10^Scale units, so sums and
+// comparisons never drift the way float64 arithmetic does. Each currency has a
+// number of minor units (2 for USD, 0 for JPY, 3 for KWD, ...) that governs how
+// amounts in that currency are rounded and validated.
+//
+// Amounts entered by users are never rounded silently: CheckPrecision rejects
+// input with more decimal places than the currency allows, so a ledger line is
+// always exactly what was submitted. Round applies to amounts the system derives
+// itself, such as converted or allocated amounts, before they are posted.
+//
+// Arithmetic that would leave the range of an Amount (about ±9.22e14) panics
+// rather than wrapping around; values read from text or the database return an
+// error instead.
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Scale is the number of decimal places held internally by an Amount.
+const Scale = 4
+
+const scaleFactor int64 = 10000 // 10^Scale
+
+// DefaultMinorUnits is used for currencies that are not listed in minorUnits.
+const DefaultMinorUnits = 2
+
+// minorUnits maps ISO 4217 currency codes to their number of decimal places
+// where that differs from DefaultMinorUnits.
+var minorUnits = map[string]int{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+	"CLF": 4, "UYW": 4,
+}
+
+// Amount is an exact monetary value with Scale decimal places.
+// The zero value is 0.
+type Amount struct {
+	units int64 // value * 10^Scale
+}
+
+// Zero is the zero Amount.
+var Zero = Amount{}
+
+// ErrOverflow reports a value outside the range of an Amount.
+var ErrOverflow = errors.New("money: amount out of range")
+
+// MinorUnits returns the number of decimal places allowed for a currency code.
+func MinorUnits(currency string) int {
+	if places, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return places
+	}
+	return DefaultMinorUnits
+}
+
+// FromInt returns the Amount for a whole number of currency units.
+// It panics if the amount is out of range.
+func FromInt(i int64) Amount {
+	return mustScale(i, scaleFactor)
+}
+
+// FromMinorUnits returns the Amount for a count of the currency's minor units,
+// e.g. FromMinorUnits(1050, "USD") is 10.50. It panics if the amount is out of range.
+func FromMinorUnits(minor int64, currency string) Amount {
+	return mustScale(minor, pow10(Scale-MinorUnits(currency)))
+}
+
+// FromFloat converts a float64 to an Amount, rounding half away from zero to
+// Scale decimal places. Prefer Parse for values that originate as text.
+// It panics if f is NaN, infinite or out of range.
+func FromFloat(f float64) Amount {
+	a, err := fromFloat(f)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func fromFloat(f float64) (Amount, error) {
+	units := math.Round(f * float64(scaleFactor))
+	// float64(math.MaxInt64) rounds up to 2^63, which is itself out of range.
+	if math.IsNaN(units) || units >= float64(math.MaxInt64) || units < float64(math.MinInt64) {
+		return Zero, ErrOverflow
+	}
+	return Amount{units: int64(units)}, nil
+}
+
+func scale(n, factor int64) (Amount, error) {
+	if n > math.MaxInt64/factor || n < math.MinInt64/factor {
+		return Zero, ErrOverflow
+	}
+	return Amount{units: n * factor}, nil
+}
+
+func mustScale(n, factor int64) Amount {
+	a, err := scale(n, factor)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Parse reads a plain decimal string such as "1234.5" or "-0.07".
+// It rejects exponents, thousands separators and more than Scale decimals.
+func Parse(s string) (Amount, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Zero, fmt.Errorf("money: empty amount")
+	}
+	negative := false
+	switch str[0] {
+	case '-':
+		negative = true
+		str = str[1:]
+	case '+':
+		str = str[1:]
+	}
+	intPart, fracPart := str, ""
+	if dot := strings.IndexByte(str, '.'); dot >= 0 {
+		intPart, fracPart = str[:dot], str[dot+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return Zero, fmt.Errorf("money: invalid amount %q", s)
+	}
+	if len(fracPart) > Scale {
+		return Zero, fmt.Errorf("money: amount %q has more than %d decimal places", s, Scale)
+	}
+
+	var units int64
+	for _, ch := range intPart + fracPart + strings.Repeat("0", Scale-len(fracPart)) {
+		if ch < '0' || ch > '9' {
+			return Zero, fmt.Errorf("money: invalid amount %q", s)
+		}
+		if units > (math.MaxInt64-int64(ch-'0'))/10 {
+			return Zero, fmt.Errorf("money: amount %q is out of range", s)
+		}
+		units = units*10 + int64(ch-'0')
+	}
+	if negative {
+		units = -units
+	}
+	return Amount{units: units}, nil
+}
+
+// MustParse is like Parse but panics on error. Intended for constants and tests.
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Add returns a + b. It panics if the sum is out of range.
+func (a Amount) Add(b Amount) Amount {
+	sum := a.units + b.units
+	if (b.units > 0 && sum < a.units) || (b.units < 0 && sum > a.units) {
+		panic(ErrOverflow)
+	}
+	return Amount{units: sum}
+}
+
+// Sub returns a - b. It panics if the difference is out of range.
+func (a Amount) Sub(b Amount) Amount {
+	diff := a.units - b.units
+	if (b.units > 0 && diff > a.units) || (b.units < 0 && diff < a.units) {
+		panic(ErrOverflow)
+	}
+	return Amount{units: diff}
+}
+
+// Neg returns -a. It panics for the one negative amount without a positive counterpart.
+func (a Amount) Neg() Amount {
+	if a.units == math.MinInt64 {
+		panic(ErrOverflow)
+	}
+	return Amount{units: -a.units}
+}
+
+// Abs returns the absolute value of a.
+func (a Amount) Abs() Amount {
+	if a.units < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// Cmp returns -1, 0 or +1 depending on whether a is less than, equal to or greater than b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a.units < b.units:
+		return -1
+	case a.units > b.units:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Equal reports whether a and b are the same amount.
+func (a Amount) Equal(b Amount) bool { return a.units == b.units }
+
+// Sign returns -1, 0 or +1 according to the sign of a.
+func (a Amount) Sign() int { return a.Cmp(Zero) }
+
+// IsZero reports whether a is 0.
+func (a Amount) IsZero() bool { return a.units == 0 }
+
+// IsPositive reports whether a > 0.
+func (a Amount) IsPositive() bool { return a.units > 0 }
+
+// IsNegative reports whether a < 0.
+func (a Amount) IsNegative() bool { return a.units < 0 }
+
+// Places returns the number of significant decimal places in a, ignoring trailing zeros.
+func (a Amount) Places() int {
+	places := Scale
+	for u := a.units; places > 0 && u%10 == 0; u /= 10 {
+		places--
+	}
+	return places
+}
+
+// RoundTo rounds a half away from zero to the given number of decimal places.
+func (a Amount) RoundTo(places int) Amount {
+	if places >= Scale {
+		return a
+	}
+	if places < 0 {
+		places = 0
+	}
+	step := pow10(Scale - places)
+	rem := a.units % step
+	units := a.units - rem
+	if 2*abs64(rem) >= step {
+		if a.units < 0 {
+			units -= step
+		} else {
+			units += step
+		}
+	}
+	return Amount{units: units}
+}
+
+// Round rounds a to the minor units of the given currency.
+func (a Amount) Round(currency string) Amount {
+	return a.RoundTo(MinorUnits(currency))
+}
+
+// CheckPrecision returns an error if a has more decimal places than the currency allows.
+func (a Amount) CheckPrecision(currency string) error {
+	if allowed := MinorUnits(currency); a.Places() > allowed {
+		return fmt.Errorf("amount %s has more than %d decimal places allowed for %s", a, allowed, strings.ToUpper(currency))
+	}
+	return nil
+}
+
+// Float64 returns the nearest float64 to a. Use only for display ratios, never for sums.
+func (a Amount) Float64() float64 {
+	return float64(a.units) / float64(scaleFactor)
+}
+
+// String formats a with at least two and at most Scale decimal places, e.g. "100.50".
+func (a Amount) String() string {
+	places := a.Places()
+	if places < 2 {
+		places = 2
+	}
+	return a.StringFixed(places)
+}
+
+// StringFixed formats a with exactly the given number of decimal places, rounding if needed.
+func (a Amount) StringFixed(places int) string {
+	if places > Scale {
+		places = Scale
+	}
+	r := a.RoundTo(places)
+	sign := ""
+	u := r.units
+	if u < 0 {
+		sign = "-"
+		u = -u
+	}
+	whole := u / scaleFactor
+	if places == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	frac := (u % scaleFactor) / pow10(Scale-places)
+	return fmt.Sprintf("%s%d.%0*d", sign, whole, places, frac)
+}
+
+// MarshalJSON encodes a as a JSON number with its exact decimal digits.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, a quoted decimal string or null.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	str := strings.TrimSpace(string(data))
+	if str == "null" {
+		*a = Zero
+		return nil
+	}
+	if strings.HasPrefix(str, `"`) {
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+	}
+	parsed, err := Parse(str)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so amounts are written to NUMERIC columns exactly.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan implements sql.Scanner for NUMERIC, integer and float columns.
+func (a *Amount) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = Zero
+		return nil
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	case int64:
+		parsed, err := scale(v, scaleFactor)
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	case float64:
+		parsed, err := fromFloat(v)
+		if err != nil {
+			return err
+		}
+		*a = parsed
+		return nil
+	default:
+		return fmt.Errorf("money: cannot scan %T into Amount", src)
+	}
+}
+
+func (a *Amount) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
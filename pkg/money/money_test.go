@@ -0,0 +1,119 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "100", want: "100.00"},
+		{in: "100.5", want: "100.50"},
+		{in: "-0.07", want: "-0.07"},
+		{in: "+1.2345", want: "1.2345"},
+		{in: ".5", want: "0.50"},
+		{in: "1.23456", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "1,000", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr {
+			assert.Error(t, err, "Parse(%q)", tt.in)
+			continue
+		}
+		require.NoError(t, err, "Parse(%q)", tt.in)
+		assert.Equal(t, tt.want, got.String(), "Parse(%q)", tt.in)
+	}
+}
+
+func TestArithmeticIsExact(t *testing.T) {
+	sum := Zero
+	for i := 0; i < 10; i++ {
+		sum = sum.Add(MustParse("0.10"))
+	}
+	assert.True(t, sum.Equal(FromInt(1)))
+	assert.Equal(t, "-0.25", MustParse("0.25").Sub(MustParse("0.50")).String())
+	assert.Equal(t, 1, MustParse("2").Cmp(MustParse("1.9999")))
+}
+
+func TestOverflowPanics(t *testing.T) {
+	max := Amount{units: math.MaxInt64}
+	min := Amount{units: math.MinInt64}
+	assert.PanicsWithValue(t, ErrOverflow, func() { max.Add(MustParse("0.0001")) })
+	assert.PanicsWithValue(t, ErrOverflow, func() { min.Sub(MustParse("0.0001")) })
+	assert.PanicsWithValue(t, ErrOverflow, func() { min.Neg() })
+	assert.PanicsWithValue(t, ErrOverflow, func() { FromInt(math.MaxInt64 / 1000) })
+	assert.PanicsWithValue(t, ErrOverflow, func() { FromFloat(1e15) })
+	assert.PanicsWithValue(t, ErrOverflow, func() { FromFloat(math.NaN()) })
+	assert.NotPanics(t, func() { max.Sub(MustParse("1")).Add(MustParse("1")) })
+	assert.NotPanics(t, func() { min.Add(MustParse("1")).Sub(MustParse("1")) })
+}
+
+func TestRoundAndCheckPrecision(t *testing.T) {
+	assert.Equal(t, "10.13", MustParse("10.125").Round("USD").String())
+	assert.Equal(t, "-10.13", MustParse("-10.125").Round("USD").String())
+	assert.Equal(t, "11.00", MustParse("10.5").Round("JPY").String())
+	assert.Equal(t, "1.235", MustParse("1.2345").Round("KWD").String())
+
+	assert.NoError(t, MustParse("10.10").CheckPrecision("USD"))
+	assert.Error(t, MustParse("10.101").CheckPrecision("USD"))
+	assert.Error(t, MustParse("10.5").CheckPrecision("JPY"))
+	assert.NoError(t, MustParse("10.125").CheckPrecision("kwd"))
+}
+
+func TestFromMinorUnits(t *testing.T) {
+	assert.Equal(t, "10.50", FromMinorUnits(1050, "USD").String())
+	assert.Equal(t, "1050.00", FromMinorUnits(1050, "JPY").String())
+	assert.Equal(t, "1.050", FromMinorUnits(1050, "BHD").StringFixed(3))
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		Amount Amount `json:"amount"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 150.75}`), &v))
+	assert.Equal(t, MustParse("150.75"), v.Amount)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": "0.1"}`), &v))
+	assert.Equal(t, MustParse("0.10"), v.Amount)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": 1.23456}`), &v))
+
+	out, err := json.Marshal(struct {
+		Amount Amount `json:"amount"`
+	}{Amount: MustParse("1234.5")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 1234.50}`, string(out))
+}
+
+func TestScanAndValue(t *testing.T) {
+	var a Amount
+	require.NoError(t, a.Scan([]byte("150.7500")))
+	assert.Equal(t, MustParse("150.75"), a)
+
+	require.NoError(t, a.Scan(int64(3)))
+	assert.Equal(t, FromInt(3), a)
+
+	require.NoError(t, a.Scan(nil))
+	assert.True(t, a.IsZero())
+
+	assert.Error(t, a.Scan(true))
+	assert.Error(t, a.Scan([]byte("1000000000000000.0000")), "beyond the range of an Amount")
+	assert.Error(t, a.Scan(int64(math.MaxInt64)))
+
+	v, err := MustParse("42.5").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "42.50", v)
+}
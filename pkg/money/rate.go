@@ -0,0 +1,181 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// RateScale is the number of decimal places held by a Rate.
+const RateScale = 10
+
+const rateScaleFactor int64 = 10000000000 // 10^RateScale
+
+// Rate is an exact exchange rate: how many units of one currency one unit of another is worth.
+// The zero value is 0, which is never a valid rate to convert with.
+type Rate struct {
+	units int64 // value * 10^RateScale
+}
+
+// One is the rate between a currency and itself.
+var One = Rate{units: rateScaleFactor}
+
+// ParseRate reads a plain decimal string such as "1.0825" or "0.0067114".
+// It rejects exponents, negative values and more than RateScale decimals.
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-") {
+		return Rate{}, fmt.Errorf("money: rate %q is negative", s)
+	}
+	whole, frac, _ := strings.Cut(strings.TrimPrefix(s, "+"), ".")
+	if whole == "" && frac == "" {
+		return Rate{}, fmt.Errorf("money: invalid rate %q", s)
+	}
+	if len(frac) > RateScale {
+		return Rate{}, fmt.Errorf("money: rate %q has more than %d decimal places", s, RateScale)
+	}
+	digits := whole + frac + strings.Repeat("0", RateScale-len(frac))
+	var units int64
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Rate{}, fmt.Errorf("money: invalid rate %q", s)
+		}
+		if units > (1<<63-1-int64(c-'0'))/10 {
+			return Rate{}, fmt.Errorf("money: rate %q: %w", s, ErrOverflow)
+		}
+		units = units*10 + int64(c-'0')
+	}
+	return Rate{units: units}, nil
+}
+
+// MustParseRate is like ParseRate but panics on error. Intended for constants and tests.
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// IsPositive reports whether r > 0.
+func (r Rate) IsPositive() bool { return r.units > 0 }
+
+// Cmp compares r and o and returns -1, 0 or +1.
+func (r Rate) Cmp(o Rate) int {
+	switch {
+	case r.units < o.units:
+		return -1
+	case r.units > o.units:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether r and o are the same rate.
+func (r Rate) Equal(o Rate) bool { return r.units == o.units }
+
+// String formats r with at least four and at most RateScale decimal places, e.g. "1.0825".
+func (r Rate) String() string {
+	places := RateScale
+	for u := r.units; places > 4 && u%10 == 0; u /= 10 {
+		places--
+	}
+	frac := (r.units % rateScaleFactor) / pow10(RateScale-places)
+	return fmt.Sprintf("%d.%0*d", r.units/rateScaleFactor, places, frac)
+}
+
+// Convert returns a * r rounded half away from zero to Scale decimal places. Round the result to
+// the target currency before posting it. It panics if the result is out of range.
+func (a Amount) Convert(r Rate) Amount {
+	product := new(big.Int).Mul(big.NewInt(a.units), big.NewInt(r.units))
+	divisor := big.NewInt(rateScaleFactor)
+	quo, rem := new(big.Int).QuoRem(product, divisor, new(big.Int))
+	if new(big.Int).Abs(rem).Cmp(new(big.Int).Rsh(divisor, 1)) >= 0 {
+		quo.Add(quo, big.NewInt(int64(product.Sign())))
+	}
+	if !quo.IsInt64() {
+		panic(ErrOverflow)
+	}
+	return Amount{units: quo.Int64()}
+}
+
+// WeightedAverageRate returns the average of rates, each counted weights[i] times (e.g. the number
+// of days it applied), rounded half up to RateScale decimal places. It returns the zero Rate if the
+// weights add up to zero, and panics if the slices differ in length or a weight is negative.
+func WeightedAverageRate(rates []Rate, weights []int64) Rate {
+	if len(rates) != len(weights) {
+		panic("money: rates and weights differ in length")
+	}
+	sum, total := new(big.Int), new(big.Int)
+	for i, r := range rates {
+		if weights[i] < 0 {
+			panic("money: negative rate weight")
+		}
+		sum.Add(sum, new(big.Int).Mul(big.NewInt(r.units), big.NewInt(weights[i])))
+		total.Add(total, big.NewInt(weights[i]))
+	}
+	if total.Sign() == 0 {
+		return Rate{}
+	}
+	quo, rem := new(big.Int).QuoRem(sum, total, new(big.Int))
+	if new(big.Int).Lsh(rem, 1).Cmp(total) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return Rate{units: quo.Int64()}
+}
+
+// MarshalJSON encodes r as a JSON number with its exact decimal digits.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, a quoted decimal string or null.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	str := strings.TrimSpace(string(data))
+	if str == "null" {
+		*r = Rate{}
+		return nil
+	}
+	if strings.HasPrefix(str, `"`) {
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseRate(str)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so rates are written to NUMERIC columns exactly.
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns.
+func (r *Rate) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*r = Rate{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = fmt.Sprint(v)
+	default:
+		return fmt.Errorf("money: cannot scan %T into Rate", src)
+	}
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
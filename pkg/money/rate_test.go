@@ -0,0 +1,75 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "1", want: "1.0000"},
+		{in: "1.0825", want: "1.0825"},
+		{in: "0.0067114094", want: "0.0067114094"},
+		{in: "149.5", want: "149.5000"},
+		{in: "0.00000000001", wantErr: true},
+		{in: "-1.2", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "9999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRate(tt.in)
+		if tt.wantErr {
+			assert.Error(t, err, "ParseRate(%q)", tt.in)
+			continue
+		}
+		require.NoError(t, err, "ParseRate(%q)", tt.in)
+		assert.Equal(t, tt.want, got.String(), "ParseRate(%q)", tt.in)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	assert.Equal(t, "108.25", MustParse("100").Convert(MustParseRate("1.0825")).String())
+	// 1000 JPY at 0.0067114094 USD is 6.7114094, held at Scale places and rounded for posting.
+	converted := MustParse("1000").Convert(MustParseRate("0.0067114094"))
+	assert.Equal(t, "6.7114", converted.String())
+	assert.Equal(t, "6.71", converted.Round("USD").String())
+	assert.Equal(t, "-0.0001", MustParse("-0.0001").Convert(MustParseRate("0.5")).String(), "half rounds away from zero")
+	assert.True(t, MustParse("123.45").Convert(One).Equal(MustParse("123.45")))
+	assert.PanicsWithValue(t, ErrOverflow, func() { Amount{units: 1 << 62}.Convert(MustParseRate("4")) })
+}
+
+func TestWeightedAverageRate(t *testing.T) {
+	// 1.10 for 10 days and 1.20 for 20 days
+	avg := WeightedAverageRate([]Rate{MustParseRate("1.10"), MustParseRate("1.20")}, []int64{10, 20})
+	assert.Equal(t, "1.1666666667", avg.String(), "rounds half up")
+	assert.True(t, WeightedAverageRate([]Rate{MustParseRate("0.85")}, []int64{31}).Equal(MustParseRate("0.85")))
+	assert.False(t, WeightedAverageRate(nil, nil).IsPositive(), "no weight gives the zero rate")
+	assert.Panics(t, func() { WeightedAverageRate([]Rate{One}, nil) })
+}
+
+func TestRateCmp(t *testing.T) {
+	assert.Equal(t, -1, MustParseRate("0.2").Cmp(One))
+	assert.Equal(t, 0, MustParseRate("1.0000").Cmp(One))
+	assert.Equal(t, 1, MustParseRate("1.0000000001").Cmp(One))
+}
+
+func TestRateJSONAndScan(t *testing.T) {
+	var r Rate
+	require.NoError(t, json.Unmarshal([]byte(`"1.10"`), &r))
+	assert.Equal(t, "1.1000", r.String())
+	out, err := json.Marshal(MustParseRate("0.85"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.8500", string(out))
+
+	require.NoError(t, r.Scan([]byte("1.2345678900")))
+	assert.True(t, r.Equal(MustParseRate("1.23456789")))
+	assert.Error(t, r.Scan(1.5))
+}